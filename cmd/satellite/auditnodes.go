@@ -0,0 +1,49 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+	"storj.io/private/process"
+	"storj.io/storj/satellite/audit"
+	"storj.io/storj/satellite/satellitedb"
+)
+
+func cmdAuditNodes(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+	log := zap.L()
+
+	if auditNodesCfg.PiecesPerNode <= 0 || auditNodesCfg.PiecesPerNode > audit.MaxNodeAuditPieces {
+		return errs.New("pieces-per-node must be between 1 and %d", audit.MaxNodeAuditPieces)
+	}
+
+	nodeIDs := make([]storj.NodeID, 0, len(args))
+	for _, arg := range args {
+		nodeID, err := storj.NodeIDFromString(arg)
+		if err != nil {
+			return errs.New("invalid node ID %q: %w", arg, err)
+		}
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+
+	db, err := satellitedb.Open(ctx, log.Named("db"), auditNodesCfg.Database, satellitedb.Options{ApplicationName: "satellite-audit-nodes"})
+	if err != nil {
+		return errs.New("Error starting master database: %+v", err)
+	}
+	defer func() {
+		err = errs.Combine(err, db.Close())
+	}()
+
+	for _, nodeID := range nodeIDs {
+		if err := db.NodeAuditQueue().Enqueue(ctx, nodeID, auditNodesCfg.PiecesPerNode); err != nil {
+			return errs.New("failed to queue audit of node %s: %w", nodeID, err)
+		}
+		log.Info("queued piece-level node audit", zap.Stringer("Node ID", nodeID), zap.Int("Pieces", auditNodesCfg.PiecesPerNode))
+	}
+	return nil
+}
@@ -17,6 +17,7 @@ import (
 	"storj.io/common/uuid"
 	"storj.io/private/process"
 	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/payments/invoicing"
 	"storj.io/storj/satellite/payments/stripecoinpayments"
 	"storj.io/storj/satellite/satellitedb"
 )
@@ -68,6 +69,52 @@ func setupPayments(log *zap.Logger, db satellite.DB) (*stripecoinpayments.Servic
 		pc.BonusRate)
 }
 
+// usesInvoicing returns whether the satellite bills with the built-in invoicing instead of Stripe.
+func usesInvoicing() bool {
+	return runCfg.Payments.Provider == "invoicing"
+}
+
+func runInvoicingCmd(ctx context.Context, cmdFunc func(context.Context, *invoicing.Service) error) error {
+	// Open SatelliteDB for the Invoicing Service
+	logger := zap.L()
+	db, err := satellitedb.Open(ctx, logger.Named("db"), runCfg.Database, satellitedb.Options{ApplicationName: "satellite-billing"})
+	if err != nil {
+		return errs.New("error connecting to master database on satellite: %+v", err)
+	}
+	defer func() {
+		err = errs.Combine(err, db.Close())
+	}()
+
+	mail, err := satellite.SetupMailService(logger, runCfg.Config)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errs.Combine(err, mail.Close())
+	}()
+
+	pc := runCfg.Payments
+
+	service, err := invoicing.NewService(
+		logger.Named("payments.invoicing:service"),
+		pc.Invoicing,
+		db.Invoicing(),
+		db.Billing(),
+		db.Console(),
+		db.ProjectAccounting(),
+		db.Wallets(),
+		db.StorjscanPayments(),
+		mail,
+		pc.StorageTBPrice,
+		pc.EgressTBPrice,
+		pc.SegmentPrice)
+	if err != nil {
+		return err
+	}
+
+	return cmdFunc(ctx, service)
+}
+
 // parseBillingPeriodFromString parses provided date string and returns corresponding time.Time.
 func parseBillingPeriod(s string) (time.Time, error) {
 	values := strings.Split(s, "/")
@@ -0,0 +1,61 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/private/process"
+	"storj.io/storj/satellite/accounting/forecast"
+	"storj.io/storj/satellite/satellitedb"
+)
+
+func cmdReportsCapacityForecast(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+
+	switch reportsCapacityForecastCfg.Format {
+	case "csv", "json":
+	default:
+		return errs.New("unsupported format %q, must be csv or json", reportsCapacityForecastCfg.Format)
+	}
+	if reportsCapacityForecastCfg.Weeks <= 0 || reportsCapacityForecastCfg.Weeks > forecast.MaxWeeks {
+		return errs.New("weeks must be between 1 and %d, got %d", forecast.MaxWeeks, reportsCapacityForecastCfg.Weeks)
+	}
+
+	return runWithOutput(reportsCapacityForecastCfg.Output, func(w io.Writer) error {
+		return generateCapacityForecast(ctx, w)
+	})
+}
+
+// generateCapacityForecast writes the projected free capacity of the nodes per placement and country.
+func generateCapacityForecast(ctx context.Context, output io.Writer) (err error) {
+	log := zap.L().Named("capacity-forecast")
+
+	db, err := satellitedb.Open(ctx, log.Named("db"), reportsCapacityForecastCfg.Database, satellitedb.Options{ApplicationName: "satellite-capacity-forecast"})
+	if err != nil {
+		return errs.New("error connecting to master database on satellite: %+v", err)
+	}
+	defer func() {
+		err = errs.Combine(err, db.Close())
+	}()
+
+	forecaster := forecast.NewForecaster(log, db.OverlayCache(), db.StoragenodeAccounting())
+	report, err := forecaster.Forecast(ctx, reportsCapacityForecastCfg.Config)
+	if err != nil {
+		return err
+	}
+
+	if reportsCapacityForecastCfg.Format == "json" {
+		encoder := json.NewEncoder(output)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+	return report.WriteCSV(output)
+}
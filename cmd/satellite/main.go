@@ -28,6 +28,7 @@ import (
 	"storj.io/common/rpc"
 	"storj.io/common/storj"
 	"storj.io/common/sync2"
+	"storj.io/common/uuid"
 	"storj.io/private/cfgstruct"
 	"storj.io/private/process"
 	_ "storj.io/private/process/googleprofiler" // This attaches google cloud profiler.
@@ -37,11 +38,13 @@ import (
 	_ "storj.io/storj/private/version" // This attaches version information during release builds.
 	"storj.io/storj/satellite"
 	"storj.io/storj/satellite/accounting"
+	"storj.io/storj/satellite/accounting/forecast"
 	"storj.io/storj/satellite/accounting/live"
 	"storj.io/storj/satellite/compensation"
 	"storj.io/storj/satellite/metabase"
 	"storj.io/storj/satellite/orders"
 	"storj.io/storj/satellite/overlay"
+	"storj.io/storj/satellite/payments/invoicing"
 	"storj.io/storj/satellite/payments/stripecoinpayments"
 	"storj.io/storj/satellite/satellitedb"
 )
@@ -158,6 +161,12 @@ var (
 		Args:  cobra.MinimumNArgs(2),
 		RunE:  reportsVerifyGEReceipt,
 	}
+	reportsCapacityForecastCmd = &cobra.Command{
+		Use:   "capacity-forecast",
+		Short: "Generate a node capacity forecast",
+		Long:  "Generate a forecast of the free node capacity per placement and country for the next weeks, based on the recent growth of the data stored on the nodes.",
+		RunE:  cmdReportsCapacityForecast,
+	}
 	compensationCmd = &cobra.Command{
 		Use:   "compensation",
 		Short: "Storage Node Compensation commands",
@@ -220,6 +229,19 @@ var (
 		Long:  "Finalizes all draft stripe invoices known to satellite's stripe account.",
 		RunE:  cmdFinalizeCustomerInvoices,
 	}
+	markInvoicePaidCmd = &cobra.Command{
+		Use:   "mark-invoice-paid [invoice-id]",
+		Short: "Marks an open invoice as paid",
+		Long:  "Marks an open invoice of the built-in invoicing as paid, e.g. after receiving a bank transfer.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  cmdMarkInvoicePaid,
+	}
+	payInvoicesCmd = &cobra.Command{
+		Use:   "pay-invoices",
+		Short: "Pays open invoices from the account balances",
+		Long:  "Credits confirmed STORJ token payments and pays open invoices of the built-in invoicing from the account balances.",
+		RunE:  cmdPayInvoices,
+	}
 	stripeCustomerCmd = &cobra.Command{
 		Use:   "ensure-stripe-customer",
 		Short: "Ensures that we have a stripe customer for every user",
@@ -257,6 +279,15 @@ var (
 		Args:  cobra.ExactArgs(3),
 		RunE:  cmdFetchPieces,
 	}
+	auditNodesCmd = &cobra.Command{
+		Use:   "audit-nodes <node-id> [<node-id> ...]",
+		Short: "Queue piece-level audits of the given nodes",
+		Long: "Queue the given nodes for piece-level audits. The audit node chore of the core satellite samples segments " +
+			"with pieces on the nodes, downloads and verifies those pieces against their piece hashes, " +
+			"and records the outcomes like regular audits.",
+		Args: cobra.MinimumNArgs(1),
+		RunE: cmdAuditNodes,
+	}
 
 	runCfg   Satellite
 	setupCfg Satellite
@@ -292,6 +323,16 @@ var (
 	}
 	reportsVerifyGracefulExitReceiptCfg struct {
 	}
+	reportsCapacityForecastCfg struct {
+		Database string `help:"satellite database connection string" releaseDefault:"postgres://" devDefault:"postgres://"`
+		Output   string `help:"destination of report output" default:""`
+		Format   string `help:"format of the report output, csv or json" default:"csv"`
+		forecast.Config
+	}
+	auditNodesCfg struct {
+		Database      string `help:"satellite database connection string" releaseDefault:"postgres://" devDefault:"postgres://"`
+		PiecesPerNode int    `help:"number of pieces to verify for each node" default:"10"`
+	}
 	consistencyGECleanupCfg struct {
 		Database string `help:"satellite database connection string" releaseDefault:"postgres://" devDefault:"postgres://"`
 		Before   string `help:"select only exited nodes before this UTC date formatted like YYYY-MM. Date cannot be newer than the current time (required)"`
@@ -322,10 +363,12 @@ func init() {
 	rootCmd.AddCommand(restoreTrashCmd)
 	rootCmd.AddCommand(registerLostSegments)
 	rootCmd.AddCommand(fetchPiecesCmd)
+	rootCmd.AddCommand(auditNodesCmd)
 	reportsCmd.AddCommand(nodeUsageCmd)
 	reportsCmd.AddCommand(partnerAttributionCmd)
 	reportsCmd.AddCommand(reportsGracefulExitCmd)
 	reportsCmd.AddCommand(reportsVerifyGEReceiptCmd)
+	reportsCmd.AddCommand(reportsCapacityForecastCmd)
 	compensationCmd.AddCommand(generateInvoicesCmd)
 	compensationCmd.AddCommand(recordPeriodCmd)
 	compensationCmd.AddCommand(recordOneOffPaymentsCmd)
@@ -334,6 +377,8 @@ func init() {
 	billingCmd.AddCommand(createCustomerInvoiceItemsCmd)
 	billingCmd.AddCommand(createCustomerInvoicesCmd)
 	billingCmd.AddCommand(finalizeCustomerInvoicesCmd)
+	billingCmd.AddCommand(markInvoicePaidCmd)
+	billingCmd.AddCommand(payInvoicesCmd)
 	billingCmd.AddCommand(stripeCustomerCmd)
 	consistencyCmd.AddCommand(consistencyGECleanupCmd)
 	process.Bind(runCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
@@ -345,6 +390,7 @@ func init() {
 	process.Bind(restoreTrashCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(registerLostSegments, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(fetchPiecesCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(auditNodesCmd, &auditNodesCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(setupCmd, &setupCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir), cfgstruct.SetupMode())
 	process.Bind(qdiagCmd, &qdiagCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(nodeUsageCmd, &nodeUsageCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
@@ -353,12 +399,15 @@ func init() {
 	process.Bind(recordOneOffPaymentsCmd, &recordOneOffPaymentsCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(reportsGracefulExitCmd, &reportsGracefulExitCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(reportsVerifyGEReceiptCmd, &reportsVerifyGracefulExitReceiptCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(reportsCapacityForecastCmd, &reportsCapacityForecastCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(partnerAttributionCmd, &partnerAttribtionCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(applyFreeTierCouponsCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(prepareCustomerInvoiceRecordsCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(createCustomerInvoiceItemsCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(createCustomerInvoicesCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(finalizeCustomerInvoicesCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(markInvoicePaidCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(payInvoicesCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(stripeCustomerCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(consistencyGECleanupCmd, &consistencyGECleanupCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 
@@ -713,6 +762,11 @@ func cmdPrepareCustomerInvoiceRecords(cmd *cobra.Command, args []string) (err er
 		return errs.New("invalid period specified: %v", err)
 	}
 
+	if usesInvoicing() {
+		zap.L().Info("Invoice project records aren't needed with the built-in invoicing")
+		return nil
+	}
+
 	return runBillingCmd(ctx, func(ctx context.Context, payments *stripecoinpayments.Service, _ satellite.DB) error {
 		return payments.PrepareInvoiceProjectRecords(ctx, period)
 	})
@@ -726,6 +780,11 @@ func cmdCreateCustomerInvoiceItems(cmd *cobra.Command, args []string) (err error
 		return errs.New("invalid period specified: %v", err)
 	}
 
+	if usesInvoicing() {
+		zap.L().Info("Invoice items aren't needed with the built-in invoicing")
+		return nil
+	}
+
 	return runBillingCmd(ctx, func(ctx context.Context, payments *stripecoinpayments.Service, _ satellite.DB) error {
 		return payments.InvoiceApplyProjectRecords(ctx, period)
 	})
@@ -739,6 +798,12 @@ func cmdCreateCustomerInvoices(cmd *cobra.Command, args []string) (err error) {
 		return errs.New("invalid period specified: %v", err)
 	}
 
+	if usesInvoicing() {
+		return runInvoicingCmd(ctx, func(ctx context.Context, service *invoicing.Service) error {
+			return service.CreateInvoices(ctx, period)
+		})
+	}
+
 	return runBillingCmd(ctx, func(ctx context.Context, payments *stripecoinpayments.Service, _ satellite.DB) error {
 		return payments.CreateInvoices(ctx, period)
 	})
@@ -747,11 +812,49 @@ func cmdCreateCustomerInvoices(cmd *cobra.Command, args []string) (err error) {
 func cmdFinalizeCustomerInvoices(cmd *cobra.Command, args []string) (err error) {
 	ctx, _ := process.Ctx(cmd)
 
+	if usesInvoicing() {
+		return runInvoicingCmd(ctx, func(ctx context.Context, service *invoicing.Service) error {
+			return service.FinalizeInvoices(ctx)
+		})
+	}
+
 	return runBillingCmd(ctx, func(ctx context.Context, payments *stripecoinpayments.Service, _ satellite.DB) error {
 		return payments.FinalizeInvoices(ctx)
 	})
 }
 
+func cmdMarkInvoicePaid(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+
+	if !usesInvoicing() {
+		return errs.New("invoices can be marked as paid only with the built-in invoicing")
+	}
+
+	invoiceID, err := uuid.FromString(args[0])
+	if err != nil {
+		return errs.New("invalid invoice ID specified: %v", err)
+	}
+
+	return runInvoicingCmd(ctx, func(ctx context.Context, service *invoicing.Service) error {
+		return service.MarkPaid(ctx, invoiceID)
+	})
+}
+
+func cmdPayInvoices(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+
+	if !usesInvoicing() {
+		return errs.New("invoices can be paid from balances only with the built-in invoicing")
+	}
+
+	return runInvoicingCmd(ctx, func(ctx context.Context, service *invoicing.Service) error {
+		if err := service.CreditTokenPayments(ctx); err != nil {
+			return err
+		}
+		return service.PayInvoices(ctx)
+	})
+}
+
 func cmdStripeCustomer(cmd *cobra.Command, args []string) (err error) {
 	ctx, _ := process.Ctx(cmd)
 
@@ -0,0 +1,182 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/private/process"
+	"storj.io/storj/storagenode"
+	"storj.io/storj/storagenode/apikeys"
+	"storj.io/storj/storagenode/storagenodedb"
+)
+
+var (
+	apiKeyCmd = &cobra.Command{
+		Use:         "apikey",
+		Short:       "Manage the api keys of the multinode dashboard",
+		Annotations: map[string]string{"type": "helper"},
+	}
+	apiKeyIssueCmd = &cobra.Command{
+		Use:   "issue",
+		Short: "Issue a named api key for the multinode dashboard",
+		Long: `Issue a named api key for the multinode dashboard.
+
+Keys with the read-only scope can read the node's stats, storage, bandwidth
+and payouts, but not the operator's email and wallet. Keys with the admin
+scope can use every endpoint.
+
+WARNING: The output includes the api secret of the storagenode.
+`,
+		RunE:        cmdAPIKeyIssue,
+		Annotations: map[string]string{"type": "helper"},
+		Args:        cobra.ExactArgs(0),
+	}
+	apiKeyListCmd = &cobra.Command{
+		Use:         "list",
+		Short:       "List the api keys without their secrets",
+		RunE:        cmdAPIKeyList,
+		Annotations: map[string]string{"type": "helper"},
+		Args:        cobra.ExactArgs(0),
+	}
+	apiKeyRevokeCmd = &cobra.Command{
+		Use:         "revoke <name|id>",
+		Short:       "Revoke the api key with the given name or id",
+		RunE:        cmdAPIKeyRevoke,
+		Annotations: map[string]string{"type": "helper"},
+		Args:        cobra.ExactArgs(1),
+	}
+
+	apiKeyCfg struct {
+		storagenode.Config
+
+		Name       string        `help:"name of the api key, describing who it is issued to" default:""`
+		Scope      string        `help:"scope of the api key (read-only or admin)" default:"read-only"`
+		ExpiresIn  time.Duration `help:"duration after which the api key expires (default: never)" default:"0s"`
+		Expiration string        `help:"time the api key expires in RFC3339 format, instead of --expires-in" default:""`
+	}
+)
+
+// openAPIKeysService opens the storage node databases and returns the api keys
+// service on top of them.
+func openAPIKeysService(ctx context.Context) (_ *apikeys.Service, closeDB func() error, err error) {
+	db, err := storagenodedb.OpenExisting(ctx, zap.L().Named("db"), apiKeyCfg.DatabaseConfig())
+	if err != nil {
+		return nil, nil, errs.New("Error opening the databases of the storage node: %v", err)
+	}
+	return apikeys.NewService(db.APIKeys()), db.Close, nil
+}
+
+// apiKeyExpirationFromFlags returns the expiration given by --expires-in and
+// --expiration, or nil when the key doesn't expire.
+func apiKeyExpirationFromFlags(now time.Time) (*time.Time, error) {
+	switch {
+	case apiKeyCfg.Expiration != "" && apiKeyCfg.ExpiresIn != 0:
+		return nil, errs.New("--expiration and --expires-in are mutually exclusive")
+	case apiKeyCfg.Expiration != "":
+		expiresAt, err := time.Parse(time.RFC3339, apiKeyCfg.Expiration)
+		if err != nil {
+			return nil, errs.New("invalid --expiration: %v", err)
+		}
+		return &expiresAt, nil
+	case apiKeyCfg.ExpiresIn < 0:
+		return nil, errs.New("--expires-in must be positive")
+	case apiKeyCfg.ExpiresIn > 0:
+		expiresAt := now.Add(apiKeyCfg.ExpiresIn)
+		return &expiresAt, nil
+	default:
+		return nil, nil
+	}
+}
+
+func cmdAPIKeyIssue(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+
+	scope, err := apikeys.ParseScope(apiKeyCfg.Scope)
+	if err != nil {
+		return err
+	}
+	expiresAt, err := apiKeyExpirationFromFlags(time.Now())
+	if err != nil {
+		return err
+	}
+
+	service, closeDB, err := openAPIKeysService(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, closeDB()) }()
+
+	apiKey, err := service.IssueWithOptions(ctx, apikeys.IssueOptions{
+		Name:      apiKeyCfg.Name,
+		Scope:     scope,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return errs.New("Error while trying to issue new api key: %v", err)
+	}
+
+	fmt.Println(apiKey.Secret.String())
+	return nil
+}
+
+func cmdAPIKeyList(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+
+	service, closeDB, err := openAPIKeysService(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, closeDB()) }()
+
+	keys, err := service.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "ID\tNAME\tSCOPE\tCREATED\tEXPIRES")
+	for _, key := range keys {
+		name := key.Name
+		if name == "" {
+			name = "-"
+		}
+		expires := "never"
+		if key.ExpiresAt != nil {
+			expires = key.ExpiresAt.Format(time.RFC3339)
+			if key.Expired(now) {
+				expires += " (expired)"
+			}
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", key.ID(), name, key.Scope, key.CreatedAt.Format(time.RFC3339), expires)
+	}
+	return w.Flush()
+}
+
+func cmdAPIKeyRevoke(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+
+	service, closeDB, err := openAPIKeysService(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, closeDB()) }()
+
+	apiKey, err := service.Revoke(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Revoked api key %s.\n", apiKey.ID())
+	return nil
+}
@@ -0,0 +1,91 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/memory"
+	"storj.io/private/process"
+	"storj.io/storj/storagenode"
+	"storj.io/storj/storagenode/benchmark"
+	"storj.io/storj/storagenode/storagenodedb"
+)
+
+var (
+	benchmarkCmd = &cobra.Command{
+		Use:   "benchmark",
+		Short: "Measure the performance of the storage directory",
+		Long: `Measure the performance of the storage directory.
+
+The benchmark writes, commits and reads pieces the same way uploads and
+downloads do, compares the measurements with the recommended values (see the
+benchmark.* flags) and deletes the pieces afterwards. The pieces are written
+to a separate namespace of the blob store the node is configured with, so
+the pieces of the node aren't touched. The result is shown in the dashboard
+too. It can run while the node is running, but the measurements are affected
+by the traffic of the node.
+`,
+		RunE:        cmdBenchmark,
+		Annotations: map[string]string{"type": "helper"},
+		Args:        cobra.ExactArgs(0),
+	}
+
+	benchmarkCfg storagenode.Config
+)
+
+func cmdBenchmark(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+
+	db, err := storagenodedb.OpenExisting(ctx, zap.L().Named("db"), benchmarkCfg.DatabaseConfig())
+	if err != nil {
+		return errs.New("Error opening the databases of the storage node: %v", err)
+	}
+	defer func() { err = errs.Combine(err, db.Close()) }()
+
+	service := benchmark.NewService(zap.L().Named("benchmark"), db.Pieces(), benchmarkCfg.Pieces, benchmarkCfg.Benchmark, benchmarkCfg.BenchmarkResultPath())
+
+	fmt.Printf("Writing and reading %d pieces of %s...\n\n", benchmarkCfg.Benchmark.Pieces, benchmarkCfg.Benchmark.PieceSize)
+	result, err := service.Run(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := printBenchmarkResult(os.Stdout, result); err != nil {
+		return err
+	}
+	if !result.Passed() {
+		return errs.New("the storage directory is slower than recommended")
+	}
+	return nil
+}
+
+// printBenchmarkResult prints the measurements and the warnings of the result.
+func printBenchmarkResult(out io.Writer, result benchmark.Result) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "\tAVERAGE\tP95\tMAX\tTHROUGHPUT")
+	_, _ = fmt.Fprintf(w, "Write\t%v\t%v\t%v\t%s/s\n", result.WriteLatency.Average, result.WriteLatency.P95, result.WriteLatency.Max, memory.Size(result.WriteThroughput))
+	_, _ = fmt.Fprintf(w, "Commit (fsync)\t%v\t%v\t%v\t\n", result.CommitLatency.Average, result.CommitLatency.P95, result.CommitLatency.Max)
+	_, _ = fmt.Fprintf(w, "Read\t%v\t%v\t%v\t%s/s\n", result.ReadLatency.Average, result.ReadLatency.P95, result.ReadLatency.Max, memory.Size(result.ReadThroughput))
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintln(out)
+	if result.Passed() {
+		_, _ = fmt.Fprintln(out, "The storage directory meets the recommendations.")
+		return nil
+	}
+	for _, warning := range result.Warnings {
+		_, _ = fmt.Fprintf(out, "WARNING: %s\n", warning)
+	}
+	return nil
+}
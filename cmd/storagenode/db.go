@@ -0,0 +1,294 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/private/process"
+	"storj.io/storj/storagenode"
+	"storj.io/storj/storagenode/storagenodedb"
+)
+
+var (
+	dbCmd = &cobra.Command{
+		Use:         "db",
+		Short:       "Check and repair the storage node databases",
+		Annotations: map[string]string{"type": "helper"},
+	}
+	dbCheckCmd = &cobra.Command{
+		Use:   "check [database...]",
+		Short: "Check the integrity and the schema of the databases",
+		Long: `Check the integrity and the schema of the databases.
+
+Without arguments all databases are checked. The storage node must be stopped.
+`,
+		RunE:        cmdDBCheck,
+		Annotations: map[string]string{"type": "helper"},
+	}
+	dbBackupCmd = &cobra.Command{
+		Use:         "backup",
+		Short:       "Copy all database files into the backup directory",
+		RunE:        cmdDBBackup,
+		Annotations: map[string]string{"type": "helper"},
+		Args:        cobra.ExactArgs(0),
+	}
+	dbRepairCmd = &cobra.Command{
+		Use:   "repair [database...]",
+		Short: "Rebuild damaged databases, keeping every row that can be read",
+		Long: `Rebuild damaged databases, keeping every row that can be read.
+
+The database is copied into the backup directory, recreated with the latest
+schema and the rows that can still be read are copied back. Without arguments
+all databases failing the check are repaired. The storage node must be stopped.
+`,
+		RunE:        cmdDBRepair,
+		Annotations: map[string]string{"type": "helper"},
+	}
+	dbRecreateCmd = &cobra.Command{
+		Use:   "recreate database...",
+		Short: "Replace non-critical databases with empty ones",
+		Long: `Replace non-critical databases with empty ones.
+
+The database is copied into the backup directory and recreated from scratch,
+losing all its rows. Databases that can't be rebuilt from the satellites or
+the pieces are refused; use 'repair' for those. The storage node must be stopped.
+`,
+		RunE:        cmdDBRecreate,
+		Annotations: map[string]string{"type": "helper"},
+		Args:        cobra.MinimumNArgs(1),
+	}
+
+	dbCfg struct {
+		storagenode.Config
+
+		BackupDir string `help:"directory for the backups of the databases (default: a new directory next to the databases)" default:""`
+	}
+)
+
+// openDatabasesForRepair opens the storage node databases, including the
+// ones that can't be opened anymore.
+func openDatabasesForRepair(ctx context.Context) (*storagenodedb.DB, error) {
+	db, err := storagenodedb.OpenForRepair(ctx, zap.L().Named("db"), dbCfg.DatabaseConfig())
+	if err != nil {
+		return nil, errs.New("Error opening the databases of the storage node: %v", err)
+	}
+	return db, nil
+}
+
+// dbBackupDir returns the configured backup directory or a new one next to
+// the databases.
+func dbBackupDir() string {
+	if dbCfg.BackupDir != "" {
+		return dbCfg.BackupDir
+	}
+	return filepath.Join(filepath.Dir(dbCfg.DatabaseConfig().Info2),
+		"db-backup-"+time.Now().UTC().Format("20060102T150405Z"))
+}
+
+// databaseNames returns the databases given as arguments, or all databases.
+func databaseNames(args []string) []string {
+	if len(args) > 0 {
+		return args
+	}
+	return storagenodedb.DatabaseNames()
+}
+
+func cmdDBCheck(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+
+	db, err := openDatabasesForRepair(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, db.Close()) }()
+
+	reports, err := checkDatabases(ctx, db, databaseNames(args))
+	if err != nil {
+		return err
+	}
+	printHealthReports(reports)
+
+	unhealthy := 0
+	for _, report := range reports {
+		if !report.Healthy() {
+			unhealthy++
+		}
+	}
+	if unhealthy > 0 {
+		return errs.New("%d of %d databases failed the check, see 'storagenode db repair --help'", unhealthy, len(reports))
+	}
+	return nil
+}
+
+func checkDatabases(ctx context.Context, db *storagenodedb.DB, dbNames []string) (reports []storagenodedb.HealthReport, err error) {
+	for _, dbName := range dbNames {
+		report, err := db.CheckDatabase(ctx, dbName)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+func printHealthReports(reports []storagenodedb.HealthReport) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Database\tCritical\tStatus\t")
+	for _, report := range reports {
+		status := "OK"
+		switch {
+		case report.Missing:
+			status = "MISSING"
+		case !report.Healthy():
+			status = "DAMAGED"
+		}
+		fmt.Fprintf(w, "%s\t%t\t%s\t\n", report.Database, report.Critical, status)
+	}
+	_ = w.Flush()
+
+	for _, report := range reports {
+		for _, problem := range report.Problems {
+			fmt.Printf("\n%s: %s\n", report.Database, problem)
+		}
+	}
+}
+
+func cmdDBBackup(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+
+	db, err := openDatabasesForRepair(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, db.Close()) }()
+
+	backupDir := dbBackupDir()
+	if err := db.BackupDatabases(ctx, backupDir); err != nil {
+		return err
+	}
+
+	fmt.Printf("Backed up the databases to %s\n", backupDir)
+	return nil
+}
+
+func cmdDBRepair(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+
+	db, err := openDatabasesForRepair(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, db.Close()) }()
+
+	dbNames := args
+	if len(dbNames) == 0 {
+		reports, err := checkDatabases(ctx, db, storagenodedb.DatabaseNames())
+		if err != nil {
+			return err
+		}
+		for _, report := range reports {
+			if !report.Healthy() {
+				dbNames = append(dbNames, report.Database)
+			}
+		}
+		if len(dbNames) == 0 {
+			fmt.Println("All databases passed the check, nothing to repair.")
+			return nil
+		}
+	}
+
+	backupDir := dbBackupDir()
+	for _, dbName := range dbNames {
+		report, err := db.RepairDatabase(ctx, dbName, backupDir)
+		if err != nil {
+			return errs.New("Repairing %s failed, the backup is in %s: %v", dbName, backupDir, err)
+		}
+		printRepairReport(report)
+	}
+
+	// verify the rebuilt databases.
+	reports, err := checkDatabases(ctx, db, dbNames)
+	if err != nil {
+		return err
+	}
+	for _, report := range reports {
+		if !report.Healthy() {
+			printHealthReports(reports)
+			return errs.New("%s still fails the check after the repair", report.Database)
+		}
+	}
+	return nil
+}
+
+func cmdDBRecreate(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+
+	for _, dbName := range args {
+		if reason, critical := storagenodedb.CriticalDatabase(dbName); critical {
+			return errs.New("%s can't be recreated, because %s; use 'storagenode db repair %s' instead", dbName, reason, dbName)
+		}
+	}
+
+	db, err := openDatabasesForRepair(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, db.Close()) }()
+
+	backupDir := dbBackupDir()
+	for _, dbName := range args {
+		report, err := db.RecreateDatabase(ctx, dbName, backupDir)
+		if err != nil {
+			return errs.New("Recreating %s failed, the backup is in %s: %v", dbName, backupDir, err)
+		}
+		printRepairReport(report)
+	}
+	return nil
+}
+
+func printRepairReport(report storagenodedb.RepairReport) {
+	fmt.Printf("\n%s (backup: %s)\n", report.Database, report.BackupPath)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Table\tRecovered\tLost\t")
+	for _, table := range report.Tables {
+		fmt.Fprintf(w, "%s\t%d\t%s\t\n", table.Table, table.Recovered, formatLost(table.Lost))
+	}
+	_ = w.Flush()
+
+	for _, table := range report.Tables {
+		if len(table.Errors) > 0 {
+			fmt.Printf("%s: %s\n", table.Table, strings.Join(table.Errors, "; "))
+		}
+	}
+
+	lost, known := report.Lost()
+	switch {
+	case !known:
+		fmt.Printf("At least %d rows were lost; some tables couldn't be counted.\n", lost)
+	case lost > 0:
+		fmt.Printf("%d rows were lost.\n", lost)
+	default:
+		fmt.Println("No rows were lost.")
+	}
+}
+
+func formatLost(lost int64) string {
+	if lost < 0 {
+		return "unknown"
+	}
+	return strconv.FormatInt(lost, 10)
+}
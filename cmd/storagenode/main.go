@@ -4,6 +4,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -158,6 +159,27 @@ func init() {
 	rootCmd.AddCommand(gracefulExitStatusCmd)
 	rootCmd.AddCommand(issueAPITokenCmd)
 	rootCmd.AddCommand(nodeInfoCmd)
+	rootCmd.AddCommand(migratePiecesCmd)
+	rootCmd.AddCommand(migrateStorageCmd)
+	rootCmd.AddCommand(dbCmd)
+	dbCmd.AddCommand(dbCheckCmd)
+	dbCmd.AddCommand(dbBackupCmd)
+	dbCmd.AddCommand(dbRepairCmd)
+	dbCmd.AddCommand(dbRecreateCmd)
+	rootCmd.AddCommand(maintenanceCmd)
+	maintenanceCmd.AddCommand(maintenancePlanCmd)
+	maintenanceCmd.AddCommand(maintenanceCancelCmd)
+	maintenanceCmd.AddCommand(maintenanceStatusCmd)
+	rootCmd.AddCommand(piecesCmd)
+	piecesCmd.AddCommand(piecesListCmd)
+	piecesCmd.AddCommand(piecesHeaderCmd)
+	piecesCmd.AddCommand(piecesVerifyCmd)
+	piecesCmd.AddCommand(piecesStatusCmd)
+	rootCmd.AddCommand(apiKeyCmd)
+	apiKeyCmd.AddCommand(apiKeyIssueCmd)
+	apiKeyCmd.AddCommand(apiKeyListCmd)
+	apiKeyCmd.AddCommand(apiKeyRevokeCmd)
+	rootCmd.AddCommand(benchmarkCmd)
 	process.Bind(runCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(setupCmd, &setupCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir), cfgstruct.SetupMode())
 	process.Bind(configCmd, &setupCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir), cfgstruct.SetupMode())
@@ -167,9 +189,32 @@ func init() {
 	process.Bind(gracefulExitStatusCmd, &diagCfg, defaults, cfgstruct.ConfDir(defaultDiagDir))
 	process.Bind(issueAPITokenCmd, &diagCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(nodeInfoCmd, &nodeInfoCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(migrateStorageCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(migratePiecesCmd, &migratePiecesCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(dbCheckCmd, &dbCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(dbBackupCmd, &dbCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(dbRepairCmd, &dbCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(dbRecreateCmd, &dbCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(maintenancePlanCmd, &maintenanceCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(maintenanceCancelCmd, &maintenanceCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(maintenanceStatusCmd, &maintenanceCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(piecesListCmd, &piecesCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(piecesHeaderCmd, &piecesCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(piecesVerifyCmd, &piecesCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(piecesStatusCmd, &piecesCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(apiKeyIssueCmd, &apiKeyCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(apiKeyListCmd, &apiKeyCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(apiKeyRevokeCmd, &apiKeyCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(benchmarkCmd, &benchmarkCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 }
 
 func cmdRun(cmd *cobra.Command, args []string) (err error) {
+	return runStorageNode(cmd, runCfg.DatabaseConfig(), nil)
+}
+
+// runStorageNode runs the storage node configured by runCfg with the given
+// databases. prepare, if set, is called with the peer before it's started.
+func runStorageNode(cmd *cobra.Command, dbConfig storagenodedb.Config, prepare func(ctx context.Context, peer *storagenode.Peer) error) (err error) {
 	// inert constructors only ====
 
 	ctx, _ := process.Ctx(cmd)
@@ -190,7 +235,7 @@ func cmdRun(cmd *cobra.Command, args []string) (err error) {
 		return err
 	}
 
-	db, err := storagenodedb.OpenExisting(ctx, log.Named("db"), runCfg.DatabaseConfig())
+	db, err := storagenodedb.OpenExisting(ctx, log.Named("db"), dbConfig)
 	if err != nil {
 		return errs.New("Error starting master database on storagenode: %+v", err)
 	}
@@ -248,6 +293,12 @@ func cmdRun(cmd *cobra.Command, args []string) (err error) {
 		log.Error("Failed to initialize CacheService.", zap.Error(err))
 	}
 
+	if prepare != nil {
+		if err := prepare(ctx, peer); err != nil {
+			return err
+		}
+	}
+
 	runError := peer.Run(ctx)
 	closeError := peer.Close()
 
@@ -0,0 +1,167 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/private/process"
+	"storj.io/storj/private/nodemaintenance"
+	"storj.io/storj/storagenode"
+	"storj.io/storj/storagenode/maintenance"
+	"storj.io/storj/storagenode/storagenodedb"
+)
+
+var (
+	maintenanceCmd = &cobra.Command{
+		Use:         "maintenance",
+		Short:       "Plan a maintenance window of the storage node",
+		Annotations: map[string]string{"type": "helper"},
+	}
+	maintenancePlanCmd = &cobra.Command{
+		Use:   "plan",
+		Short: "Plan a maintenance window, replacing the planned one",
+		Long: `Plan a maintenance window, replacing the planned one.
+
+During the window the satellites don't select the node for uploads and audits
+and don't count it being offline against it, within a yearly allowance. The
+window is reported to the satellites at the next check-in (see contact.interval),
+which may shorten or reject it.
+`,
+		RunE:        cmdMaintenancePlan,
+		Annotations: map[string]string{"type": "helper"},
+		Args:        cobra.ExactArgs(0),
+	}
+	maintenanceCancelCmd = &cobra.Command{
+		Use:         "cancel",
+		Short:       "Cancel the planned maintenance window or end the one in progress",
+		RunE:        cmdMaintenanceCancel,
+		Annotations: map[string]string{"type": "helper"},
+		Args:        cobra.ExactArgs(0),
+	}
+	maintenanceStatusCmd = &cobra.Command{
+		Use:         "status",
+		Short:       "Print the planned maintenance window",
+		RunE:        cmdMaintenanceStatus,
+		Annotations: map[string]string{"type": "helper"},
+		Args:        cobra.ExactArgs(0),
+	}
+
+	maintenanceCfg struct {
+		storagenode.Config
+
+		Start    string        `help:"start of the maintenance window in RFC3339 format (default: now)" default:""`
+		Duration time.Duration `help:"length of the maintenance window" default:"0s"`
+		End      string        `help:"end of the maintenance window in RFC3339 format, instead of --duration" default:""`
+	}
+)
+
+// openMaintenanceService opens the storage node databases and returns the
+// maintenance service on top of them.
+func openMaintenanceService(ctx context.Context) (_ *maintenance.Service, closeDB func() error, err error) {
+	db, err := storagenodedb.OpenExisting(ctx, zap.L().Named("db"), maintenanceCfg.DatabaseConfig())
+	if err != nil {
+		return nil, nil, errs.New("Error opening the databases of the storage node: %v", err)
+	}
+	return maintenance.NewService(zap.L().Named("maintenance"), db.Maintenance()), db.Close, nil
+}
+
+// maintenanceWindowFromFlags returns the window given by --start, --duration
+// and --end.
+func maintenanceWindowFromFlags(now time.Time) (window nodemaintenance.Window, err error) {
+	window.Start = now
+	if maintenanceCfg.Start != "" {
+		window.Start, err = time.Parse(time.RFC3339, maintenanceCfg.Start)
+		if err != nil {
+			return nodemaintenance.Window{}, errs.New("invalid --start: %v", err)
+		}
+	}
+
+	switch {
+	case maintenanceCfg.End != "" && maintenanceCfg.Duration != 0:
+		return nodemaintenance.Window{}, errs.New("--end and --duration are mutually exclusive")
+	case maintenanceCfg.End != "":
+		window.End, err = time.Parse(time.RFC3339, maintenanceCfg.End)
+		if err != nil {
+			return nodemaintenance.Window{}, errs.New("invalid --end: %v", err)
+		}
+	case maintenanceCfg.Duration > 0:
+		window.End = window.Start.Add(maintenanceCfg.Duration)
+	default:
+		return nodemaintenance.Window{}, errs.New("either --end or --duration is required")
+	}
+
+	return window, nil
+}
+
+func cmdMaintenancePlan(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+
+	window, err := maintenanceWindowFromFlags(time.Now())
+	if err != nil {
+		return err
+	}
+
+	service, closeDB, err := openMaintenanceService(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, closeDB()) }()
+
+	if err := service.Plan(ctx, window); err != nil {
+		return err
+	}
+
+	fmt.Printf("Planned the maintenance window from %s to %s.\n",
+		window.Start.Format(time.RFC3339), window.End.Format(time.RFC3339))
+	fmt.Printf("It's reported to the satellites at the next check-in, within %s.\n", maintenanceCfg.Contact.Interval)
+	return nil
+}
+
+func cmdMaintenanceCancel(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+
+	service, closeDB, err := openMaintenanceService(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, closeDB()) }()
+
+	if err := service.Cancel(ctx); err != nil {
+		return err
+	}
+
+	fmt.Printf("Canceled the maintenance window. It's reported to the satellites at the next check-in, within %s.\n", maintenanceCfg.Contact.Interval)
+	return nil
+}
+
+func cmdMaintenanceStatus(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+
+	service, closeDB, err := openMaintenanceService(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, closeDB()) }()
+
+	window, err := service.Window(ctx)
+	if err != nil {
+		return err
+	}
+	if window.IsZero() {
+		fmt.Println("No maintenance window is planned.")
+		return nil
+	}
+
+	fmt.Printf("Maintenance window from %s to %s (%s).\n",
+		window.Start.Format(time.RFC3339), window.End.Format(time.RFC3339), window.Duration())
+	fmt.Println("The answers of the satellites are shown on the dashboard.")
+	return nil
+}
@@ -0,0 +1,71 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/memory"
+	"storj.io/private/process"
+	"storj.io/storj/storage/filestore"
+	"storj.io/storj/storage/packstore"
+	"storj.io/storj/storagenode"
+)
+
+var (
+	migratePiecesCmd = &cobra.Command{
+		Use:   "migrate-pieces",
+		Short: "Move pieces stored one file per piece into packed log files",
+		Long: `Move pieces stored one file per piece into packed log files.
+
+The storage node must be stopped while the pieces are migrated. An interrupted
+migration can be started again and continues where it stopped. Once it finishes,
+set 'packstore.enabled: true' in the config before starting the node.
+`,
+		RunE:        cmdMigratePieces,
+		Annotations: map[string]string{"type": "helper"},
+		Args:        cobra.ExactArgs(0),
+	}
+
+	migratePiecesCfg struct {
+		storagenode.Config
+
+		DeleteSource bool `default:"true" help:"delete every piece file once it's copied into the packed log files"`
+	}
+)
+
+func cmdMigratePieces(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+	log := zap.L()
+
+	dir, err := filestore.OpenDir(log.Named("filestore"), migratePiecesCfg.Storage.Path)
+	if err != nil {
+		return errs.New("unable to open the pieces directory: %v", err)
+	}
+
+	from := filestore.New(log.Named("filestore"), dir, migratePiecesCfg.Filestore)
+	defer func() { err = errs.Combine(err, from.Close()) }()
+
+	to, err := packstore.New(log.Named("packstore"), dir, migratePiecesCfg.Packstore)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, to.Close()) }()
+
+	stats, err := packstore.Migrate(ctx, log, from, to, migratePiecesCfg.DeleteSource)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Migrated %d pieces (%s), skipped %d already migrated pieces.\n",
+		stats.Blobs, memory.Size(stats.Bytes).Base10String(), stats.Skipped)
+	if !migratePiecesCfg.Packstore.Enabled {
+		fmt.Println("Set 'packstore.enabled: true' in the config before starting the node.")
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+
+	"storj.io/common/fpath"
+	"storj.io/common/memory"
+	"storj.io/storj/storagenode"
+)
+
+var (
+	migrateStorageCmd = &cobra.Command{
+		Use:   "migrate-storage --to <path>",
+		Short: "Run the storagenode while moving its pieces to a new storage directory",
+		Long: `Run the storagenode while moving its pieces to a new storage directory.
+
+The node runs as usual, while the pieces are copied to the new directory in the
+background. New pieces are written to the new directory and pieces are read from
+both directories until the copy is verified. Then 'storage.path' is switched to
+the new directory in the config and the pieces in the old directory aren't used
+anymore; they can be deleted once the node was restarted. The databases stay in
+the old directory, so 'storage2.database-dir' is set to it, unless it's already
+configured.
+
+An interrupted migration can be started again and continues where it stopped.
+`,
+		RunE: cmdMigrateStorage,
+		Args: cobra.ExactArgs(0),
+	}
+
+	migrateStorageTo string
+)
+
+func init() {
+	migrateStorageCmd.Flags().StringVar(&migrateStorageTo, "to", "", "the new storage directory")
+}
+
+func cmdMigrateStorage(cmd *cobra.Command, args []string) (err error) {
+	if migrateStorageTo == "" {
+		return errs.New("the new storage directory must be set with --to")
+	}
+	if runCfg.Packstore.Enabled {
+		return errs.New("pieces stored in packed log files can't be migrated to a new storage directory")
+	}
+
+	from, err := filepath.Abs(runCfg.Storage.Path)
+	if err != nil {
+		return err
+	}
+	to, err := filepath.Abs(migrateStorageTo)
+	if err != nil {
+		return err
+	}
+	if from == to {
+		return errs.New("the node already stores its pieces in %s", to)
+	}
+
+	dbConfig := runCfg.DatabaseConfig()
+	dbConfig.MigrateTo = to
+
+	return runStorageNode(cmd, dbConfig, func(ctx context.Context, peer *storagenode.Peer) error {
+		if err := checkMigrationSpace(ctx, zap.L(), peer, to); err != nil {
+			return err
+		}
+
+		peer.Storage2.Migration.OnSwitch(func(ctx context.Context) error {
+			return switchStoragePath(cmd, zap.L(), from, to)
+		})
+		return nil
+	})
+}
+
+// checkMigrationSpace checks whether the new storage directory has enough
+// free space for the pieces and the trash. The check is skipped when an
+// interrupted migration already copied pieces to the new storage directory.
+func checkMigrationSpace(ctx context.Context, log *zap.Logger, peer *storagenode.Peer, to string) error {
+	piecesTotal, _, err := peer.DB.PieceSpaceUsedDB().GetPieceTotals(ctx)
+	if err != nil {
+		return errs.New("Error retrieving the space used by pieces: %+v", err)
+	}
+	trashTotal, err := peer.DB.PieceSpaceUsedDB().GetTrashTotal(ctx)
+	if err != nil {
+		return errs.New("Error retrieving the space used by the trash: %+v", err)
+	}
+	free, err := peer.DB.Pieces().FreeSpace(ctx)
+	if err != nil {
+		return errs.New("Error retrieving the free space of %s: %+v", to, err)
+	}
+
+	required := piecesTotal + trashTotal
+	if free >= required {
+		return nil
+	}
+
+	if entries, err := ioutil.ReadDir(filepath.Join(to, "blobs")); err == nil && len(entries) > 0 {
+		log.Warn("The new storage directory may not have enough free space left for the remaining pieces.",
+			zap.Stringer("Free", memory.Size(free)),
+			zap.Stringer("Used", memory.Size(required)))
+		return nil
+	}
+	return errs.New("%s has %s free, but the pieces and the trash use %s", to, memory.Size(free), memory.Size(required))
+}
+
+// switchStoragePath points the config to the new storage directory. The
+// databases stay in the old storage directory.
+func switchStoragePath(cmd *cobra.Command, log *zap.Logger, from, to string) error {
+	values := map[string]string{"storage.path": to}
+	if runCfg.Config.Storage2.DatabaseDir == "" {
+		values["storage2.database-dir"] = from
+	}
+
+	changed := false
+	for key := range values {
+		if flag := cmd.Flags().Lookup(key); flag != nil && flag.Changed {
+			changed = true
+		}
+	}
+	if changed {
+		// the command line overrides the config file, so it's up to the
+		// operator to change it.
+		fields := []zap.Field{zap.String("storage.path", to)}
+		if dbdir, ok := values["storage2.database-dir"]; ok {
+			fields = append(fields, zap.String("storage2.database-dir", dbdir))
+		}
+		log.Warn("The storage directory is set on the command line; change these flags before restarting the node.", fields...)
+		return nil
+	}
+
+	configFile := filepath.Join(confDir, "config.yaml")
+	if err := updateConfigFile(configFile, values); err != nil {
+		return errs.New("Error updating %s: %+v", configFile, err)
+	}
+	log.Info("Updated the config to use the new storage directory.", zap.String("Config", configFile))
+	return nil
+}
+
+// updateConfigFile replaces or appends the given top-level keys of a config
+// file, keeping everything else as is.
+func updateConfigFile(path string, values map[string]string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	for _, key := range keys {
+		encoded, err := yaml.Marshal(map[string]string{key: values[key]})
+		if err != nil {
+			return err
+		}
+		line := strings.TrimRight(string(encoded), "\n")
+
+		found := false
+		for i := range lines {
+			if strings.HasPrefix(lines[i], key+":") {
+				lines[i] = line
+				found = true
+			}
+		}
+		if !found {
+			lines = append(lines, line)
+		}
+	}
+
+	return fpath.AtomicWriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), info.Mode())
+}
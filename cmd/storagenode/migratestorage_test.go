@@ -0,0 +1,44 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+)
+
+func TestUpdateConfigFile(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	configFile := filepath.Join(ctx.Dir("config"), "config.yaml")
+	require.NoError(t, ioutil.WriteFile(configFile, []byte(`# path to store data in
+storage.path: /mnt/old
+
+# directory where database files live
+# storage2.database-dir: ""
+server.address: :28967
+`), 0644))
+
+	require.NoError(t, updateConfigFile(configFile, map[string]string{
+		"storage.path":          "/mnt/new disk",
+		"storage2.database-dir": "/mnt/old",
+	}))
+
+	data, err := ioutil.ReadFile(configFile)
+	require.NoError(t, err)
+	require.Equal(t, `# path to store data in
+storage.path: /mnt/new disk
+
+# directory where database files live
+# storage2.database-dir: ""
+server.address: :28967
+storage2.database-dir: /mnt/old
+`, string(data))
+}
@@ -0,0 +1,360 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/memory"
+	"storj.io/common/pb"
+	"storj.io/common/pkcrypto"
+	"storj.io/common/storj"
+	"storj.io/private/process"
+	"storj.io/storj/storage"
+	"storj.io/storj/storagenode"
+	"storj.io/storj/storagenode/pieces"
+	"storj.io/storj/storagenode/storagenodedb"
+)
+
+var (
+	piecesCmd = &cobra.Command{
+		Use:         "pieces",
+		Short:       "Inspect the pieces stored by the node",
+		Annotations: map[string]string{"type": "helper"},
+	}
+	piecesListCmd = &cobra.Command{
+		Use:   "list [satellite-id...]",
+		Short: "List the pieces of satellites with their size and creation time",
+		Long: `List the pieces of satellites with their size and creation time.
+
+Without arguments the pieces of all satellites are listed. The storage node
+should be stopped.
+`,
+		RunE:        cmdPiecesList,
+		Annotations: map[string]string{"type": "helper"},
+	}
+	piecesHeaderCmd = &cobra.Command{
+		Use:         "header satellite-id piece-id",
+		Short:       "Print the header of a piece with its order limit and hash",
+		RunE:        cmdPiecesHeader,
+		Annotations: map[string]string{"type": "helper"},
+		Args:        cobra.ExactArgs(2),
+	}
+	piecesVerifyCmd = &cobra.Command{
+		Use:   "verify satellite-id [piece-id...]",
+		Short: "Verify the hashes of pieces",
+		Long: `Verify the hashes of pieces.
+
+The content of the pieces is hashed and compared with the hash the uplink
+signed when uploading them. Without piece IDs all pieces of the satellite are
+verified. The storage node should be stopped.
+`,
+		RunE:        cmdPiecesVerify,
+		Annotations: map[string]string{"type": "helper"},
+		Args:        cobra.MinimumNArgs(1),
+	}
+	piecesStatusCmd = &cobra.Command{
+		Use:         "status satellite-id piece-id...",
+		Short:       "Print whether pieces are stored, in the trash or expired",
+		RunE:        cmdPiecesStatus,
+		Annotations: map[string]string{"type": "helper"},
+		Args:        cobra.MinimumNArgs(2),
+	}
+
+	piecesCfg struct {
+		storagenode.Config
+	}
+)
+
+// openPieceStore opens the storage node databases and returns the piece store
+// on top of them.
+func openPieceStore(ctx context.Context) (_ *pieces.Store, _ *storagenodedb.DB, err error) {
+	log := zap.L()
+
+	db, err := storagenodedb.OpenExisting(ctx, log.Named("db"), piecesCfg.DatabaseConfig())
+	if err != nil {
+		return nil, nil, errs.New("Error opening the databases of the storage node: %v", err)
+	}
+
+	store := pieces.NewStore(log.Named("pieces"), db.Pieces(), db.V0PieceInfo(), db.PieceExpirationDB(), db.PieceSpaceUsedDB(), piecesCfg.Pieces)
+	return store, db, nil
+}
+
+// parsePieceIDs parses the piece IDs given as arguments.
+func parsePieceIDs(args []string) ([]storj.PieceID, error) {
+	pieceIDs := make([]storj.PieceID, 0, len(args))
+	for _, arg := range args {
+		pieceID, err := storj.PieceIDFromString(arg)
+		if err != nil {
+			return nil, errs.New("invalid piece ID %q: %v", arg, err)
+		}
+		pieceIDs = append(pieceIDs, pieceID)
+	}
+	return pieceIDs, nil
+}
+
+func cmdPiecesList(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+
+	store, db, err := openPieceStore(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, db.Close()) }()
+
+	var satellites []storj.NodeID
+	if len(args) > 0 {
+		for _, arg := range args {
+			satellite, err := storj.NodeIDFromString(arg)
+			if err != nil {
+				return errs.New("invalid satellite ID %q: %v", arg, err)
+			}
+			satellites = append(satellites, satellite)
+		}
+	} else {
+		namespaces, err := db.Pieces().ListNamespaces(ctx)
+		if err != nil {
+			return err
+		}
+		for _, namespace := range namespaces {
+			satellite, err := storj.NodeIDFromBytes(namespace)
+			if err != nil {
+				continue
+			}
+			satellites = append(satellites, satellite)
+		}
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	defer func() { err = errs.Combine(err, tw.Flush()) }()
+
+	fmt.Fprintln(tw, "SATELLITE\tPIECE ID\tFORMAT\tSIZE\tCONTENT SIZE\tCREATED")
+	for _, satellite := range satellites {
+		err := store.WalkSatellitePieces(ctx, satellite, func(access pieces.StoredPieceAccess) error {
+			size, contentSize, err := access.Size(ctx)
+			if err != nil {
+				return err
+			}
+			created, err := access.CreationTime(ctx)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(tw, "%s\t%s\tv%d\t%s\t%s\t%s\n", satellite, access.PieceID(), access.StorageFormatVersion(),
+				memory.Size(size).Base10String(), memory.Size(contentSize).Base10String(), created.UTC().Format(time.RFC3339))
+			return nil
+		})
+		if err != nil {
+			return errs.New("listing the pieces of %s failed: %v", satellite, err)
+		}
+	}
+	return nil
+}
+
+func cmdPiecesHeader(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+
+	satellite, err := storj.NodeIDFromString(args[0])
+	if err != nil {
+		return errs.New("invalid satellite ID: %v", err)
+	}
+	pieceID, err := storj.PieceIDFromString(args[1])
+	if err != nil {
+		return errs.New("invalid piece ID: %v", err)
+	}
+
+	store, db, err := openPieceStore(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, db.Close()) }()
+
+	reader, err := store.Reader(ctx, satellite, pieceID)
+	if err != nil {
+		return errs.New("unable to open the piece: %v", err)
+	}
+	defer func() { err = errs.Combine(err, reader.Close()) }()
+
+	hash, limit, err := store.GetHashAndLimit(ctx, satellite, pieceID, reader)
+	if err != nil {
+		return err
+	}
+
+	printPieceHeader(os.Stdout, reader.StorageFormatVersion(), hash, limit)
+	return nil
+}
+
+// printPieceHeader prints the hash and the order limit of a piece.
+func printPieceHeader(w io.Writer, formatVersion storage.FormatVersion, hash pb.PieceHash, limit pb.OrderLimit) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	defer func() { _ = tw.Flush() }()
+
+	formatTime := func(t time.Time) string {
+		if t.IsZero() {
+			return "-"
+		}
+		return t.UTC().Format(time.RFC3339)
+	}
+
+	fmt.Fprintf(tw, "Piece ID:\t%s\n", hash.PieceId)
+	fmt.Fprintf(tw, "Storage format:\tv%d\n", formatVersion)
+	fmt.Fprintf(tw, "Content size:\t%s\n", memory.Size(hash.PieceSize).Base10String())
+	fmt.Fprintf(tw, "Hash:\t%s\n", hex.EncodeToString(hash.Hash))
+	fmt.Fprintf(tw, "Hash signature:\t%s\n", hex.EncodeToString(hash.Signature))
+	fmt.Fprintf(tw, "Created:\t%s\n", formatTime(hash.Timestamp))
+	fmt.Fprintf(tw, "Expiration:\t%s\n", formatTime(limit.PieceExpiration))
+	fmt.Fprintln(tw, "Order limit:\t")
+	fmt.Fprintf(tw, "  Serial number:\t%s\n", limit.SerialNumber)
+	fmt.Fprintf(tw, "  Satellite:\t%s\n", limit.SatelliteId)
+	fmt.Fprintf(tw, "  Storage node:\t%s\n", limit.StorageNodeId)
+	fmt.Fprintf(tw, "  Original piece ID:\t%s\n", limit.PieceId)
+	fmt.Fprintf(tw, "  Action:\t%s\n", limit.Action)
+	fmt.Fprintf(tw, "  Limit:\t%s\n", memory.Size(limit.Limit).Base10String())
+	fmt.Fprintf(tw, "  Created:\t%s\n", formatTime(limit.OrderCreation))
+	fmt.Fprintf(tw, "  Expiration:\t%s\n", formatTime(limit.OrderExpiration))
+}
+
+// verifyPiece hashes the content of the piece and compares it with the hash
+// signed by the uplink.
+func verifyPiece(ctx context.Context, store *pieces.Store, satellite storj.NodeID, pieceID storj.PieceID) (err error) {
+	reader, err := store.Reader(ctx, satellite, pieceID)
+	if err != nil {
+		return errs.New("unable to open the piece: %v", err)
+	}
+	defer func() { err = errs.Combine(err, reader.Close()) }()
+
+	expected, _, err := store.GetHashAndLimit(ctx, satellite, pieceID, reader)
+	if err != nil {
+		return err
+	}
+
+	hash := pkcrypto.NewHash()
+	size, err := io.Copy(hash, reader)
+	if err != nil {
+		return errs.New("unable to read the piece: %v", err)
+	}
+	if size != reader.Size() {
+		return errs.New("read %d bytes, but the piece has %d bytes", size, reader.Size())
+	}
+	if actual := hash.Sum(nil); !bytes.Equal(actual, expected.Hash) {
+		return errs.New("hash mismatch: content hashes to %x, but the uplink signed %x", actual, expected.Hash)
+	}
+	return nil
+}
+
+func cmdPiecesVerify(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+
+	satellite, err := storj.NodeIDFromString(args[0])
+	if err != nil {
+		return errs.New("invalid satellite ID: %v", err)
+	}
+	pieceIDs, err := parsePieceIDs(args[1:])
+	if err != nil {
+		return err
+	}
+
+	store, db, err := openPieceStore(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, db.Close()) }()
+
+	if len(pieceIDs) == 0 {
+		err := store.WalkSatellitePieces(ctx, satellite, func(access pieces.StoredPieceAccess) error {
+			pieceIDs = append(pieceIDs, access.PieceID())
+			return nil
+		})
+		if err != nil {
+			return errs.New("listing the pieces of %s failed: %v", satellite, err)
+		}
+	}
+
+	var failed int
+	for _, pieceID := range pieceIDs {
+		if err := verifyPiece(ctx, store, satellite, pieceID); err != nil {
+			failed++
+			fmt.Printf("%s\tFAILED\t%v\n", pieceID, err)
+			continue
+		}
+		fmt.Printf("%s\tOK\n", pieceID)
+	}
+
+	fmt.Printf("Verified %d pieces, %d failed.\n", len(pieceIDs), failed)
+	if failed > 0 {
+		return errs.New("%d pieces failed the verification", failed)
+	}
+	return nil
+}
+
+// pieceStatus returns whether the piece is stored, in the trash or expired.
+func pieceStatus(ctx context.Context, store *pieces.Store, satellite storj.NodeID, pieceID storj.PieceID, now time.Time) (_ string, err error) {
+	reader, err := store.Reader(ctx, satellite, pieceID)
+	if err == nil {
+		defer func() { err = errs.Combine(err, reader.Close()) }()
+
+		_, limit, err := store.GetHashAndLimit(ctx, satellite, pieceID, reader)
+		if err != nil {
+			return "", err
+		}
+		if !limit.PieceExpiration.IsZero() && limit.PieceExpiration.Before(now) {
+			return fmt.Sprintf("expired at %s, waiting for deletion", limit.PieceExpiration.UTC().Format(time.RFC3339)), nil
+		}
+		if !limit.PieceExpiration.IsZero() {
+			return fmt.Sprintf("stored, expires at %s", limit.PieceExpiration.UTC().Format(time.RFC3339)), nil
+		}
+		return "stored", nil
+	}
+	if !errs.IsFunc(err, os.IsNotExist) {
+		return "", err
+	}
+
+	trashedAt, ok, err := store.TrashedAt(ctx, satellite, pieceID)
+	if err != nil {
+		return "", err
+	}
+	if ok {
+		return fmt.Sprintf("in the trash since %s", trashedAt.UTC().Format(time.RFC3339)), nil
+	}
+	return "not found", nil
+}
+
+func cmdPiecesStatus(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+
+	satellite, err := storj.NodeIDFromString(args[0])
+	if err != nil {
+		return errs.New("invalid satellite ID: %v", err)
+	}
+	pieceIDs, err := parsePieceIDs(args[1:])
+	if err != nil {
+		return err
+	}
+
+	store, db, err := openPieceStore(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, db.Close()) }()
+
+	now := time.Now()
+	for _, pieceID := range pieceIDs {
+		status, err := pieceStatus(ctx, store, satellite, pieceID, now)
+		if err != nil {
+			status = fmt.Sprintf("error: %v", err)
+		}
+		fmt.Printf("%s\t%s\n", pieceID, status)
+	}
+	return nil
+}
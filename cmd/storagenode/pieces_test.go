@@ -0,0 +1,88 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/filestore"
+	"storj.io/storj/storagenode/pieces"
+)
+
+func TestPieceVerifyAndStatus(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	dir, err := filestore.NewDir(zaptest.NewLogger(t), ctx.Dir("pieces"))
+	require.NoError(t, err)
+
+	blobs := filestore.New(zaptest.NewLogger(t), dir, filestore.DefaultConfig)
+	defer ctx.Check(blobs.Close)
+
+	store := pieces.NewStore(zaptest.NewLogger(t), blobs, nil, nil, nil, pieces.DefaultConfig)
+
+	satellite := testrand.NodeID()
+	now := time.Now()
+
+	writePiece := func(expiration time.Time) storj.PieceID {
+		pieceID := testrand.PieceID()
+		writer, err := store.Writer(ctx, satellite, pieceID)
+		require.NoError(t, err)
+		_, err = writer.Write(testrand.Bytes(4096))
+		require.NoError(t, err)
+		require.NoError(t, writer.Commit(ctx, &pb.PieceHeader{
+			Hash:         writer.Hash(),
+			CreationTime: now,
+			OrderLimit:   pb.OrderLimit{PieceExpiration: expiration},
+		}))
+		return pieceID
+	}
+
+	stored := writePiece(time.Time{})
+	expired := writePiece(now.Add(-time.Hour))
+	trashed := writePiece(time.Time{})
+	require.NoError(t, blobs.Trash(ctx, storage.BlobRef{Namespace: satellite.Bytes(), Key: trashed.Bytes()}))
+
+	require.NoError(t, verifyPiece(ctx, store, satellite, stored))
+	require.Error(t, verifyPiece(ctx, store, satellite, trashed))
+
+	status, err := pieceStatus(ctx, store, satellite, stored, now)
+	require.NoError(t, err)
+	require.Equal(t, "stored", status)
+
+	status, err = pieceStatus(ctx, store, satellite, expired, now)
+	require.NoError(t, err)
+	require.Contains(t, status, "expired at")
+
+	status, err = pieceStatus(ctx, store, satellite, trashed, now)
+	require.NoError(t, err)
+	require.Contains(t, status, "in the trash since")
+
+	status, err = pieceStatus(ctx, store, satellite, testrand.PieceID(), now)
+	require.NoError(t, err)
+	require.Equal(t, "not found", status)
+
+	// corrupt the content of the stored piece.
+	info, err := blobs.Stat(ctx, storage.BlobRef{Namespace: satellite.Bytes(), Key: stored.Bytes()})
+	require.NoError(t, err)
+	path, err := info.FullPath(ctx)
+	require.NoError(t, err)
+	file, err := os.OpenFile(path, os.O_WRONLY, 0)
+	require.NoError(t, err)
+	_, err = file.WriteAt([]byte("corrupted"), pieces.V1PieceHeaderReservedArea+100)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	require.Error(t, verifyPiece(ctx, store, satellite, stored))
+}
@@ -80,6 +80,9 @@ func (eg *EndpointGroup) addEndpoint(path, method string, endpoint *Endpoint) {
 type Param struct {
 	Name string
 	Type reflect.Type
+	// Optional marks a string or integer query param of a GET endpoint,
+	// which may be omitted to pass its zero value.
+	Optional bool
 }
 
 // NewParam constructor which creates new Param entity by given name and type.
@@ -89,3 +92,10 @@ func NewParam(name string, instance interface{}) Param {
 		Type: reflect.TypeOf(instance),
 	}
 }
+
+// NewOptionalParam constructor which creates new optional Param entity by given name and type.
+func NewOptionalParam(name string, instance interface{}) Param {
+	param := NewParam(name, instance)
+	param.Optional = true
+	return param
+}
@@ -0,0 +1,285 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package apigen
+
+import (
+	"fmt"
+	"go/format"
+	"net/http"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/zeebo/errs"
+)
+
+// MustWriteGoClient writes generated Go client code into a file.
+func (a *API) MustWriteGoClient(path, packageName string) {
+	generated, err := a.generateGoClient(packageName)
+	if err != nil {
+		panic(errs.Wrap(err))
+	}
+
+	err = os.WriteFile(path, generated, 0644)
+	if err != nil {
+		panic(errs.Wrap(err))
+	}
+}
+
+// generateGoClient generates a Go package with a client for the API.
+func (a *API) generateGoClient(packageName string) ([]byte, error) {
+	var result string
+
+	p := func(format string, a ...interface{}) {
+		result += fmt.Sprintf(format+"\n", a...)
+	}
+
+	imports := map[string]bool{}
+	i := func(paths ...string) {
+		for _, path := range paths {
+			imports[path] = true
+		}
+	}
+
+	var addTypeImports func(t reflect.Type)
+	addTypeImports = func(t reflect.Type) {
+		if t.PkgPath() != "" {
+			i(t.PkgPath())
+			return
+		}
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array:
+			addTypeImports(t.Elem())
+		case reflect.Map:
+			addTypeImports(t.Key())
+			addTypeImports(t.Elem())
+		}
+	}
+
+	methods := map[string]bool{}
+
+	i("bytes", "context", "encoding/json", "fmt", "io", "net/http", "net/url", "strings", "github.com/zeebo/errs")
+
+	p("const dateLayout = \"2006-01-02T15:04:05.000Z\"")
+	p("")
+	p("// Error is returned when the API responds with an error.")
+	p("type Error struct {")
+	p("Status int")
+	p("Message string")
+	p("}")
+	p("")
+	p("// Error implements the error interface.")
+	p("func (err *Error) Error() string {")
+	p("return fmt.Sprintf(\"%%s (status %%d)\", err.Message, err.Status)")
+	p("}")
+	p("")
+	p("// Client is a client for the %s API.", a.Version)
+	p("type Client struct {")
+	p("URL string")
+	p("APIKey string")
+	p("HTTPClient *http.Client")
+	p("}")
+	p("")
+	p("// NewClient returns a client for the API served at the url, which")
+	p("// authenticates with the API key.")
+	p("func NewClient(url, apiKey string) *Client {")
+	p("return &Client{")
+	p("URL: strings.TrimSuffix(url, \"/\"),")
+	p("APIKey: apiKey,")
+	p("HTTPClient: http.DefaultClient,")
+	p("}")
+	p("}")
+	p("")
+	p("// do sends the request and decodes the response into response, when it's not nil.")
+	p("func (client *Client) do(ctx context.Context, method, path string, query url.Values, request, response interface{}) (err error) {")
+	p("var body io.Reader")
+	p("if request != nil {")
+	p("data, err := json.Marshal(request)")
+	p("if err != nil {")
+	p("return err")
+	p("}")
+	p("body = bytes.NewReader(data)")
+	p("}")
+	p("")
+	p("target := client.URL + path")
+	p("if len(query) > 0 {")
+	p("target += \"?\" + query.Encode()")
+	p("}")
+	p("")
+	p("req, err := http.NewRequestWithContext(ctx, method, target, body)")
+	p("if err != nil {")
+	p("return err")
+	p("}")
+	p("req.Header.Set(\"Content-Type\", \"application/json\")")
+	p("if client.APIKey != \"\" {")
+	p("req.Header.Set(\"Authorization\", \"Bearer \"+client.APIKey)")
+	p("}")
+	p("")
+	p("resp, err := client.HTTPClient.Do(req)")
+	p("if err != nil {")
+	p("return err")
+	p("}")
+	p("defer func() { err = errs.Combine(err, resp.Body.Close()) }()")
+	p("")
+	p("if resp.StatusCode != http.StatusOK {")
+	p("var apiErr struct {")
+	p("Error string `json:\"error\"`")
+	p("}")
+	p("_ = json.NewDecoder(resp.Body).Decode(&apiErr)")
+	p("return &Error{Status: resp.StatusCode, Message: apiErr.Error}")
+	p("}")
+	p("")
+	p("if response == nil {")
+	p("return nil")
+	p("}")
+	p("return json.NewDecoder(resp.Body).Decode(response)")
+	p("}")
+
+	for _, group := range a.EndpointGroups {
+		for _, endpoint := range group.endpoints {
+			if methods[endpoint.MethodName] {
+				return nil, errs.New("duplicate method name %q", endpoint.MethodName)
+			}
+			methods[endpoint.MethodName] = true
+
+			var params []string
+			for _, param := range endpoint.Params {
+				addTypeImports(param.Type)
+				params = append(params, param.Name+" "+param.Type.String())
+			}
+
+			results := "error"
+			if endpoint.Response != nil {
+				responseType := reflect.TypeOf(endpoint.Response)
+				addTypeImports(responseType)
+				results = "(" + responseType.String() + ", error)"
+			}
+
+			p("")
+			p("// %s %s.", endpoint.MethodName, docSentence(endpoint.Description))
+			p("func (client *Client) %s(ctx context.Context, %s) %s {", endpoint.MethodName, strings.Join(params, ", "), results)
+
+			path := "\"/api/" + a.Version + "/" + group.Prefix + endpoint.Path + "\""
+			query, request := "nil", "nil"
+			for _, param := range endpoint.Params {
+				switch {
+				case endpoint.isBodyParam(param):
+					request = param.Name
+				case endpoint.isPathParam(param):
+					path = strings.Replace(path, "{"+param.Name+"}", "\" + url.PathEscape("+goStringOf(param)+") + \"", 1)
+				default:
+					if query == "nil" {
+						query = "query"
+						p("query := url.Values{}")
+					}
+					if isInteger(param.Type) {
+						i("strconv")
+					}
+					p("query.Set(%q, %s)", param.Name, goStringOf(param))
+				}
+			}
+			path = strings.TrimSuffix(path, " + \"\"")
+
+			if endpoint.Response != nil {
+				p("var response %s", reflect.TypeOf(endpoint.Response))
+				p("err := client.do(ctx, http.Method%s, %s, %s, %s, &response)", methodSuffix(endpoint.Method), path, query, request)
+				p("return response, err")
+			} else {
+				p("return client.do(ctx, http.Method%s, %s, %s, %s, nil)", methodSuffix(endpoint.Method), path, query, request)
+			}
+			p("}")
+		}
+	}
+
+	fileBody := result
+	result = ""
+
+	p("// AUTOGENERATED BY private/apigen")
+	p("// DO NOT EDIT.")
+	p("")
+	p("package %s", packageName)
+	p("")
+
+	var standard, external, internal []string
+	for path := range imports {
+		switch {
+		case !strings.Contains(path, "."):
+			standard = append(standard, path)
+		case strings.HasPrefix(path, "storj.io"):
+			internal = append(internal, path)
+		default:
+			external = append(external, path)
+		}
+	}
+
+	p("import (")
+	slices := [][]string{standard, external, internal}
+	for sn, slice := range slices {
+		sort.Strings(slice)
+		for pn, path := range slice {
+			p(`"%s"`, path)
+			if pn == len(slice)-1 && sn < len(slices)-1 {
+				p("")
+			}
+		}
+	}
+	p(")")
+	p("")
+
+	result += fileBody
+
+	output, err := format.Source([]byte(result))
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// goStringOf returns an expression which formats the param like the
+// generated handlers parse it.
+func goStringOf(param Param) string {
+	switch param.Type {
+	case typeUUID:
+		return param.Name + ".String()"
+	case typeTime:
+		return param.Name + ".Format(dateLayout)"
+	}
+
+	switch {
+	case isUnsigned(param.Type):
+		return "strconv.FormatUint(uint64(" + param.Name + "), 10)"
+	case isInteger(param.Type):
+		return "strconv.FormatInt(int64(" + param.Name + "), 10)"
+	default:
+		return param.Name
+	}
+}
+
+// methodSuffix returns the suffix of the net/http constant of the HTTP method.
+func methodSuffix(method string) string {
+	switch method {
+	case http.MethodGet:
+		return "Get"
+	case http.MethodPatch:
+		return "Patch"
+	case http.MethodPost:
+		return "Post"
+	case http.MethodDelete:
+		return "Delete"
+	default:
+		return method
+	}
+}
+
+// docSentence turns an endpoint description into the rest of a sentence
+// starting with the name of the documented method.
+func docSentence(description string) string {
+	description = strings.TrimSuffix(strings.TrimSpace(description), ".")
+	if description == "" {
+		return "calls the endpoint"
+	}
+	return strings.ToLower(description[:1]) + description[1:]
+}
@@ -87,6 +87,11 @@ func (a *API) generateGo() ([]byte, error) {
 			if method.Response != nil {
 				i(reflect.TypeOf(method.Response).Elem().PkgPath())
 			}
+			for _, param := range method.Params {
+				if param.Type.PkgPath() != "" {
+					i(param.Type.PkgPath())
+				}
+			}
 		}
 	}
 
@@ -198,6 +203,10 @@ func (a *API) generateGo() ([]byte, error) {
 						handleStringQuery(p, param)
 						continue
 					}
+					if isInteger(param.Type) {
+						i("strconv")
+						handleIntegerQuery(p, param)
+					}
 				}
 			case http.MethodPatch:
 				for _, param := range endpoint.Params {
@@ -317,6 +326,10 @@ func (a *API) handleTypesPackage(t reflect.Type) interface{} {
 // handleStringQuery handles request query param of type string.
 func handleStringQuery(p func(format string, a ...interface{}), param Param) {
 	p("%s := r.URL.Query().Get(\"%s\")", param.Name, param.Name)
+	if param.Optional {
+		p("")
+		return
+	}
 	p("if %s == \"\" {", param.Name)
 	p("api.ServeError(h.log, w, http.StatusBadRequest, errs.New(\"parameter '%s' can't be empty\"))", param.Name)
 	p("return")
@@ -324,6 +337,41 @@ func handleStringQuery(p func(format string, a ...interface{}), param Param) {
 	p("")
 }
 
+// handleIntegerQuery handles request query param of an integer type.
+func handleIntegerQuery(p func(format string, a ...interface{}), param Param) {
+	parse := "strconv.ParseInt"
+	if isUnsigned(param.Type) {
+		parse = "strconv.ParseUint"
+	}
+	// int and uint are parsed in their platform dependent size.
+	bitSize := 0
+	if param.Type.Kind() != reflect.Int && param.Type.Kind() != reflect.Uint {
+		bitSize = param.Type.Bits()
+	}
+
+	if param.Optional {
+		p("var %s %s", param.Name, param.Type)
+		p("if %sParam := r.URL.Query().Get(\"%s\"); %sParam != \"\" {", param.Name, param.Name, param.Name)
+		p("value, err := %s(%sParam, 10, %d)", parse, param.Name, bitSize)
+		p("if err != nil {")
+		p("api.ServeError(h.log, w, http.StatusBadRequest, err)")
+		p("return")
+		p("}")
+		p("%s = %s(value)", param.Name, param.Type)
+		p("}")
+		p("")
+		return
+	}
+
+	p("%sParam, err := %s(r.URL.Query().Get(\"%s\"), 10, %d)", param.Name, parse, param.Name, bitSize)
+	p("if err != nil {")
+	p("api.ServeError(h.log, w, http.StatusBadRequest, err)")
+	p("return")
+	p("}")
+	p("%s := %s(%sParam)", param.Name, param.Type, param.Name)
+	p("")
+}
+
 // handleUUIDQuery handles request query param of type uuid.UUID.
 func handleUUIDQuery(p func(format string, a ...interface{}), param Param) {
 	p("%s, err := uuid.FromString(r.URL.Query().Get(\"%s\"))", param.Name, param.Name)
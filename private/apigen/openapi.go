@@ -0,0 +1,259 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package apigen
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/zeebo/errs"
+)
+
+// MustWriteOpenAPI writes generated OpenAPI 3 document into a file.
+func (a *API) MustWriteOpenAPI(path string) {
+	generated, err := a.generateOpenAPI()
+	if err != nil {
+		panic(errs.Wrap(err))
+	}
+
+	err = os.WriteFile(path, generated, 0644)
+	if err != nil {
+		panic(errs.Wrap(err))
+	}
+}
+
+// openAPIDocument is the root of an OpenAPI 3 document.
+type openAPIDocument struct {
+	OpenAPI    string                                  `json:"openapi"`
+	Info       openAPIInfo                             `json:"info"`
+	Paths      map[string]map[string]*openAPIOperation `json:"paths"`
+	Components openAPIComponents                       `json:"components"`
+}
+
+type openAPIInfo struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+type openAPIComponents struct {
+	Schemas         map[string]*openAPISchema         `json:"schemas"`
+	SecuritySchemes map[string]*openAPISecurityScheme `json:"securitySchemes"`
+}
+
+type openAPISecurityScheme struct {
+	Type   string `json:"type"`
+	Scheme string `json:"scheme,omitempty"`
+	In     string `json:"in,omitempty"`
+	Name   string `json:"name,omitempty"`
+}
+
+type openAPIOperation struct {
+	OperationID string                      `json:"operationId"`
+	Summary     string                      `json:"summary,omitempty"`
+	Description string                      `json:"description,omitempty"`
+	Tags        []string                    `json:"tags,omitempty"`
+	Parameters  []*openAPIParameter         `json:"parameters,omitempty"`
+	RequestBody *openAPIRequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*openAPIResponse `json:"responses"`
+	Security    []map[string][]string       `json:"security"`
+}
+
+type openAPIParameter struct {
+	Name     string         `json:"name"`
+	In       string         `json:"in"`
+	Required bool           `json:"required"`
+	Schema   *openAPISchema `json:"schema"`
+}
+
+type openAPIRequestBody struct {
+	Required bool                         `json:"required"`
+	Content  map[string]*openAPIMediaType `json:"content"`
+}
+
+type openAPIResponse struct {
+	Description string                       `json:"description"`
+	Content     map[string]*openAPIMediaType `json:"content,omitempty"`
+}
+
+type openAPIMediaType struct {
+	Schema *openAPISchema `json:"schema"`
+}
+
+type openAPISchema struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Nullable             bool                      `json:"nullable,omitempty"`
+	Items                *openAPISchema            `json:"items,omitempty"`
+	Properties           map[string]*openAPISchema `json:"properties,omitempty"`
+	Required             []string                  `json:"required,omitempty"`
+	AdditionalProperties *openAPISchema            `json:"additionalProperties,omitempty"`
+}
+
+// generateOpenAPI generates an OpenAPI 3 document describing the API.
+func (a *API) generateOpenAPI() ([]byte, error) {
+	types, err := a.structTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	doc := openAPIDocument{
+		OpenAPI: "3.0.3",
+		Info: openAPIInfo{
+			Title:       a.PackageName,
+			Description: a.Description,
+			Version:     a.Version,
+		},
+		Paths: map[string]map[string]*openAPIOperation{},
+		Components: openAPIComponents{
+			Schemas: map[string]*openAPISchema{
+				"Error": {
+					Type:       "object",
+					Properties: map[string]*openAPISchema{"error": {Type: "string"}},
+				},
+			},
+			SecuritySchemes: map[string]*openAPISecurityScheme{
+				"cookieAuth": {Type: "apiKey", In: "cookie", Name: "_tokenKey"},
+				"bearerAuth": {Type: "http", Scheme: "bearer"},
+			},
+		},
+	}
+
+	for _, t := range types {
+		schema := &openAPISchema{Type: "object", Properties: map[string]*openAPISchema{}}
+		for _, field := range jsonFields(t) {
+			schema.Properties[field.Name] = openAPISchemaOf(field.Type)
+			if !field.OmitEmpty {
+				schema.Required = append(schema.Required, field.Name)
+			}
+		}
+		doc.Components.Schemas[t.Name()] = schema
+	}
+
+	for _, group := range a.EndpointGroups {
+		for _, endpoint := range group.endpoints {
+			path := "/api/" + a.Version + "/" + group.Prefix + endpoint.Path
+
+			operation := &openAPIOperation{
+				OperationID: endpoint.MethodName,
+				Summary:     endpoint.Name,
+				Description: endpoint.Description,
+				Tags:        []string{group.Name},
+				Responses: map[string]*openAPIResponse{
+					"default": {
+						Description: "error",
+						Content: map[string]*openAPIMediaType{
+							"application/json": {Schema: &openAPISchema{Ref: "#/components/schemas/Error"}},
+						},
+					},
+				},
+				Security: []map[string][]string{},
+			}
+
+			for _, param := range endpoint.Params {
+				switch {
+				case endpoint.isBodyParam(param):
+					operation.RequestBody = &openAPIRequestBody{
+						Required: true,
+						Content: map[string]*openAPIMediaType{
+							"application/json": {Schema: openAPISchemaOf(param.Type)},
+						},
+					}
+				case endpoint.isPathParam(param):
+					operation.Parameters = append(operation.Parameters, &openAPIParameter{
+						Name:     param.Name,
+						In:       "path",
+						Required: true,
+						Schema:   openAPISchemaOf(param.Type),
+					})
+				default:
+					operation.Parameters = append(operation.Parameters, &openAPIParameter{
+						Name:     param.Name,
+						In:       "query",
+						Required: !param.Optional,
+						Schema:   openAPISchemaOf(param.Type),
+					})
+				}
+			}
+
+			response := &openAPIResponse{Description: "OK"}
+			if endpoint.Response != nil {
+				response.Content = map[string]*openAPIMediaType{
+					"application/json": {Schema: openAPISchemaOf(reflect.TypeOf(endpoint.Response))},
+				}
+			}
+			operation.Responses["200"] = response
+
+			if endpoint.CookieAuth() {
+				operation.Security = append(operation.Security, map[string][]string{"cookieAuth": {}})
+			}
+			if endpoint.APIAuth() {
+				operation.Security = append(operation.Security, map[string][]string{"bearerAuth": {}})
+			}
+
+			if doc.Paths[path] == nil {
+				doc.Paths[path] = map[string]*openAPIOperation{}
+			}
+			doc.Paths[path][strings.ToLower(endpoint.Method)] = operation
+		}
+	}
+
+	output, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(output, '\n'), nil
+}
+
+// openAPISchemaOf returns the schema of the type as it's encoded by encoding/json.
+func openAPISchemaOf(t reflect.Type) *openAPISchema {
+	if t.Kind() == reflect.Ptr {
+		schema := openAPISchemaOf(t.Elem())
+		// siblings of $ref are ignored by OpenAPI 3.0, hence references
+		// can't be marked as nullable.
+		if schema.Ref == "" {
+			schema.Nullable = true
+		}
+		return schema
+	}
+
+	switch {
+	case t == typeUUID:
+		return &openAPISchema{Type: "string", Format: "uuid"}
+	case t == typeTime:
+		return &openAPISchema{Type: "string", Format: "date-time"}
+	case isJSONString(t):
+		return &openAPISchema{Type: "string"}
+	case isBytes(t):
+		return &openAPISchema{Type: "string", Format: "byte", Nullable: true}
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		return &openAPISchema{Ref: "#/components/schemas/" + t.Name()}
+	case reflect.Slice:
+		return &openAPISchema{Type: "array", Items: openAPISchemaOf(t.Elem()), Nullable: true}
+	case reflect.Array:
+		return &openAPISchema{Type: "array", Items: openAPISchemaOf(t.Elem())}
+	case reflect.Map:
+		return &openAPISchema{Type: "object", AdditionalProperties: openAPISchemaOf(t.Elem())}
+	case reflect.Bool:
+		return &openAPISchema{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return &openAPISchema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return &openAPISchema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &openAPISchema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &openAPISchema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &openAPISchema{Type: "string"}
+	default:
+		return &openAPISchema{}
+	}
+}
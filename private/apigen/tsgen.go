@@ -0,0 +1,203 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package apigen
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/zeebo/errs"
+)
+
+// MustWriteTS writes generated TypeScript code into a file.
+func (a *API) MustWriteTS(path string) {
+	generated, err := a.generateTS()
+	if err != nil {
+		panic(errs.Wrap(err))
+	}
+
+	err = os.WriteFile(path, generated, 0644)
+	if err != nil {
+		panic(errs.Wrap(err))
+	}
+}
+
+// generateTS generates TypeScript classes for the types used by the API and
+// a client class for every endpoint group.
+func (a *API) generateTS() ([]byte, error) {
+	types, err := a.structTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	var result string
+
+	p := func(format string, a ...interface{}) {
+		result += fmt.Sprintf(format+"\n", a...)
+	}
+
+	p("// AUTOGENERATED BY private/apigen")
+	p("// DO NOT EDIT.")
+	p("")
+	p("import { HttpClient } from '@/utils/httpClient';")
+
+	for _, t := range types {
+		p("")
+		p("export class %s {", t.Name())
+		for _, field := range jsonFields(t) {
+			optional := ""
+			if field.OmitEmpty {
+				optional = "?"
+			}
+			p("    %s%s: %s;", tsPropertyName(field.Name), optional, tsTypeOf(field.Type))
+		}
+		p("}")
+	}
+
+	for _, group := range a.EndpointGroups {
+		p("")
+		p("export class %sHttpApi%s {", group.Name, strings.ToUpper(a.Version[:1])+a.Version[1:])
+		p("    private readonly http: HttpClient = new HttpClient();")
+		p("    private readonly ROOT_PATH: string = '/api/%s/%s';", a.Version, group.Prefix)
+
+		for _, endpoint := range group.endpoints {
+			var params []string
+			for _, param := range endpoint.Params {
+				params = append(params, param.Name+": "+tsParamTypeOf(param.Type))
+			}
+
+			returnType := "void"
+			if endpoint.Response != nil {
+				responseType := reflect.TypeOf(endpoint.Response)
+				// handlers respond with the value a pointer points to.
+				if responseType.Kind() == reflect.Ptr {
+					responseType = responseType.Elem()
+				}
+				returnType = tsTypeOf(responseType)
+			}
+
+			path := "${this.ROOT_PATH}" + endpoint.Path
+			body := "null"
+			var query []string
+			for _, param := range endpoint.Params {
+				switch {
+				case endpoint.isBodyParam(param):
+					body = "JSON.stringify(" + param.Name + ")"
+				case endpoint.isPathParam(param):
+					path = strings.Replace(path, "{"+param.Name+"}", "${encodeURIComponent("+tsStringOf(param)+")}", 1)
+				default:
+					query = append(query, fmt.Sprintf("u.searchParams.set('%s', %s);", param.Name, tsStringOf(param)))
+				}
+			}
+
+			p("")
+			p("    /**")
+			p("     * %s.", strings.TrimSuffix(endpoint.Description, "."))
+			p("     *")
+			p("     * @throws Error")
+			p("     */")
+			p("    public async %s(%s): Promise<%s> {", strings.ToLower(endpoint.MethodName[:1])+endpoint.MethodName[1:], strings.Join(params, ", "), returnType)
+			if len(query) > 0 {
+				p("        const u = new URL(`%s`, window.location.href);", path)
+				for _, line := range query {
+					p("        %s", line)
+				}
+				p("        const path = u.toString();")
+			} else {
+				p("        const path = `%s`;", path)
+			}
+
+			switch endpoint.Method {
+			case http.MethodGet, http.MethodDelete:
+				p("        const response = await this.http.%s(path);", strings.ToLower(endpoint.Method))
+			default:
+				p("        const response = await this.http.%s(path, %s);", strings.ToLower(endpoint.Method), body)
+			}
+			p("")
+			p("        if (response.ok) {")
+			if endpoint.Response != nil {
+				p("            return response.json().then((body) => body as %s);", returnType)
+			} else {
+				p("            return;")
+			}
+			p("        }")
+			p("        const err = await response.json();")
+			p("        throw new Error(err.error);")
+			p("    }")
+		}
+		p("}")
+	}
+
+	return []byte(result), nil
+}
+
+// tsTypeOf returns the TypeScript type of the JSON encoding of the type.
+func tsTypeOf(t reflect.Type) string {
+	if t.Kind() == reflect.Ptr {
+		return tsTypeOf(t.Elem()) + " | null"
+	}
+
+	switch {
+	case isJSONString(t), isBytes(t):
+		return "string"
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		return t.Name()
+	case reflect.Slice, reflect.Array:
+		elem := tsTypeOf(t.Elem())
+		if strings.Contains(elem, " ") {
+			elem = "(" + elem + ")"
+		}
+		return elem + "[]"
+	case reflect.Map:
+		return "Record<string, " + tsTypeOf(t.Elem()) + ">"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	default:
+		return "unknown"
+	}
+}
+
+// tsParamTypeOf returns the TypeScript type of an endpoint param. Unlike in
+// JSON bodies, times are passed as Date.
+func tsParamTypeOf(t reflect.Type) string {
+	if t == typeTime {
+		return "Date"
+	}
+	return tsTypeOf(t)
+}
+
+// tsStringOf returns an expression which formats the param like the
+// generated handlers parse it.
+func tsStringOf(param Param) string {
+	if param.Type == typeTime {
+		return param.Name + ".toISOString()"
+	}
+	if isInteger(param.Type) {
+		return "String(" + param.Name + ")"
+	}
+	return param.Name
+}
+
+// tsPropertyName quotes JSON field names which aren't valid identifiers.
+func tsPropertyName(name string) string {
+	for i, r := range name {
+		if r == '_' || r == '$' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || (i > 0 && '0' <= r && r <= '9') {
+			continue
+		}
+		return "'" + name + "'"
+	}
+	return name
+}
@@ -0,0 +1,190 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package apigen
+
+import (
+	"encoding"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/uuid"
+)
+
+var (
+	typeUUID = reflect.TypeOf(uuid.UUID{})
+	typeTime = reflect.TypeOf(time.Time{})
+	typeByte = reflect.TypeOf(byte(0))
+
+	typeJSONMarshaler = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	typeTextMarshaler = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// isJSONString returns whether a type with custom marshaling is encoded as a
+// JSON string, e.g. memory.Size.
+func isJSONString(t reflect.Type) bool {
+	if t == typeUUID || t == typeTime {
+		return true
+	}
+	return t.Kind() != reflect.Struct && (t.Implements(typeJSONMarshaler) || t.Implements(typeTextMarshaler))
+}
+
+// isInteger returns whether the type is a signed or unsigned integer, which
+// includes named types like enums.
+func isInteger(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	default:
+		return isUnsigned(t)
+	}
+}
+
+// isUnsigned returns whether the type is an unsigned integer.
+func isUnsigned(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	default:
+		return false
+	}
+}
+
+// isBytes returns whether the type is a byte slice, which is encoded as a base64 string.
+func isBytes(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && t.Elem() == typeByte
+}
+
+// jsonField describes a struct field as it's encoded by encoding/json.
+type jsonField struct {
+	Name      string
+	Type      reflect.Type
+	OmitEmpty bool
+}
+
+// jsonFields returns the fields of the struct type as they're encoded by
+// encoding/json. Fields of embedded structs are flattened.
+func jsonFields(t reflect.Type) []jsonField {
+	var fields []jsonField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options := tag, ""
+		if comma := strings.Index(tag, ","); comma >= 0 {
+			name, options = tag[:comma], tag[comma+1:]
+		}
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				fields = append(fields, jsonFields(embedded)...)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		fields = append(fields, jsonField{
+			Name:      name,
+			Type:      field.Type,
+			OmitEmpty: strings.Contains(options, "omitempty"),
+		})
+	}
+	return fields
+}
+
+// structTypes returns all the struct types which are used by the endpoints of
+// the API in the order of their first usage. It returns an error when two
+// different types have the same name, because the generated clients and
+// documentation refer to types only by their name.
+func (a *API) structTypes() ([]reflect.Type, error) {
+	var types []reflect.Type
+	names := map[string]reflect.Type{}
+
+	var visit func(t reflect.Type) error
+	visit = func(t reflect.Type) error {
+		switch {
+		case isJSONString(t), isBytes(t):
+			return nil
+		case t.Kind() == reflect.Ptr, t.Kind() == reflect.Slice, t.Kind() == reflect.Array, t.Kind() == reflect.Map:
+			return visit(t.Elem())
+		case t.Kind() != reflect.Struct:
+			return nil
+		}
+
+		if existing, ok := names[t.Name()]; ok {
+			if existing != t {
+				return errs.New("types %s and %s have the same name", existing, t)
+			}
+			return nil
+		}
+		names[t.Name()] = t
+		types = append(types, t)
+
+		for _, field := range jsonFields(t) {
+			if err := visit(field.Type); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, group := range a.EndpointGroups {
+		for _, endpoint := range group.endpoints {
+			for _, param := range endpoint.Params {
+				if err := visit(param.Type); err != nil {
+					return nil, err
+				}
+			}
+			if endpoint.Response != nil {
+				if err := visit(reflect.TypeOf(endpoint.Response)); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	return types, nil
+}
+
+// isBodyParam returns whether the param of the endpoint is sent in the request
+// body. Otherwise it's sent as a route param for PATCH and DELETE requests and
+// as a query param for GET requests, matching the generated handlers.
+func (e *fullEndpoint) isBodyParam(param Param) bool {
+	switch e.Method {
+	case http.MethodPost:
+		return true
+	case http.MethodPatch:
+		return param.Type != typeUUID
+	default:
+		return false
+	}
+}
+
+// isPathParam returns whether the param of the endpoint is a route param.
+func (e *fullEndpoint) isPathParam(param Param) bool {
+	switch e.Method {
+	case http.MethodPatch:
+		return param.Type == typeUUID
+	case http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
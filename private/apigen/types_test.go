@@ -0,0 +1,102 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package apigen
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/memory"
+	"storj.io/common/uuid"
+)
+
+type testInner struct {
+	Size memory.Size `json:"size"`
+}
+
+type testOuter struct {
+	testInner
+	ID      uuid.UUID  `json:"id"`
+	Created time.Time  `json:"createdAt,omitempty"`
+	Inner   *testInner `json:"inner"`
+	Names   []string   `json:"names"`
+	Data    []byte     `json:"data"`
+	Count   *int       `json:"count"`
+	Skipped string     `json:"-"`
+	Untyped time.Duration
+}
+
+func TestJSONFields(t *testing.T) {
+	fields := jsonFields(reflect.TypeOf(testOuter{}))
+
+	var names []string
+	for _, field := range fields {
+		names = append(names, field.Name)
+	}
+	require.Equal(t, []string{"size", "id", "createdAt", "inner", "names", "data", "count", "Untyped"}, names)
+	require.True(t, fields[2].OmitEmpty)
+	require.False(t, fields[1].OmitEmpty)
+}
+
+func TestTypeMapping(t *testing.T) {
+	for _, tt := range []struct {
+		value   interface{}
+		openAPI openAPISchema
+		ts      string
+	}{
+		{uuid.UUID{}, openAPISchema{Type: "string", Format: "uuid"}, "string"},
+		{time.Time{}, openAPISchema{Type: "string", Format: "date-time"}, "string"},
+		{memory.Size(0), openAPISchema{Type: "string"}, "string"},
+		{[]byte{}, openAPISchema{Type: "string", Format: "byte", Nullable: true}, "string"},
+		{int64(0), openAPISchema{Type: "integer", Format: "int64"}, "number"},
+		{new(int), openAPISchema{Type: "integer", Format: "int64", Nullable: true}, "number | null"},
+		{&testInner{}, openAPISchema{Ref: "#/components/schemas/testInner"}, "testInner | null"},
+		{map[string]bool{}, openAPISchema{Type: "object", AdditionalProperties: &openAPISchema{Type: "boolean"}}, "Record<string, boolean>"},
+		{[]*testInner{}, openAPISchema{Type: "array", Items: &openAPISchema{Ref: "#/components/schemas/testInner"}, Nullable: true}, "(testInner | null)[]"},
+	} {
+		typ := reflect.TypeOf(tt.value)
+		require.Equal(t, tt.openAPI, *openAPISchemaOf(typ), typ.String())
+		require.Equal(t, tt.ts, tsTypeOf(typ), typ.String())
+	}
+}
+
+func TestStructTypes(t *testing.T) {
+	a := &API{Version: "v0", PackageName: "test"}
+	g := a.Group("Test", "test")
+	g.Post("/create", &Endpoint{
+		MethodName: "Create",
+		Request:    testOuter{},
+		Response:   &testOuter{},
+		Params:     []Param{NewParam("outer", testOuter{})},
+	})
+
+	types, err := a.structTypes()
+	require.NoError(t, err)
+	require.Equal(t, []reflect.Type{reflect.TypeOf(testOuter{}), reflect.TypeOf(testInner{})}, types)
+}
+
+func TestIntegerTypes(t *testing.T) {
+	type order uint8
+
+	for _, tt := range []struct {
+		value    interface{}
+		integer  bool
+		unsigned bool
+	}{
+		{0, true, false},
+		{int8(0), true, false},
+		{uint(0), true, true},
+		{order(0), true, true},
+		{memory.Size(0), true, false},
+		{"", false, false},
+		{0.5, false, false},
+	} {
+		typ := reflect.TypeOf(tt.value)
+		require.Equal(t, tt.integer, isInteger(typ), typ.String())
+		require.Equal(t, tt.unsigned, isUnsigned(typ), typ.String())
+	}
+}
@@ -0,0 +1,8 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+//go:generate go run gen.go
+
+// Package maintenancepb contains the proto definitions of the planned
+// maintenance windows of storage nodes.
+package maintenancepb
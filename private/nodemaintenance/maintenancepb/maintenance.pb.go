@@ -0,0 +1,226 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: maintenance.proto
+
+package maintenancepb
+
+import (
+	fmt "fmt"
+	math "math"
+
+	proto "github.com/gogo/protobuf/proto"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// NodeMaintenance is a planned maintenance window of a storage node.
+type NodeMaintenance struct {
+	StartUnix            int64    `protobuf:"varint,1,opt,name=start_unix,json=startUnix,proto3" json:"start_unix,omitempty"`
+	EndUnix              int64    `protobuf:"varint,2,opt,name=end_unix,json=endUnix,proto3" json:"end_unix,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NodeMaintenance) Reset()         { *m = NodeMaintenance{} }
+func (m *NodeMaintenance) String() string { return proto.CompactTextString(m) }
+func (*NodeMaintenance) ProtoMessage()    {}
+func (*NodeMaintenance) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6053ae89a3b3f561, []int{0}
+}
+func (m *NodeMaintenance) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeMaintenance.Unmarshal(m, b)
+}
+func (m *NodeMaintenance) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeMaintenance.Marshal(b, m, deterministic)
+}
+func (m *NodeMaintenance) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeMaintenance.Merge(m, src)
+}
+func (m *NodeMaintenance) XXX_Size() int {
+	return xxx_messageInfo_NodeMaintenance.Size(m)
+}
+func (m *NodeMaintenance) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeMaintenance.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeMaintenance proto.InternalMessageInfo
+
+func (m *NodeMaintenance) GetStartUnix() int64 {
+	if m != nil {
+		return m.StartUnix
+	}
+	return 0
+}
+
+func (m *NodeMaintenance) GetEndUnix() int64 {
+	if m != nil {
+		return m.EndUnix
+	}
+	return 0
+}
+
+// NodeMaintenanceStatus is the answer of a satellite to a reported window.
+type NodeMaintenanceStatus struct {
+	Accepted             *NodeMaintenance `protobuf:"bytes,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Message              string           `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *NodeMaintenanceStatus) Reset()         { *m = NodeMaintenanceStatus{} }
+func (m *NodeMaintenanceStatus) String() string { return proto.CompactTextString(m) }
+func (*NodeMaintenanceStatus) ProtoMessage()    {}
+func (*NodeMaintenanceStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6053ae89a3b3f561, []int{1}
+}
+func (m *NodeMaintenanceStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeMaintenanceStatus.Unmarshal(m, b)
+}
+func (m *NodeMaintenanceStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeMaintenanceStatus.Marshal(b, m, deterministic)
+}
+func (m *NodeMaintenanceStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeMaintenanceStatus.Merge(m, src)
+}
+func (m *NodeMaintenanceStatus) XXX_Size() int {
+	return xxx_messageInfo_NodeMaintenanceStatus.Size(m)
+}
+func (m *NodeMaintenanceStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeMaintenanceStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeMaintenanceStatus proto.InternalMessageInfo
+
+func (m *NodeMaintenanceStatus) GetAccepted() *NodeMaintenance {
+	if m != nil {
+		return m.Accepted
+	}
+	return nil
+}
+
+func (m *NodeMaintenanceStatus) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+// CheckInRequestExtension are the fields the storage nodes add to
+// contact.CheckInRequest, until they're part of it in storj.io/common.
+type CheckInRequestExtension struct {
+	Maintenance          *NodeMaintenance `protobuf:"bytes,100,opt,name=maintenance,proto3" json:"maintenance,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *CheckInRequestExtension) Reset()         { *m = CheckInRequestExtension{} }
+func (m *CheckInRequestExtension) String() string { return proto.CompactTextString(m) }
+func (*CheckInRequestExtension) ProtoMessage()    {}
+func (*CheckInRequestExtension) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6053ae89a3b3f561, []int{2}
+}
+func (m *CheckInRequestExtension) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckInRequestExtension.Unmarshal(m, b)
+}
+func (m *CheckInRequestExtension) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckInRequestExtension.Marshal(b, m, deterministic)
+}
+func (m *CheckInRequestExtension) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckInRequestExtension.Merge(m, src)
+}
+func (m *CheckInRequestExtension) XXX_Size() int {
+	return xxx_messageInfo_CheckInRequestExtension.Size(m)
+}
+func (m *CheckInRequestExtension) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckInRequestExtension.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckInRequestExtension proto.InternalMessageInfo
+
+func (m *CheckInRequestExtension) GetMaintenance() *NodeMaintenance {
+	if m != nil {
+		return m.Maintenance
+	}
+	return nil
+}
+
+// CheckInResponseExtension are the fields the satellites add to
+// contact.CheckInResponse, until they're part of it in storj.io/common.
+type CheckInResponseExtension struct {
+	MaintenanceStatus    *NodeMaintenanceStatus `protobuf:"bytes,100,opt,name=maintenance_status,json=maintenanceStatus,proto3" json:"maintenance_status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *CheckInResponseExtension) Reset()         { *m = CheckInResponseExtension{} }
+func (m *CheckInResponseExtension) String() string { return proto.CompactTextString(m) }
+func (*CheckInResponseExtension) ProtoMessage()    {}
+func (*CheckInResponseExtension) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6053ae89a3b3f561, []int{3}
+}
+func (m *CheckInResponseExtension) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckInResponseExtension.Unmarshal(m, b)
+}
+func (m *CheckInResponseExtension) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckInResponseExtension.Marshal(b, m, deterministic)
+}
+func (m *CheckInResponseExtension) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckInResponseExtension.Merge(m, src)
+}
+func (m *CheckInResponseExtension) XXX_Size() int {
+	return xxx_messageInfo_CheckInResponseExtension.Size(m)
+}
+func (m *CheckInResponseExtension) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckInResponseExtension.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckInResponseExtension proto.InternalMessageInfo
+
+func (m *CheckInResponseExtension) GetMaintenanceStatus() *NodeMaintenanceStatus {
+	if m != nil {
+		return m.MaintenanceStatus
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*NodeMaintenance)(nil), "nodemaintenance.NodeMaintenance")
+	proto.RegisterType((*NodeMaintenanceStatus)(nil), "nodemaintenance.NodeMaintenanceStatus")
+	proto.RegisterType((*CheckInRequestExtension)(nil), "nodemaintenance.CheckInRequestExtension")
+	proto.RegisterType((*CheckInResponseExtension)(nil), "nodemaintenance.CheckInResponseExtension")
+}
+
+func init() { proto.RegisterFile("maintenance.proto", fileDescriptor_6053ae89a3b3f561) }
+
+var fileDescriptor_6053ae89a3b3f561 = []byte{
+	// 268 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x91, 0xc1, 0x4b, 0xf3, 0x40,
+	0x10, 0xc5, 0xe9, 0xf7, 0x81, 0x6d, 0xa7, 0x87, 0xe2, 0x82, 0x18, 0x0f, 0x42, 0xc9, 0x41, 0x3c,
+	0x25, 0xa0, 0xe2, 0xc9, 0x53, 0xc5, 0x83, 0x88, 0x1e, 0x56, 0x7a, 0x11, 0xa4, 0x6c, 0xb3, 0x83,
+	0xae, 0xd2, 0xd9, 0x6d, 0x66, 0x22, 0xf9, 0xf3, 0xc5, 0x8d, 0xb6, 0x4b, 0x2e, 0x7a, 0x9b, 0xbc,
+	0x97, 0xf7, 0x7b, 0xbb, 0x3b, 0xb0, 0xbf, 0x36, 0x8e, 0x04, 0xc9, 0x50, 0x85, 0x45, 0xa8, 0xbd,
+	0x78, 0x35, 0x25, 0x6f, 0x31, 0x91, 0xf3, 0x3b, 0x98, 0x3e, 0x78, 0x8b, 0xf7, 0x3b, 0x49, 0x1d,
+	0x03, 0xb0, 0x98, 0x5a, 0x96, 0x0d, 0xb9, 0x36, 0x1b, 0xcc, 0x06, 0xa7, 0xff, 0xf5, 0x38, 0x2a,
+	0x0b, 0x72, 0xad, 0x3a, 0x82, 0x11, 0x92, 0xed, 0xcc, 0x7f, 0xd1, 0x1c, 0x22, 0xd9, 0x2f, 0x2b,
+	0xf7, 0x70, 0xd0, 0x83, 0x3d, 0x8a, 0x91, 0x86, 0xd5, 0x15, 0x8c, 0x4c, 0x55, 0x61, 0x10, 0xb4,
+	0x11, 0x38, 0x39, 0x9b, 0x15, 0xbd, 0x93, 0x14, 0xbd, 0xa4, 0xde, 0x26, 0x54, 0x06, 0xc3, 0x35,
+	0x32, 0x9b, 0x17, 0x8c, 0x85, 0x63, 0xfd, 0xf3, 0x99, 0x3f, 0xc3, 0xe1, 0xf5, 0x2b, 0x56, 0xef,
+	0xb7, 0xa4, 0x71, 0xd3, 0x20, 0xcb, 0x4d, 0x2b, 0x48, 0xec, 0x3c, 0xa9, 0x39, 0x4c, 0x12, 0x7a,
+	0x66, 0xff, 0xd8, 0x9a, 0x86, 0xf2, 0x0d, 0x64, 0x5b, 0x3c, 0x07, 0x4f, 0x8c, 0x3b, 0xfe, 0x02,
+	0x54, 0xf2, 0xeb, 0x92, 0xe3, 0x45, 0xbf, 0x6b, 0x4e, 0x7e, 0xab, 0xe9, 0x9e, 0x45, 0xa7, 0x0b,
+	0xea, 0xa4, 0xf9, 0xe5, 0xd3, 0x05, 0x8b, 0xaf, 0xdf, 0x0a, 0xe7, 0xcb, 0x38, 0x94, 0xa1, 0x76,
+	0x1f, 0x46, 0xb0, 0xec, 0x21, 0xcb, 0x64, 0x0e, 0xab, 0xd5, 0x5e, 0xdc, 0xef, 0xf9, 0x67, 0x00,
+	0x00, 0x00, 0xff, 0xff, 0xe5, 0xc6, 0xc1, 0x2e, 0xf4, 0x01, 0x00, 0x00,
+}
@@ -0,0 +1,150 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package nodemaintenance implements the planned maintenance windows, which
+// storage nodes report to the satellites when checking in.
+//
+// The windows aren't part of the check-in messages in storj.io/common yet, so
+// they're sent as field 100 of the messages, which is defined by the extension
+// messages in maintenancepb. Older peers keep the field as an unknown field.
+package nodemaintenance
+
+import (
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/zeebo/errs"
+
+	"storj.io/common/pb"
+	"storj.io/storj/private/nodemaintenance/maintenancepb"
+)
+
+// Error is the error class for maintenance windows.
+var Error = errs.Class("node maintenance")
+
+// Window is a planned maintenance window of a storage node.
+type Window struct {
+	Start time.Time
+	End   time.Time
+}
+
+// IsZero returns whether no window is set.
+func (window Window) IsZero() bool {
+	return window.Start.IsZero() && window.End.IsZero()
+}
+
+// Duration returns the length of the window.
+func (window Window) Duration() time.Duration {
+	return window.End.Sub(window.Start)
+}
+
+// Equal returns whether both windows start and end at the same time.
+func (window Window) Equal(other Window) bool {
+	return window.Start.Equal(other.Start) && window.End.Equal(other.End)
+}
+
+// Contains returns whether t is within the window.
+func (window Window) Contains(t time.Time) bool {
+	return !t.Before(window.Start) && t.Before(window.End)
+}
+
+// Validate checks whether the window can be reported.
+func (window Window) Validate() error {
+	if window.Start.IsZero() || window.End.IsZero() {
+		return Error.New("start and end must be set")
+	}
+	if !window.End.After(window.Start) {
+		return Error.New("end must be after start")
+	}
+	return nil
+}
+
+// Status is the answer of a satellite to a reported window.
+type Status struct {
+	// Accepted is the window the satellite accepted, which may be shorter
+	// than the reported one. It's zero when the window was rejected.
+	Accepted Window
+	// Message explains why the window was shortened or rejected.
+	Message string
+}
+
+// SetRequest adds the window to a check-in request. A zero window is omitted,
+// which cancels a previously reported window.
+func SetRequest(req *pb.CheckInRequest, window Window) {
+	var ext maintenancepb.CheckInRequestExtension
+	if err := proto.Unmarshal(req.XXX_unrecognized, &ext); err != nil {
+		// malformed unknown fields are dropped.
+		ext = maintenancepb.CheckInRequestExtension{}
+	}
+	ext.Maintenance = nil
+	if !window.IsZero() {
+		ext.Maintenance = encodeWindow(window)
+	}
+	req.XXX_unrecognized = marshal(&ext)
+}
+
+// FromRequest returns the window of a check-in request or a zero window,
+// when the node didn't report one.
+func FromRequest(req *pb.CheckInRequest) (Window, error) {
+	var ext maintenancepb.CheckInRequestExtension
+	if err := proto.Unmarshal(req.XXX_unrecognized, &ext); err != nil {
+		return Window{}, Error.Wrap(err)
+	}
+	return decodeWindow(ext.Maintenance), nil
+}
+
+// SetResponse adds the status of the reported window to a check-in response.
+func SetResponse(resp *pb.CheckInResponse, status Status) {
+	var ext maintenancepb.CheckInResponseExtension
+	if err := proto.Unmarshal(resp.XXX_unrecognized, &ext); err != nil {
+		// malformed unknown fields are dropped.
+		ext = maintenancepb.CheckInResponseExtension{}
+	}
+	// the status is always set, so that the node knows that the satellite
+	// supports maintenance windows.
+	ext.MaintenanceStatus = &maintenancepb.NodeMaintenanceStatus{Message: status.Message}
+	if !status.Accepted.IsZero() {
+		ext.MaintenanceStatus.Accepted = encodeWindow(status.Accepted)
+	}
+	resp.XXX_unrecognized = marshal(&ext)
+}
+
+// FromResponse returns the status of the reported window. ok is false when
+// the satellite doesn't support maintenance windows.
+func FromResponse(resp *pb.CheckInResponse) (status Status, ok bool, err error) {
+	var ext maintenancepb.CheckInResponseExtension
+	if err := proto.Unmarshal(resp.XXX_unrecognized, &ext); err != nil {
+		return Status{}, false, Error.Wrap(err)
+	}
+	if ext.MaintenanceStatus == nil {
+		return Status{}, false, nil
+	}
+	return Status{
+		Accepted: decodeWindow(ext.MaintenanceStatus.Accepted),
+		Message:  ext.MaintenanceStatus.Message,
+	}, true, nil
+}
+
+// marshal encodes the extension together with the unknown fields it kept.
+func marshal(ext proto.Message) []byte {
+	// messages of integers, strings and nested messages can't fail encoding.
+	data, _ := proto.Marshal(ext)
+	return data
+}
+
+func encodeWindow(window Window) *maintenancepb.NodeMaintenance {
+	return &maintenancepb.NodeMaintenance{
+		StartUnix: window.Start.Unix(),
+		EndUnix:   window.End.Unix(),
+	}
+}
+
+func decodeWindow(window *maintenancepb.NodeMaintenance) Window {
+	if window == nil {
+		return Window{}
+	}
+	return Window{
+		Start: time.Unix(window.StartUnix, 0).UTC(),
+		End:   time.Unix(window.EndUnix, 0).UTC(),
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package nodemaintenance_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/pb"
+	"storj.io/storj/private/nodemaintenance"
+	"storj.io/storj/private/nodemaintenance/maintenancepb"
+)
+
+func TestCheckInRoundTrip(t *testing.T) {
+	start := time.Date(2022, 7, 1, 10, 0, 0, 0, time.UTC)
+	window := nodemaintenance.Window{Start: start, End: start.Add(4 * time.Hour)}
+
+	req := &pb.CheckInRequest{Address: "127.0.0.1:28967"}
+	nodemaintenance.SetRequest(req, window)
+	// setting it again replaces the window.
+	nodemaintenance.SetRequest(req, window)
+
+	data, err := proto.Marshal(req)
+	require.NoError(t, err)
+
+	var received pb.CheckInRequest
+	require.NoError(t, proto.Unmarshal(data, &received))
+	require.Equal(t, "127.0.0.1:28967", received.Address)
+
+	got, err := nodemaintenance.FromRequest(&received)
+	require.NoError(t, err)
+	require.Equal(t, window, got)
+
+	// a zero window cancels it.
+	nodemaintenance.SetRequest(req, nodemaintenance.Window{})
+	got, err = nodemaintenance.FromRequest(req)
+	require.NoError(t, err)
+	require.True(t, got.IsZero())
+
+	resp := &pb.CheckInResponse{PingNodeSuccess: true}
+	_, ok, err := nodemaintenance.FromResponse(resp)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	status := nodemaintenance.Status{
+		Accepted: nodemaintenance.Window{Start: start, End: start.Add(time.Hour)},
+		Message:  "shortened to the remaining allowance",
+	}
+	nodemaintenance.SetResponse(resp, status)
+
+	data, err = proto.Marshal(resp)
+	require.NoError(t, err)
+
+	var receivedResp pb.CheckInResponse
+	require.NoError(t, proto.Unmarshal(data, &receivedResp))
+	require.True(t, receivedResp.PingNodeSuccess)
+
+	gotStatus, ok, err := nodemaintenance.FromResponse(&receivedResp)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, status, gotStatus)
+}
+
+func TestCheckInKeepsUnknownFields(t *testing.T) {
+	start := time.Date(2022, 7, 1, 10, 0, 0, 0, time.UTC)
+	window := nodemaintenance.Window{Start: start, End: start.Add(time.Hour)}
+
+	// field 101 with the string "newer", as a newer peer might send it.
+	unknown := []byte{0xaa, 0x06, 0x05, 'n', 'e', 'w', 'e', 'r'}
+
+	req := &pb.CheckInRequest{XXX_unrecognized: append([]byte{}, unknown...)}
+	nodemaintenance.SetRequest(req, window)
+	require.Contains(t, string(req.XXX_unrecognized), string(unknown))
+
+	var ext maintenancepb.CheckInRequestExtension
+	require.NoError(t, proto.Unmarshal(req.XXX_unrecognized, &ext))
+	require.Equal(t, start.Unix(), ext.Maintenance.StartUnix)
+	require.Equal(t, start.Add(time.Hour).Unix(), ext.Maintenance.EndUnix)
+
+	nodemaintenance.SetRequest(req, nodemaintenance.Window{})
+	require.Equal(t, unknown, req.XXX_unrecognized)
+}
+
+func TestWindow(t *testing.T) {
+	start := time.Date(2022, 7, 1, 10, 0, 0, 0, time.UTC)
+	window := nodemaintenance.Window{Start: start, End: start.Add(time.Hour)}
+
+	require.NoError(t, window.Validate())
+	require.Error(t, nodemaintenance.Window{Start: start, End: start}.Validate())
+	require.Error(t, nodemaintenance.Window{End: start}.Validate())
+
+	require.True(t, window.Contains(start))
+	require.True(t, window.Contains(start.Add(59*time.Minute)))
+	require.False(t, window.Contains(start.Add(time.Hour)))
+	require.False(t, window.Contains(start.Add(-time.Second)))
+	require.Equal(t, time.Hour, window.Duration())
+}
@@ -8,6 +8,8 @@ import (
 	"runtime/pprof"
 	"testing"
 
+	"go.uber.org/zap"
+
 	"storj.io/common/grant"
 	"storj.io/common/storj"
 	"storj.io/common/testcontext"
@@ -70,7 +72,41 @@ func Run(t *testing.T, config Config, test func(t *testing.T, ctx *testcontext.C
 	}
 }
 
-func provisionUplinks(ctx context.Context, t *testing.T, planet *Planet) {
+// Bench makes benchmark with testplanet as a context.
+func Bench(b *testing.B, config Config, bench func(b *testing.B, ctx *testcontext.Context, planet *Planet)) {
+	for _, satelliteDB := range satellitedbtest.Databases() {
+		satelliteDB := satelliteDB
+		b.Run(satelliteDB.Name, func(b *testing.B) {
+			if satelliteDB.MasterDB.URL == "" {
+				b.Skipf("Database %s connection string not provided. %s", satelliteDB.MasterDB.Name, satelliteDB.MasterDB.Message)
+			}
+			planetConfig := config
+			if planetConfig.Name == "" {
+				planetConfig.Name = b.Name()
+			}
+
+			timeout := config.Timeout
+			if timeout == 0 {
+				timeout = testcontext.DefaultTimeout
+			}
+			ctx := testcontext.NewWithTimeout(b, timeout)
+			defer ctx.Cleanup()
+
+			planet, err := NewCustom(ctx, zap.NewNop(), planetConfig, satelliteDB)
+			if err != nil {
+				b.Fatalf("%+v", err)
+			}
+			defer ctx.Check(planet.Shutdown)
+
+			planet.Start(ctx)
+			provisionUplinks(ctx, b, planet)
+
+			bench(b, ctx, planet)
+		})
+	}
+}
+
+func provisionUplinks(ctx context.Context, t testing.TB, planet *Planet) {
 	for _, planetUplink := range planet.Uplinks {
 		for _, satellite := range planet.Satellites {
 			apiKey := planetUplink.APIKey[satellite.ID()]
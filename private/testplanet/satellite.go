@@ -122,11 +122,12 @@ type Satellite struct {
 	}
 
 	Audit struct {
-		Queues   *audit.Queues
-		Worker   *audit.Worker
-		Chore    *audit.Chore
-		Verifier *audit.Verifier
-		Reporter audit.Reporter
+		Queues    *audit.Queues
+		Worker    *audit.Worker
+		Chore     *audit.Chore
+		NodeChore *audit.NodeAuditChore
+		Verifier  *audit.Verifier
+		Reporter  audit.Reporter
 	}
 
 	Reputation struct {
@@ -379,6 +380,13 @@ func (planet *Planet) newSatellite(ctx context.Context, prefix string, index int
 	if err != nil {
 		return nil, err
 	}
+	usageExportKeys, err := orders.NewEncryptionKeys(orders.EncryptionKey{
+		ID:  orders.EncryptionKeyID{2},
+		Key: storj.Key{2},
+	})
+	if err != nil {
+		return nil, err
+	}
 
 	var config satellite.Config
 	cfgstruct.Bind(pflag.NewFlagSet("", pflag.PanicOnError), &config,
@@ -445,6 +453,7 @@ func (planet *Planet) newSatellite(ctx context.Context, prefix string, index int
 	config.Metainfo.RS.Success = atLeastOne(planet.config.StorageNodeCount * 3 / 5)
 	config.Metainfo.RS.Total = atLeastOne(planet.config.StorageNodeCount * 4 / 5)
 	config.Orders.EncryptionKeys = *encryptionKeys
+	config.Console.UsageExportKeys = *usageExportKeys
 	config.LiveAccounting.StorageBackend = "redis://" + redis.Addr() + "?db=0"
 	config.Mail.TemplatePath = filepath.Join(developmentRoot, "web/satellite/static/emails")
 	config.Console.StaticDir = filepath.Join(developmentRoot, "web/satellite")
@@ -582,6 +591,7 @@ func createNewSystem(name string, log *zap.Logger, config satellite.Config, peer
 	system.Audit.Queues = peer.Audit.Queues
 	system.Audit.Worker = peer.Audit.Worker
 	system.Audit.Chore = peer.Audit.Chore
+	system.Audit.NodeChore = peer.Audit.NodeChore
 	system.Audit.Verifier = peer.Audit.Verifier
 	system.Audit.Reporter = peer.Audit.Reporter
 
@@ -24,6 +24,7 @@ import (
 	"storj.io/storj/private/revocation"
 	"storj.io/storj/private/server"
 	"storj.io/storj/storage/filestore"
+	"storj.io/storj/storage/packstore"
 	"storj.io/storj/storagenode"
 	"storj.io/storj/storagenode/apikeys"
 	"storj.io/storj/storagenode/bandwidth"
@@ -192,6 +193,7 @@ func (planet *Planet) newStorageNode(ctx context.Context, prefix string, index,
 		},
 		Pieces:    pieces.DefaultConfig,
 		Filestore: filestore.DefaultConfig,
+		Packstore: packstore.DefaultConfig,
 		Retain: retain.Config{
 			MaxTimeSkew: 10 * time.Second,
 			Status:      retain.Enabled,
@@ -89,6 +89,9 @@ type ProjectLimits struct {
 	Usage     *int64
 	Bandwidth *int64
 	Segments  *int64
+
+	BandwidthRate  *int64
+	BandwidthBurst *int64
 }
 
 // ProjectDailyUsage holds project daily usage.
@@ -106,16 +109,16 @@ type ProjectUsageByDay struct {
 
 // BucketUsage consist of total bucket usage for period.
 type BucketUsage struct {
-	ProjectID  uuid.UUID
-	BucketName string
+	ProjectID  uuid.UUID `json:"projectID"`
+	BucketName string    `json:"bucketName"`
 
-	Storage      float64
-	Egress       float64
-	ObjectCount  int64
-	SegmentCount int64
+	Storage      float64 `json:"storage"`
+	Egress       float64 `json:"egress"`
+	ObjectCount  int64   `json:"objectCount"`
+	SegmentCount int64   `json:"segmentCount"`
 
-	Since  time.Time
-	Before time.Time
+	Since  time.Time `json:"since"`
+	Before time.Time `json:"before"`
 }
 
 // BucketUsageCursor holds info for bucket usage
@@ -128,15 +131,19 @@ type BucketUsageCursor struct {
 
 // BucketUsagePage represents bucket usage page result.
 type BucketUsagePage struct {
-	BucketUsages []BucketUsage
+	BucketUsages []BucketUsage `json:"bucketUsages"`
 
-	Search string
-	Limit  uint
-	Offset uint64
+	Search string `json:"search"`
+	Limit  uint   `json:"limit"`
+	Offset uint64 `json:"offset"`
+
+	PageCount   uint   `json:"pageCount"`
+	CurrentPage uint   `json:"currentPage"`
+	TotalCount  uint64 `json:"totalCount"`
 
-	PageCount   uint
-	CurrentPage uint
-	TotalCount  uint64
+	// NextCursor is the opaque cursor of the next page of the console api,
+	// which is empty on the last page.
+	NextCursor string `json:"nextCursor"`
 }
 
 // BucketUsageRollup is total bucket usage info
@@ -222,6 +229,10 @@ type ProjectAccounting interface {
 	UpdateProjectUsageLimit(ctx context.Context, projectID uuid.UUID, limit memory.Size) error
 	// UpdateProjectBandwidthLimit updates project bandwidth limit.
 	UpdateProjectBandwidthLimit(ctx context.Context, projectID uuid.UUID, limit memory.Size) error
+	// UpdateProjectBandwidthRateLimit updates the number of bytes per second a project can allocate.
+	UpdateProjectBandwidthRateLimit(ctx context.Context, projectID uuid.UUID, limit memory.Size) error
+	// UpdateProjectBandwidthBurstLimit updates the number of bytes a project can allocate at once.
+	UpdateProjectBandwidthBurstLimit(ctx context.Context, projectID uuid.UUID, limit memory.Size) error
 	// UpdateProjectSegmentLimit updates project segment limit.
 	UpdateProjectSegmentLimit(ctx context.Context, projectID uuid.UUID, limit int64) error
 	// GetProjectStorageLimit returns project storage usage limit.
@@ -0,0 +1,399 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package forecast projects the free capacity of the storage nodes from their
+// historical ingress.
+package forecast
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+	"storj.io/common/storj/location"
+	"storj.io/storj/satellite/accounting"
+	"storj.io/storj/satellite/overlay"
+)
+
+var (
+	// Error is the default error class for the forecast package.
+	Error = errs.Class("capacity forecast")
+
+	mon = monkit.Package()
+)
+
+const (
+	week = 7 * 24 * time.Hour
+
+	defaultWeeks        = 12
+	defaultHistory      = 4 * week
+	defaultOnlineWindow = 4 * time.Hour
+)
+
+// MaxWeeks is the maximum number of weeks a forecast can project.
+const MaxWeeks = 520
+
+// Config contains configurable values for the capacity forecast.
+type Config struct {
+	Weeks        int           `help:"number of weeks to project the free capacity for, at most 520" default:"12"`
+	History      time.Duration `help:"how much tally and ingress history is used for estimating the growth" default:"672h"`
+	OnlineWindow time.Duration `help:"nodes not contacted within this window are not counted" default:"4h"`
+}
+
+// Kinds of the forecast groups.
+const (
+	KindPlacement = "placement"
+	KindCountry   = "country"
+)
+
+// placements are the placement constraints included in the forecast.
+var placements = []struct {
+	Name       string
+	Constraint storj.PlacementConstraint
+}{
+	{"every-country", storj.EveryCountry},
+	{"eu", storj.EU},
+	{"eea", storj.EEA},
+	{"us", storj.US},
+	{"de", storj.DE},
+}
+
+// Node contains the capacity information of a single node used for the
+// forecast.
+type Node struct {
+	ID          storj.NodeID
+	CountryCode location.CountryCode
+	Vetted      bool
+	FreeDisk    int64
+	// Ingress is the number of bytes uploaded to the node during the history.
+	Ingress int64
+	// Growth is the change of the data stored on the node during GrowthPeriod
+	// as measured by the node tally. Unlike the ingress it includes deletes.
+	Growth int64
+	// GrowthPeriod is zero when there were not enough node tallies, in which
+	// case the ingress is used for projecting the growth.
+	GrowthPeriod time.Duration
+}
+
+// Group is the projected free capacity of the nodes of a placement or a
+// country.
+type Group struct {
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	Vetted bool   `json:"vetted"`
+
+	Nodes         int   `json:"nodes"`
+	FreeDisk      int64 `json:"freeDisk"`
+	WeeklyIngress int64 `json:"weeklyIngress"`
+	// WeeklyGrowth is the projected growth of the stored data, which is the
+	// tallied growth of the nodes or their ingress where tallies are missing.
+	WeeklyGrowth int64 `json:"weeklyGrowth"`
+	// WeeksUntilFull is -1 when the stored data did not grow.
+	WeeksUntilFull float64 `json:"weeksUntilFull"`
+	// Projected contains the free capacity at the end of each week.
+	Projected []int64 `json:"projected"`
+}
+
+// Report is the capacity forecast.
+type Report struct {
+	GeneratedAt time.Time     `json:"generatedAt"`
+	Weeks       int           `json:"weeks"`
+	History     time.Duration `json:"history"`
+	Groups      []Group       `json:"groups"`
+}
+
+// Forecaster creates capacity forecasts.
+//
+// architecture: Service
+type Forecaster struct {
+	log                   *zap.Logger
+	overlay               overlay.DB
+	storagenodeAccounting accounting.StoragenodeAccounting
+
+	nowFn func() time.Time
+}
+
+// NewForecaster creates a new capacity forecaster.
+func NewForecaster(log *zap.Logger, overlay overlay.DB, storagenodeAccounting accounting.StoragenodeAccounting) *Forecaster {
+	return &Forecaster{
+		log:                   log,
+		overlay:               overlay,
+		storagenodeAccounting: storagenodeAccounting,
+		nowFn:                 time.Now,
+	}
+}
+
+// Forecast projects the free capacity of the nodes which are online and not
+// disqualified or exiting. Zero config values are replaced with the defaults.
+//
+// The growth is estimated from the data at rest recorded by the node tally
+// during the history, so that deletes are taken into account. For nodes
+// without at least two tallies the settled PUT and PUT_REPAIR bandwidth is
+// used instead.
+func (forecaster *Forecaster) Forecast(ctx context.Context, config Config) (_ *Report, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if config.Weeks > MaxWeeks {
+		return nil, Error.New("weeks must be at most %d, got %d", MaxWeeks, config.Weeks)
+	}
+	if config.Weeks <= 0 {
+		config.Weeks = defaultWeeks
+	}
+	if config.History <= 0 {
+		config.History = defaultHistory
+	}
+	if config.OnlineWindow <= 0 {
+		config.OnlineWindow = defaultOnlineWindow
+	}
+
+	now := forecaster.nowFn()
+
+	nodes := make(map[storj.NodeID]*Node)
+	err = forecaster.overlay.IterateAllNodeDossiers(ctx, func(ctx context.Context, dossier *overlay.NodeDossier) error {
+		if dossier.Disqualified != nil || dossier.ExitStatus.ExitInitiatedAt != nil {
+			return nil
+		}
+		if dossier.Reputation.LastContactSuccess.Before(now.Add(-config.OnlineWindow)) {
+			return nil
+		}
+		nodes[dossier.Id] = &Node{
+			ID:          dossier.Id,
+			CountryCode: dossier.CountryCode,
+			Vetted:      dossier.Reputation.Status.VettedAt != nil,
+			FreeDisk:    dossier.Capacity.FreeDisk,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	since := now.Add(-config.History)
+	err = forecaster.storagenodeAccounting.GetBandwidthSince(ctx, since, func(ctx context.Context, rollup *accounting.StoragenodeBandwidthRollup) error {
+		if rollup.IntervalStart.Before(since) {
+			return nil
+		}
+		switch pb.PieceAction(rollup.Action) {
+		case pb.PieceAction_PUT, pb.PieceAction_PUT_REPAIR:
+		default:
+			return nil
+		}
+		if node, ok := nodes[rollup.NodeID]; ok {
+			node.Ingress += int64(rollup.Settled)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	tallies, err := forecaster.storagenodeAccounting.GetTalliesSince(ctx, since)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	addTalliedGrowth(nodes, tallies)
+
+	list := make([]Node, 0, len(nodes))
+	for _, node := range nodes {
+		list = append(list, *node)
+	}
+
+	forecaster.log.Debug("capacity forecast created", zap.Int("nodes", len(list)))
+
+	return &Report{
+		GeneratedAt: now,
+		Weeks:       config.Weeks,
+		History:     config.History,
+		Groups:      Project(list, config.Weeks, config.History),
+	}, nil
+}
+
+// addTalliedGrowth sets the growth of the nodes from the node tallies.
+//
+// The node tally records byte-hours since the previous tally, hence the stored
+// bytes are the recorded value divided by the hours since the previous tally.
+// The first tally of the history has no known previous tally and is skipped.
+func addTalliedGrowth(nodes map[storj.NodeID]*Node, tallies []*accounting.StoragenodeStorageTally) {
+	var intervals []time.Time
+	seen := make(map[time.Time]bool)
+	for _, tally := range tallies {
+		if !seen[tally.IntervalEndTime] {
+			seen[tally.IntervalEndTime] = true
+			intervals = append(intervals, tally.IntervalEndTime)
+		}
+	}
+	sort.Slice(intervals, func(i, k int) bool { return intervals[i].Before(intervals[k]) })
+
+	hours := make(map[time.Time]float64, len(intervals))
+	for i := 1; i < len(intervals); i++ {
+		hours[intervals[i]] = intervals[i].Sub(intervals[i-1]).Hours()
+	}
+
+	type sample struct {
+		at     time.Time
+		stored float64
+	}
+	first := make(map[storj.NodeID]sample)
+	last := make(map[storj.NodeID]sample)
+	for _, tally := range tallies {
+		h := hours[tally.IntervalEndTime]
+		if h <= 0 {
+			continue
+		}
+		current := sample{at: tally.IntervalEndTime, stored: tally.DataTotal / h}
+		if s, ok := first[tally.NodeID]; !ok || current.at.Before(s.at) {
+			first[tally.NodeID] = current
+		}
+		if s, ok := last[tally.NodeID]; !ok || current.at.After(s.at) {
+			last[tally.NodeID] = current
+		}
+	}
+
+	for id, node := range nodes {
+		start, end := first[id], last[id]
+		if !end.at.After(start.at) {
+			continue
+		}
+		node.Growth = int64(end.stored - start.stored)
+		node.GrowthPeriod = end.at.Sub(start.at)
+	}
+}
+
+// Project groups the nodes by placement and by country, separating vetted and
+// unvetted nodes, and projects the free capacity of each group for the given
+// number of weeks assuming the growth seen during the history continues.
+func Project(nodes []Node, weeks int, history time.Duration) []Group {
+	type key struct {
+		kind, name string
+		vetted     bool
+	}
+	type sum struct {
+		nodes    int
+		freeDisk int64
+		ingress  int64
+		growth   float64
+	}
+
+	sums := make(map[key]*sum)
+	add := func(k key, node Node) {
+		s, ok := sums[k]
+		if !ok {
+			s = &sum{}
+			sums[k] = s
+		}
+		s.nodes++
+		if node.FreeDisk > 0 {
+			s.freeDisk += node.FreeDisk
+		}
+		s.ingress += node.Ingress
+		switch {
+		case node.GrowthPeriod > 0:
+			s.growth += float64(node.Growth) * float64(week) / float64(node.GrowthPeriod)
+		case history > 0:
+			s.growth += float64(node.Ingress) * float64(week) / float64(history)
+		}
+	}
+
+	for _, node := range nodes {
+		for _, placement := range placements {
+			if placement.Constraint.AllowedCountry(node.CountryCode) {
+				add(key{KindPlacement, placement.Name, node.Vetted}, node)
+			}
+		}
+		country := node.CountryCode.String()
+		if country == "" {
+			country = "unknown"
+		}
+		add(key{KindCountry, country, node.Vetted}, node)
+	}
+
+	groups := make([]Group, 0, len(sums))
+	for k, s := range sums {
+		group := Group{
+			Kind:           k.kind,
+			Name:           k.name,
+			Vetted:         k.vetted,
+			Nodes:          s.nodes,
+			FreeDisk:       s.freeDisk,
+			WeeksUntilFull: -1,
+			Projected:      make([]int64, weeks),
+		}
+		if history > 0 {
+			group.WeeklyIngress = int64(float64(s.ingress) * float64(week) / float64(history))
+		}
+		group.WeeklyGrowth = int64(s.growth)
+		// shrinking groups are projected as if they did not shrink
+		growth := group.WeeklyGrowth
+		if growth < 0 {
+			growth = 0
+		}
+		if growth > 0 {
+			group.WeeksUntilFull = float64(group.FreeDisk) / float64(growth)
+		}
+		for i := range group.Projected {
+			free := group.FreeDisk - growth*int64(i+1)
+			if free < 0 {
+				free = 0
+			}
+			group.Projected[i] = free
+		}
+		groups = append(groups, group)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		a, b := groups[i], groups[j]
+		if a.Kind != b.Kind {
+			return a.Kind == KindPlacement
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Vetted && !b.Vetted
+	})
+
+	return groups
+}
+
+// WriteCSV writes the report as CSV.
+func (report *Report) WriteCSV(output io.Writer) error {
+	w := csv.NewWriter(output)
+
+	headers := []string{"kind", "name", "vetted", "nodes", "freeDisk", "weeklyIngress", "weeklyGrowth", "weeksUntilFull"}
+	for i := 1; i <= report.Weeks; i++ {
+		headers = append(headers, "week"+strconv.Itoa(i))
+	}
+	if err := w.Write(headers); err != nil {
+		return Error.Wrap(err)
+	}
+
+	for _, group := range report.Groups {
+		record := []string{
+			group.Kind,
+			group.Name,
+			strconv.FormatBool(group.Vetted),
+			strconv.Itoa(group.Nodes),
+			strconv.FormatInt(group.FreeDisk, 10),
+			strconv.FormatInt(group.WeeklyIngress, 10),
+			strconv.FormatInt(group.WeeklyGrowth, 10),
+			strconv.FormatFloat(group.WeeksUntilFull, 'f', 1, 64),
+		}
+		for _, free := range group.Projected {
+			record = append(record, strconv.FormatInt(free, 10))
+		}
+		if err := w.Write(record); err != nil {
+			return Error.Wrap(err)
+		}
+	}
+
+	w.Flush()
+	return Error.Wrap(w.Error())
+}
@@ -0,0 +1,95 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package forecast_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/memory"
+	"storj.io/common/storj/location"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite/accounting/forecast"
+)
+
+func TestProject(t *testing.T) {
+	const week = 7 * 24 * time.Hour
+
+	nodes := []forecast.Node{
+		{ID: testrand.NodeID(), CountryCode: location.Germany, Vetted: true, FreeDisk: 10 * memory.TB.Int64(), Ingress: 4 * memory.TB.Int64()},
+		{ID: testrand.NodeID(), CountryCode: location.Germany, Vetted: false, FreeDisk: memory.TB.Int64()},
+		{ID: testrand.NodeID(), CountryCode: location.UnitedStates, Vetted: true, FreeDisk: 2 * memory.TB.Int64(), Ingress: 2 * memory.TB.Int64()},
+		// the tallied growth takes precedence over the ingress
+		{ID: testrand.NodeID(), CountryCode: location.France, Vetted: true, FreeDisk: 3 * memory.TB.Int64(), Ingress: 8 * memory.TB.Int64(), Growth: memory.TB.Int64(), GrowthPeriod: week},
+		{ID: testrand.NodeID(), CountryCode: location.Poland, Vetted: true, FreeDisk: memory.TB.Int64(), Ingress: memory.TB.Int64(), Growth: -memory.TB.Int64(), GrowthPeriod: week},
+	}
+
+	groups := forecast.Project(nodes, 3, 2*week)
+
+	find := func(kind, name string, vetted bool) forecast.Group {
+		for _, group := range groups {
+			if group.Kind == kind && group.Name == name && group.Vetted == vetted {
+				return group
+			}
+		}
+		require.Failf(t, "group not found", "%s %s %v", kind, name, vetted)
+		return forecast.Group{}
+	}
+
+	everywhere := find(forecast.KindPlacement, "every-country", true)
+	require.Equal(t, 4, everywhere.Nodes)
+	require.Equal(t, 16*memory.TB.Int64(), everywhere.FreeDisk)
+	require.Equal(t, 15*memory.TB.Int64()/2, everywhere.WeeklyIngress)
+	require.Equal(t, 3*memory.TB.Int64(), everywhere.WeeklyGrowth)
+
+	de := find(forecast.KindCountry, "DE", true)
+	require.Equal(t, 2*memory.TB.Int64(), de.WeeklyGrowth)
+	require.Equal(t, float64(5), de.WeeksUntilFull)
+	require.Equal(t, []int64{8 * memory.TB.Int64(), 6 * memory.TB.Int64(), 4 * memory.TB.Int64()}, de.Projected)
+
+	fr := find(forecast.KindCountry, "FR", true)
+	require.Equal(t, memory.TB.Int64(), fr.WeeklyGrowth)
+	require.Equal(t, []int64{2 * memory.TB.Int64(), memory.TB.Int64(), 0}, fr.Projected)
+
+	// shrinking groups are not projected to gain free capacity
+	pl := find(forecast.KindCountry, "PL", true)
+	require.Equal(t, -memory.TB.Int64(), pl.WeeklyGrowth)
+	require.Equal(t, float64(-1), pl.WeeksUntilFull)
+	require.Equal(t, []int64{memory.TB.Int64(), memory.TB.Int64(), memory.TB.Int64()}, pl.Projected)
+
+	us := find(forecast.KindCountry, "US", true)
+	require.Equal(t, []int64{memory.TB.Int64(), 0, 0}, us.Projected)
+
+	unvetted := find(forecast.KindCountry, "DE", false)
+	require.Equal(t, 1, unvetted.Nodes)
+	require.Equal(t, float64(-1), unvetted.WeeksUntilFull)
+	require.Equal(t, []int64{memory.TB.Int64(), memory.TB.Int64(), memory.TB.Int64()}, unvetted.Projected)
+
+	// placements are listed before countries
+	require.Equal(t, forecast.KindPlacement, groups[0].Kind)
+	require.Equal(t, forecast.KindCountry, groups[len(groups)-1].Kind)
+
+	report := &forecast.Report{Weeks: 3, History: 2 * week, Groups: groups}
+	var buf bytes.Buffer
+	require.NoError(t, report.WriteCSV(&buf))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, len(groups)+1)
+	require.Equal(t, []string{"kind", "name", "vetted", "nodes", "freeDisk", "weeklyIngress", "weeklyGrowth", "weeksUntilFull", "week1", "week2", "week3"}, records[0])
+}
+
+func TestForecastWeeksLimit(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	forecaster := forecast.NewForecaster(zaptest.NewLogger(t), nil, nil)
+	_, err := forecaster.Forecast(ctx, forecast.Config{Weeks: forecast.MaxWeeks + 1})
+	require.Error(t, err)
+}
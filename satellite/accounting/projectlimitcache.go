@@ -35,6 +35,9 @@ type ProjectLimitDB interface {
 type ProjectLimitConfig struct {
 	CacheCapacity   int           `help:"number of projects to cache." releaseDefault:"10000" devDefault:"100"`
 	CacheExpiration time.Duration `help:"how long to cache the project limits." releaseDefault:"10m" devDefault:"30s"`
+
+	BandwidthRate  memory.Size `help:"the default number of bytes per second a project can allocate for uploads and for downloads, zero means unlimited" default:"0B"`
+	BandwidthBurst memory.Size `help:"the default number of bytes a project can allocate at once, the bandwidth rate is used when zero; larger allocations, such as segment uploads, are rejected" default:"0B"`
 }
 
 // ProjectLimitCache stores the values for both storage usage limit and bandwidth limit for
@@ -45,6 +48,9 @@ type ProjectLimitCache struct {
 	defaultMaxBandwidth memory.Size
 	defaultMaxSegments  int64
 
+	defaultBandwidthRate  memory.Size
+	defaultBandwidthBurst memory.Size
+
 	state *lrucache.ExpiringLRU
 }
 
@@ -55,6 +61,10 @@ func NewProjectLimitCache(db ProjectLimitDB, defaultMaxUsage, defaultMaxBandwidt
 		defaultMaxUsage:     defaultMaxUsage,
 		defaultMaxBandwidth: defaultMaxBandwidth,
 		defaultMaxSegments:  defaultMaxSegments,
+
+		defaultBandwidthRate:  config.BandwidthRate,
+		defaultBandwidthBurst: config.BandwidthBurst,
+
 		state: lrucache.New(lrucache.Options{
 			Capacity:   config.CacheCapacity,
 			Expiration: config.CacheExpiration,
@@ -139,3 +149,27 @@ func (c *ProjectLimitCache) GetProjectSegmentLimit(ctx context.Context, projectI
 
 	return *projectLimits.Segments, nil
 }
+
+// GetProjectBandwidthRateLimit returns the number of bytes per second and the
+// burst a project can allocate for uploads and for downloads. A zero rate
+// means that the project is not rate limited.
+func (c *ProjectLimitCache) GetProjectBandwidthRateLimit(ctx context.Context, projectID uuid.UUID) (rate, burst memory.Size, err error) {
+	defer mon.Task()(&ctx)(&err)
+	projectLimits, err := c.Get(ctx, projectID)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	rate, burst = c.defaultBandwidthRate, c.defaultBandwidthBurst
+	if projectLimits.BandwidthRate != nil {
+		rate = memory.Size(*projectLimits.BandwidthRate)
+	}
+	if projectLimits.BandwidthBurst != nil {
+		burst = memory.Size(*projectLimits.BandwidthBurst)
+	}
+	if burst <= 0 {
+		burst = rate
+	}
+
+	return rate, burst, nil
+}
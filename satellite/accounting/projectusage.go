@@ -218,6 +218,12 @@ func (usage *Service) GetProjectSegmentLimit(ctx context.Context, projectID uuid
 	return usage.projectLimitCache.GetProjectSegmentLimit(ctx, projectID)
 }
 
+// GetProjectBandwidthRateLimit returns current project bandwidth rate and burst limits.
+func (usage *Service) GetProjectBandwidthRateLimit(ctx context.Context, projectID uuid.UUID) (rate, burst memory.Size, err error) {
+	defer mon.Task()(&ctx, projectID)(&err)
+	return usage.projectLimitCache.GetProjectBandwidthRateLimit(ctx, projectID)
+}
+
 // UpdateProjectLimits sets new value for project's bandwidth and storage limit.
 // TODO remove because it's not used.
 func (usage *Service) UpdateProjectLimits(ctx context.Context, projectID uuid.UUID, limit memory.Size) (err error) {
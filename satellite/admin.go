@@ -25,6 +25,7 @@ import (
 	"storj.io/storj/satellite/console/restkeys"
 	"storj.io/storj/satellite/metabase"
 	"storj.io/storj/satellite/payments"
+	"storj.io/storj/satellite/payments/invoicing"
 	"storj.io/storj/satellite/payments/stripecoinpayments"
 )
 
@@ -163,6 +164,27 @@ func NewAdmin(log *zap.Logger, full *identity.FullIdentity, db DB, metabaseDB *m
 
 		peer.Payments.Stripe = stripeClient
 		peer.Payments.Accounts = peer.Payments.Service.Accounts()
+
+		if pc.Provider == "invoicing" {
+			// invoices aren't sent from the admin peer, so it doesn't need a mail service.
+			invoicingService, err := invoicing.NewService(
+				peer.Log.Named("payments.invoicing:service"),
+				pc.Invoicing,
+				peer.DB.Invoicing(),
+				peer.DB.Billing(),
+				peer.DB.Console(),
+				peer.DB.ProjectAccounting(),
+				peer.DB.Wallets(),
+				peer.DB.StorjscanPayments(),
+				nil,
+				pc.StorageTBPrice,
+				pc.EgressTBPrice,
+				pc.SegmentPrice)
+			if err != nil {
+				return nil, errs.Combine(err, peer.Close())
+			}
+			peer.Payments.Accounts = invoicingService.Accounts()
+		}
 	}
 
 	{ // setup admin endpoint
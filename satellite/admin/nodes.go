@@ -0,0 +1,133 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package admin
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"storj.io/common/storj"
+	"storj.io/storj/satellite/accounting/forecast"
+	"storj.io/storj/satellite/audit"
+	"storj.io/storj/satellite/overlay"
+)
+
+func (server *Server) capacityForecast(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var config forecast.Config
+
+	query := r.URL.Query()
+	if weeks := query.Get("weeks"); weeks != "" {
+		value, err := strconv.Atoi(weeks)
+		if err != nil || value <= 0 || value > forecast.MaxWeeks {
+			sendJSONError(w, "invalid weeks",
+				fmt.Sprintf("weeks must be an integer between 1 and %d, got %q", forecast.MaxWeeks, weeks), http.StatusBadRequest)
+			return
+		}
+		config.Weeks = value
+	}
+	if history := query.Get("history"); history != "" {
+		value, err := time.ParseDuration(history)
+		if err != nil || value <= 0 {
+			sendJSONError(w, "invalid history",
+				fmt.Sprintf("history must be a positive duration, got %q", history), http.StatusBadRequest)
+			return
+		}
+		config.History = value
+	}
+
+	format := query.Get("format")
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "csv" {
+		sendJSONError(w, "invalid format",
+			fmt.Sprintf("format must be json or csv, got %q", format), http.StatusBadRequest)
+		return
+	}
+
+	report, err := forecast.NewForecaster(server.log.Named("forecast"), server.db.OverlayCache(), server.db.StoragenodeAccounting()).Forecast(ctx, config)
+	if err != nil {
+		sendJSONError(w, "unable to create capacity forecast",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if format == "csv" {
+		var buf bytes.Buffer
+		if err := report.WriteCSV(&buf); err != nil {
+			sendJSONError(w, "csv encoding failed",
+				err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/csv")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(buf.Bytes())
+		return
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		sendJSONError(w, "json encoding failed",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	sendJSONData(w, http.StatusOK, data)
+}
+
+func (server *Server) auditNode(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	nodeIDString, ok := vars["nodeid"]
+	if !ok {
+		sendJSONError(w, "node-id missing", "", http.StatusBadRequest)
+		return
+	}
+
+	nodeID, err := storj.NodeIDFromString(nodeIDString)
+	if err != nil {
+		sendJSONError(w, "invalid node-id",
+			err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	pieces := audit.DefaultNodeAuditPieces
+	if piecesString := r.URL.Query().Get("pieces"); piecesString != "" {
+		value, err := strconv.Atoi(piecesString)
+		if err != nil || value <= 0 || value > audit.MaxNodeAuditPieces {
+			sendJSONError(w, "invalid pieces",
+				fmt.Sprintf("pieces must be an integer between 1 and %d, got %q", audit.MaxNodeAuditPieces, piecesString), http.StatusBadRequest)
+			return
+		}
+		pieces = value
+	}
+
+	_, err = server.db.OverlayCache().Get(ctx, nodeID)
+	if overlay.ErrNodeNotFound.Has(err) {
+		sendJSONError(w, fmt.Sprintf("node %q does not exist", nodeID),
+			"", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		sendJSONError(w, "failed to get node details",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	err = server.db.NodeAuditQueue().Enqueue(ctx, nodeID, pieces)
+	if err != nil {
+		sendJSONError(w, "failed to queue node audit",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
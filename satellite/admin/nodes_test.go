@@ -0,0 +1,93 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package admin_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/accounting/forecast"
+)
+
+func TestCapacityForecast(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 4,
+		UplinkCount:      0,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(_ *zap.Logger, _ int, config *satellite.Config) {
+				config.Admin.Address = "127.0.0.1:0"
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		address := planet.Satellites[0].Admin.Admin.Listener.Addr()
+		authToken := planet.Satellites[0].Config.Console.AuthToken
+
+		link := "http://" + address.String() + "/api/nodes/capacity-forecast?weeks=2"
+		body := assertReq(ctx, t, link, http.MethodGet, "", http.StatusOK, "", authToken)
+
+		var report forecast.Report
+		require.NoError(t, json.Unmarshal(body, &report))
+		require.Equal(t, 2, report.Weeks)
+		require.NotEmpty(t, report.Groups)
+
+		var nodes int
+		for _, group := range report.Groups {
+			require.Len(t, group.Projected, 2)
+			if group.Kind == forecast.KindPlacement && group.Name == "every-country" {
+				nodes += group.Nodes
+			}
+		}
+		require.Equal(t, len(planet.StorageNodes), nodes)
+
+		link = "http://" + address.String() + "/api/nodes/capacity-forecast?weeks=-1"
+		assertReq(ctx, t, link, http.MethodGet, "", http.StatusBadRequest, "", authToken)
+
+		link = "http://" + address.String() + "/api/nodes/capacity-forecast?weeks=521"
+		assertReq(ctx, t, link, http.MethodGet, "", http.StatusBadRequest, "", authToken)
+	})
+}
+
+func TestAuditNode(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 1,
+		UplinkCount:      0,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(_ *zap.Logger, _ int, config *satellite.Config) {
+				config.Admin.Address = "127.0.0.1:0"
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		sat.Audit.NodeChore.Loop.Pause()
+
+		address := sat.Admin.Admin.Listener.Addr()
+		authToken := sat.Config.Console.AuthToken
+		nodeID := planet.StorageNodes[0].ID()
+
+		link := "http://" + address.String() + "/api/nodes/" + nodeID.String() + "/audit?pieces=20"
+		assertReq(ctx, t, link, http.MethodPost, "", http.StatusOK, "", authToken)
+
+		requests, err := sat.DB.NodeAuditQueue().Select(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, requests, 1)
+		require.Equal(t, nodeID, requests[0].NodeID)
+		require.Equal(t, 20, requests[0].Pieces)
+
+		link = "http://" + address.String() + "/api/nodes/" + nodeID.String() + "/audit?pieces=0"
+		assertReq(ctx, t, link, http.MethodPost, "", http.StatusBadRequest, "", authToken)
+
+		link = "http://" + address.String() + "/api/nodes/" + testrand.NodeID().String() + "/audit"
+		assertReq(ctx, t, link, http.MethodPost, "", http.StatusNotFound, "", authToken)
+	})
+}
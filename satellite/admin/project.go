@@ -130,6 +130,14 @@ func (server *Server) getProjectLimit(w http.ResponseWriter, r *http.Request) {
 		Rate struct {
 			RPS int `json:"rps"`
 		} `json:"rate"`
+		BandwidthRate struct {
+			Amount memory.Size `json:"amount"`
+			Bytes  int64       `json:"bytes"`
+		} `json:"bandwidthRate"`
+		BandwidthBurst struct {
+			Amount memory.Size `json:"amount"`
+			Bytes  int64       `json:"bytes"`
+		} `json:"bandwidthBurst"`
 		Buckets  int   `json:"maxBuckets"`
 		Segments int64 `json:"maxSegments"`
 	}
@@ -151,6 +159,21 @@ func (server *Server) getProjectLimit(w http.ResponseWriter, r *http.Request) {
 		output.Segments = *project.SegmentLimit
 	}
 
+	limits, err := server.db.ProjectAccounting().GetProjectLimits(ctx, projectUUID)
+	if err != nil {
+		sendJSONError(w, "failed to get project limits",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if limits.BandwidthRate != nil {
+		output.BandwidthRate.Amount = memory.Size(*limits.BandwidthRate)
+		output.BandwidthRate.Bytes = *limits.BandwidthRate
+	}
+	if limits.BandwidthBurst != nil {
+		output.BandwidthBurst.Amount = memory.Size(*limits.BandwidthBurst)
+		output.BandwidthBurst.Bytes = *limits.BandwidthBurst
+	}
+
 	data, err := json.Marshal(output)
 	if err != nil {
 		sendJSONError(w, "json encoding failed",
@@ -186,6 +209,9 @@ func (server *Server) putProjectLimit(w http.ResponseWriter, r *http.Request) {
 		Burst     *int         `schema:"burst"`
 		Buckets   *int         `schema:"buckets"`
 		Segments  *int64       `schema:"segments"`
+
+		BandwidthRate  *memory.Size `schema:"bandwidthRate"`
+		BandwidthBurst *memory.Size `schema:"bandwidthBurst"`
 	}
 
 	if err := r.ParseForm(); err != nil {
@@ -304,6 +330,36 @@ func (server *Server) putProjectLimit(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	}
+
+	if arguments.BandwidthRate != nil {
+		if *arguments.BandwidthRate < 0 {
+			sendJSONError(w, "negative bandwidth rate",
+				fmt.Sprintf("%v", arguments.BandwidthRate), http.StatusBadRequest)
+			return
+		}
+
+		err = server.db.ProjectAccounting().UpdateProjectBandwidthRateLimit(ctx, projectUUID, *arguments.BandwidthRate)
+		if err != nil {
+			sendJSONError(w, "failed to update bandwidth rate limit",
+				err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if arguments.BandwidthBurst != nil {
+		if *arguments.BandwidthBurst < 0 {
+			sendJSONError(w, "negative bandwidth burst",
+				fmt.Sprintf("%v", arguments.BandwidthBurst), http.StatusBadRequest)
+			return
+		}
+
+		err = server.db.ProjectAccounting().UpdateProjectBandwidthBurstLimit(ctx, projectUUID, *arguments.BandwidthBurst)
+		if err != nil {
+			sendJSONError(w, "failed to update bandwidth burst limit",
+				err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
 }
 
 func (server *Server) addProject(w http.ResponseWriter, r *http.Request) {
@@ -10,11 +10,13 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
 	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
 	"storj.io/storj/private/testplanet"
 	"storj.io/storj/satellite"
 	"storj.io/storj/satellite/console"
@@ -260,6 +262,40 @@ func TestDisableMFA(t *testing.T) {
 	})
 }
 
+func TestDeleteUserSessions(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 0,
+		UplinkCount:      1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(_ *zap.Logger, _ int, config *satellite.Config) {
+				config.Admin.Address = "127.0.0.1:0"
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		address := planet.Satellites[0].Admin.Admin.Listener.Addr()
+		sessions := planet.Satellites[0].DB.Console().WebappSessions()
+		user, err := planet.Satellites[0].DB.Console().Users().GetByEmail(ctx, planet.Uplinks[0].Projects[0].Owner.Email)
+		require.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			_, err = sessions.Create(ctx, testrand.UUID(), user.ID, "127.0.0.1", "test_user_agent", time.Now().Add(time.Hour))
+			require.NoError(t, err)
+		}
+
+		link := fmt.Sprintf("http://"+address.String()+"/api/users/%s/sessions", user.Email)
+		body := assertReq(ctx, t, link, http.MethodDelete, "", http.StatusOK, "", planet.Satellites[0].Config.Console.AuthToken)
+		require.Len(t, body, 0)
+
+		userSessions, err := sessions.GetAllByUserID(ctx, user.ID)
+		require.NoError(t, err)
+		require.Empty(t, userSessions)
+
+		link = "http://" + address.String() + "/api/users/unknown@storj.test/sessions"
+		assertReq(ctx, t, link, http.MethodDelete, "", http.StatusNotFound, "", planet.Satellites[0].Config.Console.AuthToken)
+	})
+}
+
 func TestUserDelete(t *testing.T) {
 	testplanet.Run(t, testplanet.Config{
 		SatelliteCount:   1,
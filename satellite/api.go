@@ -34,6 +34,7 @@ import (
 	"storj.io/storj/satellite/console/consoleauth"
 	"storj.io/storj/satellite/console/consoleweb"
 	"storj.io/storj/satellite/console/restkeys"
+	"storj.io/storj/satellite/console/sso"
 	"storj.io/storj/satellite/contact"
 	"storj.io/storj/satellite/gracefulexit"
 	"storj.io/storj/satellite/inspector"
@@ -47,6 +48,7 @@ import (
 	"storj.io/storj/satellite/orders"
 	"storj.io/storj/satellite/overlay"
 	"storj.io/storj/satellite/payments"
+	"storj.io/storj/satellite/payments/invoicing"
 	"storj.io/storj/satellite/payments/paymentsconfig"
 	"storj.io/storj/satellite/payments/storjscan"
 	"storj.io/storj/satellite/payments/stripecoinpayments"
@@ -137,6 +139,8 @@ type API struct {
 		Conversion    *stripecoinpayments.ConversionService
 		StripeService *stripecoinpayments.Service
 		StripeClient  stripecoinpayments.StripeClient
+
+		InvoicingService *invoicing.Service
 	}
 
 	REST struct {
@@ -148,6 +152,7 @@ type API struct {
 		Service    *console.Service
 		Endpoint   *consoleweb.Server
 		AuthTokens *consoleauth.Service
+		SSO        *sso.Service
 	}
 
 	Marketing struct {
@@ -366,6 +371,7 @@ func NewAPI(log *zap.Logger, full *identity.FullIdentity, db DB,
 			peer.Overlay.Service,
 			peer.Orders.DB,
 			peer.Buckets.Service,
+			peer.ProjectLimits.Cache,
 			config.Orders,
 		)
 		if err != nil {
@@ -465,7 +471,7 @@ func NewAPI(log *zap.Logger, full *identity.FullIdentity, db DB,
 	}
 
 	{ // setup mailservice
-		peer.Mail.Service, err = setupMailService(peer.Log, *config)
+		peer.Mail.Service, err = SetupMailService(peer.Log, *config)
 		if err != nil {
 			return nil, errs.Combine(err, peer.Close())
 		}
@@ -509,6 +515,26 @@ func NewAPI(log *zap.Logger, full *identity.FullIdentity, db DB,
 
 		peer.Payments.StripeClient = stripeClient
 		peer.Payments.Accounts = peer.Payments.StripeService.Accounts()
+
+		if pc.Provider == "invoicing" {
+			peer.Payments.InvoicingService, err = invoicing.NewService(
+				peer.Log.Named("payments.invoicing:service"),
+				pc.Invoicing,
+				peer.DB.Invoicing(),
+				peer.DB.Billing(),
+				peer.DB.Console(),
+				peer.DB.ProjectAccounting(),
+				peer.DB.Wallets(),
+				peer.DB.StorjscanPayments(),
+				peer.Mail.Service,
+				pc.StorageTBPrice,
+				pc.EgressTBPrice,
+				pc.SegmentPrice)
+			if err != nil {
+				return nil, errs.Combine(err, peer.Close())
+			}
+			peer.Payments.Accounts = peer.Payments.InvoicingService.Accounts()
+		}
 		peer.Payments.Conversion = stripecoinpayments.NewConversionService(
 			peer.Log.Named("payments.stripe:version"),
 			peer.Payments.StripeService,
@@ -547,11 +573,13 @@ func NewAPI(log *zap.Logger, full *identity.FullIdentity, db DB,
 		}
 
 		peer.Console.AuthTokens = consoleauth.NewService(config.ConsoleAuth, &consoleauth.Hmac{Secret: []byte(consoleConfig.AuthTokenSecret)})
+		peer.Console.SSO = sso.NewService(peer.Log.Named("console:sso"), consoleConfig.SSO, &consoleauth.Hmac{Secret: []byte(consoleConfig.AuthTokenSecret)})
 
 		peer.Console.Service, err = console.NewService(
 			peer.Log.Named("console:service"),
 			peer.DB.Console(),
 			peer.REST.Keys,
+			peer.DB.Revocation(),
 			peer.DB.ProjectAccounting(),
 			peer.Accounting.ProjectUsage,
 			peer.Buckets.Service,
@@ -577,6 +605,7 @@ func NewAPI(log *zap.Logger, full *identity.FullIdentity, db DB,
 			consoleConfig,
 			peer.Console.Service,
 			peer.OIDC.Service,
+			peer.Console.SSO,
 			peer.Mail.Service,
 			peer.Marketing.PartnersService,
 			peer.Analytics.Service,
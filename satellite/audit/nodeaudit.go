@@ -0,0 +1,168 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package audit
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+	"storj.io/common/sync2"
+	"storj.io/storj/satellite/metabase/segmentloop"
+)
+
+var _ segmentloop.Observer = (*NodeCollector)(nil)
+
+// NodeCollector uses the segment loop to sample segments with pieces on a
+// given set of nodes.
+type NodeCollector struct {
+	Segments map[storj.NodeID][]Segment
+
+	counts map[storj.NodeID]int
+	seen   map[storj.NodeID]int64
+	rand   *rand.Rand
+}
+
+// NewNodeCollector instantiates a collector which samples uniformly at most
+// the requested number of segments for each of the nodes.
+func NewNodeCollector(requests []NodeAuditRequest, r *rand.Rand) *NodeCollector {
+	collector := &NodeCollector{
+		Segments: make(map[storj.NodeID][]Segment, len(requests)),
+		counts:   make(map[storj.NodeID]int, len(requests)),
+		seen:     make(map[storj.NodeID]int64, len(requests)),
+		rand:     r,
+	}
+	for _, request := range requests {
+		collector.Segments[request.NodeID] = nil
+		collector.counts[request.NodeID] = request.Pieces
+	}
+	return collector
+}
+
+// LoopStarted is called at each start of a loop.
+func (collector *NodeCollector) LoopStarted(context.Context, segmentloop.LoopInfo) (err error) {
+	return nil
+}
+
+// RemoteSegment samples the segment for the requested nodes holding a piece of it.
+func (collector *NodeCollector) RemoteSegment(ctx context.Context, segment *segmentloop.Segment) error {
+	for _, piece := range segment.Pieces {
+		segments, ok := collector.Segments[piece.StorageNode]
+		if !ok {
+			continue
+		}
+
+		seen := collector.seen[piece.StorageNode]
+		collector.seen[piece.StorageNode] = seen + 1
+
+		count := collector.counts[piece.StorageNode]
+		if len(segments) < count {
+			collector.Segments[piece.StorageNode] = append(segments, NewSegment(segment))
+			continue
+		}
+		if index := collector.rand.Int63n(seen + 1); index < int64(count) {
+			segments[index] = NewSegment(segment)
+		}
+	}
+	return nil
+}
+
+// InlineSegment returns nil because inline segments are not stored on nodes.
+func (collector *NodeCollector) InlineSegment(ctx context.Context, segment *segmentloop.Segment) (err error) {
+	return nil
+}
+
+// NodeAuditResult contains the outcome of auditing the pieces of a single node.
+type NodeAuditResult struct {
+	NodeID    storj.NodeID
+	Segments  int
+	Successes int
+	Fails     int
+	Offlines  int
+	Unknown   int
+}
+
+// NodeAuditor audits whole pieces held by specific nodes, for investigating
+// nodes suspected of losing data without auditing every node of a segment.
+//
+// architecture: Service
+type NodeAuditor struct {
+	log         *zap.Logger
+	segmentLoop *segmentloop.Service
+	verifier    *Verifier
+	reporter    Reporter
+	concurrency int
+}
+
+// NewNodeAuditor instantiates NodeAuditor.
+func NewNodeAuditor(log *zap.Logger, segmentLoop *segmentloop.Service, verifier *Verifier, reporter Reporter, concurrency int) *NodeAuditor {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &NodeAuditor{
+		log:         log,
+		segmentLoop: segmentLoop,
+		verifier:    verifier,
+		reporter:    reporter,
+		concurrency: concurrency,
+	}
+}
+
+// AuditNodes samples up to the requested number of segments for each of the
+// nodes using the segment loop, verifies the pieces the nodes hold, and records
+// the outcomes like regular audits.
+func (auditor *NodeAuditor) AuditNodes(ctx context.Context, requests []NodeAuditRequest) (_ []NodeAuditResult, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	collector := NewNodeCollector(requests, rand.New(rand.NewSource(time.Now().UnixNano())))
+	if err := auditor.segmentLoop.Join(ctx, collector); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	results := make([]NodeAuditResult, len(requests))
+	var mu sync.Mutex
+
+	limiter := sync2.NewLimiter(auditor.concurrency)
+	for i, request := range requests {
+		nodeID := request.NodeID
+		results[i] = NodeAuditResult{
+			NodeID:   nodeID,
+			Segments: len(collector.Segments[nodeID]),
+		}
+
+		result := &results[i]
+		for _, segment := range collector.Segments[nodeID] {
+			nodeID, segment := nodeID, segment
+			limiter.Go(ctx, func() {
+				report, err := auditor.verifier.VerifyPiece(ctx, segment, nodeID)
+				if err != nil {
+					auditor.log.Error("failed to verify piece",
+						zap.Stringer("Node ID", nodeID),
+						zap.String("Segment", segmentInfoString(segment)),
+						zap.Error(err))
+				}
+
+				if _, err := auditor.reporter.RecordAudits(ctx, report); err != nil {
+					auditor.log.Error("failed to record piece audit",
+						zap.Stringer("Node ID", nodeID),
+						zap.Error(err))
+				}
+
+				mu.Lock()
+				defer mu.Unlock()
+				result.Successes += len(report.Successes)
+				result.Fails += len(report.Fails)
+				result.Offlines += len(report.Offlines)
+				result.Unknown += len(report.Unknown)
+			})
+		}
+	}
+	limiter.Wait()
+
+	return results, ctx.Err()
+}
@@ -0,0 +1,110 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package audit_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/memory"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite/audit"
+)
+
+func TestNodeAuditor(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 4, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		audits := satellite.Audit
+
+		audits.Worker.Loop.Pause()
+		audits.Chore.Loop.Pause()
+		audits.NodeChore.Loop.Pause()
+
+		ul := planet.Uplinks[0]
+		testData := testrand.Bytes(8 * memory.KiB)
+
+		err := ul.Upload(ctx, satellite, "testbucket", "test/path", testData)
+		require.NoError(t, err)
+
+		segments, err := satellite.Metabase.DB.TestingAllSegments(ctx)
+		require.NoError(t, err)
+		require.Len(t, segments, 1)
+		segment := segments[0]
+
+		// delete the piece from the first node
+		piece := segment.Pieces[0]
+		pieceID := segment.RootPieceID.Derive(piece.StorageNode, int32(piece.Number))
+		node := planet.FindNode(piece.StorageNode)
+		err = node.Storage2.Store.Delete(ctx, satellite.ID(), pieceID)
+		require.NoError(t, err)
+
+		healthy := segment.Pieces[1].StorageNode
+
+		auditor := audit.NewNodeAuditor(zaptest.NewLogger(t), satellite.Metabase.SegmentLoop, audits.Verifier, audits.Reporter, 2)
+		results, err := auditor.AuditNodes(ctx, []audit.NodeAuditRequest{
+			{NodeID: piece.StorageNode, Pieces: 5},
+			{NodeID: healthy, Pieces: 5},
+		})
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+
+		require.Equal(t, audit.NodeAuditResult{NodeID: piece.StorageNode, Segments: 1, Fails: 1}, results[0])
+		require.Equal(t, audit.NodeAuditResult{NodeID: healthy, Segments: 1, Successes: 1}, results[1])
+
+		// the failure must be recorded like a regular audit failure
+		reputationInfo, err := satellite.Reputation.Service.Get(ctx, piece.StorageNode)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, reputationInfo.TotalAuditCount)
+		require.Zero(t, reputationInfo.AuditSuccessCount)
+	})
+}
+
+func TestNodeAuditChore(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 4, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		audits := satellite.Audit
+
+		audits.Worker.Loop.Pause()
+		audits.Chore.Loop.Pause()
+		audits.NodeChore.Loop.Pause()
+
+		err := planet.Uplinks[0].Upload(ctx, satellite, "testbucket", "test/path", testrand.Bytes(8*memory.KiB))
+		require.NoError(t, err)
+
+		segments, err := satellite.Metabase.DB.TestingAllSegments(ctx)
+		require.NoError(t, err)
+		require.Len(t, segments, 1)
+		nodeID := segments[0].Pieces[0].StorageNode
+
+		queue := satellite.DB.NodeAuditQueue()
+		require.NoError(t, queue.Enqueue(ctx, nodeID, 3))
+		// requeueing replaces the pending request
+		require.NoError(t, queue.Enqueue(ctx, nodeID, 5))
+
+		requests, err := queue.Select(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, requests, 1)
+		require.Equal(t, nodeID, requests[0].NodeID)
+		require.Equal(t, 5, requests[0].Pieces)
+
+		audits.NodeChore.Loop.TriggerWait()
+
+		requests, err = queue.Select(ctx, 10)
+		require.NoError(t, err)
+		require.Empty(t, requests)
+
+		reputationInfo, err := satellite.Reputation.Service.Get(ctx, nodeID)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, reputationInfo.TotalAuditCount)
+		require.EqualValues(t, 1, reputationInfo.AuditSuccessCount)
+	})
+}
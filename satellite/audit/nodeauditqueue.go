@@ -0,0 +1,124 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package audit
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/errs2"
+	"storj.io/common/storj"
+	"storj.io/common/sync2"
+)
+
+const (
+	// DefaultNodeAuditPieces is the default number of pieces verified by a
+	// piece-level audit of a node.
+	DefaultNodeAuditPieces = 10
+	// MaxNodeAuditPieces is the maximum number of pieces verified by a
+	// piece-level audit of a node.
+	MaxNodeAuditPieces = 1000
+)
+
+// NodeAuditRequest is a piece-level audit of a node waiting in the queue.
+type NodeAuditRequest struct {
+	NodeID      storj.NodeID
+	Pieces      int
+	RequestedAt time.Time
+}
+
+// NodeAuditQueue stores the nodes queued for piece-level audits.
+//
+// architecture: Database
+type NodeAuditQueue interface {
+	// Enqueue queues the node for auditing the given number of pieces. A node
+	// already in the queue is requeued with the new number of pieces.
+	Enqueue(ctx context.Context, nodeID storj.NodeID, pieces int) error
+	// Select returns at most limit requests, the oldest first.
+	Select(ctx context.Context, limit int) ([]NodeAuditRequest, error)
+	// Delete removes the request from the queue, unless the node was requeued
+	// since the request was selected.
+	Delete(ctx context.Context, request NodeAuditRequest) error
+}
+
+// NodeAuditChore drains the queue of piece-level node audits.
+//
+// architecture: Chore
+type NodeAuditChore struct {
+	log     *zap.Logger
+	queue   NodeAuditQueue
+	auditor *NodeAuditor
+	Loop    *sync2.Cycle
+
+	batchSize int
+}
+
+// NewNodeAuditChore instantiates NodeAuditChore.
+func NewNodeAuditChore(log *zap.Logger, queue NodeAuditQueue, auditor *NodeAuditor, config Config) *NodeAuditChore {
+	return &NodeAuditChore{
+		log:     log,
+		queue:   queue,
+		auditor: auditor,
+		Loop:    sync2.NewCycle(config.NodeAuditInterval),
+
+		batchSize: config.NodeAuditBatchSize,
+	}
+}
+
+// Run starts the chore.
+func (chore *NodeAuditChore) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return chore.Loop.Run(ctx, func(ctx context.Context) error {
+		err := chore.RunOnce(ctx)
+		if err != nil {
+			if errs2.IsCanceled(err) {
+				return err
+			}
+			chore.log.Error("piece-level node audits failed", zap.Error(err))
+		}
+		return nil
+	})
+}
+
+// RunOnce audits a batch of the queued nodes within a single pass of the
+// segment loop and removes them from the queue.
+func (chore *NodeAuditChore) RunOnce(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	requests, err := chore.queue.Select(ctx, chore.batchSize)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	if len(requests) == 0 {
+		return nil
+	}
+
+	results, err := chore.auditor.AuditNodes(ctx, requests)
+	if err != nil {
+		return err
+	}
+
+	for i, result := range results {
+		chore.log.Info("piece-level node audit completed",
+			zap.Stringer("Node ID", result.NodeID),
+			zap.Int("Segments", result.Segments),
+			zap.Int("Successes", result.Successes),
+			zap.Int("Fails", result.Fails),
+			zap.Int("Offlines", result.Offlines),
+			zap.Int("Unknown", result.Unknown))
+
+		if err := chore.queue.Delete(ctx, requests[i]); err != nil {
+			return Error.Wrap(err)
+		}
+	}
+	return nil
+}
+
+// Close halts the chore.
+func (chore *NodeAuditChore) Close() error {
+	chore.Loop.Close()
+	return nil
+}
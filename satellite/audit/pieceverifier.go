@@ -0,0 +1,218 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/pb"
+	"storj.io/common/pkcrypto"
+	"storj.io/common/signing"
+	"storj.io/common/storj"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/overlay"
+	"storj.io/uplink/private/eestream"
+	"storj.io/uplink/private/piecestore"
+)
+
+// ErrPieceHashMismatch is the errs class for when a downloaded piece does not match its signed hash.
+var ErrPieceHashMismatch = errs.Class("piece hash mismatch")
+
+// VerifyPiece downloads the whole piece of the segment stored by the node and
+// verifies it against the piece hash signed by the uplink.
+//
+// Unlike Verify, which downloads a single stripe from all the nodes storing the
+// segment, VerifyPiece only contacts the given node. Nodes which time out
+// while sending the piece are reported as unknown, because there is no stripe
+// which could be used for reverifying them later.
+func (verifier *Verifier) VerifyPiece(ctx context.Context, segment Segment, nodeID storj.NodeID) (report Report, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if segment.Expired(verifier.nowFn()) {
+		verifier.log.Debug("segment expired before VerifyPiece")
+		return Report{}, nil
+	}
+
+	segmentInfo, err := verifier.metabase.GetSegmentByPosition(ctx, metabase.GetSegmentByPosition{
+		StreamID: segment.StreamID,
+		Position: segment.Position,
+	})
+	if err != nil {
+		if metabase.ErrSegmentNotFound.Has(err) {
+			verifier.log.Debug("segment deleted before VerifyPiece")
+			return Report{}, nil
+		}
+		return Report{}, err
+	}
+
+	pieceNum, found := -1, false
+	for _, piece := range segmentInfo.Pieces {
+		if piece.StorageNode == nodeID {
+			pieceNum, found = int(piece.Number), true
+			break
+		}
+	}
+	if !found {
+		verifier.log.Debug("node does not hold a piece of the segment anymore",
+			zap.Stringer("Node ID", nodeID),
+			zap.String("Segment", segmentInfoString(segment)))
+		return Report{}, nil
+	}
+
+	redundancy, err := eestream.NewRedundancyStrategyFromStorj(segmentInfo.Redundancy)
+	if err != nil {
+		return Report{}, Error.Wrap(err)
+	}
+	pieceSize := eestream.CalcPieceSize(int64(segmentInfo.EncryptedSize), redundancy)
+
+	limit, privateKey, nodeInfo, err := verifier.orders.CreateAuditPieceOrderLimit(ctx, nodeID, uint16(pieceNum), segmentInfo.RootPieceID, pieceSize)
+	if err != nil {
+		if overlay.ErrNodeDisqualified.Has(err) || overlay.ErrNodeFinishedGE.Has(err) {
+			verifier.log.Debug("VerifyPiece: node is disqualified or has exited", zap.Stringer("Node ID", nodeID))
+			return Report{}, nil
+		}
+		if overlay.ErrNodeOffline.Has(err) {
+			return Report{
+				Offlines:        storj.NodeIDList{nodeID},
+				NodesReputation: map[storj.NodeID]overlay.ReputationStatus{nodeID: nodeInfo.Reputation},
+			}, nil
+		}
+		return Report{}, err
+	}
+	report.NodesReputation = map[storj.NodeID]overlay.ReputationStatus{nodeID: nodeInfo.Reputation}
+
+	downloadErr := verifier.downloadAndVerifyPiece(ctx, limit, privateKey, nodeInfo.LastIPPort, pieceSize)
+
+	err = verifier.checkIfSegmentAltered(ctx, segmentInfo)
+	if err != nil {
+		if ErrSegmentDeleted.Has(err) {
+			verifier.log.Debug("segment deleted during VerifyPiece")
+			return Report{}, nil
+		}
+		if ErrSegmentModified.Has(err) {
+			verifier.log.Debug("segment modified during VerifyPiece")
+			return Report{}, nil
+		}
+		return Report{}, err
+	}
+
+	log := verifier.log.With(
+		zap.Stringer("Node ID", nodeID),
+		zap.String("Segment", segmentInfoString(segment)),
+		zap.Error(downloadErr))
+
+	if ErrPieceHashMismatch.Has(downloadErr) {
+		log.Info("VerifyPiece: piece data altered (audit failed)")
+		report.Fails = storj.NodeIDList{nodeID}
+		return report, nil
+	}
+
+	switch PieceAuditFromErr(downloadErr) {
+	case PieceAuditSuccess:
+		report.Successes = storj.NodeIDList{nodeID}
+	case PieceAuditFailure:
+		log.Info("VerifyPiece: piece not found (audit failed)")
+		report.Fails = storj.NodeIDList{nodeID}
+	case PieceAuditOffline:
+		log.Debug("VerifyPiece: dial failed (offline)")
+		report.Offlines = storj.NodeIDList{nodeID}
+	case PieceAuditContained:
+		log.Info("VerifyPiece: download timeout (skipped)")
+		report.Unknown = storj.NodeIDList{nodeID}
+	default:
+		log.Info("VerifyPiece: unknown error (skipped)")
+		report.Unknown = storj.NodeIDList{nodeID}
+	}
+	return report, nil
+}
+
+// downloadAndVerifyPiece downloads the whole piece and verifies the original
+// order limit and the piece hash sent by the node.
+func (verifier *Verifier) downloadAndVerifyPiece(ctx context.Context, limit *pb.AddressedOrderLimit, privateKey storj.PiecePrivateKey, cachedIPAndPort string, pieceSize int64) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	// determines number of seconds allotted for receiving data from a storage node
+	timedCtx := ctx
+	if verifier.minBytesPerSecond > 0 {
+		maxTransferTime := time.Duration(int64(time.Second) * pieceSize / verifier.minBytesPerSecond.Int64())
+		if maxTransferTime < verifier.minDownloadTimeout {
+			maxTransferTime = verifier.minDownloadTimeout
+		}
+		var cancel func()
+		timedCtx, cancel = context.WithTimeout(ctx, maxTransferTime)
+		defer cancel()
+	}
+
+	targetNodeID := limit.GetLimit().StorageNodeId
+	var ps *piecestore.Client
+
+	// if cached IP is given, try connecting there first
+	if cachedIPAndPort != "" {
+		ps, err = piecestore.Dial(timedCtx, verifier.dialer, storj.NodeURL{
+			ID:      targetNodeID,
+			Address: cachedIPAndPort,
+		}, piecestore.DefaultConfig)
+		if err != nil {
+			verifier.log.Debug("failed to connect to audit target node at cached IP",
+				zap.Stringer("Node ID", targetNodeID),
+				zap.String("cached-ip-and-port", cachedIPAndPort),
+				zap.Error(err))
+		}
+	}
+
+	// if no cached IP was given, or connecting to cached IP failed, use node address
+	if ps == nil {
+		ps, err = piecestore.Dial(timedCtx, verifier.dialer, storj.NodeURL{
+			ID:      targetNodeID,
+			Address: limit.GetStorageNodeAddress().Address,
+		}, piecestore.DefaultConfig)
+		if err != nil {
+			return Error.Wrap(err)
+		}
+	}
+	defer func() { err = errs.Combine(err, ps.Close()) }()
+
+	downloader, err := ps.Download(timedCtx, limit.GetLimit(), privateKey, 0, pieceSize)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, downloader.Close()) }()
+
+	hashWriter := pkcrypto.NewHash()
+	downloaded, err := io.Copy(hashWriter, downloader)
+	if err != nil {
+		return err
+	}
+	mon.Meter("audit_piece_bytes_downloaded").Mark64(downloaded)
+
+	if downloaded != pieceSize {
+		return ErrPieceHashMismatch.New("downloaded %d bytes, expected %d", downloaded, pieceSize)
+	}
+
+	hash, originalLimit := downloader.GetHashAndLimit()
+	if hash == nil || originalLimit == nil {
+		return ErrPieceHashMismatch.New("piece hash or original order limit not sent by storage node")
+	}
+
+	if err := signing.VerifyOrderLimitSignature(ctx, signing.SigneeFromPeerIdentity(verifier.auditor), originalLimit); err != nil {
+		return ErrPieceHashMismatch.New("invalid original order limit signature: %v", err)
+	}
+	if originalLimit.PieceId != limit.GetLimit().PieceId || hash.PieceId != limit.GetLimit().PieceId {
+		return ErrPieceHashMismatch.New("piece id changed")
+	}
+	if err := signing.VerifyUplinkPieceHashSignature(ctx, originalLimit.UplinkPublicKey, hash); err != nil {
+		return ErrPieceHashMismatch.New("invalid piece hash signature: %v", err)
+	}
+	if calculated := hashWriter.Sum(nil); !bytes.Equal(hash.Hash, calculated) {
+		return ErrPieceHashMismatch.New("hash from storage node, %x, does not match calculated hash, %x", hash.Hash, calculated)
+	}
+
+	return nil
+}
@@ -114,6 +114,11 @@ func (verifier *Verifier) Verify(ctx context.Context, segment Segment, skip map[
 		return Report{}, err
 	}
 
+	skip, err = verifier.skipNodesInMaintenance(ctx, segmentInfo, skip)
+	if err != nil {
+		return Report{}, err
+	}
+
 	var offlineNodes storj.NodeIDList
 	var failedNodes storj.NodeIDList
 	var unknownNodes storj.NodeIDList
@@ -735,6 +740,33 @@ func makeCopies(ctx context.Context, originals map[int]Share) (copies []infectio
 	return copies, nil
 }
 
+// skipNodesInMaintenance returns skip extended by the nodes of the segment
+// which are in a planned maintenance window.
+func (verifier *Verifier) skipNodesInMaintenance(ctx context.Context, segment metabase.Segment, skip map[storj.NodeID]bool) (_ map[storj.NodeID]bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	nodeIDs := make(storj.NodeIDList, 0, len(segment.Pieces))
+	for _, piece := range segment.Pieces {
+		if !skip[piece.StorageNode] {
+			nodeIDs = append(nodeIDs, piece.StorageNode)
+		}
+	}
+
+	inMaintenance, err := verifier.overlay.NodesInMaintenance(ctx, nodeIDs, verifier.nowFn())
+	if err != nil || len(inMaintenance) == 0 {
+		return skip, err
+	}
+
+	extended := make(map[storj.NodeID]bool, len(skip)+len(inMaintenance))
+	for nodeID := range skip {
+		extended[nodeID] = true
+	}
+	for _, nodeID := range inMaintenance {
+		extended[nodeID] = true
+	}
+	return extended, nil
+}
+
 // getOfflines nodes returns these storage nodes from the segment which have no
 // order limit nor are skipped.
 func getOfflineNodes(segment metabase.Segment, limits []*pb.AddressedOrderLimit, skip map[storj.NodeID]bool) storj.NodeIDList {
@@ -29,6 +29,10 @@ type Config struct {
 	QueueInterval     time.Duration `help:"how often to recheck an empty audit queue" releaseDefault:"1h" devDefault:"1m" testDefault:"$TESTINTERVAL"`
 	Slots             int           `help:"number of reservoir slots allotted for nodes, currently capped at 3" default:"3"`
 	WorkerConcurrency int           `help:"number of workers to run audits on segments" default:"2"`
+
+	NodeAuditInterval    time.Duration `help:"how often to check the queue of piece-level node audits" releaseDefault:"5m" devDefault:"1m" testDefault:"$TESTINTERVAL"`
+	NodeAuditBatchSize   int           `help:"number of queued nodes audited within a single pass of the segment loop" default:"10"`
+	NodeAuditConcurrency int           `help:"number of pieces verified concurrently by piece-level node audits" default:"5"`
 }
 
 // Worker contains information for populating audit queue and processing audits.
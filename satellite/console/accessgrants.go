@@ -0,0 +1,306 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package console
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/macaroon"
+	"storj.io/common/pb"
+	"storj.io/common/uuid"
+)
+
+// AccessGrants exposes methods to manage the access grants users registered
+// with the satellite.
+//
+// architecture: Database
+type AccessGrants interface {
+	// Insert inserts the registered access grant.
+	Insert(ctx context.Context, accessGrant AccessGrant) error
+	// Get returns the registered access grant.
+	Get(ctx context.Context, id uuid.UUID) (*AccessGrant, error)
+	// GetByProjectID returns the access grants registered in the project.
+	GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]AccessGrant, error)
+	// Delete removes the registered access grant.
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// AccessGrant is an access grant derived in the browser which a user
+// registered with the satellite to track and revoke it.
+type AccessGrant struct {
+	ID        uuid.UUID `json:"id"`
+	ProjectID uuid.UUID `json:"projectId"`
+	APIKeyID  uuid.UUID `json:"apiKeyId"`
+	Name      string    `json:"name"`
+	// Caveats is a human readable summary of the restrictions of the access grant.
+	Caveats string `json:"caveats"`
+	// Tails are the macaroon tails of the API key of the access grant, from
+	// the tail of the API key it was derived from to its own tail.
+	Tails [][]byte `json:"-"`
+	// Revoked is whether the access grant or any access grant it was derived
+	// from is revoked.
+	Revoked bool `json:"revoked"`
+	// CreatedBy is the user who registered the access grant.
+	CreatedBy uuid.UUID `json:"createdBy"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// RegisterAccessGrantRequest holds data needed to register an access grant.
+// APIKey is the serialized API key of the access grant, so the encryption key
+// of the access grant never leaves the browser.
+type RegisterAccessGrantRequest struct {
+	Name   string `json:"name"`
+	APIKey string `json:"apiKey"`
+}
+
+// RegisterAccessGrant registers an access grant derived from an API key of the project.
+func (s *Service) RegisterAccessGrant(ctx context.Context, projectID uuid.UUID, request RegisterAccessGrantRequest) (_ *AccessGrant, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getScopedUserAndAuditLog(ctx, []RESTKeyPermission{RESTKeyPermissionManageAPIKeys}, "register access grant", zap.String("projectID", projectID.String()), zap.String("name", request.Name))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	if _, err = s.isProjectMember(ctx, user.ID, projectID); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	name := strings.TrimSpace(request.Name)
+	if name == "" {
+		return nil, ErrValidation.New("access grant name can't be empty")
+	}
+
+	apiKey, err := macaroon.ParseAPIKey(request.APIKey)
+	if err != nil {
+		return nil, ErrValidation.Wrap(err)
+	}
+
+	mac, err := macaroon.ParseMacaroon(apiKey.SerializeRaw())
+	if err != nil {
+		return nil, ErrValidation.Wrap(err)
+	}
+
+	// registering an unrestricted API key would allow to revoke the API key
+	// itself, which is done by deleting it instead.
+	if mac.CaveatLen() == 0 {
+		return nil, ErrValidation.New("only restricted access grants can be registered")
+	}
+
+	keyInfo, err := s.store.APIKeys().GetByHead(ctx, mac.Head())
+	if err != nil || keyInfo.ProjectID != projectID {
+		return nil, ErrValidation.New("access grant is not derived from an API key of the project")
+	}
+
+	valid, tails := mac.ValidateAndTails(keyInfo.Secret)
+	if !valid {
+		return nil, ErrValidation.New("access grant is not derived from an API key of the project")
+	}
+
+	caveats, err := summarizeCaveats(mac.Caveats())
+	if err != nil {
+		return nil, ErrValidation.Wrap(err)
+	}
+
+	id, err := uuid.New()
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	err = s.store.AccessGrants().Insert(ctx, AccessGrant{
+		ID:        id,
+		ProjectID: projectID,
+		APIKeyID:  keyInfo.ID,
+		Name:      name,
+		Caveats:   caveats,
+		Tails:     tails,
+		CreatedBy: user.ID,
+	})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	accessGrant, err := s.store.AccessGrants().Get(ctx, id)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return accessGrant, nil
+}
+
+// GetAccessGrants returns the access grants registered in the project with
+// their revocation status.
+func (s *Service) GetAccessGrants(ctx context.Context, projectID uuid.UUID) (_ []AccessGrant, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getScopedUserAndAuditLog(ctx, []RESTKeyPermission{RESTKeyPermissionManageAPIKeys}, "get access grants", zap.String("projectID", projectID.String()))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	if _, err = s.isProjectMember(ctx, user.ID, projectID); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	accessGrants, err := s.store.AccessGrants().GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	for i := range accessGrants {
+		accessGrants[i].Revoked, err = s.revocations.Check(ctx, accessGrants[i].Tails)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+	}
+
+	return accessGrants, nil
+}
+
+// RevokeAccessGrant revokes the registered access grant and every access grant
+// derived from it.
+func (s *Service) RevokeAccessGrant(ctx context.Context, id uuid.UUID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getScopedUserAndAuditLog(ctx, []RESTKeyPermission{RESTKeyPermissionManageAPIKeys}, "revoke access grant", zap.String("accessGrantID", id.String()))
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	accessGrant, err := s.getManagedAccessGrant(ctx, user.ID, id)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	if len(accessGrant.Tails) == 0 {
+		return Error.New("access grant has no tails")
+	}
+
+	tail := accessGrant.Tails[len(accessGrant.Tails)-1]
+	return Error.Wrap(s.revocations.Revoke(ctx, tail, accessGrant.APIKeyID[:]))
+}
+
+// DeleteAccessGrant removes the registered access grant without revoking it.
+func (s *Service) DeleteAccessGrant(ctx context.Context, id uuid.UUID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getScopedUserAndAuditLog(ctx, []RESTKeyPermission{RESTKeyPermissionManageAPIKeys}, "delete access grant", zap.String("accessGrantID", id.String()))
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	if _, err = s.getManagedAccessGrant(ctx, user.ID, id); err != nil {
+		return Error.Wrap(err)
+	}
+
+	return Error.Wrap(s.store.AccessGrants().Delete(ctx, id))
+}
+
+// getManagedAccessGrant returns the registered access grant if the user is the
+// owner of its project or the member who registered it.
+func (s *Service) getManagedAccessGrant(ctx context.Context, userID, id uuid.UUID) (_ *AccessGrant, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	accessGrant, err := s.store.AccessGrants().Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	isMember, err := s.isProjectMember(ctx, userID, accessGrant.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if isMember.project.OwnerID != userID && accessGrant.CreatedBy != userID {
+		return nil, ErrUnauthorized.New(unauthorizedErrMsg)
+	}
+
+	return accessGrant, nil
+}
+
+// summarizeCaveats returns a human readable summary of the effective
+// restrictions of the serialized caveats.
+func summarizeCaveats(serialized [][]byte) (string, error) {
+	var (
+		disallowed = map[string]bool{}
+		buckets    map[string]bool
+		notBefore  *time.Time
+		notAfter   *time.Time
+	)
+
+	for _, data := range serialized {
+		var caveat macaroon.Caveat
+		if err := pb.Unmarshal(data, &caveat); err != nil {
+			return "", errors.New("invalid caveat format")
+		}
+
+		disallowed["read"] = disallowed["read"] || caveat.DisallowReads
+		disallowed["write"] = disallowed["write"] || caveat.DisallowWrites
+		disallowed["list"] = disallowed["list"] || caveat.DisallowLists
+		disallowed["delete"] = disallowed["delete"] || caveat.DisallowDeletes
+
+		// the allowed buckets are the intersection of the buckets of all caveats.
+		if len(caveat.AllowedPaths) > 0 {
+			caveatBuckets := map[string]bool{}
+			for _, path := range caveat.AllowedPaths {
+				caveatBuckets[string(path.Bucket)] = true
+			}
+			if buckets == nil {
+				buckets = caveatBuckets
+			} else {
+				for bucket := range buckets {
+					if !caveatBuckets[bucket] {
+						delete(buckets, bucket)
+					}
+				}
+			}
+		}
+
+		if caveat.NotBefore != nil && (notBefore == nil || caveat.NotBefore.After(*notBefore)) {
+			notBefore = caveat.NotBefore
+		}
+		if caveat.NotAfter != nil && (notAfter == nil || caveat.NotAfter.Before(*notAfter)) {
+			notAfter = caveat.NotAfter
+		}
+	}
+
+	var allowed []string
+	for _, operation := range []string{"read", "write", "list", "delete"} {
+		if !disallowed[operation] {
+			allowed = append(allowed, operation)
+		}
+	}
+
+	parts := []string{"no operations"}
+	if len(allowed) > 0 {
+		parts[0] = strings.Join(allowed, ", ")
+	}
+
+	if buckets != nil {
+		names := make([]string, 0, len(buckets))
+		for bucket := range buckets {
+			names = append(names, bucket)
+		}
+		sort.Strings(names)
+		if len(names) == 0 {
+			names = []string{"none"}
+		}
+		parts = append(parts, "buckets: "+strings.Join(names, ", "))
+	}
+
+	if notBefore != nil {
+		parts = append(parts, "not before "+notBefore.UTC().Format(time.RFC3339))
+	}
+	if notAfter != nil {
+		parts = append(parts, "not after "+notAfter.UTC().Format(time.RFC3339))
+	}
+
+	return strings.Join(parts, "; "), nil
+}
@@ -30,19 +30,17 @@ type APIKeys interface {
 	Delete(ctx context.Context, id uuid.UUID) error
 }
 
-// RESTKeys is an interface for rest key operations.
-type RESTKeys interface {
-	Create(ctx context.Context, userID uuid.UUID, expiration time.Duration) (apiKey string, expiresAt time.Time, err error)
-	GetUserAndExpirationFromKey(ctx context.Context, apiKey string) (userID uuid.UUID, exp time.Time, err error)
-	Revoke(ctx context.Context, apiKey string) (err error)
-}
-
 // CreateAPIKeyRequest holds create API key info.
 type CreateAPIKeyRequest struct {
 	ProjectID string `json:"projectID"`
 	Name      string `json:"name"`
 }
 
+// DeleteAPIKeysRequest holds the ids of the API keys to delete.
+type DeleteAPIKeysRequest struct {
+	IDs []uuid.UUID `json:"ids"`
+}
+
 // CreateAPIKeyResponse holds macaroon.APIKey and APIKeyInfo.
 type CreateAPIKeyResponse struct {
 	Key     string      `json:"key"`
@@ -72,17 +70,21 @@ type APIKeyCursor struct {
 
 // APIKeyPage represent api key page result.
 type APIKeyPage struct {
-	APIKeys []APIKeyInfo
+	APIKeys []APIKeyInfo `json:"apiKeys"`
 
-	Search         string
-	Limit          uint
-	Order          APIKeyOrder
-	OrderDirection OrderDirection
-	Offset         uint64
+	Search         string         `json:"search"`
+	Limit          uint           `json:"limit"`
+	Order          APIKeyOrder    `json:"order"`
+	OrderDirection OrderDirection `json:"orderDirection"`
+	Offset         uint64         `json:"offset"`
+
+	PageCount   uint   `json:"pageCount"`
+	CurrentPage uint   `json:"currentPage"`
+	TotalCount  uint64 `json:"totalCount"`
 
-	PageCount   uint
-	CurrentPage uint
-	TotalCount  uint64
+	// NextCursor is the opaque cursor of the next page of the generated api,
+	// which is empty on the last page.
+	NextCursor string `json:"nextCursor"`
 }
 
 // APIKeyOrder is used for querying api keys in specified order.
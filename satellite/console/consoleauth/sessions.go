@@ -18,6 +18,8 @@ type WebappSessions interface {
 	GetBySessionID(ctx context.Context, sessionID uuid.UUID) (WebappSession, error)
 	// GetAllByUserID gets all webapp sessions with userID.
 	GetAllByUserID(ctx context.Context, userID uuid.UUID) ([]WebappSession, error)
+	// UpdateLastSeen updates the time the session was last used.
+	UpdateLastSeen(ctx context.Context, sessionID uuid.UUID, lastSeenAt time.Time) error
 	// DeleteBySessionID deletes a webapp session by ID.
 	DeleteBySessionID(ctx context.Context, sessionID uuid.UUID) error
 	// DeleteAllByUserID deletes all webapp sessions by user ID.
@@ -26,10 +28,12 @@ type WebappSessions interface {
 
 // WebappSession represents a session on the satellite web app.
 type WebappSession struct {
-	ID        uuid.UUID
-	UserID    uuid.UUID
-	Address   string
-	UserAgent string
-	Status    int
-	ExpiresAt time.Time
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Address    string
+	UserAgent  string
+	Status     int
+	ExpiresAt  time.Time
+	CreatedAt  time.Time
+	LastSeenAt time.Time
 }
@@ -52,3 +52,15 @@ func DeriveRootKey(encryptionPassphrase, projectID string) (*storj.Key, error) {
 
 	return encryption.DeriveRootKey([]byte(encryptionPassphrase), salt[:], "", concurrency)
 }
+
+// AccessGrantAPIKey returns the serialized API key of the access grant. It is
+// used to register an access grant with the satellite without sending its
+// encryption key.
+func AccessGrantAPIKey(accessGrant string) (string, error) {
+	access, err := grant.ParseAccess(accessGrant)
+	if err != nil {
+		return "", err
+	}
+
+	return access.APIKey.Serialize(), nil
+}
@@ -80,3 +80,28 @@ func TestDefaultAccess(t *testing.T) {
 		require.NoError(t, uplinkPeer.DeleteBucket(ctx, satellitePeer, testbucket1))
 	})
 }
+
+// TestAccessGrantAPIKey confirms that the API key of an access grant can be
+// extracted to register the access grant with the satellite.
+func TestAccessGrantAPIKey(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellitePeer := planet.Satellites[0]
+		satelliteNodeURL := satellitePeer.NodeURL().String()
+
+		uplinkPeer := planet.Uplinks[0]
+		apiKeyString := uplinkPeer.Projects[0].APIKey
+		projectID := uplinkPeer.Projects[0].ID.String()
+
+		access, err := console.GenAccessGrant(satelliteNodeURL, apiKeyString, "supersecretpassphrase", projectID)
+		require.NoError(t, err)
+
+		apiKey, err := console.AccessGrantAPIKey(access)
+		require.NoError(t, err)
+		require.Equal(t, apiKeyString, apiKey)
+
+		_, err = console.AccessGrantAPIKey("invalid")
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,303 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleweb_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"storj.io/common/testcontext"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/console/consoleweb/consoleapi"
+)
+
+// TestGraphQLRESTCompatibility checks that the generated REST api returns
+// the same results as the GraphQL api it replaces.
+func TestGraphQLRESTCompatibility(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Console.GeneratedAPIEnabled = true
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		test := newTest(t, ctx, planet)
+		user := test.defaultUser()
+		user2 := test.registerUser("user@mail.test", "#$Rnkl12i3nkljfds")
+		test.login(user.email, user.password)
+
+		projectID := test.defaultProjectID()
+		before := time.Now().Add(time.Hour).UTC().Format("2006-01-02T15:04:05.000Z")
+		since := time.Now().Add(-time.Hour).UTC().Format("2006-01-02T15:04:05.000Z")
+
+		{ // GraphQL_Deprecated
+			resp, _ := test.request(http.MethodPost, "/graphql",
+				test.toJSON(map[string]interface{}{"query": `{ myProjects { id } }`}))
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+			require.Equal(t, "true", resp.Header.Get("Deprecation"))
+			require.Contains(t, resp.Header.Get("Link"), consoleapi.APIDocsPath)
+
+			resp, body := test.request(http.MethodGet, "/apidocs.json", nil)
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+			require.Contains(t, body, "/members/paged")
+		}
+
+		{ // Project
+			gql := test.graphql(`
+				query ($projectId: String!) {
+					project(id: $projectId) { id name description ownerId memberCount }
+				}`, map[string]interface{}{"projectId": projectID})["project"].(map[string]interface{})
+
+			var rest map[string]interface{}
+			test.rest(http.MethodGet, "/projects/project?"+url.Values{"id": {projectID}}.Encode(), nil, &rest)
+
+			requireEqualFields(t, gql, rest, "id", "name", "description", "ownerId", "memberCount")
+		}
+
+		{ // OwnedProjects
+			gql := test.graphql(`
+				query ($limit: Int!, $page: Int!) {
+					ownedProjects(cursor: {limit: $limit, page: $page}) {
+						projects { id name }
+						limit offset pageCount currentPage totalCount
+					}
+				}`, map[string]interface{}{"limit": 7, "page": 1})["ownedProjects"].(map[string]interface{})
+
+			var rest map[string]interface{}
+			test.rest(http.MethodGet, "/projects/paged?limit=7", nil, &rest)
+
+			requireEqualFields(t, gql, rest, "limit", "offset", "pageCount", "currentPage", "totalCount")
+			requireEqualList(t, gql["projects"], rest["projects"], "id", "name")
+		}
+
+		{ // Usage
+			gql := test.graphql(`
+				query ($projectId: String!, $since: DateTime!, $before: DateTime!) {
+					project(id: $projectId) {
+						usage(since: $since, before: $before) { storage egress objectCount segmentCount }
+					}
+				}`, map[string]interface{}{"projectId": projectID, "since": since, "before": before})
+			gqlUsage := gql["project"].(map[string]interface{})["usage"].(map[string]interface{})
+
+			var rest map[string]interface{}
+			test.rest(http.MethodGet, "/projects/usage?"+url.Values{
+				"projectID": {projectID}, "since": {since}, "before": {before},
+			}.Encode(), nil, &rest)
+
+			requireEqualFields(t, gqlUsage, rest, "storage", "egress", "objectCount", "segmentCount")
+		}
+
+		{ // BucketTotals
+			gql := test.graphql(`
+				query ($projectId: String!, $before: DateTime!, $limit: Int!, $search: String!, $page: Int!) {
+					project(id: $projectId) {
+						bucketUsages(before: $before, cursor: {limit: $limit, search: $search, page: $page}) {
+							bucketUsages { bucketName storage egress objectCount segmentCount }
+							search limit offset pageCount currentPage totalCount
+						}
+					}
+				}`, map[string]interface{}{"projectId": projectID, "before": before, "limit": 7, "search": "", "page": 1})
+			gqlPage := gql["project"].(map[string]interface{})["bucketUsages"].(map[string]interface{})
+
+			var rest map[string]interface{}
+			test.rest(http.MethodGet, "/projects/bucket-totals?"+url.Values{
+				"projectID": {projectID}, "before": {before}, "limit": {"7"},
+			}.Encode(), nil, &rest)
+
+			requireEqualFields(t, gqlPage, rest, "search", "limit", "offset", "pageCount", "currentPage", "totalCount")
+			requireEqualList(t, gqlPage["bucketUsages"], rest["bucketUsages"], "bucketName", "storage", "egress", "objectCount", "segmentCount")
+		}
+
+		{ // AddMembers
+			var added []map[string]interface{}
+			test.rest(http.MethodPost, "/members/add", map[string]interface{}{
+				"projectID": projectID,
+				"emails":    []string{user2.email},
+			}, &added)
+			require.Len(t, added, 1)
+			require.Equal(t, user2.email, added[0]["email"])
+		}
+
+		membersQuery := `
+			query ($projectId: String!, $limit: Int!, $search: String!, $page: Int!, $order: Int!, $orderDirection: Int!) {
+				project(id: $projectId) {
+					members(cursor: {limit: $limit, search: $search, page: $page, order: $order, orderDirection: $orderDirection}) {
+						projectMembers { user { id fullName shortName email } }
+						search limit order pageCount currentPage totalCount
+					}
+				}
+			}`
+		cursor := map[string]interface{}{"projectId": projectID, "limit": 6, "search": "", "page": 1, "order": 1, "orderDirection": 1}
+		cursorQuery := url.Values{
+			"projectID": {projectID}, "limit": {"6"}, "order": {"1"}, "orderDirection": {"1"},
+		}.Encode()
+
+		{ // Members
+			gql := test.graphql(membersQuery, cursor)
+			gqlPage := gql["project"].(map[string]interface{})["members"].(map[string]interface{})
+
+			var rest map[string]interface{}
+			test.rest(http.MethodGet, "/members/paged?"+cursorQuery, nil, &rest)
+
+			requireEqualFields(t, gqlPage, rest, "search", "limit", "order", "pageCount", "currentPage", "totalCount")
+
+			gqlUsers := []interface{}{}
+			for _, member := range gqlPage["projectMembers"].([]interface{}) {
+				gqlUsers = append(gqlUsers, member.(map[string]interface{})["user"])
+			}
+			requireEqualList(t, gqlUsers, rest["projectMembers"], "id", "fullName", "shortName", "email")
+			require.Len(t, gqlUsers, 2)
+			require.Empty(t, rest["nextCursor"])
+
+			// walking the pages with the cursor returns the members of the first page.
+			var restUsers []interface{}
+			next := url.Values{
+				"projectID": {projectID}, "limit": {"1"}, "order": {"1"}, "orderDirection": {"1"},
+			}
+			for {
+				var page map[string]interface{}
+				test.rest(http.MethodGet, "/members/paged?"+next.Encode(), nil, &page)
+				restUsers = append(restUsers, page["projectMembers"].([]interface{})...)
+				if page["nextCursor"] == "" {
+					break
+				}
+				next = url.Values{"projectID": {projectID}, "cursor": {page["nextCursor"].(string)}}
+			}
+			requireEqualList(t, gqlUsers, restUsers, "id", "fullName", "shortName", "email")
+		}
+
+		{ // DeleteMembers
+			test.rest(http.MethodPost, "/members/delete", map[string]interface{}{
+				"projectID": projectID,
+				"emails":    []string{user2.email},
+			}, nil)
+
+			gql := test.graphql(membersQuery, cursor)
+			gqlPage := gql["project"].(map[string]interface{})["members"].(map[string]interface{})
+			require.EqualValues(t, 1, gqlPage["totalCount"])
+		}
+
+		apiKeysQuery := `
+			query ($projectId: String!, $limit: Int!, $search: String!, $page: Int!, $order: Int!, $orderDirection: Int!) {
+				project(id: $projectId) {
+					apiKeys(cursor: {limit: $limit, search: $search, page: $page, order: $order, orderDirection: $orderDirection}) {
+						apiKeys { id name }
+						search limit order pageCount currentPage totalCount
+					}
+				}
+			}`
+
+		{ // APIKeys
+			test.graphql(`
+				mutation ($projectId: String!, $name: String!) {
+					createAPIKey(projectID: $projectId, name: $name) { key }
+				}`, map[string]interface{}{"projectId": projectID, "name": "compat"})
+
+			gql := test.graphql(apiKeysQuery, cursor)
+			gqlPage := gql["project"].(map[string]interface{})["apiKeys"].(map[string]interface{})
+
+			var rest map[string]interface{}
+			test.rest(http.MethodGet, "/apikeys/paged?"+cursorQuery, nil, &rest)
+
+			requireEqualFields(t, gqlPage, rest, "search", "limit", "order", "pageCount", "currentPage", "totalCount")
+			requireEqualList(t, gqlPage["apiKeys"], rest["apiKeys"], "id", "name")
+
+			var keyID string
+			for _, key := range rest["apiKeys"].([]interface{}) {
+				if key.(map[string]interface{})["name"] == "compat" {
+					keyID = key.(map[string]interface{})["id"].(string)
+				}
+			}
+			require.NotEmpty(t, keyID)
+
+			test.rest(http.MethodDelete, "/apikeys/delete/"+keyID, nil, nil)
+
+			gql = test.graphql(apiKeysQuery, cursor)
+			gqlPage = gql["project"].(map[string]interface{})["apiKeys"].(map[string]interface{})
+			for _, key := range gqlPage["apiKeys"].([]interface{}) {
+				require.NotEqual(t, keyID, key.(map[string]interface{})["id"])
+			}
+		}
+
+		{ // DeleteAPIKeys
+			var ids []string
+			for _, name := range []string{"compat1", "compat2"} {
+				var created map[string]interface{}
+				test.rest(http.MethodPost, "/apikeys/create", map[string]interface{}{
+					"projectID": projectID,
+					"name":      name,
+				}, &created)
+				ids = append(ids, created["keyInfo"].(map[string]interface{})["id"].(string))
+			}
+
+			test.rest(http.MethodPost, "/apikeys/delete", map[string]interface{}{"ids": ids}, nil)
+
+			gql := test.graphql(apiKeysQuery, cursor)
+			gqlPage := gql["project"].(map[string]interface{})["apiKeys"].(map[string]interface{})
+			for _, key := range gqlPage["apiKeys"].([]interface{}) {
+				require.NotContains(t, ids, key.(map[string]interface{})["id"])
+			}
+		}
+	})
+}
+
+// graphql runs the query and returns the decoded data of the result.
+func (test *test) graphql(query string, variables map[string]interface{}) map[string]interface{} {
+	resp, body := test.request(http.MethodPost, "/graphql",
+		test.toJSON(map[string]interface{}{
+			"variables": variables,
+			"query":     query,
+		}))
+	require.Equal(test.t, http.StatusOK, resp.StatusCode, body)
+
+	var result struct {
+		Data   map[string]interface{} `json:"data"`
+		Errors []interface{}          `json:"errors"`
+	}
+	require.NoError(test.t, json.Unmarshal([]byte(body), &result))
+	require.Empty(test.t, result.Errors)
+
+	return result.Data
+}
+
+// rest calls the generated api and decodes its response into v, if not nil.
+func (test *test) rest(method, path string, data, v interface{}) {
+	resp, body := test.request(method, path, test.toJSON(data))
+	require.Equal(test.t, http.StatusOK, resp.StatusCode, body)
+
+	if v != nil {
+		require.NoError(test.t, json.Unmarshal([]byte(body), v))
+	}
+}
+
+// requireEqualFields requires the fields to have the same values in both results.
+func requireEqualFields(t *testing.T, expected, actual interface{}, fields ...string) {
+	expectedObject := expected.(map[string]interface{})
+	actualObject := actual.(map[string]interface{})
+
+	for _, field := range fields {
+		require.Contains(t, actualObject, field)
+		require.EqualValues(t, expectedObject[field], actualObject[field], field)
+	}
+}
+
+// requireEqualList requires both lists to have the same length and their
+// elements to have the same values for the fields.
+func requireEqualList(t *testing.T, expected, actual interface{}, fields ...string) {
+	expectedList := expected.([]interface{})
+	actualList := actual.([]interface{})
+
+	require.Len(t, actualList, len(expectedList))
+	for i := range expectedList {
+		requireEqualFields(t, expectedList[i], actualList[i], fields...)
+	}
+}
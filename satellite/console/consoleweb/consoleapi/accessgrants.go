@@ -0,0 +1,156 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleapi
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/console"
+)
+
+var (
+	// ErrAccessGrantsAPI - console access grants api error type.
+	ErrAccessGrantsAPI = errs.Class("console access grants")
+)
+
+// AccessGrants is an api controller that exposes the access grants registered with the satellite.
+type AccessGrants struct {
+	log     *zap.Logger
+	service *console.Service
+}
+
+// NewAccessGrants is a constructor for api access grants controller.
+func NewAccessGrants(log *zap.Logger, service *console.Service) *AccessGrants {
+	return &AccessGrants{
+		log:     log,
+		service: service,
+	}
+}
+
+// List returns the access grants registered in the project with their revocation status.
+func (a *AccessGrants) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	projectID, err := uuid.FromString(r.URL.Query().Get("projectID"))
+	if err != nil {
+		a.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	accessGrants, err := a.service.GetAccessGrants(ctx, projectID)
+	if err != nil {
+		a.serveServiceError(w, err)
+		return
+	}
+
+	a.serveJSON(w, accessGrants)
+}
+
+// Register registers an access grant derived in the browser.
+func (a *AccessGrants) Register(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	var request struct {
+		ProjectID uuid.UUID `json:"projectId"`
+		console.RegisterAccessGrantRequest
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		a.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	accessGrant, err := a.service.RegisterAccessGrant(ctx, request.ProjectID, request.RegisterAccessGrantRequest)
+	if err != nil {
+		a.serveServiceError(w, err)
+		return
+	}
+
+	a.serveJSON(w, accessGrant)
+}
+
+// Revoke revokes the registered access grant.
+func (a *AccessGrants) Revoke(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	id, err := a.accessGrantID(r)
+	if err != nil {
+		a.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err = a.service.RevokeAccessGrant(ctx, id)
+	if err != nil {
+		a.serveServiceError(w, err)
+		return
+	}
+}
+
+// Delete removes the registered access grant without revoking it.
+func (a *AccessGrants) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	id, err := a.accessGrantID(r)
+	if err != nil {
+		a.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err = a.service.DeleteAccessGrant(ctx, id)
+	if err != nil {
+		a.serveServiceError(w, err)
+		return
+	}
+}
+
+// accessGrantID parses the ID of the access grant from the route.
+func (a *AccessGrants) accessGrantID(r *http.Request) (uuid.UUID, error) {
+	id, ok := mux.Vars(r)["id"]
+	if !ok {
+		return uuid.UUID{}, errs.New("missing id route param")
+	}
+
+	return uuid.FromString(id)
+}
+
+// serveJSON writes the value as JSON to response output stream.
+func (a *AccessGrants) serveJSON(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(value)
+	if err != nil {
+		a.log.Error("failed to write json response", zap.Error(ErrAccessGrantsAPI.Wrap(err)))
+	}
+}
+
+// serveServiceError writes JSON error of the console service to response output stream.
+func (a *AccessGrants) serveServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case console.ErrUnauthorized.Has(err), console.ErrNoMembership.Has(err):
+		a.serveJSONError(w, http.StatusUnauthorized, err)
+	case console.ErrValidation.Has(err):
+		a.serveJSONError(w, http.StatusBadRequest, err)
+	default:
+		a.serveJSONError(w, http.StatusInternalServerError, err)
+	}
+}
+
+// serveJSONError writes JSON error to response output stream.
+func (a *AccessGrants) serveJSONError(w http.ResponseWriter, status int, err error) {
+	serveJSONError(a.log, w, status, err)
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gorilla/mux"
@@ -23,6 +24,7 @@ const dateLayout = "2006-01-02T15:04:05.000Z"
 
 var ErrProjectsAPI = errs.Class("consoleapi projects api")
 var ErrApikeysAPI = errs.Class("consoleapi apikeys api")
+var ErrMembersAPI = errs.Class("consoleapi members api")
 var ErrUsersAPI = errs.Class("consoleapi users api")
 
 type ProjectManagementService interface {
@@ -30,12 +32,25 @@ type ProjectManagementService interface {
 	GenUpdateProject(context.Context, uuid.UUID, console.ProjectInfo) (*console.Project, api.HTTPError)
 	GenDeleteProject(context.Context, uuid.UUID) api.HTTPError
 	GenGetUsersProjects(context.Context) ([]console.Project, api.HTTPError)
+	GenGetProject(context.Context, uuid.UUID) (*console.Project, api.HTTPError)
+	GenGetUsersOwnedProjectsPage(context.Context, string, uint) (*console.ProjectsPage, api.HTTPError)
+	GenGetProjectUsage(context.Context, uuid.UUID, time.Time, time.Time) (*accounting.ProjectUsage, api.HTTPError)
+	GenGetBucketTotals(context.Context, uuid.UUID, string, string, uint, time.Time) (*accounting.BucketUsagePage, api.HTTPError)
 	GenGetSingleBucketUsageRollup(context.Context, uuid.UUID, string, time.Time, time.Time) (*accounting.BucketUsageRollup, api.HTTPError)
 	GenGetBucketUsageRollups(context.Context, uuid.UUID, time.Time, time.Time) ([]accounting.BucketUsageRollup, api.HTTPError)
 }
 
 type APIKeyManagementService interface {
 	GenCreateAPIKey(context.Context, console.CreateAPIKeyRequest) (*console.CreateAPIKeyResponse, api.HTTPError)
+	GenGetAPIKeys(context.Context, uuid.UUID, string, string, uint, console.APIKeyOrder, console.OrderDirection) (*console.APIKeyPage, api.HTTPError)
+	GenDeleteAPIKey(context.Context, uuid.UUID) api.HTTPError
+	GenDeleteAPIKeys(context.Context, console.DeleteAPIKeysRequest) api.HTTPError
+}
+
+type ProjectMemberManagementService interface {
+	GenGetProjectMembers(context.Context, uuid.UUID, string, string, uint, console.ProjectMemberOrder, console.OrderDirection) (*console.ProjectMemberInfosPage, api.HTTPError)
+	GenAddProjectMembers(context.Context, console.ProjectMembersRequest) ([]console.ProjectMemberInfo, api.HTTPError)
+	GenDeleteProjectMembers(context.Context, console.ProjectMembersRequest) api.HTTPError
 }
 
 type UserManagementService interface {
@@ -56,6 +71,13 @@ type APIKeyManagementHandler struct {
 	auth    api.Auth
 }
 
+// ProjectMemberManagementHandler is an api handler that exposes all members related functionality.
+type ProjectMemberManagementHandler struct {
+	log     *zap.Logger
+	service ProjectMemberManagementService
+	auth    api.Auth
+}
+
 // UserManagementHandler is an api handler that exposes all users related functionality.
 type UserManagementHandler struct {
 	log     *zap.Logger
@@ -75,6 +97,10 @@ func NewProjectManagement(log *zap.Logger, service ProjectManagementService, rou
 	projectsRouter.HandleFunc("/update/{id}", handler.handleGenUpdateProject).Methods("PATCH")
 	projectsRouter.HandleFunc("/delete/{id}", handler.handleGenDeleteProject).Methods("DELETE")
 	projectsRouter.HandleFunc("/", handler.handleGenGetUsersProjects).Methods("GET")
+	projectsRouter.HandleFunc("/project", handler.handleGenGetProject).Methods("GET")
+	projectsRouter.HandleFunc("/paged", handler.handleGenGetUsersOwnedProjectsPage).Methods("GET")
+	projectsRouter.HandleFunc("/usage", handler.handleGenGetProjectUsage).Methods("GET")
+	projectsRouter.HandleFunc("/bucket-totals", handler.handleGenGetBucketTotals).Methods("GET")
 	projectsRouter.HandleFunc("/bucket-rollup", handler.handleGenGetSingleBucketUsageRollup).Methods("GET")
 	projectsRouter.HandleFunc("/bucket-rollups", handler.handleGenGetBucketUsageRollups).Methods("GET")
 
@@ -90,6 +116,24 @@ func NewAPIKeyManagement(log *zap.Logger, service APIKeyManagementService, route
 
 	apikeysRouter := router.PathPrefix("/api/v0/apikeys").Subrouter()
 	apikeysRouter.HandleFunc("/create", handler.handleGenCreateAPIKey).Methods("POST")
+	apikeysRouter.HandleFunc("/paged", handler.handleGenGetAPIKeys).Methods("GET")
+	apikeysRouter.HandleFunc("/delete/{id}", handler.handleGenDeleteAPIKey).Methods("DELETE")
+	apikeysRouter.HandleFunc("/delete", handler.handleGenDeleteAPIKeys).Methods("POST")
+
+	return handler
+}
+
+func NewProjectMemberManagement(log *zap.Logger, service ProjectMemberManagementService, router *mux.Router, auth api.Auth) *ProjectMemberManagementHandler {
+	handler := &ProjectMemberManagementHandler{
+		log:     log,
+		service: service,
+		auth:    auth,
+	}
+
+	membersRouter := router.PathPrefix("/api/v0/members").Subrouter()
+	membersRouter.HandleFunc("/paged", handler.handleGenGetProjectMembers).Methods("GET")
+	membersRouter.HandleFunc("/add", handler.handleGenAddProjectMembers).Methods("POST")
+	membersRouter.HandleFunc("/delete", handler.handleGenDeleteProjectMembers).Methods("POST")
 
 	return handler
 }
@@ -116,6 +160,7 @@ func (h *ProjectManagementHandler) handleGenCreateProject(w http.ResponseWriter,
 
 	ctx, err = h.auth.IsAuthenticated(ctx, r, true, true)
 	if err != nil {
+		h.auth.RemoveAuthCookie(w)
 		api.ServeError(h.log, w, http.StatusUnauthorized, err)
 		return
 	}
@@ -223,6 +268,7 @@ func (h *ProjectManagementHandler) handleGenGetUsersProjects(w http.ResponseWrit
 
 	ctx, err = h.auth.IsAuthenticated(ctx, r, true, true)
 	if err != nil {
+		h.auth.RemoveAuthCookie(w)
 		api.ServeError(h.log, w, http.StatusUnauthorized, err)
 		return
 	}
@@ -239,6 +285,172 @@ func (h *ProjectManagementHandler) handleGenGetUsersProjects(w http.ResponseWrit
 	}
 }
 
+func (h *ProjectManagementHandler) handleGenGetProject(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	ctx, err = h.auth.IsAuthenticated(ctx, r, true, true)
+	if err != nil {
+		h.auth.RemoveAuthCookie(w)
+		api.ServeError(h.log, w, http.StatusUnauthorized, err)
+		return
+	}
+
+	id, err := uuid.FromString(r.URL.Query().Get("id"))
+	if err != nil {
+		api.ServeError(h.log, w, http.StatusBadRequest, err)
+		return
+	}
+
+	retVal, httpErr := h.service.GenGetProject(ctx, id)
+	if httpErr.Err != nil {
+		api.ServeError(h.log, w, httpErr.Status, httpErr.Err)
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(retVal)
+	if err != nil {
+		h.log.Debug("failed to write json GenGetProject response", zap.Error(ErrProjectsAPI.Wrap(err)))
+	}
+}
+
+func (h *ProjectManagementHandler) handleGenGetUsersOwnedProjectsPage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	ctx, err = h.auth.IsAuthenticated(ctx, r, true, true)
+	if err != nil {
+		h.auth.RemoveAuthCookie(w)
+		api.ServeError(h.log, w, http.StatusUnauthorized, err)
+		return
+	}
+
+	cursor := r.URL.Query().Get("cursor")
+
+	var limit uint
+	if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+		value, err := strconv.ParseUint(limitParam, 10, 0)
+		if err != nil {
+			api.ServeError(h.log, w, http.StatusBadRequest, err)
+			return
+		}
+		limit = uint(value)
+	}
+
+	retVal, httpErr := h.service.GenGetUsersOwnedProjectsPage(ctx, cursor, limit)
+	if httpErr.Err != nil {
+		api.ServeError(h.log, w, httpErr.Status, httpErr.Err)
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(retVal)
+	if err != nil {
+		h.log.Debug("failed to write json GenGetUsersOwnedProjectsPage response", zap.Error(ErrProjectsAPI.Wrap(err)))
+	}
+}
+
+func (h *ProjectManagementHandler) handleGenGetProjectUsage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	ctx, err = h.auth.IsAuthenticated(ctx, r, true, true)
+	if err != nil {
+		h.auth.RemoveAuthCookie(w)
+		api.ServeError(h.log, w, http.StatusUnauthorized, err)
+		return
+	}
+
+	projectID, err := uuid.FromString(r.URL.Query().Get("projectID"))
+	if err != nil {
+		api.ServeError(h.log, w, http.StatusBadRequest, err)
+		return
+	}
+
+	since, err := time.Parse(dateLayout, r.URL.Query().Get("since"))
+	if err != nil {
+		api.ServeError(h.log, w, http.StatusBadRequest, err)
+		return
+	}
+
+	before, err := time.Parse(dateLayout, r.URL.Query().Get("before"))
+	if err != nil {
+		api.ServeError(h.log, w, http.StatusBadRequest, err)
+		return
+	}
+
+	retVal, httpErr := h.service.GenGetProjectUsage(ctx, projectID, since, before)
+	if httpErr.Err != nil {
+		api.ServeError(h.log, w, httpErr.Status, httpErr.Err)
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(retVal)
+	if err != nil {
+		h.log.Debug("failed to write json GenGetProjectUsage response", zap.Error(ErrProjectsAPI.Wrap(err)))
+	}
+}
+
+func (h *ProjectManagementHandler) handleGenGetBucketTotals(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	ctx, err = h.auth.IsAuthenticated(ctx, r, true, true)
+	if err != nil {
+		h.auth.RemoveAuthCookie(w)
+		api.ServeError(h.log, w, http.StatusUnauthorized, err)
+		return
+	}
+
+	projectID, err := uuid.FromString(r.URL.Query().Get("projectID"))
+	if err != nil {
+		api.ServeError(h.log, w, http.StatusBadRequest, err)
+		return
+	}
+
+	cursor := r.URL.Query().Get("cursor")
+
+	search := r.URL.Query().Get("search")
+
+	var limit uint
+	if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+		value, err := strconv.ParseUint(limitParam, 10, 0)
+		if err != nil {
+			api.ServeError(h.log, w, http.StatusBadRequest, err)
+			return
+		}
+		limit = uint(value)
+	}
+
+	before, err := time.Parse(dateLayout, r.URL.Query().Get("before"))
+	if err != nil {
+		api.ServeError(h.log, w, http.StatusBadRequest, err)
+		return
+	}
+
+	retVal, httpErr := h.service.GenGetBucketTotals(ctx, projectID, cursor, search, limit, before)
+	if httpErr.Err != nil {
+		api.ServeError(h.log, w, httpErr.Status, httpErr.Err)
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(retVal)
+	if err != nil {
+		h.log.Debug("failed to write json GenGetBucketTotals response", zap.Error(ErrProjectsAPI.Wrap(err)))
+	}
+}
+
 func (h *ProjectManagementHandler) handleGenGetSingleBucketUsageRollup(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	var err error
@@ -365,6 +577,254 @@ func (h *APIKeyManagementHandler) handleGenCreateAPIKey(w http.ResponseWriter, r
 	}
 }
 
+func (h *APIKeyManagementHandler) handleGenGetAPIKeys(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	ctx, err = h.auth.IsAuthenticated(ctx, r, true, true)
+	if err != nil {
+		h.auth.RemoveAuthCookie(w)
+		api.ServeError(h.log, w, http.StatusUnauthorized, err)
+		return
+	}
+
+	projectID, err := uuid.FromString(r.URL.Query().Get("projectID"))
+	if err != nil {
+		api.ServeError(h.log, w, http.StatusBadRequest, err)
+		return
+	}
+
+	cursor := r.URL.Query().Get("cursor")
+
+	search := r.URL.Query().Get("search")
+
+	var limit uint
+	if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+		value, err := strconv.ParseUint(limitParam, 10, 0)
+		if err != nil {
+			api.ServeError(h.log, w, http.StatusBadRequest, err)
+			return
+		}
+		limit = uint(value)
+	}
+
+	var order console.APIKeyOrder
+	if orderParam := r.URL.Query().Get("order"); orderParam != "" {
+		value, err := strconv.ParseUint(orderParam, 10, 8)
+		if err != nil {
+			api.ServeError(h.log, w, http.StatusBadRequest, err)
+			return
+		}
+		order = console.APIKeyOrder(value)
+	}
+
+	var orderDirection console.OrderDirection
+	if orderDirectionParam := r.URL.Query().Get("orderDirection"); orderDirectionParam != "" {
+		value, err := strconv.ParseUint(orderDirectionParam, 10, 8)
+		if err != nil {
+			api.ServeError(h.log, w, http.StatusBadRequest, err)
+			return
+		}
+		orderDirection = console.OrderDirection(value)
+	}
+
+	retVal, httpErr := h.service.GenGetAPIKeys(ctx, projectID, cursor, search, limit, order, orderDirection)
+	if httpErr.Err != nil {
+		api.ServeError(h.log, w, httpErr.Status, httpErr.Err)
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(retVal)
+	if err != nil {
+		h.log.Debug("failed to write json GenGetAPIKeys response", zap.Error(ErrApikeysAPI.Wrap(err)))
+	}
+}
+
+func (h *APIKeyManagementHandler) handleGenDeleteAPIKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	ctx, err = h.auth.IsAuthenticated(ctx, r, true, true)
+	if err != nil {
+		h.auth.RemoveAuthCookie(w)
+		api.ServeError(h.log, w, http.StatusUnauthorized, err)
+		return
+	}
+
+	idParam, ok := mux.Vars(r)["id"]
+	if !ok {
+		api.ServeError(h.log, w, http.StatusBadRequest, errs.New("missing id route param"))
+		return
+	}
+
+	id, err := uuid.FromString(idParam)
+	if err != nil {
+		api.ServeError(h.log, w, http.StatusBadRequest, err)
+		return
+	}
+
+	httpErr := h.service.GenDeleteAPIKey(ctx, id)
+	if httpErr.Err != nil {
+		api.ServeError(h.log, w, httpErr.Status, httpErr.Err)
+	}
+}
+
+func (h *APIKeyManagementHandler) handleGenDeleteAPIKeys(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	ctx, err = h.auth.IsAuthenticated(ctx, r, true, true)
+	if err != nil {
+		h.auth.RemoveAuthCookie(w)
+		api.ServeError(h.log, w, http.StatusUnauthorized, err)
+		return
+	}
+
+	request := &console.DeleteAPIKeysRequest{}
+	if err = json.NewDecoder(r.Body).Decode(&request); err != nil {
+		api.ServeError(h.log, w, http.StatusBadRequest, err)
+		return
+	}
+
+	httpErr := h.service.GenDeleteAPIKeys(ctx, *request)
+	if httpErr.Err != nil {
+		api.ServeError(h.log, w, httpErr.Status, httpErr.Err)
+	}
+}
+
+func (h *ProjectMemberManagementHandler) handleGenGetProjectMembers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	ctx, err = h.auth.IsAuthenticated(ctx, r, true, true)
+	if err != nil {
+		h.auth.RemoveAuthCookie(w)
+		api.ServeError(h.log, w, http.StatusUnauthorized, err)
+		return
+	}
+
+	projectID, err := uuid.FromString(r.URL.Query().Get("projectID"))
+	if err != nil {
+		api.ServeError(h.log, w, http.StatusBadRequest, err)
+		return
+	}
+
+	cursor := r.URL.Query().Get("cursor")
+
+	search := r.URL.Query().Get("search")
+
+	var limit uint
+	if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+		value, err := strconv.ParseUint(limitParam, 10, 0)
+		if err != nil {
+			api.ServeError(h.log, w, http.StatusBadRequest, err)
+			return
+		}
+		limit = uint(value)
+	}
+
+	var order console.ProjectMemberOrder
+	if orderParam := r.URL.Query().Get("order"); orderParam != "" {
+		value, err := strconv.ParseInt(orderParam, 10, 8)
+		if err != nil {
+			api.ServeError(h.log, w, http.StatusBadRequest, err)
+			return
+		}
+		order = console.ProjectMemberOrder(value)
+	}
+
+	var orderDirection console.OrderDirection
+	if orderDirectionParam := r.URL.Query().Get("orderDirection"); orderDirectionParam != "" {
+		value, err := strconv.ParseUint(orderDirectionParam, 10, 8)
+		if err != nil {
+			api.ServeError(h.log, w, http.StatusBadRequest, err)
+			return
+		}
+		orderDirection = console.OrderDirection(value)
+	}
+
+	retVal, httpErr := h.service.GenGetProjectMembers(ctx, projectID, cursor, search, limit, order, orderDirection)
+	if httpErr.Err != nil {
+		api.ServeError(h.log, w, httpErr.Status, httpErr.Err)
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(retVal)
+	if err != nil {
+		h.log.Debug("failed to write json GenGetProjectMembers response", zap.Error(ErrMembersAPI.Wrap(err)))
+	}
+}
+
+func (h *ProjectMemberManagementHandler) handleGenAddProjectMembers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	ctx, err = h.auth.IsAuthenticated(ctx, r, true, true)
+	if err != nil {
+		h.auth.RemoveAuthCookie(w)
+		api.ServeError(h.log, w, http.StatusUnauthorized, err)
+		return
+	}
+
+	request := &console.ProjectMembersRequest{}
+	if err = json.NewDecoder(r.Body).Decode(&request); err != nil {
+		api.ServeError(h.log, w, http.StatusBadRequest, err)
+		return
+	}
+
+	retVal, httpErr := h.service.GenAddProjectMembers(ctx, *request)
+	if httpErr.Err != nil {
+		api.ServeError(h.log, w, httpErr.Status, httpErr.Err)
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(retVal)
+	if err != nil {
+		h.log.Debug("failed to write json GenAddProjectMembers response", zap.Error(ErrMembersAPI.Wrap(err)))
+	}
+}
+
+func (h *ProjectMemberManagementHandler) handleGenDeleteProjectMembers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	ctx, err = h.auth.IsAuthenticated(ctx, r, true, true)
+	if err != nil {
+		h.auth.RemoveAuthCookie(w)
+		api.ServeError(h.log, w, http.StatusUnauthorized, err)
+		return
+	}
+
+	request := &console.ProjectMembersRequest{}
+	if err = json.NewDecoder(r.Body).Decode(&request); err != nil {
+		api.ServeError(h.log, w, http.StatusBadRequest, err)
+		return
+	}
+
+	httpErr := h.service.GenDeleteProjectMembers(ctx, *request)
+	if httpErr.Err != nil {
+		api.ServeError(h.log, w, httpErr.Status, httpErr.Err)
+	}
+}
+
 func (h *UserManagementHandler) handleGenGetUser(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	var err error
@@ -0,0 +1,333 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleapi_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/testrand"
+	"storj.io/common/uuid"
+	"storj.io/storj/private/api"
+	"storj.io/storj/satellite/accounting"
+	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/console/consoleweb/consoleapi"
+)
+
+// These tests check the generated handlers without a satellite, against
+// services which record their arguments. The results of the handlers are
+// compared with the GraphQL api in consoleweb.TestGraphQLRESTCompatibility.
+
+func TestGeneratedAPIKeysHandlers(t *testing.T) {
+	service := &apiKeysService{
+		page: &console.APIKeyPage{
+			APIKeys:     []console.APIKeyInfo{{ID: testrand.UUID(), Name: "key"}},
+			Limit:       1,
+			PageCount:   2,
+			CurrentPage: 1,
+			TotalCount:  2,
+			NextCursor:  "next",
+		},
+	}
+	auth := &auth{}
+	router := mux.NewRouter()
+	consoleapi.NewAPIKeyManagement(zaptest.NewLogger(t), service, router, auth)
+
+	projectID := testrand.UUID()
+
+	t.Run("paged", func(t *testing.T) {
+		query := url.Values{
+			"projectID":      {projectID.String()},
+			"cursor":         {"opaque"},
+			"search":         {"k"},
+			"limit":          {"1"},
+			"order":          {"2"},
+			"orderDirection": {"1"},
+		}
+		status, body := serve(t, router, http.MethodGet, "/api/v0/apikeys/paged?"+query.Encode(), nil)
+		require.Equal(t, http.StatusOK, status, body)
+
+		require.Equal(t, projectID, service.projectID)
+		require.Equal(t, "opaque", service.cursor)
+		require.Equal(t, "k", service.search)
+		require.EqualValues(t, 1, service.limit)
+		require.Equal(t, console.CreationDate, service.order)
+		require.Equal(t, console.Ascending, service.orderDirection)
+
+		var page console.APIKeyPage
+		require.NoError(t, json.Unmarshal([]byte(body), &page))
+		require.Equal(t, *service.page, page)
+	})
+
+	t.Run("paged without optional params", func(t *testing.T) {
+		status, body := serve(t, router, http.MethodGet, "/api/v0/apikeys/paged?projectID="+projectID.String(), nil)
+		require.Equal(t, http.StatusOK, status, body)
+
+		require.Equal(t, "", service.cursor)
+		require.Equal(t, "", service.search)
+		require.EqualValues(t, 0, service.limit)
+		require.EqualValues(t, 0, service.order)
+		require.EqualValues(t, 0, service.orderDirection)
+	})
+
+	t.Run("paged with invalid params", func(t *testing.T) {
+		status, _ := serve(t, router, http.MethodGet, "/api/v0/apikeys/paged", nil)
+		require.Equal(t, http.StatusBadRequest, status)
+
+		status, _ = serve(t, router, http.MethodGet, "/api/v0/apikeys/paged?projectID="+projectID.String()+"&limit=-1", nil)
+		require.Equal(t, http.StatusBadRequest, status)
+
+		status, _ = serve(t, router, http.MethodGet, "/api/v0/apikeys/paged?projectID="+projectID.String()+"&order=256", nil)
+		require.Equal(t, http.StatusBadRequest, status)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		service.err = api.HTTPError{Status: http.StatusUnauthorized, Err: errs.New("not a member")}
+		defer func() { service.err = api.HTTPError{} }()
+
+		status, body := serve(t, router, http.MethodGet, "/api/v0/apikeys/paged?projectID="+projectID.String(), nil)
+		require.Equal(t, http.StatusUnauthorized, status)
+		require.Contains(t, body, "not a member")
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		auth.err = errs.New("no token")
+		defer func() { auth.err = nil }()
+
+		service.projectID = uuid.UUID{}
+		status, _ := serve(t, router, http.MethodGet, "/api/v0/apikeys/paged?projectID="+projectID.String(), nil)
+		require.Equal(t, http.StatusUnauthorized, status)
+		require.True(t, auth.cookieRemoved)
+		require.True(t, service.projectID.IsZero())
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		id := testrand.UUID()
+		status, body := serve(t, router, http.MethodDelete, "/api/v0/apikeys/delete/"+id.String(), nil)
+		require.Equal(t, http.StatusOK, status, body)
+		require.Equal(t, []uuid.UUID{id}, service.deleted)
+
+		status, _ = serve(t, router, http.MethodDelete, "/api/v0/apikeys/delete/invalid", nil)
+		require.Equal(t, http.StatusBadRequest, status)
+	})
+
+	t.Run("bulk delete", func(t *testing.T) {
+		ids := []uuid.UUID{testrand.UUID(), testrand.UUID()}
+		status, body := serve(t, router, http.MethodPost, "/api/v0/apikeys/delete", console.DeleteAPIKeysRequest{IDs: ids})
+		require.Equal(t, http.StatusOK, status, body)
+		require.Equal(t, ids, service.deleted)
+
+		status, _ = serve(t, router, http.MethodPost, "/api/v0/apikeys/delete", "not a request")
+		require.Equal(t, http.StatusBadRequest, status)
+	})
+}
+
+func TestGeneratedProjectsHandlers(t *testing.T) {
+	service := &projectsService{}
+	router := mux.NewRouter()
+	consoleapi.NewProjectManagement(zaptest.NewLogger(t), service, router, &auth{})
+
+	t.Run("owned projects", func(t *testing.T) {
+		service.projectsPage = &console.ProjectsPage{
+			Projects:   []console.Project{{ID: testrand.UUID(), Name: "project"}},
+			NextCursor: "next",
+		}
+
+		status, body := serve(t, router, http.MethodGet, "/api/v0/projects/paged?cursor=opaque&limit=3", nil)
+		require.Equal(t, http.StatusOK, status, body)
+		require.Equal(t, "opaque", service.cursor)
+		require.EqualValues(t, 3, service.limit)
+
+		var page console.ProjectsPage
+		require.NoError(t, json.Unmarshal([]byte(body), &page))
+		require.Equal(t, "next", page.NextCursor)
+		require.Len(t, page.Projects, 1)
+		require.Equal(t, service.projectsPage.Projects[0].ID, page.Projects[0].ID)
+	})
+
+	t.Run("bucket totals", func(t *testing.T) {
+		service.bucketsPage = &accounting.BucketUsagePage{
+			BucketUsages: []accounting.BucketUsage{{BucketName: "bucket", Storage: 1.5}},
+			NextCursor:   "next",
+		}
+
+		projectID := testrand.UUID()
+		before := time.Date(2022, 5, 1, 12, 0, 0, 0, time.UTC)
+		query := url.Values{
+			"projectID": {projectID.String()},
+			"cursor":    {"opaque"},
+			"before":    {before.Format("2006-01-02T15:04:05.000Z")},
+		}
+		status, body := serve(t, router, http.MethodGet, "/api/v0/projects/bucket-totals?"+query.Encode(), nil)
+		require.Equal(t, http.StatusOK, status, body)
+		require.Equal(t, projectID, service.projectID)
+		require.Equal(t, "opaque", service.cursor)
+		require.EqualValues(t, 0, service.limit)
+		require.True(t, before.Equal(service.before))
+
+		var page accounting.BucketUsagePage
+		require.NoError(t, json.Unmarshal([]byte(body), &page))
+		require.Equal(t, *service.bucketsPage, page)
+
+		delete(query, "before")
+		status, _ = serve(t, router, http.MethodGet, "/api/v0/projects/bucket-totals?"+query.Encode(), nil)
+		require.Equal(t, http.StatusBadRequest, status)
+	})
+}
+
+func TestGeneratedProjectMembersHandlers(t *testing.T) {
+	service := &membersService{}
+	router := mux.NewRouter()
+	consoleapi.NewProjectMemberManagement(zaptest.NewLogger(t), service, router, &auth{})
+
+	request := console.ProjectMembersRequest{
+		ProjectID: testrand.UUID(),
+		Emails:    []string{"member@mail.test"},
+	}
+
+	t.Run("paged", func(t *testing.T) {
+		service.page = &console.ProjectMemberInfosPage{
+			ProjectMembers: []console.ProjectMemberInfo{{ID: testrand.UUID(), Email: "member@mail.test"}},
+			NextCursor:     "next",
+		}
+
+		status, body := serve(t, router, http.MethodGet, "/api/v0/members/paged?projectID="+request.ProjectID.String()+"&cursor=opaque", nil)
+		require.Equal(t, http.StatusOK, status, body)
+		require.Equal(t, request.ProjectID, service.projectID)
+		require.Equal(t, "opaque", service.cursor)
+
+		var page console.ProjectMemberInfosPage
+		require.NoError(t, json.Unmarshal([]byte(body), &page))
+		require.Equal(t, "next", page.NextCursor)
+		require.Equal(t, service.page.ProjectMembers[0].Email, page.ProjectMembers[0].Email)
+	})
+
+	t.Run("add and delete", func(t *testing.T) {
+		status, body := serve(t, router, http.MethodPost, "/api/v0/members/add", request)
+		require.Equal(t, http.StatusOK, status, body)
+		require.Equal(t, request, service.added)
+
+		status, body = serve(t, router, http.MethodPost, "/api/v0/members/delete", request)
+		require.Equal(t, http.StatusOK, status, body)
+		require.Equal(t, request, service.deleted)
+	})
+}
+
+// serve serves the request with the router and returns the status and the body of the response.
+func serve(t *testing.T, router http.Handler, method, target string, data interface{}) (int, string) {
+	var body bytes.Buffer
+	if data != nil {
+		require.NoError(t, json.NewEncoder(&body).Encode(data))
+	}
+
+	req := httptest.NewRequest(method, target, &body)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	return rr.Code, rr.Body.String()
+}
+
+// auth authenticates every request unless err is set.
+type auth struct {
+	err           error
+	cookieRemoved bool
+}
+
+func (a *auth) IsAuthenticated(ctx context.Context, r *http.Request, isCookieAuth, isKeyAuth bool) (context.Context, error) {
+	return ctx, a.err
+}
+
+func (a *auth) RemoveAuthCookie(w http.ResponseWriter) {
+	a.cookieRemoved = true
+}
+
+// apiKeysService records the arguments of the api keys handlers.
+type apiKeysService struct {
+	page *console.APIKeyPage
+	err  api.HTTPError
+
+	projectID      uuid.UUID
+	cursor, search string
+	limit          uint
+	order          console.APIKeyOrder
+	orderDirection console.OrderDirection
+	deleted        []uuid.UUID
+}
+
+func (s *apiKeysService) GenCreateAPIKey(ctx context.Context, request console.CreateAPIKeyRequest) (*console.CreateAPIKeyResponse, api.HTTPError) {
+	return &console.CreateAPIKeyResponse{}, s.err
+}
+
+func (s *apiKeysService) GenGetAPIKeys(ctx context.Context, projectID uuid.UUID, cursor, search string, limit uint, order console.APIKeyOrder, orderDirection console.OrderDirection) (*console.APIKeyPage, api.HTTPError) {
+	s.projectID, s.cursor, s.search, s.limit, s.order, s.orderDirection = projectID, cursor, search, limit, order, orderDirection
+	return s.page, s.err
+}
+
+func (s *apiKeysService) GenDeleteAPIKey(ctx context.Context, id uuid.UUID) api.HTTPError {
+	s.deleted = []uuid.UUID{id}
+	return s.err
+}
+
+func (s *apiKeysService) GenDeleteAPIKeys(ctx context.Context, request console.DeleteAPIKeysRequest) api.HTTPError {
+	s.deleted = request.IDs
+	return s.err
+}
+
+// projectsService records the arguments of the paged projects handlers.
+type projectsService struct {
+	consoleapi.ProjectManagementService
+
+	projectsPage *console.ProjectsPage
+	bucketsPage  *accounting.BucketUsagePage
+
+	projectID      uuid.UUID
+	cursor, search string
+	limit          uint
+	before         time.Time
+}
+
+func (s *projectsService) GenGetUsersOwnedProjectsPage(ctx context.Context, cursor string, limit uint) (*console.ProjectsPage, api.HTTPError) {
+	s.cursor, s.limit = cursor, limit
+	return s.projectsPage, api.HTTPError{}
+}
+
+func (s *projectsService) GenGetBucketTotals(ctx context.Context, projectID uuid.UUID, cursor, search string, limit uint, before time.Time) (*accounting.BucketUsagePage, api.HTTPError) {
+	s.projectID, s.cursor, s.search, s.limit, s.before = projectID, cursor, search, limit, before
+	return s.bucketsPage, api.HTTPError{}
+}
+
+// membersService records the arguments of the project members handlers.
+type membersService struct {
+	page *console.ProjectMemberInfosPage
+
+	projectID      uuid.UUID
+	cursor         string
+	added, deleted console.ProjectMembersRequest
+}
+
+func (s *membersService) GenGetProjectMembers(ctx context.Context, projectID uuid.UUID, cursor, search string, limit uint, order console.ProjectMemberOrder, orderDirection console.OrderDirection) (*console.ProjectMemberInfosPage, api.HTTPError) {
+	s.projectID, s.cursor = projectID, cursor
+	return s.page, api.HTTPError{}
+}
+
+func (s *membersService) GenAddProjectMembers(ctx context.Context, request console.ProjectMembersRequest) ([]console.ProjectMemberInfo, api.HTTPError) {
+	s.added = request
+	return nil, api.HTTPError{}
+}
+
+func (s *membersService) GenDeleteProjectMembers(ctx context.Context, request console.ProjectMembersRequest) api.HTTPError {
+	s.deleted = request
+	return api.HTTPError{}
+}
@@ -0,0 +1,28 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleapi
+
+import (
+	_ "embed" // used for embedding the generated api documentation.
+	"net/http"
+
+	"go.uber.org/zap"
+)
+
+// APIDocsPath is the path the generated api documentation is served at.
+const APIDocsPath = "/api/v0/apidocs.json"
+
+//go:embed apidocs.gen.json
+var apiDocs []byte
+
+// ServeAPIDocs returns a handler serving the OpenAPI documentation of the generated api.
+func ServeAPIDocs(log *zap.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if _, err := w.Write(apiDocs); err != nil {
+			log.Debug("failed to write api docs", zap.Error(err))
+		}
+	}
+}
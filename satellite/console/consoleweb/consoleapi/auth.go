@@ -718,6 +718,65 @@ func (a *Auth) GenerateMFARecoveryCodes(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// GetSessions lists the active sessions of the user.
+func (a *Auth) GetSessions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	sessions, err := a.service.GetSessions(ctx)
+	if err != nil {
+		a.serveJSONError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(sessions)
+	if err != nil {
+		a.log.Error("could not encode sessions", zap.Error(ErrAuthAPI.Wrap(err)))
+		return
+	}
+}
+
+// RevokeSession signs the user out of one of their sessions.
+func (a *Auth) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	sessionID, err := uuid.FromString(mux.Vars(r)["id"])
+	if err != nil {
+		serveJSONError(a.log, w, http.StatusBadRequest, ErrAuthAPI.Wrap(err))
+		return
+	}
+
+	err = a.service.RevokeSession(ctx, sessionID)
+	if err != nil {
+		a.serveJSONError(w, err)
+		return
+	}
+}
+
+// RevokeOtherSessions signs the user out of all sessions except the current one.
+func (a *Auth) RevokeOtherSessions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	revoked, err := a.service.RevokeOtherSessions(ctx)
+	if err != nil {
+		a.serveJSONError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(map[string]int{"revoked": revoked})
+	if err != nil {
+		a.log.Error("could not encode revoked sessions", zap.Error(ErrAuthAPI.Wrap(err)))
+		return
+	}
+}
+
 // ResetPassword resets user's password using recovery token.
 func (a *Auth) ResetPassword(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -774,6 +833,8 @@ func (a *Auth) getStatusCode(err error) int {
 		return http.StatusUnauthorized
 	case console.ErrEmailUsed.Has(err), console.ErrMFAConflict.Has(err):
 		return http.StatusConflict
+	case console.ErrSSORequired.Has(err):
+		return http.StatusForbidden
 	case errors.Is(err, errNotImplemented):
 		return http.StatusNotImplemented
 	case console.ErrMFAPasscode.Has(err), console.ErrMFARecoveryCode.Has(err):
@@ -811,6 +872,8 @@ func (a *Auth) getUserErrorMessage(err error) string {
 		return "Your login credentials are incorrect. You have just used up one of your login attempts"
 	case console.ErrLockedAccount.Has(err):
 		return err.Error()
+	case console.ErrSSORequired.Has(err):
+		return "Your organization requires you to sign in with single sign-on"
 	case errors.Is(err, errNotImplemented):
 		return "The server is incapable of fulfilling the request"
 	default:
@@ -0,0 +1,254 @@
+// AUTOGENERATED BY private/apigen
+// DO NOT EDIT.
+
+package consoleclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/accounting"
+	"storj.io/storj/satellite/console"
+)
+
+const dateLayout = "2006-01-02T15:04:05.000Z"
+
+// Error is returned when the API responds with an error.
+type Error struct {
+	Status  int
+	Message string
+}
+
+// Error implements the error interface.
+func (err *Error) Error() string {
+	return fmt.Sprintf("%s (status %d)", err.Message, err.Status)
+}
+
+// Client is a client for the v0 API.
+type Client struct {
+	URL        string
+	APIKey     string
+	HTTPClient *http.Client
+}
+
+// NewClient returns a client for the API served at the url, which
+// authenticates with the API key.
+func NewClient(url, apiKey string) *Client {
+	return &Client{
+		URL:        strings.TrimSuffix(url, "/"),
+		APIKey:     apiKey,
+		HTTPClient: http.DefaultClient,
+	}
+}
+
+// do sends the request and decodes the response into response, when it's not nil.
+func (client *Client) do(ctx context.Context, method, path string, query url.Values, request, response interface{}) (err error) {
+	var body io.Reader
+	if request != nil {
+		data, err := json.Marshal(request)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	target := client.URL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if client.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+client.APIKey)
+	}
+
+	resp, err := client.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, resp.Body.Close()) }()
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Error string `json:"error"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&apiErr)
+		return &Error{Status: resp.StatusCode, Message: apiErr.Error}
+	}
+
+	if response == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(response)
+}
+
+// GenCreateProject creates new Project with given info.
+func (client *Client) GenCreateProject(ctx context.Context, projectInfo console.ProjectInfo) (*console.Project, error) {
+	var response *console.Project
+	err := client.do(ctx, http.MethodPost, "/api/v0/projects/create", nil, projectInfo, &response)
+	return response, err
+}
+
+// GenUpdateProject updates project with given info.
+func (client *Client) GenUpdateProject(ctx context.Context, id uuid.UUID, projectInfo console.ProjectInfo) (*console.Project, error) {
+	var response *console.Project
+	err := client.do(ctx, http.MethodPatch, "/api/v0/projects/update/"+url.PathEscape(id.String()), nil, projectInfo, &response)
+	return response, err
+}
+
+// GenDeleteProject deletes project by id.
+func (client *Client) GenDeleteProject(ctx context.Context, id uuid.UUID) error {
+	return client.do(ctx, http.MethodDelete, "/api/v0/projects/delete/"+url.PathEscape(id.String()), nil, nil, nil)
+}
+
+// GenGetUsersProjects gets all projects user has.
+func (client *Client) GenGetUsersProjects(ctx context.Context) ([]console.Project, error) {
+	var response []console.Project
+	err := client.do(ctx, http.MethodGet, "/api/v0/projects/", nil, nil, &response)
+	return response, err
+}
+
+// GenGetProject gets project by id.
+func (client *Client) GenGetProject(ctx context.Context, id uuid.UUID) (*console.Project, error) {
+	query := url.Values{}
+	query.Set("id", id.String())
+	var response *console.Project
+	err := client.do(ctx, http.MethodGet, "/api/v0/projects/project", query, nil, &response)
+	return response, err
+}
+
+// GenGetUsersOwnedProjectsPage gets paged projects user owns, starting at the page of the cursor or the first page of the limit.
+func (client *Client) GenGetUsersOwnedProjectsPage(ctx context.Context, cursor string, limit uint) (*console.ProjectsPage, error) {
+	query := url.Values{}
+	query.Set("cursor", cursor)
+	query.Set("limit", strconv.FormatUint(uint64(limit), 10))
+	var response *console.ProjectsPage
+	err := client.do(ctx, http.MethodGet, "/api/v0/projects/paged", query, nil, &response)
+	return response, err
+}
+
+// GenGetProjectUsage gets project's storage, egress, object and segment usage for the period.
+func (client *Client) GenGetProjectUsage(ctx context.Context, projectID uuid.UUID, since time.Time, before time.Time) (*accounting.ProjectUsage, error) {
+	query := url.Values{}
+	query.Set("projectID", projectID.String())
+	query.Set("since", since.Format(dateLayout))
+	query.Set("before", before.Format(dateLayout))
+	var response *accounting.ProjectUsage
+	err := client.do(ctx, http.MethodGet, "/api/v0/projects/usage", query, nil, &response)
+	return response, err
+}
+
+// GenGetBucketTotals gets paged total usage of project's buckets since their creation, starting at the page of the cursor or the first page of the search and limit.
+func (client *Client) GenGetBucketTotals(ctx context.Context, projectID uuid.UUID, cursor string, search string, limit uint, before time.Time) (*accounting.BucketUsagePage, error) {
+	query := url.Values{}
+	query.Set("projectID", projectID.String())
+	query.Set("cursor", cursor)
+	query.Set("search", search)
+	query.Set("limit", strconv.FormatUint(uint64(limit), 10))
+	query.Set("before", before.Format(dateLayout))
+	var response *accounting.BucketUsagePage
+	err := client.do(ctx, http.MethodGet, "/api/v0/projects/bucket-totals", query, nil, &response)
+	return response, err
+}
+
+// GenGetSingleBucketUsageRollup gets project's single bucket usage by bucket ID.
+func (client *Client) GenGetSingleBucketUsageRollup(ctx context.Context, projectID uuid.UUID, bucket string, since time.Time, before time.Time) (*accounting.BucketUsageRollup, error) {
+	query := url.Values{}
+	query.Set("projectID", projectID.String())
+	query.Set("bucket", bucket)
+	query.Set("since", since.Format(dateLayout))
+	query.Set("before", before.Format(dateLayout))
+	var response *accounting.BucketUsageRollup
+	err := client.do(ctx, http.MethodGet, "/api/v0/projects/bucket-rollup", query, nil, &response)
+	return response, err
+}
+
+// GenGetBucketUsageRollups gets project's all buckets usage.
+func (client *Client) GenGetBucketUsageRollups(ctx context.Context, projectID uuid.UUID, since time.Time, before time.Time) ([]accounting.BucketUsageRollup, error) {
+	query := url.Values{}
+	query.Set("projectID", projectID.String())
+	query.Set("since", since.Format(dateLayout))
+	query.Set("before", before.Format(dateLayout))
+	var response []accounting.BucketUsageRollup
+	err := client.do(ctx, http.MethodGet, "/api/v0/projects/bucket-rollups", query, nil, &response)
+	return response, err
+}
+
+// GenCreateAPIKey creates new macaroon API key with given info.
+func (client *Client) GenCreateAPIKey(ctx context.Context, apikeyInfo console.CreateAPIKeyRequest) (*console.CreateAPIKeyResponse, error) {
+	var response *console.CreateAPIKeyResponse
+	err := client.do(ctx, http.MethodPost, "/api/v0/apikeys/create", nil, apikeyInfo, &response)
+	return response, err
+}
+
+// GenGetAPIKeys gets paged API keys of the project, starting at the page of the cursor or the first page of the search, limit and order.
+func (client *Client) GenGetAPIKeys(ctx context.Context, projectID uuid.UUID, cursor string, search string, limit uint, order console.APIKeyOrder, orderDirection console.OrderDirection) (*console.APIKeyPage, error) {
+	query := url.Values{}
+	query.Set("projectID", projectID.String())
+	query.Set("cursor", cursor)
+	query.Set("search", search)
+	query.Set("limit", strconv.FormatUint(uint64(limit), 10))
+	query.Set("order", strconv.FormatUint(uint64(order), 10))
+	query.Set("orderDirection", strconv.FormatUint(uint64(orderDirection), 10))
+	var response *console.APIKeyPage
+	err := client.do(ctx, http.MethodGet, "/api/v0/apikeys/paged", query, nil, &response)
+	return response, err
+}
+
+// GenDeleteAPIKey deletes macaroon API key by id.
+func (client *Client) GenDeleteAPIKey(ctx context.Context, id uuid.UUID) error {
+	return client.do(ctx, http.MethodDelete, "/api/v0/apikeys/delete/"+url.PathEscape(id.String()), nil, nil, nil)
+}
+
+// GenDeleteAPIKeys deletes macaroon API keys by ids.
+func (client *Client) GenDeleteAPIKeys(ctx context.Context, request console.DeleteAPIKeysRequest) error {
+	return client.do(ctx, http.MethodPost, "/api/v0/apikeys/delete", nil, request, nil)
+}
+
+// GenGetProjectMembers gets paged members of the project, starting at the page of the cursor or the first page of the search, limit and order.
+func (client *Client) GenGetProjectMembers(ctx context.Context, projectID uuid.UUID, cursor string, search string, limit uint, order console.ProjectMemberOrder, orderDirection console.OrderDirection) (*console.ProjectMemberInfosPage, error) {
+	query := url.Values{}
+	query.Set("projectID", projectID.String())
+	query.Set("cursor", cursor)
+	query.Set("search", search)
+	query.Set("limit", strconv.FormatUint(uint64(limit), 10))
+	query.Set("order", strconv.FormatInt(int64(order), 10))
+	query.Set("orderDirection", strconv.FormatUint(uint64(orderDirection), 10))
+	var response *console.ProjectMemberInfosPage
+	err := client.do(ctx, http.MethodGet, "/api/v0/members/paged", query, nil, &response)
+	return response, err
+}
+
+// GenAddProjectMembers adds users with given emails to the project.
+func (client *Client) GenAddProjectMembers(ctx context.Context, request console.ProjectMembersRequest) ([]console.ProjectMemberInfo, error) {
+	var response []console.ProjectMemberInfo
+	err := client.do(ctx, http.MethodPost, "/api/v0/members/add", nil, request, &response)
+	return response, err
+}
+
+// GenDeleteProjectMembers removes users with given emails from the project.
+func (client *Client) GenDeleteProjectMembers(ctx context.Context, request console.ProjectMembersRequest) error {
+	return client.do(ctx, http.MethodPost, "/api/v0/members/delete", nil, request, nil)
+}
+
+// GenGetUser gets User by request context.
+func (client *Client) GenGetUser(ctx context.Context) (*console.ResponseUser, error) {
+	var response *console.ResponseUser
+	err := client.do(ctx, http.MethodGet, "/api/v0/users/", nil, nil, &response)
+	return response, err
+}
@@ -63,6 +63,53 @@ func main() {
 			Response:    []console.Project{},
 		})
 
+		g.Get("/project", &apigen.Endpoint{
+			Name:        "Get Project",
+			Description: "Gets project by id",
+			MethodName:  "GenGetProject",
+			Response:    &console.Project{},
+			Params: []apigen.Param{
+				apigen.NewParam("id", uuid.UUID{}),
+			},
+		})
+
+		g.Get("/paged", &apigen.Endpoint{
+			Name:        "Get Owned Projects Page",
+			Description: "Gets paged projects user owns, starting at the page of the cursor or the first page of the limit",
+			MethodName:  "GenGetUsersOwnedProjectsPage",
+			Response:    &console.ProjectsPage{},
+			Params: []apigen.Param{
+				apigen.NewOptionalParam("cursor", ""),
+				apigen.NewOptionalParam("limit", uint(0)),
+			},
+		})
+
+		g.Get("/usage", &apigen.Endpoint{
+			Name:        "Get Project Usage",
+			Description: "Gets project's storage, egress, object and segment usage for the period",
+			MethodName:  "GenGetProjectUsage",
+			Response:    &accounting.ProjectUsage{},
+			Params: []apigen.Param{
+				apigen.NewParam("projectID", uuid.UUID{}),
+				apigen.NewParam("since", time.Time{}),
+				apigen.NewParam("before", time.Time{}),
+			},
+		})
+
+		g.Get("/bucket-totals", &apigen.Endpoint{
+			Name:        "Get Project's Bucket Totals",
+			Description: "Gets paged total usage of project's buckets since their creation, starting at the page of the cursor or the first page of the search and limit",
+			MethodName:  "GenGetBucketTotals",
+			Response:    &accounting.BucketUsagePage{},
+			Params: []apigen.Param{
+				apigen.NewParam("projectID", uuid.UUID{}),
+				apigen.NewOptionalParam("cursor", ""),
+				apigen.NewOptionalParam("search", ""),
+				apigen.NewOptionalParam("limit", uint(0)),
+				apigen.NewParam("before", time.Time{}),
+			},
+		})
+
 		g.Get("/bucket-rollup", &apigen.Endpoint{
 			Name:        "Get Project's Single Bucket Usage",
 			Description: "Gets project's single bucket usage by bucket ID",
@@ -101,6 +148,80 @@ func main() {
 				apigen.NewParam("apikeyInfo", console.CreateAPIKeyRequest{}),
 			},
 		})
+
+		g.Get("/paged", &apigen.Endpoint{
+			Name:        "Get API Keys Page",
+			Description: "Gets paged API keys of the project, starting at the page of the cursor or the first page of the search, limit and order",
+			MethodName:  "GenGetAPIKeys",
+			Response:    &console.APIKeyPage{},
+			Params: []apigen.Param{
+				apigen.NewParam("projectID", uuid.UUID{}),
+				apigen.NewOptionalParam("cursor", ""),
+				apigen.NewOptionalParam("search", ""),
+				apigen.NewOptionalParam("limit", uint(0)),
+				apigen.NewOptionalParam("order", console.APIKeyOrder(0)),
+				apigen.NewOptionalParam("orderDirection", console.OrderDirection(0)),
+			},
+		})
+
+		g.Delete("/delete/{id}", &apigen.Endpoint{
+			Name:        "Delete API Key",
+			Description: "Deletes macaroon API key by id",
+			MethodName:  "GenDeleteAPIKey",
+			Response:    nil,
+			Params: []apigen.Param{
+				apigen.NewParam("id", uuid.UUID{}),
+			},
+		})
+
+		g.Post("/delete", &apigen.Endpoint{
+			Name:        "Delete API Keys",
+			Description: "Deletes macaroon API keys by ids",
+			MethodName:  "GenDeleteAPIKeys",
+			Response:    nil,
+			Params: []apigen.Param{
+				apigen.NewParam("request", console.DeleteAPIKeysRequest{}),
+			},
+		})
+	}
+
+	{
+		g := a.Group("ProjectMemberManagement", "members")
+
+		g.Get("/paged", &apigen.Endpoint{
+			Name:        "Get Project Members Page",
+			Description: "Gets paged members of the project, starting at the page of the cursor or the first page of the search, limit and order",
+			MethodName:  "GenGetProjectMembers",
+			Response:    &console.ProjectMemberInfosPage{},
+			Params: []apigen.Param{
+				apigen.NewParam("projectID", uuid.UUID{}),
+				apigen.NewOptionalParam("cursor", ""),
+				apigen.NewOptionalParam("search", ""),
+				apigen.NewOptionalParam("limit", uint(0)),
+				apigen.NewOptionalParam("order", console.ProjectMemberOrder(0)),
+				apigen.NewOptionalParam("orderDirection", console.OrderDirection(0)),
+			},
+		})
+
+		g.Post("/add", &apigen.Endpoint{
+			Name:        "Add Project Members",
+			Description: "Adds users with given emails to the project",
+			MethodName:  "GenAddProjectMembers",
+			Response:    []console.ProjectMemberInfo{},
+			Params: []apigen.Param{
+				apigen.NewParam("request", console.ProjectMembersRequest{}),
+			},
+		})
+
+		g.Post("/delete", &apigen.Endpoint{
+			Name:        "Delete Project Members",
+			Description: "Removes users with given emails from the project",
+			MethodName:  "GenDeleteProjectMembers",
+			Response:    nil,
+			Params: []apigen.Param{
+				apigen.NewParam("request", console.ProjectMembersRequest{}),
+			},
+		})
 	}
 
 	{
@@ -115,4 +236,7 @@ func main() {
 	}
 
 	a.MustWriteGo("satellite/console/consoleweb/consoleapi/api.gen.go")
+	a.MustWriteOpenAPI("satellite/console/consoleweb/consoleapi/apidocs.gen.json")
+	a.MustWriteGoClient("satellite/console/consoleweb/consoleapi/consoleclient/client.gen.go", "consoleclient")
+	a.MustWriteTS("web/satellite/src/api/v0.gen.ts")
 }
@@ -0,0 +1,421 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleapi
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/console"
+)
+
+var (
+	// ErrOrganizationsAPI - console organizations api error type.
+	ErrOrganizationsAPI = errs.Class("console organizations")
+)
+
+// Organizations is an api controller that exposes organizations related functionality.
+type Organizations struct {
+	log     *zap.Logger
+	service *console.Service
+}
+
+// NewOrganizations is a constructor for api organizations controller.
+func NewOrganizations(log *zap.Logger, service *console.Service) *Organizations {
+	return &Organizations{
+		log:     log,
+		service: service,
+	}
+}
+
+// Create creates a new organization administered by the user.
+func (o *Organizations) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	var request struct {
+		Name string `json:"name"`
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		o.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	organization, err := o.service.CreateOrganization(ctx, request.Name)
+	if err != nil {
+		o.serveServiceError(w, err)
+		return
+	}
+
+	o.serveJSON(w, organization)
+}
+
+// List returns the organizations the user is a member of.
+func (o *Organizations) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	organizations, err := o.service.GetOrganizations(ctx)
+	if err != nil {
+		o.serveServiceError(w, err)
+		return
+	}
+
+	o.serveJSON(w, organizations)
+}
+
+// Get returns the organization.
+func (o *Organizations) Get(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	id, err := o.organizationID(r)
+	if err != nil {
+		o.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	organization, err := o.service.GetOrganization(ctx, id)
+	if err != nil {
+		o.serveServiceError(w, err)
+		return
+	}
+
+	o.serveJSON(w, organization)
+}
+
+// Rename changes the name of the organization.
+func (o *Organizations) Rename(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	id, err := o.organizationID(r)
+	if err != nil {
+		o.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var request struct {
+		Name string `json:"name"`
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		o.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err = o.service.RenameOrganization(ctx, id, request.Name)
+	if err != nil {
+		o.serveServiceError(w, err)
+		return
+	}
+}
+
+// ListMembers returns the members of the organization.
+func (o *Organizations) ListMembers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	id, err := o.organizationID(r)
+	if err != nil {
+		o.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	members, err := o.service.GetOrganizationMembers(ctx, id)
+	if err != nil {
+		o.serveServiceError(w, err)
+		return
+	}
+
+	o.serveJSON(w, members)
+}
+
+// AddMember makes the user with the requested email a member of the organization.
+func (o *Organizations) AddMember(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	id, err := o.organizationID(r)
+	if err != nil {
+		o.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var request struct {
+		Email string `json:"email"`
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		o.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err = o.service.AddOrganizationMember(ctx, id, request.Email)
+	if err != nil {
+		o.serveServiceError(w, err)
+		return
+	}
+}
+
+// RemoveMember removes the user from the members of the organization.
+func (o *Organizations) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	id, err := o.organizationID(r)
+	if err != nil {
+		o.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	memberID, err := uuid.FromString(mux.Vars(r)["memberID"])
+	if err != nil {
+		o.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err = o.service.RemoveOrganizationMember(ctx, id, memberID)
+	if err != nil {
+		o.serveServiceError(w, err)
+		return
+	}
+}
+
+// ListProjects returns the projects of the organization.
+func (o *Organizations) ListProjects(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	id, err := o.organizationID(r)
+	if err != nil {
+		o.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	projects, err := o.service.GetOrganizationProjects(ctx, id)
+	if err != nil {
+		o.serveServiceError(w, err)
+		return
+	}
+
+	o.serveJSON(w, projects)
+}
+
+// MoveProject transfers a project the user owns to the organization.
+func (o *Organizations) MoveProject(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	id, err := o.organizationID(r)
+	if err != nil {
+		o.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var request struct {
+		ProjectID uuid.UUID `json:"projectId"`
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		o.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err = o.service.MoveProjectToOrganization(ctx, request.ProjectID, id)
+	if err != nil {
+		o.serveServiceError(w, err)
+		return
+	}
+}
+
+// AddCreditCard attaches a new credit card to the payment account of the organization.
+func (o *Organizations) AddCreditCard(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	id, err := o.organizationID(r)
+	if err != nil {
+		o.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	bodyBytes, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		o.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err = o.service.Payments().AddOrganizationCreditCard(ctx, id, string(bodyBytes))
+	if err != nil {
+		o.serveServiceError(w, err)
+		return
+	}
+}
+
+// ListCreditCards returns the credit cards of the payment account of the organization.
+func (o *Organizations) ListCreditCards(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	id, err := o.organizationID(r)
+	if err != nil {
+		o.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	cards, err := o.service.Payments().ListOrganizationCreditCards(ctx, id)
+	if err != nil {
+		o.serveServiceError(w, err)
+		return
+	}
+
+	if cards == nil {
+		w.Header().Set("Content-Type", "application/json")
+		_, err = w.Write([]byte("[]"))
+		if err != nil {
+			o.log.Error("failed to write json response", zap.Error(ErrOrganizationsAPI.Wrap(err)))
+		}
+		return
+	}
+
+	o.serveJSON(w, cards)
+}
+
+// ClaimWallet claims a deposit wallet for the payment account of the organization.
+func (o *Organizations) ClaimWallet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	id, err := o.organizationID(r)
+	if err != nil {
+		o.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	wallet, err := o.service.Payments().ClaimOrganizationWallet(ctx, id)
+	if err != nil {
+		o.serveServiceError(w, err)
+		return
+	}
+
+	o.serveJSON(w, wallet)
+}
+
+// GetWallet returns the deposit wallet of the payment account of the organization.
+func (o *Organizations) GetWallet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	id, err := o.organizationID(r)
+	if err != nil {
+		o.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	wallet, err := o.service.Payments().GetOrganizationWallet(ctx, id)
+	if err != nil {
+		o.serveServiceError(w, err)
+		return
+	}
+
+	o.serveJSON(w, wallet)
+}
+
+// ProjectsCharges returns the usage and the charges of every project of the organization.
+func (o *Organizations) ProjectsCharges(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	id, err := o.organizationID(r)
+	if err != nil {
+		o.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	sinceStamp, err := strconv.ParseInt(r.URL.Query().Get("from"), 10, 64)
+	if err != nil {
+		o.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+	beforeStamp, err := strconv.ParseInt(r.URL.Query().Get("to"), 10, 64)
+	if err != nil {
+		o.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	since := time.Unix(sinceStamp, 0).UTC()
+	before := time.Unix(beforeStamp, 0).UTC()
+
+	charges, err := o.service.Payments().OrganizationProjectsCharges(ctx, id, since, before)
+	if err != nil {
+		o.serveServiceError(w, err)
+		return
+	}
+
+	o.serveJSON(w, charges)
+}
+
+// organizationID parses the ID of the organization from the route.
+func (o *Organizations) organizationID(r *http.Request) (uuid.UUID, error) {
+	id, ok := mux.Vars(r)["id"]
+	if !ok {
+		return uuid.UUID{}, errs.New("missing id route param")
+	}
+
+	return uuid.FromString(id)
+}
+
+// serveJSON writes the value as JSON to response output stream.
+func (o *Organizations) serveJSON(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(value)
+	if err != nil {
+		o.log.Error("failed to write json response", zap.Error(ErrOrganizationsAPI.Wrap(err)))
+	}
+}
+
+// serveServiceError writes JSON error of the console service to response output stream.
+func (o *Organizations) serveServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case console.ErrUnauthorized.Has(err), console.ErrNoMembership.Has(err):
+		o.serveJSONError(w, http.StatusUnauthorized, err)
+	case console.ErrValidation.Has(err):
+		o.serveJSONError(w, http.StatusBadRequest, err)
+	default:
+		o.serveJSONError(w, http.StatusInternalServerError, err)
+	}
+}
+
+// serveJSONError writes JSON error to response output stream.
+func (o *Organizations) serveJSONError(w http.ResponseWriter, status int, err error) {
+	serveJSONError(o.log, w, status, err)
+}
@@ -0,0 +1,80 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleapi
+
+import (
+	"context"
+
+	"storj.io/storj/private/api"
+	"storj.io/storj/private/post"
+	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/console/consoleweb/consoleql"
+	"storj.io/storj/satellite/mailservice"
+)
+
+// ensures that ProjectMembers implements ProjectMemberManagementService.
+var _ ProjectMemberManagementService = (*ProjectMembers)(nil)
+
+// ProjectMembers implements the project members endpoints of the generated api.
+// It sends the same invitation emails as the GraphQL addProjectMembers mutation.
+type ProjectMembers struct {
+	*console.Service
+
+	mailService           *mailservice.Service
+	externalAddress       string
+	letUsKnowURL          string
+	termsAndConditionsURL string
+	contactInfoURL        string
+}
+
+// NewProjectMembers is a constructor for the generated api project members service.
+func NewProjectMembers(service *console.Service, mailService *mailservice.Service, externalAddress, letUsKnowURL, termsAndConditionsURL, contactInfoURL string) *ProjectMembers {
+	return &ProjectMembers{
+		Service:               service,
+		mailService:           mailService,
+		externalAddress:       externalAddress,
+		letUsKnowURL:          letUsKnowURL,
+		termsAndConditionsURL: termsAndConditionsURL,
+		contactInfoURL:        contactInfoURL,
+	}
+}
+
+// GenAddProjectMembers adds users by email to given project and sends them an invitation.
+func (p *ProjectMembers) GenAddProjectMembers(ctx context.Context, request console.ProjectMembersRequest) (members []console.ProjectMemberInfo, httpError api.HTTPError) {
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	project, httpError := p.Service.GenGetProject(ctx, request.ProjectID)
+	if httpError.Err != nil {
+		return nil, httpError
+	}
+
+	members, httpError = p.Service.GenAddProjectMembers(ctx, request)
+	if httpError.Err != nil {
+		return nil, httpError
+	}
+
+	for _, member := range members {
+		userName := member.ShortName
+		if member.ShortName == "" {
+			userName = member.FullName
+		}
+
+		p.mailService.SendRenderedAsync(
+			ctx,
+			[]post.Address{{Address: member.Email, Name: userName}},
+			&consoleql.ProjectInvitationEmail{
+				Origin:                p.externalAddress,
+				UserName:              userName,
+				ProjectName:           project.Name,
+				SignInLink:            p.externalAddress + "login",
+				LetUsKnowURL:          p.letUsKnowURL,
+				TermsAndConditionsURL: p.termsAndConditionsURL,
+				ContactInfoURL:        p.contactInfoURL,
+			},
+		)
+	}
+
+	return members, api.HTTPError{}
+}
@@ -0,0 +1,136 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/console/restkeys"
+)
+
+var (
+	// ErrRESTKeysAPI - console rest keys api error type.
+	ErrRESTKeysAPI = errs.Class("console rest keys")
+)
+
+// RESTKeys is an api controller that exposes rest keys related functionality.
+type RESTKeys struct {
+	log     *zap.Logger
+	service *console.Service
+}
+
+// NewRESTKeys is a constructor for api rest keys controller.
+func NewRESTKeys(log *zap.Logger, service *console.Service) *RESTKeys {
+	return &RESTKeys{
+		log:     log,
+		service: service,
+	}
+}
+
+// Create creates a new rest key for the user, which is limited to the requested scope.
+func (keys *RESTKeys) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	var request struct {
+		Name       string               `json:"name"`
+		Scope      console.RESTKeyScope `json:"scope"`
+		Expiration string               `json:"expiration"`
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		keys.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var expiration time.Duration
+	if request.Expiration != "" {
+		expiration, err = time.ParseDuration(request.Expiration)
+		if err != nil {
+			keys.serveJSONError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	apiKey, expiresAt, err := keys.service.CreateRESTKey(ctx, request.Name, request.Scope, expiration)
+	if err != nil {
+		keys.serveServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(struct {
+		APIKey    string    `json:"apikey"`
+		ExpiresAt time.Time `json:"expiresAt"`
+	}{apiKey, expiresAt})
+	if err != nil {
+		keys.log.Error("failed to write json create rest key response", zap.Error(ErrRESTKeysAPI.Wrap(err)))
+	}
+}
+
+// List returns the unexpired rest keys of the user.
+func (keys *RESTKeys) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	list, err := keys.service.GetRESTKeys(ctx)
+	if err != nil {
+		keys.serveServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(list)
+	if err != nil {
+		keys.log.Error("failed to write json list rest keys response", zap.Error(ErrRESTKeysAPI.Wrap(err)))
+	}
+}
+
+// Revoke revokes a rest key of the user by its ID.
+func (keys *RESTKeys) Revoke(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	id, ok := mux.Vars(r)["id"]
+	if !ok {
+		keys.serveJSONError(w, http.StatusBadRequest, errs.New("missing id route param"))
+		return
+	}
+
+	err = keys.service.RevokeRESTKeyByID(ctx, id)
+	if err != nil {
+		keys.serveServiceError(w, err)
+		return
+	}
+}
+
+// serveServiceError writes JSON error of the console service to response output stream.
+func (keys *RESTKeys) serveServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case console.ErrUnauthorized.Has(err), console.ErrNoMembership.Has(err):
+		keys.serveJSONError(w, http.StatusUnauthorized, err)
+	case console.ErrRESTKeyScope.Has(err):
+		keys.serveJSONError(w, http.StatusBadRequest, err)
+	case restkeys.ErrInvalidKey.Has(err):
+		keys.serveJSONError(w, http.StatusNotFound, err)
+	default:
+		keys.serveJSONError(w, http.StatusInternalServerError, err)
+	}
+}
+
+// serveJSONError writes JSON error to response output stream.
+func (keys *RESTKeys) serveJSONError(w http.ResponseWriter, status int, err error) {
+	serveJSONError(keys.log, w, status, err)
+}
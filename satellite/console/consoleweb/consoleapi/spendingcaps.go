@@ -0,0 +1,143 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleapi
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/console"
+)
+
+var (
+	// ErrSpendingCapsAPI - console spending caps api error type.
+	ErrSpendingCapsAPI = errs.Class("console spending caps")
+)
+
+// SpendingCaps is an api controller that exposes the spending caps of projects.
+type SpendingCaps struct {
+	log     *zap.Logger
+	service *console.Service
+}
+
+// NewSpendingCaps is a constructor for api spending caps controller.
+func NewSpendingCaps(log *zap.Logger, service *console.Service) *SpendingCaps {
+	return &SpendingCaps{
+		log:     log,
+		service: service,
+	}
+}
+
+// ProjectSpending returns the spending cap and the charges of the project in the current month.
+func (sc *SpendingCaps) ProjectSpending(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	projectID, err := sc.projectID(r)
+	if err != nil {
+		sc.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	spending, err := sc.service.GetProjectSpending(ctx, projectID)
+	if err != nil {
+		sc.serveServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(spending)
+	if err != nil {
+		sc.log.Error("failed to write json project spending response", zap.Error(ErrSpendingCapsAPI.Wrap(err)))
+	}
+}
+
+// SetSpendingCap sets the monthly spending cap of the project.
+func (sc *SpendingCaps) SetSpendingCap(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	projectID, err := sc.projectID(r)
+	if err != nil {
+		sc.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var info console.ProjectSpendingCapInfo
+	err = json.NewDecoder(r.Body).Decode(&info)
+	if err != nil {
+		sc.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	spendingCap, err := sc.service.SetProjectSpendingCap(ctx, projectID, info)
+	if err != nil {
+		sc.serveServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(spendingCap)
+	if err != nil {
+		sc.log.Error("failed to write json set spending cap response", zap.Error(ErrSpendingCapsAPI.Wrap(err)))
+	}
+}
+
+// RemoveSpendingCap removes the monthly spending cap of the project.
+func (sc *SpendingCaps) RemoveSpendingCap(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	projectID, err := sc.projectID(r)
+	if err != nil {
+		sc.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err = sc.service.RemoveProjectSpendingCap(ctx, projectID)
+	if err != nil {
+		sc.serveServiceError(w, err)
+		return
+	}
+}
+
+// projectID parses the ID of the project from the route.
+func (sc *SpendingCaps) projectID(r *http.Request) (uuid.UUID, error) {
+	idParam, ok := mux.Vars(r)["id"]
+	if !ok {
+		return uuid.UUID{}, errs.New("missing project id route param")
+	}
+
+	projectID, err := uuid.FromString(idParam)
+	if err != nil {
+		return uuid.UUID{}, errs.New("invalid project id: %v", err)
+	}
+
+	return projectID, nil
+}
+
+// serveServiceError writes JSON error of the console service to response output stream.
+func (sc *SpendingCaps) serveServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case console.ErrUnauthorized.Has(err), console.ErrNoMembership.Has(err):
+		sc.serveJSONError(w, http.StatusUnauthorized, err)
+	case console.ErrValidation.Has(err):
+		sc.serveJSONError(w, http.StatusBadRequest, err)
+	default:
+		sc.serveJSONError(w, http.StatusInternalServerError, err)
+	}
+}
+
+// serveJSONError writes JSON error to response output stream.
+func (sc *SpendingCaps) serveJSONError(w http.ResponseWriter, status int, err error) {
+	serveJSONError(sc.log, w, status, err)
+}
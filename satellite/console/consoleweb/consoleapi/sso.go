@@ -0,0 +1,146 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleapi
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/private/web"
+	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/console/consoleweb/consolewebauth"
+	"storj.io/storj/satellite/console/sso"
+)
+
+const ssoNonceCookieName = "_ssoNonce"
+
+// ErrSSOAPI - console single sign-on api error type.
+var ErrSSOAPI = errs.Class("consoleapi sso")
+
+// SSO is an api controller that signs users in with the identity providers
+// of their domains.
+type SSO struct {
+	log             *zap.Logger
+	service         *console.Service
+	sso             *sso.Service
+	cookieAuth      *consolewebauth.CookieAuth
+	externalAddress string
+	callbackURL     string
+	stateExpiration time.Duration
+}
+
+// NewSSO is a constructor for api single sign-on controller.
+func NewSSO(log *zap.Logger, service *console.Service, ssoService *sso.Service, cookieAuth *consolewebauth.CookieAuth, externalAddress string, stateExpiration time.Duration) *SSO {
+	return &SSO{
+		log:             log,
+		service:         service,
+		sso:             ssoService,
+		cookieAuth:      cookieAuth,
+		externalAddress: externalAddress,
+		callbackURL:     externalAddress + "api/v0/auth/sso/callback",
+		stateExpiration: stateExpiration,
+	}
+}
+
+// Login redirects the user to the identity provider of the domain of their
+// email address.
+func (s *SSO) Login(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	email := r.URL.Query().Get("email")
+	if email == "" {
+		serveJSONError(s.log, w, http.StatusBadRequest, ErrSSOAPI.New("email is required"))
+		return
+	}
+
+	authURL, nonce, err := s.sso.AuthURL(ctx, email, s.callbackURL)
+	if err != nil {
+		s.serveJSONError(w, err)
+		return
+	}
+
+	// the nonce binds the sign in to this user agent. The cookie has to be
+	// sent along with the redirect from the identity provider.
+	http.SetCookie(w, &http.Cookie{
+		Name:     ssoNonceCookieName,
+		Value:    nonce,
+		Path:     "/api/v0/auth/sso",
+		Expires:  time.Now().Add(s.stateExpiration),
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+// Callback completes the sign in with the identity provider, sets the session
+// cookie and redirects the user to the satellite console.
+func (s *SSO) Callback(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	query := r.URL.Query()
+	if providerErr := query.Get("error"); providerErr != "" {
+		err = ErrSSOAPI.New("identity provider returned %s: %s", providerErr, query.Get("error_description"))
+		serveJSONError(s.log, w, http.StatusUnauthorized, err)
+		return
+	}
+
+	nonceCookie, err := r.Cookie(ssoNonceCookieName)
+	if err != nil {
+		serveJSONError(s.log, w, http.StatusUnauthorized, ErrSSOAPI.Wrap(err))
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     ssoNonceCookieName,
+		Value:    "",
+		Path:     "/api/v0/auth/sso",
+		Expires:  time.Unix(0, 0),
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	identity, err := s.sso.Authenticate(ctx, query.Get("state"), nonceCookie.Value, query.Get("code"), s.callbackURL)
+	if err != nil {
+		s.serveJSONError(w, err)
+		return
+	}
+
+	ip, err := web.GetRequestIP(r)
+	if err != nil {
+		serveJSONError(s.log, w, http.StatusInternalServerError, ErrSSOAPI.Wrap(err))
+		return
+	}
+
+	token, err := s.service.TokenSSO(ctx, identity, ip, r.UserAgent())
+	if err != nil {
+		s.serveJSONError(w, err)
+		return
+	}
+
+	s.cookieAuth.SetTokenCookie(w, token)
+
+	http.Redirect(w, r, s.externalAddress, http.StatusFound)
+}
+
+// serveJSONError writes JSON error to response output stream.
+func (s *SSO) serveJSONError(w http.ResponseWriter, err error) {
+	switch {
+	case sso.ErrNoProvider.Has(err):
+		serveJSONError(s.log, w, http.StatusNotFound, err)
+	case sso.ErrState.Has(err), sso.ErrIdentity.Has(err), console.ErrLoginCredentials.Has(err):
+		serveJSONError(s.log, w, http.StatusUnauthorized, err)
+	case console.ErrSSO.Has(err):
+		serveJSONError(s.log, w, http.StatusForbidden, err)
+	default:
+		serveJSONError(s.log, w, http.StatusInternalServerError, err)
+	}
+}
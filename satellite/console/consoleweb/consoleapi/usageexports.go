@@ -0,0 +1,143 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleapi
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/console"
+)
+
+var (
+	// ErrUsageExportsAPI - console usage exports api error type.
+	ErrUsageExportsAPI = errs.Class("console usage exports")
+)
+
+// UsageExports is an api controller that exposes the usage exports of projects.
+type UsageExports struct {
+	log     *zap.Logger
+	service *console.Service
+}
+
+// NewUsageExports is a constructor for api usage exports controller.
+func NewUsageExports(log *zap.Logger, service *console.Service) *UsageExports {
+	return &UsageExports{
+		log:     log,
+		service: service,
+	}
+}
+
+// UsageExport returns the usage export of the project, or null if its usage isn't exported.
+func (ue *UsageExports) UsageExport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	projectID, err := ue.projectID(r)
+	if err != nil {
+		ue.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	export, err := ue.service.GetProjectUsageExport(ctx, projectID)
+	if err != nil {
+		ue.serveServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(export)
+	if err != nil {
+		ue.log.Error("failed to write json project usage export response", zap.Error(ErrUsageExportsAPI.Wrap(err)))
+	}
+}
+
+// SetUsageExport sets the destination, format and schedule of the usage exports of the project.
+func (ue *UsageExports) SetUsageExport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	projectID, err := ue.projectID(r)
+	if err != nil {
+		ue.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var info console.ProjectUsageExportInfo
+	err = json.NewDecoder(r.Body).Decode(&info)
+	if err != nil {
+		ue.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	export, err := ue.service.SetProjectUsageExport(ctx, projectID, info)
+	if err != nil {
+		ue.serveServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(export)
+	if err != nil {
+		ue.log.Error("failed to write json set usage export response", zap.Error(ErrUsageExportsAPI.Wrap(err)))
+	}
+}
+
+// RemoveUsageExport stops the usage exports of the project.
+func (ue *UsageExports) RemoveUsageExport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	projectID, err := ue.projectID(r)
+	if err != nil {
+		ue.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err = ue.service.RemoveProjectUsageExport(ctx, projectID)
+	if err != nil {
+		ue.serveServiceError(w, err)
+		return
+	}
+}
+
+// projectID parses the ID of the project from the route.
+func (ue *UsageExports) projectID(r *http.Request) (uuid.UUID, error) {
+	idParam, ok := mux.Vars(r)["id"]
+	if !ok {
+		return uuid.UUID{}, errs.New("missing project id route param")
+	}
+
+	projectID, err := uuid.FromString(idParam)
+	if err != nil {
+		return uuid.UUID{}, errs.New("invalid project id: %v", err)
+	}
+
+	return projectID, nil
+}
+
+// serveServiceError writes JSON error of the console service to response output stream.
+func (ue *UsageExports) serveServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case console.ErrUnauthorized.Has(err), console.ErrNoMembership.Has(err):
+		ue.serveJSONError(w, http.StatusUnauthorized, err)
+	case console.ErrValidation.Has(err):
+		ue.serveJSONError(w, http.StatusBadRequest, err)
+	default:
+		ue.serveJSONError(w, http.StatusInternalServerError, err)
+	}
+}
+
+// serveJSONError writes JSON error to response output stream.
+func (ue *UsageExports) serveJSONError(w http.ResponseWriter, status int, err error) {
+	serveJSONError(ue.log, w, status, err)
+}
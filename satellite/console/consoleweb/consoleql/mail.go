@@ -70,3 +70,31 @@ func (*ProjectInvitationEmail) Template() string { return "Invite" }
 func (email *ProjectInvitationEmail) Subject() string {
 	return "You were invited to join the Project " + email.ProjectName
 }
+
+// ProjectSpendingAlertEmail is mailservice template for the email alerting
+// project members that a project is about to exceed its spending cap.
+type ProjectSpendingAlertEmail struct {
+	Origin                string
+	UserName              string
+	ProjectName           string
+	Threshold             int
+	SpendingCap           string
+	Charges               string
+	ProjectedCharges      string
+	Capped                bool
+	LimitBandwidth        bool
+	SignInLink            string
+	ContactInfoURL        string
+	TermsAndConditionsURL string
+}
+
+// Template returns email template name.
+func (*ProjectSpendingAlertEmail) Template() string { return "SpendingAlert" }
+
+// Subject gets email subject.
+func (email *ProjectSpendingAlertEmail) Subject() string {
+	if email.Capped {
+		return "The Project " + email.ProjectName + " reached its spending cap"
+	}
+	return "The Project " + email.ProjectName + " is approaching its spending cap"
+}
@@ -99,6 +99,7 @@ func TestGraphqlMutation(t *testing.T) {
 			log.Named("console"),
 			db.Console(),
 			restkeys.NewService(db.OIDC().OAuthTokens(), planet.Satellites[0].Config.RESTKeys),
+			db.Revocation(),
 			db.ProjectAccounting(),
 			projectUsage,
 			sat.API.Buckets.Service,
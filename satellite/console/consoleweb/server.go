@@ -38,6 +38,7 @@ import (
 	"storj.io/storj/satellite/console/consoleweb/consoleapi"
 	"storj.io/storj/satellite/console/consoleweb/consoleql"
 	"storj.io/storj/satellite/console/consoleweb/consolewebauth"
+	"storj.io/storj/satellite/console/sso"
 	"storj.io/storj/satellite/mailservice"
 	"storj.io/storj/satellite/oidc"
 	"storj.io/storj/satellite/payments/paymentsconfig"
@@ -235,7 +236,7 @@ func (a *apiAuth) RemoveAuthCookie(w http.ResponseWriter) {
 }
 
 // NewServer creates new instance of console server.
-func NewServer(logger *zap.Logger, config Config, service *console.Service, oidcService *oidc.Service, mailService *mailservice.Service, partners *rewards.PartnersService, analytics *analytics.Service, listener net.Listener, stripePublicKey string, pricing paymentsconfig.PricingValues, nodeURL storj.NodeURL) *Server {
+func NewServer(logger *zap.Logger, config Config, service *console.Service, oidcService *oidc.Service, ssoService *sso.Service, mailService *mailservice.Service, partners *rewards.PartnersService, analytics *analytics.Service, listener net.Listener, stripePublicKey string, pricing paymentsconfig.PricingValues, nodeURL storj.NodeURL) *Server {
 	server := Server{
 		log:               logger,
 		config:            config,
@@ -276,6 +277,8 @@ func NewServer(logger *zap.Logger, config Config, service *console.Service, oidc
 		consoleapi.NewProjectManagement(logger, server.service, router, &apiAuth{&server})
 		consoleapi.NewAPIKeyManagement(logger, server.service, router, &apiAuth{&server})
 		consoleapi.NewUserManagement(logger, server.service, router, &apiAuth{&server})
+		consoleapi.NewProjectMemberManagement(logger, consoleapi.NewProjectMembers(server.service, server.mailService, server.config.ExternalAddress, config.LetUsKnowURL, config.TermsAndConditionsURL, config.ContactInfoURL), router, &apiAuth{&server})
+		router.HandleFunc(consoleapi.APIDocsPath, consoleapi.ServeAPIDocs(logger)).Methods(http.MethodGet)
 	}
 
 	router.HandleFunc("/registrationToken/", server.createRegistrationTokenHandler)
@@ -297,6 +300,34 @@ func NewServer(logger *zap.Logger, config Config, service *console.Service, oidc
 		server.withAuth(http.HandlerFunc(usageLimitsController.DailyUsage)),
 	).Methods(http.MethodGet)
 
+	spendingCapsController := consoleapi.NewSpendingCaps(logger, service)
+	router.Handle(
+		"/api/v0/projects/{id}/spending",
+		server.withAuth(http.HandlerFunc(spendingCapsController.ProjectSpending)),
+	).Methods(http.MethodGet)
+	router.Handle(
+		"/api/v0/projects/{id}/spending-cap",
+		server.withAuth(http.HandlerFunc(spendingCapsController.SetSpendingCap)),
+	).Methods(http.MethodPut)
+	router.Handle(
+		"/api/v0/projects/{id}/spending-cap",
+		server.withAuth(http.HandlerFunc(spendingCapsController.RemoveSpendingCap)),
+	).Methods(http.MethodDelete)
+
+	usageExportsController := consoleapi.NewUsageExports(logger, service)
+	router.Handle(
+		"/api/v0/projects/{id}/usage-export",
+		server.withAuth(http.HandlerFunc(usageExportsController.UsageExport)),
+	).Methods(http.MethodGet)
+	router.Handle(
+		"/api/v0/projects/{id}/usage-export",
+		server.withAuth(http.HandlerFunc(usageExportsController.SetUsageExport)),
+	).Methods(http.MethodPut)
+	router.Handle(
+		"/api/v0/projects/{id}/usage-export",
+		server.withAuth(http.HandlerFunc(usageExportsController.RemoveUsageExport)),
+	).Methods(http.MethodDelete)
+
 	authController := consoleapi.NewAuth(logger, service, mailService, server.cookieAuth, partners, server.analytics, server.config.ExternalAddress, config.LetUsKnowURL, config.TermsAndConditionsURL, config.ContactInfoURL)
 	authRouter := router.PathPrefix("/api/v0/auth").Subrouter()
 	authRouter.Handle("/account", server.withAuth(http.HandlerFunc(authController.GetAccount))).Methods(http.MethodGet)
@@ -308,6 +339,9 @@ func NewServer(logger *zap.Logger, config Config, service *console.Service, oidc
 	authRouter.Handle("/mfa/disable", server.withAuth(http.HandlerFunc(authController.DisableUserMFA))).Methods(http.MethodPost)
 	authRouter.Handle("/mfa/generate-secret-key", server.withAuth(http.HandlerFunc(authController.GenerateMFASecretKey))).Methods(http.MethodPost)
 	authRouter.Handle("/mfa/generate-recovery-codes", server.withAuth(http.HandlerFunc(authController.GenerateMFARecoveryCodes))).Methods(http.MethodPost)
+	authRouter.Handle("/sessions", server.withAuth(http.HandlerFunc(authController.GetSessions))).Methods(http.MethodGet)
+	authRouter.Handle("/sessions", server.withAuth(http.HandlerFunc(authController.RevokeOtherSessions))).Methods(http.MethodDelete)
+	authRouter.Handle("/sessions/{id}", server.withAuth(http.HandlerFunc(authController.RevokeSession))).Methods(http.MethodDelete)
 	authRouter.HandleFunc("/logout", authController.Logout).Methods(http.MethodPost)
 	authRouter.Handle("/token", server.ipRateLimiter.Limit(http.HandlerFunc(authController.Token))).Methods(http.MethodPost)
 	authRouter.Handle("/register", server.ipRateLimiter.Limit(http.HandlerFunc(authController.Register))).Methods(http.MethodPost, http.MethodOptions)
@@ -315,6 +349,12 @@ func NewServer(logger *zap.Logger, config Config, service *console.Service, oidc
 	authRouter.Handle("/resend-email/{email}", server.ipRateLimiter.Limit(http.HandlerFunc(authController.ResendEmail))).Methods(http.MethodPost)
 	authRouter.Handle("/reset-password", server.ipRateLimiter.Limit(http.HandlerFunc(authController.ResetPassword))).Methods(http.MethodPost)
 
+	if server.config.SSO.Enabled {
+		ssoController := consoleapi.NewSSO(logger, service, ssoService, server.cookieAuth, server.config.ExternalAddress, server.config.SSO.StateExpiration)
+		authRouter.Handle("/sso/login", server.ipRateLimiter.Limit(http.HandlerFunc(ssoController.Login))).Methods(http.MethodGet)
+		authRouter.Handle("/sso/callback", server.ipRateLimiter.Limit(http.HandlerFunc(ssoController.Callback))).Methods(http.MethodGet)
+	}
+
 	paymentController := consoleapi.NewPayments(logger, service)
 	paymentsRouter := router.PathPrefix("/api/v0/payments").Subrouter()
 	paymentsRouter.Use(server.withAuth)
@@ -343,6 +383,39 @@ func NewServer(logger *zap.Logger, config Config, service *console.Service, oidc
 	apiKeysRouter.Use(server.withAuth)
 	apiKeysRouter.HandleFunc("/delete-by-name", apiKeysController.DeleteByNameAndProjectID).Methods(http.MethodDelete)
 
+	accessGrantsController := consoleapi.NewAccessGrants(logger, service)
+	accessGrantsRouter := router.PathPrefix("/api/v0/access-grants").Subrouter()
+	accessGrantsRouter.Use(server.withAuth)
+	accessGrantsRouter.HandleFunc("", accessGrantsController.List).Methods(http.MethodGet)
+	accessGrantsRouter.HandleFunc("", accessGrantsController.Register).Methods(http.MethodPost)
+	accessGrantsRouter.HandleFunc("/{id}/revoke", accessGrantsController.Revoke).Methods(http.MethodPost)
+	accessGrantsRouter.HandleFunc("/{id}", accessGrantsController.Delete).Methods(http.MethodDelete)
+
+	restKeysController := consoleapi.NewRESTKeys(logger, service)
+	restKeysRouter := router.PathPrefix("/api/v0/restkeys").Subrouter()
+	restKeysRouter.Use(server.withAuth)
+	restKeysRouter.HandleFunc("", restKeysController.List).Methods(http.MethodGet)
+	restKeysRouter.HandleFunc("", restKeysController.Create).Methods(http.MethodPost)
+	restKeysRouter.HandleFunc("/{id}", restKeysController.Revoke).Methods(http.MethodDelete)
+
+	organizationsController := consoleapi.NewOrganizations(logger, service)
+	organizationsRouter := router.PathPrefix("/api/v0/organizations").Subrouter()
+	organizationsRouter.Use(server.withAuth)
+	organizationsRouter.HandleFunc("", organizationsController.List).Methods(http.MethodGet)
+	organizationsRouter.HandleFunc("", organizationsController.Create).Methods(http.MethodPost)
+	organizationsRouter.HandleFunc("/{id}", organizationsController.Get).Methods(http.MethodGet)
+	organizationsRouter.HandleFunc("/{id}", organizationsController.Rename).Methods(http.MethodPatch)
+	organizationsRouter.HandleFunc("/{id}/members", organizationsController.ListMembers).Methods(http.MethodGet)
+	organizationsRouter.HandleFunc("/{id}/members", organizationsController.AddMember).Methods(http.MethodPost)
+	organizationsRouter.HandleFunc("/{id}/members/{memberID}", organizationsController.RemoveMember).Methods(http.MethodDelete)
+	organizationsRouter.HandleFunc("/{id}/projects", organizationsController.ListProjects).Methods(http.MethodGet)
+	organizationsRouter.HandleFunc("/{id}/projects", organizationsController.MoveProject).Methods(http.MethodPost)
+	organizationsRouter.HandleFunc("/{id}/payments/cards", organizationsController.ListCreditCards).Methods(http.MethodGet)
+	organizationsRouter.HandleFunc("/{id}/payments/cards", organizationsController.AddCreditCard).Methods(http.MethodPost)
+	organizationsRouter.HandleFunc("/{id}/payments/charges", organizationsController.ProjectsCharges).Methods(http.MethodGet)
+	organizationsRouter.HandleFunc("/{id}/payments/wallet", organizationsController.GetWallet).Methods(http.MethodGet)
+	organizationsRouter.HandleFunc("/{id}/payments/wallet", organizationsController.ClaimWallet).Methods(http.MethodPost)
+
 	analyticsController := consoleapi.NewAnalytics(logger, service, server.analytics)
 	analyticsRouter := router.PathPrefix("/api/v0/analytics").Subrouter()
 	analyticsRouter.Use(server.withAuth)
@@ -767,6 +840,12 @@ func (server *Server) graphqlHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set(contentType, applicationJSON)
+	if server.config.GeneratedAPIEnabled {
+		// every operation is also served by the generated api, which
+		// integrations should move to.
+		w.Header().Set("Deprecation", "true")
+		w.Header().Set("Link", "<"+consoleapi.APIDocsPath+">; rel=\"deprecation\"")
+	}
 
 	query, err := getQuery(w, r)
 	if err != nil {
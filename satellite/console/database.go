@@ -27,6 +27,16 @@ type DB interface {
 	ResetPasswordTokens() ResetPasswordTokens
 	// WebappSessions is a getter for WebappSessions repository.
 	WebappSessions() consoleauth.WebappSessions
+	// SSOIdentities is a getter for SSOIdentities repository.
+	SSOIdentities() SSOIdentities
+	// Organizations is a getter for Organizations repository.
+	Organizations() Organizations
+	// ProjectSpendingCaps is a getter for ProjectSpendingCaps repository.
+	ProjectSpendingCaps() ProjectSpendingCaps
+	// AccessGrants is a getter for AccessGrants repository.
+	AccessGrants() AccessGrants
+	// ProjectUsageExports is a getter for ProjectUsageExports repository.
+	ProjectUsageExports() ProjectUsageExports
 
 	// WithTx is a method for executing transactions with retrying as necessary.
 	WithTx(ctx context.Context, fn func(ctx context.Context, tx DBTx) error) error
@@ -107,6 +107,12 @@ func (s *Service) EnableUserMFA(ctx context.Context, passcode string, t time.Tim
 		return Error.Wrap(err)
 	}
 
+	// sessions signed in with the old credentials are no longer trusted.
+	currentID, _ := GetSessionID(ctx)
+	if _, err = s.revokeSessions(ctx, user.ID, currentID); err != nil {
+		return Error.Wrap(err)
+	}
+
 	return nil
 }
 
@@ -165,6 +171,12 @@ func (s *Service) DisableUserMFA(ctx context.Context, passcode string, t time.Ti
 		return Error.Wrap(err)
 	}
 
+	// sessions signed in with the old credentials are no longer trusted.
+	currentID, _ := GetSessionID(ctx)
+	if _, err = s.revokeSessions(ctx, user.ID, currentID); err != nil {
+		return Error.Wrap(err)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,517 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package console
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/payments"
+)
+
+// Organizations exposes methods to manage organizations and their members.
+//
+// architecture: Database
+type Organizations interface {
+	// Insert inserts the organization.
+	Insert(ctx context.Context, organization *Organization) (*Organization, error)
+	// Get returns the organization with the ID.
+	Get(ctx context.Context, id uuid.UUID) (*Organization, error)
+	// GetByMemberID returns the organizations the user is a member of.
+	GetByMemberID(ctx context.Context, memberID uuid.UUID) ([]Organization, error)
+	// UpdateName renames the organization.
+	UpdateName(ctx context.Context, id uuid.UUID, name string) error
+
+	// InsertMember adds the user to the members of the organization.
+	InsertMember(ctx context.Context, organizationID, memberID uuid.UUID) error
+	// DeleteMember removes the user from the members of the organization.
+	DeleteMember(ctx context.Context, organizationID, memberID uuid.UUID) error
+	// GetMembers returns the members of the organization.
+	GetMembers(ctx context.Context, organizationID uuid.UUID) ([]OrganizationMember, error)
+	// GetMemberInfos returns the members of the organization with their user details.
+	GetMemberInfos(ctx context.Context, organizationID uuid.UUID) ([]OrganizationMemberInfo, error)
+	// IsMember returns whether the user is a member of the organization.
+	IsMember(ctx context.Context, organizationID, memberID uuid.UUID) (bool, error)
+}
+
+// Organization owns projects on behalf of a company and pays for them from a
+// single payment account. Every member of an organization administers it.
+type Organization struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// OrganizationMember is a database object that describes the membership of
+// a user in an organization.
+type OrganizationMember struct {
+	OrganizationID uuid.UUID
+	MemberID       uuid.UUID
+	CreatedAt      time.Time
+}
+
+// OrganizationMemberInfo holds the information about a member of an organization.
+type OrganizationMemberInfo struct {
+	ID       uuid.UUID `json:"id"`
+	FullName string    `json:"fullName"`
+	Email    string    `json:"email"`
+	JoinedAt time.Time `json:"joinedAt"`
+}
+
+// ValidateOrganizationName validates the name of an organization.
+// Organization name must have more than 0 and less than 101 symbols.
+func ValidateOrganizationName(name string) error {
+	if len(name) == 0 {
+		return errors.New("organization name can't be empty")
+	}
+
+	if len(name) > 100 {
+		return errors.New("organization name can't have more than 100 symbols")
+	}
+
+	return nil
+}
+
+// CreateOrganization creates an organization administered by the user and
+// sets up its payment account.
+func (s *Service) CreateOrganization(ctx context.Context, name string) (_ *Organization, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "create organization", zap.String("name", name))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	if err := ValidateOrganizationName(name); err != nil {
+		return nil, ErrValidation.Wrap(err)
+	}
+
+	id, err := uuid.New()
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	var organization *Organization
+	err = s.store.WithTx(ctx, func(ctx context.Context, tx DBTx) error {
+		inserted, err := tx.Organizations().Insert(ctx, &Organization{ID: id, Name: name})
+		if err != nil {
+			return err
+		}
+		organization = inserted
+
+		return tx.Organizations().InsertMember(ctx, organization.ID, user.ID)
+	})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	// the payment account of the organization is keyed by its ID, the same way
+	// the payment accounts of users are keyed by theirs.
+	if _, err = s.accounts.Setup(ctx, organization.ID, user.Email, ""); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return organization, nil
+}
+
+// GetOrganizations returns the organizations the user is a member of.
+func (s *Service) GetOrganizations(ctx context.Context) (_ []Organization, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "get organizations")
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	organizations, err := s.store.Organizations().GetByMemberID(ctx, user.ID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return organizations, nil
+}
+
+// GetOrganization returns the organization with the ID.
+func (s *Service) GetOrganization(ctx context.Context, organizationID uuid.UUID) (_ *Organization, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "get organization", zap.String("organizationID", organizationID.String()))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return s.isOrganizationMember(ctx, user.ID, organizationID)
+}
+
+// RenameOrganization changes the name of the organization.
+func (s *Service) RenameOrganization(ctx context.Context, organizationID uuid.UUID, name string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "rename organization", zap.String("organizationID", organizationID.String()), zap.String("name", name))
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	if err := ValidateOrganizationName(name); err != nil {
+		return ErrValidation.Wrap(err)
+	}
+
+	if _, err = s.isOrganizationMember(ctx, user.ID, organizationID); err != nil {
+		return err
+	}
+
+	return Error.Wrap(s.store.Organizations().UpdateName(ctx, organizationID, name))
+}
+
+// GetOrganizationMembers returns the members of the organization.
+func (s *Service) GetOrganizationMembers(ctx context.Context, organizationID uuid.UUID) (_ []OrganizationMemberInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "get organization members", zap.String("organizationID", organizationID.String()))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	if _, err = s.isOrganizationMember(ctx, user.ID, organizationID); err != nil {
+		return nil, err
+	}
+
+	infos, err := s.store.Organizations().GetMemberInfos(ctx, organizationID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return infos, nil
+}
+
+// AddOrganizationMember makes the user with the email a member of the
+// organization and of all of its projects.
+func (s *Service) AddOrganizationMember(ctx context.Context, organizationID uuid.UUID, email string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "add organization member", zap.String("organizationID", organizationID.String()), zap.String("email", email))
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	if _, err = s.isOrganizationMember(ctx, user.ID, organizationID); err != nil {
+		return err
+	}
+
+	member, err := s.store.Users().GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrValidation.New(teamMemberDoesNotExistErrMsg)
+		}
+		return Error.Wrap(err)
+	}
+
+	isMember, err := s.store.Organizations().IsMember(ctx, organizationID, member.ID)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	if isMember {
+		return ErrValidation.New(organizationMemberExistsErrMsg)
+	}
+
+	projects, err := s.store.Projects().GetByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	err = s.store.WithTx(ctx, func(ctx context.Context, tx DBTx) error {
+		if err := tx.Organizations().InsertMember(ctx, organizationID, member.ID); err != nil {
+			return err
+		}
+		return addProjectMembers(ctx, tx, []uuid.UUID{member.ID}, projects)
+	})
+
+	return Error.Wrap(err)
+}
+
+// RemoveOrganizationMember removes the user from the members of the
+// organization and of all of its projects which the user doesn't own.
+func (s *Service) RemoveOrganizationMember(ctx context.Context, organizationID, memberID uuid.UUID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "remove organization member", zap.String("organizationID", organizationID.String()), zap.String("memberID", memberID.String()))
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	if _, err = s.isOrganizationMember(ctx, user.ID, organizationID); err != nil {
+		return err
+	}
+
+	members, err := s.store.Organizations().GetMembers(ctx, organizationID)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	found := false
+	for _, member := range members {
+		if member.MemberID == memberID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return ErrValidation.New(organizationMemberNotFoundErrMsg)
+	}
+	if len(members) == 1 {
+		return ErrValidation.New(organizationLastMemberErrMsg)
+	}
+
+	projects, err := s.store.Projects().GetByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	memberships, err := s.store.ProjectMembers().GetByMemberID(ctx, memberID)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	err = s.store.WithTx(ctx, func(ctx context.Context, tx DBTx) error {
+		if err := tx.Organizations().DeleteMember(ctx, organizationID, memberID); err != nil {
+			return err
+		}
+
+		for _, project := range projects {
+			if project.OwnerID == memberID {
+				continue
+			}
+			if _, ok := findMembershipByProjectID(memberships, project.ID); !ok {
+				continue
+			}
+			if err := tx.ProjectMembers().Delete(ctx, memberID, project.ID); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return Error.Wrap(err)
+}
+
+// GetOrganizationProjects returns the projects of the organization.
+func (s *Service) GetOrganizationProjects(ctx context.Context, organizationID uuid.UUID) (_ []Project, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "get organization projects", zap.String("organizationID", organizationID.String()))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	if _, err = s.isOrganizationMember(ctx, user.ID, organizationID); err != nil {
+		return nil, err
+	}
+
+	projects, err := s.store.Projects().GetByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return projects, nil
+}
+
+// MoveProjectToOrganization transfers a project the user owns to the
+// organization. The members of the organization become members of the project
+// and the organization pays for all usage of the project which hasn't been
+// invoiced yet.
+func (s *Service) MoveProjectToOrganization(ctx context.Context, projectID, organizationID uuid.UUID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "move project to organization", zap.String("projectID", projectID.String()), zap.String("organizationID", organizationID.String()))
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	if _, err = s.isProjectOwner(ctx, user.ID, projectID); err != nil {
+		return Error.Wrap(err)
+	}
+
+	if _, err = s.isOrganizationMember(ctx, user.ID, organizationID); err != nil {
+		return err
+	}
+
+	project, err := s.store.Projects().Get(ctx, projectID)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	if !project.OrganizationID.IsZero() {
+		return ErrValidation.New(projectInOrganizationErrMsg)
+	}
+
+	members, err := s.store.Organizations().GetMembers(ctx, organizationID)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	memberIDs := make([]uuid.UUID, 0, len(members))
+	for _, member := range members {
+		memberIDs = append(memberIDs, member.MemberID)
+	}
+
+	err = s.store.WithTx(ctx, func(ctx context.Context, tx DBTx) error {
+		if err := tx.Projects().UpdateOrganization(ctx, projectID, organizationID); err != nil {
+			return err
+		}
+		return addProjectMembers(ctx, tx, memberIDs, []Project{*project})
+	})
+
+	return Error.Wrap(err)
+}
+
+// isOrganizationMember returns the organization if the user is a member of it.
+func (s *Service) isOrganizationMember(ctx context.Context, userID, organizationID uuid.UUID) (_ *Organization, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	isMember, err := s.store.Organizations().IsMember(ctx, organizationID, userID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if !isMember {
+		return nil, ErrNoMembership.New(unauthorizedErrMsg)
+	}
+
+	organization, err := s.store.Organizations().Get(ctx, organizationID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return organization, nil
+}
+
+// addProjectMembers makes the users members of the projects unless they
+// already are.
+func addProjectMembers(ctx context.Context, tx DBTx, userIDs []uuid.UUID, projects []Project) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	for _, userID := range userIDs {
+		memberships, err := tx.ProjectMembers().GetByMemberID(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		for _, project := range projects {
+			if _, ok := findMembershipByProjectID(memberships, project.ID); ok {
+				continue
+			}
+			if _, err := tx.ProjectMembers().Insert(ctx, userID, project.ID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// AddOrganizationCreditCard saves a new credit card and attaches it to the
+// payment account of the organization.
+func (payment Payments) AddOrganizationCreditCard(ctx context.Context, organizationID uuid.UUID, creditCardToken string) (err error) {
+	defer mon.Task()(&ctx, creditCardToken)(&err)
+
+	user, err := payment.service.getUserAndAuditLog(ctx, "add organization credit card", zap.String("organizationID", organizationID.String()))
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	if _, err = payment.service.isOrganizationMember(ctx, user.ID, organizationID); err != nil {
+		return err
+	}
+
+	return Error.Wrap(payment.service.accounts.CreditCards().Add(ctx, organizationID, creditCardToken))
+}
+
+// ListOrganizationCreditCards returns the credit cards of the payment account
+// of the organization.
+func (payment Payments) ListOrganizationCreditCards(ctx context.Context, organizationID uuid.UUID) (_ []payments.CreditCard, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := payment.service.getUserAndAuditLog(ctx, "list organization credit cards", zap.String("organizationID", organizationID.String()))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	if _, err = payment.service.isOrganizationMember(ctx, user.ID, organizationID); err != nil {
+		return nil, err
+	}
+
+	cards, err := payment.service.accounts.CreditCards().List(ctx, organizationID)
+	return cards, Error.Wrap(err)
+}
+
+// ClaimOrganizationWallet claims a deposit wallet for the payment account of
+// the organization, so STORJ token payments to it are credited to the balance
+// the invoices of the organization are paid from. If a wallet is already
+// claimed, it returns the claimed wallet.
+func (payment Payments) ClaimOrganizationWallet(ctx context.Context, organizationID uuid.UUID) (_ WalletInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := payment.service.getUserAndAuditLog(ctx, "claim organization wallet", zap.String("organizationID", organizationID.String()))
+	if err != nil {
+		return WalletInfo{}, Error.Wrap(err)
+	}
+
+	if _, err = payment.service.isOrganizationMember(ctx, user.ID, organizationID); err != nil {
+		return WalletInfo{}, err
+	}
+
+	address, err := payment.service.depositWallets.Get(ctx, organizationID)
+	if errors.Is(err, sql.ErrNoRows) {
+		address, err = payment.service.depositWallets.Claim(ctx, organizationID)
+	}
+	if err != nil {
+		return WalletInfo{}, Error.Wrap(err)
+	}
+
+	return WalletInfo{Address: address}, nil
+}
+
+// GetOrganizationWallet returns the deposit wallet of the payment account of
+// the organization.
+func (payment Payments) GetOrganizationWallet(ctx context.Context, organizationID uuid.UUID) (_ WalletInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := payment.service.getUserAndAuditLog(ctx, "get organization wallet", zap.String("organizationID", organizationID.String()))
+	if err != nil {
+		return WalletInfo{}, Error.Wrap(err)
+	}
+
+	if _, err = payment.service.isOrganizationMember(ctx, user.ID, organizationID); err != nil {
+		return WalletInfo{}, err
+	}
+
+	address, err := payment.service.depositWallets.Get(ctx, organizationID)
+	if err != nil {
+		return WalletInfo{}, Error.Wrap(err)
+	}
+
+	return WalletInfo{Address: address}, nil
+}
+
+// OrganizationProjectsCharges returns the usage and the charges of every
+// project of the organization.
+func (payment Payments) OrganizationProjectsCharges(ctx context.Context, organizationID uuid.UUID, since, before time.Time) (_ []payments.ProjectCharge, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := payment.service.getUserAndAuditLog(ctx, "organization project charges", zap.String("organizationID", organizationID.String()))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	if _, err = payment.service.isOrganizationMember(ctx, user.ID, organizationID); err != nil {
+		return nil, err
+	}
+
+	charges, err := payment.service.accounts.ProjectCharges(ctx, organizationID, since, before)
+	return charges, Error.Wrap(err)
+}
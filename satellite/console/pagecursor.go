@@ -0,0 +1,77 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package console
+
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
+// defaultPageLimit is the number of items of a page of the generated api,
+// when the first page is requested without a limit.
+const defaultPageLimit = 50
+
+// PageCursor is the position of a page of the generated api together with
+// the search and order of the listing. Clients only pass it around in its
+// encoded, opaque form.
+type PageCursor struct {
+	Search         string         `json:"s,omitempty"`
+	Limit          uint           `json:"l"`
+	Page           uint           `json:"p"`
+	Order          uint8          `json:"o,omitempty"`
+	OrderDirection OrderDirection `json:"d,omitempty"`
+}
+
+// firstPageCursor returns the cursor of the first page of a listing.
+func firstPageCursor(search string, limit uint, order uint8, orderDirection OrderDirection) PageCursor {
+	if limit == 0 {
+		limit = defaultPageLimit
+	}
+	return PageCursor{
+		Search:         search,
+		Limit:          limit,
+		Page:           1,
+		Order:          order,
+		OrderDirection: orderDirection,
+	}
+}
+
+// DecodePageCursor decodes an opaque page cursor.
+func DecodePageCursor(encoded string) (cursor PageCursor, err error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return PageCursor{}, ErrValidation.New("invalid page cursor")
+	}
+	if err = json.Unmarshal(data, &cursor); err != nil || cursor.Limit == 0 || cursor.Page == 0 {
+		return PageCursor{}, ErrValidation.New("invalid page cursor")
+	}
+	return cursor, nil
+}
+
+// Encode returns the opaque form of the cursor.
+func (cursor PageCursor) Encode() string {
+	// encoding a struct of strings and integers can't fail.
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Next returns the opaque cursor of the page after the cursor, or an empty
+// string if the page of the cursor is the last one.
+func (cursor PageCursor) Next(pageCount uint) string {
+	if cursor.Page >= pageCount {
+		return ""
+	}
+	next := cursor
+	next.Page++
+	return next.Encode()
+}
+
+// resolvePageCursor returns the decoded cursor if it's given and the cursor
+// of the first page otherwise.
+func resolvePageCursor(encoded, search string, limit uint, order uint8, orderDirection OrderDirection) (PageCursor, error) {
+	if encoded != "" {
+		return DecodePageCursor(encoded)
+	}
+	return firstPageCursor(search, limit, order, orderDirection), nil
+}
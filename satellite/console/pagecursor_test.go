@@ -0,0 +1,39 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package console_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/satellite/console"
+)
+
+func TestPageCursor(t *testing.T) {
+	cursor := console.PageCursor{
+		Search:         "name",
+		Limit:          10,
+		Page:           2,
+		Order:          uint8(console.KeyName),
+		OrderDirection: console.Descending,
+	}
+
+	decoded, err := console.DecodePageCursor(cursor.Encode())
+	require.NoError(t, err)
+	require.Equal(t, cursor, decoded)
+
+	next, err := console.DecodePageCursor(cursor.Next(3))
+	require.NoError(t, err)
+	cursor.Page = 3
+	require.Equal(t, cursor, next)
+
+	require.Empty(t, cursor.Next(3))
+	require.Empty(t, cursor.Next(0))
+
+	for _, invalid := range []string{"", "not base64!", "bm90IGpzb24", (console.PageCursor{Limit: 10}).Encode()} {
+		_, err = console.DecodePageCursor(invalid)
+		require.True(t, console.ErrValidation.Has(err), invalid)
+	}
+}
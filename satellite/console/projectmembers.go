@@ -69,3 +69,48 @@ const (
 	// Created indicates that we should order by created date.
 	Created ProjectMemberOrder = 3
 )
+
+// ProjectMemberInfo describes a project member together with its user info.
+type ProjectMemberInfo struct {
+	ID        uuid.UUID `json:"id"`
+	FullName  string    `json:"fullName"`
+	ShortName string    `json:"shortName"`
+	Email     string    `json:"email"`
+	JoinedAt  time.Time `json:"joinedAt"`
+}
+
+// ProjectMemberInfosPage represents a page of project members with their user info.
+type ProjectMemberInfosPage struct {
+	ProjectMembers []ProjectMemberInfo `json:"projectMembers"`
+
+	Search         string             `json:"search"`
+	Limit          uint               `json:"limit"`
+	Order          ProjectMemberOrder `json:"order"`
+	OrderDirection OrderDirection     `json:"orderDirection"`
+	Offset         uint64             `json:"offset"`
+
+	PageCount   uint   `json:"pageCount"`
+	CurrentPage uint   `json:"currentPage"`
+	TotalCount  uint64 `json:"totalCount"`
+
+	// NextCursor is the opaque cursor of the next page of the generated api,
+	// which is empty on the last page.
+	NextCursor string `json:"nextCursor"`
+}
+
+// ProjectMembersRequest holds the emails of the users to add to or remove from a project.
+type ProjectMembersRequest struct {
+	ProjectID uuid.UUID `json:"projectID"`
+	Emails    []string  `json:"emails"`
+}
+
+// projectMemberInfo returns the project member info of the user.
+func projectMemberInfo(user *User, joinedAt time.Time) ProjectMemberInfo {
+	return ProjectMemberInfo{
+		ID:        user.ID,
+		FullName:  user.FullName,
+		ShortName: user.ShortName,
+		Email:     user.Email,
+		JoinedAt:  joinedAt,
+	}
+}
@@ -24,6 +24,8 @@ type Projects interface {
 	GetByUserID(ctx context.Context, userID uuid.UUID) ([]Project, error)
 	// GetOwn returns a list of projects where user is an owner.
 	GetOwn(ctx context.Context, userID uuid.UUID) ([]Project, error)
+	// GetByOrganizationID returns a list of projects owned by the organization.
+	GetByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]Project, error)
 	// Get is a method for querying project from the database by id.
 	Get(ctx context.Context, id uuid.UUID) (*Project, error)
 	// Insert is a method for inserting project into the database.
@@ -47,6 +49,9 @@ type Projects interface {
 	GetMaxBuckets(ctx context.Context, id uuid.UUID) (*int, error)
 	// UpdateBucketLimit is a method for updating projects bucket limit.
 	UpdateBucketLimit(ctx context.Context, id uuid.UUID, newLimit int) error
+
+	// UpdateOrganization is a method for transferring the project to an organization.
+	UpdateOrganization(ctx context.Context, id uuid.UUID, organizationID uuid.UUID) error
 }
 
 // UsageLimitsConfig is a configuration struct for default per-project usage limits.
@@ -90,6 +95,7 @@ type Project struct {
 	PartnerID      uuid.UUID    `json:"partnerId"`
 	UserAgent      []byte       `json:"userAgent"`
 	OwnerID        uuid.UUID    `json:"ownerId"`
+	OrganizationID uuid.UUID    `json:"organizationId"`
 	RateLimit      *int         `json:"rateLimit"`
 	BurstLimit     *int         `json:"burstLimit"`
 	MaxBuckets     *int         `json:"maxBuckets"`
@@ -100,6 +106,15 @@ type Project struct {
 	SegmentLimit   *int64       `json:"segmentLimit"`
 }
 
+// BillingAccountID returns the ID of the payment account which pays for the
+// project: the organization of the project if it has one, the owner otherwise.
+func (project *Project) BillingAccountID() uuid.UUID {
+	if !project.OrganizationID.IsZero() {
+		return project.OrganizationID
+	}
+	return project.OwnerID
+}
+
 // ProjectInfo holds data needed to create/update Project.
 type ProjectInfo struct {
 	Name           string      `json:"name"`
@@ -120,16 +135,20 @@ type ProjectsCursor struct {
 // providing next offset if there are more projects
 // to retrieve.
 type ProjectsPage struct {
-	Projects   []Project
-	Next       bool
-	NextOffset int64
+	Projects   []Project `json:"projects"`
+	Next       bool      `json:"next"`
+	NextOffset int64     `json:"nextOffset"`
+
+	Limit  int   `json:"limit"`
+	Offset int64 `json:"offset"`
 
-	Limit  int
-	Offset int64
+	PageCount   int   `json:"pageCount"`
+	CurrentPage int   `json:"currentPage"`
+	TotalCount  int64 `json:"totalCount"`
 
-	PageCount   int
-	CurrentPage int
-	TotalCount  int64
+	// NextCursor is the opaque cursor of the next page of the generated api,
+	// which is empty on the last page.
+	NextCursor string `json:"nextCursor"`
 }
 
 // ValidateNameAndDescription validates project name and description strings.
@@ -13,6 +13,15 @@ type ProjectUsageLimits struct {
 	BandwidthUsed  int64 `json:"bandwidthUsed"`
 	ObjectCount    int64 `json:"objectCount"`
 	SegmentCount   int64 `json:"segmentCount"`
+
+	BandwidthRateLimit  int64 `json:"bandwidthRateLimit"`
+	BandwidthBurstLimit int64 `json:"bandwidthBurstLimit"`
+
+	// SpendingCapReached is whether the limits are lowered because the
+	// project reached its enforced spending cap.
+	SpendingCapReached bool `json:"spendingCapReached"`
+	// DownloadsPaused is whether the bandwidth limit is lowered too.
+	DownloadsPaused bool `json:"downloadsPaused"`
 }
 
 // UserProjectLimits holds a users storage, bandwidth, and segment limits for new projects.
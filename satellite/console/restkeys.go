@@ -0,0 +1,180 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package console
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/uuid"
+)
+
+// ErrRESTKeyScope is error type of requests which aren't allowed by the scope of the rest key.
+var ErrRESTKeyScope = errs.Class("rest key scope")
+
+// RESTKeys is an interface for rest key operations.
+type RESTKeys interface {
+	// CreateScoped creates a named rest key, which is limited to the scope.
+	CreateScoped(ctx context.Context, userID uuid.UUID, name string, scope RESTKeyScope, expiration time.Duration) (apiKey string, expiresAt time.Time, err error)
+	// GetKeyInfo returns the information about an unexpired rest key.
+	GetKeyInfo(ctx context.Context, apiKey string) (RESTKeyInfo, error)
+	// UpdateLastUsed records that the rest key was used.
+	UpdateLastUsed(ctx context.Context, key RESTKeyInfo, now time.Time) error
+	// List returns the unexpired rest keys of the user.
+	List(ctx context.Context, userID uuid.UUID) ([]RESTKeyInfo, error)
+	// Revoke revokes a rest key.
+	Revoke(ctx context.Context, apiKey string) (err error)
+	// RevokeByID revokes a rest key of the user by its ID.
+	RevokeByID(ctx context.Context, userID uuid.UUID, id string) (err error)
+}
+
+// RESTKeyPermission is a permission granted by a scoped rest key.
+type RESTKeyPermission string
+
+const (
+	// RESTKeyPermissionReadUsage allows reading the usage and limits of projects.
+	RESTKeyPermissionReadUsage RESTKeyPermission = "read_usage"
+	// RESTKeyPermissionManageAPIKeys allows listing, creating and deleting API keys of projects.
+	RESTKeyPermissionManageAPIKeys RESTKeyPermission = "manage_api_keys"
+	// RESTKeyPermissionManageBuckets allows listing the buckets of projects.
+	RESTKeyPermissionManageBuckets RESTKeyPermission = "manage_buckets"
+	// RESTKeyPermissionReadBilling allows reading the billing information of the account.
+	RESTKeyPermissionReadBilling RESTKeyPermission = "read_billing"
+)
+
+// restKeyProjectPrefix prefixes project IDs in encoded rest key scopes.
+const restKeyProjectPrefix = "project:"
+
+// IsValid returns whether the permission is known.
+func (permission RESTKeyPermission) IsValid() bool {
+	switch permission {
+	case RESTKeyPermissionReadUsage, RESTKeyPermissionManageAPIKeys, RESTKeyPermissionManageBuckets, RESTKeyPermissionReadBilling:
+		return true
+	default:
+		return false
+	}
+}
+
+// RESTKeyScope limits what a rest key can access.
+//
+// The zero value is not scoped and grants full access to the account, like
+// the rest keys created before scopes existed.
+type RESTKeyScope struct {
+	// ProjectIDs are the projects the key can access. When empty, the key can
+	// access all projects of the user.
+	ProjectIDs []uuid.UUID `json:"projectIDs"`
+	// Permissions are the operations the key can perform.
+	Permissions []RESTKeyPermission `json:"permissions"`
+}
+
+// ParseRESTKeyScope parses a scope encoded by RESTKeyScope.String.
+func ParseRESTKeyScope(encoded string) (scope RESTKeyScope, err error) {
+	for _, field := range strings.Fields(encoded) {
+		if strings.HasPrefix(field, restKeyProjectPrefix) {
+			projectID, err := uuid.FromString(strings.TrimPrefix(field, restKeyProjectPrefix))
+			if err != nil {
+				return RESTKeyScope{}, ErrRESTKeyScope.Wrap(err)
+			}
+			scope.ProjectIDs = append(scope.ProjectIDs, projectID)
+			continue
+		}
+
+		permission := RESTKeyPermission(field)
+		if !permission.IsValid() {
+			return RESTKeyScope{}, ErrRESTKeyScope.New("unknown permission %q", field)
+		}
+		scope.Permissions = append(scope.Permissions, permission)
+	}
+	return scope, nil
+}
+
+// String encodes the scope as space separated permissions and project IDs.
+func (scope RESTKeyScope) String() string {
+	fields := make([]string, 0, len(scope.Permissions)+len(scope.ProjectIDs))
+	for _, permission := range scope.Permissions {
+		fields = append(fields, string(permission))
+	}
+	for _, projectID := range scope.ProjectIDs {
+		fields = append(fields, restKeyProjectPrefix+projectID.String())
+	}
+	return strings.Join(fields, " ")
+}
+
+// Validate checks that the scope only has known permissions and that a scope
+// limited to projects grants at least one permission.
+func (scope RESTKeyScope) Validate() error {
+	if len(scope.ProjectIDs) > 0 && len(scope.Permissions) == 0 {
+		return ErrRESTKeyScope.New("no permissions granted")
+	}
+	for _, permission := range scope.Permissions {
+		if !permission.IsValid() {
+			return ErrRESTKeyScope.New("unknown permission %q", permission)
+		}
+	}
+	return nil
+}
+
+// IsFullAccess returns whether the scope grants full access to the account.
+func (scope RESTKeyScope) IsFullAccess() bool {
+	return len(scope.ProjectIDs) == 0 && len(scope.Permissions) == 0
+}
+
+// HasAnyPermission returns whether the scope grants any of the permissions.
+// When no permissions are given, any scope is sufficient.
+func (scope RESTKeyScope) HasAnyPermission(permissions ...RESTKeyPermission) bool {
+	if scope.IsFullAccess() || len(permissions) == 0 {
+		return true
+	}
+	for _, granted := range scope.Permissions {
+		for _, permission := range permissions {
+			if granted == permission {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// HasProject returns whether the scope grants access to the project.
+func (scope RESTKeyScope) HasProject(projectID uuid.UUID) bool {
+	if len(scope.ProjectIDs) == 0 {
+		return true
+	}
+	for _, id := range scope.ProjectIDs {
+		if id == projectID {
+			return true
+		}
+	}
+	return false
+}
+
+// RESTKeyInfo holds information about a rest key.
+type RESTKeyInfo struct {
+	// ID identifies the key without revealing it.
+	ID         string       `json:"id"`
+	UserID     uuid.UUID    `json:"-"`
+	Name       string       `json:"name"`
+	Scope      RESTKeyScope `json:"scope"`
+	CreatedAt  time.Time    `json:"createdAt"`
+	ExpiresAt  time.Time    `json:"expiresAt"`
+	LastUsedAt *time.Time   `json:"lastUsedAt"`
+}
+
+// restKeyScopeKey is context key for the scope of the rest key used to authenticate.
+const restKeyScopeKey key = 2
+
+// WithRESTKeyScope creates new context with the scope of the rest key used to authenticate.
+func WithRESTKeyScope(ctx context.Context, scope RESTKeyScope) context.Context {
+	return context.WithValue(ctx, restKeyScopeKey, scope)
+}
+
+// GetRESTKeyScope returns the scope of the rest key used to authenticate.
+// Requests which aren't authenticated with a rest key have full access.
+func GetRESTKeyScope(ctx context.Context) RESTKeyScope {
+	scope, _ := ctx.Value(restKeyScopeKey).(RESTKeyScope)
+	return scope
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
 	"errors"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/zeebo/errs"
 
 	"storj.io/common/uuid"
+	"storj.io/storj/satellite/console"
 	"storj.io/storj/satellite/oidc"
 )
 
@@ -35,6 +37,7 @@ var (
 // Config contains configuration parameters for rest keys.
 type Config struct {
 	DefaultExpiration time.Duration `help:"expiration to use if user does not specify an rest key expiration" default:"720h"`
+	LastUsedInterval  time.Duration `help:"how often the last used time of a rest key is updated" default:"1m"`
 }
 
 // Service handles operations regarding rest keys.
@@ -51,18 +54,34 @@ func NewService(db oidc.OAuthTokens, config Config) *Service {
 	}
 }
 
-// Create creates and inserts an rest key into the db.
+var _ console.RESTKeys = (*Service)(nil)
+
+// Create creates and inserts an rest key into the db. The key grants full
+// access to the account of the user.
 func (s *Service) Create(ctx context.Context, userID uuid.UUID, expiration time.Duration) (apiKey string, expiresAt time.Time, err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	return s.CreateScoped(ctx, userID, "", console.RESTKeyScope{}, expiration)
+}
+
+// CreateScoped creates and inserts a named rest key, which is limited to the scope, into the db.
+func (s *Service) CreateScoped(ctx context.Context, userID uuid.UUID, name string, scope console.RESTKeyScope, expiration time.Duration) (apiKey string, expiresAt time.Time, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := scope.Validate(); err != nil {
+		return "", time.Time{}, Error.Wrap(err)
+	}
+
 	apiKey, hash, err := s.GenerateNewKey(ctx)
 	if err != nil {
 		return "", time.Time{}, Error.Wrap(err)
 	}
 	expiresAt, err = s.InsertIntoDB(ctx, oidc.OAuthToken{
 		UserID: userID,
+		Scope:  scope.String(),
 		Kind:   oidc.KindRESTTokenV0,
 		Token:  hash,
+		Name:   name,
 	}, time.Now(), expiration)
 	if err != nil {
 		return "", time.Time{}, Error.Wrap(err)
@@ -173,3 +192,111 @@ func (s *Service) Revoke(ctx context.Context, apiKey string) (err error) {
 	}
 	return nil
 }
+
+// GetKeyInfo returns the information about an unexpired rest key.
+func (s *Service) GetKeyInfo(ctx context.Context, apiKey string) (_ console.RESTKeyInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	hash, err := s.HashKey(ctx, apiKey)
+	if err != nil {
+		return console.RESTKeyInfo{}, err
+	}
+	token, err := s.db.Get(ctx, oidc.KindRESTTokenV0, hash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return console.RESTKeyInfo{}, Error.Wrap(ErrInvalidKey.New("invalid account management api key"))
+		}
+		return console.RESTKeyInfo{}, Error.Wrap(err)
+	}
+	return keyInfoFromToken(token)
+}
+
+// UpdateLastUsed records that the rest key was used. To avoid a database
+// write on every request, the time is only updated after LastUsedInterval.
+func (s *Service) UpdateLastUsed(ctx context.Context, key console.RESTKeyInfo, now time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if key.LastUsedAt != nil && now.Sub(*key.LastUsedAt) < s.config.LastUsedInterval {
+		return nil
+	}
+
+	hash, err := hashFromID(key.ID)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	return Error.Wrap(s.db.UpdateRESTTokenV0LastUsed(ctx, hash, now))
+}
+
+// List returns the unexpired rest keys of the user.
+func (s *Service) List(ctx context.Context, userID uuid.UUID) (keys []console.RESTKeyInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	tokens, err := s.db.GetRESTTokensV0(ctx, userID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	keys = make([]console.RESTKeyInfo, 0, len(tokens))
+	for _, token := range tokens {
+		key, err := keyInfoFromToken(token)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// RevokeByID revokes a rest key of the user by its ID.
+func (s *Service) RevokeByID(ctx context.Context, userID uuid.UUID, id string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	hash, err := hashFromID(id)
+	if err != nil {
+		return Error.Wrap(ErrInvalidKey.Wrap(err))
+	}
+
+	token, err := s.db.Get(ctx, oidc.KindRESTTokenV0, hash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Error.Wrap(ErrInvalidKey.New("invalid account management api key id"))
+		}
+		return Error.Wrap(err)
+	}
+	if token.UserID != userID {
+		return Error.Wrap(ErrInvalidKey.New("invalid account management api key id"))
+	}
+
+	return Error.Wrap(s.db.RevokeRESTTokenV0(ctx, hash))
+}
+
+// keyInfoFromToken converts a rest token from the db into the information about the key.
+// The ID of the key is the hex encoded hash of the key, which doesn't reveal the key.
+func keyInfoFromToken(token oidc.OAuthToken) (console.RESTKeyInfo, error) {
+	scope, err := console.ParseRESTKeyScope(token.Scope)
+	if err != nil {
+		return console.RESTKeyInfo{}, Error.Wrap(err)
+	}
+
+	return console.RESTKeyInfo{
+		ID:         hex.EncodeToString([]byte(token.Token)),
+		UserID:     token.UserID,
+		Name:       token.Name,
+		Scope:      scope,
+		CreatedAt:  token.CreatedAt,
+		ExpiresAt:  token.ExpiresAt,
+		LastUsedAt: token.LastUsedAt,
+	}, nil
+}
+
+// hashFromID returns the hash of the key identified by the ID.
+func hashFromID(id string) (string, error) {
+	hash, err := hex.DecodeString(id)
+	if err != nil {
+		return "", err
+	}
+	if len(hash) != sha256.Size {
+		return "", errs.New("invalid id length")
+	}
+	return string(hash), nil
+}
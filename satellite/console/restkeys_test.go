@@ -0,0 +1,50 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package console_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testrand"
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/console"
+)
+
+func TestRESTKeyScope(t *testing.T) {
+	projectID := testrand.UUID()
+
+	full, err := console.ParseRESTKeyScope("")
+	require.NoError(t, err)
+	require.True(t, full.IsFullAccess())
+	require.True(t, full.HasAnyPermission(console.RESTKeyPermissionReadBilling))
+	require.True(t, full.HasProject(testrand.UUID()))
+
+	scope := console.RESTKeyScope{
+		ProjectIDs:  []uuid.UUID{projectID},
+		Permissions: []console.RESTKeyPermission{console.RESTKeyPermissionReadUsage, console.RESTKeyPermissionManageAPIKeys},
+	}
+	require.NoError(t, scope.Validate())
+
+	parsed, err := console.ParseRESTKeyScope(scope.String())
+	require.NoError(t, err)
+	require.Equal(t, scope, parsed)
+
+	require.False(t, parsed.IsFullAccess())
+	require.True(t, parsed.HasAnyPermission())
+	require.True(t, parsed.HasAnyPermission(console.RESTKeyPermissionManageAPIKeys))
+	require.False(t, parsed.HasAnyPermission(console.RESTKeyPermissionReadBilling))
+	require.True(t, parsed.HasProject(projectID))
+	require.False(t, parsed.HasProject(testrand.UUID()))
+
+	_, err = console.ParseRESTKeyScope("read_usage delete_everything")
+	require.True(t, console.ErrRESTKeyScope.Has(err))
+
+	_, err = console.ParseRESTKeyScope("read_usage project:invalid")
+	require.True(t, console.ErrRESTKeyScope.Has(err))
+
+	err = console.RESTKeyScope{ProjectIDs: []uuid.UUID{projectID}}.Validate()
+	require.True(t, console.ErrRESTKeyScope.Has(err))
+}
@@ -5,6 +5,10 @@ package console
 
 import (
 	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"math"
 	"math/big"
@@ -30,8 +34,11 @@ import (
 	"storj.io/storj/satellite/accounting"
 	"storj.io/storj/satellite/analytics"
 	"storj.io/storj/satellite/console/consoleauth"
+	"storj.io/storj/satellite/console/sso"
+	"storj.io/storj/satellite/orders"
 	"storj.io/storj/satellite/payments"
 	"storj.io/storj/satellite/payments/monetary"
+	"storj.io/storj/satellite/revocation"
 	"storj.io/storj/satellite/rewards"
 )
 
@@ -63,6 +70,14 @@ const (
 	activationTokenExpiredErrMsg = "This activation token has expired, please request another one"
 	usedRegTokenErrMsg           = "This registration token has already been used"
 	projLimitErrMsg              = "Sorry, project creation is limited for your account. Please contact support!"
+	ssoRequiredErrMsg            = "Your organization requires you to sign in with single sign-on"
+	ssoAccountExistsErrMsg       = "An account with this email already exists and can't be linked to your organization's single sign-on, please contact support"
+	ssoAccountNotFoundErrMsg     = "There is no account on this Satellite for your organization's single sign-on, please contact support"
+
+	organizationMemberExistsErrMsg   = "This user is already a member of the organization"
+	organizationMemberNotFoundErrMsg = "This user is not a member of the organization"
+	organizationLastMemberErrMsg     = "The last member of an organization can not be removed"
+	projectInOrganizationErrMsg      = "This project already belongs to an organization"
 )
 
 var (
@@ -113,6 +128,13 @@ var (
 
 	// ErrRecoveryToken describes account recovery token errors.
 	ErrRecoveryToken = errs.Class("recovery token")
+
+	// ErrSSORequired occurs when a user of a domain with an identity provider
+	// tries to sign in or register with a password.
+	ErrSSORequired = errs.Class("single sign-on required")
+
+	// ErrSSO describes errors of signing in with an identity provider.
+	ErrSSO = errs.Class("single sign-on")
 )
 
 // Service is handling accounts related logic.
@@ -122,6 +144,7 @@ type Service struct {
 	log, auditLogger  *zap.Logger
 	store             DB
 	restKeys          RESTKeys
+	revocations       revocation.DB
 	projectAccounting accounting.ProjectAccounting
 	projectUsage      *accounting.Service
 	buckets           Buckets
@@ -149,16 +172,19 @@ func init() {
 
 // Config keeps track of core console service configuration parameters.
 type Config struct {
-	PasswordCost                int           `help:"password hashing cost (0=automatic)" testDefault:"4" default:"0"`
-	OpenRegistrationEnabled     bool          `help:"enable open registration" default:"false" testDefault:"true"`
-	DefaultProjectLimit         int           `help:"default project limits for users" default:"1" testDefault:"5"`
-	AsOfSystemTimeDuration      time.Duration `help:"default duration for AS OF SYSTEM TIME" devDefault:"-5m" releaseDefault:"-5m" testDefault:"0"`
-	LoginAttemptsWithoutPenalty int           `help:"number of times user can try to login without penalty" default:"3"`
-	FailedLoginPenalty          float64       `help:"incremental duration of penalty for failed login attempts in minutes" default:"2.0"`
-	SessionDuration             time.Duration `help:"duration a session is valid for" default:"168h"`
+	PasswordCost                int                   `help:"password hashing cost (0=automatic)" testDefault:"4" default:"0"`
+	OpenRegistrationEnabled     bool                  `help:"enable open registration" default:"false" testDefault:"true"`
+	DefaultProjectLimit         int                   `help:"default project limits for users" default:"1" testDefault:"5"`
+	AsOfSystemTimeDuration      time.Duration         `help:"default duration for AS OF SYSTEM TIME" devDefault:"-5m" releaseDefault:"-5m" testDefault:"0"`
+	LoginAttemptsWithoutPenalty int                   `help:"number of times user can try to login without penalty" default:"3"`
+	FailedLoginPenalty          float64               `help:"incremental duration of penalty for failed login attempts in minutes" default:"2.0"`
+	SessionDuration             time.Duration         `help:"duration a session is valid for" default:"168h"`
+	SessionLastSeenInterval     time.Duration         `help:"how often the last seen time of a session is updated" default:"5m"`
+	UsageExportKeys             orders.EncryptionKeys `help:"keys to encrypt the access grants of project usage exports with, the default key encrypts new exports" default:""`
 	UsageLimits                 UsageLimitsConfig
 	Recaptcha                   RecaptchaConfig
 	Hcaptcha                    HcaptchaConfig
+	SSO                         sso.Config
 }
 
 // RecaptchaConfig contains configurations for the reCAPTCHA system.
@@ -181,7 +207,7 @@ type Payments struct {
 }
 
 // NewService returns new instance of Service.
-func NewService(log *zap.Logger, store DB, restKeys RESTKeys, projectAccounting accounting.ProjectAccounting, projectUsage *accounting.Service, buckets Buckets, partners *rewards.PartnersService, accounts payments.Accounts, depositWallets payments.DepositWallets, analytics *analytics.Service, tokens *consoleauth.Service, config Config) (*Service, error) {
+func NewService(log *zap.Logger, store DB, restKeys RESTKeys, revocations revocation.DB, projectAccounting accounting.ProjectAccounting, projectUsage *accounting.Service, buckets Buckets, partners *rewards.PartnersService, accounts payments.Accounts, depositWallets payments.DepositWallets, analytics *analytics.Service, tokens *consoleauth.Service, config Config) (*Service, error) {
 	if store == nil {
 		return nil, errs.New("store can't be nil")
 	}
@@ -204,6 +230,7 @@ func NewService(log *zap.Logger, store DB, restKeys RESTKeys, projectAccounting
 		auditLogger:       log.Named("auditlog"),
 		store:             store,
 		restKeys:          restKeys,
+		revocations:       revocations,
 		projectAccounting: projectAccounting,
 		projectUsage:      projectUsage,
 		buckets:           buckets,
@@ -242,8 +269,23 @@ func (s *Service) auditLog(ctx context.Context, operation string, userID *uuid.U
 	s.auditLogger.Info("console activity", fields...)
 }
 
+// getUserAndAuditLog returns the user of the request and records the operation
+// in the audit log. Requests authenticated with a scoped rest key are rejected.
 func (s *Service) getUserAndAuditLog(ctx context.Context, operation string, extra ...zap.Field) (*User, error) {
+	return s.getUserAndAuditLogWithScope(ctx, GetRESTKeyScope(ctx).IsFullAccess(), operation, extra...)
+}
+
+// getScopedUserAndAuditLog is like getUserAndAuditLog, but it also allows requests
+// authenticated with a scoped rest key, which grants any of the permissions.
+func (s *Service) getScopedUserAndAuditLog(ctx context.Context, permissions []RESTKeyPermission, operation string, extra ...zap.Field) (*User, error) {
+	return s.getUserAndAuditLogWithScope(ctx, GetRESTKeyScope(ctx).HasAnyPermission(permissions...), operation, extra...)
+}
+
+func (s *Service) getUserAndAuditLogWithScope(ctx context.Context, allowed bool, operation string, extra ...zap.Field) (*User, error) {
 	user, err := GetUser(ctx)
+	if err == nil && !allowed {
+		err = ErrUnauthorized.Wrap(ErrRESTKeyScope.New(unauthorizedErrMsg))
+	}
 	if err != nil {
 		sourceIP, forwardedForIP := getRequestingIP(ctx)
 		s.auditLogger.Info("console activity unauthorized",
@@ -281,7 +323,7 @@ func (payment Payments) SetupAccount(ctx context.Context) (_ payments.CouponType
 func (payment Payments) AccountBalance(ctx context.Context) (balance payments.Balance, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	user, err := payment.service.getUserAndAuditLog(ctx, "get account balance")
+	user, err := payment.service.getScopedUserAndAuditLog(ctx, []RESTKeyPermission{RESTKeyPermissionReadBilling}, "get account balance")
 	if err != nil {
 		return payments.Balance{}, Error.Wrap(err)
 	}
@@ -359,7 +401,7 @@ func (payment Payments) MakeCreditCardDefault(ctx context.Context, cardID string
 func (payment Payments) ProjectsCharges(ctx context.Context, since, before time.Time) (_ []payments.ProjectCharge, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	user, err := payment.service.getUserAndAuditLog(ctx, "project charges")
+	user, err := payment.service.getScopedUserAndAuditLog(ctx, []RESTKeyPermission{RESTKeyPermissionReadBilling}, "project charges")
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
@@ -371,7 +413,7 @@ func (payment Payments) ProjectsCharges(ctx context.Context, since, before time.
 func (payment Payments) ListCreditCards(ctx context.Context) (_ []payments.CreditCard, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	user, err := payment.service.getUserAndAuditLog(ctx, "list credit cards")
+	user, err := payment.service.getScopedUserAndAuditLog(ctx, []RESTKeyPermission{RESTKeyPermissionReadBilling}, "list credit cards")
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
@@ -395,7 +437,7 @@ func (payment Payments) RemoveCreditCard(ctx context.Context, cardID string) (er
 func (payment Payments) BillingHistory(ctx context.Context) (billingHistory []*BillingHistoryItem, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	user, err := payment.service.getUserAndAuditLog(ctx, "get billing history")
+	user, err := payment.service.getScopedUserAndAuditLog(ctx, []RESTKeyPermission{RESTKeyPermissionReadBilling}, "get billing history")
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
@@ -590,7 +632,7 @@ func (payment Payments) ApplyCouponCode(ctx context.Context, couponCode string)
 func (payment Payments) GetCoupon(ctx context.Context) (coupon *payments.Coupon, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	user, err := payment.service.getUserAndAuditLog(ctx, "get coupon")
+	user, err := payment.service.getScopedUserAndAuditLog(ctx, []RESTKeyPermission{RESTKeyPermissionReadBilling}, "get coupon")
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
@@ -649,6 +691,11 @@ func (s *Service) CreateUser(ctx context.Context, user CreateUser, tokenSecret R
 		return nil, err
 	}
 
+	if s.requiresSSO(user.Email) {
+		mon.Counter("create_user_sso_required").Inc(1)
+		return nil, ErrSSORequired.New(ssoRequiredErrMsg)
+	}
+
 	registrationToken, err := s.checkRegistrationSecret(ctx, tokenSecret)
 	if err != nil {
 		return nil, ErrRegToken.Wrap(err)
@@ -925,6 +972,11 @@ func (s *Service) ResetPassword(ctx context.Context, resetPasswordToken, passwor
 		return Error.Wrap(err)
 	}
 
+	// the password may have been reset because the account was compromised.
+	if _, err = s.revokeSessions(ctx, user.ID, uuid.UUID{}); err != nil {
+		return Error.Wrap(err)
+	}
+
 	return nil
 }
 
@@ -946,6 +998,11 @@ func (s *Service) Token(ctx context.Context, request AuthUser) (token consoleaut
 
 	mon.Counter("login_attempt").Inc(1) //mon:locked
 
+	if s.requiresSSO(request.Email) {
+		mon.Counter("login_sso_required").Inc(1)
+		return consoleauth.Token{}, ErrSSORequired.New(ssoRequiredErrMsg)
+	}
+
 	user, unverified, err := s.store.Users().GetByEmailWithUnverified(ctx, request.Email)
 	if user == nil {
 		if len(unverified) > 0 {
@@ -1078,6 +1135,166 @@ func (s *Service) Token(ctx context.Context, request AuthUser) (token consoleaut
 	return token, nil
 }
 
+// requiresSSO returns whether the user with the email address must sign in
+// with the identity provider of their domain.
+func (s *Service) requiresSSO(email string) bool {
+	if !s.config.SSO.Enabled {
+		return false
+	}
+	_, ok := s.config.SSO.Providers.ForEmail(email)
+	return ok
+}
+
+// TokenSSO authenticates the user with the identity asserted by the identity
+// provider of their domain and returns a session token.
+//
+// The first time a user signs in, a new account is created for them when the
+// provider allows provisioning users, or their existing account is linked to
+// the identity when the provider allows linking accounts.
+func (s *Service) TokenSSO(ctx context.Context, identity sso.Identity, ip, userAgent string) (token consoleauth.Token, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	mon.Counter("login_sso_attempt").Inc(1)
+
+	provider, ok := s.config.SSO.Providers.ForEmail(identity.Email)
+	if !s.config.SSO.Enabled || !ok || provider.Issuer != identity.Issuer {
+		return consoleauth.Token{}, ErrSSO.New("no identity provider for %q", identity.Email)
+	}
+
+	user, err := s.getSSOUser(ctx, identity)
+	if err != nil {
+		return consoleauth.Token{}, err
+	}
+
+	if user == nil {
+		verified, _, err := s.store.Users().GetByEmailWithUnverified(ctx, identity.Email)
+		if err != nil {
+			return consoleauth.Token{}, Error.Wrap(err)
+		}
+
+		switch {
+		case verified != nil && provider.LinkExisting:
+			mon.Counter("login_sso_link").Inc(1)
+			user = verified
+			err = s.store.SSOIdentities().Insert(ctx, SSOIdentity{
+				Issuer:  identity.Issuer,
+				Subject: identity.Subject,
+				UserID:  user.ID,
+			})
+			if err != nil {
+				return consoleauth.Token{}, Error.Wrap(err)
+			}
+			s.auditLog(ctx, "link sso identity", &user.ID, user.Email)
+		case verified != nil:
+			mon.Counter("login_sso_account_exists").Inc(1)
+			return consoleauth.Token{}, ErrSSO.New(ssoAccountExistsErrMsg)
+		case provider.Provision:
+			mon.Counter("login_sso_provision").Inc(1)
+			user, err = s.provisionSSOUser(ctx, identity)
+			if err != nil {
+				return consoleauth.Token{}, err
+			}
+		default:
+			mon.Counter("login_sso_account_not_found").Inc(1)
+			return consoleauth.Token{}, ErrSSO.New(ssoAccountNotFoundErrMsg)
+		}
+	}
+
+	if user.Status != Active {
+		mon.Counter("login_sso_inactive").Inc(1)
+		return consoleauth.Token{}, ErrLoginCredentials.New(credentialsErrMsg)
+	}
+
+	token, err = s.GenerateSessionToken(ctx, user.ID, user.Email, ip, userAgent)
+	if err != nil {
+		return consoleauth.Token{}, err
+	}
+
+	mon.Counter("login_sso_success").Inc(1)
+
+	return token, nil
+}
+
+// getSSOUser returns the user linked to the identity, or nil when there is none.
+func (s *Service) getSSOUser(ctx context.Context, identity sso.Identity) (_ *User, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	link, err := s.store.SSOIdentities().Get(ctx, identity.Issuer, identity.Subject)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	user, err := s.store.Users().Get(ctx, link.UserID)
+	if errors.Is(err, sql.ErrNoRows) {
+		// the user has been deleted since the identity was linked.
+		return nil, Error.Wrap(s.store.SSOIdentities().DeleteByUserID(ctx, link.UserID))
+	}
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return user, nil
+}
+
+// provisionSSOUser creates an active account linked to the identity. The
+// account gets a random password since its users sign in with the identity
+// provider only.
+func (s *Service) provisionSSOUser(ctx context.Context, identity sso.Identity) (u *User, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var password [32]byte
+	if _, err := rand.Read(password[:]); err != nil {
+		return nil, Error.Wrap(err)
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(hex.EncodeToString(password[:])), s.config.PasswordCost)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	fullName := identity.Name
+	if fullName == "" {
+		fullName = identity.Email
+	}
+
+	err = s.store.WithTx(ctx, func(ctx context.Context, tx DBTx) error {
+		userID, err := uuid.New()
+		if err != nil {
+			return Error.Wrap(err)
+		}
+
+		u, err = tx.Users().Insert(ctx, &User{
+			ID:                    userID,
+			Email:                 identity.Email,
+			FullName:              fullName,
+			PasswordHash:          hash,
+			Status:                Active,
+			ProjectLimit:          s.config.UsageLimits.Project.Free,
+			ProjectStorageLimit:   s.config.UsageLimits.Storage.Free.Int64(),
+			ProjectBandwidthLimit: s.config.UsageLimits.Bandwidth.Free.Int64(),
+			ProjectSegmentLimit:   s.config.UsageLimits.Segment.Free,
+		})
+		if err != nil {
+			return Error.Wrap(err)
+		}
+
+		return Error.Wrap(tx.SSOIdentities().Insert(ctx, SSOIdentity{
+			Issuer:  identity.Issuer,
+			Subject: identity.Subject,
+			UserID:  u.ID,
+		}))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.auditLog(ctx, "create user", nil, identity.Email)
+	mon.Counter("create_user_success").Inc(1) //mon:locked
+
+	return u, nil
+}
+
 // UpdateUsersFailedLoginState updates User's failed login state.
 func (s *Service) UpdateUsersFailedLoginState(ctx context.Context, user *User, lockoutExpDate *time.Time) error {
 	updateRequest := UpdateUserRequest{}
@@ -1107,7 +1324,7 @@ func (s *Service) GenGetUser(ctx context.Context) (*ResponseUser, api.HTTPError)
 	var err error
 	defer mon.Task()(&ctx)(&err)
 
-	user, err := s.getUserAndAuditLog(ctx, "get user")
+	user, err := s.getScopedUserAndAuditLog(ctx, nil, "get user")
 	if err != nil {
 		return nil, api.HTTPError{
 			Status: http.StatusUnauthorized,
@@ -1252,6 +1469,12 @@ func (s *Service) ChangePassword(ctx context.Context, pass, newPass string) (err
 		return Error.Wrap(err)
 	}
 
+	// sessions signed in with the old credentials are no longer trusted.
+	currentID, _ := GetSessionID(ctx)
+	if _, err = s.revokeSessions(ctx, user.ID, currentID); err != nil {
+		return Error.Wrap(err)
+	}
+
 	return nil
 }
 
@@ -1284,7 +1507,7 @@ func (s *Service) DeleteAccount(ctx context.Context, password string) (err error
 // GetProject is a method for querying project by id.
 func (s *Service) GetProject(ctx context.Context, projectID uuid.UUID) (p *Project, err error) {
 	defer mon.Task()(&ctx)(&err)
-	user, err := s.getUserAndAuditLog(ctx, "get project", zap.String("projectID", projectID.String()))
+	user, err := s.getScopedUserAndAuditLog(ctx, nil, "get project", zap.String("projectID", projectID.String()))
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
@@ -1301,10 +1524,23 @@ func (s *Service) GetProject(ctx context.Context, projectID uuid.UUID) (p *Proje
 	return
 }
 
+// GenGetProject is a method for querying project by id for generated api.
+func (s *Service) GenGetProject(ctx context.Context, projectID uuid.UUID) (p *Project, httpError api.HTTPError) {
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	p, err = s.GetProject(ctx, projectID)
+	if err != nil {
+		return nil, genHTTPError(err)
+	}
+
+	return p, api.HTTPError{}
+}
+
 // GetUsersProjects is a method for querying all projects.
 func (s *Service) GetUsersProjects(ctx context.Context) (ps []Project, err error) {
 	defer mon.Task()(&ctx)(&err)
-	user, err := s.getUserAndAuditLog(ctx, "get users projects")
+	user, err := s.getScopedUserAndAuditLog(ctx, nil, "get users projects")
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
@@ -1314,7 +1550,7 @@ func (s *Service) GetUsersProjects(ctx context.Context) (ps []Project, err error
 		return nil, Error.Wrap(err)
 	}
 
-	return
+	return filterRESTKeyScopeProjects(ctx, ps), nil
 }
 
 // GenGetUsersProjects is a method for querying all projects for generated api.
@@ -1322,7 +1558,7 @@ func (s *Service) GenGetUsersProjects(ctx context.Context) (ps []Project, httpEr
 	var err error
 	defer mon.Task()(&ctx)(&err)
 
-	user, err := s.getUserAndAuditLog(ctx, "get users projects")
+	user, err := s.getScopedUserAndAuditLog(ctx, nil, "get users projects")
 	if err != nil {
 		return nil, api.HTTPError{
 			Status: http.StatusUnauthorized,
@@ -1338,7 +1574,7 @@ func (s *Service) GenGetUsersProjects(ctx context.Context) (ps []Project, httpEr
 		}
 	}
 
-	return
+	return filterRESTKeyScopeProjects(ctx, ps), api.HTTPError{}
 }
 
 // GetUsersOwnedProjectsPage is a method for querying paged projects.
@@ -1357,6 +1593,26 @@ func (s *Service) GetUsersOwnedProjectsPage(ctx context.Context, cursor Projects
 	return projects, nil
 }
 
+// GenGetUsersOwnedProjectsPage is a method for querying paged projects for generated api.
+// The page is given by the opaque cursor or is the first one of the limit.
+func (s *Service) GenGetUsersOwnedProjectsPage(ctx context.Context, cursor string, limit uint) (_ *ProjectsPage, httpError api.HTTPError) {
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	pageCursor, err := resolvePageCursor(cursor, "", limit, 0, 0)
+	if err != nil {
+		return nil, genHTTPError(err)
+	}
+
+	projects, err := s.GetUsersOwnedProjectsPage(ctx, ProjectsCursor{Limit: int(pageCursor.Limit), Page: int(pageCursor.Page)})
+	if err != nil {
+		return nil, genHTTPError(err)
+	}
+	projects.NextCursor = pageCursor.Next(uint(projects.PageCount))
+
+	return &projects, api.HTTPError{}
+}
+
 // CreateProject is a method for creating new project.
 func (s *Service) CreateProject(ctx context.Context, projectInfo ProjectInfo) (p *Project, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -1776,6 +2032,24 @@ func (s *Service) AddProjectMembers(ctx context.Context, projectID uuid.UUID, em
 	return users, nil
 }
 
+// GenAddProjectMembers adds users by email to given project for generated api.
+func (s *Service) GenAddProjectMembers(ctx context.Context, request ProjectMembersRequest) (members []ProjectMemberInfo, httpError api.HTTPError) {
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	users, err := s.AddProjectMembers(ctx, request.ProjectID, request.Emails)
+	if err != nil {
+		return nil, genHTTPError(err)
+	}
+
+	now := time.Now()
+	for _, user := range users {
+		members = append(members, projectMemberInfo(user, now))
+	}
+
+	return members, api.HTTPError{}
+}
+
 // DeleteProjectMembers removes users by email from given project.
 func (s *Service) DeleteProjectMembers(ctx context.Context, projectID uuid.UUID, emails []string) (err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -1827,6 +2101,19 @@ func (s *Service) DeleteProjectMembers(ctx context.Context, projectID uuid.UUID,
 	return Error.Wrap(err)
 }
 
+// GenDeleteProjectMembers removes users by email from given project for generated api.
+func (s *Service) GenDeleteProjectMembers(ctx context.Context, request ProjectMembersRequest) (httpError api.HTTPError) {
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	err = s.DeleteProjectMembers(ctx, request.ProjectID, request.Emails)
+	if err != nil {
+		return genHTTPError(err)
+	}
+
+	return api.HTTPError{}
+}
+
 // GetProjectMembers returns ProjectMembers for given Project.
 func (s *Service) GetProjectMembers(ctx context.Context, projectID uuid.UUID, cursor ProjectMembersCursor) (pmp *ProjectMembersPage, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -1853,11 +2140,57 @@ func (s *Service) GetProjectMembers(ctx context.Context, projectID uuid.UUID, cu
 	return
 }
 
+// GenGetProjectMembers returns paged project members with their user info for generated api.
+// The page is given by the opaque cursor or is the first one of the search, limit and order.
+func (s *Service) GenGetProjectMembers(ctx context.Context, projectID uuid.UUID, cursor, search string, limit uint, order ProjectMemberOrder, orderDirection OrderDirection) (_ *ProjectMemberInfosPage, httpError api.HTTPError) {
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	pageCursor, err := resolvePageCursor(cursor, search, limit, uint8(order), orderDirection)
+	if err != nil {
+		return nil, genHTTPError(err)
+	}
+
+	membersPage, err := s.GetProjectMembers(ctx, projectID, ProjectMembersCursor{
+		Search:         pageCursor.Search,
+		Limit:          pageCursor.Limit,
+		Page:           pageCursor.Page,
+		Order:          ProjectMemberOrder(pageCursor.Order),
+		OrderDirection: pageCursor.OrderDirection,
+	})
+	if err != nil {
+		return nil, genHTTPError(err)
+	}
+
+	infosPage := &ProjectMemberInfosPage{
+		ProjectMembers: make([]ProjectMemberInfo, 0, len(membersPage.ProjectMembers)),
+		Search:         membersPage.Search,
+		Limit:          membersPage.Limit,
+		Order:          membersPage.Order,
+		OrderDirection: membersPage.OrderDirection,
+		Offset:         membersPage.Offset,
+		PageCount:      membersPage.PageCount,
+		CurrentPage:    membersPage.CurrentPage,
+		TotalCount:     membersPage.TotalCount,
+		NextCursor:     pageCursor.Next(membersPage.PageCount),
+	}
+
+	for _, member := range membersPage.ProjectMembers {
+		user, err := s.GetUser(ctx, member.MemberID)
+		if err != nil {
+			return nil, genHTTPError(err)
+		}
+		infosPage.ProjectMembers = append(infosPage.ProjectMembers, projectMemberInfo(user, member.CreatedAt))
+	}
+
+	return infosPage, api.HTTPError{}
+}
+
 // CreateAPIKey creates new api key.
 func (s *Service) CreateAPIKey(ctx context.Context, projectID uuid.UUID, name string) (_ *APIKeyInfo, _ *macaroon.APIKey, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	user, err := s.getUserAndAuditLog(ctx, "create api key", zap.String("projectID", projectID.String()))
+	user, err := s.getScopedUserAndAuditLog(ctx, []RESTKeyPermission{RESTKeyPermissionManageAPIKeys}, "create api key", zap.String("projectID", projectID.String()))
 	if err != nil {
 		return nil, nil, Error.Wrap(err)
 	}
@@ -1903,7 +2236,7 @@ func (s *Service) GenCreateAPIKey(ctx context.Context, requestInfo CreateAPIKeyR
 	var err error
 	defer mon.Task()(&ctx)(&err)
 
-	user, err := s.getUserAndAuditLog(ctx, "create api key", zap.String("projectID", requestInfo.ProjectID))
+	user, err := s.getScopedUserAndAuditLog(ctx, []RESTKeyPermission{RESTKeyPermissionManageAPIKeys}, "create api key", zap.String("projectID", requestInfo.ProjectID))
 	if err != nil {
 		return nil, api.HTTPError{
 			Status: http.StatusUnauthorized,
@@ -1977,7 +2310,7 @@ func (s *Service) GenCreateAPIKey(ctx context.Context, requestInfo CreateAPIKeyR
 func (s *Service) GetAPIKeyInfoByName(ctx context.Context, projectID uuid.UUID, name string) (_ *APIKeyInfo, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	user, err := s.getUserAndAuditLog(ctx, "get api key info",
+	user, err := s.getScopedUserAndAuditLog(ctx, []RESTKeyPermission{RESTKeyPermissionManageAPIKeys}, "get api key info",
 		zap.String("projectID", projectID.String()),
 		zap.String("name", name))
 	if err != nil {
@@ -2001,7 +2334,7 @@ func (s *Service) GetAPIKeyInfoByName(ctx context.Context, projectID uuid.UUID,
 func (s *Service) GetAPIKeyInfo(ctx context.Context, id uuid.UUID) (_ *APIKeyInfo, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	user, err := s.getUserAndAuditLog(ctx, "get api key info", zap.String("apiKeyID", id.String()))
+	user, err := s.getScopedUserAndAuditLog(ctx, []RESTKeyPermission{RESTKeyPermissionManageAPIKeys}, "get api key info", zap.String("apiKeyID", id.String()))
 	if err != nil {
 		return nil, err
 	}
@@ -2028,7 +2361,7 @@ func (s *Service) DeleteAPIKeys(ctx context.Context, ids []uuid.UUID) (err error
 		idStrings = append(idStrings, id.String())
 	}
 
-	user, err := s.getUserAndAuditLog(ctx, "delete api keys", zap.Strings("apiKeyIDs", idStrings))
+	user, err := s.getScopedUserAndAuditLog(ctx, []RESTKeyPermission{RESTKeyPermissionManageAPIKeys}, "delete api keys", zap.Strings("apiKeyIDs", idStrings))
 	if err != nil {
 		return Error.Wrap(err)
 	}
@@ -2066,11 +2399,41 @@ func (s *Service) DeleteAPIKeys(ctx context.Context, ids []uuid.UUID) (err error
 	return Error.Wrap(err)
 }
 
+// GenDeleteAPIKey deletes api key by id for generated api.
+func (s *Service) GenDeleteAPIKey(ctx context.Context, id uuid.UUID) (httpError api.HTTPError) {
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	err = s.DeleteAPIKeys(ctx, []uuid.UUID{id})
+	if err != nil {
+		return genHTTPError(err)
+	}
+
+	return api.HTTPError{}
+}
+
+// GenDeleteAPIKeys deletes api keys by ids for generated api.
+func (s *Service) GenDeleteAPIKeys(ctx context.Context, request DeleteAPIKeysRequest) (httpError api.HTTPError) {
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	if len(request.IDs) == 0 {
+		return genHTTPError(ErrValidation.New("no api key ids to delete"))
+	}
+
+	err = s.DeleteAPIKeys(ctx, request.IDs)
+	if err != nil {
+		return genHTTPError(err)
+	}
+
+	return api.HTTPError{}
+}
+
 // DeleteAPIKeyByNameAndProjectID deletes api key by name and project ID.
 func (s *Service) DeleteAPIKeyByNameAndProjectID(ctx context.Context, name string, projectID uuid.UUID) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	user, err := s.getUserAndAuditLog(ctx, "delete api key by name and project ID", zap.String("apiKeyName", name), zap.String("projectID", projectID.String()))
+	user, err := s.getScopedUserAndAuditLog(ctx, []RESTKeyPermission{RESTKeyPermissionManageAPIKeys}, "delete api key by name and project ID", zap.String("apiKeyName", name), zap.String("projectID", projectID.String()))
 	if err != nil {
 		return Error.Wrap(err)
 	}
@@ -2097,7 +2460,7 @@ func (s *Service) DeleteAPIKeyByNameAndProjectID(ctx context.Context, name strin
 func (s *Service) GetAPIKeys(ctx context.Context, projectID uuid.UUID, cursor APIKeyCursor) (page *APIKeyPage, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	user, err := s.getUserAndAuditLog(ctx, "get api keys", zap.String("projectID", projectID.String()))
+	user, err := s.getScopedUserAndAuditLog(ctx, []RESTKeyPermission{RESTKeyPermissionManageAPIKeys}, "get api keys", zap.String("projectID", projectID.String()))
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
@@ -2119,22 +2482,75 @@ func (s *Service) GetAPIKeys(ctx context.Context, projectID uuid.UUID, cursor AP
 	return
 }
 
-// CreateRESTKey creates a satellite rest key.
-func (s *Service) CreateRESTKey(ctx context.Context, expiration time.Duration) (apiKey string, expiresAt time.Time, err error) {
+// GenGetAPIKeys returns paged api key list for given Project for generated api.
+// The page is given by the opaque cursor or is the first one of the search, limit and order.
+func (s *Service) GenGetAPIKeys(ctx context.Context, projectID uuid.UUID, cursor, search string, limit uint, order APIKeyOrder, orderDirection OrderDirection) (_ *APIKeyPage, httpError api.HTTPError) {
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	pageCursor, err := resolvePageCursor(cursor, search, limit, uint8(order), orderDirection)
+	if err != nil {
+		return nil, genHTTPError(err)
+	}
+
+	keysPage, err := s.GetAPIKeys(ctx, projectID, APIKeyCursor{
+		Search:         pageCursor.Search,
+		Limit:          pageCursor.Limit,
+		Page:           pageCursor.Page,
+		Order:          APIKeyOrder(pageCursor.Order),
+		OrderDirection: pageCursor.OrderDirection,
+	})
+	if err != nil {
+		return nil, genHTTPError(err)
+	}
+	keysPage.NextCursor = pageCursor.Next(keysPage.PageCount)
+
+	return keysPage, api.HTTPError{}
+}
+
+// CreateRESTKey creates a satellite rest key. The key is limited to the scope,
+// whose projects have to be projects the user is a member of.
+func (s *Service) CreateRESTKey(ctx context.Context, name string, scope RESTKeyScope, expiration time.Duration) (apiKey string, expiresAt time.Time, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	user, err := s.getUserAndAuditLog(ctx, "create rest key")
+	user, err := s.getUserAndAuditLog(ctx, "create rest key", zap.String("scope", scope.String()))
 	if err != nil {
 		return "", time.Time{}, Error.Wrap(err)
 	}
 
-	apiKey, expiresAt, err = s.restKeys.Create(ctx, user.ID, expiration)
+	if err := scope.Validate(); err != nil {
+		return "", time.Time{}, Error.Wrap(err)
+	}
+
+	for _, projectID := range scope.ProjectIDs {
+		if _, err := s.isProjectMember(ctx, user.ID, projectID); err != nil {
+			return "", time.Time{}, Error.Wrap(err)
+		}
+	}
+
+	apiKey, expiresAt, err = s.restKeys.CreateScoped(ctx, user.ID, name, scope, expiration)
 	if err != nil {
 		return "", time.Time{}, Error.Wrap(err)
 	}
 	return apiKey, expiresAt, nil
 }
 
+// GetRESTKeys returns the unexpired rest keys of the user.
+func (s *Service) GetRESTKeys(ctx context.Context) (keys []RESTKeyInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "get rest keys")
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	keys, err = s.restKeys.List(ctx, user.ID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return keys, nil
+}
+
 // RevokeRESTKey revokes a satellite REST key.
 func (s *Service) RevokeRESTKey(ctx context.Context, apiKey string) (err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -2151,11 +2567,27 @@ func (s *Service) RevokeRESTKey(ctx context.Context, apiKey string) (err error)
 	return nil
 }
 
+// RevokeRESTKeyByID revokes a satellite REST key of the user by its ID.
+func (s *Service) RevokeRESTKeyByID(ctx context.Context, id string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "revoke rest key by id", zap.String("restKeyID", id))
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	err = s.restKeys.RevokeByID(ctx, user.ID, id)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	return nil
+}
+
 // GetProjectUsage retrieves project usage for a given period.
 func (s *Service) GetProjectUsage(ctx context.Context, projectID uuid.UUID, since, before time.Time) (_ *accounting.ProjectUsage, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	user, err := s.getUserAndAuditLog(ctx, "get project usage", zap.String("projectID", projectID.String()))
+	user, err := s.getScopedUserAndAuditLog(ctx, []RESTKeyPermission{RESTKeyPermissionReadUsage}, "get project usage", zap.String("projectID", projectID.String()))
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
@@ -2173,11 +2605,24 @@ func (s *Service) GetProjectUsage(ctx context.Context, projectID uuid.UUID, sinc
 	return projectUsage, nil
 }
 
+// GenGetProjectUsage retrieves project usage for a given period for generated api.
+func (s *Service) GenGetProjectUsage(ctx context.Context, projectID uuid.UUID, since, before time.Time) (_ *accounting.ProjectUsage, httpError api.HTTPError) {
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	usage, err := s.GetProjectUsage(ctx, projectID, since, before)
+	if err != nil {
+		return nil, genHTTPError(err)
+	}
+
+	return usage, api.HTTPError{}
+}
+
 // GetBucketTotals retrieves paged bucket total usages since project creation.
 func (s *Service) GetBucketTotals(ctx context.Context, projectID uuid.UUID, cursor accounting.BucketUsageCursor, before time.Time) (_ *accounting.BucketUsagePage, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	user, err := s.getUserAndAuditLog(ctx, "get bucket totals", zap.String("projectID", projectID.String()))
+	user, err := s.getScopedUserAndAuditLog(ctx, []RESTKeyPermission{RESTKeyPermissionReadUsage, RESTKeyPermissionManageBuckets}, "get bucket totals", zap.String("projectID", projectID.String()))
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
@@ -2195,11 +2640,35 @@ func (s *Service) GetBucketTotals(ctx context.Context, projectID uuid.UUID, curs
 	return usage, nil
 }
 
+// GenGetBucketTotals retrieves paged bucket total usages since project creation for generated api.
+// The page is given by the opaque cursor or is the first one of the search and limit.
+func (s *Service) GenGetBucketTotals(ctx context.Context, projectID uuid.UUID, cursor, search string, limit uint, before time.Time) (_ *accounting.BucketUsagePage, httpError api.HTTPError) {
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	pageCursor, err := resolvePageCursor(cursor, search, limit, 0, 0)
+	if err != nil {
+		return nil, genHTTPError(err)
+	}
+
+	usage, err := s.GetBucketTotals(ctx, projectID, accounting.BucketUsageCursor{
+		Search: pageCursor.Search,
+		Limit:  pageCursor.Limit,
+		Page:   pageCursor.Page,
+	}, before)
+	if err != nil {
+		return nil, genHTTPError(err)
+	}
+	usage.NextCursor = pageCursor.Next(usage.PageCount)
+
+	return usage, api.HTTPError{}
+}
+
 // GetAllBucketNames retrieves all bucket names of a specific project.
 func (s *Service) GetAllBucketNames(ctx context.Context, projectID uuid.UUID) (_ []string, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	user, err := s.getUserAndAuditLog(ctx, "get all bucket names", zap.String("projectID", projectID.String()))
+	user, err := s.getScopedUserAndAuditLog(ctx, []RESTKeyPermission{RESTKeyPermissionManageBuckets}, "get all bucket names", zap.String("projectID", projectID.String()))
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
@@ -2234,7 +2703,7 @@ func (s *Service) GetAllBucketNames(ctx context.Context, projectID uuid.UUID) (_
 func (s *Service) GetBucketUsageRollups(ctx context.Context, projectID uuid.UUID, since, before time.Time) (_ []accounting.BucketUsageRollup, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	user, err := s.getUserAndAuditLog(ctx, "get bucket usage rollups", zap.String("projectID", projectID.String()))
+	user, err := s.getScopedUserAndAuditLog(ctx, []RESTKeyPermission{RESTKeyPermissionReadUsage}, "get bucket usage rollups", zap.String("projectID", projectID.String()))
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
@@ -2257,7 +2726,7 @@ func (s *Service) GenGetBucketUsageRollups(ctx context.Context, projectID uuid.U
 	var err error
 	defer mon.Task()(&ctx)(&err)
 
-	user, err := s.getUserAndAuditLog(ctx, "get bucket usage rollups", zap.String("projectID", projectID.String()))
+	user, err := s.getScopedUserAndAuditLog(ctx, []RESTKeyPermission{RESTKeyPermissionReadUsage}, "get bucket usage rollups", zap.String("projectID", projectID.String()))
 	if err != nil {
 		return nil, api.HTTPError{
 			Status: http.StatusUnauthorized,
@@ -2289,7 +2758,7 @@ func (s *Service) GenGetSingleBucketUsageRollup(ctx context.Context, projectID u
 	var err error
 	defer mon.Task()(&ctx)(&err)
 
-	user, err := s.getUserAndAuditLog(ctx, "get single bucket usage rollup", zap.String("projectID", projectID.String()))
+	user, err := s.getScopedUserAndAuditLog(ctx, []RESTKeyPermission{RESTKeyPermissionReadUsage}, "get single bucket usage rollup", zap.String("projectID", projectID.String()))
 	if err != nil {
 		return nil, api.HTTPError{
 			Status: http.StatusUnauthorized,
@@ -2320,7 +2789,7 @@ func (s *Service) GenGetSingleBucketUsageRollup(ctx context.Context, projectID u
 func (s *Service) GetDailyProjectUsage(ctx context.Context, projectID uuid.UUID, from, to time.Time) (_ *accounting.ProjectDailyUsage, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	user, err := s.getUserAndAuditLog(ctx, "get daily usage by project ID")
+	user, err := s.getScopedUserAndAuditLog(ctx, []RESTKeyPermission{RESTKeyPermissionReadUsage}, "get daily usage by project ID")
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
@@ -2345,7 +2814,7 @@ func (s *Service) GetDailyProjectUsage(ctx context.Context, projectID uuid.UUID,
 func (s *Service) GetProjectUsageLimits(ctx context.Context, projectID uuid.UUID) (_ *ProjectUsageLimits, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	user, err := s.getUserAndAuditLog(ctx, "get project usage limits", zap.String("projectID", projectID.String()))
+	user, err := s.getScopedUserAndAuditLog(ctx, []RESTKeyPermission{RESTKeyPermissionReadUsage}, "get project usage limits", zap.String("projectID", projectID.String()))
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
@@ -2365,14 +2834,39 @@ func (s *Service) GetProjectUsageLimits(ctx context.Context, projectID uuid.UUID
 		return nil, Error.Wrap(err)
 	}
 
-	return &ProjectUsageLimits{
+	bandwidthRate, bandwidthBurst, err := s.projectUsage.GetProjectBandwidthRateLimit(ctx, projectID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	limits := &ProjectUsageLimits{
 		StorageLimit:   prUsageLimits.StorageLimit,
 		BandwidthLimit: prUsageLimits.BandwidthLimit,
 		StorageUsed:    prUsageLimits.StorageUsed,
 		BandwidthUsed:  prUsageLimits.BandwidthUsed,
 		ObjectCount:    prObjectsSegments.ObjectCount,
 		SegmentCount:   prObjectsSegments.SegmentCount,
-	}, nil
+
+		BandwidthRateLimit:  bandwidthRate.Int64(),
+		BandwidthBurstLimit: bandwidthBurst.Int64(),
+	}
+
+	spendingCap, err := s.store.ProjectSpendingCaps().Get(ctx, projectID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, Error.Wrap(err)
+	}
+
+	// the cached limits of the project may not reflect the spending cap yet.
+	if spendingCap != nil && spendingCap.Capped {
+		limits.SpendingCapReached = true
+		limits.StorageLimit = 0
+		if spendingCap.LimitBandwidth {
+			limits.DownloadsPaused = true
+			limits.BandwidthLimit = 0
+		}
+	}
+
+	return limits, nil
 }
 
 // GetTotalUsageLimits returns total limits and current usage for all the projects.
@@ -2474,7 +2968,13 @@ func (s *Service) TokenAuth(ctx context.Context, token consoleauth.Token, authTi
 		return nil, err
 	}
 
-	return ctx, nil
+	if authTime.Sub(session.LastSeenAt) >= s.config.SessionLastSeenInterval {
+		if err := s.store.WebappSessions().UpdateLastSeen(ctx, sessionID, authTime); err != nil {
+			s.log.Warn("unable to update last seen time of session", zap.Stringer("sessionID", sessionID), zap.Error(err))
+		}
+	}
+
+	return WithSessionID(ctx, sessionID), nil
 }
 
 // KeyAuth returns an authenticated context by api key.
@@ -2483,17 +2983,21 @@ func (s *Service) KeyAuth(ctx context.Context, apikey string, authTime time.Time
 
 	ctx = consoleauth.WithAPIKey(ctx, []byte(apikey))
 
-	userID, exp, err := s.restKeys.GetUserAndExpirationFromKey(ctx, apikey)
+	key, err := s.restKeys.GetKeyInfo(ctx, apikey)
 	if err != nil {
 		return nil, err
 	}
 
-	ctx, err = s.authorize(ctx, userID, exp, authTime)
+	ctx, err = s.authorize(ctx, key.UserID, key.ExpiresAt, authTime)
 	if err != nil {
 		return nil, err
 	}
 
-	return ctx, nil
+	if err := s.restKeys.UpdateLastUsed(ctx, key, authTime); err != nil {
+		s.log.Warn("unable to update rest key last used time", zap.Stringer("userID", key.UserID), zap.Error(err))
+	}
+
+	return WithRESTKeyScope(ctx, key.Scope), nil
 }
 
 // checkProjectCanBeDeleted ensures that all data, api-keys and buckets are deleted and usage has been accounted.
@@ -2598,6 +3102,25 @@ func (s *Service) authorize(ctx context.Context, userID uuid.UUID, expiration ti
 	return WithUser(ctx, user), nil
 }
 
+// genHTTPError converts an error of the service methods, which the generated
+// api shares with the GraphQL api, to an error of the generated api.
+func genHTTPError(err error) api.HTTPError {
+	status := http.StatusInternalServerError
+	switch {
+	case ErrUnauthorized.Has(err), ErrNoMembership.Has(err), ErrRESTKeyScope.Has(err):
+		status = http.StatusUnauthorized
+	case ErrValidation.Has(err):
+		status = http.StatusBadRequest
+	case errors.Is(err, sql.ErrNoRows):
+		status = http.StatusNotFound
+	}
+
+	return api.HTTPError{
+		Status: status,
+		Err:    err,
+	}
+}
+
 // isProjectMember is return type of isProjectMember service method.
 type isProjectMember struct {
 	project    *Project
@@ -2607,6 +3130,10 @@ type isProjectMember struct {
 // isProjectOwner checks if the user is an owner of a project.
 func (s *Service) isProjectOwner(ctx context.Context, userID uuid.UUID, projectID uuid.UUID) (isOwner bool, err error) {
 	defer mon.Task()(&ctx)(&err)
+	if !GetRESTKeyScope(ctx).HasProject(projectID) {
+		return false, ErrUnauthorized.Wrap(ErrRESTKeyScope.New(unauthorizedErrMsg))
+	}
+
 	project, err := s.store.Projects().Get(ctx, projectID)
 	if err != nil {
 		return false, err
@@ -2622,6 +3149,10 @@ func (s *Service) isProjectOwner(ctx context.Context, userID uuid.UUID, projectI
 // isProjectMember checks if the user is a member of given project.
 func (s *Service) isProjectMember(ctx context.Context, userID uuid.UUID, projectID uuid.UUID) (_ isProjectMember, err error) {
 	defer mon.Task()(&ctx)(&err)
+	if !GetRESTKeyScope(ctx).HasProject(projectID) {
+		return isProjectMember{}, ErrNoMembership.Wrap(ErrRESTKeyScope.New(unauthorizedErrMsg))
+	}
+
 	project, err := s.store.Projects().Get(ctx, projectID)
 	if err != nil {
 		return isProjectMember{}, Error.Wrap(err)
@@ -2643,6 +3174,22 @@ func (s *Service) isProjectMember(ctx context.Context, userID uuid.UUID, project
 	return isProjectMember{}, ErrNoMembership.New(unauthorizedErrMsg)
 }
 
+// filterRESTKeyScopeProjects returns the projects which the scope of the rest key used to authenticate grants access to.
+func filterRESTKeyScopeProjects(ctx context.Context, projects []Project) []Project {
+	scope := GetRESTKeyScope(ctx)
+	if len(scope.ProjectIDs) == 0 {
+		return projects
+	}
+
+	filtered := make([]Project, 0, len(scope.ProjectIDs))
+	for _, project := range projects {
+		if scope.HasProject(project.ID) {
+			filtered = append(filtered, project)
+		}
+	}
+	return filtered
+}
+
 // WalletInfo contains all the information about a destination wallet assigned to a user.
 type WalletInfo struct {
 	Address blockchain.Address `json:"address"`
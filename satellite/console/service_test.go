@@ -15,8 +15,10 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
+	"storj.io/common/grant"
 	"storj.io/common/macaroon"
 	"storj.io/common/memory"
+	"storj.io/common/pb"
 	"storj.io/common/storj"
 	"storj.io/common/testcontext"
 	"storj.io/common/testrand"
@@ -25,6 +27,9 @@ import (
 	"storj.io/storj/private/testplanet"
 	"storj.io/storj/satellite"
 	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/console/consoleauth"
+	"storj.io/storj/satellite/console/sso"
+	"storj.io/storj/satellite/orders"
 )
 
 func TestService(t *testing.T) {
@@ -676,7 +681,7 @@ func TestRESTKeys(t *testing.T) {
 
 		now := time.Now()
 		expires := 5 * time.Hour
-		apiKey, expiresAt, err := service.CreateRESTKey(userCtx, expires)
+		apiKey, expiresAt, err := service.CreateRESTKey(userCtx, "", console.RESTKeyScope{}, expires)
 		require.NoError(t, err)
 		require.NotEmpty(t, apiKey)
 		require.True(t, expiresAt.After(now))
@@ -692,6 +697,81 @@ func TestRESTKeys(t *testing.T) {
 	})
 }
 
+func TestRESTKeyScopes(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		service := sat.API.Console.Service
+
+		proj1, err := sat.API.DB.Console().Projects().Get(ctx, planet.Uplinks[0].Projects[0].ID)
+		require.NoError(t, err)
+
+		userCtx, err := sat.UserContext(ctx, proj1.OwnerID)
+		require.NoError(t, err)
+
+		proj2, err := service.CreateProject(userCtx, console.ProjectInfo{Name: "Project 2"})
+		require.NoError(t, err)
+
+		// scopes need permissions and projects of the user
+		_, _, err = service.CreateRESTKey(userCtx, "invalid", console.RESTKeyScope{ProjectIDs: []uuid.UUID{proj1.ID}}, time.Hour)
+		require.True(t, console.ErrRESTKeyScope.Has(err))
+		_, _, err = service.CreateRESTKey(userCtx, "invalid", console.RESTKeyScope{
+			ProjectIDs:  []uuid.UUID{testrand.UUID()},
+			Permissions: []console.RESTKeyPermission{console.RESTKeyPermissionReadUsage},
+		}, time.Hour)
+		require.Error(t, err)
+
+		apiKey, _, err := service.CreateRESTKey(userCtx, "usage", console.RESTKeyScope{
+			ProjectIDs:  []uuid.UUID{proj1.ID},
+			Permissions: []console.RESTKeyPermission{console.RESTKeyPermissionReadUsage},
+		}, time.Hour)
+		require.NoError(t, err)
+
+		keyCtx, err := service.KeyAuth(ctx, apiKey, time.Now())
+		require.NoError(t, err)
+
+		// allowed by the scope
+		_, err = service.GetProjectUsageLimits(keyCtx, proj1.ID)
+		require.NoError(t, err)
+
+		projects, err := service.GetUsersProjects(keyCtx)
+		require.NoError(t, err)
+		require.Len(t, projects, 1)
+		require.Equal(t, proj1.ID, projects[0].ID)
+
+		// not allowed by the scope
+		_, err = service.GetProjectUsageLimits(keyCtx, proj2.ID)
+		require.True(t, console.ErrRESTKeyScope.Has(err))
+
+		_, _, err = service.CreateAPIKey(keyCtx, proj1.ID, "key")
+		require.True(t, console.ErrRESTKeyScope.Has(err))
+
+		_, err = service.CreateProject(keyCtx, console.ProjectInfo{Name: "Project 3"})
+		require.True(t, console.ErrRESTKeyScope.Has(err))
+
+		_, _, err = service.CreateRESTKey(keyCtx, "escalate", console.RESTKeyScope{}, time.Hour)
+		require.True(t, console.ErrRESTKeyScope.Has(err))
+
+		// listing and revocation
+		keys, err := service.GetRESTKeys(userCtx)
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+		require.Equal(t, "usage", keys[0].Name)
+		require.Equal(t, []uuid.UUID{proj1.ID}, keys[0].Scope.ProjectIDs)
+		require.NotNil(t, keys[0].LastUsedAt)
+
+		require.NoError(t, service.RevokeRESTKeyByID(userCtx, keys[0].ID))
+
+		_, err = service.KeyAuth(ctx, apiKey, time.Now())
+		require.Error(t, err)
+
+		keys, err = service.GetRESTKeys(userCtx)
+		require.NoError(t, err)
+		require.Empty(t, keys)
+	})
+}
+
 // TestLockAccount ensures user's gets locked when incorrect credentials are provided.
 func TestLockAccount(t *testing.T) {
 	testplanet.Run(t, testplanet.Config{
@@ -891,3 +971,763 @@ func TestSessionExpiration(t *testing.T) {
 		require.ErrorIs(t, sql.ErrNoRows, err)
 	})
 }
+
+func TestSessions(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 0,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		service := sat.API.Console.Service
+
+		user, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Test User",
+			Email:    "test@mail.test",
+		}, 1)
+		require.NoError(t, err)
+
+		login := func(userAgent string) (context.Context, uuid.UUID) {
+			token, err := service.Token(ctx, console.AuthUser{Email: user.Email, Password: user.FullName, IP: "127.0.0.1", UserAgent: userAgent})
+			require.NoError(t, err)
+
+			userCtx, err := service.TokenAuth(ctx, token, time.Now())
+			require.NoError(t, err)
+
+			sessionID, ok := console.GetSessionID(userCtx)
+			require.True(t, ok)
+			return userCtx, sessionID
+		}
+
+		t.Run("list", func(t *testing.T) {
+			userCtx, currentID := login("current")
+			_, otherID := login("other")
+
+			sessions, err := service.GetSessions(userCtx)
+			require.NoError(t, err)
+
+			found := map[uuid.UUID]console.Session{}
+			for _, session := range sessions {
+				found[session.ID] = session
+			}
+			require.Contains(t, found, currentID)
+			require.Contains(t, found, otherID)
+			require.True(t, found[currentID].Current)
+			require.False(t, found[otherID].Current)
+			require.Equal(t, "127.0.0.1", found[otherID].IPAddress)
+			require.Equal(t, "other", found[otherID].UserAgent)
+			require.False(t, found[otherID].CreatedAt.IsZero())
+			require.False(t, found[otherID].LastSeenAt.IsZero())
+		})
+
+		t.Run("revoke", func(t *testing.T) {
+			userCtx, _ := login("current")
+			_, otherID := login("other")
+
+			require.True(t, console.ErrUnauthorized.Has(service.RevokeSession(userCtx, testrand.UUID())))
+
+			require.NoError(t, service.RevokeSession(userCtx, otherID))
+			_, err := sat.DB.Console().WebappSessions().GetBySessionID(ctx, otherID)
+			require.ErrorIs(t, err, sql.ErrNoRows)
+		})
+
+		t.Run("revoke others", func(t *testing.T) {
+			userCtx, currentID := login("current")
+			login("other")
+
+			revoked, err := service.RevokeOtherSessions(userCtx)
+			require.NoError(t, err)
+			require.NotZero(t, revoked)
+
+			sessions, err := service.GetSessions(userCtx)
+			require.NoError(t, err)
+			require.Len(t, sessions, 1)
+			require.Equal(t, currentID, sessions[0].ID)
+		})
+
+		t.Run("password change", func(t *testing.T) {
+			userCtx, currentID := login("current")
+			login("other")
+
+			newPassword := "newpassword123"
+			require.NoError(t, service.ChangePassword(userCtx, user.FullName, newPassword))
+			defer func() {
+				require.NoError(t, service.ChangePassword(userCtx, newPassword, user.FullName))
+			}()
+
+			sessions, err := sat.DB.Console().WebappSessions().GetAllByUserID(ctx, user.ID)
+			require.NoError(t, err)
+			require.Len(t, sessions, 1)
+			require.Equal(t, currentID, sessions[0].ID)
+		})
+	})
+}
+
+func TestSSO(t *testing.T) {
+	const issuer = "https://idp.test"
+
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 0,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Console.SSO.Enabled = true
+				config.Console.SSO.Providers = sso.Providers{
+					{Domain: "provisioned.test", Issuer: issuer, ClientID: "client", Provision: true},
+					{Domain: "linked.test", Issuer: issuer, ClientID: "client", LinkExisting: true},
+					{Domain: "closed.test", Issuer: issuer, ClientID: "client"},
+				}
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		service := sat.API.Console.Service
+		users := sat.DB.Console().Users()
+
+		insertUser := func(email string) *console.User {
+			user, err := users.Insert(ctx, &console.User{
+				ID:           testrand.UUID(),
+				Email:        email,
+				FullName:     "Existing User",
+				PasswordHash: []byte("password"),
+			})
+			require.NoError(t, err)
+
+			active := console.Active
+			require.NoError(t, users.Update(ctx, user.ID, console.UpdateUserRequest{Status: &active}))
+			return user
+		}
+
+		userOfToken := func(token consoleauth.Token) uuid.UUID {
+			userCtx, err := service.TokenAuth(ctx, token, time.Now())
+			require.NoError(t, err)
+			user, err := console.GetUser(userCtx)
+			require.NoError(t, err)
+			return user.ID
+		}
+
+		t.Run("password login and registration are rejected", func(t *testing.T) {
+			insertUser("password@closed.test")
+
+			_, err := service.Token(ctx, console.AuthUser{Email: "password@closed.test", Password: "password"})
+			require.True(t, console.ErrSSORequired.Has(err))
+
+			regToken, err := service.CreateRegToken(ctx, 1)
+			require.NoError(t, err)
+			_, err = service.CreateUser(ctx, console.CreateUser{
+				FullName: "New User",
+				Email:    "new@closed.test",
+				Password: "password123",
+			}, regToken.Secret)
+			require.True(t, console.ErrSSORequired.Has(err))
+		})
+
+		t.Run("provision", func(t *testing.T) {
+			identity := sso.Identity{Issuer: issuer, Subject: "provisioned", Email: "user@provisioned.test", Name: "Provisioned User"}
+
+			token, err := service.TokenSSO(ctx, identity, "", "")
+			require.NoError(t, err)
+			userID := userOfToken(token)
+
+			user, err := users.Get(ctx, userID)
+			require.NoError(t, err)
+			require.Equal(t, identity.Email, user.Email)
+			require.Equal(t, identity.Name, user.FullName)
+			require.Equal(t, console.Active, user.Status)
+
+			// signing in again finds the same user by the linked identity.
+			identity.Email = "renamed@provisioned.test"
+			token, err = service.TokenSSO(ctx, identity, "", "")
+			require.NoError(t, err)
+			require.Equal(t, userID, userOfToken(token))
+		})
+
+		t.Run("link existing", func(t *testing.T) {
+			existing := insertUser("user@linked.test")
+			identity := sso.Identity{Issuer: issuer, Subject: "linked", Email: existing.Email}
+
+			token, err := service.TokenSSO(ctx, identity, "", "")
+			require.NoError(t, err)
+			require.Equal(t, existing.ID, userOfToken(token))
+
+			link, err := sat.DB.Console().SSOIdentities().Get(ctx, issuer, "linked")
+			require.NoError(t, err)
+			require.Equal(t, existing.ID, link.UserID)
+		})
+
+		t.Run("no provisioning or linking", func(t *testing.T) {
+			existing := insertUser("existing@closed.test")
+
+			_, err := service.TokenSSO(ctx, sso.Identity{Issuer: issuer, Subject: "existing", Email: existing.Email}, "", "")
+			require.True(t, console.ErrSSO.Has(err))
+
+			_, err = service.TokenSSO(ctx, sso.Identity{Issuer: issuer, Subject: "unknown", Email: "unknown@closed.test"}, "", "")
+			require.True(t, console.ErrSSO.Has(err))
+		})
+
+		t.Run("other issuer", func(t *testing.T) {
+			_, err := service.TokenSSO(ctx, sso.Identity{Issuer: "https://other.test", Subject: "provisioned", Email: "user@provisioned.test"}, "", "")
+			require.True(t, console.ErrSSO.Has(err))
+		})
+	})
+}
+
+func TestOrganizations(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 0,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		service := sat.API.Console.Service
+
+		admin, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Organization Admin",
+			Email:    "admin@mail.test",
+		}, 2)
+		require.NoError(t, err)
+		adminCtx, err := sat.UserContext(ctx, admin.ID)
+		require.NoError(t, err)
+
+		member, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Organization Member",
+			Email:    "member@mail.test",
+		}, 1)
+		require.NoError(t, err)
+		memberCtx, err := sat.UserContext(ctx, member.ID)
+		require.NoError(t, err)
+
+		_, err = service.CreateOrganization(adminCtx, "")
+		require.True(t, console.ErrValidation.Has(err))
+
+		organization, err := service.CreateOrganization(adminCtx, "Test Organization")
+		require.NoError(t, err)
+
+		// the organization has its own payment account.
+		_, err = sat.API.Payments.Accounts.Balance(ctx, organization.ID)
+		require.NoError(t, err)
+
+		organizations, err := service.GetOrganizations(adminCtx)
+		require.NoError(t, err)
+		require.Len(t, organizations, 1)
+		require.Equal(t, organization.ID, organizations[0].ID)
+
+		_, err = service.GetOrganization(memberCtx, organization.ID)
+		require.True(t, console.ErrNoMembership.Has(err))
+
+		require.NoError(t, service.RenameOrganization(adminCtx, organization.ID, "Renamed Organization"))
+		organization, err = service.GetOrganization(adminCtx, organization.ID)
+		require.NoError(t, err)
+		require.Equal(t, "Renamed Organization", organization.Name)
+
+		project, err := sat.AddProject(ctx, admin.ID, "Organization Project")
+		require.NoError(t, err)
+
+		// only the owner of a project can move it to an organization.
+		err = service.MoveProjectToOrganization(memberCtx, project.ID, organization.ID)
+		require.True(t, console.ErrUnauthorized.Has(err))
+
+		require.NoError(t, service.MoveProjectToOrganization(adminCtx, project.ID, organization.ID))
+
+		project, err = sat.DB.Console().Projects().Get(ctx, project.ID)
+		require.NoError(t, err)
+		require.Equal(t, organization.ID, project.OrganizationID)
+		require.Equal(t, organization.ID, project.BillingAccountID())
+
+		err = service.MoveProjectToOrganization(adminCtx, project.ID, organization.ID)
+		require.True(t, console.ErrValidation.Has(err))
+
+		t.Run("members", func(t *testing.T) {
+			require.NoError(t, service.AddOrganizationMember(adminCtx, organization.ID, member.Email))
+			require.True(t, console.ErrValidation.Has(service.AddOrganizationMember(adminCtx, organization.ID, member.Email)))
+
+			members, err := service.GetOrganizationMembers(memberCtx, organization.ID)
+			require.NoError(t, err)
+			require.Len(t, members, 2)
+			require.Equal(t, admin.ID, members[0].ID)
+			require.Equal(t, member.ID, members[1].ID)
+			require.Equal(t, member.FullName, members[1].FullName)
+			require.Equal(t, member.Email, members[1].Email)
+			require.False(t, members[1].JoinedAt.IsZero())
+
+			// new members get access to the projects of the organization.
+			projects, err := service.GetOrganizationProjects(memberCtx, organization.ID)
+			require.NoError(t, err)
+			require.Len(t, projects, 1)
+
+			_, err = service.GetProject(memberCtx, project.ID)
+			require.NoError(t, err)
+
+			require.NoError(t, service.RemoveOrganizationMember(adminCtx, organization.ID, member.ID))
+
+			_, err = service.GetProject(memberCtx, project.ID)
+			require.True(t, console.ErrNoMembership.Has(err))
+
+			// the last member of an organization can't be removed.
+			err = service.RemoveOrganizationMember(adminCtx, organization.ID, admin.ID)
+			require.True(t, console.ErrValidation.Has(err))
+		})
+
+		t.Run("charges", func(t *testing.T) {
+			since := time.Now().Add(-time.Hour)
+			before := time.Now().Add(time.Hour)
+
+			charges, err := service.Payments().OrganizationProjectsCharges(adminCtx, organization.ID, since, before)
+			require.NoError(t, err)
+			require.Len(t, charges, 1)
+			require.Equal(t, project.ID, charges[0].ProjectID)
+
+			// the project isn't charged to its owner anymore.
+			charges, err = service.Payments().ProjectsCharges(adminCtx, since, before)
+			require.NoError(t, err)
+			require.Empty(t, charges)
+
+			_, err = service.Payments().OrganizationProjectsCharges(memberCtx, organization.ID, since, before)
+			require.True(t, console.ErrNoMembership.Has(err))
+		})
+
+		t.Run("wallet", func(t *testing.T) {
+			_, err := service.Payments().GetOrganizationWallet(adminCtx, organization.ID)
+			require.Error(t, err)
+
+			// token payments to the wallet are credited to the organization.
+			address := blockchain.Address{1, 2, 3}
+			require.NoError(t, sat.DB.Wallets().Add(ctx, organization.ID, address))
+
+			wallet, err := service.Payments().GetOrganizationWallet(adminCtx, organization.ID)
+			require.NoError(t, err)
+			require.Equal(t, address, wallet.Address)
+
+			wallet, err = service.Payments().ClaimOrganizationWallet(adminCtx, organization.ID)
+			require.NoError(t, err)
+			require.Equal(t, address, wallet.Address)
+
+			_, err = service.Payments().GetOrganizationWallet(memberCtx, organization.ID)
+			require.True(t, console.ErrNoMembership.Has(err))
+		})
+	})
+}
+
+func TestProjectSpendingCaps(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 0,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		service := sat.API.Console.Service
+
+		owner, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Project Owner",
+			Email:    "owner@mail.test",
+		}, 1)
+		require.NoError(t, err)
+		ownerCtx, err := sat.UserContext(ctx, owner.ID)
+		require.NoError(t, err)
+
+		member, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Project Member",
+			Email:    "member@mail.test",
+		}, 1)
+		require.NoError(t, err)
+		memberCtx, err := sat.UserContext(ctx, member.ID)
+		require.NoError(t, err)
+
+		project, err := sat.AddProject(ctx, owner.ID, "spending")
+		require.NoError(t, err)
+
+		_, err = service.AddProjectMembers(ownerCtx, project.ID, []string{member.Email})
+		require.NoError(t, err)
+
+		spending, err := service.GetProjectSpending(memberCtx, project.ID)
+		require.NoError(t, err)
+		require.Nil(t, spending.SpendingCap)
+		require.Zero(t, spending.Charges)
+
+		_, err = service.SetProjectSpendingCap(ownerCtx, project.ID, console.ProjectSpendingCapInfo{Cap: 0})
+		require.True(t, console.ErrValidation.Has(err))
+
+		_, err = service.SetProjectSpendingCap(ownerCtx, project.ID, console.ProjectSpendingCapInfo{Cap: 1000, AlertThresholds: []int{101}})
+		require.True(t, console.ErrValidation.Has(err))
+
+		_, err = service.SetProjectSpendingCap(memberCtx, project.ID, console.ProjectSpendingCapInfo{Cap: 1000})
+		require.True(t, console.ErrUnauthorized.Has(err))
+
+		spendingCap, err := service.SetProjectSpendingCap(ownerCtx, project.ID, console.ProjectSpendingCapInfo{
+			Cap:             1000,
+			AlertThresholds: []int{100, 50, 80, 50},
+			Enforce:         true,
+		})
+		require.NoError(t, err)
+		require.EqualValues(t, 1000, spendingCap.Cap)
+		require.Equal(t, []int{50, 80, 100}, spendingCap.AlertThresholds)
+		require.True(t, spendingCap.Enforce)
+		require.False(t, spendingCap.Capped)
+
+		spending, err = service.GetProjectSpending(memberCtx, project.ID)
+		require.NoError(t, err)
+		require.NotNil(t, spending.SpendingCap)
+		require.EqualValues(t, 1000, spending.SpendingCap.Cap)
+
+		// a capped project has its effective limits lowered.
+		spendingCap.Capped = true
+		require.NoError(t, sat.DB.Console().ProjectSpendingCaps().Update(ctx, *spendingCap))
+
+		limits, err := sat.DB.ProjectAccounting().GetProjectLimits(ctx, project.ID)
+		require.NoError(t, err)
+		require.NotNil(t, limits.Usage)
+		require.Zero(t, *limits.Usage)
+		require.NotNil(t, limits.Segments)
+		require.Zero(t, *limits.Segments)
+		if limits.Bandwidth != nil {
+			require.NotZero(t, *limits.Bandwidth)
+		}
+
+		usageLimits, err := service.GetProjectUsageLimits(memberCtx, project.ID)
+		require.NoError(t, err)
+		require.True(t, usageLimits.SpendingCapReached)
+		require.False(t, usageLimits.DownloadsPaused)
+
+		// changing only the other settings keeps the alert state.
+		spendingCap, err = service.SetProjectSpendingCap(ownerCtx, project.ID, console.ProjectSpendingCapInfo{
+			Cap:             1000,
+			AlertThresholds: []int{50, 80, 100},
+			Enforce:         true,
+			LimitBandwidth:  true,
+		})
+		require.NoError(t, err)
+		require.True(t, spendingCap.Capped)
+		require.True(t, spendingCap.LimitBandwidth)
+
+		limits, err = sat.DB.ProjectAccounting().GetProjectLimits(ctx, project.ID)
+		require.NoError(t, err)
+		require.NotNil(t, limits.Bandwidth)
+		require.Zero(t, *limits.Bandwidth)
+
+		// setting a new cap lifts the lowered limits.
+		spendingCap, err = service.SetProjectSpendingCap(ownerCtx, project.ID, console.ProjectSpendingCapInfo{Cap: 2000})
+		require.NoError(t, err)
+		require.False(t, spendingCap.Capped)
+
+		limits, err = sat.DB.ProjectAccounting().GetProjectLimits(ctx, project.ID)
+		require.NoError(t, err)
+		if limits.Usage != nil {
+			require.NotZero(t, *limits.Usage)
+		}
+
+		err = service.RemoveProjectSpendingCap(memberCtx, project.ID)
+		require.True(t, console.ErrUnauthorized.Has(err))
+
+		require.NoError(t, service.RemoveProjectSpendingCap(ownerCtx, project.ID))
+
+		spending, err = service.GetProjectSpending(ownerCtx, project.ID)
+		require.NoError(t, err)
+		require.Nil(t, spending.SpendingCap)
+	})
+}
+
+func TestProjectedCharges(t *testing.T) {
+	start, end := console.BillingPeriod(time.Date(2022, time.June, 15, 12, 0, 0, 0, time.UTC))
+	require.Equal(t, time.Date(2022, time.June, 1, 0, 0, 0, 0, time.UTC), start)
+	require.Equal(t, time.Date(2022, time.July, 1, 0, 0, 0, 0, time.UTC), end)
+
+	require.EqualValues(t, 300, console.ProjectedCharges(150, time.Date(2022, time.June, 16, 0, 0, 0, 0, time.UTC)))
+	require.EqualValues(t, 150, console.ProjectedCharges(150, time.Date(2022, time.June, 1, 0, 0, 0, 0, time.UTC)))
+	require.EqualValues(t, 0, console.ProjectedCharges(0, time.Date(2022, time.June, 20, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestAccessGrants(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 0,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		service := sat.API.Console.Service
+
+		user, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Access Grant User",
+			Email:    "grants@mail.test",
+		}, 1)
+		require.NoError(t, err)
+		userCtx, err := sat.UserContext(ctx, user.ID)
+		require.NoError(t, err)
+
+		other, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Other User",
+			Email:    "other@mail.test",
+		}, 1)
+		require.NoError(t, err)
+		otherCtx, err := sat.UserContext(ctx, other.ID)
+		require.NoError(t, err)
+
+		project, err := sat.AddProject(ctx, user.ID, "grants")
+		require.NoError(t, err)
+
+		_, apiKey, err := service.CreateAPIKey(userCtx, project.ID, "key")
+		require.NoError(t, err)
+
+		restricted, err := apiKey.Restrict(macaroon.WithNonce(macaroon.Caveat{
+			DisallowWrites:  true,
+			DisallowDeletes: true,
+			AllowedPaths:    []*macaroon.Caveat_Path{{Bucket: []byte("photos")}},
+		}))
+		require.NoError(t, err)
+
+		_, err = service.RegisterAccessGrant(userCtx, project.ID, console.RegisterAccessGrantRequest{
+			Name:   "unrestricted",
+			APIKey: apiKey.Serialize(),
+		})
+		require.True(t, console.ErrValidation.Has(err))
+
+		_, err = service.RegisterAccessGrant(userCtx, project.ID, console.RegisterAccessGrantRequest{
+			Name:   "",
+			APIKey: restricted.Serialize(),
+		})
+		require.True(t, console.ErrValidation.Has(err))
+
+		_, err = service.RegisterAccessGrant(otherCtx, project.ID, console.RegisterAccessGrantRequest{
+			Name:   "read-only",
+			APIKey: restricted.Serialize(),
+		})
+		require.True(t, console.ErrNoMembership.Has(err))
+
+		accessGrant, err := service.RegisterAccessGrant(userCtx, project.ID, console.RegisterAccessGrantRequest{
+			Name:   "read-only",
+			APIKey: restricted.Serialize(),
+		})
+		require.NoError(t, err)
+		require.Equal(t, "read-only", accessGrant.Name)
+		require.Equal(t, "read, list; buckets: photos", accessGrant.Caveats)
+		require.Len(t, accessGrant.Tails, 2)
+		require.Equal(t, restricted.Tail(), accessGrant.Tails[1])
+
+		derived, err := restricted.Restrict(macaroon.WithNonce(macaroon.Caveat{DisallowLists: true}))
+		require.NoError(t, err)
+
+		derivedGrant, err := service.RegisterAccessGrant(userCtx, project.ID, console.RegisterAccessGrantRequest{
+			Name:   "derived",
+			APIKey: derived.Serialize(),
+		})
+		require.NoError(t, err)
+		require.Equal(t, "read; buckets: photos", derivedGrant.Caveats)
+
+		accessGrants, err := service.GetAccessGrants(userCtx, project.ID)
+		require.NoError(t, err)
+		require.Len(t, accessGrants, 2)
+		for _, grant := range accessGrants {
+			require.False(t, grant.Revoked)
+		}
+
+		err = service.RevokeAccessGrant(otherCtx, accessGrant.ID)
+		require.True(t, console.ErrNoMembership.Has(err))
+
+		require.NoError(t, service.RevokeAccessGrant(userCtx, accessGrant.ID))
+
+		// revoking an access grant revokes the access grants derived from it.
+		accessGrants, err = service.GetAccessGrants(userCtx, project.ID)
+		require.NoError(t, err)
+		require.Len(t, accessGrants, 2)
+		for _, grant := range accessGrants {
+			require.True(t, grant.Revoked)
+		}
+
+		revoked, err := sat.DB.Revocation().Check(ctx, [][]byte{restricted.Tail()})
+		require.NoError(t, err)
+		require.True(t, revoked)
+
+		// the API key itself isn't revoked.
+		revoked, err = sat.DB.Revocation().Check(ctx, [][]byte{apiKey.Tail()})
+		require.NoError(t, err)
+		require.False(t, revoked)
+
+		require.NoError(t, service.DeleteAccessGrant(userCtx, derivedGrant.ID))
+
+		accessGrants, err = service.GetAccessGrants(userCtx, project.ID)
+		require.NoError(t, err)
+		require.Len(t, accessGrants, 1)
+		require.Equal(t, accessGrant.ID, accessGrants[0].ID)
+
+		// members can only manage the access grants they registered.
+		member, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Member User",
+			Email:    "grants-member@mail.test",
+		}, 1)
+		require.NoError(t, err)
+		memberCtx, err := sat.UserContext(ctx, member.ID)
+		require.NoError(t, err)
+
+		_, err = service.AddProjectMembers(userCtx, project.ID, []string{member.Email})
+		require.NoError(t, err)
+
+		memberKey, err := apiKey.Restrict(macaroon.WithNonce(macaroon.Caveat{DisallowDeletes: true}))
+		require.NoError(t, err)
+
+		memberGrant, err := service.RegisterAccessGrant(memberCtx, project.ID, console.RegisterAccessGrantRequest{
+			Name:   "member",
+			APIKey: memberKey.Serialize(),
+		})
+		require.NoError(t, err)
+		require.Equal(t, member.ID, memberGrant.CreatedBy)
+
+		err = service.RevokeAccessGrant(memberCtx, accessGrant.ID)
+		require.True(t, console.ErrUnauthorized.Has(err))
+
+		err = service.DeleteAccessGrant(memberCtx, accessGrant.ID)
+		require.True(t, console.ErrUnauthorized.Has(err))
+
+		require.NoError(t, service.RevokeAccessGrant(memberCtx, memberGrant.ID))
+
+		// the owner manages the access grants of every member.
+		require.NoError(t, service.DeleteAccessGrant(userCtx, memberGrant.ID))
+	})
+}
+
+func TestProjectUsageExports(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		service := sat.API.Console.Service
+
+		project := planet.Uplinks[0].Projects[0]
+		ownerCtx, err := sat.UserContext(ctx, project.Owner.ID)
+		require.NoError(t, err)
+
+		member, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Project Member",
+			Email:    "member@mail.test",
+		}, 1)
+		require.NoError(t, err)
+		memberCtx, err := sat.UserContext(ctx, member.ID)
+		require.NoError(t, err)
+
+		_, err = service.AddProjectMembers(ownerCtx, project.ID, []string{member.Email})
+		require.NoError(t, err)
+
+		access, err := planet.Uplinks[0].Access[sat.ID()].Serialize()
+		require.NoError(t, err)
+
+		export, err := service.GetProjectUsageExport(memberCtx, project.ID)
+		require.NoError(t, err)
+		require.Nil(t, export)
+
+		info := console.ProjectUsageExportInfo{
+			Access:   access,
+			Bucket:   "exports",
+			Prefix:   "/billing/usage",
+			Format:   console.UsageExportFormatParquet,
+			Schedule: console.UsageExportScheduleDaily,
+		}
+
+		for _, invalid := range []func(info *console.ProjectUsageExportInfo){
+			func(info *console.ProjectUsageExportInfo) { info.Access = "invalid" },
+			func(info *console.ProjectUsageExportInfo) { info.Bucket = "" },
+			func(info *console.ProjectUsageExportInfo) { info.Format = "xlsx" },
+			func(info *console.ProjectUsageExportInfo) { info.Schedule = "hourly" },
+		} {
+			invalidInfo := info
+			invalid(&invalidInfo)
+			_, err = service.SetProjectUsageExport(ownerCtx, project.ID, invalidInfo)
+			require.True(t, console.ErrValidation.Has(err))
+		}
+
+		_, err = service.SetProjectUsageExport(memberCtx, project.ID, info)
+		require.True(t, console.ErrUnauthorized.Has(err))
+
+		export, err = service.SetProjectUsageExport(ownerCtx, project.ID, info)
+		require.NoError(t, err)
+		require.Equal(t, "billing/usage/", export.Prefix)
+		exportedUntil := console.UsageExportScheduleDaily.PeriodStart(time.Now())
+		require.Equal(t, exportedUntil, export.ExportedUntil)
+
+		// switching to a longer schedule doesn't export the same usage again.
+		info.Schedule = console.UsageExportScheduleMonthly
+		export, err = service.SetProjectUsageExport(ownerCtx, project.ID, info)
+		require.NoError(t, err)
+		require.Equal(t, console.UsageExportScheduleMonthly, export.Schedule)
+		require.Equal(t, exportedUntil, export.ExportedUntil)
+
+		stored, err := service.GetProjectUsageExport(memberCtx, project.ID)
+		require.NoError(t, err)
+		require.Equal(t, export, stored)
+		require.NotContains(t, string(stored.EncryptedAccess), access)
+
+		// only an access grant restricted to uploads to the prefix is stored.
+		decrypted, err := console.DecryptUsageExportAccess(sat.Config.Console.UsageExportKeys, stored.EncryptedAccess)
+		require.NoError(t, err)
+		require.NotEqual(t, access, decrypted)
+
+		restricted, err := grant.ParseAccess(decrypted)
+		require.NoError(t, err)
+		mac, err := macaroon.ParseMacaroon(restricted.APIKey.SerializeRaw())
+		require.NoError(t, err)
+		require.NotEmpty(t, mac.Caveats())
+
+		var caveat macaroon.Caveat
+		require.NoError(t, pb.Unmarshal(mac.Caveats()[len(mac.Caveats())-1], &caveat))
+		require.True(t, caveat.DisallowReads)
+		require.True(t, caveat.DisallowLists)
+		require.True(t, caveat.DisallowDeletes)
+		require.False(t, caveat.DisallowWrites)
+		require.Len(t, caveat.AllowedPaths, 1)
+		require.Equal(t, []byte("exports"), caveat.AllowedPaths[0].Bucket)
+
+		err = service.RemoveProjectUsageExport(memberCtx, project.ID)
+		require.True(t, console.ErrUnauthorized.Has(err))
+
+		require.NoError(t, service.RemoveProjectUsageExport(ownerCtx, project.ID))
+
+		export, err = service.GetProjectUsageExport(memberCtx, project.ID)
+		require.NoError(t, err)
+		require.Nil(t, export)
+	})
+}
+
+func TestUsageExportAccessEncryption(t *testing.T) {
+	keys, err := orders.NewEncryptionKeys(orders.EncryptionKey{
+		ID:  orders.EncryptionKeyID{1},
+		Key: storj.Key{1},
+	})
+	require.NoError(t, err)
+
+	_, err = console.EncryptUsageExportAccess(orders.EncryptionKeys{}, "access")
+	require.Error(t, err)
+
+	encrypted, err := console.EncryptUsageExportAccess(*keys, "access")
+	require.NoError(t, err)
+	require.NotContains(t, string(encrypted), "access")
+
+	decrypted, err := console.DecryptUsageExportAccess(*keys, encrypted)
+	require.NoError(t, err)
+	require.Equal(t, "access", decrypted)
+
+	// the key is looked up by its identifier, so new keys can be added.
+	rotated, err := orders.NewEncryptionKeys(orders.EncryptionKey{
+		ID:  orders.EncryptionKeyID{2},
+		Key: storj.Key{2},
+	}, keys.Default)
+	require.NoError(t, err)
+
+	decrypted, err = console.DecryptUsageExportAccess(*rotated, encrypted)
+	require.NoError(t, err)
+	require.Equal(t, "access", decrypted)
+
+	encrypted[len(encrypted)-1] ^= 1
+	_, err = console.DecryptUsageExportAccess(*keys, encrypted)
+	require.Error(t, err)
+
+	_, err = console.DecryptUsageExportAccess(*keys, encrypted[:8])
+	require.Error(t, err)
+}
+
+func TestUsageExportSchedulePeriodStart(t *testing.T) {
+	// 2022-06-09 is a Thursday.
+	now := time.Date(2022, time.June, 9, 15, 30, 0, 0, time.UTC)
+	sunday := time.Date(2022, time.June, 12, 23, 0, 0, 0, time.UTC)
+
+	for _, tt := range []struct {
+		schedule console.UsageExportSchedule
+		now      time.Time
+		expected time.Time
+	}{
+		{console.UsageExportScheduleDaily, now, time.Date(2022, time.June, 9, 0, 0, 0, 0, time.UTC)},
+		{console.UsageExportScheduleWeekly, now, time.Date(2022, time.June, 6, 0, 0, 0, 0, time.UTC)},
+		{console.UsageExportScheduleWeekly, sunday, time.Date(2022, time.June, 6, 0, 0, 0, 0, time.UTC)},
+		{console.UsageExportScheduleMonthly, now, time.Date(2022, time.June, 1, 0, 0, 0, 0, time.UTC)},
+		{console.UsageExportScheduleDaily, now.In(time.FixedZone("", -20*60*60)), time.Date(2022, time.June, 9, 0, 0, 0, 0, time.UTC)},
+	} {
+		require.Equal(t, tt.expected, tt.schedule.PeriodStart(tt.now), tt.schedule)
+	}
+}
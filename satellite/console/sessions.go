@@ -0,0 +1,149 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package console
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/uuid"
+)
+
+// sessionKey is context key for the ID of the webapp session used to authenticate.
+const sessionKey key = 3
+
+// WithSessionID creates new context with the ID of the webapp session used to authenticate.
+func WithSessionID(ctx context.Context, sessionID uuid.UUID) context.Context {
+	return context.WithValue(ctx, sessionKey, sessionID)
+}
+
+// GetSessionID returns the ID of the webapp session used to authenticate.
+// It returns false if the request wasn't authenticated with a session.
+func GetSessionID(ctx context.Context) (uuid.UUID, bool) {
+	sessionID, ok := ctx.Value(sessionKey).(uuid.UUID)
+	return sessionID, ok
+}
+
+// Session holds the information about an active webapp session of a user.
+type Session struct {
+	ID         uuid.UUID `json:"id"`
+	IPAddress  string    `json:"ipAddress"`
+	UserAgent  string    `json:"userAgent"`
+	CreatedAt  time.Time `json:"createdAt"`
+	LastSeenAt time.Time `json:"lastSeenAt"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+	// Current is whether the session is the one used for the request.
+	Current bool `json:"current"`
+}
+
+// GetSessions returns the active webapp sessions of the user, most recently
+// used first.
+func (s *Service) GetSessions(ctx context.Context) (_ []Session, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "get sessions")
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	webappSessions, err := s.store.WebappSessions().GetAllByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	currentID, _ := GetSessionID(ctx)
+	now := time.Now()
+
+	sessions := make([]Session, 0, len(webappSessions))
+	for _, session := range webappSessions {
+		if !session.ExpiresAt.After(now) {
+			continue
+		}
+		sessions = append(sessions, Session{
+			ID:         session.ID,
+			IPAddress:  session.Address,
+			UserAgent:  session.UserAgent,
+			CreatedAt:  session.CreatedAt,
+			LastSeenAt: session.LastSeenAt,
+			ExpiresAt:  session.ExpiresAt,
+			Current:    session.ID == currentID,
+		})
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastSeenAt.After(sessions[j].LastSeenAt)
+	})
+
+	return sessions, nil
+}
+
+// RevokeSession signs the user out of one of their webapp sessions.
+func (s *Service) RevokeSession(ctx context.Context, sessionID uuid.UUID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "revoke session", zap.String("sessionID", sessionID.String()))
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	session, err := s.store.WebappSessions().GetBySessionID(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrUnauthorized.New(unauthorizedErrMsg)
+		}
+		return Error.Wrap(err)
+	}
+	if session.UserID != user.ID {
+		return ErrUnauthorized.New(unauthorizedErrMsg)
+	}
+
+	return Error.Wrap(s.store.WebappSessions().DeleteBySessionID(ctx, sessionID))
+}
+
+// RevokeOtherSessions signs the user out of all of their webapp sessions
+// except the one used for the request. It returns the number of revoked sessions.
+func (s *Service) RevokeOtherSessions(ctx context.Context) (_ int, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "revoke other sessions")
+	if err != nil {
+		return 0, Error.Wrap(err)
+	}
+
+	currentID, _ := GetSessionID(ctx)
+
+	revoked, err := s.revokeSessions(ctx, user.ID, currentID)
+	return revoked, Error.Wrap(err)
+}
+
+// revokeSessions deletes all webapp sessions of the user except the one with
+// the ID except. A zero except deletes all sessions.
+func (s *Service) revokeSessions(ctx context.Context, userID, except uuid.UUID) (revoked int, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	sessions, err := s.store.WebappSessions().GetAllByUserID(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	var group errs.Group
+	for _, session := range sessions {
+		if session.ID == except {
+			continue
+		}
+		if err := s.store.WebappSessions().DeleteBySessionID(ctx, session.ID); err != nil {
+			group.Add(err)
+			continue
+		}
+		revoked++
+	}
+
+	return revoked, group.Err()
+}
@@ -0,0 +1,236 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package console
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/uuid"
+)
+
+// maxSpendingAlertThresholds is the maximum number of alert thresholds of a spending cap.
+const maxSpendingAlertThresholds = 10
+
+// ProjectSpendingCaps exposes methods to manage the monthly spending caps of projects.
+//
+// architecture: Database
+type ProjectSpendingCaps interface {
+	// Insert inserts the spending cap of a project.
+	Insert(ctx context.Context, spendingCap ProjectSpendingCap) error
+	// Get returns the spending cap of the project.
+	Get(ctx context.Context, projectID uuid.UUID) (*ProjectSpendingCap, error)
+	// GetAll returns the spending caps of all projects.
+	GetAll(ctx context.Context) ([]ProjectSpendingCap, error)
+	// Update updates the spending cap of a project.
+	Update(ctx context.Context, spendingCap ProjectSpendingCap) error
+	// Delete removes the spending cap of the project.
+	Delete(ctx context.Context, projectID uuid.UUID) error
+}
+
+// ProjectSpendingCap is the monthly budget of a project.
+type ProjectSpendingCap struct {
+	ProjectID uuid.UUID `json:"projectId"`
+	// Cap is the monthly budget of the project in cents.
+	Cap int64 `json:"cap"`
+	// AlertThresholds are the percentages of the cap at which the members of
+	// the project are alerted, in ascending order.
+	AlertThresholds []int `json:"alertThresholds"`
+	// Enforce is whether the storage and segment limits of the project are
+	// lowered when the charges of the month reach the cap.
+	Enforce bool `json:"enforce"`
+	// LimitBandwidth is whether the bandwidth limit of an enforced cap is
+	// lowered too, which pauses downloads.
+	LimitBandwidth bool `json:"limitBandwidth"`
+
+	// AlertPeriod is the start of the month AlertedThreshold applies to.
+	AlertPeriod time.Time `json:"-"`
+	// AlertedThreshold is the highest threshold the members of the project
+	// were alerted about in AlertPeriod.
+	AlertedThreshold int `json:"alertedThreshold"`
+	// Capped is whether the limits of the project are lowered because the cap
+	// was reached.
+	Capped bool `json:"capped"`
+
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// ProjectSpendingCapInfo holds data needed to set the spending cap of a project.
+type ProjectSpendingCapInfo struct {
+	Cap             int64 `json:"cap"`
+	AlertThresholds []int `json:"alertThresholds"`
+	Enforce         bool  `json:"enforce"`
+	LimitBandwidth  bool  `json:"limitBandwidth"`
+}
+
+// ProjectSpending holds the spending cap and the charges of a project in the
+// current month. All amounts are in cents.
+type ProjectSpending struct {
+	SpendingCap      *ProjectSpendingCap `json:"spendingCap"`
+	Charges          int64               `json:"charges"`
+	ProjectedCharges int64               `json:"projectedCharges"`
+}
+
+// BillingPeriod returns the start and the end of the month of now.
+func BillingPeriod(now time.Time) (start, end time.Time) {
+	year, month, _ := now.UTC().Date()
+	start = time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 1, 0)
+}
+
+// ProjectedCharges extrapolates the charges of the month of now so far to the
+// whole month.
+func ProjectedCharges(charges int64, now time.Time) int64 {
+	start, end := BillingPeriod(now)
+
+	elapsed := now.Sub(start)
+	if elapsed <= 0 {
+		return charges
+	}
+
+	return int64(float64(charges) * float64(end.Sub(start)) / float64(elapsed))
+}
+
+// validateProjectSpendingCap validates the spending cap and returns its alert
+// thresholds sorted and without duplicates.
+func validateProjectSpendingCap(info ProjectSpendingCapInfo) ([]int, error) {
+	if info.Cap <= 0 {
+		return nil, errors.New("spending cap must be positive")
+	}
+
+	if len(info.AlertThresholds) > maxSpendingAlertThresholds {
+		return nil, errors.New("spending cap can't have more than 10 alert thresholds")
+	}
+
+	thresholds := make([]int, 0, len(info.AlertThresholds))
+	seen := make(map[int]bool, len(info.AlertThresholds))
+	for _, threshold := range info.AlertThresholds {
+		if threshold < 1 || threshold > 100 {
+			return nil, errors.New("alert thresholds must be percentages between 1 and 100")
+		}
+		if seen[threshold] {
+			continue
+		}
+		seen[threshold] = true
+		thresholds = append(thresholds, threshold)
+	}
+	sort.Ints(thresholds)
+
+	return thresholds, nil
+}
+
+// GetProjectSpending returns the spending cap and the charges of the project
+// in the current month.
+func (s *Service) GetProjectSpending(ctx context.Context, projectID uuid.UUID) (_ *ProjectSpending, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getScopedUserAndAuditLog(ctx, []RESTKeyPermission{RESTKeyPermissionReadBilling}, "get project spending", zap.String("projectID", projectID.String()))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	if _, err = s.isProjectMember(ctx, user.ID, projectID); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	spendingCap, err := s.store.ProjectSpendingCaps().Get(ctx, projectID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, Error.Wrap(err)
+	}
+
+	now := time.Now()
+	start, _ := BillingPeriod(now)
+
+	charge, err := s.accounts.ProjectCharge(ctx, projectID, start, now)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return &ProjectSpending{
+		SpendingCap:      spendingCap,
+		Charges:          charge.Total(),
+		ProjectedCharges: ProjectedCharges(charge.Total(), now),
+	}, nil
+}
+
+// SetProjectSpendingCap sets the monthly spending cap of the project. Changing
+// the cap lifts the lowered limits of a capped project until the cap is
+// checked again, while changing only the other settings keeps the alert state.
+func (s *Service) SetProjectSpendingCap(ctx context.Context, projectID uuid.UUID, info ProjectSpendingCapInfo) (_ *ProjectSpendingCap, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "set project spending cap", zap.String("projectID", projectID.String()), zap.Int64("cap", info.Cap))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	if _, err = s.isProjectOwner(ctx, user.ID, projectID); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	thresholds, err := validateProjectSpendingCap(info)
+	if err != nil {
+		return nil, ErrValidation.Wrap(err)
+	}
+
+	start, _ := BillingPeriod(time.Now())
+	spendingCap := ProjectSpendingCap{
+		ProjectID:       projectID,
+		Cap:             info.Cap,
+		AlertThresholds: thresholds,
+		Enforce:         info.Enforce,
+		LimitBandwidth:  info.LimitBandwidth,
+		AlertPeriod:     start,
+	}
+
+	err = s.store.WithTx(ctx, func(ctx context.Context, tx DBTx) error {
+		current, err := tx.ProjectSpendingCaps().Get(ctx, projectID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return tx.ProjectSpendingCaps().Insert(ctx, spendingCap)
+		}
+		if err != nil {
+			return err
+		}
+
+		// the members are alerted again about the thresholds of a new cap only.
+		if current.Cap == spendingCap.Cap {
+			spendingCap.AlertPeriod = current.AlertPeriod
+			spendingCap.AlertedThreshold = current.AlertedThreshold
+			spendingCap.Capped = current.Capped && spendingCap.Enforce
+		}
+
+		return tx.ProjectSpendingCaps().Update(ctx, spendingCap)
+	})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	inserted, err := s.store.ProjectSpendingCaps().Get(ctx, projectID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return inserted, nil
+}
+
+// RemoveProjectSpendingCap removes the monthly spending cap of the project.
+func (s *Service) RemoveProjectSpendingCap(ctx context.Context, projectID uuid.UUID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "remove project spending cap", zap.String("projectID", projectID.String()))
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	if _, err = s.isProjectOwner(ctx, user.ID, projectID); err != nil {
+		return Error.Wrap(err)
+	}
+
+	return Error.Wrap(s.store.ProjectSpendingCaps().Delete(ctx, projectID))
+}
@@ -0,0 +1,226 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package spendingcaps
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/sync2"
+	"storj.io/common/uuid"
+	"storj.io/storj/private/post"
+	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/console/consoleweb/consoleql"
+	"storj.io/storj/satellite/mailservice"
+	"storj.io/storj/satellite/payments"
+)
+
+var (
+	// Error is the error class of project spending caps chore.
+	Error = errs.Class("spending caps")
+
+	mon = monkit.Package()
+)
+
+// Config contains configurations for checking the spending caps of projects.
+type Config struct {
+	Enabled  bool          `help:"whether to check the spending caps of projects" default:"true"`
+	Interval time.Duration `help:"how often to check the spending caps of projects" default:"1h"`
+}
+
+// Chore checks the charges of projects with a spending cap, alerts the
+// members of the projects about reached alert thresholds and lowers the limits
+// of projects which reached their cap.
+//
+// architecture: Chore
+type Chore struct {
+	log  *zap.Logger
+	Loop *sync2.Cycle
+
+	db          console.DB
+	accounts    payments.Accounts
+	mailService *mailservice.Service
+	address     string
+	nowFn       func() time.Time
+}
+
+// NewChore instantiates Chore.
+func NewChore(log *zap.Logger, db console.DB, accounts payments.Accounts, mailService *mailservice.Service, config Config, address string) *Chore {
+	if !strings.HasSuffix(address, "/") {
+		address += "/"
+	}
+	return &Chore{
+		log:         log,
+		Loop:        sync2.NewCycle(config.Interval),
+		db:          db,
+		accounts:    accounts,
+		mailService: mailService,
+		address:     address,
+		nowFn:       time.Now,
+	}
+}
+
+// Run starts the chore.
+func (chore *Chore) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return chore.Loop.Run(ctx, func(ctx context.Context) (err error) {
+		defer mon.Task()(&ctx)(&err)
+
+		spendingCaps, err := chore.db.ProjectSpendingCaps().GetAll(ctx)
+		if err != nil {
+			chore.log.Error("error getting project spending caps", zap.Error(err))
+			return nil
+		}
+
+		now := chore.nowFn()
+		for _, spendingCap := range spendingCaps {
+			if err := chore.checkSpendingCap(ctx, spendingCap, now); err != nil {
+				chore.log.Error("error checking project spending cap", zap.Stringer("Project ID", spendingCap.ProjectID), zap.Error(err))
+			}
+		}
+
+		return nil
+	})
+}
+
+// checkSpendingCap compares the charges of the project in the month of now
+// with its spending cap.
+func (chore *Chore) checkSpendingCap(ctx context.Context, spendingCap console.ProjectSpendingCap, now time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	start, _ := console.BillingPeriod(now)
+
+	charge, err := chore.accounts.ProjectCharge(ctx, spendingCap.ProjectID, start, now)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	charges := charge.Total()
+	projected := console.ProjectedCharges(charges, now)
+
+	updated := spendingCap
+	if !updated.AlertPeriod.Equal(start) {
+		updated.AlertPeriod = start
+		updated.AlertedThreshold = 0
+	}
+	updated.Capped = spendingCap.Enforce && charges >= spendingCap.Cap
+
+	// alert about the highest threshold the projected charges reach.
+	threshold := 0
+	for _, t := range spendingCap.AlertThresholds {
+		if projected*100 >= spendingCap.Cap*int64(t) {
+			threshold = t
+		}
+	}
+
+	if threshold > updated.AlertedThreshold || (updated.Capped && !spendingCap.Capped) {
+		err = chore.sendAlert(ctx, updated, threshold, charges, projected)
+		if err != nil {
+			// the members are alerted again in the next run.
+			chore.log.Error("error sending project spending alert", zap.Stringer("Project ID", spendingCap.ProjectID), zap.Error(err))
+		} else if threshold > updated.AlertedThreshold {
+			updated.AlertedThreshold = threshold
+		}
+	}
+
+	if updated.AlertPeriod.Equal(spendingCap.AlertPeriod) &&
+		updated.AlertedThreshold == spendingCap.AlertedThreshold &&
+		updated.Capped == spendingCap.Capped {
+		return nil
+	}
+
+	return Error.Wrap(chore.db.ProjectSpendingCaps().Update(ctx, updated))
+}
+
+// sendAlert emails the members of the project about its spending.
+func (chore *Chore) sendAlert(ctx context.Context, spendingCap console.ProjectSpendingCap, threshold int, charges, projected int64) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	project, err := chore.db.Projects().Get(ctx, spendingCap.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	recipients, err := chore.projectMembers(ctx, spendingCap.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	var group errs.Group
+	for _, recipient := range recipients {
+		group.Add(chore.mailService.SendRendered(
+			ctx,
+			[]post.Address{recipient},
+			&consoleql.ProjectSpendingAlertEmail{
+				Origin:           chore.address,
+				UserName:         recipient.Name,
+				ProjectName:      project.Name,
+				Threshold:        threshold,
+				SpendingCap:      formatCents(spendingCap.Cap),
+				Charges:          formatCents(charges),
+				ProjectedCharges: formatCents(projected),
+				Capped:           spendingCap.Capped,
+				LimitBandwidth:   spendingCap.LimitBandwidth,
+				SignInLink:       chore.address + "login",
+			},
+		))
+	}
+
+	return group.Err()
+}
+
+// projectMembers returns the email addresses of the members of the project.
+func (chore *Chore) projectMembers(ctx context.Context, projectID uuid.UUID) (_ []post.Address, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var recipients []post.Address
+
+	cursor := console.ProjectMembersCursor{Limit: 50, Page: 1}
+	for {
+		page, err := chore.db.ProjectMembers().GetPagedByProjectID(ctx, projectID, cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, member := range page.ProjectMembers {
+			user, err := chore.db.Users().Get(ctx, member.MemberID)
+			if err != nil {
+				return nil, err
+			}
+
+			name := user.ShortName
+			if name == "" {
+				name = user.FullName
+			}
+			recipients = append(recipients, post.Address{Address: user.Email, Name: name})
+		}
+
+		if cursor.Page >= page.PageCount {
+			return recipients, nil
+		}
+		cursor.Page++
+	}
+}
+
+// formatCents formats an amount of cents as dollars.
+func formatCents(cents int64) string {
+	return fmt.Sprintf("$%.2f", float64(cents)/100)
+}
+
+// Close closes chore.
+func (chore *Chore) Close() error {
+	chore.Loop.Close()
+	return nil
+}
+
+// TestSetNow allows tests to have the Chore act as if the current time is
+// whatever they want.
+func (chore *Chore) TestSetNow(now func() time.Time) {
+	chore.nowFn = now
+}
@@ -0,0 +1,102 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package sso
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/zeebo/errs"
+)
+
+// Config contains configuration for signing in to the satellite console
+// with external identity providers.
+type Config struct {
+	Enabled         bool          `help:"whether users can sign in to the console with external identity providers" default:"false"`
+	Providers       Providers     `help:"identity providers of email domains in JSON list format: [{\"domain\":\"example.com\",\"issuer\":\"https://idp.example.com\",\"clientID\":\"id\",\"clientSecret\":\"secret\",\"provision\":true,\"linkExisting\":true}]" default:"[]"`
+	StateExpiration time.Duration `help:"how long a sign in with an identity provider may take" default:"10m"`
+}
+
+// Provider is an OpenID Connect identity provider which authenticates the
+// users of an email domain.
+type Provider struct {
+	// Domain is the email domain whose users must sign in with the provider.
+	Domain string `json:"domain"`
+	// Issuer is the issuer identifier of the provider, which is used to
+	// discover its configuration.
+	Issuer       string `json:"issuer"`
+	ClientID     string `json:"clientID"`
+	ClientSecret string `json:"clientSecret"`
+	// Provision is whether users unknown to the satellite are created when
+	// they sign in for the first time.
+	Provision bool `json:"provision"`
+	// LinkExisting is whether existing accounts are linked to the identity
+	// of the user at the provider when they sign in for the first time.
+	LinkExisting bool `json:"linkExisting"`
+}
+
+// Providers is a configuration value that contains a list of identity providers.
+//
+// Can be used as a flag.
+type Providers []Provider
+
+// Type implements pflag.Value.
+func (Providers) Type() string { return "sso.Providers" }
+
+// String is required for pflag.Value.
+func (providers *Providers) String() string {
+	if len(*providers) == 0 {
+		return "[]"
+	}
+	data, err := json.Marshal(*providers)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// Set parses and validates the configured JSON list of identity providers.
+func (providers *Providers) Set(s string) error {
+	var parsed Providers
+	if err := json.Unmarshal([]byte(s), &parsed); err != nil {
+		return errs.New("Could not parse identity providers config: %v", err)
+	}
+
+	domains := make(map[string]bool, len(parsed))
+	for i, provider := range parsed {
+		provider.Domain = strings.ToLower(strings.TrimSpace(provider.Domain))
+		if provider.Domain == "" || provider.Issuer == "" || provider.ClientID == "" {
+			return errs.New("Could not parse identity providers config. Each provider must have a domain, an issuer and a client ID")
+		}
+		if domains[provider.Domain] {
+			return errs.New("Could not parse identity providers config. Domain %q has more than one provider", provider.Domain)
+		}
+		domains[provider.Domain] = true
+		parsed[i] = provider
+	}
+
+	*providers = parsed
+	return nil
+}
+
+// ForDomain returns the identity provider of the email domain.
+func (providers Providers) ForDomain(domain string) (Provider, bool) {
+	domain = strings.ToLower(domain)
+	for _, provider := range providers {
+		if provider.Domain == domain {
+			return provider, true
+		}
+	}
+	return Provider{}, false
+}
+
+// ForEmail returns the identity provider of the domain of the email address.
+func (providers Providers) ForEmail(email string) (Provider, bool) {
+	at := strings.LastIndexByte(email, '@')
+	if at < 0 {
+		return Provider{}, false
+	}
+	return providers.ForDomain(strings.TrimSpace(email[at+1:]))
+}
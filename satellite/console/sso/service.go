@@ -0,0 +1,385 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package sso
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+	"golang.org/x/oauth2"
+
+	"storj.io/storj/satellite/console/consoleauth"
+)
+
+var mon = monkit.Package()
+
+var (
+	// Error describes internal single sign-on error.
+	Error = errs.Class("sso")
+
+	// ErrNoProvider is returned when the domain of an email address has no
+	// identity provider.
+	ErrNoProvider = errs.Class("no identity provider")
+
+	// ErrState is returned when the state of a sign in is invalid or expired.
+	ErrState = errs.Class("invalid sso state")
+
+	// ErrIdentity is returned when the identity provider does not assert
+	// a valid identity.
+	ErrIdentity = errs.Class("invalid identity")
+)
+
+// Identity is the identity of a user asserted by an identity provider.
+type Identity struct {
+	Issuer  string
+	Subject string
+	Email   string
+	Name    string
+}
+
+// state is the signed state of a sign in which is round-tripped through
+// the identity provider.
+type state struct {
+	Domain    string    `json:"domain"`
+	Nonce     string    `json:"nonce"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// discovery is the part of the OpenID Provider metadata used by the service.
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Service signs users in with OpenID Connect identity providers.
+//
+// architecture: Service
+type Service struct {
+	log    *zap.Logger
+	config Config
+	signer consoleauth.Signer
+	client *http.Client
+
+	mu         sync.Mutex
+	discovered map[string]discovery
+}
+
+// NewService returns a new single sign-on service. The signer is used to
+// protect the state of sign ins.
+func NewService(log *zap.Logger, config Config, signer consoleauth.Signer) *Service {
+	return &Service{
+		log:        log,
+		config:     config,
+		signer:     signer,
+		client:     &http.Client{Timeout: 30 * time.Second},
+		discovered: make(map[string]discovery),
+	}
+}
+
+// AuthURL returns the URL of the identity provider of the domain of the email
+// address which the user must visit to sign in, and the nonce which must be
+// kept by the user agent until the sign in completes.
+func (s *Service) AuthURL(ctx context.Context, email, redirectURL string) (authURL, nonce string, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	provider, ok := s.config.Providers.ForEmail(email)
+	if !ok {
+		return "", "", ErrNoProvider.New("%s", email)
+	}
+
+	metadata, err := s.discover(ctx, provider)
+	if err != nil {
+		return "", "", err
+	}
+
+	var nonceBytes [16]byte
+	if _, err := rand.Read(nonceBytes[:]); err != nil {
+		return "", "", Error.Wrap(err)
+	}
+	nonce = hex.EncodeToString(nonceBytes[:])
+
+	signedState, err := s.signState(state{
+		Domain:    provider.Domain,
+		Nonce:     nonce,
+		ExpiresAt: time.Now().Add(s.config.StateExpiration),
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	authURL = oauthConfig(provider, metadata, redirectURL).AuthCodeURL(signedState,
+		oauth2.SetAuthURLParam("nonce", nonce),
+		oauth2.SetAuthURLParam("login_hint", email),
+	)
+
+	return authURL, nonce, nil
+}
+
+// Authenticate completes a sign in by exchanging the authorization code with
+// the identity provider and returns the identity of the user.
+func (s *Service) Authenticate(ctx context.Context, signedState, nonce, code, redirectURL string) (_ Identity, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	st, err := s.verifyState(signedState)
+	if err != nil {
+		return Identity{}, err
+	}
+	if nonce == "" || subtle.ConstantTimeCompare([]byte(st.Nonce), []byte(nonce)) != 1 {
+		return Identity{}, ErrState.New("nonce mismatch")
+	}
+
+	provider, ok := s.config.Providers.ForDomain(st.Domain)
+	if !ok {
+		return Identity{}, ErrNoProvider.New("%s", st.Domain)
+	}
+
+	metadata, err := s.discover(ctx, provider)
+	if err != nil {
+		return Identity{}, err
+	}
+
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, s.client)
+	token, err := oauthConfig(provider, metadata, redirectURL).Exchange(ctx, code)
+	if err != nil {
+		return Identity{}, Error.Wrap(err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return Identity{}, ErrIdentity.New("token response has no id token")
+	}
+
+	keys, err := s.fetchKeys(ctx, metadata.JWKSURI)
+	if err != nil {
+		return Identity{}, err
+	}
+
+	return verifyIDToken(provider, keys, rawIDToken, nonce)
+}
+
+// oauthConfig returns the OAuth 2.0 configuration of the provider.
+func oauthConfig(provider Provider, metadata discovery, redirectURL string) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     provider.ClientID,
+		ClientSecret: provider.ClientSecret,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  metadata.AuthorizationEndpoint,
+			TokenURL: metadata.TokenEndpoint,
+		},
+		RedirectURL: redirectURL,
+		Scopes:      []string{"openid", "email", "profile"},
+	}
+}
+
+// verifyIDToken verifies the signature and the claims of the ID token and
+// returns the identity it asserts.
+func verifyIDToken(provider Provider, keys map[string]*rsa.PublicKey, rawIDToken, nonce string) (Identity, error) {
+	claims := jwt.MapClaims{}
+	_, err := new(jwt.Parser).ParseWithClaims(rawIDToken, claims, func(token *jwt.Token) (interface{}, error) {
+		if token.Method != jwt.SigningMethodRS256 {
+			return nil, errs.New("unexpected signing method %v", token.Header["alg"])
+		}
+		kid, _ := token.Header["kid"].(string)
+		if key, ok := keys[kid]; ok {
+			return key, nil
+		}
+		if kid == "" && len(keys) == 1 {
+			for _, key := range keys {
+				return key, nil
+			}
+		}
+		return nil, errs.New("unknown signing key %q", kid)
+	})
+	if err != nil {
+		return Identity{}, ErrIdentity.Wrap(err)
+	}
+
+	switch {
+	case !claims.VerifyIssuer(provider.Issuer, true):
+		return Identity{}, ErrIdentity.New("unexpected issuer %v", claims["iss"])
+	case !claims.VerifyAudience(provider.ClientID, true):
+		return Identity{}, ErrIdentity.New("unexpected audience %v", claims["aud"])
+	case !claims.VerifyExpiresAt(time.Now().Unix(), true):
+		return Identity{}, ErrIdentity.New("id token has expired")
+	}
+
+	tokenNonce, _ := claims["nonce"].(string)
+	if subtle.ConstantTimeCompare([]byte(tokenNonce), []byte(nonce)) != 1 {
+		return Identity{}, ErrIdentity.New("nonce mismatch")
+	}
+
+	identity := Identity{Issuer: provider.Issuer}
+	identity.Subject, _ = claims["sub"].(string)
+	identity.Email, _ = claims["email"].(string)
+	identity.Name, _ = claims["name"].(string)
+	if identity.Subject == "" {
+		return Identity{}, ErrIdentity.New("id token has no subject")
+	}
+
+	// an identity provider is only trusted to authenticate the users of its
+	// own domain, and only with email addresses it has verified.
+	if at := strings.LastIndexByte(identity.Email, '@'); at < 0 || !strings.EqualFold(identity.Email[at+1:], provider.Domain) {
+		return Identity{}, ErrIdentity.New("email %q is not in domain %q", identity.Email, provider.Domain)
+	}
+	if verified, ok := claims["email_verified"].(bool); ok && !verified {
+		return Identity{}, ErrIdentity.New("email %q is not verified", identity.Email)
+	}
+
+	return identity, nil
+}
+
+// discover fetches the metadata of the identity provider, caching it for
+// subsequent sign ins.
+func (s *Service) discover(ctx context.Context, provider Provider) (_ discovery, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	s.mu.Lock()
+	metadata, ok := s.discovered[provider.Issuer]
+	s.mu.Unlock()
+	if ok {
+		return metadata, nil
+	}
+
+	err = s.getJSON(ctx, strings.TrimSuffix(provider.Issuer, "/")+"/.well-known/openid-configuration", &metadata)
+	if err != nil {
+		return discovery{}, err
+	}
+	if metadata.Issuer != provider.Issuer {
+		return discovery{}, Error.New("issuer %q of provider metadata does not match %q", metadata.Issuer, provider.Issuer)
+	}
+	if metadata.AuthorizationEndpoint == "" || metadata.TokenEndpoint == "" || metadata.JWKSURI == "" {
+		return discovery{}, Error.New("incomplete provider metadata of %q", provider.Issuer)
+	}
+
+	s.log.Debug("discovered identity provider", zap.String("Issuer", provider.Issuer))
+
+	s.mu.Lock()
+	s.discovered[provider.Issuer] = metadata
+	s.mu.Unlock()
+
+	return metadata, nil
+}
+
+// fetchKeys fetches the RSA signing keys of the identity provider by key ID.
+// Keys aren't cached so rotated keys are picked up immediately.
+func (s *Service) fetchKeys(ctx context.Context, jwksURI string) (_ map[string]*rsa.PublicKey, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var jwks struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Use string `json:"use"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := s.getJSON(ctx, jwksURI, &jwks); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]*rsa.PublicKey)
+	for _, key := range jwks.Keys {
+		if key.Kty != "RSA" || (key.Use != "" && key.Use != "sig") {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(key.N)
+		if err != nil {
+			return nil, Error.New("invalid modulus of key %q: %v", key.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(key.E)
+		if err != nil {
+			return nil, Error.New("invalid exponent of key %q: %v", key.Kid, err)
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if !exponent.IsInt64() || exponent.Int64() > 1<<31-1 {
+			return nil, Error.New("invalid exponent of key %q", key.Kid)
+		}
+		keys[key.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}
+	}
+	return keys, nil
+}
+
+// getJSON decodes the JSON response to a GET request of the URL into v.
+func (s *Service) getJSON(ctx context.Context, url string, v interface{}) (err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, Error.Wrap(resp.Body.Close())) }()
+
+	if resp.StatusCode != http.StatusOK {
+		return Error.New("unexpected status %d from %s", resp.StatusCode, url)
+	}
+
+	return Error.Wrap(json.NewDecoder(resp.Body).Decode(v))
+}
+
+// signState encodes and signs the state of a sign in.
+func (s *Service) signState(st state) (string, error) {
+	payload, err := json.Marshal(st)
+	if err != nil {
+		return "", Error.Wrap(err)
+	}
+	signature, err := s.signer.Sign(payload)
+	if err != nil {
+		return "", Error.Wrap(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// verifyState verifies the signature and the expiration of the state of
+// a sign in.
+func (s *Service) verifyState(signedState string) (st state, err error) {
+	parts := strings.Split(signedState, ".")
+	if len(parts) != 2 {
+		return state{}, ErrState.New("malformed state")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return state{}, ErrState.Wrap(err)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return state{}, ErrState.Wrap(err)
+	}
+
+	expected, err := s.signer.Sign(payload)
+	if err != nil {
+		return state{}, Error.Wrap(err)
+	}
+	if !hmac.Equal(expected, signature) {
+		return state{}, ErrState.New("invalid signature")
+	}
+
+	if err := json.Unmarshal(payload, &st); err != nil {
+		return state{}, ErrState.Wrap(err)
+	}
+	if time.Now().After(st.ExpiresAt) {
+		return state{}, ErrState.New("state has expired")
+	}
+	return st, nil
+}
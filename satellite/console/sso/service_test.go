@@ -0,0 +1,260 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package sso_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/testcontext"
+	"storj.io/storj/satellite/console/consoleauth"
+	"storj.io/storj/satellite/console/sso"
+)
+
+const redirectURL = "https://satellite.test/api/v0/auth/sso/callback"
+
+// fakeIdP is a minimal OpenID Connect identity provider.
+type fakeIdP struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	mu     sync.Mutex
+	claims map[string]jwt.MapClaims
+}
+
+func newFakeIdP(t *testing.T) *fakeIdP {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	idp := &fakeIdP{key: key, claims: make(map[string]jwt.MapClaims)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 idp.server.URL,
+			"authorization_endpoint": idp.server.URL + "/authorize",
+			"token_endpoint":         idp.server.URL + "/token",
+			"jwks_uri":               idp.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "key",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		idp.mu.Lock()
+		claims, ok := idp.claims[r.PostForm.Get("code")]
+		idp.mu.Unlock()
+		if !ok {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = "key"
+		idToken, err := token.SignedString(key)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+			"id_token":     idToken,
+		})
+	})
+
+	idp.server = httptest.NewServer(mux)
+	return idp
+}
+
+// authorize simulates the user signing in at the identity provider and
+// returns the authorization code.
+func (idp *fakeIdP) authorize(t *testing.T, authURL string, claims jwt.MapClaims) (code, state string) {
+	parsed, err := url.Parse(authURL)
+	require.NoError(t, err)
+	require.Equal(t, idp.server.URL+"/authorize", parsed.Scheme+"://"+parsed.Host+parsed.Path)
+
+	query := parsed.Query()
+	require.Equal(t, "client", query.Get("client_id"))
+	require.Equal(t, redirectURL, query.Get("redirect_uri"))
+
+	token := jwt.MapClaims{
+		"iss":            idp.server.URL,
+		"aud":            query.Get("client_id"),
+		"sub":            "subject",
+		"email":          "user@example.test",
+		"email_verified": true,
+		"name":           "Test User",
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"nonce":          query.Get("nonce"),
+	}
+	for name, value := range claims {
+		token[name] = value
+	}
+
+	code = query.Get("nonce")
+	idp.mu.Lock()
+	idp.claims[code] = token
+	idp.mu.Unlock()
+
+	return code, query.Get("state")
+}
+
+func TestService(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	idp := newFakeIdP(t)
+	defer idp.server.Close()
+
+	newService := func(stateExpiration time.Duration) *sso.Service {
+		return sso.NewService(zaptest.NewLogger(t), sso.Config{
+			Enabled: true,
+			Providers: sso.Providers{{
+				Domain:   "example.test",
+				Issuer:   idp.server.URL,
+				ClientID: "client",
+			}},
+			StateExpiration: stateExpiration,
+		}, &consoleauth.Hmac{Secret: []byte("secret")})
+	}
+	service := newService(time.Minute)
+
+	t.Run("no provider", func(t *testing.T) {
+		_, _, err := service.AuthURL(ctx, "user@other.test", redirectURL)
+		require.True(t, sso.ErrNoProvider.Has(err))
+	})
+
+	t.Run("success", func(t *testing.T) {
+		authURL, nonce, err := service.AuthURL(ctx, "user@EXAMPLE.test", redirectURL)
+		require.NoError(t, err)
+
+		code, state := idp.authorize(t, authURL, nil)
+
+		identity, err := service.Authenticate(ctx, state, nonce, code, redirectURL)
+		require.NoError(t, err)
+		require.Equal(t, sso.Identity{
+			Issuer:  idp.server.URL,
+			Subject: "subject",
+			Email:   "user@example.test",
+			Name:    "Test User",
+		}, identity)
+	})
+
+	for _, tt := range []struct {
+		name   string
+		claims jwt.MapClaims
+	}{
+		{name: "email of other domain", claims: jwt.MapClaims{"email": "user@other.test"}},
+		{name: "unverified email", claims: jwt.MapClaims{"email_verified": false}},
+		{name: "wrong audience", claims: jwt.MapClaims{"aud": "other"}},
+		{name: "wrong issuer", claims: jwt.MapClaims{"iss": "https://other.test"}},
+		{name: "expired", claims: jwt.MapClaims{"exp": time.Now().Add(-time.Minute).Unix()}},
+		{name: "wrong nonce", claims: jwt.MapClaims{"nonce": "other"}},
+		{name: "no subject", claims: jwt.MapClaims{"sub": ""}},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			authURL, nonce, err := service.AuthURL(ctx, "user@example.test", redirectURL)
+			require.NoError(t, err)
+
+			code, state := idp.authorize(t, authURL, tt.claims)
+
+			_, err = service.Authenticate(ctx, state, nonce, code, redirectURL)
+			require.True(t, sso.ErrIdentity.Has(err), err)
+		})
+	}
+
+	t.Run("nonce of other user agent", func(t *testing.T) {
+		authURL, _, err := service.AuthURL(ctx, "user@example.test", redirectURL)
+		require.NoError(t, err)
+		_, otherNonce, err := service.AuthURL(ctx, "user@example.test", redirectURL)
+		require.NoError(t, err)
+
+		code, state := idp.authorize(t, authURL, nil)
+
+		_, err = service.Authenticate(ctx, state, otherNonce, code, redirectURL)
+		require.True(t, sso.ErrState.Has(err))
+	})
+
+	t.Run("tampered state", func(t *testing.T) {
+		authURL, nonce, err := service.AuthURL(ctx, "user@example.test", redirectURL)
+		require.NoError(t, err)
+
+		code, _ := idp.authorize(t, authURL, nil)
+
+		payload := base64.RawURLEncoding.EncodeToString([]byte(`{"domain":"example.test","nonce":"` + nonce + `","expiresAt":"2100-01-01T00:00:00Z"}`))
+		_, err = service.Authenticate(ctx, payload+".c2lnbmF0dXJl", nonce, code, redirectURL)
+		require.True(t, sso.ErrState.Has(err))
+	})
+
+	t.Run("expired state", func(t *testing.T) {
+		expiring := newService(-time.Second)
+
+		authURL, nonce, err := expiring.AuthURL(ctx, "user@example.test", redirectURL)
+		require.NoError(t, err)
+
+		code, state := idp.authorize(t, authURL, nil)
+
+		_, err = expiring.Authenticate(ctx, state, nonce, code, redirectURL)
+		require.True(t, sso.ErrState.Has(err))
+	})
+}
+
+func TestProviders(t *testing.T) {
+	var providers sso.Providers
+	require.NoError(t, providers.Set(`[]`))
+	require.Empty(t, providers)
+	require.Equal(t, "[]", providers.String())
+
+	require.NoError(t, providers.Set(`[{"domain":"Example.Test","issuer":"https://idp.test","clientID":"client","provision":true}]`))
+	require.Len(t, providers, 1)
+
+	provider, ok := providers.ForEmail("user@example.TEST")
+	require.True(t, ok)
+	require.Equal(t, sso.Provider{Domain: "example.test", Issuer: "https://idp.test", ClientID: "client", Provision: true}, provider)
+
+	_, ok = providers.ForEmail("user@other.test")
+	require.False(t, ok)
+	_, ok = providers.ForEmail("example.test")
+	require.False(t, ok)
+
+	var parsed sso.Providers
+	require.NoError(t, parsed.Set(providers.String()))
+	require.Equal(t, providers, parsed)
+
+	require.Error(t, providers.Set(`{}`))
+	require.Error(t, providers.Set(`[{"domain":"example.test","issuer":"https://idp.test"}]`))
+	require.Error(t, providers.Set(`[{"domain":"example.test","issuer":"https://idp.test","clientID":"a"},{"domain":"EXAMPLE.test","issuer":"https://idp.test","clientID":"b"}]`))
+}
@@ -0,0 +1,32 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package console
+
+import (
+	"context"
+	"time"
+
+	"storj.io/common/uuid"
+)
+
+// SSOIdentities exposes methods to manage the links between users and their
+// identities at external identity providers.
+//
+// architecture: Database
+type SSOIdentities interface {
+	// Insert links the user to the identity.
+	Insert(ctx context.Context, identity SSOIdentity) error
+	// Get returns the link of the identity with the subject at the issuer.
+	Get(ctx context.Context, issuer, subject string) (*SSOIdentity, error)
+	// DeleteByUserID deletes all links of the user.
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
+
+// SSOIdentity links a user to their identity at an external identity provider.
+type SSOIdentity struct {
+	Issuer    string
+	Subject   string
+	UserID    uuid.UUID
+	CreatedAt time.Time
+}
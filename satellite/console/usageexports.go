@@ -0,0 +1,321 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package console
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/crypto/nacl/secretbox"
+
+	"storj.io/common/grant"
+	"storj.io/common/storj"
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/orders"
+)
+
+// ProjectUsageExports exposes methods to manage the scheduled usage exports of projects.
+//
+// architecture: Database
+type ProjectUsageExports interface {
+	// Insert inserts the usage export of a project.
+	Insert(ctx context.Context, export ProjectUsageExport) error
+	// Get returns the usage export of the project.
+	Get(ctx context.Context, projectID uuid.UUID) (*ProjectUsageExport, error)
+	// GetAll returns the usage exports of all projects.
+	GetAll(ctx context.Context) ([]ProjectUsageExport, error)
+	// Update updates the usage export of a project.
+	Update(ctx context.Context, export ProjectUsageExport) error
+	// Delete removes the usage export of the project.
+	Delete(ctx context.Context, projectID uuid.UUID) error
+}
+
+// UsageExportFormat is the file format of usage exports.
+type UsageExportFormat string
+
+const (
+	// UsageExportFormatCSV exports the usage as comma-separated values.
+	UsageExportFormatCSV UsageExportFormat = "csv"
+	// UsageExportFormatParquet exports the usage as an Apache Parquet file.
+	UsageExportFormatParquet UsageExportFormat = "parquet"
+)
+
+// Valid returns whether the format is supported.
+func (format UsageExportFormat) Valid() bool {
+	return format == UsageExportFormatCSV || format == UsageExportFormatParquet
+}
+
+// UsageExportSchedule is how often the usage of a project is exported.
+type UsageExportSchedule string
+
+const (
+	// UsageExportScheduleDaily exports the usage of every day.
+	UsageExportScheduleDaily UsageExportSchedule = "daily"
+	// UsageExportScheduleWeekly exports the usage of every week starting on Monday.
+	UsageExportScheduleWeekly UsageExportSchedule = "weekly"
+	// UsageExportScheduleMonthly exports the usage of every month.
+	UsageExportScheduleMonthly UsageExportSchedule = "monthly"
+)
+
+// Valid returns whether the schedule is supported.
+func (schedule UsageExportSchedule) Valid() bool {
+	switch schedule {
+	case UsageExportScheduleDaily, UsageExportScheduleWeekly, UsageExportScheduleMonthly:
+		return true
+	default:
+		return false
+	}
+}
+
+// PeriodStart returns the start of the export period of now, which is the end
+// of the last period that can be exported.
+func (schedule UsageExportSchedule) PeriodStart(now time.Time) time.Time {
+	year, month, day := now.UTC().Date()
+	today := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+
+	switch schedule {
+	case UsageExportScheduleWeekly:
+		// time.Sunday is 0, so it's moved to the end of the week.
+		weekday := (int(today.Weekday()) + 6) % 7
+		return today.AddDate(0, 0, -weekday)
+	case UsageExportScheduleMonthly:
+		return time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return today
+	}
+}
+
+// ProjectUsageExport is the destination of the scheduled usage and cost exports of a project.
+type ProjectUsageExport struct {
+	ProjectID uuid.UUID `json:"projectId"`
+	// EncryptedAccess is the access grant used to upload the exports,
+	// restricted to uploads to the bucket and prefix of the export. It still
+	// holds the encryption key of that prefix, so it's encrypted with
+	// Config.UsageExportKeys, which the satellite holds to upload the exports.
+	EncryptedAccess []byte              `json:"-"`
+	Bucket          string              `json:"bucket"`
+	Prefix          string              `json:"prefix"`
+	Format          UsageExportFormat   `json:"format"`
+	Schedule        UsageExportSchedule `json:"schedule"`
+	// ExportedUntil is the end of the last exported period.
+	ExportedUntil time.Time `json:"exportedUntil"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// ProjectUsageExportInfo holds data needed to set the usage export of a project.
+// Access is an access grant with the permission to upload to the bucket. Only
+// a restriction of it to uploads to the bucket and prefix is stored.
+type ProjectUsageExportInfo struct {
+	Access   string              `json:"access"`
+	Bucket   string              `json:"bucket"`
+	Prefix   string              `json:"prefix"`
+	Format   UsageExportFormat   `json:"format"`
+	Schedule UsageExportSchedule `json:"schedule"`
+}
+
+// validateProjectUsageExport validates the usage export and returns its prefix
+// normalized to end with a slash.
+func validateProjectUsageExport(info ProjectUsageExportInfo) (string, error) {
+	if _, err := grant.ParseAccess(info.Access); err != nil {
+		return "", errors.New("invalid access grant")
+	}
+
+	if info.Bucket == "" {
+		return "", errors.New("bucket can't be empty")
+	}
+
+	if !info.Format.Valid() {
+		return "", errors.New("format must be csv or parquet")
+	}
+
+	if !info.Schedule.Valid() {
+		return "", errors.New("schedule must be daily, weekly or monthly")
+	}
+
+	prefix := strings.TrimPrefix(info.Prefix, "/")
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+
+	return prefix, nil
+}
+
+// restrictUsageExportAccess restricts the access grant to uploads to the
+// bucket and prefix of the export.
+func restrictUsageExportAccess(serialized, bucket, prefix string) (string, error) {
+	access, err := grant.ParseAccess(serialized)
+	if err != nil {
+		return "", err
+	}
+
+	restricted, err := access.Restrict(grant.Permission{AllowUpload: true}, grant.SharePrefix{
+		Bucket: bucket,
+		Prefix: prefix,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return restricted.Serialize()
+}
+
+// EncryptUsageExportAccess encrypts the access grant of a usage export with
+// the default key. The identifier of the key and the nonce are prepended to
+// the encrypted access grant.
+func EncryptUsageExportAccess(keys orders.EncryptionKeys, access string) ([]byte, error) {
+	if keys.Default.IsZero() {
+		return nil, errors.New("no key to encrypt usage exports is configured")
+	}
+
+	var nonce storj.SerialNumber
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return nil, err
+	}
+
+	encrypted := make([]byte, 0, len(keys.Default.ID)+len(nonce)+len(access)+secretbox.Overhead)
+	encrypted = append(encrypted, keys.Default.ID[:]...)
+	encrypted = append(encrypted, nonce[:]...)
+	return append(encrypted, keys.Default.Encrypt([]byte(access), nonce)...), nil
+}
+
+// DecryptUsageExportAccess decrypts the access grant of a usage export
+// encrypted by EncryptUsageExportAccess.
+func DecryptUsageExportAccess(keys orders.EncryptionKeys, encrypted []byte) (string, error) {
+	var key orders.EncryptionKey
+	var nonce storj.SerialNumber
+	if len(encrypted) < len(key.ID)+len(nonce)+secretbox.Overhead {
+		return "", errors.New("encrypted access grant is too short")
+	}
+
+	copy(key.ID[:], encrypted)
+	encrypted = encrypted[len(key.ID):]
+	copy(nonce[:], encrypted)
+	encrypted = encrypted[len(nonce):]
+
+	var ok bool
+	key.Key, ok = keys.KeyByID[key.ID]
+	if !ok {
+		return "", errors.New("unknown key of encrypted access grant")
+	}
+
+	access, err := key.Decrypt(encrypted, nonce)
+	if err != nil {
+		return "", err
+	}
+
+	return string(access), nil
+}
+
+// GetProjectUsageExport returns the usage export of the project, or nil if
+// the usage of the project isn't exported.
+func (s *Service) GetProjectUsageExport(ctx context.Context, projectID uuid.UUID) (_ *ProjectUsageExport, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getScopedUserAndAuditLog(ctx, []RESTKeyPermission{RESTKeyPermissionReadUsage}, "get project usage export", zap.String("projectID", projectID.String()))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	if _, err = s.isProjectMember(ctx, user.ID, projectID); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	export, err := s.store.ProjectUsageExports().Get(ctx, projectID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return export, nil
+}
+
+// SetProjectUsageExport sets the destination, format and schedule of the usage
+// exports of the project. The first export covers the first full period after
+// the export is set.
+func (s *Service) SetProjectUsageExport(ctx context.Context, projectID uuid.UUID, info ProjectUsageExportInfo) (_ *ProjectUsageExport, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "set project usage export", zap.String("projectID", projectID.String()), zap.String("bucket", info.Bucket))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	if _, err = s.isProjectOwner(ctx, user.ID, projectID); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	prefix, err := validateProjectUsageExport(info)
+	if err != nil {
+		return nil, ErrValidation.Wrap(err)
+	}
+
+	access, err := restrictUsageExportAccess(info.Access, info.Bucket, prefix)
+	if err != nil {
+		return nil, ErrValidation.Wrap(err)
+	}
+
+	encryptedAccess, err := EncryptUsageExportAccess(s.config.UsageExportKeys, access)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	export := ProjectUsageExport{
+		ProjectID:       projectID,
+		EncryptedAccess: encryptedAccess,
+		Bucket:          info.Bucket,
+		Prefix:          prefix,
+		Format:          info.Format,
+		Schedule:        info.Schedule,
+		ExportedUntil:   info.Schedule.PeriodStart(time.Now()),
+	}
+
+	err = s.store.WithTx(ctx, func(ctx context.Context, tx DBTx) error {
+		existing, err := tx.ProjectUsageExports().Get(ctx, projectID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return tx.ProjectUsageExports().Insert(ctx, export)
+		}
+		if err != nil {
+			return err
+		}
+
+		// changing the schedule mustn't export the same usage twice.
+		if existing.ExportedUntil.After(export.ExportedUntil) {
+			export.ExportedUntil = existing.ExportedUntil
+		}
+		return tx.ProjectUsageExports().Update(ctx, export)
+	})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	stored, err := s.store.ProjectUsageExports().Get(ctx, projectID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return stored, nil
+}
+
+// RemoveProjectUsageExport stops the usage exports of the project.
+func (s *Service) RemoveProjectUsageExport(ctx context.Context, projectID uuid.UUID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.getUserAndAuditLog(ctx, "remove project usage export", zap.String("projectID", projectID.String()))
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	if _, err = s.isProjectOwner(ctx, user.ID, projectID); err != nil {
+		return Error.Wrap(err)
+	}
+
+	return Error.Wrap(s.store.ProjectUsageExports().Delete(ctx, projectID))
+}
@@ -0,0 +1,176 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package usageexports
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/sync2"
+	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/orders"
+	"storj.io/storj/satellite/payments"
+	"storj.io/uplink"
+)
+
+var (
+	// Error is the error class of project usage exports chore.
+	Error = errs.Class("usage exports")
+
+	mon = monkit.Package()
+)
+
+// Config contains configurations for exporting the usage of projects.
+type Config struct {
+	Enabled  bool          `help:"whether to export the usage of projects to their buckets" default:"true"`
+	Interval time.Duration `help:"how often to check for usage exports which are due" default:"1h"`
+}
+
+// Chore exports the daily usage and charges of the buckets of projects to
+// the buckets designated by the project owners.
+//
+// architecture: Chore
+type Chore struct {
+	log  *zap.Logger
+	Loop *sync2.Cycle
+
+	db       console.DB
+	accounts payments.Accounts
+	keys     orders.EncryptionKeys
+	nowFn    func() time.Time
+}
+
+// NewChore instantiates Chore. The keys decrypt the access grants of the
+// exports.
+func NewChore(log *zap.Logger, db console.DB, accounts payments.Accounts, keys orders.EncryptionKeys, config Config) *Chore {
+	return &Chore{
+		log:      log,
+		Loop:     sync2.NewCycle(config.Interval),
+		db:       db,
+		accounts: accounts,
+		keys:     keys,
+		nowFn:    time.Now,
+	}
+}
+
+// Run starts the chore.
+func (chore *Chore) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return chore.Loop.Run(ctx, func(ctx context.Context) (err error) {
+		defer mon.Task()(&ctx)(&err)
+
+		exports, err := chore.db.ProjectUsageExports().GetAll(ctx)
+		if err != nil {
+			chore.log.Error("error getting project usage exports", zap.Error(err))
+			return nil
+		}
+
+		now := chore.nowFn()
+		for _, export := range exports {
+			if err := chore.export(ctx, export, now); err != nil {
+				// the export is retried in the next run.
+				chore.log.Error("error exporting project usage", zap.Stringer("Project ID", export.ProjectID), zap.Error(err))
+			}
+		}
+
+		return nil
+	})
+}
+
+// export uploads the usage of the project since the last export, if a new
+// period of its schedule has ended.
+func (chore *Chore) export(ctx context.Context, export console.ProjectUsageExport, now time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	until := export.Schedule.PeriodStart(now)
+	if !until.After(export.ExportedUntil) {
+		return nil
+	}
+
+	var rows []Row
+	for day := export.ExportedUntil; day.Before(until); day = day.AddDate(0, 0, 1) {
+		charges, err := chore.accounts.BucketCharges(ctx, export.ProjectID, day, day.AddDate(0, 0, 1))
+		if err != nil {
+			return Error.Wrap(err)
+		}
+
+		for _, charge := range charges {
+			rows = append(rows, rowFromCharge(day, charge))
+		}
+	}
+
+	var data bytes.Buffer
+	switch export.Format {
+	case console.UsageExportFormatParquet:
+		err = WriteParquet(&data, rows)
+	default:
+		err = WriteCSV(&data, rows)
+	}
+	if err != nil {
+		return err
+	}
+
+	key := fmt.Sprintf("%susage-%s-%s.%s",
+		export.Prefix,
+		export.ExportedUntil.Format(dateFormat),
+		until.AddDate(0, 0, -1).Format(dateFormat),
+		export.Format,
+	)
+
+	if err := chore.upload(ctx, export, key, data.Bytes()); err != nil {
+		return err
+	}
+
+	export.ExportedUntil = until
+	return Error.Wrap(chore.db.ProjectUsageExports().Update(ctx, export))
+}
+
+// upload uploads the data to the bucket of the export.
+func (chore *Chore) upload(ctx context.Context, export console.ProjectUsageExport, key string, data []byte) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	serialized, err := console.DecryptUsageExportAccess(chore.keys, export.EncryptedAccess)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	access, err := uplink.ParseAccess(serialized)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	project, err := uplink.OpenProject(ctx, access)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, Error.Wrap(project.Close())) }()
+
+	upload, err := project.UploadObject(ctx, export.Bucket, key, nil)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	if _, err = upload.Write(data); err != nil {
+		return Error.Wrap(errs.Combine(err, upload.Abort()))
+	}
+
+	return Error.Wrap(upload.Commit())
+}
+
+// Close stops the chore.
+func (chore *Chore) Close() error {
+	chore.Loop.Close()
+	return nil
+}
+
+// TestSetNow sets nowFn on chore for testing.
+func (chore *Chore) TestSetNow(f func() time.Time) {
+	chore.nowFn = f
+}
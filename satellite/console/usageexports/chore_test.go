@@ -0,0 +1,83 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package usageexports_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite/console"
+)
+
+func TestChore(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 4, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		upl := planet.Uplinks[0]
+		chore := sat.Core.UsageExports.Chore
+		chore.Loop.Pause()
+
+		project := upl.Projects[0]
+		require.NoError(t, upl.CreateBucket(ctx, sat, "exports"))
+
+		ownerCtx, err := sat.UserContext(ctx, project.Owner.ID)
+		require.NoError(t, err)
+
+		export, err := sat.API.Console.Service.SetProjectUsageExport(ownerCtx, project.ID, console.ProjectUsageExportInfo{
+			Access:   mustSerialize(t, upl, sat),
+			Bucket:   "exports",
+			Prefix:   "usage",
+			Format:   console.UsageExportFormatCSV,
+			Schedule: console.UsageExportScheduleDaily,
+		})
+		require.NoError(t, err)
+		require.Equal(t, "usage/", export.Prefix)
+
+		// nothing is due until the day is over.
+		chore.TestSetNow(func() time.Time { return export.ExportedUntil.Add(time.Hour) })
+		chore.Loop.TriggerWait()
+
+		objects, err := upl.ListObjects(ctx, sat, "exports")
+		require.NoError(t, err)
+		require.Empty(t, objects)
+
+		chore.TestSetNow(func() time.Time { return export.ExportedUntil.AddDate(0, 0, 2).Add(time.Hour) })
+		chore.Loop.TriggerWait()
+
+		objects, err = upl.ListObjects(ctx, sat, "exports")
+		require.NoError(t, err)
+		require.Len(t, objects, 1)
+
+		from := export.ExportedUntil.Format("2006-01-02")
+		to := export.ExportedUntil.AddDate(0, 0, 1).Format("2006-01-02")
+		require.Equal(t, "usage/usage-"+from+"-"+to+".csv", objects[0].Key)
+
+		data, err := upl.Download(ctx, sat, "exports", objects[0].Key)
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(string(data), "date,project_id,bucket_name,"))
+
+		updated, err := sat.DB.Console().ProjectUsageExports().Get(ctx, project.ID)
+		require.NoError(t, err)
+		require.Equal(t, export.ExportedUntil.AddDate(0, 0, 2), updated.ExportedUntil)
+
+		// the exported period isn't exported again.
+		chore.Loop.TriggerWait()
+
+		objects, err = upl.ListObjects(ctx, sat, "exports")
+		require.NoError(t, err)
+		require.Len(t, objects, 1)
+	})
+}
+
+func mustSerialize(t *testing.T, upl *testplanet.Uplink, sat *testplanet.Satellite) string {
+	serialized, err := upl.Access[sat.ID()].Serialize()
+	require.NoError(t, err)
+	return serialized
+}
@@ -0,0 +1,303 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package usageexports
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"time"
+)
+
+// The exports are small, so they are written as Parquet files with a single
+// row group of required columns, each stored in a single uncompressed page
+// with plain encoding. This needs only the parts of the format described in
+// https://github.com/apache/parquet-format.
+
+// parquetMagic starts and ends every Parquet file.
+const parquetMagic = "PAR1"
+
+// Parquet physical types.
+const (
+	parquetInt32     = 1
+	parquetDouble    = 5
+	parquetByteArray = 6
+)
+
+// Parquet converted types.
+const (
+	parquetNoConvertedType = -1
+	parquetUTF8            = 0
+	parquetDate            = 6
+)
+
+// Parquet enum values of the single choices used for every column.
+const (
+	parquetRequired      = 0
+	parquetPlain         = 0
+	parquetRLE           = 3
+	parquetUncompressed  = 0
+	parquetDataPage      = 0
+	parquetFormatVersion = 1
+)
+
+// parquetColumn describes how a column is stored.
+type parquetColumn struct {
+	name          string
+	physicalType  int32
+	convertedType int32
+	encode        func(buf *bytes.Buffer, row Row)
+}
+
+// parquetColumns are the exported columns in the order of columns.
+var parquetColumns = []parquetColumn{
+	{"date", parquetInt32, parquetDate, func(buf *bytes.Buffer, row Row) {
+		// dates are stored as days since the unix epoch.
+		writeInt32LE(buf, int32(row.Date.Unix()/int64(24*time.Hour/time.Second)))
+	}},
+	{"project_id", parquetByteArray, parquetUTF8, func(buf *bytes.Buffer, row Row) {
+		writeByteArray(buf, row.ProjectID.String())
+	}},
+	{"bucket_name", parquetByteArray, parquetUTF8, func(buf *bytes.Buffer, row Row) {
+		writeByteArray(buf, row.BucketName)
+	}},
+	{"storage_gb_hours", parquetDouble, parquetNoConvertedType, func(buf *bytes.Buffer, row Row) {
+		writeDouble(buf, row.StorageGBHours)
+	}},
+	{"egress_gb", parquetDouble, parquetNoConvertedType, func(buf *bytes.Buffer, row Row) {
+		writeDouble(buf, row.EgressGB)
+	}},
+	{"segment_hours", parquetDouble, parquetNoConvertedType, func(buf *bytes.Buffer, row Row) {
+		writeDouble(buf, row.SegmentHours)
+	}},
+	{"storage_cents", parquetDouble, parquetNoConvertedType, func(buf *bytes.Buffer, row Row) {
+		writeDouble(buf, row.StorageCents)
+	}},
+	{"egress_cents", parquetDouble, parquetNoConvertedType, func(buf *bytes.Buffer, row Row) {
+		writeDouble(buf, row.EgressCents)
+	}},
+	{"segment_cents", parquetDouble, parquetNoConvertedType, func(buf *bytes.Buffer, row Row) {
+		writeDouble(buf, row.SegmentCents)
+	}},
+	{"total_cents", parquetDouble, parquetNoConvertedType, func(buf *bytes.Buffer, row Row) {
+		writeDouble(buf, row.TotalCents())
+	}},
+}
+
+// parquetChunk is the location of a column chunk in the file.
+type parquetChunk struct {
+	offset int64
+	size   int64
+}
+
+// WriteParquet writes the rows as an Apache Parquet file.
+func WriteParquet(w io.Writer, rows []Row) error {
+	var file bytes.Buffer
+	file.WriteString(parquetMagic)
+
+	chunks := make([]parquetChunk, 0, len(parquetColumns))
+	for _, column := range parquetColumns {
+		var data bytes.Buffer
+		for _, row := range rows {
+			column.encode(&data, row)
+		}
+
+		// required columns have no repetition and definition levels, so the
+		// page contains only the values.
+		var header thriftWriter
+		header.i32Field(1, parquetDataPage)
+		header.i32Field(2, int32(data.Len()))
+		header.i32Field(3, int32(data.Len()))
+		header.structField(5)
+		header.i32Field(1, int32(len(rows)))
+		header.i32Field(2, parquetPlain)
+		header.i32Field(3, parquetRLE)
+		header.i32Field(4, parquetRLE)
+		header.structEnd()
+		header.structEnd()
+
+		offset := int64(file.Len())
+		file.Write(header.buf.Bytes())
+		file.Write(data.Bytes())
+		chunks = append(chunks, parquetChunk{offset: offset, size: int64(file.Len()) - offset})
+	}
+
+	footer := parquetFooter(chunks, int64(len(rows)))
+	file.Write(footer)
+	writeInt32LE(&file, int32(len(footer)))
+	file.WriteString(parquetMagic)
+
+	_, err := w.Write(file.Bytes())
+	return Error.Wrap(err)
+}
+
+// parquetFooter encodes the file metadata.
+func parquetFooter(chunks []parquetChunk, numRows int64) []byte {
+	var meta thriftWriter
+	meta.i32Field(1, parquetFormatVersion)
+
+	meta.listField(2, thriftStruct, len(parquetColumns)+1)
+	meta.structBegin()
+	meta.binaryField(4, "schema")
+	meta.i32Field(5, int32(len(parquetColumns)))
+	meta.structEnd()
+	for _, column := range parquetColumns {
+		meta.structBegin()
+		meta.i32Field(1, column.physicalType)
+		meta.i32Field(3, parquetRequired)
+		meta.binaryField(4, column.name)
+		if column.convertedType != parquetNoConvertedType {
+			meta.i32Field(6, column.convertedType)
+		}
+		meta.structEnd()
+	}
+
+	meta.i64Field(3, numRows)
+
+	var totalSize int64
+	for _, chunk := range chunks {
+		totalSize += chunk.size
+	}
+
+	meta.listField(4, thriftStruct, 1)
+	meta.structBegin()
+	meta.listField(1, thriftStruct, len(chunks))
+	for i, chunk := range chunks {
+		column := parquetColumns[i]
+
+		meta.structBegin()
+		meta.i64Field(2, chunk.offset)
+		meta.structField(3)
+		meta.i32Field(1, column.physicalType)
+		meta.listField(2, thriftI32, 1)
+		meta.i32(parquetPlain)
+		meta.listField(3, thriftBinary, 1)
+		meta.binary(column.name)
+		meta.i32Field(4, parquetUncompressed)
+		meta.i64Field(5, numRows)
+		meta.i64Field(6, chunk.size)
+		meta.i64Field(7, chunk.size)
+		meta.i64Field(9, chunk.offset)
+		meta.structEnd()
+		meta.structEnd()
+	}
+	meta.i64Field(2, totalSize)
+	meta.i64Field(3, numRows)
+	meta.structEnd()
+
+	meta.binaryField(6, "storj satellite usage export")
+	meta.structEnd()
+
+	return meta.buf.Bytes()
+}
+
+func writeInt32LE(buf *bytes.Buffer, value int32) {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], uint32(value))
+	buf.Write(b[:])
+}
+
+func writeDouble(buf *bytes.Buffer, value float64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], math.Float64bits(value))
+	buf.Write(b[:])
+}
+
+func writeByteArray(buf *bytes.Buffer, value string) {
+	writeInt32LE(buf, int32(len(value)))
+	buf.WriteString(value)
+}
+
+// Thrift compact protocol types.
+const (
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+// thriftWriter encodes Thrift structs with the compact protocol, which is
+// used for the metadata of Parquet files. The outermost struct is implicitly
+// begun and has to be ended with structEnd.
+type thriftWriter struct {
+	buf       bytes.Buffer
+	lastField int16
+	stack     []int16
+}
+
+func (w *thriftWriter) fieldHeader(id int16, typ byte) {
+	if delta := id - w.lastField; delta > 0 && delta <= 15 {
+		w.buf.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		w.buf.WriteByte(typ)
+		w.varint(int64(id))
+	}
+	w.lastField = id
+}
+
+func (w *thriftWriter) uvarint(value uint64) {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(b[:], value)
+	w.buf.Write(b[:n])
+}
+
+// varint writes a zigzag encoded integer.
+func (w *thriftWriter) varint(value int64) {
+	w.uvarint(uint64((value << 1) ^ (value >> 63)))
+}
+
+func (w *thriftWriter) i32(value int32) { w.varint(int64(value)) }
+
+func (w *thriftWriter) binary(value string) {
+	w.uvarint(uint64(len(value)))
+	w.buf.WriteString(value)
+}
+
+func (w *thriftWriter) i32Field(id int16, value int32) {
+	w.fieldHeader(id, thriftI32)
+	w.i32(value)
+}
+
+func (w *thriftWriter) i64Field(id int16, value int64) {
+	w.fieldHeader(id, thriftI64)
+	w.varint(value)
+}
+
+func (w *thriftWriter) binaryField(id int16, value string) {
+	w.fieldHeader(id, thriftBinary)
+	w.binary(value)
+}
+
+// listField writes the header of a list, which has to be followed by its elements.
+func (w *thriftWriter) listField(id int16, elemType byte, size int) {
+	w.fieldHeader(id, thriftList)
+	if size < 15 {
+		w.buf.WriteByte(byte(size)<<4 | elemType)
+	} else {
+		w.buf.WriteByte(0xF0 | elemType)
+		w.uvarint(uint64(size))
+	}
+}
+
+// structField begins a struct field, which has to be ended with structEnd.
+func (w *thriftWriter) structField(id int16) {
+	w.fieldHeader(id, thriftStruct)
+	w.structBegin()
+}
+
+// structBegin begins a struct, which has to be ended with structEnd.
+func (w *thriftWriter) structBegin() {
+	w.stack = append(w.stack, w.lastField)
+	w.lastField = 0
+}
+
+func (w *thriftWriter) structEnd() {
+	w.buf.WriteByte(0)
+	if len(w.stack) > 0 {
+		w.lastField = w.stack[len(w.stack)-1]
+		w.stack = w.stack[:len(w.stack)-1]
+	}
+}
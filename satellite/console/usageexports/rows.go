@@ -0,0 +1,98 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package usageexports
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/payments"
+)
+
+// dateFormat is the format of the dates of the rows.
+const dateFormat = "2006-01-02"
+
+// columns are the names of the exported columns, in order.
+var columns = []string{
+	"date",
+	"project_id",
+	"bucket_name",
+	"storage_gb_hours",
+	"egress_gb",
+	"segment_hours",
+	"storage_cents",
+	"egress_cents",
+	"segment_cents",
+	"total_cents",
+}
+
+// Row is the usage and the charges of a bucket in a day.
+type Row struct {
+	Date           time.Time
+	ProjectID      uuid.UUID
+	BucketName     string
+	StorageGBHours float64
+	EgressGB       float64
+	SegmentHours   float64
+	StorageCents   float64
+	EgressCents    float64
+	SegmentCents   float64
+}
+
+// TotalCents returns the charges of the bucket in the day.
+func (row Row) TotalCents() float64 {
+	return row.StorageCents + row.EgressCents + row.SegmentCents
+}
+
+// rowFromCharge converts the charge of a bucket in the day to a row.
+func rowFromCharge(day time.Time, charge payments.BucketCharge) Row {
+	return Row{
+		Date:           day,
+		ProjectID:      charge.ProjectID,
+		BucketName:     charge.BucketName,
+		StorageGBHours: charge.TotalStoredData,
+		EgressGB:       charge.GetEgress,
+		SegmentHours:   charge.TotalSegments,
+		StorageCents:   charge.StoragePrice,
+		EgressCents:    charge.EgressPrice,
+		SegmentCents:   charge.SegmentPrice,
+	}
+}
+
+// WriteCSV writes the rows as comma-separated values with a header.
+func WriteCSV(w io.Writer, rows []Row) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(columns); err != nil {
+		return Error.Wrap(err)
+	}
+
+	formatFloat := func(value float64) string {
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+
+	for _, row := range rows {
+		err := writer.Write([]string{
+			row.Date.Format(dateFormat),
+			row.ProjectID.String(),
+			row.BucketName,
+			formatFloat(row.StorageGBHours),
+			formatFloat(row.EgressGB),
+			formatFloat(row.SegmentHours),
+			formatFloat(row.StorageCents),
+			formatFloat(row.EgressCents),
+			formatFloat(row.SegmentCents),
+			formatFloat(row.TotalCents()),
+		})
+		if err != nil {
+			return Error.Wrap(err)
+		}
+	}
+
+	writer.Flush()
+	return Error.Wrap(writer.Error())
+}
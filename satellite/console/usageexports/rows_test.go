@@ -0,0 +1,267 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package usageexports_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/csv"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite/console/usageexports"
+)
+
+func testRows() []usageexports.Row {
+	projectID := testrand.UUID()
+	day := time.Date(2022, time.June, 9, 0, 0, 0, 0, time.UTC)
+
+	return []usageexports.Row{
+		{
+			Date:           day,
+			ProjectID:      projectID,
+			BucketName:     "photos",
+			StorageGBHours: 24,
+			EgressGB:       1.5,
+			SegmentHours:   48,
+			StorageCents:   0.5,
+			EgressCents:    10.5,
+			SegmentCents:   0.25,
+		},
+		{
+			Date:       day.AddDate(0, 0, 1),
+			ProjectID:  projectID,
+			BucketName: "videos, \"raw\"",
+		},
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	rows := testRows()
+
+	var buf bytes.Buffer
+	require.NoError(t, usageexports.WriteCSV(&buf, rows))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+
+	require.Equal(t, []string{
+		"date", "project_id", "bucket_name",
+		"storage_gb_hours", "egress_gb", "segment_hours",
+		"storage_cents", "egress_cents", "segment_cents", "total_cents",
+	}, records[0])
+	require.Equal(t, []string{
+		"2022-06-09", rows[0].ProjectID.String(), "photos",
+		"24", "1.5", "48",
+		"0.5", "10.5", "0.25", "11.25",
+	}, records[1])
+	require.Equal(t, []string{
+		"2022-06-10", rows[1].ProjectID.String(), "videos, \"raw\"",
+		"0", "0", "0",
+		"0", "0", "0", "0",
+	}, records[2])
+}
+
+func TestWriteParquet(t *testing.T) {
+	rows := testRows()
+
+	var buf bytes.Buffer
+	require.NoError(t, usageexports.WriteParquet(&buf, rows))
+
+	numRows, columns := readParquet(t, buf.Bytes())
+	require.EqualValues(t, len(rows), numRows)
+
+	require.Equal(t, []string{
+		"date", "project_id", "bucket_name",
+		"storage_gb_hours", "egress_gb", "segment_hours",
+		"storage_cents", "egress_cents", "segment_cents", "total_cents",
+	}, columns.names)
+
+	for i, row := range rows {
+		require.Equal(t, row.Date, time.Unix(int64(columns.values["date"][i].(int32))*24*60*60, 0).UTC())
+		require.Equal(t, row.ProjectID.String(), columns.values["project_id"][i])
+		require.Equal(t, row.BucketName, columns.values["bucket_name"][i])
+		require.Equal(t, row.StorageGBHours, columns.values["storage_gb_hours"][i])
+		require.Equal(t, row.EgressGB, columns.values["egress_gb"][i])
+		require.Equal(t, row.SegmentHours, columns.values["segment_hours"][i])
+		require.Equal(t, row.StorageCents, columns.values["storage_cents"][i])
+		require.Equal(t, row.EgressCents, columns.values["egress_cents"][i])
+		require.Equal(t, row.SegmentCents, columns.values["segment_cents"][i])
+		require.Equal(t, row.TotalCents(), columns.values["total_cents"][i])
+	}
+
+	buf.Reset()
+	require.NoError(t, usageexports.WriteParquet(&buf, nil))
+
+	numRows, columns = readParquet(t, buf.Bytes())
+	require.Zero(t, numRows)
+	require.Len(t, columns.names, 10)
+	for _, values := range columns.values {
+		require.Empty(t, values)
+	}
+}
+
+// parquetColumns are the values of the columns of a Parquet file by name.
+type parquetColumns struct {
+	names  []string
+	values map[string][]interface{}
+}
+
+// readParquet reads a Parquet file of required columns stored in uncompressed
+// pages with plain encoding, following https://github.com/apache/parquet-format
+// independently of the writer. No Parquet library is a dependency of the
+// repository, so the file is read here.
+func readParquet(t *testing.T, data []byte) (numRows int64, columns parquetColumns) {
+	require.Greater(t, len(data), 12)
+	require.Equal(t, "PAR1", string(data[:4]))
+	require.Equal(t, "PAR1", string(data[len(data)-4:]))
+
+	footerSize := int(binary.LittleEndian.Uint32(data[len(data)-8 : len(data)-4]))
+	require.LessOrEqual(t, footerSize, len(data)-12)
+
+	footer := data[len(data)-8-footerSize : len(data)-8]
+	meta, n := readThriftStruct(t, footer)
+	require.Equal(t, footerSize, n)
+
+	numRows = meta[3].(int64)
+
+	// the first schema element is the root, which is followed by the columns.
+	schema := meta[2].([]interface{})
+	types := map[string]int32{}
+	for _, element := range schema[1:] {
+		element := element.(map[int16]interface{})
+		name := string(element[4].([]byte))
+		require.EqualValues(t, 0, element[3], "column %s must be required", name)
+		columns.names = append(columns.names, name)
+		types[name] = element[1].(int32)
+	}
+
+	rowGroups := meta[4].([]interface{})
+	require.Len(t, rowGroups, 1)
+
+	columns.values = map[string][]interface{}{}
+	for _, chunk := range rowGroups[0].(map[int16]interface{})[1].([]interface{}) {
+		chunkMeta := chunk.(map[int16]interface{})[3].(map[int16]interface{})
+		name := string(chunkMeta[3].([]interface{})[0].([]byte))
+		require.Equal(t, types[name], chunkMeta[1].(int32))
+		require.EqualValues(t, 0, chunkMeta[4], "column %s must be uncompressed", name)
+		require.Equal(t, numRows, chunkMeta[5].(int64))
+
+		offset := chunkMeta[9].(int64)
+		header, n := readThriftStruct(t, data[offset:])
+		require.EqualValues(t, 0, header[1], "page of column %s must be a data page", name)
+		require.Equal(t, header[2], header[3])
+
+		dataPage := header[5].(map[int16]interface{})
+		require.EqualValues(t, numRows, dataPage[1])
+		require.EqualValues(t, 0, dataPage[2], "column %s must be plain encoded", name)
+
+		page := data[offset+int64(n) : offset+int64(n)+int64(header[3].(int32))]
+		values := []interface{}{}
+		for len(page) > 0 {
+			switch types[name] {
+			case 1: // INT32
+				values = append(values, int32(binary.LittleEndian.Uint32(page)))
+				page = page[4:]
+			case 5: // DOUBLE
+				values = append(values, math.Float64frombits(binary.LittleEndian.Uint64(page)))
+				page = page[8:]
+			case 6: // BYTE_ARRAY
+				size := binary.LittleEndian.Uint32(page)
+				values = append(values, string(page[4:4+size]))
+				page = page[4+size:]
+			default:
+				t.Fatalf("unexpected type %d of column %s", types[name], name)
+			}
+		}
+		require.Len(t, values, int(numRows))
+		columns.values[name] = values
+	}
+
+	return numRows, columns
+}
+
+// readThriftStruct decodes a struct encoded with the Thrift compact protocol
+// into its fields by id and returns the number of bytes it read.
+func readThriftStruct(t *testing.T, data []byte) (map[int16]interface{}, int) {
+	fields := map[int16]interface{}{}
+	pos := 0
+	var lastID int16
+	for {
+		header := data[pos]
+		pos++
+		if header == 0 {
+			return fields, pos
+		}
+
+		id := lastID + int16(header>>4)
+		if header>>4 == 0 {
+			value, n := binary.Varint(data[pos:])
+			require.Greater(t, n, 0)
+			pos += n
+			id = int16(value)
+		}
+		lastID = id
+
+		var n int
+		fields[id], n = readThriftValue(t, header&0x0F, data[pos:])
+		pos += n
+	}
+}
+
+// readThriftValue decodes a value of the Thrift compact protocol type and
+// returns the number of bytes it read.
+func readThriftValue(t *testing.T, typ byte, data []byte) (interface{}, int) {
+	switch typ {
+	case 1, 2: // BOOLEAN_TRUE, BOOLEAN_FALSE
+		return typ == 1, 0
+	case 4, 5, 6: // I16, I32, I64 are zigzag varints
+		value, n := binary.Varint(data)
+		require.Greater(t, n, 0)
+		switch typ {
+		case 4:
+			return int16(value), n
+		case 5:
+			return int32(value), n
+		default:
+			return value, n
+		}
+	case 8: // BINARY
+		size, n := binary.Uvarint(data)
+		require.Greater(t, n, 0)
+		return data[n : n+int(size)], n + int(size)
+	case 9: // LIST
+		size, elemType := int(data[0]>>4), data[0]&0x0F
+		pos := 1
+		if size == 15 {
+			value, n := binary.Uvarint(data[pos:])
+			require.Greater(t, n, 0)
+			size = int(value)
+			pos += n
+		}
+		list := make([]interface{}, 0, size)
+		for i := 0; i < size; i++ {
+			// booleans of lists are stored as bytes instead of in the type.
+			if elemType == 1 || elemType == 2 {
+				list = append(list, data[pos] == 1)
+				pos++
+				continue
+			}
+			value, n := readThriftValue(t, elemType, data[pos:])
+			list = append(list, value)
+			pos += n
+		}
+		return list, pos
+	case 12: // STRUCT
+		return readThriftStruct(t, data)
+	default:
+		t.Fatalf("unexpected thrift type %d", typ)
+		return nil, 0
+	}
+}
@@ -24,6 +24,7 @@ func main() {
 	js.Global().Set("setAPIKeyPermission", setAPIKeyPermission())
 	js.Global().Set("newPermission", newPermission())
 	js.Global().Set("restrictGrant", restrictGrant())
+	js.Global().Set("accessGrantAPIKey", accessGrantAPIKey())
 	<-make(chan bool)
 }
 
@@ -152,6 +153,17 @@ func restrictGrant() js.Func {
 	}))
 }
 
+// accessGrantAPIKey returns the serialized API key of an access grant.
+func accessGrantAPIKey() js.Func {
+	return js.FuncOf(responseHandler(func(this js.Value, args []js.Value) (interface{}, error) {
+		if len(args) < 1 {
+			return nil, errs.New("not enough arguments. Need 1, but only %d supplied. The order of arguments are: access grant.", len(args))
+		}
+
+		return console.AccessGrantAPIKey(args[0].String())
+	}))
+}
+
 // newPermission creates a new permission object.
 func newPermission() js.Func {
 	return js.FuncOf(responseHandler(func(this js.Value, args []js.Value) (interface{}, error) {
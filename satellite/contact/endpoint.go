@@ -15,6 +15,7 @@ import (
 	"storj.io/common/pb"
 	"storj.io/common/rpc/rpcstatus"
 	"storj.io/common/storj"
+	"storj.io/storj/private/nodemaintenance"
 	"storj.io/storj/private/nodeoperator"
 	"storj.io/storj/satellite/overlay"
 )
@@ -120,12 +121,28 @@ func (endpoint *Endpoint) CheckIn(ctx context.Context, req *pb.CheckInRequest) (
 		return nil, rpcstatus.Error(rpcstatus.Internal, Error.Wrap(err).Error())
 	}
 
-	endpoint.log.Debug("checking in", zap.Stringer("Node ID", nodeID), zap.String("node addr", req.Address), zap.Bool("ping node success", pingNodeSuccess), zap.String("ping node err msg", pingErrorMessage))
-	return &pb.CheckInResponse{
+	resp := &pb.CheckInResponse{
 		PingNodeSuccess:     pingNodeSuccess,
 		PingNodeSuccessQuic: pingNodeSuccessQUIC,
 		PingErrorMessage:    pingErrorMessage,
-	}, nil
+	}
+
+	// a failing maintenance window doesn't fail the check-in.
+	maintenance, err := nodemaintenance.FromRequest(req)
+	if err != nil {
+		endpoint.log.Debug("ignoring invalid maintenance window", zap.Stringer("Node ID", nodeID), zap.Error(err))
+		nodemaintenance.SetResponse(resp, nodemaintenance.Status{Message: err.Error()})
+	} else {
+		status, err := endpoint.service.overlay.UpdateMaintenance(ctx, nodeID, maintenance, time.Now())
+		if err != nil {
+			endpoint.log.Info("failed to update maintenance window", zap.Stringer("Node ID", nodeID), zap.Error(err))
+			status = nodemaintenance.Status{Message: "the maintenance window couldn't be recorded"}
+		}
+		nodemaintenance.SetResponse(resp, status)
+	}
+
+	endpoint.log.Debug("checking in", zap.Stringer("Node ID", nodeID), zap.String("node addr", req.Address), zap.Bool("ping node success", pingNodeSuccess), zap.String("ping node err msg", pingErrorMessage))
+	return resp, nil
 }
 
 // GetTime returns current timestamp.
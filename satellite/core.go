@@ -34,6 +34,8 @@ import (
 	"storj.io/storj/satellite/buckets"
 	"storj.io/storj/satellite/console/consoleauth"
 	"storj.io/storj/satellite/console/emailreminders"
+	"storj.io/storj/satellite/console/spendingcaps"
+	"storj.io/storj/satellite/console/usageexports"
 	"storj.io/storj/satellite/gracefulexit"
 	"storj.io/storj/satellite/mailservice"
 	"storj.io/storj/satellite/metabase"
@@ -45,6 +47,7 @@ import (
 	"storj.io/storj/satellite/overlay"
 	"storj.io/storj/satellite/overlay/straynodes"
 	"storj.io/storj/satellite/payments"
+	"storj.io/storj/satellite/payments/invoicing"
 	"storj.io/storj/satellite/payments/stripecoinpayments"
 	"storj.io/storj/satellite/repair/checker"
 	"storj.io/storj/satellite/reputation"
@@ -106,11 +109,12 @@ type Core struct {
 	}
 
 	Audit struct {
-		Queues   *audit.Queues
-		Worker   *audit.Worker
-		Chore    *audit.Chore
-		Verifier *audit.Verifier
-		Reporter audit.Reporter
+		Queues    *audit.Queues
+		Worker    *audit.Worker
+		Chore     *audit.Chore
+		NodeChore *audit.NodeAuditChore
+		Verifier  *audit.Verifier
+		Reporter  audit.Reporter
 	}
 
 	ExpiredDeletion struct {
@@ -135,7 +139,18 @@ type Core struct {
 
 	Payments struct {
 		Accounts payments.Accounts
-		Chore    *stripecoinpayments.Chore
+		// Chore is nil when the built-in invoicing is used.
+		Chore *stripecoinpayments.Chore
+
+		InvoicingChore *invoicing.Chore
+	}
+
+	SpendingCaps struct {
+		Chore *spendingcaps.Chore
+	}
+
+	UsageExports struct {
+		Chore *usageexports.Chore
 	}
 
 	GracefulExit struct {
@@ -218,7 +233,7 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB,
 	}
 
 	{ // setup mailservice
-		peer.Mail.Service, err = setupMailService(peer.Log, *config)
+		peer.Mail.Service, err = SetupMailService(peer.Log, *config)
 		if err != nil {
 			return nil, errs.Combine(err, peer.Close())
 		}
@@ -295,6 +310,7 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB,
 			peer.Overlay.Service,
 			peer.Orders.DB,
 			peer.Buckets.Service,
+			nil,
 			config.Orders,
 		)
 		if err != nil {
@@ -407,6 +423,24 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB,
 		})
 		peer.Debug.Server.Panel.Add(
 			debug.Cycle("Audit Chore", peer.Audit.Chore.Loop))
+
+		peer.Audit.NodeChore = audit.NewNodeAuditChore(peer.Log.Named("audit:node-chore"),
+			peer.DB.NodeAuditQueue(),
+			audit.NewNodeAuditor(peer.Log.Named("audit:node-auditor"),
+				peer.Metainfo.SegmentLoop,
+				peer.Audit.Verifier,
+				peer.Audit.Reporter,
+				config.NodeAuditConcurrency,
+			),
+			config,
+		)
+		peer.Services.Add(lifecycle.Item{
+			Name:  "audit:node-chore",
+			Run:   peer.Audit.NodeChore.Run,
+			Close: peer.Audit.NodeChore.Close,
+		})
+		peer.Debug.Server.Panel.Add(
+			debug.Cycle("Audit Node Chore", peer.Audit.NodeChore.Loop))
 	}
 
 	{ // setup expired segment cleanup
@@ -495,49 +529,128 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB,
 	{ // setup payments
 		pc := config.Payments
 
-		var stripeClient stripecoinpayments.StripeClient
-		switch pc.Provider {
-		default:
-			stripeClient = stripecoinpayments.NewStripeMock(
-				peer.ID(),
-				peer.DB.StripeCoinPayments().Customers(),
-				peer.DB.Console().Users(),
+		// the built-in invoicing replaces the Stripe clearing, which would
+		// otherwise run against the Stripe mock.
+		if pc.Provider == "invoicing" {
+			invoicingService, err := invoicing.NewService(
+				peer.Log.Named("payments.invoicing:service"),
+				pc.Invoicing,
+				peer.DB.Invoicing(),
+				peer.DB.Billing(),
+				peer.DB.Console(),
+				peer.DB.ProjectAccounting(),
+				peer.DB.Wallets(),
+				peer.DB.StorjscanPayments(),
+				peer.Mail.Service,
+				pc.StorageTBPrice,
+				pc.EgressTBPrice,
+				pc.SegmentPrice)
+			if err != nil {
+				return nil, errs.Combine(err, peer.Close())
+			}
+
+			peer.Payments.Accounts = invoicingService.Accounts()
+
+			peer.Payments.InvoicingChore = invoicing.NewChore(
+				peer.Log.Named("payments.invoicing:chore"),
+				invoicingService,
+				pc.Invoicing.PaymentsInterval,
 			)
-		case "stripecoinpayments":
-			stripeClient = stripecoinpayments.NewStripeClient(log, pc.StripeCoinPayments)
-		}
+			peer.Services.Add(lifecycle.Item{
+				Name:  "payments.invoicing:chore",
+				Run:   peer.Payments.InvoicingChore.Run,
+				Close: peer.Payments.InvoicingChore.Close,
+			})
+			peer.Debug.Server.Panel.Add(
+				debug.Cycle("Payments Invoicing", peer.Payments.InvoicingChore.Loop))
+		} else {
+			var stripeClient stripecoinpayments.StripeClient
+			switch pc.Provider {
+			default:
+				stripeClient = stripecoinpayments.NewStripeMock(
+					peer.ID(),
+					peer.DB.StripeCoinPayments().Customers(),
+					peer.DB.Console().Users(),
+				)
+			case "stripecoinpayments":
+				stripeClient = stripecoinpayments.NewStripeClient(log, pc.StripeCoinPayments)
+			}
 
-		service, err := stripecoinpayments.NewService(
-			peer.Log.Named("payments.stripe:service"),
-			stripeClient,
-			pc.StripeCoinPayments,
-			peer.DB.StripeCoinPayments(),
-			peer.DB.Console().Projects(),
-			peer.DB.ProjectAccounting(),
-			pc.StorageTBPrice,
-			pc.EgressTBPrice,
-			pc.SegmentPrice,
-			pc.BonusRate)
-		if err != nil {
-			return nil, errs.Combine(err, peer.Close())
+			service, err := stripecoinpayments.NewService(
+				peer.Log.Named("payments.stripe:service"),
+				stripeClient,
+				pc.StripeCoinPayments,
+				peer.DB.StripeCoinPayments(),
+				peer.DB.Console().Projects(),
+				peer.DB.ProjectAccounting(),
+				pc.StorageTBPrice,
+				pc.EgressTBPrice,
+				pc.SegmentPrice,
+				pc.BonusRate)
+			if err != nil {
+				return nil, errs.Combine(err, peer.Close())
+			}
+
+			peer.Payments.Accounts = service.Accounts()
+
+			peer.Payments.Chore = stripecoinpayments.NewChore(
+				peer.Log.Named("payments.stripe:clearing"),
+				service,
+				pc.StripeCoinPayments.TransactionUpdateInterval,
+				pc.StripeCoinPayments.AccountBalanceUpdateInterval,
+			)
+			peer.Services.Add(lifecycle.Item{
+				Name: "payments.stripe:service",
+				Run:  peer.Payments.Chore.Run,
+			})
+			peer.Debug.Server.Panel.Add(
+				debug.Cycle("Payments Stripe Transactions", peer.Payments.Chore.TransactionCycle),
+				debug.Cycle("Payments Stripe Account Balance", peer.Payments.Chore.AccountBalanceCycle),
+			)
 		}
+	}
 
-		peer.Payments.Accounts = service.Accounts()
+	{ // setup project spending caps
+		if config.SpendingCaps.Enabled {
+			peer.SpendingCaps.Chore = spendingcaps.NewChore(
+				peer.Log.Named("console:spending-caps"),
+				peer.DB.Console(),
+				peer.Payments.Accounts,
+				peer.Mail.Service,
+				config.SpendingCaps,
+				config.Console.ExternalAddress,
+			)
+			peer.Services.Add(lifecycle.Item{
+				Name:  "console:spending-caps",
+				Run:   peer.SpendingCaps.Chore.Run,
+				Close: peer.SpendingCaps.Chore.Close,
+			})
+			peer.Debug.Server.Panel.Add(
+				debug.Cycle("Console Spending Caps", peer.SpendingCaps.Chore.Loop))
+		} else {
+			peer.Log.Named("console:spending-caps").Info("disabled")
+		}
+	}
 
-		peer.Payments.Chore = stripecoinpayments.NewChore(
-			peer.Log.Named("payments.stripe:clearing"),
-			service,
-			pc.StripeCoinPayments.TransactionUpdateInterval,
-			pc.StripeCoinPayments.AccountBalanceUpdateInterval,
-		)
-		peer.Services.Add(lifecycle.Item{
-			Name: "payments.stripe:service",
-			Run:  peer.Payments.Chore.Run,
-		})
-		peer.Debug.Server.Panel.Add(
-			debug.Cycle("Payments Stripe Transactions", peer.Payments.Chore.TransactionCycle),
-			debug.Cycle("Payments Stripe Account Balance", peer.Payments.Chore.AccountBalanceCycle),
-		)
+	{ // setup project usage exports
+		if config.UsageExports.Enabled {
+			peer.UsageExports.Chore = usageexports.NewChore(
+				peer.Log.Named("console:usage-exports"),
+				peer.DB.Console(),
+				peer.Payments.Accounts,
+				config.Console.UsageExportKeys,
+				config.UsageExports,
+			)
+			peer.Services.Add(lifecycle.Item{
+				Name:  "console:usage-exports",
+				Run:   peer.UsageExports.Chore.Run,
+				Close: peer.UsageExports.Chore.Close,
+			})
+			peer.Debug.Server.Panel.Add(
+				debug.Cycle("Console Usage Exports", peer.UsageExports.Chore.Loop))
+		} else {
+			peer.Log.Named("console:usage-exports").Info("disabled")
+		}
 	}
 
 	{ // setup graceful exit
@@ -392,14 +392,19 @@ func (endpoint *Endpoint) processIncomplete(ctx context.Context, stream pb.DRPCS
 	// populate excluded node IDs
 	pieces := segment.Pieces
 	excludedIDs := make([]storj.NodeID, len(pieces))
+	existingIDs := make([]storj.NodeID, 0, len(pieces))
 	for i, piece := range pieces {
 		excludedIDs[i] = piece.StorageNode
+		if piece.StorageNode != nodeID {
+			existingIDs = append(existingIDs, piece.StorageNode)
+		}
 	}
 
 	// get replacement node
 	request := &overlay.FindStorageNodesRequest{
 		RequestedCount: 1,
 		ExcludedIDs:    excludedIDs,
+		ExistingIDs:    existingIDs,
 	}
 
 	newNodes, err := endpoint.overlay.FindStorageNodesForGracefulExit(ctx, *request)
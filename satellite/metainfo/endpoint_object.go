@@ -490,6 +490,9 @@ func (endpoint *Endpoint) DownloadObject(ctx context.Context, req *pb.ObjectDown
 
 		limits, privateKey, err := endpoint.orders.CreateGetOrderLimits(ctx, object.Location().Bucket(), segment, downloadSizes.orderLimit)
 		if err != nil {
+			if orders.ErrBandwidthRateLimited.Has(err) {
+				return nil, rpcstatus.Error(rpcstatus.ResourceExhausted, "Exceeded Bandwidth Rate Limit")
+			}
 			if orders.ErrDownloadFailedNotEnoughPieces.Has(err) {
 				endpoint.log.Error("Unable to create order limits.",
 					zap.Stringer("Project ID", keyInfo.ProjectID),
@@ -77,8 +77,11 @@ func (endpoint *Endpoint) BeginSegment(ctx context.Context, req *pb.SegmentBegin
 	}
 
 	bucket := metabase.BucketLocation{ProjectID: keyInfo.ProjectID, BucketName: string(streamID.Bucket)}
-	rootPieceID, addressedLimits, piecePrivateKey, err := endpoint.orders.CreatePutOrderLimits(ctx, bucket, nodes, streamID.ExpirationDate, maxPieceSize)
+	rootPieceID, addressedLimits, piecePrivateKey, err := endpoint.orders.CreatePutOrderLimits(ctx, bucket, nodes, streamID.ExpirationDate, maxPieceSize, req.MaxOrderLimit)
 	if err != nil {
+		if orders.ErrBandwidthRateLimited.Has(err) {
+			return nil, rpcstatus.Error(rpcstatus.ResourceExhausted, "Exceeded Bandwidth Rate Limit")
+		}
 		endpoint.log.Error("internal", zap.Error(err))
 		return nil, rpcstatus.Error(rpcstatus.Internal, err.Error())
 	}
@@ -613,6 +616,9 @@ func (endpoint *Endpoint) DownloadSegment(ctx context.Context, req *pb.SegmentDo
 	// Remote segment
 	limits, privateKey, err := endpoint.orders.CreateGetOrderLimits(ctx, bucket, segment, 0)
 	if err != nil {
+		if orders.ErrBandwidthRateLimited.Has(err) {
+			return nil, rpcstatus.Error(rpcstatus.ResourceExhausted, "Exceeded Bandwidth Rate Limit")
+		}
 		if orders.ErrDownloadFailedNotEnoughPieces.Has(err) {
 			endpoint.log.Error("Unable to create order limits.",
 				zap.Stringer("Project ID", keyInfo.ProjectID),
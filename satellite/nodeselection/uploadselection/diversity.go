@@ -0,0 +1,118 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package uploadselection
+
+import (
+	"storj.io/common/storj/location"
+)
+
+// DiversityAttributes selects the node attributes which are diversified.
+type DiversityAttributes struct {
+	Country bool
+	ASN     bool
+	Wallet  bool
+}
+
+// Concentration is the largest number of nodes sharing the same value of an
+// attribute.
+type Concentration struct {
+	Country int
+	ASN     int
+	Wallet  int
+}
+
+// SelectDiverse selects up to n nodes from the candidates, preferring the
+// nodes whose attributes are least represented among the existing and the
+// already selected nodes. Between equally diverse candidates the earlier one
+// is selected, hence the candidates should be in random order.
+//
+// Unknown attribute values are counted as a value of their own.
+func SelectDiverse(existing, candidates []*Node, n int, attributes DiversityAttributes) []*Node {
+	counts := newAttributeCounts()
+	for _, node := range existing {
+		counts.add(node)
+	}
+
+	remaining := append([]*Node{}, candidates...)
+	selected := make([]*Node, 0, n)
+	for len(selected) < n && len(remaining) > 0 {
+		best, bestScore := 0, -1
+		for i, node := range remaining {
+			score := counts.score(node, attributes)
+			if bestScore < 0 || score < bestScore {
+				best, bestScore = i, score
+			}
+			if bestScore == 0 {
+				break
+			}
+		}
+
+		node := remaining[best]
+		remaining = append(remaining[:best], remaining[best+1:]...)
+
+		counts.add(node)
+		selected = append(selected, node)
+	}
+
+	return selected
+}
+
+// MeasureConcentration returns how concentrated the nodes are.
+func MeasureConcentration(nodes []*Node) Concentration {
+	counts := newAttributeCounts()
+	for _, node := range nodes {
+		counts.add(node)
+	}
+
+	var concentration Concentration
+	for _, count := range counts.country {
+		if count > concentration.Country {
+			concentration.Country = count
+		}
+	}
+	for _, count := range counts.asn {
+		if count > concentration.ASN {
+			concentration.ASN = count
+		}
+	}
+	for _, count := range counts.wallet {
+		if count > concentration.Wallet {
+			concentration.Wallet = count
+		}
+	}
+	return concentration
+}
+
+type attributeCounts struct {
+	country map[location.CountryCode]int
+	asn     map[uint]int
+	wallet  map[string]int
+}
+
+func newAttributeCounts() *attributeCounts {
+	return &attributeCounts{
+		country: map[location.CountryCode]int{},
+		asn:     map[uint]int{},
+		wallet:  map[string]int{},
+	}
+}
+
+func (counts *attributeCounts) add(node *Node) {
+	counts.country[node.CountryCode]++
+	counts.asn[node.ASN]++
+	counts.wallet[node.Wallet]++
+}
+
+func (counts *attributeCounts) score(node *Node, attributes DiversityAttributes) (score int) {
+	if attributes.Country {
+		score += counts.country[node.CountryCode]
+	}
+	if attributes.ASN {
+		score += counts.asn[node.ASN]
+	}
+	if attributes.Wallet {
+		score += counts.wallet[node.Wallet]
+	}
+	return score
+}
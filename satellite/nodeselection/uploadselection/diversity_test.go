@@ -0,0 +1,73 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package uploadselection_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/storj"
+	"storj.io/common/storj/location"
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite/nodeselection/uploadselection"
+)
+
+func TestSelectDiverse(t *testing.T) {
+	newNode := func(country location.CountryCode, asn uint, wallet string) *uploadselection.Node {
+		return &uploadselection.Node{
+			NodeURL:     storj.NodeURL{ID: testrand.NodeID()},
+			CountryCode: country,
+			ASN:         asn,
+			Wallet:      wallet,
+		}
+	}
+
+	existing := []*uploadselection.Node{
+		newNode(location.Germany, 1, "0xa"),
+		newNode(location.Germany, 1, "0xa"),
+		newNode(location.UnitedStates, 2, "0xb"),
+	}
+
+	germany := newNode(location.Germany, 3, "0xc")
+	sameASN := newNode(location.Canada, 1, "0xd")
+	sameWallet := newNode(location.Japan, 4, "0xa")
+	diverse := newNode(location.Canada, 5, "0xe")
+	candidates := []*uploadselection.Node{germany, sameASN, sameWallet, diverse}
+
+	all := uploadselection.DiversityAttributes{Country: true, ASN: true, Wallet: true}
+
+	t.Run("all attributes", func(t *testing.T) {
+		selected := uploadselection.SelectDiverse(existing, candidates, 1, all)
+		require.Equal(t, []*uploadselection.Node{diverse}, selected)
+
+		// the second canadian node shares both the country and the ASN,
+		// the others only share a single attribute twice, so the earlier one wins.
+		selected = uploadselection.SelectDiverse(existing, candidates, 2, all)
+		require.Equal(t, []*uploadselection.Node{diverse, germany}, selected)
+	})
+
+	t.Run("country only", func(t *testing.T) {
+		selected := uploadselection.SelectDiverse(existing, candidates, 2, uploadselection.DiversityAttributes{Country: true})
+		require.Equal(t, []*uploadselection.Node{sameASN, sameWallet}, selected)
+	})
+
+	t.Run("no attributes keeps order", func(t *testing.T) {
+		selected := uploadselection.SelectDiverse(existing, candidates, 3, uploadselection.DiversityAttributes{})
+		require.Equal(t, candidates[:3], selected)
+	})
+
+	t.Run("not enough candidates", func(t *testing.T) {
+		selected := uploadselection.SelectDiverse(existing, candidates, 10, all)
+		require.Len(t, selected, len(candidates))
+	})
+
+	t.Run("concentration", func(t *testing.T) {
+		before := uploadselection.MeasureConcentration(append(append([]*uploadselection.Node{}, existing...), germany))
+		require.Equal(t, uploadselection.Concentration{Country: 3, ASN: 2, Wallet: 2}, before)
+
+		after := uploadselection.MeasureConcentration(append(append([]*uploadselection.Node{}, existing...), diverse))
+		require.Equal(t, uploadselection.Concentration{Country: 2, ASN: 2, Wallet: 2}, after)
+	})
+}
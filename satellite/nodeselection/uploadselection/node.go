@@ -14,6 +14,8 @@ type Node struct {
 	LastNet     string
 	LastIPPort  string
 	CountryCode location.CountryCode
+	Wallet      string
+	ASN         uint
 }
 
 // Clone returns a deep clone of the selected node.
@@ -23,5 +25,7 @@ func (node *Node) Clone() *Node {
 		LastNet:     node.LastNet,
 		LastIPPort:  node.LastIPPort,
 		CountryCode: node.CountryCode,
+		Wallet:      node.Wallet,
+		ASN:         node.ASN,
 	}
 }
@@ -94,6 +94,12 @@ type OAuthTokens interface {
 
 	// RevokeRESTTokenV0 revokes a v0 rest token by setting its expires_at time to zero.
 	RevokeRESTTokenV0(ctx context.Context, token string) error
+
+	// GetRESTTokensV0 returns all unexpired v0 rest tokens of the user ordered by their creation time.
+	GetRESTTokensV0(ctx context.Context, userID uuid.UUID) ([]OAuthToken, error)
+
+	// UpdateRESTTokenV0LastUsed sets the time a v0 rest token was last used.
+	UpdateRESTTokenV0LastUsed(ctx context.Context, token string, lastUsedAt time.Time) error
 }
 
 // OAuthTokenKind defines an enumeration of different types of supported tokens.
@@ -124,7 +130,7 @@ type OAuthCode struct {
 	ClaimedAt       *time.Time
 }
 
-// OAuthToken represents a token stored within our database (either access / refresh).
+// OAuthToken represents a token stored within our database (either access / refresh / rest).
 type OAuthToken struct {
 	ClientID  uuid.UUID
 	UserID    uuid.UUID
@@ -133,6 +139,10 @@ type OAuthToken struct {
 	Token     string
 	CreatedAt time.Time
 	ExpiresAt time.Time
+
+	// Name and LastUsedAt are only set for rest tokens.
+	Name       string
+	LastUsedAt *time.Time
 }
 
 // NewDB constructs a database using the provided dbx db.
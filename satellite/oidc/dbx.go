@@ -149,6 +149,39 @@ func (o *tokensDBX) Get(ctx context.Context, kind OAuthTokenKind, token string)
 		return oauthToken, err
 	}
 
+	if time.Now().After(dbToken.ExpiresAt) {
+		return oauthToken, sql.ErrNoRows
+	}
+
+	return tokenFromDBX(dbToken)
+}
+
+func (o *tokensDBX) GetRESTTokensV0(ctx context.Context, userID uuid.UUID) (tokens []OAuthToken, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	dbTokens, err := o.db.All_OauthToken_By_UserId_And_Kind_OrderBy_Asc_CreatedAt(ctx,
+		dbx.OauthToken_UserId(userID.Bytes()), dbx.OauthToken_Kind(int(KindRESTTokenV0)))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, dbToken := range dbTokens {
+		if now.After(dbToken.ExpiresAt) {
+			continue
+		}
+
+		token, err := tokenFromDBX(dbToken)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+
+	return tokens, nil
+}
+
+func tokenFromDBX(dbToken *dbx.OauthToken) (oauthToken OAuthToken, err error) {
 	clientID, err := uuid.FromBytes(dbToken.ClientId)
 	if err != nil {
 		return oauthToken, err
@@ -159,17 +192,17 @@ func (o *tokensDBX) Get(ctx context.Context, kind OAuthTokenKind, token string)
 		return oauthToken, err
 	}
 
-	if time.Now().After(dbToken.ExpiresAt) {
-		return oauthToken, sql.ErrNoRows
-	}
-
 	oauthToken.ClientID = clientID
 	oauthToken.UserID = userID
 	oauthToken.Scope = dbToken.Scope
 	oauthToken.Kind = OAuthTokenKind(dbToken.Kind)
-	oauthToken.Token = token
+	oauthToken.Token = string(dbToken.Token)
 	oauthToken.CreatedAt = dbToken.CreatedAt
 	oauthToken.ExpiresAt = dbToken.ExpiresAt
+	oauthToken.LastUsedAt = dbToken.LastUsedAt
+	if dbToken.Name != nil {
+		oauthToken.Name = *dbToken.Name
+	}
 
 	return oauthToken, nil
 }
@@ -177,10 +210,18 @@ func (o *tokensDBX) Get(ctx context.Context, kind OAuthTokenKind, token string)
 func (o *tokensDBX) Create(ctx context.Context, token OAuthToken) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	optional := dbx.OauthToken_Create_Fields{
+		LastUsedAt: dbx.OauthToken_LastUsedAt_Raw(token.LastUsedAt),
+	}
+	if token.Name != "" {
+		optional.Name = dbx.OauthToken_Name(token.Name)
+	}
+
 	err = o.db.CreateNoReturn_OauthToken(ctx, dbx.OauthToken_ClientId(token.ClientID.Bytes()),
 		dbx.OauthToken_UserId(token.UserID.Bytes()), dbx.OauthToken_Scope(token.Scope),
 		dbx.OauthToken_Kind(int(token.Kind)), dbx.OauthToken_Token([]byte(token.Token)),
-		dbx.OauthToken_CreatedAt(token.CreatedAt), dbx.OauthToken_ExpiresAt(token.ExpiresAt))
+		dbx.OauthToken_CreatedAt(token.CreatedAt), dbx.OauthToken_ExpiresAt(token.ExpiresAt),
+		optional)
 
 	// ignore duplicate key errors as they're somewhat expected
 	if err != nil && dbx.IsConstraintError(err) {
@@ -199,3 +240,14 @@ func (o *tokensDBX) RevokeRESTTokenV0(ctx context.Context, token string) (err er
 			ExpiresAt: dbx.OauthToken_ExpiresAt(time.Time{}),
 		})
 }
+
+// UpdateRESTTokenV0LastUsed sets the time a v0 REST token was last used.
+func (o *tokensDBX) UpdateRESTTokenV0LastUsed(ctx context.Context, token string, lastUsedAt time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return o.db.UpdateNoReturn_OauthToken_By_Token_And_Kind(ctx, dbx.OauthToken_Token([]byte(token)),
+		dbx.OauthToken_Kind(int(KindRESTTokenV0)),
+		dbx.OauthToken_Update_Fields{
+			LastUsedAt: dbx.OauthToken_LastUsedAt(lastUsedAt),
+		})
+}
@@ -151,3 +151,39 @@ func TestOAuthTokens(t *testing.T) {
 		}
 	})
 }
+
+func TestRESTTokensV0(t *testing.T) {
+	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
+		userID, err := uuid.New()
+		require.NoError(t, err)
+
+		tokens := db.OIDC().OAuthTokens()
+
+		start := time.Now().Truncate(time.Microsecond)
+
+		for _, token := range []oidc.OAuthToken{
+			{UserID: userID, Kind: oidc.KindRESTTokenV0, Token: "expired", CreatedAt: start.Add(-2 * time.Hour), ExpiresAt: start.Add(-time.Hour)},
+			{UserID: userID, Kind: oidc.KindRESTTokenV0, Token: "second", Name: "second", CreatedAt: start.Add(time.Minute), ExpiresAt: start.Add(time.Hour)},
+			{UserID: userID, Kind: oidc.KindRESTTokenV0, Token: "first", Name: "first", Scope: "read_usage", CreatedAt: start, ExpiresAt: start.Add(time.Hour)},
+			{UserID: userID, Kind: oidc.KindAccessToken, Token: "access", CreatedAt: start, ExpiresAt: start.Add(time.Hour)},
+		} {
+			require.NoError(t, tokens.Create(ctx, token))
+		}
+
+		restTokens, err := tokens.GetRESTTokensV0(ctx, userID)
+		require.NoError(t, err)
+		require.Len(t, restTokens, 2)
+		require.Equal(t, "first", restTokens[0].Name)
+		require.Equal(t, "read_usage", restTokens[0].Scope)
+		require.Nil(t, restTokens[0].LastUsedAt)
+		require.Equal(t, "second", restTokens[1].Name)
+
+		lastUsedAt := start.Add(10 * time.Minute)
+		require.NoError(t, tokens.UpdateRESTTokenV0LastUsed(ctx, "first", lastUsedAt))
+
+		token, err := tokens.Get(ctx, oidc.KindRESTTokenV0, "first")
+		require.NoError(t, err)
+		require.NotNil(t, token.LastUsedAt)
+		require.WithinDuration(t, lastUsedAt, *token.LastUsedAt, time.Microsecond)
+	})
+}
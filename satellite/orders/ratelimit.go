@@ -0,0 +1,117 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package orders
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+
+	"storj.io/common/lrucache"
+	"storj.io/common/memory"
+	"storj.io/common/pb"
+	"storj.io/common/uuid"
+)
+
+// ErrBandwidthRateLimited is returned when a project allocates bandwidth faster than its bandwidth rate limit.
+var ErrBandwidthRateLimited = errs.Class("bandwidth rate limit exceeded")
+
+// BandwidthRateLimiterConfig is a configuration struct for limiting the bandwidth rate of projects.
+type BandwidthRateLimiterConfig struct {
+	CacheCapacity   int           `help:"number of projects to cache bandwidth rate limiters for." releaseDefault:"10000" devDefault:"10" testDefault:"100"`
+	CacheExpiration time.Duration `help:"how long to keep the bandwidth rate limiter state of an idle project." releaseDefault:"10m" devDefault:"10s"`
+}
+
+// ProjectBandwidthRateLimits returns the bandwidth rate limits of projects.
+type ProjectBandwidthRateLimits interface {
+	// GetProjectBandwidthRateLimit returns the number of bytes per second and
+	// the burst a project can allocate. A zero rate means no limit.
+	GetProjectBandwidthRateLimit(ctx context.Context, projectID uuid.UUID) (rate, burst memory.Size, err error)
+}
+
+// bandwidthRateLimiter limits the bandwidth projects can allocate with order
+// limits, separately for uploads and downloads.
+//
+// Only the state of the token buckets is cached. The limits are looked up on
+// every allocation, so that changes made through the admin API take effect as
+// soon as they are visible to ProjectBandwidthRateLimits.
+type bandwidthRateLimiter struct {
+	log      *zap.Logger
+	limits   ProjectBandwidthRateLimits
+	limiters *lrucache.ExpiringLRU
+}
+
+func newBandwidthRateLimiter(log *zap.Logger, limits ProjectBandwidthRateLimits, config BandwidthRateLimiterConfig) *bandwidthRateLimiter {
+	return &bandwidthRateLimiter{
+		log:    log,
+		limits: limits,
+		limiters: lrucache.New(lrucache.Options{
+			Capacity:   config.CacheCapacity,
+			Expiration: config.CacheExpiration,
+		}),
+	}
+}
+
+// allow returns ErrBandwidthRateLimited when the project cannot allocate amount
+// bytes for the action now. Requests larger than the burst are always
+// rejected, as they could never be allowed by the token bucket.
+func (limiter *bandwidthRateLimiter) allow(ctx context.Context, projectID uuid.UUID, action pb.PieceAction, amount int64) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	if limiter == nil || limiter.limits == nil || amount <= 0 {
+		return nil
+	}
+
+	rateLimit, burstLimit, err := limiter.limits.GetProjectBandwidthRateLimit(ctx, projectID)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	if rateLimit <= 0 {
+		return nil
+	}
+
+	if amount > burstLimit.Int64() {
+		limiter.log.Debug("bandwidth allocation exceeds the burst limit of project",
+			zap.Stringer("Project ID", projectID),
+			zap.Stringer("Action", action),
+			zap.Int64("Amount", amount),
+			zap.Int64("Burst Limit", burstLimit.Int64()))
+
+		mon.Event("orders_bandwidth_burst_limit_exceeded")
+
+		return ErrBandwidthRateLimited.New("%s of %d bytes exceeds the burst limit of %d bytes for project %s", action, amount, burstLimit.Int64(), projectID)
+	}
+
+	value, err := limiter.limiters.Get(projectID.String()+action.String(), func() (interface{}, error) {
+		return rate.NewLimiter(rate.Limit(rateLimit), burstLimit.Int()), nil
+	})
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	now := time.Now()
+	projectLimiter := value.(*rate.Limiter)
+	if projectLimiter.Limit() != rate.Limit(rateLimit) {
+		projectLimiter.SetLimitAt(now, rate.Limit(rateLimit))
+	}
+	if projectLimiter.Burst() != burstLimit.Int() {
+		projectLimiter.SetBurstAt(now, burstLimit.Int())
+	}
+
+	if !projectLimiter.AllowN(now, int(amount)) {
+		limiter.log.Debug("bandwidth rate limit exceeded for project",
+			zap.Stringer("Project ID", projectID),
+			zap.Stringer("Action", action),
+			zap.Float64("Rate Limit", float64(projectLimiter.Limit())),
+			zap.Int("Burst Limit", projectLimiter.Burst()))
+
+		mon.Event("orders_bandwidth_rate_limit_exceeded")
+
+		return ErrBandwidthRateLimited.New("%s of %d bytes for project %s", action, amount, projectID)
+	}
+
+	return nil
+}
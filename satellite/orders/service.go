@@ -38,6 +38,8 @@ type Config struct {
 	FlushInterval       time.Duration  `help:"how often to flush the rollups write cache to the database" devDefault:"30s" releaseDefault:"1m" testDefault:"$TESTINTERVAL"`
 	NodeStatusLogging   bool           `hidden:"true" help:"deprecated, log the offline/disqualification status of nodes" default:"false" testDefault:"true"`
 	OrdersSemaphoreSize int            `help:"how many concurrent orders to process at once. zero is unlimited" default:"2"`
+
+	BandwidthRateLimiter BandwidthRateLimiterConfig `help:"per-project bandwidth rate limiter configuration"`
 }
 
 // BucketsDB returns information about buckets.
@@ -56,6 +58,8 @@ type Service struct {
 	orders    DB
 	buckets   BucketsDB
 
+	bandwidthRateLimiter *bandwidthRateLimiter
+
 	encryptionKeys EncryptionKeys
 
 	orderExpiration time.Duration
@@ -67,7 +71,7 @@ type Service struct {
 // NewService creates new service for creating order limits.
 func NewService(
 	log *zap.Logger, satellite signing.Signer, overlay *overlay.Service,
-	orders DB, buckets BucketsDB,
+	orders DB, buckets BucketsDB, rateLimits ProjectBandwidthRateLimits,
 	config Config,
 ) (*Service, error) {
 	if config.EncryptionKeys.Default.IsZero() {
@@ -81,6 +85,8 @@ func NewService(
 		orders:    orders,
 		buckets:   buckets,
 
+		bandwidthRateLimiter: newBandwidthRateLimiter(log, rateLimits, config.BandwidthRateLimiter),
+
 		encryptionKeys: config.EncryptionKeys,
 
 		orderExpiration: config.Expiration,
@@ -155,6 +161,16 @@ func (service *Service) CreateGetOrderLimits(ctx context.Context, bucket metabas
 
 	neededLimits := segment.Redundancy.DownloadNodes()
 
+	// the rate limit is charged for the downloaded data, not for the order
+	// limits, since more order limits than needed are handed out.
+	downloadSize := int64(segment.EncryptedSize)
+	if rangeSize := orderLimit * int64(redundancy.RequiredCount()); overrideLimit > 0 && rangeSize < downloadSize {
+		downloadSize = rangeSize
+	}
+	if err := service.bandwidthRateLimiter.allow(ctx, bucket.ProjectID, pb.PieceAction_GET, downloadSize); err != nil {
+		return nil, storj.PiecePrivateKey{}, err
+	}
+
 	pieces := segment.Pieces
 	for _, pieceIndex := range service.perm(len(pieces)) {
 		piece := pieces[pieceIndex]
@@ -236,9 +252,16 @@ func getLimitByStorageNodeID(limits []*pb.AddressedOrderLimit, storageNodeID sto
 }
 
 // CreatePutOrderLimits creates the order limits for uploading pieces to nodes.
-func (service *Service) CreatePutOrderLimits(ctx context.Context, bucket metabase.BucketLocation, nodes []*overlay.SelectedNode, pieceExpiration time.Time, maxPieceSize int64) (_ storj.PieceID, _ []*pb.AddressedOrderLimit, privateKey storj.PiecePrivateKey, err error) {
+//
+// The bandwidth rate limit of the project is charged the maximum size of the
+// uploaded segment.
+func (service *Service) CreatePutOrderLimits(ctx context.Context, bucket metabase.BucketLocation, nodes []*overlay.SelectedNode, pieceExpiration time.Time, maxPieceSize, maxSegmentSize int64) (_ storj.PieceID, _ []*pb.AddressedOrderLimit, privateKey storj.PiecePrivateKey, err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	if err := service.bandwidthRateLimiter.allow(ctx, bucket.ProjectID, pb.PieceAction_PUT, maxSegmentSize); err != nil {
+		return storj.PieceID{}, nil, storj.PiecePrivateKey{}, err
+	}
+
 	signer, err := NewSignerPut(service, pieceExpiration, time.Now(), maxPieceSize, bucket)
 	if err != nil {
 		return storj.PieceID{}, nil, storj.PiecePrivateKey{}, Error.Wrap(err)
@@ -364,6 +387,53 @@ func (service *Service) CreateAuditOrderLimit(ctx context.Context, nodeID storj.
 	return orderLimit, signer.PrivateKey, nodeInfo, nil
 }
 
+// CreateAuditPieceOrderLimit creates an order limit for downloading a whole piece from a node for auditing it.
+//
+// The order limit uses the GET_REPAIR action, because storage nodes only send the
+// piece hash and the original order limit, which are needed to verify the piece,
+// for repair downloads.
+func (service *Service) CreateAuditPieceOrderLimit(ctx context.Context, nodeID storj.NodeID, pieceNum uint16, rootPieceID storj.PieceID, pieceSize int64) (limit *pb.AddressedOrderLimit, _ storj.PiecePrivateKey, nodeInfo *overlay.NodeReputation, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	node, err := service.overlay.Get(ctx, nodeID)
+	if err != nil {
+		return nil, storj.PiecePrivateKey{}, nil, Error.Wrap(err)
+	}
+
+	nodeInfo = &overlay.NodeReputation{
+		ID:         nodeID,
+		Address:    node.Address,
+		LastNet:    node.LastNet,
+		LastIPPort: node.LastIPPort,
+		Reputation: node.Reputation.Status,
+	}
+
+	if node.Disqualified != nil {
+		return nil, storj.PiecePrivateKey{}, nodeInfo, overlay.ErrNodeDisqualified.New("%v", nodeID)
+	}
+	if node.ExitStatus.ExitFinishedAt != nil {
+		return nil, storj.PiecePrivateKey{}, nodeInfo, overlay.ErrNodeFinishedGE.New("%v", nodeID)
+	}
+	if !service.overlay.IsOnline(node) {
+		return nil, storj.PiecePrivateKey{}, nodeInfo, overlay.ErrNodeOffline.New("%v", nodeID)
+	}
+
+	signer, err := NewSignerRepairGet(service, rootPieceID, time.Now(), pieceSize, metabase.BucketLocation{})
+	if err != nil {
+		return nil, storj.PiecePrivateKey{}, nodeInfo, Error.Wrap(err)
+	}
+
+	orderLimit, err := signer.Sign(ctx, storj.NodeURL{
+		ID:      nodeID,
+		Address: node.Address.Address,
+	}, int32(pieceNum))
+	if err != nil {
+		return nil, storj.PiecePrivateKey{}, nodeInfo, Error.Wrap(err)
+	}
+
+	return orderLimit, signer.PrivateKey, nodeInfo, nil
+}
+
 // CreateGetRepairOrderLimits creates the order limits for downloading the
 // healthy pieces of segment as the source for repair.
 //
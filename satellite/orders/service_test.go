@@ -5,14 +5,20 @@ package orders_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 
 	"storj.io/common/memory"
+	"storj.io/common/pb"
 	"storj.io/common/testcontext"
 	"storj.io/common/testrand"
 	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite"
 	"storj.io/storj/satellite/metabase"
+	"storj.io/storj/satellite/orders"
+	"storj.io/storj/satellite/overlay"
 )
 
 func TestOrderLimitsEncryptedMetadata(t *testing.T) {
@@ -64,3 +70,83 @@ func TestOrderLimitsEncryptedMetadata(t *testing.T) {
 		require.Equal(t, projectID, actualBucketInfo.ProjectID)
 	})
 }
+
+func TestCreateGetOrderLimitsBandwidthRateLimit(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 4, UplinkCount: 1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				// make changes of the project limits visible immediately
+				config.ProjectLimit.CacheExpiration = time.Nanosecond
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellitePeer := planet.Satellites[0]
+		uplinkPeer := planet.Uplinks[0]
+		projectID := uplinkPeer.Projects[0].ID
+
+		require.NoError(t, uplinkPeer.Upload(ctx, satellitePeer, "testbucket", "test/path", testrand.Bytes(5*memory.KiB)))
+
+		segments, err := satellitePeer.Metabase.DB.TestingAllSegments(ctx)
+		require.NoError(t, err)
+		require.Len(t, segments, 1)
+
+		projectAccounting := satellitePeer.DB.ProjectAccounting()
+		// allow a single download of the segment and refill one byte per second
+		require.NoError(t, projectAccounting.UpdateProjectBandwidthRateLimit(ctx, projectID, 1))
+		require.NoError(t, projectAccounting.UpdateProjectBandwidthBurstLimit(ctx, projectID, 8*memory.KiB))
+
+		bucket := metabase.BucketLocation{ProjectID: projectID, BucketName: "testbucket"}
+
+		_, _, err = satellitePeer.Orders.Service.CreateGetOrderLimits(ctx, bucket, segments[0], 0)
+		require.NoError(t, err)
+
+		_, _, err = satellitePeer.Orders.Service.CreateGetOrderLimits(ctx, bucket, segments[0], 0)
+		require.Error(t, err)
+		require.True(t, orders.ErrBandwidthRateLimited.Has(err))
+
+		// raising the limits applies to the rate limiter of the project right away
+		require.NoError(t, projectAccounting.UpdateProjectBandwidthRateLimit(ctx, projectID, memory.MiB))
+		require.NoError(t, projectAccounting.UpdateProjectBandwidthBurstLimit(ctx, projectID, memory.MiB))
+
+		_, _, err = satellitePeer.Orders.Service.CreateGetOrderLimits(ctx, bucket, segments[0], 0)
+		require.NoError(t, err)
+	})
+}
+
+func TestCreatePutOrderLimitsBandwidthRateLimit(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 4, UplinkCount: 1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.ProjectLimit.BandwidthRate = 1
+				config.ProjectLimit.BandwidthBurst = 8 * memory.KiB
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellitePeer := planet.Satellites[0]
+		bucket := metabase.BucketLocation{ProjectID: planet.Uplinks[0].Projects[0].ID, BucketName: "testbucket"}
+
+		var nodes []*overlay.SelectedNode
+		for _, node := range planet.StorageNodes {
+			nodes = append(nodes, &overlay.SelectedNode{ID: node.ID(), Address: &pb.NodeAddress{Address: node.Addr()}})
+		}
+
+		createPutOrderLimits := func(maxSegmentSize memory.Size) error {
+			_, _, _, err := satellitePeer.Orders.Service.CreatePutOrderLimits(ctx, bucket, nodes, time.Time{}, memory.KiB.Int64(), maxSegmentSize.Int64())
+			return err
+		}
+
+		// segments larger than the burst can never be uploaded
+		err := createPutOrderLimits(16 * memory.KiB)
+		require.Error(t, err)
+		require.True(t, orders.ErrBandwidthRateLimited.Has(err))
+
+		// the whole segment size is charged, not the burst
+		require.NoError(t, createPutOrderLimits(6*memory.KiB))
+
+		err = createPutOrderLimits(6 * memory.KiB)
+		require.Error(t, err)
+		require.True(t, orders.ErrBandwidthRateLimited.Has(err))
+	})
+}
@@ -26,6 +26,25 @@ type Config struct {
 	UpdateStatsBatchSize       int           `help:"number of update requests to process per transaction" default:"100"`
 	NodeCheckInWaitPeriod      time.Duration `help:"the amount of time to wait before accepting a redundant check-in from a node (unmodified info since last check-in)" default:"2h" testDefault:"30s"`
 	RepairExcludedCountryCodes []string      `help:"list of country codes to exclude nodes from target repair selection" default:"" testDefault:"FR,BE"`
+	RepairDiversity            DiversityConfig
+	Maintenance                MaintenanceConfig
+}
+
+// DiversityConfig configures how the repair and graceful exit targets are
+// selected to avoid concentrating the pieces of a segment.
+type DiversityConfig struct {
+	Enabled             bool `help:"select repair and graceful exit targets which maximize the diversity of the pieces of a segment" default:"false"`
+	Country             bool `help:"avoid concentrating the pieces of a segment in one country" default:"true"`
+	ASN                 bool `help:"avoid concentrating the pieces of a segment in one autonomous system, requires overlay.geo-ip.asn-database" default:"true"`
+	Wallet              bool `help:"avoid concentrating the pieces of a segment on nodes of one operator wallet" default:"true"`
+	CandidateMultiplier int  `help:"number of candidate nodes sampled for each requested node to choose the most diverse ones from" default:"4"`
+}
+
+// MaintenanceConfig configures the planned maintenance windows of nodes.
+type MaintenanceConfig struct {
+	Enabled     bool          `help:"accept planned maintenance windows reported by nodes" default:"true"`
+	Allowance   time.Duration `help:"how long a node may be in planned maintenance within a year" default:"72h"`
+	MaxLeadTime time.Duration `help:"how far in advance a maintenance window may be planned" default:"720h"`
 }
 
 // AsOfSystemTimeConfig is a configuration struct to enable 'AS OF SYSTEM TIME' for CRDB queries.
@@ -51,6 +70,7 @@ type NodeSelectionConfig struct {
 // GeoIPConfig is a configuration struct that helps configure the GeoIP lookup features on the satellite.
 type GeoIPConfig struct {
 	DB            string   `help:"the location of the maxmind database containing geoip country information"`
+	ASNDatabase   string   `help:"the location of the maxmind database containing autonomous system information"`
 	MockCountries []string `help:"a mock list of countries the satellite will attribute to nodes (useful for testing)"`
 }
 
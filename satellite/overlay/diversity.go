@@ -0,0 +1,94 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"storj.io/storj/satellite/nodeselection/uploadselection"
+)
+
+// findDiverseStorageNodes samples more candidates than requested from the
+// upload selection cache and picks the ones which diversify the pieces of the
+// segment the most.
+func (service *Service) findDiverseStorageNodes(ctx context.Context, req FindStorageNodesRequest) (_ []*SelectedNode, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	config := service.config.RepairDiversity
+	if service.config.Node.AsOfSystemTime.Enabled && service.config.Node.AsOfSystemTime.DefaultInterval < 0 {
+		req.AsOfSystemInterval = service.config.Node.AsOfSystemTime.DefaultInterval
+	}
+
+	multiplier := config.CandidateMultiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	candidatesReq := req
+	candidatesReq.RequestedCount = req.RequestedCount * multiplier
+	candidates, err := service.UploadSelectionCache.GetNodes(ctx, candidatesReq)
+	if err != nil && !ErrNotEnoughNodes.Has(err) {
+		return nil, err
+	}
+	if len(candidates) <= req.RequestedCount {
+		if len(candidates) < req.RequestedCount {
+			return candidates, ErrNotEnoughNodes.New("requested %d found %d", req.RequestedCount, len(candidates))
+		}
+		return candidates, nil
+	}
+
+	var existing []*uploadselection.Node
+	if len(req.ExistingIDs) > 0 {
+		// the download selection cache contains all the online nodes,
+		// including the ones which don't accept uploads anymore.
+		existingNodes, err := service.DownloadSelectionCache.GetNodes(ctx, req.ExistingIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, node := range existingNodes {
+			existing = append(existing, service.diversityNode(node))
+		}
+	}
+
+	candidateNodes := make([]*uploadselection.Node, len(candidates))
+	for i, node := range candidates {
+		candidateNodes[i] = service.diversityNode(node)
+	}
+
+	selected := uploadselection.SelectDiverse(existing, candidateNodes, req.RequestedCount, uploadselection.DiversityAttributes{
+		Country: config.Country,
+		ASN:     config.ASN && service.ASN != nil,
+		Wallet:  config.Wallet,
+	})
+
+	// the candidates are in random order, hence the first ones are what
+	// a selection without diversity would have returned.
+	before := uploadselection.MeasureConcentration(append(append([]*uploadselection.Node{}, existing...), candidateNodes[:req.RequestedCount]...))
+	after := uploadselection.MeasureConcentration(append(append([]*uploadselection.Node{}, existing...), selected...))
+
+	mon.IntVal("diversity_max_pieces_per_country").Observe(int64(after.Country))
+	mon.IntVal("diversity_max_pieces_per_asn").Observe(int64(after.ASN))
+	mon.IntVal("diversity_max_pieces_per_wallet").Observe(int64(after.Wallet))
+	mon.IntVal("diversity_improvement_country").Observe(int64(before.Country - after.Country))
+	mon.IntVal("diversity_improvement_asn").Observe(int64(before.ASN - after.ASN))
+	mon.IntVal("diversity_improvement_wallet").Observe(int64(before.Wallet - after.Wallet))
+
+	return convNodesToSelectedNodes(selected), nil
+}
+
+// diversityNode converts the node for diversity selection, looking up its
+// autonomous system when an ASN database is configured.
+func (service *Service) diversityNode(node *SelectedNode) *uploadselection.Node {
+	converted := convSelectedNodesToNodes([]*SelectedNode{node})[0]
+	if service.ASN != nil && node.LastIPPort != "" {
+		asn, err := service.ASN.LookupASN(node.LastIPPort)
+		if err != nil {
+			service.log.Debug("failed to look up ASN", zap.Stringer("Node ID", node.ID), zap.Error(err))
+		}
+		converted.ASN = asn
+	}
+	return converted
+}
@@ -101,6 +101,26 @@ func (cache *DownloadSelectionCache) GetNodeIPs(ctx context.Context, nodes []sto
 	return state.IPs(nodes), nil
 }
 
+// GetNodes gets the nodes from the cache, refreshing when needed.
+func (cache *DownloadSelectionCache) GetNodes(ctx context.Context, nodes []storj.NodeID) (_ map[storj.NodeID]*SelectedNode, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	cache.mu.RLock()
+	lastRefresh := cache.lastRefresh
+	state := cache.state
+	cache.mu.RUnlock()
+
+	// if the cache is stale, then refresh it before we get nodes
+	if state == nil || time.Since(lastRefresh) > cache.config.Staleness {
+		state, err = cache.refresh(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return state.Nodes(nodes), nil
+}
+
 // Size returns how many nodes are in the cache.
 func (cache *DownloadSelectionCache) Size() int {
 	cache.mu.RLock()
@@ -116,32 +136,43 @@ func (cache *DownloadSelectionCache) Size() int {
 
 // DownloadSelectionCacheState contains state of download selection cache.
 type DownloadSelectionCacheState struct {
-	// ipPortByID returns IP based on storj.NodeID
-	ipPortByID map[storj.NodeID]string
+	// byID returns the node based on storj.NodeID
+	byID map[storj.NodeID]*SelectedNode
 }
 
 // NewDownloadSelectionCacheState creates a new state from the nodes.
 func NewDownloadSelectionCacheState(nodes []*SelectedNode) *DownloadSelectionCacheState {
-	ipPortByID := map[storj.NodeID]string{}
+	byID := map[storj.NodeID]*SelectedNode{}
 	for _, n := range nodes {
-		ipPortByID[n.ID] = n.LastIPPort
+		byID[n.ID] = n
 	}
 	return &DownloadSelectionCacheState{
-		ipPortByID: ipPortByID,
+		byID: byID,
 	}
 }
 
 // Size returns how many nodes are in the state.
 func (state *DownloadSelectionCacheState) Size() int {
-	return len(state.ipPortByID)
+	return len(state.byID)
 }
 
 // IPs returns node ip:port for nodes that are in state.
 func (state *DownloadSelectionCacheState) IPs(nodes []storj.NodeID) map[storj.NodeID]string {
 	xs := make(map[storj.NodeID]string, len(nodes))
 	for _, nodeID := range nodes {
-		if ip, exists := state.ipPortByID[nodeID]; exists {
-			xs[nodeID] = ip
+		if n, exists := state.byID[nodeID]; exists {
+			xs[nodeID] = n.LastIPPort
+		}
+	}
+	return xs
+}
+
+// Nodes returns a clone of the nodes that are in state.
+func (state *DownloadSelectionCacheState) Nodes(nodes []storj.NodeID) map[storj.NodeID]*SelectedNode {
+	xs := make(map[storj.NodeID]*SelectedNode, len(nodes))
+	for _, nodeID := range nodes {
+		if n, exists := state.byID[nodeID]; exists {
+			xs[nodeID] = n.Clone()
 		}
 	}
 	return xs
@@ -12,6 +12,7 @@ import (
 
 	"storj.io/common/pb"
 	"storj.io/common/storj"
+	"storj.io/common/storj/location"
 	"storj.io/common/testcontext"
 	"storj.io/common/testrand"
 	"storj.io/storj/satellite"
@@ -88,3 +89,23 @@ func TestDownloadSelectionCacheState_IPs(t *testing.T) {
 	require.Len(t, ips, 1)
 	require.Equal(t, node.LastIPPort, ips[node.ID])
 }
+
+func TestDownloadSelectionCacheState_Nodes(t *testing.T) {
+	node := &overlay.SelectedNode{
+		ID: testrand.NodeID(),
+		Address: &pb.NodeAddress{
+			Address: "1.0.1.1:8080",
+		},
+		LastNet:     "1.0.1",
+		LastIPPort:  "1.0.1.1:8080",
+		CountryCode: location.Germany,
+		Wallet:      "0x0123456789",
+	}
+
+	state := overlay.NewDownloadSelectionCacheState([]*overlay.SelectedNode{node})
+
+	nodes := state.Nodes([]storj.NodeID{testrand.NodeID(), node.ID})
+	require.Len(t, nodes, 1)
+	require.Equal(t, node, nodes[node.ID])
+	require.NotSame(t, node, nodes[node.ID])
+}
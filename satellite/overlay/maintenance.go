@@ -0,0 +1,224 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"storj.io/common/storj"
+	"storj.io/storj/private/nodemaintenance"
+)
+
+// maintenanceAllowancePeriod is the period the maintenance allowance applies to.
+const maintenanceAllowancePeriod = 365 * 24 * time.Hour
+
+// UpdateMaintenance records the maintenance window a node reported when
+// checking in and returns the accepted window.
+//
+// A node has at most one planned window. Reporting a different window replaces
+// the planned one, unless it already started; a window in progress can only
+// be extended or shortened. Windows can't start in the past and are shortened
+// to the remaining yearly allowance. Reporting no window ends a window in
+// progress and cancels the planned one.
+func (service *Service) UpdateMaintenance(ctx context.Context, nodeID storj.NodeID, reported nodemaintenance.Window, now time.Time) (status nodemaintenance.Status, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	config := service.config.Maintenance
+	if !config.Enabled {
+		if reported.IsZero() {
+			return nodemaintenance.Status{}, nil
+		}
+		return nodemaintenance.Status{Message: "the satellite doesn't accept maintenance windows"}, nil
+	}
+
+	now = now.UTC().Truncate(time.Second)
+
+	// the window of the node may change, which must be seen by the next
+	// lookup of the nodes in maintenance.
+	defer service.maintenance.invalidate()
+
+	windows, err := service.db.GetMaintenanceWindows(ctx, nodeID, now.Add(-maintenanceAllowancePeriod))
+	if err != nil {
+		return nodemaintenance.Status{}, Error.Wrap(err)
+	}
+
+	var used time.Duration
+	var planned []nodemaintenance.Window
+	var ongoing *nodemaintenance.Window
+	for i, window := range windows {
+		switch {
+		case window.Start.After(now):
+			planned = append(planned, window)
+		case window.Contains(now):
+			ongoing = &windows[i]
+		default:
+			used += window.Duration()
+		}
+	}
+
+	// replace cancels the planned windows other than the accepted one and
+	// records it.
+	replace := func(accepted nodemaintenance.Window) error {
+		for _, window := range planned {
+			if accepted.IsZero() || !window.Start.Equal(accepted.Start) {
+				if err := service.db.DeleteMaintenanceWindow(ctx, nodeID, window.Start); err != nil {
+					return Error.Wrap(err)
+				}
+			}
+		}
+		if accepted.IsZero() {
+			return nil
+		}
+		return Error.Wrap(service.db.SetMaintenanceWindow(ctx, nodeID, accepted))
+	}
+
+	if reported.IsZero() {
+		if ongoing != nil {
+			// the maintenance is over early, which gives back the rest of
+			// the allowance.
+			ended := nodemaintenance.Window{Start: ongoing.Start, End: now}
+			if ended.Duration() <= 0 {
+				err = service.db.DeleteMaintenanceWindow(ctx, nodeID, ongoing.Start)
+			} else {
+				err = service.db.SetMaintenanceWindow(ctx, nodeID, ended)
+			}
+			if err != nil {
+				return nodemaintenance.Status{}, Error.Wrap(err)
+			}
+		}
+		return nodemaintenance.Status{}, replace(nodemaintenance.Window{})
+	}
+
+	if err := reported.Validate(); err != nil {
+		return nodemaintenance.Status{Message: err.Error()}, nil
+	}
+
+	candidate := nodemaintenance.Window{
+		Start: reported.Start.UTC().Truncate(time.Second),
+		End:   reported.End.UTC().Truncate(time.Second),
+	}
+	switch {
+	case ongoing != nil && candidate.Start.After(ongoing.Start):
+		return nodemaintenance.Status{
+			Accepted: *ongoing,
+			Message:  "another maintenance window is in progress",
+		}, nil
+	case ongoing != nil:
+		// the window in progress, which may have been reported with an
+		// earlier start than it was accepted with.
+		candidate.Start = ongoing.Start
+	case candidate.Start.Before(now):
+		candidate.Start = now
+	}
+
+	if candidate.Start.After(now.Add(config.MaxLeadTime)) {
+		return nodemaintenance.Status{
+			Message: fmt.Sprintf("maintenance windows can be planned at most %s in advance", config.MaxLeadTime),
+		}, nil
+	}
+	if !candidate.End.After(candidate.Start) {
+		return nodemaintenance.Status{Message: "the maintenance window is already over"}, nil
+	}
+
+	remaining := config.Allowance - used
+	if remaining <= 0 {
+		return nodemaintenance.Status{
+			Message: fmt.Sprintf("the yearly maintenance allowance of %s is used up", config.Allowance),
+		}, nil
+	}
+	if candidate.Duration() > remaining {
+		candidate.End = candidate.Start.Add(remaining)
+		status.Message = fmt.Sprintf("shortened to the remaining yearly maintenance allowance of %s", remaining)
+	}
+
+	if err := replace(candidate); err != nil {
+		return nodemaintenance.Status{}, err
+	}
+	status.Accepted = candidate
+	return status, nil
+}
+
+// NodesInMaintenance returns the nodes of nodeIDs which are in a maintenance
+// window at the given time. The windows are looked up in a cache, which is
+// refreshed like the node selection cache.
+func (service *Service) NodesInMaintenance(ctx context.Context, nodeIDs storj.NodeIDList, at time.Time) (_ storj.NodeIDList, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if !service.config.Maintenance.Enabled || len(nodeIDs) == 0 {
+		return nil, nil
+	}
+
+	state, err := service.maintenance.get(ctx)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if at.Before(state.since) {
+		// the cache only contains the windows which didn't end before it
+		// was refreshed.
+		nodes, err := service.db.GetNodesInMaintenance(ctx, nodeIDs, at)
+		return nodes, Error.Wrap(err)
+	}
+
+	var inMaintenance storj.NodeIDList
+	for _, nodeID := range nodeIDs {
+		for _, window := range state.windows[nodeID] {
+			if window.Contains(at) {
+				inMaintenance = append(inMaintenance, nodeID)
+				break
+			}
+		}
+	}
+	return inMaintenance, nil
+}
+
+// maintenanceCache keeps the maintenance windows of all nodes, which didn't
+// end yet, so that checking whether the nodes of a segment are in maintenance
+// doesn't query the database.
+type maintenanceCache struct {
+	db        DB
+	staleness time.Duration
+
+	mu          sync.Mutex
+	lastRefresh time.Time
+	state       *maintenanceState
+}
+
+// maintenanceState contains the maintenance windows ending after since.
+type maintenanceState struct {
+	since   time.Time
+	windows map[storj.NodeID][]nodemaintenance.Window
+}
+
+// get returns the cached windows, refreshing them when they are stale.
+func (cache *maintenanceCache) get(ctx context.Context) (_ *maintenanceState, err error) {
+	defer mon.Task()(&ctx)(&err)
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if cache.state != nil && time.Since(cache.lastRefresh) <= cache.staleness {
+		return cache.state, nil
+	}
+
+	now := time.Now().UTC()
+	windows, err := cache.db.GetActiveMaintenanceWindows(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+
+	cache.lastRefresh = now
+	cache.state = &maintenanceState{since: now, windows: windows}
+
+	mon.IntVal("refresh_maintenance_cache_size").Observe(int64(len(windows)))
+	return cache.state, nil
+}
+
+// invalidate makes the next lookup refresh the cached windows.
+func (cache *maintenanceCache) invalidate() {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	cache.state = nil
+}
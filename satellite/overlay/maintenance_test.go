@@ -0,0 +1,138 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/private/nodemaintenance"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/overlay"
+	"storj.io/storj/satellite/satellitedb/satellitedbtest"
+)
+
+func TestUpdateMaintenance(t *testing.T) {
+	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
+		service, err := overlay.NewService(zaptest.NewLogger(t), db.OverlayCache(), overlay.Config{
+			Node: testNodeSelectionConfig(0, false),
+			Maintenance: overlay.MaintenanceConfig{
+				Enabled:     true,
+				Allowance:   10 * time.Hour,
+				MaxLeadTime: 7 * 24 * time.Hour,
+			},
+		})
+		require.NoError(t, err)
+
+		nodeID := testrand.NodeID()
+		now := time.Date(2022, 7, 1, 12, 0, 0, 0, time.UTC)
+		inMaintenance := func(at time.Time) bool {
+			nodes, err := service.NodesInMaintenance(ctx, storj.NodeIDList{nodeID}, at)
+			require.NoError(t, err)
+			return len(nodes) > 0
+		}
+
+		// a planned window is accepted as reported.
+		planned := nodemaintenance.Window{Start: now.Add(time.Hour), End: now.Add(5 * time.Hour)}
+		status, err := service.UpdateMaintenance(ctx, nodeID, planned, now)
+		require.NoError(t, err)
+		require.Equal(t, planned, status.Accepted)
+		require.Empty(t, status.Message)
+		require.False(t, inMaintenance(now))
+		require.True(t, inMaintenance(now.Add(2*time.Hour)))
+
+		// reporting another window replaces the planned one.
+		replaced := nodemaintenance.Window{Start: now.Add(2 * time.Hour), End: now.Add(8 * time.Hour)}
+		status, err = service.UpdateMaintenance(ctx, nodeID, replaced, now)
+		require.NoError(t, err)
+		require.Equal(t, replaced, status.Accepted)
+		require.False(t, inMaintenance(now.Add(90*time.Minute)))
+
+		// windows can't be planned too far in advance.
+		status, err = service.UpdateMaintenance(ctx, nodeID, nodemaintenance.Window{
+			Start: now.Add(30 * 24 * time.Hour), End: now.Add(31 * 24 * time.Hour),
+		}, now)
+		require.NoError(t, err)
+		require.True(t, status.Accepted.IsZero())
+		require.NotEmpty(t, status.Message)
+
+		// the window in progress can be extended within the allowance.
+		later := now.Add(3 * time.Hour)
+		status, err = service.UpdateMaintenance(ctx, nodeID, nodemaintenance.Window{
+			Start: replaced.Start, End: replaced.Start.Add(20 * time.Hour),
+		}, later)
+		require.NoError(t, err)
+		require.Equal(t, nodemaintenance.Window{Start: replaced.Start, End: replaced.Start.Add(10 * time.Hour)}, status.Accepted)
+		require.NotEmpty(t, status.Message)
+
+		// reporting no window ends the window in progress early.
+		status, err = service.UpdateMaintenance(ctx, nodeID, nodemaintenance.Window{}, later)
+		require.NoError(t, err)
+		require.True(t, status.Accepted.IsZero())
+		require.False(t, inMaintenance(later))
+
+		// the hour used before counts against the allowance.
+		next := now.Add(24 * time.Hour)
+		status, err = service.UpdateMaintenance(ctx, nodeID, nodemaintenance.Window{
+			Start: next, End: next.Add(12 * time.Hour),
+		}, next.Add(-time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, nodemaintenance.Window{Start: next, End: next.Add(9 * time.Hour)}, status.Accepted)
+
+		// windows can't start in the past.
+		_, err = service.UpdateMaintenance(ctx, nodeID, nodemaintenance.Window{}, next.Add(-time.Hour))
+		require.NoError(t, err)
+		status, err = service.UpdateMaintenance(ctx, nodeID, nodemaintenance.Window{
+			Start: next.Add(-10 * time.Hour), End: next.Add(time.Hour),
+		}, next)
+		require.NoError(t, err)
+		require.Equal(t, nodemaintenance.Window{Start: next, End: next.Add(time.Hour)}, status.Accepted)
+	})
+}
+
+func TestNodesInMaintenanceCache(t *testing.T) {
+	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
+		service, err := overlay.NewService(zaptest.NewLogger(t), db.OverlayCache(), overlay.Config{
+			Node:               testNodeSelectionConfig(0, false),
+			NodeSelectionCache: overlay.UploadSelectionCacheConfig{Staleness: time.Hour},
+			Maintenance: overlay.MaintenanceConfig{
+				Enabled:     true,
+				Allowance:   10 * time.Hour,
+				MaxLeadTime: 7 * 24 * time.Hour,
+			},
+		})
+		require.NoError(t, err)
+
+		nodeID, otherID := testrand.NodeID(), testrand.NodeID()
+		now := time.Now()
+		window := nodemaintenance.Window{Start: now.Add(time.Minute), End: now.Add(time.Hour)}
+		_, err = service.UpdateMaintenance(ctx, nodeID, window, now)
+		require.NoError(t, err)
+
+		at := now.Add(2 * time.Minute)
+		nodes, err := service.NodesInMaintenance(ctx, storj.NodeIDList{nodeID, otherID}, at)
+		require.NoError(t, err)
+		require.Equal(t, storj.NodeIDList{nodeID}, nodes)
+
+		// the windows are cached, so changes of the database are seen once
+		// the cache is stale.
+		require.NoError(t, db.OverlayCache().SetMaintenanceWindow(ctx, otherID, window))
+		nodes, err = service.NodesInMaintenance(ctx, storj.NodeIDList{nodeID, otherID}, at)
+		require.NoError(t, err)
+		require.Equal(t, storj.NodeIDList{nodeID}, nodes)
+
+		// the windows reported by nodes are seen right away.
+		_, err = service.UpdateMaintenance(ctx, nodeID, nodemaintenance.Window{}, now)
+		require.NoError(t, err)
+		nodes, err = service.NodesInMaintenance(ctx, storj.NodeIDList{nodeID, otherID}, at)
+		require.NoError(t, err)
+		require.Equal(t, storj.NodeIDList{otherID}, nodes)
+	})
+}
@@ -15,6 +15,7 @@ import (
 	"storj.io/common/pb"
 	"storj.io/common/storj"
 	"storj.io/common/storj/location"
+	"storj.io/storj/private/nodemaintenance"
 	"storj.io/storj/satellite/geoip"
 	"storj.io/storj/satellite/metabase"
 )
@@ -117,6 +118,17 @@ type DB interface {
 	IterateAllNodes(context.Context, func(context.Context, *SelectedNode) error) error
 	// IterateAllNodes will call cb on all known nodes (used for invoice generation).
 	IterateAllNodeDossiers(context.Context, func(context.Context, *NodeDossier) error) error
+
+	// GetMaintenanceWindows returns the maintenance windows of a node ending after since, ordered by their start.
+	GetMaintenanceWindows(ctx context.Context, nodeID storj.NodeID, since time.Time) ([]nodemaintenance.Window, error)
+	// SetMaintenanceWindow inserts a maintenance window of a node or updates the end of the window starting at the same time.
+	SetMaintenanceWindow(ctx context.Context, nodeID storj.NodeID, window nodemaintenance.Window) error
+	// DeleteMaintenanceWindow deletes the maintenance window of a node starting at start.
+	DeleteMaintenanceWindow(ctx context.Context, nodeID storj.NodeID, start time.Time) error
+	// GetNodesInMaintenance returns the nodes which are in a maintenance window at the given time.
+	GetNodesInMaintenance(ctx context.Context, nodeIDs storj.NodeIDList, at time.Time) (storj.NodeIDList, error)
+	// GetActiveMaintenanceWindows returns the maintenance windows of all nodes ending after since.
+	GetActiveMaintenanceWindows(ctx context.Context, since time.Time) (map[storj.NodeID][]nodemaintenance.Window, error)
 }
 
 // DisqualificationReason is disqualification reason enum type.
@@ -163,6 +175,9 @@ type FindStorageNodesRequest struct {
 	MinimumVersion     string        // semver or empty
 	AsOfSystemInterval time.Duration // only used for CRDB queries
 	Placement          storj.PlacementConstraint
+	// ExistingIDs are the nodes holding the other pieces of the segment, used
+	// for diversifying repair and graceful exit targets.
+	ExistingIDs []storj.NodeID
 }
 
 // NodeCriteria are the requirements for selecting nodes.
@@ -259,6 +274,7 @@ type SelectedNode struct {
 	LastNet     string
 	LastIPPort  string
 	CountryCode location.CountryCode
+	Wallet      string
 }
 
 // NodeReputation is used as a result for creating orders limits for audits.
@@ -278,8 +294,10 @@ func (node *SelectedNode) Clone() *SelectedNode {
 			Transport: node.Address.Transport,
 			Address:   node.Address.Address,
 		},
-		LastNet:    node.LastNet,
-		LastIPPort: node.LastIPPort,
+		LastNet:     node.LastNet,
+		LastIPPort:  node.LastIPPort,
+		CountryCode: node.CountryCode,
+		Wallet:      node.Wallet,
 	}
 }
 
@@ -292,8 +310,11 @@ type Service struct {
 	config Config
 
 	GeoIP                  geoip.IPToCountry
+	ASN                    geoip.IPToASN
 	UploadSelectionCache   *UploadSelectionCache
 	DownloadSelectionCache *DownloadSelectionCache
+
+	maintenance *maintenanceCache
 }
 
 // NewService returns a new Service.
@@ -311,12 +332,21 @@ func NewService(log *zap.Logger, db DB, config Config) (*Service, error) {
 		}
 	}
 
+	var asn geoip.IPToASN
+	if config.GeoIP.ASNDatabase != "" {
+		asn, err = geoip.OpenMaxmindDB(config.GeoIP.ASNDatabase)
+		if err != nil {
+			return nil, Error.Wrap(errs.Combine(err, geoIP.Close()))
+		}
+	}
+
 	return &Service{
 		log:    log,
 		db:     db,
 		config: config,
 
 		GeoIP: geoIP,
+		ASN:   asn,
 
 		UploadSelectionCache: NewUploadSelectionCache(log, db,
 			config.NodeSelectionCache.Staleness, config.Node,
@@ -327,11 +357,19 @@ func NewService(log *zap.Logger, db DB, config Config) (*Service, error) {
 			OnlineWindow:   config.Node.OnlineWindow,
 			AsOfSystemTime: config.Node.AsOfSystemTime,
 		}),
+
+		maintenance: &maintenanceCache{
+			db:        db,
+			staleness: config.NodeSelectionCache.Staleness,
+		},
 	}, nil
 }
 
 // Close closes resources.
 func (service *Service) Close() error {
+	if service.ASN != nil {
+		return errs.Combine(service.GeoIP.Close(), service.ASN.Close())
+	}
 	return service.GeoIP.Close()
 }
 
@@ -370,11 +408,41 @@ func (service *Service) IsOnline(node *NodeDossier) bool {
 }
 
 // FindStorageNodesForGracefulExit searches the overlay network for nodes that meet the provided requirements for graceful-exit requests.
+//
+// When repair diversity is enabled, it prefers nodes which diversify the pieces of the segment,
+// otherwise or when that fails, it selects the nodes like for uploads.
+// When the node selection cache is disabled, it selects the nodes from the database.
 func (service *Service) FindStorageNodesForGracefulExit(ctx context.Context, req FindStorageNodesRequest) (_ []*SelectedNode, err error) {
 	defer mon.Task()(&ctx)(&err)
+	if service.config.NodeSelectionCache.Disabled {
+		return service.FindStorageNodesWithPreferences(ctx, req, &service.config.Node)
+	}
+	if service.config.RepairDiversity.Enabled {
+		nodes, err := service.findDiverseStorageNodes(ctx, req)
+		if err == nil {
+			return nodes, nil
+		}
+		service.log.Debug("diverse node selection failed, falling back to upload selection", zap.Error(err))
+	}
 	return service.UploadSelectionCache.GetNodes(ctx, req)
 }
 
+// FindStorageNodesForRepair searches the overlay network for nodes to store the repaired pieces of a segment.
+//
+// When repair diversity is enabled, it prefers nodes which diversify the pieces of the segment,
+// otherwise it selects the nodes like for uploads.
+func (service *Service) FindStorageNodesForRepair(ctx context.Context, req FindStorageNodesRequest) (_ []*SelectedNode, err error) {
+	defer mon.Task()(&ctx)(&err)
+	if service.config.RepairDiversity.Enabled && !service.config.NodeSelectionCache.Disabled {
+		nodes, err := service.findDiverseStorageNodes(ctx, req)
+		if err == nil {
+			return nodes, nil
+		}
+		service.log.Debug("diverse node selection failed, falling back to upload selection", zap.Error(err))
+	}
+	return service.FindStorageNodesForUpload(ctx, req)
+}
+
 // FindStorageNodesForUpload searches the overlay network for nodes that meet the provided requirements for upload.
 //
 // When enabled it uses the cache to select nodes.
@@ -867,3 +867,48 @@ func TestKnownReliableInExcludedCountries(t *testing.T) {
 		require.Equal(t, node.ID(), nodes[0])
 	})
 }
+
+func TestFindStorageNodesForGracefulExitCacheDisabled(t *testing.T) {
+	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
+		config := overlay.Config{
+			Node:               testNodeSelectionConfig(0, false),
+			NodeSelectionCache: overlay.UploadSelectionCacheConfig{Disabled: true, Staleness: time.Hour},
+			RepairDiversity:    overlay.DiversityConfig{Enabled: true, CandidateMultiplier: 2},
+		}
+		service, err := overlay.NewService(zaptest.NewLogger(t), db.OverlayCache(), config)
+		require.NoError(t, err)
+		defer ctx.Check(service.Close)
+
+		// a cached selection would not see the nodes added afterwards.
+		_, err = service.FindStorageNodesForGracefulExit(ctx, overlay.FindStorageNodesRequest{RequestedCount: 1})
+		require.Error(t, err)
+
+		var nodeIDs []storj.NodeID
+		for i := 0; i < 3; i++ {
+			nodeID := testrand.NodeID()
+			addr := fmt.Sprintf("127.0.%d.0:8080", i)
+			err := db.OverlayCache().UpdateCheckIn(ctx, overlay.NodeCheckInInfo{
+				NodeID:     nodeID,
+				Address:    &pb.NodeAddress{Address: addr},
+				LastIPPort: addr,
+				LastNet:    fmt.Sprintf("127.0.%d", i),
+				Version:    &pb.NodeVersion{Version: "v1.0.0"},
+				Capacity:   &pb.NodeCapacity{FreeDisk: memory.GB.Int64()},
+				IsUp:       true,
+			}, time.Now(), config.Node)
+			require.NoError(t, err)
+			_, err = db.OverlayCache().TestVetNode(ctx, nodeID)
+			require.NoError(t, err)
+			nodeIDs = append(nodeIDs, nodeID)
+		}
+
+		nodes, err := service.FindStorageNodesForGracefulExit(ctx, overlay.FindStorageNodesRequest{
+			RequestedCount: 1,
+			ExcludedIDs:    nodeIDs[:2],
+			ExistingIDs:    nodeIDs[1:2],
+		})
+		require.NoError(t, err)
+		require.Len(t, nodes, 1)
+		require.Equal(t, nodeIDs[2], nodes[0].ID)
+	})
+}
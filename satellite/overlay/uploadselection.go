@@ -142,6 +142,7 @@ func convNodesToSelectedNodes(nodes []*uploadselection.Node) (xs []*SelectedNode
 			LastNet:     n.LastNet,
 			LastIPPort:  n.LastIPPort,
 			CountryCode: n.CountryCode,
+			Wallet:      n.Wallet,
 		})
 	}
 	return xs
@@ -157,6 +158,7 @@ func convSelectedNodesToNodes(nodes []*SelectedNode) (xs []*uploadselection.Node
 			LastNet:     n.LastNet,
 			LastIPPort:  n.LastIPPort,
 			CountryCode: n.CountryCode,
+			Wallet:      n.Wallet,
 		})
 	}
 	return xs
@@ -29,6 +29,12 @@ type Accounts interface {
 	// ProjectCharges returns how much money current user will be charged for each project.
 	ProjectCharges(ctx context.Context, userID uuid.UUID, since, before time.Time) ([]ProjectCharge, error)
 
+	// ProjectCharge returns how much money the project will be charged for its usage in the period.
+	ProjectCharge(ctx context.Context, projectID uuid.UUID, since, before time.Time) (ProjectCharge, error)
+
+	// BucketCharges returns how much money each bucket of the project will be charged for its usage in the period.
+	BucketCharges(ctx context.Context, projectID uuid.UUID, since, before time.Time) ([]BucketCharge, error)
+
 	// CheckProjectInvoicingStatus returns error if for the given project there are outstanding project records and/or usage
 	// which have not been applied/invoiced yet (meaning sent over to stripe).
 	CheckProjectInvoicingStatus(ctx context.Context, projectID uuid.UUID) error
@@ -22,6 +22,8 @@ const (
 	Stripe
 	// Coinpayments defines transactions which are originated from coinpayments.
 	Coinpayments
+	// Invoicing defines transactions which pay invoices of the built-in invoicing.
+	Invoicing
 )
 
 // Int returns int representation of transaction type.
@@ -38,6 +40,8 @@ func (t TXType) String() string {
 		return "Stripe"
 	case Coinpayments:
 		return "Coinpayments"
+	case Invoicing:
+		return "Invoicing"
 	default:
 		return fmt.Sprintf("%d", int(t))
 	}
@@ -0,0 +1,362 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package invoicing
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/payments"
+)
+
+// ensures that accounts implements payments.Accounts.
+var _ payments.Accounts = (*accounts)(nil)
+
+// accounts is an implementation of payments.Accounts.
+//
+// architecture: Service
+type accounts struct {
+	service *Service
+}
+
+// CreditCards exposes all needed functionality to manage account credit cards.
+func (accounts *accounts) CreditCards() payments.CreditCards {
+	return &creditCards{}
+}
+
+// Invoices exposes all needed functionality to manage account invoices.
+func (accounts *accounts) Invoices() payments.Invoices {
+	return &invoices{service: accounts.service}
+}
+
+// StorjTokens exposes all storj token related functionality.
+func (accounts *accounts) StorjTokens() payments.StorjTokens {
+	return &storjTokens{}
+}
+
+// Coupons exposes all needed functionality to manage coupons.
+func (accounts *accounts) Coupons() payments.Coupons {
+	return &coupons{}
+}
+
+// Setup creates a payment account for the user.
+// The built-in invoicing bills the users and organizations directly, so
+// there is nothing to set up.
+func (accounts *accounts) Setup(ctx context.Context, userID uuid.UUID, email string, signupPromoCode string) (_ payments.CouponType, err error) {
+	defer mon.Task()(&ctx, userID, email)(&err)
+	return payments.NoCoupon, nil
+}
+
+// Balance returns an integer amount in cents that represents the current balance of payment account.
+func (accounts *accounts) Balance(ctx context.Context, userID uuid.UUID) (_ payments.Balance, err error) {
+	defer mon.Task()(&ctx, userID)(&err)
+
+	balance, err := accounts.service.billingDB.ComputeBalance(ctx, userID)
+	if err != nil {
+		return payments.Balance{}, Error.Wrap(err)
+	}
+
+	return payments.Balance{
+		Coins: balance.BaseUnits(),
+	}, nil
+}
+
+// ProjectCharges returns how much money current user will be charged for each project.
+func (accounts *accounts) ProjectCharges(ctx context.Context, userID uuid.UUID, since, before time.Time) (charges []payments.ProjectCharge, err error) {
+	defer mon.Task()(&ctx, userID, since, before)(&err)
+
+	// to return empty slice instead of nil if there are no projects
+	charges = make([]payments.ProjectCharge, 0)
+
+	projects, err := accounts.service.consoleDB.Projects().GetOwn(ctx, userID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	organizationProjects, err := accounts.service.consoleDB.Projects().GetByOrganizationID(ctx, userID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	for _, project := range append(projects, organizationProjects...) {
+		if project.BillingAccountID() != userID {
+			continue
+		}
+
+		charge, err := accounts.projectCharge(ctx, project.ID, since, before)
+		if err != nil {
+			return charges, Error.Wrap(err)
+		}
+
+		charges = append(charges, charge)
+	}
+
+	return charges, nil
+}
+
+// ProjectCharge returns how much money the project will be charged for its usage in the period.
+func (accounts *accounts) ProjectCharge(ctx context.Context, projectID uuid.UUID, since, before time.Time) (_ payments.ProjectCharge, err error) {
+	defer mon.Task()(&ctx, projectID, since, before)(&err)
+
+	charge, err := accounts.projectCharge(ctx, projectID, since, before)
+	return charge, Error.Wrap(err)
+}
+
+// projectCharge calculates the price of the usage of the project in the period.
+func (accounts *accounts) projectCharge(ctx context.Context, projectID uuid.UUID, since, before time.Time) (_ payments.ProjectCharge, err error) {
+	usage, err := accounts.service.usageDB.GetProjectTotal(ctx, projectID, since, before)
+	if err != nil {
+		return payments.ProjectCharge{}, err
+	}
+
+	service := accounts.service
+	return payments.ProjectCharge{
+		ProjectUsage: *usage,
+
+		ProjectID:    projectID,
+		StorageGbHrs: service.StorageMBMonthPriceCents.Mul(storageMBMonthDecimal(usage.Storage)).Round(0).IntPart(),
+		Egress:       service.EgressMBPriceCents.Mul(egressMBDecimal(usage.Egress)).Round(0).IntPart(),
+		SegmentCount: service.SegmentMonthPriceCents.Mul(segmentMonthDecimal(usage.SegmentCount)).Round(0).IntPart(),
+	}, nil
+}
+
+// BucketCharges returns how much money each bucket of the project will be charged for its usage in the period.
+func (accounts *accounts) BucketCharges(ctx context.Context, projectID uuid.UUID, since, before time.Time) (_ []payments.BucketCharge, err error) {
+	defer mon.Task()(&ctx, projectID, since, before)(&err)
+
+	rollups, err := accounts.service.usageDB.GetBucketUsageRollups(ctx, projectID, since, before)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	storagePrice, _ := accounts.service.StorageMBMonthPriceCents.Float64()
+	egressPrice, _ := accounts.service.EgressMBPriceCents.Float64()
+	segmentPrice, _ := accounts.service.SegmentMonthPriceCents.Float64()
+
+	charges := make([]payments.BucketCharge, 0, len(rollups))
+	for _, rollup := range rollups {
+		charges = append(charges, payments.BucketCharge{
+			BucketUsageRollup: rollup,
+
+			// the rollups are in GB*Hrs, GB and segment*Hrs.
+			StoragePrice: storagePrice * rollup.TotalStoredData * 1000 / hoursPerMonth,
+			EgressPrice:  egressPrice * rollup.GetEgress * 1000,
+			SegmentPrice: segmentPrice * rollup.TotalSegments / hoursPerMonth,
+		})
+	}
+
+	return charges, nil
+}
+
+// CheckProjectInvoicingStatus returns error if the project is on a draft
+// invoice of the previous month, which hasn't been sent yet.
+func (accounts *accounts) CheckProjectInvoicingStatus(ctx context.Context, projectID uuid.UUID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	project, err := accounts.service.consoleDB.Projects().Get(ctx, projectID)
+	if err != nil {
+		return err
+	}
+
+	start, _ := periodBounds(accounts.service.nowFn().UTC().AddDate(0, -1, 0))
+
+	invoice, err := accounts.service.db.GetByPeriod(ctx, project.BillingAccountID(), start)
+	if ErrInvoiceNotFound.Has(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if invoice.Status != StatusDraft {
+		return nil
+	}
+	for _, item := range invoice.Items {
+		if item.ProjectID == projectID {
+			return errs.New("unsent invoice of the project exists")
+		}
+	}
+
+	return nil
+}
+
+// CheckProjectUsageStatus returns error if for the given project there is some usage for current or previous month.
+func (accounts *accounts) CheckProjectUsageStatus(ctx context.Context, projectID uuid.UUID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	now := accounts.service.nowFn()
+	firstOfMonth, _ := periodBounds(now)
+
+	// check current month usage and do not allow deletion if usage exists
+	currentUsage, err := accounts.service.usageDB.GetProjectTotal(ctx, projectID, firstOfMonth, now)
+	if err != nil {
+		return err
+	}
+	if currentUsage.Storage > 0 || currentUsage.Egress > 0 || currentUsage.SegmentCount > 0 {
+		return errs.New("usage for current month exists")
+	}
+
+	// check usage for last month, if exists, ensure it has been invoiced.
+	lastMonth := firstOfMonth.AddDate(0, -1, 0)
+	lastMonthUsage, err := accounts.service.usageDB.GetProjectTotal(ctx, projectID, lastMonth, firstOfMonth)
+	if err != nil {
+		return err
+	}
+	if lastMonthUsage.Storage > 0 || lastMonthUsage.Egress > 0 || lastMonthUsage.SegmentCount > 0 {
+		project, err := accounts.service.consoleDB.Projects().Get(ctx, projectID)
+		if err != nil {
+			return err
+		}
+
+		_, err = accounts.service.db.GetByPeriod(ctx, project.BillingAccountID(), lastMonth)
+		if ErrInvoiceNotFound.Has(err) {
+			return errs.New("usage for last month exist, but is not billed yet")
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Charges returns list of all credit card charges related to account.
+// The built-in invoicing doesn't charge credit cards.
+func (accounts *accounts) Charges(ctx context.Context, userID uuid.UUID) (_ []payments.Charge, err error) {
+	defer mon.Task()(&ctx, userID)(&err)
+	return nil, nil
+}
+
+// invoices is an implementation of payments.Invoices.
+//
+// architecture: Service
+type invoices struct {
+	service *Service
+}
+
+// List returns a list of invoices for a given payment account.
+func (invoices *invoices) List(ctx context.Context, userID uuid.UUID) (_ []payments.Invoice, err error) {
+	defer mon.Task()(&ctx, userID)(&err)
+
+	stored, err := invoices.service.db.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	var list []payments.Invoice
+	for _, invoice := range stored {
+		// drafts may still change, so they aren't shown until they are sent.
+		if invoice.Status == StatusDraft {
+			continue
+		}
+
+		list = append(list, payments.Invoice{
+			ID:          invoice.ID.String(),
+			Description: invoice.Description,
+			Amount:      invoice.Amount,
+			Status:      string(invoice.Status),
+			Start:       invoice.PeriodStart,
+			End:         invoice.PeriodEnd,
+		})
+	}
+
+	return list, nil
+}
+
+// ListWithDiscounts returns a list of invoices and coupon usages for a given payment account.
+func (invoices *invoices) ListWithDiscounts(ctx context.Context, userID uuid.UUID) (_ []payments.Invoice, _ []payments.CouponUsage, err error) {
+	defer mon.Task()(&ctx, userID)(&err)
+
+	list, err := invoices.List(ctx, userID)
+	return list, nil, err
+}
+
+// CheckPendingItems returns if pending invoice items for a given payment account exist.
+func (invoices *invoices) CheckPendingItems(ctx context.Context, userID uuid.UUID) (existingItems bool, err error) {
+	defer mon.Task()(&ctx, userID)(&err)
+
+	stored, err := invoices.service.db.ListByUserID(ctx, userID)
+	if err != nil {
+		return false, Error.Wrap(err)
+	}
+
+	for _, invoice := range stored {
+		if invoice.Status == StatusDraft {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// creditCards is an implementation of payments.CreditCards, which doesn't
+// support any credit cards.
+//
+// architecture: Service
+type creditCards struct{}
+
+// List returns a list of credit cards for a given payment account.
+func (creditCards *creditCards) List(ctx context.Context, userID uuid.UUID) ([]payments.CreditCard, error) {
+	return nil, nil
+}
+
+// Add is used to save new credit card and attach it to payment account.
+func (creditCards *creditCards) Add(ctx context.Context, userID uuid.UUID, cardToken string) error {
+	return ErrUnsupported.New("credit cards")
+}
+
+// Remove is used to detach a credit card from payment account.
+func (creditCards *creditCards) Remove(ctx context.Context, userID uuid.UUID, cardID string) error {
+	return ErrUnsupported.New("credit cards")
+}
+
+// RemoveAll is used to detach all credit cards from payment account.
+func (creditCards *creditCards) RemoveAll(ctx context.Context, userID uuid.UUID) error {
+	return nil
+}
+
+// MakeDefault makes a credit card default payment method.
+func (creditCards *creditCards) MakeDefault(ctx context.Context, userID uuid.UUID, cardID string) error {
+	return ErrUnsupported.New("credit cards")
+}
+
+// storjTokens is an implementation of payments.StorjTokens. Tokens are paid
+// to the deposit wallets instead of through deposit transactions.
+//
+// architecture: Service
+type storjTokens struct{}
+
+// Deposit creates deposit transaction for specified amount in cents.
+func (tokens *storjTokens) Deposit(ctx context.Context, userID uuid.UUID, amount int64) (*payments.Transaction, error) {
+	return nil, ErrUnsupported.New("deposit transactions")
+}
+
+// ListTransactionInfos returns all transactions associated with user.
+func (tokens *storjTokens) ListTransactionInfos(ctx context.Context, userID uuid.UUID) ([]payments.TransactionInfo, error) {
+	return nil, nil
+}
+
+// ListDepositBonuses returns all deposit bonuses associated with user.
+func (tokens *storjTokens) ListDepositBonuses(ctx context.Context, userID uuid.UUID) ([]payments.DepositBonus, error) {
+	return nil, nil
+}
+
+// coupons is an implementation of payments.Coupons, which doesn't support
+// any coupons.
+//
+// architecture: Service
+type coupons struct{}
+
+// GetByUserID returns the coupon applied to the specified user.
+func (coupons *coupons) GetByUserID(ctx context.Context, userID uuid.UUID) (*payments.Coupon, error) {
+	return nil, nil
+}
+
+// ApplyCouponCode attempts to apply a coupon code to the user.
+func (coupons *coupons) ApplyCouponCode(ctx context.Context, userID uuid.UUID, couponCode string) (*payments.Coupon, error) {
+	return nil, ErrUnsupported.New("coupons")
+}
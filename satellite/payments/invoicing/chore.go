@@ -0,0 +1,58 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package invoicing
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/sync2"
+)
+
+// Chore credits the STORJ token payments and pays the open invoices from
+// the balances.
+//
+// architecture: Chore
+type Chore struct {
+	log     *zap.Logger
+	service *Service
+
+	Loop *sync2.Cycle
+}
+
+// NewChore creates new chore.
+func NewChore(log *zap.Logger, service *Service, interval time.Duration) *Chore {
+	return &Chore{
+		log:     log,
+		service: service,
+		Loop:    sync2.NewCycle(interval),
+	}
+}
+
+// Run runs the chore.
+func (chore *Chore) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return chore.Loop.Run(ctx, func(ctx context.Context) error {
+		if err := chore.service.CreditTokenPayments(ctx); err != nil {
+			chore.log.Error("error crediting token payments", zap.Error(err))
+		}
+
+		if err := chore.service.PayInvoices(ctx); err != nil {
+			chore.log.Error("error paying invoices from balances", zap.Error(err))
+		}
+
+		return nil
+	})
+}
+
+// Close closes all underlying resources.
+func (chore *Chore) Close() (err error) {
+	defer mon.Task()(nil)(&err)
+
+	chore.Loop.Close()
+	return nil
+}
@@ -0,0 +1,73 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package invoicing
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/uuid"
+)
+
+// ErrInvoiceNotFound is returned when the invoice doesn't exist.
+var ErrInvoiceNotFound = errs.Class("invoice not found")
+
+// DB stores the invoices of the built-in invoicing.
+//
+// architecture: Database
+type DB interface {
+	// Insert inserts the invoice.
+	Insert(ctx context.Context, invoice Invoice) error
+	// Get returns the invoice with the ID.
+	Get(ctx context.Context, id uuid.UUID) (Invoice, error)
+	// GetByPeriod returns the invoice of the payment account for the period starting at periodStart.
+	GetByPeriod(ctx context.Context, userID uuid.UUID, periodStart time.Time) (Invoice, error)
+	// ListByUserID returns the invoices of the payment account, the latest period first.
+	ListByUserID(ctx context.Context, userID uuid.UUID) ([]Invoice, error)
+	// ListByStatus returns the invoices with the status, the earliest period first.
+	ListByStatus(ctx context.Context, status Status) ([]Invoice, error)
+	// UpdateStatus sets the status of the invoice and the time it was paid at.
+	UpdateStatus(ctx context.Context, id uuid.UUID, status Status, paidAt *time.Time) error
+}
+
+// Status is the payment state of an invoice.
+type Status string
+
+const (
+	// StatusDraft is an invoice which hasn't been sent to the customer yet.
+	StatusDraft Status = "draft"
+	// StatusOpen is an invoice which has been sent and waits for the payment.
+	StatusOpen Status = "open"
+	// StatusPaid is an invoice which has been paid.
+	StatusPaid Status = "paid"
+)
+
+// Invoice is the bill of a payment account for the usage of its projects in a month.
+type Invoice struct {
+	ID uuid.UUID
+	// UserID is the ID of the payment account, which is a user or an organization.
+	UserID      uuid.UUID
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	Description string
+	Items       []Item
+	// Amount is the total of the items in cents.
+	Amount    int64
+	Status    Status
+	CreatedAt time.Time
+	PaidAt    *time.Time
+}
+
+// Item is a line of an invoice.
+type Item struct {
+	ProjectID   uuid.UUID `json:"projectId"`
+	Description string    `json:"description"`
+	Quantity    int64     `json:"quantity"`
+	// UnitPrice is the price of a unit in cents.
+	UnitPrice float64 `json:"unitPrice"`
+	// Amount is the price of the line rounded to cents.
+	Amount int64 `json:"amount"`
+}
@@ -0,0 +1,203 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package invoicing
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"time"
+)
+
+// dateFormat is the format of the dates on invoices.
+const dateFormat = "2006-01-02"
+
+// formatCents formats an amount in cents as dollars.
+func formatCents(cents int64) string {
+	sign := ""
+	if cents < 0 {
+		sign, cents = "-", -cents
+	}
+	return fmt.Sprintf("%s$%d.%02d", sign, cents/100, cents%100)
+}
+
+// formatUnitPrice formats a price in cents, which may be a fraction of a cent, as dollars.
+func formatUnitPrice(cents float64) string {
+	return "$" + strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.8f", cents/100), "0"), ".")
+}
+
+var invoiceHTML = template.Must(template.New("invoice").Funcs(template.FuncMap{
+	"cents":     formatCents,
+	"unitPrice": formatUnitPrice,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{ .Invoice.Description }}</title>
+<style>
+body { font-family: sans-serif; color: #1b2533; }
+table { border-collapse: collapse; width: 100%; }
+th, td { padding: 6px 8px; border-bottom: 1px solid #dadde5; text-align: right; }
+th:first-child, td:first-child { text-align: left; }
+tfoot td { font-weight: bold; border-bottom: none; }
+</style>
+</head>
+<body>
+<h1>{{ .Issuer }}</h1>
+<h2>{{ .Invoice.Description }}</h2>
+<p>
+Invoice: {{ .Invoice.ID }}<br>
+Period: {{ .Invoice.PeriodStart.Format "2006-01-02" }} to {{ .LastDay.Format "2006-01-02" }}<br>
+Status: {{ .Invoice.Status }}
+</p>
+<table>
+<thead>
+<tr><th>Description</th><th>Quantity</th><th>Unit price</th><th>Amount</th></tr>
+</thead>
+<tbody>
+{{- range .Invoice.Items }}
+<tr><td>{{ .Description }}</td><td>{{ .Quantity }}</td><td>{{ unitPrice .UnitPrice }}</td><td>{{ cents .Amount }}</td></tr>
+{{- end }}
+</tbody>
+<tfoot>
+<tr><td>Total</td><td></td><td></td><td>{{ cents .Invoice.Amount }}</td></tr>
+</tfoot>
+</table>
+</body>
+</html>
+`))
+
+// RenderHTML writes the invoice as an HTML document.
+func (service *Service) RenderHTML(w io.Writer, invoice Invoice) error {
+	return Error.Wrap(invoiceHTML.Execute(w, struct {
+		Issuer  string
+		Invoice Invoice
+		LastDay time.Time
+	}{
+		Issuer:  service.issuer,
+		Invoice: invoice,
+		LastDay: invoice.PeriodEnd.AddDate(0, 0, -1),
+	}))
+}
+
+// RenderPDF writes the invoice as a PDF document.
+func (service *Service) RenderPDF(w io.Writer, invoice Invoice) error {
+	const descriptionWidth = 48
+
+	lines := []string{
+		service.issuer,
+		"",
+		invoice.Description,
+		"",
+		"Invoice: " + invoice.ID.String(),
+		fmt.Sprintf("Period:  %s to %s", invoice.PeriodStart.Format(dateFormat), invoice.PeriodEnd.AddDate(0, 0, -1).Format(dateFormat)),
+		"Status:  " + string(invoice.Status),
+		"",
+		fmt.Sprintf("%-*s %12s %14s %12s", descriptionWidth, "Description", "Quantity", "Unit price", "Amount"),
+		strings.Repeat("-", descriptionWidth+41),
+	}
+
+	for _, item := range invoice.Items {
+		description := item.Description
+		if len(description) > descriptionWidth {
+			description = description[:descriptionWidth-3] + "..."
+		}
+		lines = append(lines, fmt.Sprintf("%-*s %12d %14s %12s",
+			descriptionWidth, description, item.Quantity, formatUnitPrice(item.UnitPrice), formatCents(item.Amount)))
+	}
+
+	lines = append(lines,
+		strings.Repeat("-", descriptionWidth+41),
+		fmt.Sprintf("%-*s %40s", descriptionWidth, "Total", formatCents(invoice.Amount)),
+	)
+
+	_, err := w.Write(textPDF(lines))
+	return Error.Wrap(err)
+}
+
+// PDF page layout in points of US Letter pages.
+const (
+	pdfPageWidth    = 612
+	pdfPageHeight   = 792
+	pdfMargin       = 50
+	pdfFontSize     = 8
+	pdfLeading      = 11
+	pdfLinesPerPage = (pdfPageHeight - 2*pdfMargin) / pdfLeading
+)
+
+// textPDF lays out the lines in a monospace font on as many pages as needed.
+func textPDF(lines []string) []byte {
+	var pages [][]string
+	for len(lines) > pdfLinesPerPage {
+		pages = append(pages, lines[:pdfLinesPerPage])
+		lines = lines[pdfLinesPerPage:]
+	}
+	pages = append(pages, lines)
+
+	// objects are numbered from 1: the catalog, the page tree, the font and
+	// then a page and its content for every page.
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Courier >>",
+	}
+
+	var kids []string
+	for _, page := range pages {
+		pageObject := len(objects) + 1
+		kids = append(kids, fmt.Sprintf("%d 0 R", pageObject))
+
+		var content bytes.Buffer
+		fmt.Fprintf(&content, "BT /F1 %d Tf %d TL %d %d Td\n", pdfFontSize, pdfLeading, pdfMargin, pdfPageHeight-pdfMargin)
+		for _, line := range page {
+			fmt.Fprintf(&content, "(%s) Tj T*\n", escapePDFText(line))
+		}
+		content.WriteString("ET")
+
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+				pdfPageWidth, pdfPageHeight, pageObject+1),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.String()),
+		)
+	}
+	objects[1] = fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages))
+
+	var pdf bytes.Buffer
+	pdf.WriteString("%PDF-1.4\n")
+
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = pdf.Len()
+		fmt.Fprintf(&pdf, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xref := pdf.Len()
+	fmt.Fprintf(&pdf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&pdf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&pdf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+
+	return pdf.Bytes()
+}
+
+// escapePDFText escapes the text for a PDF string. The standard fonts cover
+// only ASCII reliably, so other characters are replaced.
+func escapePDFText(text string) string {
+	var escaped strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			escaped.WriteByte('\\')
+			escaped.WriteRune(r)
+		case r < ' ' || r > '~':
+			escaped.WriteByte('?')
+		default:
+			escaped.WriteRune(r)
+		}
+	}
+	return escaped.String()
+}
@@ -0,0 +1,103 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package invoicing_test
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite/payments/invoicing"
+)
+
+func newRenderService(t *testing.T) *invoicing.Service {
+	service, err := invoicing.NewService(zaptest.NewLogger(t), invoicing.Config{Issuer: "Test Satellite"},
+		nil, nil, nil, nil, nil, nil, nil, "4", "7", "0.0000088")
+	require.NoError(t, err)
+	return service
+}
+
+func testInvoice(items int) invoicing.Invoice {
+	invoice := invoicing.Invoice{
+		ID:          testrand.UUID(),
+		UserID:      testrand.UUID(),
+		PeriodStart: time.Date(2022, time.March, 1, 0, 0, 0, 0, time.UTC),
+		PeriodEnd:   time.Date(2022, time.April, 1, 0, 0, 0, 0, time.UTC),
+		Description: "Storj DCS usage for March 2022",
+		Status:      invoicing.StatusOpen,
+	}
+	for i := 0; i < items; i++ {
+		item := invoicing.Item{
+			ProjectID:   testrand.UUID(),
+			Description: "Project (x) <" + strconv.Itoa(i) + "> - Egress Bandwidth (MB)",
+			Quantity:    1234,
+			UnitPrice:   0.0007,
+			Amount:      101,
+		}
+		invoice.Items = append(invoice.Items, item)
+		invoice.Amount += item.Amount
+	}
+	return invoice
+}
+
+func TestRenderHTML(t *testing.T) {
+	service := newRenderService(t)
+	invoice := testInvoice(2)
+
+	var html bytes.Buffer
+	require.NoError(t, service.RenderHTML(&html, invoice))
+
+	require.Contains(t, html.String(), "Test Satellite")
+	require.Contains(t, html.String(), invoice.ID.String())
+	require.Contains(t, html.String(), "2022-03-01 to 2022-03-31")
+	require.Contains(t, html.String(), "Project (x) &lt;1&gt; - Egress Bandwidth (MB)")
+	require.Contains(t, html.String(), "$0.000007")
+	require.Contains(t, html.String(), "$1.01")
+	require.Contains(t, html.String(), "$2.02")
+}
+
+func TestRenderPDF(t *testing.T) {
+	service := newRenderService(t)
+
+	for _, tt := range []struct {
+		items int
+		pages int
+	}{
+		{items: 1, pages: 1},
+		{items: 200, pages: 4},
+	} {
+		invoice := testInvoice(tt.items)
+
+		var pdf bytes.Buffer
+		require.NoError(t, service.RenderPDF(&pdf, invoice))
+		data := pdf.Bytes()
+
+		require.True(t, bytes.HasPrefix(data, []byte("%PDF-1.4\n")))
+		require.True(t, bytes.HasSuffix(data, []byte("%%EOF\n")))
+		require.Contains(t, string(data), "/Count "+strconv.Itoa(tt.pages)+" ")
+		require.Contains(t, string(data), `Project \(x\) <0> - Egress Bandwidth \(MB\)`)
+		require.Contains(t, string(data), invoice.ID.String())
+
+		// every object has to be at the offset listed in the cross-reference table.
+		startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(data)
+		require.NotNil(t, startxref)
+		xref, err := strconv.Atoi(string(startxref[1]))
+		require.NoError(t, err)
+		require.True(t, bytes.HasPrefix(data[xref:], []byte("xref\n")))
+
+		offsets := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(data[xref:], -1)
+		require.Len(t, offsets, 3+2*tt.pages)
+		for i, offset := range offsets {
+			at, err := strconv.Atoi(string(offset[1]))
+			require.NoError(t, err)
+			require.True(t, bytes.HasPrefix(data[at:], []byte(strconv.Itoa(i+1)+" 0 obj\n")))
+		}
+	}
+}
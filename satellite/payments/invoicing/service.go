@@ -0,0 +1,593 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package invoicing
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/private/post"
+	"storj.io/storj/satellite/accounting"
+	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/mailservice"
+	"storj.io/storj/satellite/payments"
+	"storj.io/storj/satellite/payments/billing"
+	"storj.io/storj/satellite/payments/monetary"
+	"storj.io/storj/satellite/payments/storjscan"
+)
+
+var (
+	// Error defines invoicing service error.
+	Error = errs.Class("invoicing service")
+
+	// ErrUnsupported is returned for the payment methods which the built-in invoicing doesn't support.
+	ErrUnsupported = errs.Class("not supported by the built-in invoicing")
+
+	mon = monkit.Package()
+)
+
+// hoursPerMonth is the number of hours in a billing month. For the purpose of billing, the billing month is always 30 days.
+const hoursPerMonth = 24 * 30
+
+// Config stores needed information for the built-in invoicing.
+type Config struct {
+	Issuer           string        `help:"issuer of the invoices, shown at their top" default:"Storj DCS"`
+	StorjTokenPrice  string        `help:"price of a STORJ token in dollars used to credit token payments, token payments aren't credited if empty" default:""`
+	PaymentsInterval time.Duration `help:"how often to credit token payments and pay the open invoices from the balances" default:"1h" testDefault:"$TESTINTERVAL"`
+	ListingLimit     int           `help:"sets the maximum amount of items before we start paging on requests" default:"100" hidden:"true"`
+}
+
+// Service is the built-in invoicing, which bills the payment accounts
+// without Stripe. It stores the invoices in the satellite database, emails
+// them and tracks their payment manually or from the STORJ token balance.
+//
+// architecture: Service
+type Service struct {
+	log               *zap.Logger
+	db                DB
+	billingDB         billing.TransactionsDB
+	consoleDB         console.DB
+	usageDB           accounting.ProjectAccounting
+	walletsDB         storjscan.WalletsDB
+	storjscanPayments storjscan.PaymentsDB
+	mail              *mailservice.Service
+
+	StorageMBMonthPriceCents decimal.Decimal
+	EgressMBPriceCents       decimal.Decimal
+	SegmentMonthPriceCents   decimal.Decimal
+	// StorjTokenPrice is the price of a token in dollars, or zero if token
+	// payments aren't credited.
+	StorjTokenPrice decimal.Decimal
+
+	issuer       string
+	listingLimit int
+	nowFn        func() time.Time
+}
+
+// NewService creates a Service instance.
+func NewService(log *zap.Logger, config Config, db DB, billingDB billing.TransactionsDB, consoleDB console.DB, usageDB accounting.ProjectAccounting, walletsDB storjscan.WalletsDB, storjscanPayments storjscan.PaymentsDB, mail *mailservice.Service, storageTBPrice, egressTBPrice, segmentPrice string) (*Service, error) {
+	storageTBMonthDollars, err := decimal.NewFromString(storageTBPrice)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	egressTBDollars, err := decimal.NewFromString(egressTBPrice)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	segmentMonthDollars, err := decimal.NewFromString(segmentPrice)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	var storjTokenPrice decimal.Decimal
+	if config.StorjTokenPrice != "" {
+		storjTokenPrice, err = decimal.NewFromString(config.StorjTokenPrice)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+	}
+
+	return &Service{
+		log:               log,
+		db:                db,
+		billingDB:         billingDB,
+		consoleDB:         consoleDB,
+		usageDB:           usageDB,
+		walletsDB:         walletsDB,
+		storjscanPayments: storjscanPayments,
+		mail:              mail,
+
+		// change the precision from TB dollars to MB cents
+		StorageMBMonthPriceCents: storageTBMonthDollars.Shift(-6).Shift(2),
+		EgressMBPriceCents:       egressTBDollars.Shift(-6).Shift(2),
+		SegmentMonthPriceCents:   segmentMonthDollars.Shift(2),
+		StorjTokenPrice:          storjTokenPrice,
+
+		issuer:       config.Issuer,
+		listingLimit: config.ListingLimit,
+		nowFn:        time.Now,
+	}, nil
+}
+
+// Accounts exposes all needed functionality to manage payment accounts.
+func (service *Service) Accounts() payments.Accounts {
+	return &accounts{service: service}
+}
+
+// periodBounds returns the start and the end of the month of the period.
+func periodBounds(period time.Time) (start, end time.Time) {
+	utc := period.UTC()
+	start = time.Date(utc.Year(), utc.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 1, 0)
+}
+
+// CreateInvoices creates a draft invoice for the usage in the month of the
+// period for every payment account which doesn't have one yet.
+func (service *Service) CreateInvoices(ctx context.Context, period time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	start, end := periodBounds(period)
+	if end.After(service.nowFn()) {
+		return Error.New("allowed for past periods only")
+	}
+
+	var accountIDs []uuid.UUID
+	accountProjects := make(map[uuid.UUID][]console.Project)
+
+	var offset int64
+	for {
+		if err = ctx.Err(); err != nil {
+			return Error.Wrap(err)
+		}
+
+		page, err := service.consoleDB.Projects().List(ctx, offset, service.listingLimit, end)
+		if err != nil {
+			return Error.Wrap(err)
+		}
+
+		for _, project := range page.Projects {
+			accountID := project.BillingAccountID()
+			if _, ok := accountProjects[accountID]; !ok {
+				accountIDs = append(accountIDs, accountID)
+			}
+			accountProjects[accountID] = append(accountProjects[accountID], project)
+		}
+
+		if !page.Next {
+			break
+		}
+		offset = page.NextOffset
+	}
+
+	var invoices int
+	for _, accountID := range accountIDs {
+		if err = ctx.Err(); err != nil {
+			return Error.Wrap(err)
+		}
+
+		created, err := service.createInvoice(ctx, accountID, accountProjects[accountID], start, end)
+		if err != nil {
+			return Error.Wrap(err)
+		}
+		if created {
+			invoices++
+		}
+	}
+
+	service.log.Info("Number of created draft invoices.", zap.Int("Invoices", invoices))
+	return nil
+}
+
+// createInvoice creates the draft invoice of the payment account for the
+// usage of its projects. It returns false if the account has no charges or
+// already has an invoice for the period.
+func (service *Service) createInvoice(ctx context.Context, accountID uuid.UUID, projects []console.Project, start, end time.Time) (created bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = service.db.GetByPeriod(ctx, accountID, start)
+	if err == nil {
+		service.log.Warn("Invoice for this payment account already exists.", zap.Stringer("Account ID", accountID))
+		return false, nil
+	}
+	if !ErrInvoiceNotFound.Has(err) {
+		return false, err
+	}
+
+	var items []Item
+	var amount int64
+	for _, project := range projects {
+		usage, err := service.usageDB.GetProjectTotal(ctx, project.ID, start, end)
+		if err != nil {
+			return false, err
+		}
+
+		for _, item := range service.projectItems(project, *usage) {
+			items = append(items, item)
+			amount += item.Amount
+		}
+	}
+
+	if amount == 0 {
+		return false, nil
+	}
+
+	id, err := uuid.New()
+	if err != nil {
+		return false, err
+	}
+
+	return true, service.db.Insert(ctx, Invoice{
+		ID:          id,
+		UserID:      accountID,
+		PeriodStart: start,
+		PeriodEnd:   end,
+		Description: fmt.Sprintf("Storj DCS Cloud Storage for %s %d", start.Month(), start.Year()),
+		Items:       items,
+		Amount:      amount,
+		Status:      StatusDraft,
+	})
+}
+
+// projectItems calculates the invoice items of the usage of the project.
+func (service *Service) projectItems(project console.Project, usage accounting.ProjectUsage) []Item {
+	item := func(description string, quantity decimal.Decimal, unitPrice decimal.Decimal) Item {
+		price, _ := unitPrice.Float64()
+		return Item{
+			ProjectID:   project.ID,
+			Description: fmt.Sprintf("Project %s - %s", project.Name, description),
+			Quantity:    quantity.IntPart(),
+			UnitPrice:   price,
+			Amount:      quantity.Mul(unitPrice).Round(0).IntPart(),
+		}
+	}
+
+	return []Item{
+		item("Segment Storage (MB-Month)", storageMBMonthDecimal(usage.Storage), service.StorageMBMonthPriceCents),
+		item("Egress Bandwidth (MB)", egressMBDecimal(usage.Egress), service.EgressMBPriceCents),
+		item("Segment Fee (Segment-Month)", segmentMonthDecimal(usage.SegmentCount), service.SegmentMonthPriceCents),
+	}
+}
+
+// FinalizeInvoices emails all draft invoices to their payment accounts and
+// opens them for payment.
+func (service *Service) FinalizeInvoices(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	drafts, err := service.db.ListByStatus(ctx, StatusDraft)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	for _, invoice := range drafts {
+		if err = ctx.Err(); err != nil {
+			return Error.Wrap(err)
+		}
+
+		if err = service.sendInvoice(ctx, invoice); err != nil {
+			return Error.Wrap(err)
+		}
+
+		if err = service.db.UpdateStatus(ctx, invoice.ID, StatusOpen, nil); err != nil {
+			return Error.Wrap(err)
+		}
+	}
+
+	service.log.Info("Number of finalized invoices.", zap.Int("Invoices", len(drafts)))
+	return nil
+}
+
+// sendInvoice emails the invoice as HTML with a PDF attachment.
+func (service *Service) sendInvoice(ctx context.Context, invoice Invoice) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if service.mail == nil {
+		service.log.Warn("Mail service isn't configured, invoice isn't sent.", zap.Stringer("Invoice ID", invoice.ID))
+		return nil
+	}
+
+	recipients, err := service.recipients(ctx, invoice.UserID)
+	if err != nil {
+		return err
+	}
+
+	var html, pdf bytes.Buffer
+	if err = service.RenderHTML(&html, invoice); err != nil {
+		return err
+	}
+	if err = service.RenderPDF(&pdf, invoice); err != nil {
+		return err
+	}
+
+	filename := fmt.Sprintf("invoice-%s.pdf", invoice.PeriodStart.Format("2006-01"))
+
+	return service.mail.Send(ctx, &post.Message{
+		From:    service.mail.Sender.FromAddress(),
+		To:      recipients,
+		Subject: invoice.Description,
+		// clients show the last alternative they can display, so the
+		// attachment precedes the HTML.
+		Parts: []post.Part{
+			{
+				Type:        "application/pdf; name=" + filename,
+				Encoding:    "base64",
+				Disposition: "attachment; filename=" + filename,
+				Content:     wrapBase64(pdf.Bytes()),
+			},
+			{
+				Type:    "text/html; charset=UTF-8",
+				Content: html.String(),
+			},
+		},
+	})
+}
+
+// recipients returns the addresses the invoices of the payment account are
+// sent to: the user, or all members of the organization.
+func (service *Service) recipients(ctx context.Context, accountID uuid.UUID) (_ []post.Address, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := service.consoleDB.Users().Get(ctx, accountID)
+	if err == nil {
+		return []post.Address{{Address: user.Email, Name: user.FullName}}, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	members, err := service.consoleDB.Organizations().GetMembers(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	var recipients []post.Address
+	for _, member := range members {
+		user, err := service.consoleDB.Users().Get(ctx, member.MemberID)
+		if err != nil {
+			return nil, err
+		}
+		recipients = append(recipients, post.Address{Address: user.Email, Name: user.FullName})
+	}
+
+	if len(recipients) == 0 {
+		return nil, errs.New("payment account %s has no email address", accountID)
+	}
+
+	return recipients, nil
+}
+
+// wrapBase64 encodes the data as base64 in lines of 76 characters.
+func wrapBase64(data []byte) string {
+	encoded := base64.StdEncoding.EncodeToString(data)
+
+	var lines strings.Builder
+	for len(encoded) > 76 {
+		lines.WriteString(encoded[:76])
+		lines.WriteString("\r\n")
+		encoded = encoded[76:]
+	}
+	lines.WriteString(encoded)
+
+	return lines.String()
+}
+
+// MarkPaid records that the open invoice has been paid outside of the satellite.
+func (service *Service) MarkPaid(ctx context.Context, invoiceID uuid.UUID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	invoice, err := service.db.Get(ctx, invoiceID)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	if invoice.Status != StatusOpen {
+		return Error.New("invoice %s is %s", invoiceID, invoice.Status)
+	}
+
+	now := service.nowFn()
+	return Error.Wrap(service.db.UpdateStatus(ctx, invoiceID, StatusPaid, &now))
+}
+
+// CreditTokenPayments credits the confirmed STORJ token payments to the
+// deposit wallets of the payment accounts to their balances. A wallet claimed
+// for an organization credits the balance its invoices are paid from.
+func (service *Service) CreditTokenPayments(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if service.StorjTokenPrice.IsZero() {
+		return nil
+	}
+
+	userIDs, err := service.walletsDB.GetAllUsers(ctx)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	var credited int
+	for _, userID := range userIDs {
+		if err = ctx.Err(); err != nil {
+			return Error.Wrap(err)
+		}
+
+		n, err := service.creditTokenPayments(ctx, userID)
+		if err != nil {
+			return Error.Wrap(err)
+		}
+		credited += n
+	}
+
+	service.log.Debug("Number of credited token payments.", zap.Int("Payments", credited))
+	return nil
+}
+
+// creditTokenPayments credits the payments to the wallet of the user which
+// haven't been credited yet.
+func (service *Service) creditTokenPayments(ctx context.Context, userID uuid.UUID) (credited int, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	wallet, err := service.walletsDB.Get(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	transactions, err := service.billingDB.ListType(ctx, userID, billing.Storjscan)
+	if err != nil {
+		return 0, err
+	}
+
+	creditedIDs := make(map[string]struct{}, len(transactions))
+	for _, tx := range transactions {
+		creditedIDs[tx.TXID] = struct{}{}
+	}
+
+	var offset int64
+	for {
+		tokenPayments, err := service.storjscanPayments.ListWallet(ctx, wallet, service.listingLimit, offset)
+		if err != nil {
+			return credited, err
+		}
+
+		for _, payment := range tokenPayments {
+			if payment.Status != storjscan.PaymentStatusConfirmed {
+				continue
+			}
+
+			txID := fmt.Sprintf("%s#%d", payment.Transaction.Hex(), payment.LogIndex)
+			if _, ok := creditedIDs[txID]; ok {
+				continue
+			}
+
+			amount := payment.TokenValue.AsDecimal().Mul(service.StorjTokenPrice)
+			err = service.billingDB.Insert(ctx, billing.Transaction{
+				TXID:        txID,
+				AccountID:   userID,
+				Amount:      monetary.AmountFromDecimal(amount, monetary.USDollars),
+				Description: fmt.Sprintf("STORJ token payment of %s", payment.TokenValue.AsDecimal()),
+				TXType:      billing.Storjscan,
+				Timestamp:   payment.Timestamp,
+			})
+			if err != nil {
+				return credited, err
+			}
+			credited++
+		}
+
+		if len(tokenPayments) < service.listingLimit {
+			return credited, nil
+		}
+		offset += int64(len(tokenPayments))
+	}
+}
+
+// PayInvoices pays the open invoices from the balances of their payment
+// accounts, the earliest invoice first. Invoices which are larger than the
+// balance stay open.
+func (service *Service) PayInvoices(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	open, err := service.db.ListByStatus(ctx, StatusOpen)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	var paid int
+	for _, invoice := range open {
+		if err = ctx.Err(); err != nil {
+			return Error.Wrap(err)
+		}
+
+		ok, err := service.payInvoice(ctx, invoice)
+		if err != nil {
+			return Error.Wrap(err)
+		}
+		if ok {
+			paid++
+		}
+	}
+
+	service.log.Debug("Number of invoices paid from balances.", zap.Int("Invoices", paid))
+	return nil
+}
+
+// payInvoice pays the invoice from the balance of its payment account, if
+// the balance covers it.
+func (service *Service) payInvoice(ctx context.Context, invoice Invoice) (paid bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	txID := "invoice-" + invoice.ID.String()
+
+	transactions, err := service.billingDB.ListType(ctx, invoice.UserID, billing.Invoicing)
+	if err != nil {
+		return false, err
+	}
+
+	var charged bool
+	for _, tx := range transactions {
+		if tx.TXID == txID {
+			// the balance was charged, but the status wasn't updated.
+			charged = true
+			break
+		}
+	}
+
+	now := service.nowFn()
+	if !charged {
+		balance, err := service.billingDB.ComputeBalance(ctx, invoice.UserID)
+		if err != nil {
+			return false, err
+		}
+		if balance.BaseUnits() < invoice.Amount {
+			return false, nil
+		}
+
+		err = service.billingDB.Insert(ctx, billing.Transaction{
+			TXID:        txID,
+			AccountID:   invoice.UserID,
+			Amount:      monetary.AmountFromBaseUnits(-invoice.Amount, monetary.USDollars),
+			Description: invoice.Description,
+			TXType:      billing.Invoicing,
+			Timestamp:   now,
+		})
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return true, service.db.UpdateStatus(ctx, invoice.ID, StatusPaid, &now)
+}
+
+// SetNow allows tests to have the Service act as if the current time is whatever
+// they want. This avoids races and sleeping, making tests more reliable and efficient.
+func (service *Service) SetNow(now func() time.Time) {
+	service.nowFn = now
+}
+
+// storageMBMonthDecimal converts storage usage from Byte-Hours to Megabyte-Months.
+// The result is rounded to the nearest whole number, but returned as Decimal for convenience.
+func storageMBMonthDecimal(storage float64) decimal.Decimal {
+	return decimal.NewFromFloat(storage).Shift(-6).Div(decimal.NewFromInt(hoursPerMonth)).Round(0)
+}
+
+// egressMBDecimal converts egress usage from bytes to Megabytes
+// The result is rounded to the nearest whole number, but returned as Decimal for convenience.
+func egressMBDecimal(egress int64) decimal.Decimal {
+	return decimal.NewFromInt(egress).Shift(-6).Round(0)
+}
+
+// segmentMonthDecimal converts segments usage from Segment-Hours to Segment-Months.
+// The result is rounded to the nearest whole number, but returned as Decimal for convenience.
+func segmentMonthDecimal(segments float64) decimal.Decimal {
+	return decimal.NewFromFloat(segments).Div(decimal.NewFromInt(hoursPerMonth)).Round(0)
+}
@@ -0,0 +1,159 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package invoicing_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"storj.io/common/memory"
+	"storj.io/common/pb"
+	"storj.io/common/testcontext"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/payments/billing"
+	"storj.io/storj/satellite/payments/invoicing"
+	"storj.io/storj/satellite/payments/monetary"
+)
+
+func TestService_InvoiceLifecycle(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 0,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Payments.Provider = "invoicing"
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		service := sat.API.Payments.InvoicingService
+		invoicesDB := sat.DB.Invoicing()
+
+		period := time.Now().UTC()
+		service.SetNow(func() time.Time {
+			return time.Date(period.Year(), period.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		})
+
+		// invoices can't be created before the end of the period.
+		require.Error(t, service.CreateInvoices(ctx, period.AddDate(0, 2, 0)))
+
+		var users []*console.User
+		for i, email := range []string{"manual@test.test", "balance@test.test"} {
+			user, err := sat.AddUser(ctx, console.CreateUser{FullName: "Test User", Email: email}, 1)
+			require.NoError(t, err)
+			users = append(users, user)
+
+			project, err := sat.AddProject(ctx, user.ID, "testproject")
+			require.NoError(t, err)
+
+			err = sat.DB.Orders().UpdateBucketBandwidthSettle(ctx, project.ID, []byte("testbucket"),
+				pb.PieceAction_GET, int64(i+10)*memory.GiB.Int64(), 0, period)
+			require.NoError(t, err)
+		}
+
+		// a user without usage isn't invoiced.
+		idle, err := sat.AddUser(ctx, console.CreateUser{FullName: "Idle User", Email: "idle@test.test"}, 1)
+		require.NoError(t, err)
+		_, err = sat.AddProject(ctx, idle.ID, "idleproject")
+		require.NoError(t, err)
+
+		require.NoError(t, service.CreateInvoices(ctx, period))
+		// creating the invoices again doesn't duplicate them.
+		require.NoError(t, service.CreateInvoices(ctx, period))
+
+		idleInvoices, err := invoicesDB.ListByUserID(ctx, idle.ID)
+		require.NoError(t, err)
+		require.Empty(t, idleInvoices)
+
+		var invoices []invoicing.Invoice
+		for _, user := range users {
+			userInvoices, err := invoicesDB.ListByUserID(ctx, user.ID)
+			require.NoError(t, err)
+			require.Len(t, userInvoices, 1)
+
+			invoice := userInvoices[0]
+			require.Equal(t, invoicing.StatusDraft, invoice.Status)
+			require.Positive(t, invoice.Amount)
+			require.NotEmpty(t, invoice.Items)
+
+			var total int64
+			for _, item := range invoice.Items {
+				total += item.Amount
+			}
+			require.Equal(t, invoice.Amount, total)
+
+			// drafts aren't listed to the users yet.
+			listed, err := service.Accounts().Invoices().List(ctx, user.ID)
+			require.NoError(t, err)
+			require.Empty(t, listed)
+
+			pending, err := service.Accounts().Invoices().CheckPendingItems(ctx, user.ID)
+			require.NoError(t, err)
+			require.True(t, pending)
+
+			invoices = append(invoices, invoice)
+		}
+
+		// drafts can't be paid.
+		require.Error(t, service.MarkPaid(ctx, invoices[0].ID))
+
+		require.NoError(t, service.FinalizeInvoices(ctx))
+
+		for _, invoice := range invoices {
+			finalized, err := invoicesDB.Get(ctx, invoice.ID)
+			require.NoError(t, err)
+			require.Equal(t, invoicing.StatusOpen, finalized.Status)
+
+			listed, err := service.Accounts().Invoices().List(ctx, invoice.UserID)
+			require.NoError(t, err)
+			require.Len(t, listed, 1)
+			require.Equal(t, invoice.Amount, listed[0].Amount)
+		}
+
+		// the first invoice is paid manually.
+		require.NoError(t, service.MarkPaid(ctx, invoices[0].ID))
+		require.Error(t, service.MarkPaid(ctx, invoices[0].ID))
+
+		paid, err := invoicesDB.Get(ctx, invoices[0].ID)
+		require.NoError(t, err)
+		require.Equal(t, invoicing.StatusPaid, paid.Status)
+		require.NotNil(t, paid.PaidAt)
+
+		// the second invoice is paid from the balance once it covers the invoice.
+		balanceUser := invoices[1].UserID
+		credit := func(txID string, cents int64) {
+			require.NoError(t, sat.DB.Billing().Insert(ctx, billing.Transaction{
+				TXID:        txID,
+				AccountID:   balanceUser,
+				Amount:      monetary.AmountFromBaseUnits(cents, monetary.USDollars),
+				Description: "credit",
+				TXType:      billing.Storjscan,
+				Timestamp:   time.Now(),
+			}))
+		}
+
+		credit("credit-1", invoices[1].Amount-1)
+		require.NoError(t, service.PayInvoices(ctx))
+
+		open, err := invoicesDB.Get(ctx, invoices[1].ID)
+		require.NoError(t, err)
+		require.Equal(t, invoicing.StatusOpen, open.Status)
+
+		credit("credit-2", 1)
+		require.NoError(t, service.PayInvoices(ctx))
+		require.NoError(t, service.PayInvoices(ctx))
+
+		paid, err = invoicesDB.Get(ctx, invoices[1].ID)
+		require.NoError(t, err)
+		require.Equal(t, invoicing.StatusPaid, paid.Status)
+
+		balance, err := sat.DB.Billing().ComputeBalance(ctx, balanceUser)
+		require.NoError(t, err)
+		require.Zero(t, balance.BaseUnits())
+	})
+}
@@ -4,15 +4,17 @@
 package paymentsconfig
 
 import (
+	"storj.io/storj/satellite/payments/invoicing"
 	"storj.io/storj/satellite/payments/storjscan"
 	"storj.io/storj/satellite/payments/stripecoinpayments"
 )
 
 // Config defines global payments config.
 type Config struct {
-	Provider                 string `help:"payments provider to use" default:""`
+	Provider                 string `help:"payments provider to use: stripecoinpayments, invoicing or empty for the mock" default:""`
 	StripeCoinPayments       stripecoinpayments.Config
 	Storjscan                storjscan.Config
+	Invoicing                invoicing.Config
 	StorageTBPrice           string `help:"price user should pay for storing TB per month" default:"4" testDefault:"10"`
 	EgressTBPrice            string `help:"price user should pay for each TB of egress" default:"7" testDefault:"45"`
 	SegmentPrice             string `help:"price user should pay for each segment stored in network per month" default:"0.0000088" testDefault:"0.0000022"`
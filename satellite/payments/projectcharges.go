@@ -20,3 +20,27 @@ type ProjectCharge struct {
 	// SegmentCount shows how many cents we should pay for objects count.
 	SegmentCount int64 `json:"segmentPrice"`
 }
+
+// Total returns how many cents the project will be charged in total.
+func (charge ProjectCharge) Total() int64 {
+	return charge.StorageGbHrs + charge.Egress + charge.SegmentCount
+}
+
+// BucketCharge shows bucket usage and how much money the bucket is charged
+// for it. Unlike ProjectCharge the prices aren't rounded to whole cents, so
+// the charges of short periods can be summed up.
+type BucketCharge struct {
+	accounting.BucketUsageRollup
+
+	// StoragePrice shows how many cents we should pay for storing GB*Hrs.
+	StoragePrice float64 `json:"storagePrice"`
+	// EgressPrice shows how many cents we should pay for Egress.
+	EgressPrice float64 `json:"egressPrice"`
+	// SegmentPrice shows how many cents we should pay for segments.
+	SegmentPrice float64 `json:"segmentPrice"`
+}
+
+// Total returns how many cents the bucket is charged in total.
+func (charge BucketCharge) Total() float64 {
+	return charge.StoragePrice + charge.EgressPrice + charge.SegmentPrice
+}
@@ -121,32 +121,78 @@ func (accounts *accounts) ProjectCharges(ctx context.Context, userID uuid.UUID,
 	// to return empty slice instead of nil if there are no projects
 	charges = make([]payments.ProjectCharge, 0)
 
-	projects, err := accounts.service.projectsDB.GetOwn(ctx, userID)
+	projects, err := accounts.service.billedProjects(ctx, userID)
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
 
 	for _, project := range projects {
-		usage, err := accounts.service.usageDB.GetProjectTotal(ctx, project.ID, since, before)
+		charge, err := accounts.projectCharge(ctx, project.ID, since, before)
 		if err != nil {
 			return charges, Error.Wrap(err)
 		}
 
-		projectPrice := accounts.service.calculateProjectUsagePrice(usage.Egress, usage.Storage, usage.SegmentCount)
+		charges = append(charges, charge)
+	}
+
+	return charges, nil
+}
+
+// ProjectCharge returns how much money the project will be charged for its usage in the period.
+func (accounts *accounts) ProjectCharge(ctx context.Context, projectID uuid.UUID, since, before time.Time) (_ payments.ProjectCharge, err error) {
+	defer mon.Task()(&ctx, projectID, since, before)(&err)
+
+	charge, err := accounts.projectCharge(ctx, projectID, since, before)
+	return charge, Error.Wrap(err)
+}
+
+// BucketCharges returns how much money each bucket of the project will be charged for its usage in the period.
+func (accounts *accounts) BucketCharges(ctx context.Context, projectID uuid.UUID, since, before time.Time) (_ []payments.BucketCharge, err error) {
+	defer mon.Task()(&ctx, projectID, since, before)(&err)
+
+	rollups, err := accounts.service.usageDB.GetBucketUsageRollups(ctx, projectID, since, before)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	storagePrice, _ := accounts.service.StorageMBMonthPriceCents.Float64()
+	egressPrice, _ := accounts.service.EgressMBPriceCents.Float64()
+	segmentPrice, _ := accounts.service.SegmentMonthPriceCents.Float64()
 
-		charges = append(charges, payments.ProjectCharge{
-			ProjectUsage: *usage,
+	charges := make([]payments.BucketCharge, 0, len(rollups))
+	for _, rollup := range rollups {
+		charges = append(charges, payments.BucketCharge{
+			BucketUsageRollup: rollup,
 
-			ProjectID:    project.ID,
-			Egress:       projectPrice.Egress.IntPart(),
-			SegmentCount: projectPrice.Segments.IntPart(),
-			StorageGbHrs: projectPrice.Storage.IntPart(),
+			// the rollups are in GB*Hrs, GB and segment*Hrs.
+			StoragePrice: storagePrice * rollup.TotalStoredData * 1000 / hoursPerMonth,
+			EgressPrice:  egressPrice * rollup.GetEgress * 1000,
+			SegmentPrice: segmentPrice * rollup.TotalSegments / hoursPerMonth,
 		})
 	}
 
 	return charges, nil
 }
 
+// projectCharge calculates the price of the usage of the project in the period.
+func (accounts *accounts) projectCharge(ctx context.Context, projectID uuid.UUID, since, before time.Time) (_ payments.ProjectCharge, err error) {
+	usage, err := accounts.service.usageDB.GetProjectTotal(ctx, projectID, since, before)
+	if err != nil {
+		return payments.ProjectCharge{}, err
+	}
+
+	projectPrice := accounts.service.calculateProjectUsagePrice(usage.Egress, usage.Storage, usage.SegmentCount)
+
+	return payments.ProjectCharge{
+		ProjectUsage: *usage,
+
+		ProjectID:    projectID,
+		Egress:       projectPrice.Egress.IntPart(),
+		SegmentCount: projectPrice.Segments.IntPart(),
+		StorageGbHrs: projectPrice.Storage.IntPart(),
+	}, nil
+}
+
 // CheckProjectInvoicingStatus returns error if for the given project there are outstanding project records and/or usage
 // which have not been applied/invoiced yet (meaning sent over to stripe).
 func (accounts *accounts) CheckProjectInvoicingStatus(ctx context.Context, projectID uuid.UUID) (err error) {
@@ -78,6 +78,7 @@ func TestSignupCouponCodes(t *testing.T) {
 			log.Named("console"),
 			db.Console(),
 			restkeys.NewService(db.OIDC().OAuthTokens(), planet.Satellites[0].Config.RESTKeys),
+			db.Revocation(),
 			db.ProjectAccounting(),
 			projectUsage,
 			sat.API.Buckets.Service,
@@ -18,6 +18,7 @@ import (
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 
+	"storj.io/common/uuid"
 	"storj.io/storj/satellite/accounting"
 	"storj.io/storj/satellite/console"
 	"storj.io/storj/satellite/payments"
@@ -430,7 +431,7 @@ func (service *Service) PrepareInvoiceProjectRecords(ctx context.Context, period
 func (service *Service) processCustomers(ctx context.Context, customers []Customer, start, end time.Time) (int, error) {
 	var allRecords []CreateProjectRecord
 	for _, customer := range customers {
-		projects, err := service.projectsDB.GetOwn(ctx, customer.UserID)
+		projects, err := service.billedProjects(ctx, customer.UserID)
 		if err != nil {
 			return 0, err
 		}
@@ -446,6 +447,32 @@ func (service *Service) processCustomers(ctx context.Context, customers []Custom
 	return len(allRecords), service.db.ProjectRecords().Create(ctx, allRecords, start, end)
 }
 
+// billedProjects returns the projects the payment account pays for: the
+// projects the user owns outside of any organization, or the projects of the
+// organization.
+func (service *Service) billedProjects(ctx context.Context, accountID uuid.UUID) (_ []console.Project, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	owned, err := service.projectsDB.GetOwn(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	var projects []console.Project
+	for _, project := range owned {
+		if project.OrganizationID.IsZero() {
+			projects = append(projects, project)
+		}
+	}
+
+	organizationProjects, err := service.projectsDB.GetByOrganizationID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(projects, organizationProjects...), nil
+}
+
 // createProjectRecords creates invoice project record if none exists.
 func (service *Service) createProjectRecords(ctx context.Context, customerID string, projects []console.Project, start, end time.Time) (_ []CreateProjectRecord, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -549,10 +576,10 @@ func (service *Service) applyProjectRecords(ctx context.Context, records []Proje
 			return errs.Wrap(err)
 		}
 
-		cusID, err := service.db.Customers().GetCustomerID(ctx, proj.OwnerID)
+		cusID, err := service.db.Customers().GetCustomerID(ctx, proj.BillingAccountID())
 		if err != nil {
 			if errors.Is(err, ErrNoCustomer) {
-				service.log.Warn("Stripe customer does not exist for project owner.", zap.Stringer("Owner ID", proj.OwnerID), zap.Stringer("Project ID", proj.ID))
+				service.log.Warn("Stripe customer does not exist for project owner.", zap.Stringer("Owner ID", proj.BillingAccountID()), zap.Stringer("Project ID", proj.ID))
 				continue
 			}
 
@@ -36,6 +36,8 @@ import (
 	"storj.io/storj/satellite/console/consoleweb"
 	"storj.io/storj/satellite/console/emailreminders"
 	"storj.io/storj/satellite/console/restkeys"
+	"storj.io/storj/satellite/console/spendingcaps"
+	"storj.io/storj/satellite/console/usageexports"
 	"storj.io/storj/satellite/contact"
 	"storj.io/storj/satellite/gc"
 	"storj.io/storj/satellite/gracefulexit"
@@ -51,6 +53,7 @@ import (
 	"storj.io/storj/satellite/overlay"
 	"storj.io/storj/satellite/overlay/straynodes"
 	"storj.io/storj/satellite/payments/billing"
+	"storj.io/storj/satellite/payments/invoicing"
 	"storj.io/storj/satellite/payments/paymentsconfig"
 	"storj.io/storj/satellite/payments/storjscan"
 	"storj.io/storj/satellite/payments/stripecoinpayments"
@@ -104,6 +107,8 @@ type DB interface {
 	Orders() orders.DB
 	// Containment returns database for containment
 	Containment() audit.Containment
+	// NodeAuditQueue returns the queue of piece-level node audits
+	NodeAuditQueue() audit.NodeAuditQueue
 	// Buckets returns the database to interact with buckets
 	Buckets() buckets.DB
 	// GracefulExit returns database for graceful exit
@@ -112,6 +117,8 @@ type DB interface {
 	StripeCoinPayments() stripecoinpayments.DB
 	// Billing returns storjscan transactions database.
 	Billing() billing.TransactionsDB
+	// Invoicing returns the database of the built-in invoicing.
+	Invoicing() invoicing.DB
 	// SNOPayouts returns database for payouts.
 	// Wallets returns storjscan wallets database.
 	Wallets() storjscan.WalletsDB
@@ -167,6 +174,8 @@ type Config struct {
 	Console        consoleweb.Config
 	ConsoleAuth    consoleauth.Config
 	EmailReminders emailreminders.Config
+	SpendingCaps   spendingcaps.Config
+	UsageExports   usageexports.Config
 
 	Version version_checker.Config
 
@@ -181,7 +190,8 @@ type Config struct {
 	Analytics analytics.Config
 }
 
-func setupMailService(log *zap.Logger, config Config) (*mailservice.Service, error) {
+// SetupMailService creates the mail service of the satellite from the config.
+func SetupMailService(log *zap.Logger, config Config) (*mailservice.Service, error) {
 	// TODO(yar): test multiple satellites using same OAUTH credentials
 	mailConfig := config.Mail
 
@@ -50,13 +50,24 @@ import (
 //	 threshold
 // - Downloads the data from those left nodes and check that it's the same than the uploaded one.
 func TestDataRepairInMemory(t *testing.T) {
-	testDataRepair(t, true)
+	testDataRepair(t, func(config *repairer.Config) {
+		config.InMemoryRepair = true
+	})
 }
 func TestDataRepairToDisk(t *testing.T) {
-	testDataRepair(t, false)
+	testDataRepair(t, func(config *repairer.Config) {
+		config.InMemoryRepair = false
+	})
+}
+func TestDataRepairStreaming(t *testing.T) {
+	testDataRepair(t, func(config *repairer.Config) {
+		config.StreamingRepair = true
+		// force the pieces to be streamed through buffers smaller than a piece
+		config.MaxBufferMem = memory.KiB
+	})
 }
 
-func testDataRepair(t *testing.T, inMemoryRepair bool) {
+func testDataRepair(t *testing.T, configureRepairer func(config *repairer.Config)) {
 	const (
 		RepairMaxExcessRateOptimalThreshold = 0.05
 		minThreshold                        = 3
@@ -71,7 +82,7 @@ func testDataRepair(t *testing.T, inMemoryRepair bool) {
 			Satellite: testplanet.Combine(
 				func(log *zap.Logger, index int, config *satellite.Config) {
 					config.Repairer.MaxExcessRateOptimalThreshold = RepairMaxExcessRateOptimalThreshold
-					config.Repairer.InMemoryRepair = inMemoryRepair
+					configureRepairer(&config.Repairer)
 				},
 				testplanet.ReconfigureRS(minThreshold, 5, successThreshold, 9),
 			),
@@ -1043,6 +1054,24 @@ func TestMissingPieceDataRepair(t *testing.T) {
 // - Expects that the repair succeed and the pointer should not contain the corrupted piece.
 //   Reputation info to be updated for all remaining nodes.
 func TestCorruptDataRepair_Succeed(t *testing.T) {
+	testCorruptDataRepairSucceed(t, 4, 1, func(config *repairer.Config) {
+		config.InMemoryRepair = true
+	})
+}
+
+// TestCorruptDataRepairStreaming_Succeed checks that streaming repair corrects
+// the stripes of a piece corrupted in the middle with the spare pieces, instead
+// of failing the repair when the hash of the piece doesn't match.
+func TestCorruptDataRepairStreaming_Succeed(t *testing.T) {
+	testCorruptDataRepairSucceed(t, 5, 1000, func(config *repairer.Config) {
+		config.StreamingRepair = true
+		config.StreamingSparePieces = 2
+		// force the pieces to be streamed through buffers smaller than a piece
+		config.MaxBufferMem = memory.KiB
+	})
+}
+
+func testCorruptDataRepairSucceed(t *testing.T, available int, corruptFromEnd int64, configureRepairer func(config *repairer.Config)) {
 	const RepairMaxExcessRateOptimalThreshold = 0.05
 
 	testplanet.Run(t, testplanet.Config{
@@ -1053,7 +1082,7 @@ func TestCorruptDataRepair_Succeed(t *testing.T) {
 			Satellite: testplanet.Combine(
 				func(log *zap.Logger, index int, config *satellite.Config) {
 					config.Repairer.MaxExcessRateOptimalThreshold = RepairMaxExcessRateOptimalThreshold
-					config.Repairer.InMemoryRepair = true
+					configureRepairer(&config.Repairer)
 				},
 				testplanet.ReconfigureRS(3, 4, 9, 9),
 			),
@@ -1075,7 +1104,7 @@ func TestCorruptDataRepair_Succeed(t *testing.T) {
 		segment, _ := getRemoteSegment(ctx, t, satellite, planet.Uplinks[0].Projects[0].ID, "testbucket")
 		require.Equal(t, 9, len(segment.Pieces))
 		require.Equal(t, 3, int(segment.Redundancy.RequiredShares))
-		toKill := 5
+		toKill := len(segment.Pieces) - available
 
 		// kill nodes and track lost pieces
 		var availablePieces metabase.Pieces
@@ -1089,7 +1118,7 @@ func TestCorruptDataRepair_Succeed(t *testing.T) {
 			err := planet.StopNodeAndUpdate(ctx, planet.FindNode(piece.StorageNode))
 			require.NoError(t, err)
 		}
-		require.Equal(t, 4, len(availablePieces))
+		require.Equal(t, available, len(availablePieces))
 
 		// choose first piece for corruption, for it to always be in the first limiter batch
 		corruptedPiece := availablePieces[0]
@@ -1098,7 +1127,7 @@ func TestCorruptDataRepair_Succeed(t *testing.T) {
 		corruptedNode := planet.FindNode(corruptedPiece.StorageNode)
 		require.NotNil(t, corruptedNode)
 		corruptedPieceID := segment.RootPieceID.Derive(corruptedPiece.StorageNode, int32(corruptedPiece.Number))
-		corruptPieceData(ctx, t, planet, corruptedNode, corruptedPieceID)
+		corruptPieceDataAt(ctx, t, planet, corruptedNode, corruptedPieceID, corruptFromEnd)
 
 		reputationService := planet.Satellites[0].Reputation.Service
 
@@ -2315,7 +2344,7 @@ func TestRepairGracefullyExited(t *testing.T) {
 // getRemoteSegment returns a remote pointer its path from satellite.
 // nolint:golint
 func getRemoteSegment(
-	ctx context.Context, t *testing.T, satellite *testplanet.Satellite, projectID uuid.UUID, bucketName string,
+	ctx context.Context, t testing.TB, satellite *testplanet.Satellite, projectID uuid.UUID, bucketName string,
 ) (_ metabase.Segment, key metabase.SegmentKey) {
 	t.Helper()
 
@@ -2339,6 +2368,13 @@ func getRemoteSegment(
 // corruptPieceData manipulates piece data on a storage node.
 func corruptPieceData(ctx context.Context, t *testing.T, planet *testplanet.Planet, corruptedNode *testplanet.StorageNode, corruptedPieceID storj.PieceID) {
 	t.Helper()
+	corruptPieceDataAt(ctx, t, planet, corruptedNode, corruptedPieceID, 1)
+}
+
+// corruptPieceDataAt manipulates the piece data byte at fromEnd bytes from the
+// end of the piece on a storage node.
+func corruptPieceDataAt(ctx context.Context, t *testing.T, planet *testplanet.Planet, corruptedNode *testplanet.StorageNode, corruptedPieceID storj.PieceID, fromEnd int64) {
+	t.Helper()
 
 	blobRef := storage.BlobRef{
 		Namespace: planet.Satellites[0].ID().Bytes(),
@@ -2350,7 +2386,7 @@ func corruptPieceData(ctx context.Context, t *testing.T, planet *testplanet.Plan
 	require.NoError(t, err)
 	pieceSize, err := reader.Size()
 	require.NoError(t, err)
-	require.True(t, pieceSize > 0)
+	require.True(t, pieceSize > fromEnd)
 	pieceData := make([]byte, pieceSize)
 	n, err := io.ReadFull(reader, pieceData)
 	require.NoError(t, err)
@@ -2362,7 +2398,7 @@ func corruptPieceData(ctx context.Context, t *testing.T, planet *testplanet.Plan
 
 	// corrupt piece data (not PieceHeader) and write back to storagenode
 	// this means repair downloading should fail during piece hash verification
-	pieceData[pieceSize-1]++ // if we don't do this, this test should fail
+	pieceData[pieceSize-fromEnd]++ // if we don't do this, this test should fail
 	writer, err := corruptedNode.Storage2.BlobsCache.Create(ctx, blobRef, pieceSize)
 	require.NoError(t, err)
 
@@ -2414,10 +2450,86 @@ func ecRepairerWithMockConnector(t testing.TB, sat *testplanet.Satellite, mock *
 		signing.SigneeFromPeerIdentity(sat.Identity.PeerIdentity()),
 		sat.Config.Repairer.DownloadTimeout,
 		sat.Config.Repairer.InMemoryRepair,
+		sat.Config.Repairer.StreamingRepair,
+		sat.Config.Repairer.StreamingSparePieces,
+		sat.Config.Repairer.MaxBufferMem,
 	)
 	return ec
 }
 
+// BenchmarkECRepairer measures downloading the pieces of a segment and
+// uploading the repaired pieces in every repair mode.
+func BenchmarkECRepairer(b *testing.B) {
+	testplanet.Bench(b, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 10,
+		UplinkCount:      1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: testplanet.ReconfigureRS(4, 6, 8, 8),
+		},
+	}, func(b *testing.B, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		satellite.Audit.Worker.Loop.Pause()
+		satellite.Repair.Checker.Loop.Pause()
+		satellite.Repair.Repairer.Loop.Pause()
+
+		err := planet.Uplinks[0].Upload(ctx, satellite, "testbucket", "test/path", testrand.Bytes(4*memory.MiB))
+		require.NoError(b, err)
+
+		segment, _ := getRemoteSegment(ctx, b, satellite, planet.Uplinks[0].Projects[0].ID, "testbucket")
+		redundancy, err := eestream.NewRedundancyStrategyFromStorj(segment.Redundancy)
+		require.NoError(b, err)
+
+		// two of the pieces are lost and repaired to the nodes without a piece.
+		healthy := segment.Pieces[2:]
+		var excluded []storj.NodeID
+		for _, piece := range segment.Pieces {
+			excluded = append(excluded, piece.StorageNode)
+		}
+		newNodes, err := satellite.Overlay.Service.FindStorageNodesForRepair(ctx, overlay.FindStorageNodesRequest{
+			RequestedCount: 2,
+			ExcludedIDs:    excluded,
+		})
+		require.NoError(b, err)
+
+		for _, mode := range []struct {
+			name                string
+			inmemory, streaming bool
+		}{
+			{name: "disk"},
+			{name: "inmemory", inmemory: true},
+			{name: "streaming", streaming: true},
+		} {
+			ec := repairer.NewECRepairer(
+				zap.NewNop(),
+				satellite.Dialer,
+				signing.SigneeFromPeerIdentity(satellite.Identity.PeerIdentity()),
+				satellite.Config.Repairer.DownloadTimeout,
+				mode.inmemory,
+				mode.streaming,
+				satellite.Config.Repairer.StreamingSparePieces,
+				satellite.Config.Repairer.MaxBufferMem,
+			)
+
+			b.Run(mode.name, func(b *testing.B) {
+				b.SetBytes(int64(segment.EncryptedSize))
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					getLimits, getPrivateKey, cachedNodesInfo, err := satellite.Orders.Service.CreateGetRepairOrderLimits(ctx, metabase.BucketLocation{}, segment, healthy)
+					require.NoError(b, err)
+					putLimits, putPrivateKey, err := satellite.Orders.Service.CreatePutRepairOrderLimits(ctx, metabase.BucketLocation{}, segment, getLimits, newNodes, 1, 0)
+					require.NoError(b, err)
+
+					segmentReader, _, err := ec.Get(ctx, getLimits, cachedNodesInfo, getPrivateKey, redundancy, int64(segment.EncryptedSize))
+					require.NoError(b, err)
+					_, _, err = ec.Repair(ctx, putLimits, putPrivateKey, redundancy, segmentReader, satellite.Config.Repairer.Timeout, len(newNodes))
+					require.NoError(b, errs.Combine(err, segmentReader.Close()))
+				}
+			})
+		}
+	})
+}
+
 func TestECRepairerGet(t *testing.T) {
 	testplanet.Run(t, testplanet.Config{
 		SatelliteCount:   1,
@@ -21,6 +21,7 @@ import (
 	"go.uber.org/zap"
 
 	"storj.io/common/errs2"
+	"storj.io/common/memory"
 	"storj.io/common/pb"
 	"storj.io/common/pkcrypto"
 	"storj.io/common/rpc"
@@ -44,16 +45,29 @@ type ECRepairer struct {
 	satelliteSignee signing.Signee
 	downloadTimeout time.Duration
 	inmemory        bool
+	streaming       bool
+	streamingSpares int
+	maxBufferMem    int
 }
 
 // NewECRepairer creates a new repairer for interfacing with storagenodes.
-func NewECRepairer(log *zap.Logger, dialer rpc.Dialer, satelliteSignee signing.Signee, downloadTimeout time.Duration, inmemory bool) *ECRepairer {
+//
+// When streaming is enabled, pieces are not downloaded completely before the
+// segment is reconstructed. Instead stripes are decoded and the repaired pieces
+// are uploaded as the pieces are downloaded, using at most maxBufferMem for
+// buffering per segment. Streaming repair downloads streamingSpares pieces in
+// addition to the required ones, so that corrupted pieces are corrected before
+// their hash can be verified.
+func NewECRepairer(log *zap.Logger, dialer rpc.Dialer, satelliteSignee signing.Signee, downloadTimeout time.Duration, inmemory, streaming bool, streamingSpares int, maxBufferMem memory.Size) *ECRepairer {
 	return &ECRepairer{
 		log:             log,
 		dialer:          dialer,
 		satelliteSignee: satelliteSignee,
 		downloadTimeout: downloadTimeout,
 		inmemory:        inmemory,
+		streaming:       streaming,
+		streamingSpares: streamingSpares,
+		maxBufferMem:    maxBufferMem.Int(),
 	}
 }
 
@@ -66,6 +80,12 @@ func (ec *ECRepairer) dialPiecestore(ctx context.Context, n storj.NodeURL) (*pie
 // After downloading a piece, the ECRepairer will verify the hash and original order limit for that piece.
 // If verification fails, another piece will be downloaded until we reach the minimum required or run out of order limits.
 // If piece hash verification fails, it will return all failed node IDs.
+//
+// In streaming mode only the beginning of each piece is downloaded before Get
+// returns. Piece hashes are verified while the returned reader is consumed.
+// Spare pieces are downloaded in addition to the required ones and every stripe
+// is error corrected, so a corrupted piece is left out instead of failing the
+// repair, as long as enough of the other pieces are intact.
 func (ec *ECRepairer) Get(ctx context.Context, limits []*pb.AddressedOrderLimit, cachedNodesInfo map[storj.NodeID]overlay.NodeReputation, privateKey storj.PiecePrivateKey, es eestream.ErasureScheme, dataSize int64) (_ io.ReadCloser, _ audit.Pieces, err error) {
 	defer mon.Task()(&ctx)(&err)
 
@@ -86,7 +106,18 @@ func (ec *ECRepairer) Get(ctx context.Context, limits []*pb.AddressedOrderLimit,
 	pieceReaders := make(map[int]io.ReadCloser)
 	var pieces audit.Pieces
 
-	limiter := sync2.NewLimiter(es.RequiredCount())
+	// wanted is the number of pieces to download.
+	wanted := es.RequiredCount()
+	var streamed *streamedSegment
+	if ec.streaming {
+		streamed = &streamedSegment{}
+		wanted += ec.streamingSpares
+		if wanted > nonNilLimits {
+			wanted = nonNilLimits
+		}
+	}
+
+	limiter := sync2.NewLimiter(wanted)
 	cond := sync.NewCond(&sync.Mutex{})
 
 	var errlist errs.Group
@@ -104,18 +135,18 @@ func (ec *ECRepairer) Get(ctx context.Context, limits []*pb.AddressedOrderLimit,
 			defer cond.L.Unlock()
 
 			for {
-				if successfulPieces >= es.RequiredCount() {
-					// already downloaded minimum number of pieces
+				if successfulPieces >= wanted {
+					// already downloaded wanted number of pieces
 					cond.Broadcast()
 					return
 				}
-				if successfulPieces+inProgress+unusedLimits < es.RequiredCount() {
-					// not enough available limits left to get required number of pieces
+				if successfulPieces+inProgress+unusedLimits < wanted {
+					// not enough available limits left to get wanted number of pieces
 					cond.Broadcast()
 					return
 				}
 
-				if successfulPieces+inProgress >= es.RequiredCount() {
+				if successfulPieces+inProgress >= wanted {
 					cond.Wait()
 					continue
 				}
@@ -124,6 +155,23 @@ func (ec *ECRepairer) Get(ctx context.Context, limits []*pb.AddressedOrderLimit,
 				inProgress++
 				cond.L.Unlock()
 
+				piece := metabase.Piece{
+					Number:      uint16(currentLimitIndex),
+					StorageNode: limit.GetLimit().StorageNodeId,
+				}
+
+				download := func(address string) (io.ReadCloser, error) {
+					if streamed != nil {
+						stream, err := ec.openPieceStream(ctx, limit, address, privateKey, pieceSize, es.ErasureShareSize(), piece, streamed)
+						if err != nil {
+							return nil, err
+						}
+						return stream, nil
+					}
+					pieceReadCloser, _, _, err := ec.downloadAndVerifyPiece(ctx, limit, address, privateKey, "", pieceSize)
+					return pieceReadCloser, err
+				}
+
 				info := cachedNodesInfo[limit.GetLimit().StorageNodeId]
 				address := limit.GetStorageNodeAddress().GetAddress()
 				var triedLastIPPort bool
@@ -132,21 +180,17 @@ func (ec *ECRepairer) Get(ctx context.Context, limits []*pb.AddressedOrderLimit,
 					triedLastIPPort = true
 				}
 
-				pieceReadCloser, _, _, err := ec.downloadAndVerifyPiece(ctx, limit, address, privateKey, "", pieceSize)
+				pieceReadCloser, err := download(address)
 				// if piecestore dial with last ip:port failed try again with node address
 				if triedLastIPPort && piecestore.Error.Has(err) && !piecestore.CloseError.Has(err) {
 					if pieceReadCloser != nil {
 						_ = pieceReadCloser.Close()
 					}
-					pieceReadCloser, _, _, err = ec.downloadAndVerifyPiece(ctx, limit, limit.GetStorageNodeAddress().GetAddress(), privateKey, "", pieceSize)
+					pieceReadCloser, err = download(limit.GetStorageNodeAddress().GetAddress())
 				}
 
 				cond.L.Lock()
 				inProgress--
-				piece := metabase.Piece{
-					Number:      uint16(currentLimitIndex),
-					StorageNode: limit.GetLimit().StorageNodeId,
-				}
 
 				if err != nil {
 					if pieceReadCloser != nil {
@@ -211,6 +255,9 @@ func (ec *ECRepairer) Get(ctx context.Context, limits []*pb.AddressedOrderLimit,
 	limiter.Wait()
 
 	if successfulPieces < es.RequiredCount() {
+		for _, pieceReader := range pieceReaders {
+			_ = pieceReader.Close()
+		}
 		mon.Meter("download_failed_not_enough_pieces_repair").Mark(1) //mon:locked
 		return nil, pieces, &irreparableError{
 			piecesAvailable: int32(successfulPieces),
@@ -228,6 +275,14 @@ func (ec *ECRepairer) Get(ctx context.Context, limits []*pb.AddressedOrderLimit,
 	expectedSize := pieceSize * int64(es.RequiredCount())
 
 	ctx, cancel := context.WithCancel(ctx)
+	if streamed != nil {
+		// the hashes of streamed pieces are verified only after the stripes
+		// were decoded, so the stripes are error corrected with the spare pieces.
+		esScheme = eestream.NewRSScheme(fec, es.ErasureShareSize())
+		// the encoder of the repaired pieces gets the other half of the buffer memory
+		streamed.ReadCloser = eestream.DecodeReaders2(ctx, cancel, pieceReaders, esScheme, expectedSize, ec.maxBufferMem/2, false)
+		return streamed, pieces, nil
+	}
 	decodeReader := eestream.DecodeReaders2(ctx, cancel, pieceReaders, esScheme, expectedSize, 0, false)
 
 	return decodeReader, pieces, nil
@@ -363,9 +418,14 @@ func (ec *ECRepairer) Repair(ctx context.Context, limits []*pb.AddressedOrderLim
 		return nil, nil, Error.New("duplicated nodes are not allowed")
 	}
 
-	readers, err := eestream.EncodeReader2(ctx, ioutil.NopCloser(data), rs)
-	if err != nil {
-		return nil, nil, err
+	var readers []io.ReadCloser
+	if ec.streaming {
+		readers = newStreamEncoder(ctx, data, rs, limits, successfulNeeded, streamStallTimeout, ec.maxBufferMem/2)
+	} else {
+		readers, err = eestream.EncodeReader2(ctx, ioutil.NopCloser(data), rs)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
 	// info contains data about a single piece transfer
@@ -0,0 +1,248 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package repairer
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vivint/infectious"
+
+	"storj.io/common/fpath"
+	"storj.io/common/memory"
+	"storj.io/common/pb"
+	"storj.io/common/sync2"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/uplink/private/eestream"
+)
+
+func TestStreamEncoder(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	rs := newTestRedundancy(t, 4, 6, 8, 10, 256)
+	data := testrand.Bytes(64 * memory.KiB)
+
+	expected := encodePieces(ctx, t, rs, data)
+
+	limits := make([]*pb.AddressedOrderLimit, rs.TotalCount())
+	for i := range limits {
+		if i%3 != 0 {
+			limits[i] = &pb.AddressedOrderLimit{}
+		}
+	}
+
+	readers := newStreamEncoder(ctx, bytes.NewReader(data), rs, limits, nonNilCount(limits), streamStallTimeout, memory.KiB.Int())
+	pieces := readAllConcurrently(t, readers)
+	for i := range limits {
+		if limits[i] == nil {
+			require.Empty(t, pieces[i])
+			continue
+		}
+		require.Equal(t, expected[i], pieces[i], "piece %d", i)
+	}
+}
+
+func TestStreamEncoder_ClosedReader(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	rs := newTestRedundancy(t, 4, 6, 8, 10, 256)
+	data := testrand.Bytes(64 * memory.KiB)
+
+	limits := make([]*pb.AddressedOrderLimit, rs.TotalCount())
+	for i := range limits {
+		limits[i] = &pb.AddressedOrderLimit{}
+	}
+
+	// a closed reader, e.g. a canceled upload, must not block the others
+	readers := newStreamEncoder(ctx, bytes.NewReader(data), rs, limits, nonNilCount(limits), streamStallTimeout, memory.KiB.Int())
+	require.NoError(t, readers[0].Close())
+
+	pieces := readAllConcurrently(t, readers[1:])
+	for _, piece := range pieces {
+		require.Len(t, piece, len(data)/rs.RequiredCount())
+	}
+}
+
+func TestStreamEncoder_SlowUpload(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	rs := newTestRedundancy(t, 4, 6, 8, 10, 256)
+	data := testrand.Bytes(64 * memory.KiB)
+
+	expected := encodePieces(ctx, t, rs, data)
+
+	limits := make([]*pb.AddressedOrderLimit, rs.TotalCount())
+	for i := range limits {
+		limits[i] = &pb.AddressedOrderLimit{}
+	}
+
+	// the upload of the first piece stalls until the others are done.
+	readers := newStreamEncoder(ctx, bytes.NewReader(data), rs, limits, len(limits)-1, 100*time.Millisecond, memory.KiB.Int())
+	pieces := readAllConcurrently(t, readers[1:])
+	for i, piece := range pieces {
+		require.Equal(t, expected[i+1], piece, "piece %d", i+1)
+	}
+
+	// the slow upload was dropped, so that it doesn't hold back the others.
+	_, err := ioutil.ReadAll(readers[0])
+	require.Error(t, err)
+	require.NoError(t, readers[0].Close())
+}
+
+func TestStreamEncoder_SlowUploadNeeded(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	rs := newTestRedundancy(t, 4, 6, 8, 10, 256)
+	data := testrand.Bytes(64 * memory.KiB)
+
+	expected := encodePieces(ctx, t, rs, data)
+
+	limits := make([]*pb.AddressedOrderLimit, rs.TotalCount())
+	for i := range limits {
+		limits[i] = &pb.AddressedOrderLimit{}
+	}
+
+	// a slow upload, which is needed for the repair, is waited for.
+	readers := newStreamEncoder(ctx, bytes.NewReader(data), rs, limits, len(limits), time.Millisecond, memory.KiB.Int())
+
+	var slow []byte
+	var slowErr error
+	var group sync2.WorkGroup
+	group.Go(func() {
+		time.Sleep(100 * time.Millisecond)
+		slow, slowErr = ioutil.ReadAll(readers[0])
+	})
+
+	pieces := readAllConcurrently(t, readers[1:])
+	group.Wait()
+	require.NoError(t, slowErr)
+	require.Equal(t, expected[0], slow)
+	for i, piece := range pieces {
+		require.Equal(t, expected[i+1], piece, "piece %d", i+1)
+	}
+}
+
+// BenchmarkRepairPipeline compares reconstructing a segment from downloaded
+// pieces and encoding the repaired pieces with the buffered ECRepairer against
+// the streaming one.
+func BenchmarkRepairPipeline(b *testing.B) {
+	ctx := testcontext.New(b)
+
+	rs := newTestRedundancy(b, 29, 35, 80, 110, 256)
+	segmentSize := 8 * memory.MiB.Int()
+	segmentSize -= segmentSize % rs.StripeSize()
+	data := testrand.BytesInt(segmentSize)
+
+	pieces := encodePieces(ctx, b, rs, data)
+
+	// the pieces uploaded by a typical repair
+	limits := make([]*pb.AddressedOrderLimit, rs.TotalCount())
+	for i := rs.RequiredCount(); i < rs.OptimalThreshold(); i++ {
+		limits[i] = &pb.AddressedOrderLimit{}
+	}
+
+	decode := func(ctx context.Context, maxBufferMem int) io.ReadCloser {
+		readers := make(map[int]io.ReadCloser, rs.RequiredCount())
+		for i := 0; i < rs.RequiredCount(); i++ {
+			readers[i] = ioutil.NopCloser(bytes.NewReader(pieces[i]))
+		}
+		ctx, cancel := context.WithCancel(ctx)
+		return eestream.DecodeReaders2(ctx, cancel, readers, rs, int64(len(data)), maxBufferMem, false)
+	}
+
+	upload := func(limits []*pb.AddressedOrderLimit, readers []io.ReadCloser) {
+		var group sync2.WorkGroup
+		for i, reader := range readers {
+			reader, skip := reader, limits[i] == nil
+			group.Go(func() {
+				defer func() { _ = reader.Close() }()
+				if skip {
+					return
+				}
+				_, _ = io.Copy(ioutil.Discard, reader)
+			})
+		}
+		group.Wait()
+	}
+
+	b.Run("inmemory", func(b *testing.B) {
+		ctx := fpath.WithTempData(ctx, "", true)
+		b.SetBytes(int64(len(data)))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			segment := decode(ctx, 0)
+			readers, err := eestream.EncodeReader2(ctx, segment, rs)
+			require.NoError(b, err)
+			upload(limits, readers)
+			require.NoError(b, segment.Close())
+		}
+	})
+
+	b.Run("disk", func(b *testing.B) {
+		ctx := fpath.WithTempData(ctx, ctx.Dir("repair"), false)
+		b.SetBytes(int64(len(data)))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			segment := decode(ctx, 0)
+			readers, err := eestream.EncodeReader2(ctx, segment, rs)
+			require.NoError(b, err)
+			upload(limits, readers)
+			require.NoError(b, segment.Close())
+		}
+	})
+
+	for _, maxBufferMem := range []memory.Size{memory.MiB, 4 * memory.MiB} {
+		maxBufferMem := maxBufferMem
+		b.Run("streaming/"+maxBufferMem.String(), func(b *testing.B) {
+			b.SetBytes(int64(len(data)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				segment := decode(ctx, maxBufferMem.Int()/2)
+				readers := newStreamEncoder(ctx, segment, rs, limits, nonNilCount(limits), streamStallTimeout, maxBufferMem.Int()/2)
+				upload(limits, readers)
+				require.NoError(b, segment.Close())
+			}
+		})
+	}
+}
+
+func newTestRedundancy(t testing.TB, required, repair, optimal, total, shareSize int) eestream.RedundancyStrategy {
+	fec, err := infectious.NewFEC(required, total)
+	require.NoError(t, err)
+	rs, err := eestream.NewRedundancyStrategy(eestream.NewRSScheme(fec, shareSize), repair, optimal)
+	require.NoError(t, err)
+	return rs
+}
+
+func encodePieces(ctx context.Context, t testing.TB, rs eestream.RedundancyStrategy, data []byte) [][]byte {
+	readers, err := eestream.EncodeReader2(fpath.WithTempData(ctx, "", true), bytes.NewReader(data), rs)
+	require.NoError(t, err)
+	return readAllConcurrently(t, readers)
+}
+
+func readAllConcurrently(t testing.TB, readers []io.ReadCloser) [][]byte {
+	pieces := make([][]byte, len(readers))
+	errs := make([]error, len(readers))
+
+	var group sync2.WorkGroup
+	for i, reader := range readers {
+		i, reader := i, reader
+		group.Go(func() {
+			defer func() { _ = reader.Close() }()
+			pieces[i], errs[i] = ioutil.ReadAll(reader)
+		})
+	}
+	group.Wait()
+
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	return pieces
+}
@@ -0,0 +1,518 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package repairer
+
+import (
+	"bytes"
+	"context"
+	"hash"
+	"io"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/context2"
+	"storj.io/common/pb"
+	"storj.io/common/pkcrypto"
+	"storj.io/common/storj"
+	"storj.io/storj/satellite/audit"
+	"storj.io/storj/satellite/metabase"
+	"storj.io/uplink/private/eestream"
+	"storj.io/uplink/private/piecestore"
+)
+
+// streamedSegment is the segment reader returned by ECRepairer.Get when
+// streaming repair is enabled.
+//
+// Piece hashes can only be verified once a piece has been read completely,
+// which happens while the repaired pieces are already being uploaded. The
+// pieces which passed or failed verification are tracked here so the audit
+// results can be reported after the upload.
+type streamedSegment struct {
+	io.ReadCloser
+
+	mu       sync.Mutex
+	streams  []*pieceStream
+	verified metabase.Pieces
+	failed   metabase.Pieces
+}
+
+// streamVerifyGrace is how long the pieces, which were not completely read
+// when the last stripe was decoded, are waited for to be verified.
+const streamVerifyGrace = 5 * time.Second
+
+func (segment *streamedSegment) add(stream *pieceStream) {
+	segment.mu.Lock()
+	defer segment.mu.Unlock()
+	segment.streams = append(segment.streams, stream)
+}
+
+// wait waits until all pieces were read and verified, or failed. The spare
+// pieces are not needed for decoding the last stripe, so their hashes may be
+// verified after the segment was read completely.
+func (segment *streamedSegment) wait(ctx context.Context) {
+	segment.mu.Lock()
+	streams := append([]*pieceStream(nil), segment.streams...)
+	segment.mu.Unlock()
+
+	timer := time.NewTimer(streamVerifyGrace)
+	defer timer.Stop()
+	for _, stream := range streams {
+		select {
+		case <-stream.finished:
+		case <-timer.C:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (segment *streamedSegment) addVerified(piece metabase.Piece) {
+	segment.mu.Lock()
+	defer segment.mu.Unlock()
+	segment.verified = append(segment.verified, piece)
+}
+
+func (segment *streamedSegment) addFailed(piece metabase.Piece) {
+	segment.mu.Lock()
+	defer segment.mu.Unlock()
+	segment.failed = append(segment.failed, piece)
+}
+
+// verifiedPieces returns the report of the pieces returned by Get, updated with
+// the results of the piece hash verification. Pieces which were not read
+// completely are left out of the report.
+func (segment *streamedSegment) verifiedPieces(pieces audit.Pieces) audit.Pieces {
+	segment.mu.Lock()
+	defer segment.mu.Unlock()
+
+	pieces.Successful = append(metabase.Pieces(nil), segment.verified...)
+	pieces.Failed = append(append(metabase.Pieces(nil), pieces.Failed...), segment.failed...)
+	return pieces
+}
+
+// pieceStream reads a piece from a storage node while calculating its hash.
+//
+// The last erasure share of the piece is held back until the whole piece has
+// been downloaded and verified against the signed piece hash, so a segment
+// decoded from corrupted pieces can never be completely read.
+type pieceStream struct {
+	ec         *ECRepairer
+	ctx        context.Context
+	cancel     func(error)
+	piece      metabase.Piece
+	segment    *streamedSegment
+	ps         *piecestore.Client
+	downloader *piecestore.Download
+	hasher     hash.Hash
+
+	// remaining is the number of bytes not yet read from the downloader.
+	remaining int64
+	tailSize  int64
+	pending   []byte
+	err       error
+
+	finished   chan struct{}
+	finishOnce sync.Once
+}
+
+// finish marks the stream as completely read, failed or closed.
+func (stream *pieceStream) finish() {
+	stream.finishOnce.Do(func() { close(stream.finished) })
+}
+
+// prime reads the beginning of the piece, so a node which does not have the
+// piece is detected before the piece is used for decoding. Pieces no larger
+// than a single erasure share are completely verified.
+func (stream *pieceStream) prime() error {
+	size := stream.remaining - stream.tailSize
+	if size <= 0 {
+		return stream.readTail()
+	}
+	if size > stream.tailSize {
+		size = stream.tailSize
+	}
+	head := make([]byte, size)
+	if _, err := io.ReadFull(stream, head); err != nil {
+		return err
+	}
+	stream.pending = head
+	return nil
+}
+
+// Read implements io.Reader.
+func (stream *pieceStream) Read(p []byte) (n int, err error) {
+	if len(stream.pending) > 0 {
+		n = copy(p, stream.pending)
+		stream.pending = stream.pending[n:]
+		return n, nil
+	}
+	if stream.err != nil {
+		return 0, stream.err
+	}
+
+	if stream.remaining <= stream.tailSize {
+		if err := stream.readTail(); err != nil {
+			return 0, err
+		}
+		return stream.Read(p)
+	}
+
+	if int64(len(p)) > stream.remaining-stream.tailSize {
+		p = p[:stream.remaining-stream.tailSize]
+	}
+	n, err = stream.downloader.Read(p)
+	_, _ = stream.hasher.Write(p[:n])
+	stream.remaining -= int64(n)
+	mon.Meter("repair_bytes_downloaded").Mark(n)
+
+	if errs.Is(err, io.EOF) {
+		err = Error.New("didn't download the correct amount of data, missing %d bytes", stream.remaining)
+	}
+	if err != nil {
+		stream.err = err
+		stream.finish()
+	}
+	return n, err
+}
+
+// readTail downloads the rest of the piece and verifies it. On success the
+// tail is made available for reading.
+func (stream *pieceStream) readTail() (err error) {
+	if stream.err != nil {
+		return stream.err
+	}
+	defer stream.finish()
+	defer func() {
+		if err != nil {
+			stream.err = err
+		}
+	}()
+
+	tail := make([]byte, stream.remaining)
+	n, err := io.ReadFull(stream.downloader, tail)
+	_, _ = stream.hasher.Write(tail[:n])
+	mon.Meter("repair_bytes_downloaded").Mark(n)
+	if err != nil {
+		if errs.Is(err, io.ErrUnexpectedEOF) || errs.Is(err, io.EOF) {
+			return Error.New("didn't download the correct amount of data, missing %d bytes", stream.remaining-int64(n))
+		}
+		return err
+	}
+
+	stream.remaining = 0
+
+	if err := stream.verify(); err != nil {
+		if ErrPieceHashVerifyFailed.Has(err) && stream.segment != nil {
+			stream.segment.addFailed(stream.piece)
+		}
+		return err
+	}
+	if stream.segment != nil {
+		stream.segment.addVerified(stream.piece)
+	}
+
+	stream.pending = tail
+	stream.err = io.EOF
+	return nil
+}
+
+// verify checks the original order limit and the hash of the downloaded piece.
+func (stream *pieceStream) verify() (err error) {
+	ctx := stream.ctx
+	defer mon.Task()(&ctx)(&err)
+
+	hash, originalLimit := stream.downloader.GetHashAndLimit()
+	if hash == nil {
+		return Error.New("hash was not sent from storagenode")
+	}
+	if originalLimit == nil {
+		return Error.New("original order limit was not sent from storagenode")
+	}
+
+	if err := verifyOrderLimitSignature(ctx, stream.ec.satelliteSignee, originalLimit); err != nil {
+		return err
+	}
+
+	if err := verifyPieceHash(ctx, originalLimit, hash, stream.hasher.Sum(nil)); err != nil {
+		stream.ec.log.Info("audit failed",
+			zap.Stringer("node ID", stream.piece.StorageNode),
+			zap.Stringer("Piece ID", originalLimit.PieceId),
+			zap.String("reason", err.Error()))
+		return ErrPieceHashVerifyFailed.Wrap(err)
+	}
+	return nil
+}
+
+// Close closes the download and the connection to the storage node.
+func (stream *pieceStream) Close() (err error) {
+	if stream.downloader != nil {
+		err = errs.Combine(err, stream.downloader.Close())
+	}
+	if stream.ps != nil {
+		err = errs.Combine(err, stream.ps.Close())
+	}
+	stream.cancel(context.Canceled)
+	stream.finish()
+	return err
+}
+
+// openPieceStream dials the storage node, starts downloading the piece and
+// primes the returned stream. The downloadTimeout only applies to priming, the
+// rest of the piece is read as fast as the repaired pieces are uploaded.
+func (ec *ECRepairer) openPieceStream(ctx context.Context, limit *pb.AddressedOrderLimit, address string, privateKey storj.PiecePrivateKey, pieceSize int64, shareSize int, piece metabase.Piece, segment *streamedSegment) (_ *pieceStream, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	streamCtx, cancel := context2.WithCustomCancel(ctx)
+	stream := &pieceStream{
+		ec:        ec,
+		ctx:       streamCtx,
+		cancel:    cancel,
+		piece:     piece,
+		segment:   segment,
+		hasher:    pkcrypto.NewHash(),
+		remaining: pieceSize,
+		tailSize:  int64(shareSize),
+		finished:  make(chan struct{}),
+	}
+	defer func() {
+		if err != nil {
+			// keep the original error intact for classifying the audit outcome
+			_ = stream.Close()
+		}
+	}()
+
+	timer := time.AfterFunc(ec.downloadTimeout, func() { cancel(context.DeadlineExceeded) })
+	defer timer.Stop()
+
+	stream.ps, err = ec.dialPiecestore(streamCtx, storj.NodeURL{
+		ID:      limit.GetLimit().StorageNodeId,
+		Address: address,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stream.downloader, err = stream.ps.Download(streamCtx, limit.GetLimit(), privateKey, 0, pieceSize)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stream.prime(); err != nil {
+		return nil, err
+	}
+	if segment != nil {
+		segment.add(stream)
+	}
+	return stream, nil
+}
+
+// streamStallTimeout is how long the encoder waits for the upload of a piece,
+// whose buffer is full, before dropping it, as long as the other uploads can
+// still store the needed number of pieces.
+const streamStallTimeout = 5 * time.Second
+
+// streamEncoder erasure encodes a segment into the pieces to upload. Stripes
+// are encoded as the uploads consume them, and every piece buffers at most a
+// fixed number of erasure shares, which bounds the memory used by a repair
+// regardless of the segment size.
+//
+// The uploads proceed at the pace of the slowest one, so an upload which
+// stalls is dropped once the remaining uploads suffice for the repair.
+type streamEncoder struct {
+	rs           eestream.RedundancyStrategy
+	data         io.Reader
+	shares       *sync.Pool
+	pieces       []*encodedStream
+	needed       int
+	stallTimeout time.Duration
+}
+
+// newStreamEncoder starts encoding data for the non-nil limits. The returned
+// readers for nil limits are empty. Uploads stalling for longer than
+// stallTimeout fail, unless fewer than needed uploads would remain.
+func newStreamEncoder(ctx context.Context, data io.Reader, rs eestream.RedundancyStrategy, limits []*pb.AddressedOrderLimit, needed int, stallTimeout time.Duration, maxBufferMem int) []io.ReadCloser {
+	pieceCount := nonNilCount(limits)
+	bufferedShares := 1
+	if pieceCount > 0 {
+		bufferedShares = maxBufferMem / (pieceCount * rs.ErasureShareSize())
+	}
+	if bufferedShares < 1 {
+		bufferedShares = 1
+	}
+
+	shareSize := rs.ErasureShareSize()
+	encoder := &streamEncoder{
+		rs:   rs,
+		data: data,
+		shares: &sync.Pool{New: func() interface{} {
+			share := make([]byte, shareSize)
+			return &share
+		}},
+		pieces:       make([]*encodedStream, len(limits)),
+		needed:       needed,
+		stallTimeout: stallTimeout,
+	}
+
+	readers := make([]io.ReadCloser, len(limits))
+	for i, limit := range limits {
+		if limit == nil {
+			readers[i] = ioutil.NopCloser(bytes.NewReader(nil))
+			continue
+		}
+		encoder.pieces[i] = &encodedStream{
+			pool:   encoder.shares,
+			shares: make(chan *[]byte, bufferedShares),
+			closed: make(chan struct{}),
+		}
+		readers[i] = encoder.pieces[i]
+	}
+
+	go encoder.run(ctx)
+
+	return readers
+}
+
+func (encoder *streamEncoder) run(ctx context.Context) {
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	defer func() {
+		for _, piece := range encoder.pieces {
+			if piece != nil {
+				piece.finish(err)
+			}
+		}
+	}()
+
+	stripe := make([]byte, encoder.rs.StripeSize())
+	for {
+		if encoder.active() == 0 {
+			// all uploads finished or were canceled
+			return
+		}
+
+		_, err = io.ReadFull(encoder.data, stripe)
+		if err != nil {
+			if errs.Is(err, io.EOF) {
+				err = nil
+			}
+			return
+		}
+
+		for i, piece := range encoder.pieces {
+			if piece == nil {
+				continue
+			}
+
+			share := encoder.shares.Get().(*[]byte)
+			if err = encoder.rs.EncodeSingle(stripe, *share, i); err != nil {
+				return
+			}
+			if err = encoder.send(ctx, i, share); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// send passes the share to the i-th piece. When the buffer of the piece is
+// full, it waits for the upload to catch up, for at most stallTimeout if the
+// other uploads can store the needed number of pieces without it.
+func (encoder *streamEncoder) send(ctx context.Context, i int, share *[]byte) error {
+	piece := encoder.pieces[i]
+	select {
+	case piece.shares <- share:
+		return nil
+	case <-piece.closed:
+		encoder.shares.Put(share)
+		encoder.pieces[i] = nil
+		return nil
+	default:
+	}
+
+	var stalled <-chan time.Time
+	if encoder.active()-1 >= encoder.needed {
+		timer := time.NewTimer(encoder.stallTimeout)
+		defer timer.Stop()
+		stalled = timer.C
+	}
+
+	select {
+	case piece.shares <- share:
+	case <-piece.closed:
+		encoder.shares.Put(share)
+		encoder.pieces[i] = nil
+	case <-stalled:
+		mon.Meter("repair_stream_stalled_uploads").Mark(1)
+		encoder.shares.Put(share)
+		encoder.pieces[i] = nil
+		piece.finish(Error.New("upload stalled for %v", encoder.stallTimeout))
+	case <-ctx.Done():
+		encoder.shares.Put(share)
+		return ctx.Err()
+	}
+	return nil
+}
+
+// active returns the number of pieces which are still uploaded.
+func (encoder *streamEncoder) active() int {
+	count := 0
+	for _, piece := range encoder.pieces {
+		if piece != nil {
+			count++
+		}
+	}
+	return count
+}
+
+// encodedStream is the reader for a single piece produced by streamEncoder.
+type encodedStream struct {
+	pool    *sync.Pool
+	shares  chan *[]byte
+	closed  chan struct{}
+	share   *[]byte
+	current []byte
+	err     error
+
+	closeOnce sync.Once
+}
+
+// finish is called by the encoder after the last share was sent.
+func (stream *encodedStream) finish(err error) {
+	stream.err = err
+	close(stream.shares)
+}
+
+// Read implements io.Reader.
+func (stream *encodedStream) Read(p []byte) (n int, err error) {
+	if len(stream.current) == 0 {
+		share, ok := <-stream.shares
+		if !ok {
+			if stream.err != nil {
+				return 0, stream.err
+			}
+			return 0, io.EOF
+		}
+		stream.share, stream.current = share, *share
+	}
+
+	n = copy(p, stream.current)
+	stream.current = stream.current[n:]
+	if len(stream.current) == 0 {
+		stream.pool.Put(stream.share)
+		stream.share = nil
+	}
+	return n, nil
+}
+
+// Close signals the encoder to stop producing shares for this piece.
+func (stream *encodedStream) Close() error {
+	stream.closeOnce.Do(func() { close(stream.closed) })
+	return nil
+}
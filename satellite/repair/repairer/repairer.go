@@ -34,6 +34,8 @@ type Config struct {
 	MaxBufferMem                  memory.Size   `help:"maximum buffer memory (in bytes) to be allocated for read buffers" default:"4.0 MiB"`
 	MaxExcessRateOptimalThreshold float64       `help:"ratio applied to the optimal threshold to calculate the excess of the maximum number of repaired pieces to upload" default:"0.05"`
 	InMemoryRepair                bool          `help:"whether to download pieces for repair in memory (true) or download to disk (false)" default:"false"`
+	StreamingRepair               bool          `help:"whether to stream pieces through repair using at most max-buffer-mem per segment instead of downloading whole pieces; overrides in-memory-repair" default:"false"`
+	StreamingSparePieces          int           `help:"number of pieces downloaded in addition to the required ones by streaming repair, so that a corrupted piece can be corrected" default:"2"`
 }
 
 // Service contains the information needed to run the repair service.
@@ -279,12 +279,18 @@ func (repairer *SegmentRepairer) Repair(ctx context.Context, queueSegment *queue
 		minSuccessfulNeeded = redundancy.OptimalThreshold() - len(healthyPieces) + numHealthyInExcludedCountries
 	}
 
+	existingNodeIDs := make([]storj.NodeID, len(healthyPieces))
+	for i, piece := range healthyPieces {
+		existingNodeIDs[i] = piece.StorageNode
+	}
+
 	// Request Overlay for n-h new storage nodes
 	request := overlay.FindStorageNodesRequest{
 		RequestedCount: requestCount,
 		ExcludedIDs:    excludeNodeIDs,
+		ExistingIDs:    existingNodeIDs,
 	}
-	newNodes, err := repairer.overlay.FindStorageNodesForUpload(ctx, request)
+	newNodes, err := repairer.overlay.FindStorageNodesForRepair(ctx, request)
 	if err != nil {
 		return false, overlayQueryError.Wrap(err)
 	}
@@ -355,36 +361,22 @@ func (repairer *SegmentRepairer) Repair(ctx context.Context, queueSegment *queue
 	}
 	defer func() { err = errs.Combine(err, segmentReader.Close()) }()
 
-	// only report audit result when segment can be successfully downloaded
-	cachedNodesReputation := make(map[storj.NodeID]overlay.ReputationStatus, len(cachedNodesInfo))
-	for id, info := range cachedNodesInfo {
-		cachedNodesReputation[id] = info.Reputation
-	}
-
-	report := audit.Report{
-		NodesReputation: cachedNodesReputation,
-	}
-
-	for _, piece := range piecesReport.Successful {
-		report.Successes = append(report.Successes, piece.StorageNode)
-	}
-	for _, piece := range piecesReport.Failed {
-		report.Fails = append(report.Fails, piece.StorageNode)
-	}
-	for _, piece := range piecesReport.Offline {
-		report.Offlines = append(report.Offlines, piece.StorageNode)
-	}
-	for _, piece := range piecesReport.Unknown {
-		report.Unknown = append(report.Unknown, piece.StorageNode)
-	}
-	_, reportErr := repairer.reporter.RecordAudits(ctx, report)
-	if reportErr != nil {
-		// failed updates should not affect repair, therefore we will not return the error
-		repairer.log.Debug("failed to record audit", zap.Error(reportErr))
+	// only report audit result when segment can be successfully downloaded.
+	// Pieces of a streamed segment are verified only while the repaired pieces
+	// are uploaded, so their results are reported after the upload.
+	streamed, isStreamed := segmentReader.(*streamedSegment)
+	if !isStreamed {
+		repairer.recordAudits(ctx, cachedNodesInfo, piecesReport)
 	}
 
 	// Upload the repaired pieces
 	successfulNodes, _, err := repairer.ec.Repair(ctx, putLimits, putPrivateKey, redundancy, segmentReader, repairer.timeout, minSuccessfulNeeded)
+	if isStreamed {
+		if err == nil {
+			streamed.wait(ctx)
+		}
+		repairer.recordAudits(ctx, cachedNodesInfo, streamed.verifiedPieces(piecesReport))
+	}
 	if err != nil {
 		return false, repairPutError.Wrap(err)
 	}
@@ -522,6 +514,38 @@ func (repairer *SegmentRepairer) checkIfSegmentAltered(ctx context.Context, oldS
 	return nil
 }
 
+// recordAudits reports the outcome of downloading the pieces as audit results.
+func (repairer *SegmentRepairer) recordAudits(ctx context.Context, cachedNodesInfo map[storj.NodeID]overlay.NodeReputation, piecesReport audit.Pieces) {
+	defer mon.Task()(&ctx)(nil)
+
+	cachedNodesReputation := make(map[storj.NodeID]overlay.ReputationStatus, len(cachedNodesInfo))
+	for id, info := range cachedNodesInfo {
+		cachedNodesReputation[id] = info.Reputation
+	}
+
+	report := audit.Report{
+		NodesReputation: cachedNodesReputation,
+	}
+
+	for _, piece := range piecesReport.Successful {
+		report.Successes = append(report.Successes, piece.StorageNode)
+	}
+	for _, piece := range piecesReport.Failed {
+		report.Fails = append(report.Fails, piece.StorageNode)
+	}
+	for _, piece := range piecesReport.Offline {
+		report.Offlines = append(report.Offlines, piece.StorageNode)
+	}
+	for _, piece := range piecesReport.Unknown {
+		report.Unknown = append(report.Unknown, piece.StorageNode)
+	}
+	_, reportErr := repairer.reporter.RecordAudits(ctx, report)
+	if reportErr != nil {
+		// failed updates should not affect repair, therefore we will not return the error
+		repairer.log.Debug("failed to record audit", zap.Error(reportErr))
+	}
+}
+
 func (repairer *SegmentRepairer) getStatsByRS(redundancy *pb.RedundancyScheme) *stats {
 	rsString := getRSString(repairer.loadRedundancy(redundancy))
 	return repairer.statsCollector.getStatsByRS(rsString)
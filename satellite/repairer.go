@@ -190,6 +190,7 @@ func NewRepairer(log *zap.Logger, full *identity.FullIdentity,
 			peer.Overlay,
 			peer.Orders.DB,
 			peer.Buckets.Service,
+			nil,
 			config.Orders,
 		)
 		if err != nil {
@@ -212,7 +213,10 @@ func NewRepairer(log *zap.Logger, full *identity.FullIdentity,
 			peer.Dialer,
 			signing.SigneeFromPeerIdentity(peer.Identity.PeerIdentity()),
 			config.Repairer.DownloadTimeout,
-			config.Repairer.InMemoryRepair)
+			config.Repairer.InMemoryRepair,
+			config.Repairer.StreamingRepair,
+			config.Repairer.StreamingSparePieces,
+			config.Repairer.MaxBufferMem)
 
 		peer.SegmentRepairer = repairer.NewSegmentRepairer(
 			log.Named("segment-repair"),
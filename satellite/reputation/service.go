@@ -84,6 +84,18 @@ func (service *Service) ApplyAudit(ctx context.Context, nodeID storj.NodeID, rep
 	defer mon.Task()(&ctx)(&err)
 
 	now := time.Now()
+	if result == AuditOffline {
+		// nodes are expected to be offline during planned maintenance.
+		inMaintenance, err := service.overlay.GetNodesInMaintenance(ctx, storj.NodeIDList{nodeID}, now)
+		if err != nil {
+			return err
+		}
+		if len(inMaintenance) > 0 {
+			mon.Counter("audit_offline_in_maintenance").Inc(1)
+			return nil
+		}
+	}
+
 	statusUpdate, err := service.db.Update(ctx, UpdateRequest{
 		NodeID:       nodeID,
 		AuditOutcome: result,
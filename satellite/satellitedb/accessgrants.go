@@ -0,0 +1,129 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitedb
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/satellitedb/dbx"
+)
+
+// ensures that *accessGrants implements console.AccessGrants.
+var _ console.AccessGrants = (*accessGrants)(nil)
+
+// macaroonTailSize is the size of a macaroon tail, which is a HMAC-SHA256.
+const macaroonTailSize = sha256.Size
+
+type accessGrants struct {
+	db dbx.Methods
+}
+
+// Insert inserts the registered access grant.
+func (db *accessGrants) Insert(ctx context.Context, accessGrant console.AccessGrant) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	for _, tail := range accessGrant.Tails {
+		if len(tail) != macaroonTailSize {
+			return Error.New("invalid macaroon tail size %d", len(tail))
+		}
+	}
+
+	return db.db.CreateNoReturn_AccessGrant(ctx,
+		dbx.AccessGrant_Id(accessGrant.ID.Bytes()),
+		dbx.AccessGrant_ProjectId(accessGrant.ProjectID.Bytes()),
+		dbx.AccessGrant_ApiKeyId(accessGrant.APIKeyID.Bytes()),
+		dbx.AccessGrant_Name(accessGrant.Name),
+		dbx.AccessGrant_Caveats(accessGrant.Caveats),
+		dbx.AccessGrant_Tails(bytes.Join(accessGrant.Tails, nil)),
+		dbx.AccessGrant_CreatedBy(accessGrant.CreatedBy.Bytes()),
+	)
+}
+
+// Get returns the registered access grant.
+func (db *accessGrants) Get(ctx context.Context, id uuid.UUID) (_ *console.AccessGrant, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	dbxAccessGrant, err := db.db.Get_AccessGrant_By_Id(ctx, dbx.AccessGrant_Id(id.Bytes()))
+	if err != nil {
+		return nil, err
+	}
+
+	return accessGrantFromDBX(dbxAccessGrant)
+}
+
+// GetByProjectID returns the access grants registered in the project.
+func (db *accessGrants) GetByProjectID(ctx context.Context, projectID uuid.UUID) (_ []console.AccessGrant, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	dbxAccessGrants, err := db.db.All_AccessGrant_By_ProjectId_OrderBy_Asc_CreatedAt(ctx, dbx.AccessGrant_ProjectId(projectID.Bytes()))
+	if err != nil {
+		return nil, err
+	}
+
+	accessGrants := make([]console.AccessGrant, 0, len(dbxAccessGrants))
+	for _, dbxAccessGrant := range dbxAccessGrants {
+		accessGrant, err := accessGrantFromDBX(dbxAccessGrant)
+		if err != nil {
+			return nil, err
+		}
+		accessGrants = append(accessGrants, *accessGrant)
+	}
+
+	return accessGrants, nil
+}
+
+// Delete removes the registered access grant.
+func (db *accessGrants) Delete(ctx context.Context, id uuid.UUID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = db.db.Delete_AccessGrant_By_Id(ctx, dbx.AccessGrant_Id(id.Bytes()))
+	return err
+}
+
+// accessGrantFromDBX is used for creating AccessGrant entity from autogenerated dbx.AccessGrant struct.
+func accessGrantFromDBX(accessGrant *dbx.AccessGrant) (_ *console.AccessGrant, err error) {
+	id, err := uuid.FromBytes(accessGrant.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	projectID, err := uuid.FromBytes(accessGrant.ProjectId)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKeyID, err := uuid.FromBytes(accessGrant.ApiKeyId)
+	if err != nil {
+		return nil, err
+	}
+
+	createdBy, err := uuid.FromBytes(accessGrant.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(accessGrant.Tails)%macaroonTailSize != 0 {
+		return nil, Error.New("invalid macaroon tails size %d", len(accessGrant.Tails))
+	}
+
+	tails := make([][]byte, 0, len(accessGrant.Tails)/macaroonTailSize)
+	for i := 0; i < len(accessGrant.Tails); i += macaroonTailSize {
+		tails = append(tails, accessGrant.Tails[i:i+macaroonTailSize])
+	}
+
+	return &console.AccessGrant{
+		ID:        id,
+		ProjectID: projectID,
+		APIKeyID:  apiKeyID,
+		Name:      accessGrant.Name,
+		Caveats:   accessGrant.Caveats,
+		Tails:     tails,
+		CreatedBy: createdBy,
+		CreatedAt: accessGrant.CreatedAt,
+	}, nil
+}
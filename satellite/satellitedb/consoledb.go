@@ -74,6 +74,31 @@ func (db *ConsoleDB) WebappSessions() consoleauth.WebappSessions {
 	return &webappSessions{db.methods}
 }
 
+// SSOIdentities is a getter for SSOIdentities repository.
+func (db *ConsoleDB) SSOIdentities() console.SSOIdentities {
+	return &ssoIdentities{db.methods}
+}
+
+// Organizations is a getter for Organizations repository.
+func (db *ConsoleDB) Organizations() console.Organizations {
+	return &organizations{db.methods, db.db}
+}
+
+// ProjectSpendingCaps is a getter for ProjectSpendingCaps repository.
+func (db *ConsoleDB) ProjectSpendingCaps() console.ProjectSpendingCaps {
+	return &projectSpendingCaps{db.methods}
+}
+
+// AccessGrants is a getter for AccessGrants repository.
+func (db *ConsoleDB) AccessGrants() console.AccessGrants {
+	return &accessGrants{db.methods}
+}
+
+// ProjectUsageExports is a getter for ProjectUsageExports repository.
+func (db *ConsoleDB) ProjectUsageExports() console.ProjectUsageExports {
+	return &projectUsageExports{db.methods}
+}
+
 // WithTx is a method for executing and retrying transaction.
 func (db *ConsoleDB) WithTx(ctx context.Context, fn func(context.Context, console.DBTx) error) error {
 	if db.db == nil {
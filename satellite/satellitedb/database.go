@@ -28,6 +28,7 @@ import (
 	"storj.io/storj/satellite/orders"
 	"storj.io/storj/satellite/overlay"
 	"storj.io/storj/satellite/payments/billing"
+	"storj.io/storj/satellite/payments/invoicing"
 	"storj.io/storj/satellite/payments/storjscan"
 	"storj.io/storj/satellite/payments/stripecoinpayments"
 	"storj.io/storj/satellite/repair/queue"
@@ -256,6 +257,11 @@ func (dbc *satelliteDBCollection) Containment() audit.Containment {
 	return &containment{db: dbc.getByName("containment")}
 }
 
+// NodeAuditQueue returns the queue of piece-level node audits.
+func (dbc *satelliteDBCollection) NodeAuditQueue() audit.NodeAuditQueue {
+	return &nodeAuditQueue{db: dbc.getByName("nodeauditqueue")}
+}
+
 // GracefulExit returns database for graceful exit.
 func (dbc *satelliteDBCollection) GracefulExit() gracefulexit.DB {
 	return &gracefulexitDB{db: dbc.getByName("gracefulexit")}
@@ -271,6 +277,11 @@ func (dbc *satelliteDBCollection) Billing() billing.TransactionsDB {
 	return &billingDB{db: dbc.getByName("billing")}
 }
 
+// Invoicing returns database for the invoices of the built-in invoicing.
+func (dbc *satelliteDBCollection) Invoicing() invoicing.DB {
+	return &invoicingDB{db: dbc.getByName("invoicing")}
+}
+
 // Wallets returns database for storjscan wallets.
 func (dbc *satelliteDBCollection) Wallets() storjscan.WalletsDB {
 	return &storjscanWalletsDB{db: dbc.getByName("storjscan")}
@@ -336,6 +336,20 @@ CREATE TABLE accounting_timestamps (
 	value timestamp with time zone NOT NULL,
 	PRIMARY KEY ( name )
 );
+CREATE TABLE billing_invoices (
+	id bytea NOT NULL,
+	user_id bytea NOT NULL,
+	period_start timestamp with time zone NOT NULL,
+	period_end timestamp with time zone NOT NULL,
+	description text NOT NULL,
+	items bytea NOT NULL,
+	amount bigint NOT NULL,
+	status text NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	paid_at timestamp with time zone,
+	PRIMARY KEY ( id ),
+	UNIQUE ( user_id, period_start )
+);
 CREATE TABLE billing_transactions (
 	tx_id bytea NOT NULL,
 	user_id bytea NOT NULL,
@@ -493,6 +507,19 @@ CREATE TABLE node_api_versions (
 	updated_at timestamp with time zone NOT NULL,
 	PRIMARY KEY ( id )
 );
+CREATE TABLE node_audit_queue (
+	node_id bytea NOT NULL,
+	pieces integer NOT NULL,
+	requested_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( node_id )
+);
+CREATE TABLE node_maintenance_windows (
+	node_id bytea NOT NULL,
+	start_time timestamp with time zone NOT NULL,
+	end_time timestamp with time zone NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( node_id, start_time )
+);
 CREATE TABLE oauth_clients (
 	id bytea NOT NULL,
 	encrypted_secret bytea NOT NULL,
@@ -523,6 +550,8 @@ CREATE TABLE oauth_tokens (
 	token bytea NOT NULL,
 	created_at timestamp with time zone NOT NULL,
 	expires_at timestamp with time zone NOT NULL,
+	name text,
+	last_used_at timestamp with time zone,
 	PRIMARY KEY ( token )
 );
 CREATE TABLE offers (
@@ -540,6 +569,12 @@ CREATE TABLE offers (
 	type integer NOT NULL,
 	PRIMARY KEY ( id )
 );
+CREATE TABLE organizations (
+	id bytea NOT NULL,
+	name text NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( id )
+);
 CREATE TABLE peer_identities (
 	node_id bytea NOT NULL,
 	leaf_serial_number bytea NOT NULL,
@@ -557,10 +592,13 @@ CREATE TABLE projects (
 	segment_limit bigint DEFAULT 1000000,
 	rate_limit integer,
 	burst_limit integer,
+	bandwidth_rate_limit bigint,
+	bandwidth_burst_limit bigint,
 	max_buckets integer,
 	partner_id bytea,
 	user_agent bytea,
 	owner_id bytea NOT NULL,
+	organization_id bytea,
 	created_at timestamp with time zone NOT NULL,
 	PRIMARY KEY ( id )
 );
@@ -638,6 +676,13 @@ CREATE TABLE segment_pending_audits (
 	reverify_count bigint NOT NULL,
 	PRIMARY KEY ( node_id )
 );
+CREATE TABLE sso_identities (
+	issuer text NOT NULL,
+	subject text NOT NULL,
+	user_id bytea NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( issuer, subject )
+);
 CREATE TABLE storagenode_bandwidth_rollups (
 	storagenode_id bytea NOT NULL,
 	interval_start timestamp with time zone NOT NULL,
@@ -801,6 +846,8 @@ CREATE TABLE webapp_sessions (
 	user_agent text NOT NULL,
 	status integer NOT NULL,
 	expires_at timestamp with time zone NOT NULL,
+	created_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	last_seen_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
 	PRIMARY KEY ( id )
 );
 CREATE TABLE api_keys (
@@ -837,12 +884,41 @@ CREATE TABLE bucket_metainfos (
 	PRIMARY KEY ( id ),
 	UNIQUE ( project_id, name )
 );
+CREATE TABLE organization_members (
+	organization_id bytea NOT NULL REFERENCES organizations( id ) ON DELETE CASCADE,
+	member_id bytea NOT NULL REFERENCES users( id ) ON DELETE CASCADE,
+	created_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( organization_id, member_id )
+);
 CREATE TABLE project_members (
 	member_id bytea NOT NULL REFERENCES users( id ) ON DELETE CASCADE,
 	project_id bytea NOT NULL REFERENCES projects( id ) ON DELETE CASCADE,
 	created_at timestamp with time zone NOT NULL,
 	PRIMARY KEY ( member_id, project_id )
 );
+CREATE TABLE project_spending_caps (
+	project_id bytea NOT NULL REFERENCES projects( id ) ON DELETE CASCADE,
+	cap bigint NOT NULL,
+	alert_thresholds text NOT NULL,
+	enforce boolean NOT NULL,
+	limit_bandwidth boolean NOT NULL,
+	alert_period timestamp with time zone NOT NULL,
+	alerted_threshold integer NOT NULL,
+	capped boolean NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( project_id )
+);
+CREATE TABLE project_usage_exports (
+	project_id bytea NOT NULL REFERENCES projects( id ) ON DELETE CASCADE,
+	encrypted_access bytea NOT NULL,
+	bucket text NOT NULL,
+	prefix text NOT NULL,
+	format text NOT NULL,
+	schedule text NOT NULL,
+	exported_until timestamp with time zone NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( project_id )
+);
 CREATE TABLE stripecoinpayments_apply_balance_intents (
 	tx_id text NOT NULL REFERENCES coinpayments_transactions( id ) ON DELETE CASCADE,
 	state integer NOT NULL,
@@ -862,6 +938,17 @@ CREATE TABLE user_credits (
 	PRIMARY KEY ( id ),
 	UNIQUE ( id, offer_id )
 );
+CREATE TABLE access_grants (
+	id bytea NOT NULL,
+	project_id bytea NOT NULL REFERENCES projects( id ) ON DELETE CASCADE,
+	api_key_id bytea NOT NULL REFERENCES api_keys( id ) ON DELETE CASCADE,
+	name text NOT NULL,
+	caveats text NOT NULL,
+	tails bytea NOT NULL,
+	created_by bytea NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( id )
+);
 CREATE INDEX accounting_rollups_start_time_index ON accounting_rollups ( start_time ) ;
 CREATE INDEX bucket_bandwidth_rollups_project_id_action_interval_index ON bucket_bandwidth_rollups ( project_id, action, interval_start ) ;
 CREATE INDEX bucket_bandwidth_rollups_action_interval_project_id_index ON bucket_bandwidth_rollups ( action, interval_start, project_id ) ;
@@ -876,11 +963,13 @@ CREATE INDEX nodes_dis_unk_aud_exit_init_rel_type_last_cont_success_stored_index
 CREATE INDEX oauth_clients_user_id_index ON oauth_clients ( user_id ) ;
 CREATE INDEX oauth_codes_user_id_index ON oauth_codes ( user_id ) ;
 CREATE INDEX oauth_codes_client_id_index ON oauth_codes ( client_id ) ;
-CREATE INDEX oauth_tokens_user_id_index ON oauth_tokens ( user_id ) ;
+CREATE INDEX oauth_tokens_user_id_kind_index ON oauth_tokens ( user_id, kind ) ;
 CREATE INDEX oauth_tokens_client_id_index ON oauth_tokens ( client_id ) ;
 CREATE INDEX projects_public_id_index ON projects ( public_id ) ;
+CREATE INDEX projects_organization_id_index ON projects ( organization_id ) ;
 CREATE INDEX repair_queue_updated_at_index ON repair_queue ( updated_at ) ;
 CREATE INDEX repair_queue_num_healthy_pieces_attempted_at_index ON repair_queue ( segment_health, attempted_at ) ;
+CREATE INDEX sso_identities_user_id_index ON sso_identities ( user_id ) ;
 CREATE INDEX storagenode_bandwidth_rollups_interval_start_index ON storagenode_bandwidth_rollups ( interval_start ) ;
 CREATE INDEX storagenode_bandwidth_rollup_archives_interval_start_index ON storagenode_bandwidth_rollup_archives ( interval_start ) ;
 CREATE INDEX storagenode_payments_node_id_period_index ON storagenode_payments ( node_id, period ) ;
@@ -888,6 +977,8 @@ CREATE INDEX storagenode_paystubs_node_id_index ON storagenode_paystubs ( node_i
 CREATE INDEX storagenode_storage_tallies_node_id_index ON storagenode_storage_tallies ( node_id ) ;
 CREATE INDEX storjscan_payments_block_number_log_index_index ON storjscan_payments ( block_number, log_index ) ;
 CREATE INDEX webapp_sessions_user_id_index ON webapp_sessions ( user_id ) ;
+CREATE INDEX organization_members_member_id_index ON organization_members ( member_id ) ;
+CREATE INDEX access_grants_project_id_index ON access_grants ( project_id ) ;
 CREATE UNIQUE INDEX credits_earned_user_id_offer_id ON user_credits ( id, offer_id ) ;`
 }
 
@@ -1004,6 +1095,20 @@ CREATE TABLE accounting_timestamps (
 	value timestamp with time zone NOT NULL,
 	PRIMARY KEY ( name )
 );
+CREATE TABLE billing_invoices (
+	id bytea NOT NULL,
+	user_id bytea NOT NULL,
+	period_start timestamp with time zone NOT NULL,
+	period_end timestamp with time zone NOT NULL,
+	description text NOT NULL,
+	items bytea NOT NULL,
+	amount bigint NOT NULL,
+	status text NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	paid_at timestamp with time zone,
+	PRIMARY KEY ( id ),
+	UNIQUE ( user_id, period_start )
+);
 CREATE TABLE billing_transactions (
 	tx_id bytea NOT NULL,
 	user_id bytea NOT NULL,
@@ -1161,6 +1266,19 @@ CREATE TABLE node_api_versions (
 	updated_at timestamp with time zone NOT NULL,
 	PRIMARY KEY ( id )
 );
+CREATE TABLE node_audit_queue (
+	node_id bytea NOT NULL,
+	pieces integer NOT NULL,
+	requested_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( node_id )
+);
+CREATE TABLE node_maintenance_windows (
+	node_id bytea NOT NULL,
+	start_time timestamp with time zone NOT NULL,
+	end_time timestamp with time zone NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( node_id, start_time )
+);
 CREATE TABLE oauth_clients (
 	id bytea NOT NULL,
 	encrypted_secret bytea NOT NULL,
@@ -1191,6 +1309,8 @@ CREATE TABLE oauth_tokens (
 	token bytea NOT NULL,
 	created_at timestamp with time zone NOT NULL,
 	expires_at timestamp with time zone NOT NULL,
+	name text,
+	last_used_at timestamp with time zone,
 	PRIMARY KEY ( token )
 );
 CREATE TABLE offers (
@@ -1208,6 +1328,12 @@ CREATE TABLE offers (
 	type integer NOT NULL,
 	PRIMARY KEY ( id )
 );
+CREATE TABLE organizations (
+	id bytea NOT NULL,
+	name text NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( id )
+);
 CREATE TABLE peer_identities (
 	node_id bytea NOT NULL,
 	leaf_serial_number bytea NOT NULL,
@@ -1225,10 +1351,13 @@ CREATE TABLE projects (
 	segment_limit bigint DEFAULT 1000000,
 	rate_limit integer,
 	burst_limit integer,
+	bandwidth_rate_limit bigint,
+	bandwidth_burst_limit bigint,
 	max_buckets integer,
 	partner_id bytea,
 	user_agent bytea,
 	owner_id bytea NOT NULL,
+	organization_id bytea,
 	created_at timestamp with time zone NOT NULL,
 	PRIMARY KEY ( id )
 );
@@ -1306,6 +1435,13 @@ CREATE TABLE segment_pending_audits (
 	reverify_count bigint NOT NULL,
 	PRIMARY KEY ( node_id )
 );
+CREATE TABLE sso_identities (
+	issuer text NOT NULL,
+	subject text NOT NULL,
+	user_id bytea NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( issuer, subject )
+);
 CREATE TABLE storagenode_bandwidth_rollups (
 	storagenode_id bytea NOT NULL,
 	interval_start timestamp with time zone NOT NULL,
@@ -1469,6 +1605,8 @@ CREATE TABLE webapp_sessions (
 	user_agent text NOT NULL,
 	status integer NOT NULL,
 	expires_at timestamp with time zone NOT NULL,
+	created_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	last_seen_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
 	PRIMARY KEY ( id )
 );
 CREATE TABLE api_keys (
@@ -1505,12 +1643,41 @@ CREATE TABLE bucket_metainfos (
 	PRIMARY KEY ( id ),
 	UNIQUE ( project_id, name )
 );
+CREATE TABLE organization_members (
+	organization_id bytea NOT NULL REFERENCES organizations( id ) ON DELETE CASCADE,
+	member_id bytea NOT NULL REFERENCES users( id ) ON DELETE CASCADE,
+	created_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( organization_id, member_id )
+);
 CREATE TABLE project_members (
 	member_id bytea NOT NULL REFERENCES users( id ) ON DELETE CASCADE,
 	project_id bytea NOT NULL REFERENCES projects( id ) ON DELETE CASCADE,
 	created_at timestamp with time zone NOT NULL,
 	PRIMARY KEY ( member_id, project_id )
 );
+CREATE TABLE project_spending_caps (
+	project_id bytea NOT NULL REFERENCES projects( id ) ON DELETE CASCADE,
+	cap bigint NOT NULL,
+	alert_thresholds text NOT NULL,
+	enforce boolean NOT NULL,
+	limit_bandwidth boolean NOT NULL,
+	alert_period timestamp with time zone NOT NULL,
+	alerted_threshold integer NOT NULL,
+	capped boolean NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( project_id )
+);
+CREATE TABLE project_usage_exports (
+	project_id bytea NOT NULL REFERENCES projects( id ) ON DELETE CASCADE,
+	encrypted_access bytea NOT NULL,
+	bucket text NOT NULL,
+	prefix text NOT NULL,
+	format text NOT NULL,
+	schedule text NOT NULL,
+	exported_until timestamp with time zone NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( project_id )
+);
 CREATE TABLE stripecoinpayments_apply_balance_intents (
 	tx_id text NOT NULL REFERENCES coinpayments_transactions( id ) ON DELETE CASCADE,
 	state integer NOT NULL,
@@ -1530,6 +1697,17 @@ CREATE TABLE user_credits (
 	PRIMARY KEY ( id ),
 	UNIQUE ( id, offer_id )
 );
+CREATE TABLE access_grants (
+	id bytea NOT NULL,
+	project_id bytea NOT NULL REFERENCES projects( id ) ON DELETE CASCADE,
+	api_key_id bytea NOT NULL REFERENCES api_keys( id ) ON DELETE CASCADE,
+	name text NOT NULL,
+	caveats text NOT NULL,
+	tails bytea NOT NULL,
+	created_by bytea NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( id )
+);
 CREATE INDEX accounting_rollups_start_time_index ON accounting_rollups ( start_time ) ;
 CREATE INDEX bucket_bandwidth_rollups_project_id_action_interval_index ON bucket_bandwidth_rollups ( project_id, action, interval_start ) ;
 CREATE INDEX bucket_bandwidth_rollups_action_interval_project_id_index ON bucket_bandwidth_rollups ( action, interval_start, project_id ) ;
@@ -1544,11 +1722,13 @@ CREATE INDEX nodes_dis_unk_aud_exit_init_rel_type_last_cont_success_stored_index
 CREATE INDEX oauth_clients_user_id_index ON oauth_clients ( user_id ) ;
 CREATE INDEX oauth_codes_user_id_index ON oauth_codes ( user_id ) ;
 CREATE INDEX oauth_codes_client_id_index ON oauth_codes ( client_id ) ;
-CREATE INDEX oauth_tokens_user_id_index ON oauth_tokens ( user_id ) ;
+CREATE INDEX oauth_tokens_user_id_kind_index ON oauth_tokens ( user_id, kind ) ;
 CREATE INDEX oauth_tokens_client_id_index ON oauth_tokens ( client_id ) ;
 CREATE INDEX projects_public_id_index ON projects ( public_id ) ;
+CREATE INDEX projects_organization_id_index ON projects ( organization_id ) ;
 CREATE INDEX repair_queue_updated_at_index ON repair_queue ( updated_at ) ;
 CREATE INDEX repair_queue_num_healthy_pieces_attempted_at_index ON repair_queue ( segment_health, attempted_at ) ;
+CREATE INDEX sso_identities_user_id_index ON sso_identities ( user_id ) ;
 CREATE INDEX storagenode_bandwidth_rollups_interval_start_index ON storagenode_bandwidth_rollups ( interval_start ) ;
 CREATE INDEX storagenode_bandwidth_rollup_archives_interval_start_index ON storagenode_bandwidth_rollup_archives ( interval_start ) ;
 CREATE INDEX storagenode_payments_node_id_period_index ON storagenode_payments ( node_id, period ) ;
@@ -1556,6 +1736,8 @@ CREATE INDEX storagenode_paystubs_node_id_index ON storagenode_paystubs ( node_i
 CREATE INDEX storagenode_storage_tallies_node_id_index ON storagenode_storage_tallies ( node_id ) ;
 CREATE INDEX storjscan_payments_block_number_log_index_index ON storjscan_payments ( block_number, log_index ) ;
 CREATE INDEX webapp_sessions_user_id_index ON webapp_sessions ( user_id ) ;
+CREATE INDEX organization_members_member_id_index ON organization_members ( member_id ) ;
+CREATE INDEX access_grants_project_id_index ON access_grants ( project_id ) ;
 CREATE UNIQUE INDEX credits_earned_user_id_offer_id ON user_credits ( id, offer_id ) ;`
 }
 
@@ -1837,6 +2019,233 @@ func (f AccountingTimestamps_Value_Field) value() interface{} {
 
 func (AccountingTimestamps_Value_Field) _Column() string { return "value" }
 
+type BillingInvoice struct {
+	Id          []byte
+	UserId      []byte
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	Description string
+	Items       []byte
+	Amount      int64
+	Status      string
+	CreatedAt   time.Time
+	PaidAt      *time.Time
+}
+
+func (BillingInvoice) _Table() string { return "billing_invoices" }
+
+type BillingInvoice_Create_Fields struct {
+	PaidAt BillingInvoice_PaidAt_Field
+}
+
+type BillingInvoice_Update_Fields struct {
+	Status BillingInvoice_Status_Field
+	PaidAt BillingInvoice_PaidAt_Field
+}
+
+type BillingInvoice_Id_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func BillingInvoice_Id(v []byte) BillingInvoice_Id_Field {
+	return BillingInvoice_Id_Field{_set: true, _value: v}
+}
+
+func (f BillingInvoice_Id_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (BillingInvoice_Id_Field) _Column() string { return "id" }
+
+type BillingInvoice_UserId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func BillingInvoice_UserId(v []byte) BillingInvoice_UserId_Field {
+	return BillingInvoice_UserId_Field{_set: true, _value: v}
+}
+
+func (f BillingInvoice_UserId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (BillingInvoice_UserId_Field) _Column() string { return "user_id" }
+
+type BillingInvoice_PeriodStart_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func BillingInvoice_PeriodStart(v time.Time) BillingInvoice_PeriodStart_Field {
+	return BillingInvoice_PeriodStart_Field{_set: true, _value: v}
+}
+
+func (f BillingInvoice_PeriodStart_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (BillingInvoice_PeriodStart_Field) _Column() string { return "period_start" }
+
+type BillingInvoice_PeriodEnd_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func BillingInvoice_PeriodEnd(v time.Time) BillingInvoice_PeriodEnd_Field {
+	return BillingInvoice_PeriodEnd_Field{_set: true, _value: v}
+}
+
+func (f BillingInvoice_PeriodEnd_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (BillingInvoice_PeriodEnd_Field) _Column() string { return "period_end" }
+
+type BillingInvoice_Description_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func BillingInvoice_Description(v string) BillingInvoice_Description_Field {
+	return BillingInvoice_Description_Field{_set: true, _value: v}
+}
+
+func (f BillingInvoice_Description_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (BillingInvoice_Description_Field) _Column() string { return "description" }
+
+type BillingInvoice_Items_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func BillingInvoice_Items(v []byte) BillingInvoice_Items_Field {
+	return BillingInvoice_Items_Field{_set: true, _value: v}
+}
+
+func (f BillingInvoice_Items_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (BillingInvoice_Items_Field) _Column() string { return "items" }
+
+type BillingInvoice_Amount_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func BillingInvoice_Amount(v int64) BillingInvoice_Amount_Field {
+	return BillingInvoice_Amount_Field{_set: true, _value: v}
+}
+
+func (f BillingInvoice_Amount_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (BillingInvoice_Amount_Field) _Column() string { return "amount" }
+
+type BillingInvoice_Status_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func BillingInvoice_Status(v string) BillingInvoice_Status_Field {
+	return BillingInvoice_Status_Field{_set: true, _value: v}
+}
+
+func (f BillingInvoice_Status_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (BillingInvoice_Status_Field) _Column() string { return "status" }
+
+type BillingInvoice_CreatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func BillingInvoice_CreatedAt(v time.Time) BillingInvoice_CreatedAt_Field {
+	return BillingInvoice_CreatedAt_Field{_set: true, _value: v}
+}
+
+func (f BillingInvoice_CreatedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (BillingInvoice_CreatedAt_Field) _Column() string { return "created_at" }
+
+type BillingInvoice_PaidAt_Field struct {
+	_set   bool
+	_null  bool
+	_value *time.Time
+}
+
+func BillingInvoice_PaidAt(v *time.Time) BillingInvoice_PaidAt_Field {
+	return BillingInvoice_PaidAt_Field{_set: true, _value: v}
+}
+
+func BillingInvoice_PaidAt_Raw(v *time.Time) BillingInvoice_PaidAt_Field {
+	if v == nil {
+		return BillingInvoice_PaidAt_Null()
+	}
+	return BillingInvoice_PaidAt(v)
+}
+
+func BillingInvoice_PaidAt_Null() BillingInvoice_PaidAt_Field {
+	return BillingInvoice_PaidAt_Field{_set: true, _null: true}
+}
+
+func (f BillingInvoice_PaidAt_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+
+func (f BillingInvoice_PaidAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (BillingInvoice_PaidAt_Field) _Column() string { return "paid_at" }
+
 type BillingTransaction struct {
 	TxId        []byte
 	UserId      []byte
@@ -4713,19 +5122,178 @@ func (f NodeApiVersion_UpdatedAt_Field) value() interface{} {
 
 func (NodeApiVersion_UpdatedAt_Field) _Column() string { return "updated_at" }
 
-type OauthClient struct {
-	Id              []byte
-	EncryptedSecret []byte
-	RedirectUrl     string
-	UserId          []byte
-	AppName         string
-	AppLogoUrl      string
+type NodeAuditQueue struct {
+	NodeId      []byte
+	Pieces      int
+	RequestedAt time.Time
 }
 
-func (OauthClient) _Table() string { return "oauth_clients" }
+func (NodeAuditQueue) _Table() string { return "node_audit_queue" }
 
-type OauthClient_Update_Fields struct {
-	EncryptedSecret OauthClient_EncryptedSecret_Field
+type NodeAuditQueue_Update_Fields struct {
+	Pieces      NodeAuditQueue_Pieces_Field
+	RequestedAt NodeAuditQueue_RequestedAt_Field
+}
+
+type NodeAuditQueue_NodeId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func NodeAuditQueue_NodeId(v []byte) NodeAuditQueue_NodeId_Field {
+	return NodeAuditQueue_NodeId_Field{_set: true, _value: v}
+}
+
+func (f NodeAuditQueue_NodeId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (NodeAuditQueue_NodeId_Field) _Column() string { return "node_id" }
+
+type NodeAuditQueue_Pieces_Field struct {
+	_set   bool
+	_null  bool
+	_value int
+}
+
+func NodeAuditQueue_Pieces(v int) NodeAuditQueue_Pieces_Field {
+	return NodeAuditQueue_Pieces_Field{_set: true, _value: v}
+}
+
+func (f NodeAuditQueue_Pieces_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (NodeAuditQueue_Pieces_Field) _Column() string { return "pieces" }
+
+type NodeAuditQueue_RequestedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func NodeAuditQueue_RequestedAt(v time.Time) NodeAuditQueue_RequestedAt_Field {
+	return NodeAuditQueue_RequestedAt_Field{_set: true, _value: v}
+}
+
+func (f NodeAuditQueue_RequestedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (NodeAuditQueue_RequestedAt_Field) _Column() string { return "requested_at" }
+
+type NodeMaintenanceWindow struct {
+	NodeId    []byte
+	StartTime time.Time
+	EndTime   time.Time
+	CreatedAt time.Time
+}
+
+func (NodeMaintenanceWindow) _Table() string { return "node_maintenance_windows" }
+
+type NodeMaintenanceWindow_Update_Fields struct {
+	EndTime NodeMaintenanceWindow_EndTime_Field
+}
+
+type NodeMaintenanceWindow_NodeId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func NodeMaintenanceWindow_NodeId(v []byte) NodeMaintenanceWindow_NodeId_Field {
+	return NodeMaintenanceWindow_NodeId_Field{_set: true, _value: v}
+}
+
+func (f NodeMaintenanceWindow_NodeId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (NodeMaintenanceWindow_NodeId_Field) _Column() string { return "node_id" }
+
+type NodeMaintenanceWindow_StartTime_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func NodeMaintenanceWindow_StartTime(v time.Time) NodeMaintenanceWindow_StartTime_Field {
+	return NodeMaintenanceWindow_StartTime_Field{_set: true, _value: v}
+}
+
+func (f NodeMaintenanceWindow_StartTime_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (NodeMaintenanceWindow_StartTime_Field) _Column() string { return "start_time" }
+
+type NodeMaintenanceWindow_EndTime_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func NodeMaintenanceWindow_EndTime(v time.Time) NodeMaintenanceWindow_EndTime_Field {
+	return NodeMaintenanceWindow_EndTime_Field{_set: true, _value: v}
+}
+
+func (f NodeMaintenanceWindow_EndTime_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (NodeMaintenanceWindow_EndTime_Field) _Column() string { return "end_time" }
+
+type NodeMaintenanceWindow_CreatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func NodeMaintenanceWindow_CreatedAt(v time.Time) NodeMaintenanceWindow_CreatedAt_Field {
+	return NodeMaintenanceWindow_CreatedAt_Field{_set: true, _value: v}
+}
+
+func (f NodeMaintenanceWindow_CreatedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (NodeMaintenanceWindow_CreatedAt_Field) _Column() string { return "created_at" }
+
+type OauthClient struct {
+	Id              []byte
+	EncryptedSecret []byte
+	RedirectUrl     string
+	UserId          []byte
+	AppName         string
+	AppLogoUrl      string
+}
+
+func (OauthClient) _Table() string { return "oauth_clients" }
+
+type OauthClient_Update_Fields struct {
+	EncryptedSecret OauthClient_EncryptedSecret_Field
 	RedirectUrl     OauthClient_RedirectUrl_Field
 	AppName         OauthClient_AppName_Field
 	AppLogoUrl      OauthClient_AppLogoUrl_Field
@@ -5072,19 +5640,27 @@ func (f OauthCode_ClaimedAt_Field) value() interface{} {
 func (OauthCode_ClaimedAt_Field) _Column() string { return "claimed_at" }
 
 type OauthToken struct {
-	ClientId  []byte
-	UserId    []byte
-	Scope     string
-	Kind      int
-	Token     []byte
-	CreatedAt time.Time
-	ExpiresAt time.Time
+	ClientId   []byte
+	UserId     []byte
+	Scope      string
+	Kind       int
+	Token      []byte
+	CreatedAt  time.Time
+	ExpiresAt  time.Time
+	Name       *string
+	LastUsedAt *time.Time
 }
 
 func (OauthToken) _Table() string { return "oauth_tokens" }
 
+type OauthToken_Create_Fields struct {
+	Name       OauthToken_Name_Field
+	LastUsedAt OauthToken_LastUsedAt_Field
+}
+
 type OauthToken_Update_Fields struct {
-	ExpiresAt OauthToken_ExpiresAt_Field
+	ExpiresAt  OauthToken_ExpiresAt_Field
+	LastUsedAt OauthToken_LastUsedAt_Field
 }
 
 type OauthToken_ClientId_Field struct {
@@ -5220,6 +5796,70 @@ func (f OauthToken_ExpiresAt_Field) value() interface{} {
 
 func (OauthToken_ExpiresAt_Field) _Column() string { return "expires_at" }
 
+type OauthToken_Name_Field struct {
+	_set   bool
+	_null  bool
+	_value *string
+}
+
+func OauthToken_Name(v string) OauthToken_Name_Field {
+	return OauthToken_Name_Field{_set: true, _value: &v}
+}
+
+func OauthToken_Name_Raw(v *string) OauthToken_Name_Field {
+	if v == nil {
+		return OauthToken_Name_Null()
+	}
+	return OauthToken_Name(*v)
+}
+
+func OauthToken_Name_Null() OauthToken_Name_Field {
+	return OauthToken_Name_Field{_set: true, _null: true}
+}
+
+func (f OauthToken_Name_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+
+func (f OauthToken_Name_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (OauthToken_Name_Field) _Column() string { return "name" }
+
+type OauthToken_LastUsedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value *time.Time
+}
+
+func OauthToken_LastUsedAt(v time.Time) OauthToken_LastUsedAt_Field {
+	return OauthToken_LastUsedAt_Field{_set: true, _value: &v}
+}
+
+func OauthToken_LastUsedAt_Raw(v *time.Time) OauthToken_LastUsedAt_Field {
+	if v == nil {
+		return OauthToken_LastUsedAt_Null()
+	}
+	return OauthToken_LastUsedAt(*v)
+}
+
+func OauthToken_LastUsedAt_Null() OauthToken_LastUsedAt_Field {
+	return OauthToken_LastUsedAt_Field{_set: true, _null: true}
+}
+
+func (f OauthToken_LastUsedAt_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+
+func (f OauthToken_LastUsedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (OauthToken_LastUsedAt_Field) _Column() string { return "last_used_at" }
+
 type Offer struct {
 	Id                        int
 	Name                      string
@@ -5529,6 +6169,75 @@ func (f Offer_Type_Field) value() interface{} {
 
 func (Offer_Type_Field) _Column() string { return "type" }
 
+type Organization struct {
+	Id        []byte
+	Name      string
+	CreatedAt time.Time
+}
+
+func (Organization) _Table() string { return "organizations" }
+
+type Organization_Update_Fields struct {
+	Name Organization_Name_Field
+}
+
+type Organization_Id_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func Organization_Id(v []byte) Organization_Id_Field {
+	return Organization_Id_Field{_set: true, _value: v}
+}
+
+func (f Organization_Id_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Organization_Id_Field) _Column() string { return "id" }
+
+type Organization_Name_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func Organization_Name(v string) Organization_Name_Field {
+	return Organization_Name_Field{_set: true, _value: v}
+}
+
+func (f Organization_Name_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Organization_Name_Field) _Column() string { return "name" }
+
+type Organization_CreatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func Organization_CreatedAt(v time.Time) Organization_CreatedAt_Field {
+	return Organization_CreatedAt_Field{_set: true, _value: v}
+}
+
+func (f Organization_CreatedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Organization_CreatedAt_Field) _Column() string { return "created_at" }
+
 type PeerIdentity struct {
 	NodeId           []byte
 	LeafSerialNumber []byte
@@ -5620,45 +6329,54 @@ func (f PeerIdentity_UpdatedAt_Field) value() interface{} {
 func (PeerIdentity_UpdatedAt_Field) _Column() string { return "updated_at" }
 
 type Project struct {
-	Id             []byte
-	PublicId       []byte
-	Name           string
-	Description    string
-	UsageLimit     *int64
-	BandwidthLimit *int64
-	SegmentLimit   *int64
-	RateLimit      *int
-	BurstLimit     *int
-	MaxBuckets     *int
-	PartnerId      []byte
-	UserAgent      []byte
-	OwnerId        []byte
-	CreatedAt      time.Time
+	Id                  []byte
+	PublicId            []byte
+	Name                string
+	Description         string
+	UsageLimit          *int64
+	BandwidthLimit      *int64
+	SegmentLimit        *int64
+	RateLimit           *int
+	BurstLimit          *int
+	BandwidthRateLimit  *int64
+	BandwidthBurstLimit *int64
+	MaxBuckets          *int
+	PartnerId           []byte
+	UserAgent           []byte
+	OwnerId             []byte
+	OrganizationId      []byte
+	CreatedAt           time.Time
 }
 
 func (Project) _Table() string { return "projects" }
 
 type Project_Create_Fields struct {
-	PublicId       Project_PublicId_Field
-	UsageLimit     Project_UsageLimit_Field
-	BandwidthLimit Project_BandwidthLimit_Field
-	SegmentLimit   Project_SegmentLimit_Field
-	RateLimit      Project_RateLimit_Field
-	BurstLimit     Project_BurstLimit_Field
-	MaxBuckets     Project_MaxBuckets_Field
-	PartnerId      Project_PartnerId_Field
-	UserAgent      Project_UserAgent_Field
+	PublicId            Project_PublicId_Field
+	UsageLimit          Project_UsageLimit_Field
+	BandwidthLimit      Project_BandwidthLimit_Field
+	SegmentLimit        Project_SegmentLimit_Field
+	RateLimit           Project_RateLimit_Field
+	BurstLimit          Project_BurstLimit_Field
+	BandwidthRateLimit  Project_BandwidthRateLimit_Field
+	BandwidthBurstLimit Project_BandwidthBurstLimit_Field
+	MaxBuckets          Project_MaxBuckets_Field
+	PartnerId           Project_PartnerId_Field
+	UserAgent           Project_UserAgent_Field
+	OrganizationId      Project_OrganizationId_Field
 }
 
 type Project_Update_Fields struct {
-	Name           Project_Name_Field
-	Description    Project_Description_Field
-	UsageLimit     Project_UsageLimit_Field
-	BandwidthLimit Project_BandwidthLimit_Field
-	SegmentLimit   Project_SegmentLimit_Field
-	RateLimit      Project_RateLimit_Field
-	BurstLimit     Project_BurstLimit_Field
-	MaxBuckets     Project_MaxBuckets_Field
+	Name                Project_Name_Field
+	Description         Project_Description_Field
+	UsageLimit          Project_UsageLimit_Field
+	BandwidthLimit      Project_BandwidthLimit_Field
+	SegmentLimit        Project_SegmentLimit_Field
+	RateLimit           Project_RateLimit_Field
+	BurstLimit          Project_BurstLimit_Field
+	BandwidthRateLimit  Project_BandwidthRateLimit_Field
+	BandwidthBurstLimit Project_BandwidthBurstLimit_Field
+	MaxBuckets          Project_MaxBuckets_Field
+	OrganizationId      Project_OrganizationId_Field
 }
 
 type Project_Id_Field struct {
@@ -5910,77 +6628,143 @@ func (f Project_BurstLimit_Field) value() interface{} {
 
 func (Project_BurstLimit_Field) _Column() string { return "burst_limit" }
 
-type Project_MaxBuckets_Field struct {
+type Project_BandwidthRateLimit_Field struct {
 	_set   bool
 	_null  bool
-	_value *int
+	_value *int64
 }
 
-func Project_MaxBuckets(v int) Project_MaxBuckets_Field {
-	return Project_MaxBuckets_Field{_set: true, _value: &v}
+func Project_BandwidthRateLimit(v int64) Project_BandwidthRateLimit_Field {
+	return Project_BandwidthRateLimit_Field{_set: true, _value: &v}
 }
 
-func Project_MaxBuckets_Raw(v *int) Project_MaxBuckets_Field {
+func Project_BandwidthRateLimit_Raw(v *int64) Project_BandwidthRateLimit_Field {
 	if v == nil {
-		return Project_MaxBuckets_Null()
+		return Project_BandwidthRateLimit_Null()
 	}
-	return Project_MaxBuckets(*v)
+	return Project_BandwidthRateLimit(*v)
 }
 
-func Project_MaxBuckets_Null() Project_MaxBuckets_Field {
-	return Project_MaxBuckets_Field{_set: true, _null: true}
+func Project_BandwidthRateLimit_Null() Project_BandwidthRateLimit_Field {
+	return Project_BandwidthRateLimit_Field{_set: true, _null: true}
 }
 
-func (f Project_MaxBuckets_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+func (f Project_BandwidthRateLimit_Field) isnull() bool { return !f._set || f._null || f._value == nil }
 
-func (f Project_MaxBuckets_Field) value() interface{} {
+func (f Project_BandwidthRateLimit_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Project_MaxBuckets_Field) _Column() string { return "max_buckets" }
+func (Project_BandwidthRateLimit_Field) _Column() string { return "bandwidth_rate_limit" }
 
-type Project_PartnerId_Field struct {
+type Project_BandwidthBurstLimit_Field struct {
 	_set   bool
 	_null  bool
-	_value []byte
+	_value *int64
 }
 
-func Project_PartnerId(v []byte) Project_PartnerId_Field {
-	return Project_PartnerId_Field{_set: true, _value: v}
+func Project_BandwidthBurstLimit(v int64) Project_BandwidthBurstLimit_Field {
+	return Project_BandwidthBurstLimit_Field{_set: true, _value: &v}
 }
 
-func Project_PartnerId_Raw(v []byte) Project_PartnerId_Field {
+func Project_BandwidthBurstLimit_Raw(v *int64) Project_BandwidthBurstLimit_Field {
 	if v == nil {
-		return Project_PartnerId_Null()
+		return Project_BandwidthBurstLimit_Null()
 	}
-	return Project_PartnerId(v)
+	return Project_BandwidthBurstLimit(*v)
 }
 
-func Project_PartnerId_Null() Project_PartnerId_Field {
-	return Project_PartnerId_Field{_set: true, _null: true}
+func Project_BandwidthBurstLimit_Null() Project_BandwidthBurstLimit_Field {
+	return Project_BandwidthBurstLimit_Field{_set: true, _null: true}
 }
 
-func (f Project_PartnerId_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+func (f Project_BandwidthBurstLimit_Field) isnull() bool {
+	return !f._set || f._null || f._value == nil
+}
 
-func (f Project_PartnerId_Field) value() interface{} {
+func (f Project_BandwidthBurstLimit_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Project_PartnerId_Field) _Column() string { return "partner_id" }
+func (Project_BandwidthBurstLimit_Field) _Column() string { return "bandwidth_burst_limit" }
 
-type Project_UserAgent_Field struct {
+type Project_MaxBuckets_Field struct {
 	_set   bool
 	_null  bool
-	_value []byte
+	_value *int
 }
 
-func Project_UserAgent(v []byte) Project_UserAgent_Field {
+func Project_MaxBuckets(v int) Project_MaxBuckets_Field {
+	return Project_MaxBuckets_Field{_set: true, _value: &v}
+}
+
+func Project_MaxBuckets_Raw(v *int) Project_MaxBuckets_Field {
+	if v == nil {
+		return Project_MaxBuckets_Null()
+	}
+	return Project_MaxBuckets(*v)
+}
+
+func Project_MaxBuckets_Null() Project_MaxBuckets_Field {
+	return Project_MaxBuckets_Field{_set: true, _null: true}
+}
+
+func (f Project_MaxBuckets_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+
+func (f Project_MaxBuckets_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Project_MaxBuckets_Field) _Column() string { return "max_buckets" }
+
+type Project_PartnerId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func Project_PartnerId(v []byte) Project_PartnerId_Field {
+	return Project_PartnerId_Field{_set: true, _value: v}
+}
+
+func Project_PartnerId_Raw(v []byte) Project_PartnerId_Field {
+	if v == nil {
+		return Project_PartnerId_Null()
+	}
+	return Project_PartnerId(v)
+}
+
+func Project_PartnerId_Null() Project_PartnerId_Field {
+	return Project_PartnerId_Field{_set: true, _null: true}
+}
+
+func (f Project_PartnerId_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+
+func (f Project_PartnerId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Project_PartnerId_Field) _Column() string { return "partner_id" }
+
+type Project_UserAgent_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func Project_UserAgent(v []byte) Project_UserAgent_Field {
 	return Project_UserAgent_Field{_set: true, _value: v}
 }
 
@@ -6025,6 +6809,38 @@ func (f Project_OwnerId_Field) value() interface{} {
 
 func (Project_OwnerId_Field) _Column() string { return "owner_id" }
 
+type Project_OrganizationId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func Project_OrganizationId(v []byte) Project_OrganizationId_Field {
+	return Project_OrganizationId_Field{_set: true, _value: v}
+}
+
+func Project_OrganizationId_Raw(v []byte) Project_OrganizationId_Field {
+	if v == nil {
+		return Project_OrganizationId_Null()
+	}
+	return Project_OrganizationId(v)
+}
+
+func Project_OrganizationId_Null() Project_OrganizationId_Field {
+	return Project_OrganizationId_Field{_set: true, _null: true}
+}
+
+func (f Project_OrganizationId_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+
+func (f Project_OrganizationId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Project_OrganizationId_Field) _Column() string { return "organization_id" }
+
 type Project_CreatedAt_Field struct {
 	_set   bool
 	_null  bool
@@ -7238,6 +8054,94 @@ func (f SegmentPendingAudits_ReverifyCount_Field) value() interface{} {
 
 func (SegmentPendingAudits_ReverifyCount_Field) _Column() string { return "reverify_count" }
 
+type SsoIdentity struct {
+	Issuer    string
+	Subject   string
+	UserId    []byte
+	CreatedAt time.Time
+}
+
+func (SsoIdentity) _Table() string { return "sso_identities" }
+
+type SsoIdentity_Update_Fields struct {
+}
+
+type SsoIdentity_Issuer_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func SsoIdentity_Issuer(v string) SsoIdentity_Issuer_Field {
+	return SsoIdentity_Issuer_Field{_set: true, _value: v}
+}
+
+func (f SsoIdentity_Issuer_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (SsoIdentity_Issuer_Field) _Column() string { return "issuer" }
+
+type SsoIdentity_Subject_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func SsoIdentity_Subject(v string) SsoIdentity_Subject_Field {
+	return SsoIdentity_Subject_Field{_set: true, _value: v}
+}
+
+func (f SsoIdentity_Subject_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (SsoIdentity_Subject_Field) _Column() string { return "subject" }
+
+type SsoIdentity_UserId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func SsoIdentity_UserId(v []byte) SsoIdentity_UserId_Field {
+	return SsoIdentity_UserId_Field{_set: true, _value: v}
+}
+
+func (f SsoIdentity_UserId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (SsoIdentity_UserId_Field) _Column() string { return "user_id" }
+
+type SsoIdentity_CreatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func SsoIdentity_CreatedAt(v time.Time) SsoIdentity_CreatedAt_Field {
+	return SsoIdentity_CreatedAt_Field{_set: true, _value: v}
+}
+
+func (f SsoIdentity_CreatedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (SsoIdentity_CreatedAt_Field) _Column() string { return "created_at" }
+
 type StoragenodeBandwidthRollup struct {
 	StoragenodeId   []byte
 	IntervalStart   time.Time
@@ -10046,19 +10950,22 @@ func (f ValueAttribution_LastUpdated_Field) value() interface{} {
 func (ValueAttribution_LastUpdated_Field) _Column() string { return "last_updated" }
 
 type WebappSession struct {
-	Id        []byte
-	UserId    []byte
-	IpAddress string
-	UserAgent string
-	Status    int
-	ExpiresAt time.Time
+	Id         []byte
+	UserId     []byte
+	IpAddress  string
+	UserAgent  string
+	Status     int
+	ExpiresAt  time.Time
+	CreatedAt  time.Time
+	LastSeenAt time.Time
 }
 
 func (WebappSession) _Table() string { return "webapp_sessions" }
 
 type WebappSession_Update_Fields struct {
-	Status    WebappSession_Status_Field
-	ExpiresAt WebappSession_ExpiresAt_Field
+	Status     WebappSession_Status_Field
+	ExpiresAt  WebappSession_ExpiresAt_Field
+	LastSeenAt WebappSession_LastSeenAt_Field
 }
 
 type WebappSession_Id_Field struct {
@@ -10175,6 +11082,44 @@ func (f WebappSession_ExpiresAt_Field) value() interface{} {
 
 func (WebappSession_ExpiresAt_Field) _Column() string { return "expires_at" }
 
+type WebappSession_CreatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func WebappSession_CreatedAt(v time.Time) WebappSession_CreatedAt_Field {
+	return WebappSession_CreatedAt_Field{_set: true, _value: v}
+}
+
+func (f WebappSession_CreatedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (WebappSession_CreatedAt_Field) _Column() string { return "created_at" }
+
+type WebappSession_LastSeenAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func WebappSession_LastSeenAt(v time.Time) WebappSession_LastSeenAt_Field {
+	return WebappSession_LastSeenAt_Field{_set: true, _value: v}
+}
+
+func (f WebappSession_LastSeenAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (WebappSession_LastSeenAt_Field) _Column() string { return "last_seen_at" }
+
 type ApiKey struct {
 	Id        []byte
 	ProjectId []byte
@@ -10796,6 +11741,74 @@ func (f BucketMetainfo_Placement_Field) value() interface{} {
 
 func (BucketMetainfo_Placement_Field) _Column() string { return "placement" }
 
+type OrganizationMember struct {
+	OrganizationId []byte
+	MemberId       []byte
+	CreatedAt      time.Time
+}
+
+func (OrganizationMember) _Table() string { return "organization_members" }
+
+type OrganizationMember_Update_Fields struct {
+}
+
+type OrganizationMember_OrganizationId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func OrganizationMember_OrganizationId(v []byte) OrganizationMember_OrganizationId_Field {
+	return OrganizationMember_OrganizationId_Field{_set: true, _value: v}
+}
+
+func (f OrganizationMember_OrganizationId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (OrganizationMember_OrganizationId_Field) _Column() string { return "organization_id" }
+
+type OrganizationMember_MemberId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func OrganizationMember_MemberId(v []byte) OrganizationMember_MemberId_Field {
+	return OrganizationMember_MemberId_Field{_set: true, _value: v}
+}
+
+func (f OrganizationMember_MemberId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (OrganizationMember_MemberId_Field) _Column() string { return "member_id" }
+
+type OrganizationMember_CreatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func OrganizationMember_CreatedAt(v time.Time) OrganizationMember_CreatedAt_Field {
+	return OrganizationMember_CreatedAt_Field{_set: true, _value: v}
+}
+
+func (f OrganizationMember_CreatedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (OrganizationMember_CreatedAt_Field) _Column() string { return "created_at" }
+
 type ProjectMember struct {
 	MemberId  []byte
 	ProjectId []byte
@@ -10864,283 +11877,820 @@ func (f ProjectMember_CreatedAt_Field) value() interface{} {
 
 func (ProjectMember_CreatedAt_Field) _Column() string { return "created_at" }
 
-type StripecoinpaymentsApplyBalanceIntent struct {
-	TxId      string
-	State     int
-	CreatedAt time.Time
+type ProjectSpendingCap struct {
+	ProjectId        []byte
+	Cap              int64
+	AlertThresholds  string
+	Enforce          bool
+	LimitBandwidth   bool
+	AlertPeriod      time.Time
+	AlertedThreshold int
+	Capped           bool
+	CreatedAt        time.Time
 }
 
-func (StripecoinpaymentsApplyBalanceIntent) _Table() string {
-	return "stripecoinpayments_apply_balance_intents"
-}
+func (ProjectSpendingCap) _Table() string { return "project_spending_caps" }
 
-type StripecoinpaymentsApplyBalanceIntent_Update_Fields struct {
-	State StripecoinpaymentsApplyBalanceIntent_State_Field
+type ProjectSpendingCap_Update_Fields struct {
+	Cap              ProjectSpendingCap_Cap_Field
+	AlertThresholds  ProjectSpendingCap_AlertThresholds_Field
+	Enforce          ProjectSpendingCap_Enforce_Field
+	LimitBandwidth   ProjectSpendingCap_LimitBandwidth_Field
+	AlertPeriod      ProjectSpendingCap_AlertPeriod_Field
+	AlertedThreshold ProjectSpendingCap_AlertedThreshold_Field
+	Capped           ProjectSpendingCap_Capped_Field
 }
 
-type StripecoinpaymentsApplyBalanceIntent_TxId_Field struct {
+type ProjectSpendingCap_ProjectId_Field struct {
 	_set   bool
 	_null  bool
-	_value string
+	_value []byte
 }
 
-func StripecoinpaymentsApplyBalanceIntent_TxId(v string) StripecoinpaymentsApplyBalanceIntent_TxId_Field {
-	return StripecoinpaymentsApplyBalanceIntent_TxId_Field{_set: true, _value: v}
+func ProjectSpendingCap_ProjectId(v []byte) ProjectSpendingCap_ProjectId_Field {
+	return ProjectSpendingCap_ProjectId_Field{_set: true, _value: v}
 }
 
-func (f StripecoinpaymentsApplyBalanceIntent_TxId_Field) value() interface{} {
+func (f ProjectSpendingCap_ProjectId_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (StripecoinpaymentsApplyBalanceIntent_TxId_Field) _Column() string { return "tx_id" }
+func (ProjectSpendingCap_ProjectId_Field) _Column() string { return "project_id" }
 
-type StripecoinpaymentsApplyBalanceIntent_State_Field struct {
+type ProjectSpendingCap_Cap_Field struct {
 	_set   bool
 	_null  bool
-	_value int
+	_value int64
 }
 
-func StripecoinpaymentsApplyBalanceIntent_State(v int) StripecoinpaymentsApplyBalanceIntent_State_Field {
-	return StripecoinpaymentsApplyBalanceIntent_State_Field{_set: true, _value: v}
+func ProjectSpendingCap_Cap(v int64) ProjectSpendingCap_Cap_Field {
+	return ProjectSpendingCap_Cap_Field{_set: true, _value: v}
 }
 
-func (f StripecoinpaymentsApplyBalanceIntent_State_Field) value() interface{} {
+func (f ProjectSpendingCap_Cap_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (StripecoinpaymentsApplyBalanceIntent_State_Field) _Column() string { return "state" }
+func (ProjectSpendingCap_Cap_Field) _Column() string { return "cap" }
 
-type StripecoinpaymentsApplyBalanceIntent_CreatedAt_Field struct {
+type ProjectSpendingCap_AlertThresholds_Field struct {
 	_set   bool
 	_null  bool
-	_value time.Time
+	_value string
+}
+
+func ProjectSpendingCap_AlertThresholds(v string) ProjectSpendingCap_AlertThresholds_Field {
+	return ProjectSpendingCap_AlertThresholds_Field{_set: true, _value: v}
+}
+
+func (f ProjectSpendingCap_AlertThresholds_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (ProjectSpendingCap_AlertThresholds_Field) _Column() string { return "alert_thresholds" }
+
+type ProjectSpendingCap_Enforce_Field struct {
+	_set   bool
+	_null  bool
+	_value bool
+}
+
+func ProjectSpendingCap_Enforce(v bool) ProjectSpendingCap_Enforce_Field {
+	return ProjectSpendingCap_Enforce_Field{_set: true, _value: v}
+}
+
+func (f ProjectSpendingCap_Enforce_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (ProjectSpendingCap_Enforce_Field) _Column() string { return "enforce" }
+
+type ProjectSpendingCap_LimitBandwidth_Field struct {
+	_set   bool
+	_null  bool
+	_value bool
+}
+
+func ProjectSpendingCap_LimitBandwidth(v bool) ProjectSpendingCap_LimitBandwidth_Field {
+	return ProjectSpendingCap_LimitBandwidth_Field{_set: true, _value: v}
+}
+
+func (f ProjectSpendingCap_LimitBandwidth_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (ProjectSpendingCap_LimitBandwidth_Field) _Column() string { return "limit_bandwidth" }
+
+type ProjectSpendingCap_AlertPeriod_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func ProjectSpendingCap_AlertPeriod(v time.Time) ProjectSpendingCap_AlertPeriod_Field {
+	return ProjectSpendingCap_AlertPeriod_Field{_set: true, _value: v}
+}
+
+func (f ProjectSpendingCap_AlertPeriod_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (ProjectSpendingCap_AlertPeriod_Field) _Column() string { return "alert_period" }
+
+type ProjectSpendingCap_AlertedThreshold_Field struct {
+	_set   bool
+	_null  bool
+	_value int
+}
+
+func ProjectSpendingCap_AlertedThreshold(v int) ProjectSpendingCap_AlertedThreshold_Field {
+	return ProjectSpendingCap_AlertedThreshold_Field{_set: true, _value: v}
+}
+
+func (f ProjectSpendingCap_AlertedThreshold_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (ProjectSpendingCap_AlertedThreshold_Field) _Column() string { return "alerted_threshold" }
+
+type ProjectSpendingCap_Capped_Field struct {
+	_set   bool
+	_null  bool
+	_value bool
+}
+
+func ProjectSpendingCap_Capped(v bool) ProjectSpendingCap_Capped_Field {
+	return ProjectSpendingCap_Capped_Field{_set: true, _value: v}
+}
+
+func (f ProjectSpendingCap_Capped_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (ProjectSpendingCap_Capped_Field) _Column() string { return "capped" }
+
+type ProjectSpendingCap_CreatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func ProjectSpendingCap_CreatedAt(v time.Time) ProjectSpendingCap_CreatedAt_Field {
+	return ProjectSpendingCap_CreatedAt_Field{_set: true, _value: v}
+}
+
+func (f ProjectSpendingCap_CreatedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (ProjectSpendingCap_CreatedAt_Field) _Column() string { return "created_at" }
+
+type ProjectUsageExport struct {
+	ProjectId       []byte
+	EncryptedAccess []byte
+	Bucket          string
+	Prefix          string
+	Format          string
+	Schedule        string
+	ExportedUntil   time.Time
+	CreatedAt       time.Time
+}
+
+func (ProjectUsageExport) _Table() string { return "project_usage_exports" }
+
+type ProjectUsageExport_Update_Fields struct {
+	EncryptedAccess ProjectUsageExport_EncryptedAccess_Field
+	Bucket          ProjectUsageExport_Bucket_Field
+	Prefix          ProjectUsageExport_Prefix_Field
+	Format          ProjectUsageExport_Format_Field
+	Schedule        ProjectUsageExport_Schedule_Field
+	ExportedUntil   ProjectUsageExport_ExportedUntil_Field
+}
+
+type ProjectUsageExport_ProjectId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func ProjectUsageExport_ProjectId(v []byte) ProjectUsageExport_ProjectId_Field {
+	return ProjectUsageExport_ProjectId_Field{_set: true, _value: v}
+}
+
+func (f ProjectUsageExport_ProjectId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (ProjectUsageExport_ProjectId_Field) _Column() string { return "project_id" }
+
+type ProjectUsageExport_EncryptedAccess_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func ProjectUsageExport_EncryptedAccess(v []byte) ProjectUsageExport_EncryptedAccess_Field {
+	return ProjectUsageExport_EncryptedAccess_Field{_set: true, _value: v}
+}
+
+func (f ProjectUsageExport_EncryptedAccess_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (ProjectUsageExport_EncryptedAccess_Field) _Column() string { return "encrypted_access" }
+
+type ProjectUsageExport_Bucket_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func ProjectUsageExport_Bucket(v string) ProjectUsageExport_Bucket_Field {
+	return ProjectUsageExport_Bucket_Field{_set: true, _value: v}
+}
+
+func (f ProjectUsageExport_Bucket_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (ProjectUsageExport_Bucket_Field) _Column() string { return "bucket" }
+
+type ProjectUsageExport_Prefix_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func ProjectUsageExport_Prefix(v string) ProjectUsageExport_Prefix_Field {
+	return ProjectUsageExport_Prefix_Field{_set: true, _value: v}
+}
+
+func (f ProjectUsageExport_Prefix_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (ProjectUsageExport_Prefix_Field) _Column() string { return "prefix" }
+
+type ProjectUsageExport_Format_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func ProjectUsageExport_Format(v string) ProjectUsageExport_Format_Field {
+	return ProjectUsageExport_Format_Field{_set: true, _value: v}
+}
+
+func (f ProjectUsageExport_Format_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (ProjectUsageExport_Format_Field) _Column() string { return "format" }
+
+type ProjectUsageExport_Schedule_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func ProjectUsageExport_Schedule(v string) ProjectUsageExport_Schedule_Field {
+	return ProjectUsageExport_Schedule_Field{_set: true, _value: v}
+}
+
+func (f ProjectUsageExport_Schedule_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (ProjectUsageExport_Schedule_Field) _Column() string { return "schedule" }
+
+type ProjectUsageExport_ExportedUntil_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func ProjectUsageExport_ExportedUntil(v time.Time) ProjectUsageExport_ExportedUntil_Field {
+	return ProjectUsageExport_ExportedUntil_Field{_set: true, _value: v}
+}
+
+func (f ProjectUsageExport_ExportedUntil_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (ProjectUsageExport_ExportedUntil_Field) _Column() string { return "exported_until" }
+
+type ProjectUsageExport_CreatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func ProjectUsageExport_CreatedAt(v time.Time) ProjectUsageExport_CreatedAt_Field {
+	return ProjectUsageExport_CreatedAt_Field{_set: true, _value: v}
+}
+
+func (f ProjectUsageExport_CreatedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (ProjectUsageExport_CreatedAt_Field) _Column() string { return "created_at" }
+
+type StripecoinpaymentsApplyBalanceIntent struct {
+	TxId      string
+	State     int
+	CreatedAt time.Time
+}
+
+func (StripecoinpaymentsApplyBalanceIntent) _Table() string {
+	return "stripecoinpayments_apply_balance_intents"
+}
+
+type StripecoinpaymentsApplyBalanceIntent_Update_Fields struct {
+	State StripecoinpaymentsApplyBalanceIntent_State_Field
+}
+
+type StripecoinpaymentsApplyBalanceIntent_TxId_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func StripecoinpaymentsApplyBalanceIntent_TxId(v string) StripecoinpaymentsApplyBalanceIntent_TxId_Field {
+	return StripecoinpaymentsApplyBalanceIntent_TxId_Field{_set: true, _value: v}
+}
+
+func (f StripecoinpaymentsApplyBalanceIntent_TxId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (StripecoinpaymentsApplyBalanceIntent_TxId_Field) _Column() string { return "tx_id" }
+
+type StripecoinpaymentsApplyBalanceIntent_State_Field struct {
+	_set   bool
+	_null  bool
+	_value int
+}
+
+func StripecoinpaymentsApplyBalanceIntent_State(v int) StripecoinpaymentsApplyBalanceIntent_State_Field {
+	return StripecoinpaymentsApplyBalanceIntent_State_Field{_set: true, _value: v}
+}
+
+func (f StripecoinpaymentsApplyBalanceIntent_State_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (StripecoinpaymentsApplyBalanceIntent_State_Field) _Column() string { return "state" }
+
+type StripecoinpaymentsApplyBalanceIntent_CreatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
 }
 
 func StripecoinpaymentsApplyBalanceIntent_CreatedAt(v time.Time) StripecoinpaymentsApplyBalanceIntent_CreatedAt_Field {
 	return StripecoinpaymentsApplyBalanceIntent_CreatedAt_Field{_set: true, _value: v}
 }
 
-func (f StripecoinpaymentsApplyBalanceIntent_CreatedAt_Field) value() interface{} {
+func (f StripecoinpaymentsApplyBalanceIntent_CreatedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (StripecoinpaymentsApplyBalanceIntent_CreatedAt_Field) _Column() string { return "created_at" }
+
+type UserCredit struct {
+	Id                   int
+	UserId               []byte
+	OfferId              int
+	ReferredBy           []byte
+	Type                 string
+	CreditsEarnedInCents int
+	CreditsUsedInCents   int
+	ExpiresAt            time.Time
+	CreatedAt            time.Time
+}
+
+func (UserCredit) _Table() string { return "user_credits" }
+
+type UserCredit_Create_Fields struct {
+	ReferredBy UserCredit_ReferredBy_Field
+}
+
+type UserCredit_Update_Fields struct {
+	CreditsUsedInCents UserCredit_CreditsUsedInCents_Field
+	ExpiresAt          UserCredit_ExpiresAt_Field
+}
+
+type UserCredit_Id_Field struct {
+	_set   bool
+	_null  bool
+	_value int
+}
+
+func UserCredit_Id(v int) UserCredit_Id_Field {
+	return UserCredit_Id_Field{_set: true, _value: v}
+}
+
+func (f UserCredit_Id_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (UserCredit_Id_Field) _Column() string { return "id" }
+
+type UserCredit_UserId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func UserCredit_UserId(v []byte) UserCredit_UserId_Field {
+	return UserCredit_UserId_Field{_set: true, _value: v}
+}
+
+func (f UserCredit_UserId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (UserCredit_UserId_Field) _Column() string { return "user_id" }
+
+type UserCredit_OfferId_Field struct {
+	_set   bool
+	_null  bool
+	_value int
+}
+
+func UserCredit_OfferId(v int) UserCredit_OfferId_Field {
+	return UserCredit_OfferId_Field{_set: true, _value: v}
+}
+
+func (f UserCredit_OfferId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (UserCredit_OfferId_Field) _Column() string { return "offer_id" }
+
+type UserCredit_ReferredBy_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func UserCredit_ReferredBy(v []byte) UserCredit_ReferredBy_Field {
+	return UserCredit_ReferredBy_Field{_set: true, _value: v}
+}
+
+func UserCredit_ReferredBy_Raw(v []byte) UserCredit_ReferredBy_Field {
+	if v == nil {
+		return UserCredit_ReferredBy_Null()
+	}
+	return UserCredit_ReferredBy(v)
+}
+
+func UserCredit_ReferredBy_Null() UserCredit_ReferredBy_Field {
+	return UserCredit_ReferredBy_Field{_set: true, _null: true}
+}
+
+func (f UserCredit_ReferredBy_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+
+func (f UserCredit_ReferredBy_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (UserCredit_ReferredBy_Field) _Column() string { return "referred_by" }
+
+type UserCredit_Type_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func UserCredit_Type(v string) UserCredit_Type_Field {
+	return UserCredit_Type_Field{_set: true, _value: v}
+}
+
+func (f UserCredit_Type_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (UserCredit_Type_Field) _Column() string { return "type" }
+
+type UserCredit_CreditsEarnedInCents_Field struct {
+	_set   bool
+	_null  bool
+	_value int
+}
+
+func UserCredit_CreditsEarnedInCents(v int) UserCredit_CreditsEarnedInCents_Field {
+	return UserCredit_CreditsEarnedInCents_Field{_set: true, _value: v}
+}
+
+func (f UserCredit_CreditsEarnedInCents_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (UserCredit_CreditsEarnedInCents_Field) _Column() string { return "credits_earned_in_cents" }
+
+type UserCredit_CreditsUsedInCents_Field struct {
+	_set   bool
+	_null  bool
+	_value int
+}
+
+func UserCredit_CreditsUsedInCents(v int) UserCredit_CreditsUsedInCents_Field {
+	return UserCredit_CreditsUsedInCents_Field{_set: true, _value: v}
+}
+
+func (f UserCredit_CreditsUsedInCents_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (StripecoinpaymentsApplyBalanceIntent_CreatedAt_Field) _Column() string { return "created_at" }
+func (UserCredit_CreditsUsedInCents_Field) _Column() string { return "credits_used_in_cents" }
 
-type UserCredit struct {
-	Id                   int
-	UserId               []byte
-	OfferId              int
-	ReferredBy           []byte
-	Type                 string
-	CreditsEarnedInCents int
-	CreditsUsedInCents   int
-	ExpiresAt            time.Time
-	CreatedAt            time.Time
+type UserCredit_ExpiresAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
 }
 
-func (UserCredit) _Table() string { return "user_credits" }
-
-type UserCredit_Create_Fields struct {
-	ReferredBy UserCredit_ReferredBy_Field
+func UserCredit_ExpiresAt(v time.Time) UserCredit_ExpiresAt_Field {
+	return UserCredit_ExpiresAt_Field{_set: true, _value: v}
 }
 
-type UserCredit_Update_Fields struct {
-	CreditsUsedInCents UserCredit_CreditsUsedInCents_Field
-	ExpiresAt          UserCredit_ExpiresAt_Field
+func (f UserCredit_ExpiresAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
 }
 
-type UserCredit_Id_Field struct {
+func (UserCredit_ExpiresAt_Field) _Column() string { return "expires_at" }
+
+type UserCredit_CreatedAt_Field struct {
 	_set   bool
 	_null  bool
-	_value int
+	_value time.Time
 }
 
-func UserCredit_Id(v int) UserCredit_Id_Field {
-	return UserCredit_Id_Field{_set: true, _value: v}
+func UserCredit_CreatedAt(v time.Time) UserCredit_CreatedAt_Field {
+	return UserCredit_CreatedAt_Field{_set: true, _value: v}
 }
 
-func (f UserCredit_Id_Field) value() interface{} {
+func (f UserCredit_CreatedAt_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (UserCredit_Id_Field) _Column() string { return "id" }
+func (UserCredit_CreatedAt_Field) _Column() string { return "created_at" }
 
-type UserCredit_UserId_Field struct {
+type AccessGrant struct {
+	Id        []byte
+	ProjectId []byte
+	ApiKeyId  []byte
+	Name      string
+	Caveats   string
+	Tails     []byte
+	CreatedBy []byte
+	CreatedAt time.Time
+}
+
+func (AccessGrant) _Table() string { return "access_grants" }
+
+type AccessGrant_Update_Fields struct {
+}
+
+type AccessGrant_Id_Field struct {
 	_set   bool
 	_null  bool
 	_value []byte
 }
 
-func UserCredit_UserId(v []byte) UserCredit_UserId_Field {
-	return UserCredit_UserId_Field{_set: true, _value: v}
+func AccessGrant_Id(v []byte) AccessGrant_Id_Field {
+	return AccessGrant_Id_Field{_set: true, _value: v}
 }
 
-func (f UserCredit_UserId_Field) value() interface{} {
+func (f AccessGrant_Id_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (UserCredit_UserId_Field) _Column() string { return "user_id" }
+func (AccessGrant_Id_Field) _Column() string { return "id" }
 
-type UserCredit_OfferId_Field struct {
+type AccessGrant_ProjectId_Field struct {
 	_set   bool
 	_null  bool
-	_value int
+	_value []byte
 }
 
-func UserCredit_OfferId(v int) UserCredit_OfferId_Field {
-	return UserCredit_OfferId_Field{_set: true, _value: v}
+func AccessGrant_ProjectId(v []byte) AccessGrant_ProjectId_Field {
+	return AccessGrant_ProjectId_Field{_set: true, _value: v}
 }
 
-func (f UserCredit_OfferId_Field) value() interface{} {
+func (f AccessGrant_ProjectId_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (UserCredit_OfferId_Field) _Column() string { return "offer_id" }
+func (AccessGrant_ProjectId_Field) _Column() string { return "project_id" }
 
-type UserCredit_ReferredBy_Field struct {
+type AccessGrant_ApiKeyId_Field struct {
 	_set   bool
 	_null  bool
 	_value []byte
 }
 
-func UserCredit_ReferredBy(v []byte) UserCredit_ReferredBy_Field {
-	return UserCredit_ReferredBy_Field{_set: true, _value: v}
-}
-
-func UserCredit_ReferredBy_Raw(v []byte) UserCredit_ReferredBy_Field {
-	if v == nil {
-		return UserCredit_ReferredBy_Null()
-	}
-	return UserCredit_ReferredBy(v)
-}
-
-func UserCredit_ReferredBy_Null() UserCredit_ReferredBy_Field {
-	return UserCredit_ReferredBy_Field{_set: true, _null: true}
+func AccessGrant_ApiKeyId(v []byte) AccessGrant_ApiKeyId_Field {
+	return AccessGrant_ApiKeyId_Field{_set: true, _value: v}
 }
 
-func (f UserCredit_ReferredBy_Field) isnull() bool { return !f._set || f._null || f._value == nil }
-
-func (f UserCredit_ReferredBy_Field) value() interface{} {
+func (f AccessGrant_ApiKeyId_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (UserCredit_ReferredBy_Field) _Column() string { return "referred_by" }
+func (AccessGrant_ApiKeyId_Field) _Column() string { return "api_key_id" }
 
-type UserCredit_Type_Field struct {
+type AccessGrant_Name_Field struct {
 	_set   bool
 	_null  bool
 	_value string
 }
 
-func UserCredit_Type(v string) UserCredit_Type_Field {
-	return UserCredit_Type_Field{_set: true, _value: v}
+func AccessGrant_Name(v string) AccessGrant_Name_Field {
+	return AccessGrant_Name_Field{_set: true, _value: v}
 }
 
-func (f UserCredit_Type_Field) value() interface{} {
+func (f AccessGrant_Name_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (UserCredit_Type_Field) _Column() string { return "type" }
+func (AccessGrant_Name_Field) _Column() string { return "name" }
 
-type UserCredit_CreditsEarnedInCents_Field struct {
+type AccessGrant_Caveats_Field struct {
 	_set   bool
 	_null  bool
-	_value int
+	_value string
 }
 
-func UserCredit_CreditsEarnedInCents(v int) UserCredit_CreditsEarnedInCents_Field {
-	return UserCredit_CreditsEarnedInCents_Field{_set: true, _value: v}
+func AccessGrant_Caveats(v string) AccessGrant_Caveats_Field {
+	return AccessGrant_Caveats_Field{_set: true, _value: v}
 }
 
-func (f UserCredit_CreditsEarnedInCents_Field) value() interface{} {
+func (f AccessGrant_Caveats_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (UserCredit_CreditsEarnedInCents_Field) _Column() string { return "credits_earned_in_cents" }
+func (AccessGrant_Caveats_Field) _Column() string { return "caveats" }
 
-type UserCredit_CreditsUsedInCents_Field struct {
+type AccessGrant_Tails_Field struct {
 	_set   bool
 	_null  bool
-	_value int
+	_value []byte
 }
 
-func UserCredit_CreditsUsedInCents(v int) UserCredit_CreditsUsedInCents_Field {
-	return UserCredit_CreditsUsedInCents_Field{_set: true, _value: v}
+func AccessGrant_Tails(v []byte) AccessGrant_Tails_Field {
+	return AccessGrant_Tails_Field{_set: true, _value: v}
 }
 
-func (f UserCredit_CreditsUsedInCents_Field) value() interface{} {
+func (f AccessGrant_Tails_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (UserCredit_CreditsUsedInCents_Field) _Column() string { return "credits_used_in_cents" }
+func (AccessGrant_Tails_Field) _Column() string { return "tails" }
 
-type UserCredit_ExpiresAt_Field struct {
+type AccessGrant_CreatedBy_Field struct {
 	_set   bool
 	_null  bool
-	_value time.Time
+	_value []byte
 }
 
-func UserCredit_ExpiresAt(v time.Time) UserCredit_ExpiresAt_Field {
-	return UserCredit_ExpiresAt_Field{_set: true, _value: v}
+func AccessGrant_CreatedBy(v []byte) AccessGrant_CreatedBy_Field {
+	return AccessGrant_CreatedBy_Field{_set: true, _value: v}
 }
 
-func (f UserCredit_ExpiresAt_Field) value() interface{} {
+func (f AccessGrant_CreatedBy_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (UserCredit_ExpiresAt_Field) _Column() string { return "expires_at" }
+func (AccessGrant_CreatedBy_Field) _Column() string { return "created_by" }
 
-type UserCredit_CreatedAt_Field struct {
+type AccessGrant_CreatedAt_Field struct {
 	_set   bool
 	_null  bool
 	_value time.Time
 }
 
-func UserCredit_CreatedAt(v time.Time) UserCredit_CreatedAt_Field {
-	return UserCredit_CreatedAt_Field{_set: true, _value: v}
+func AccessGrant_CreatedAt(v time.Time) AccessGrant_CreatedAt_Field {
+	return AccessGrant_CreatedAt_Field{_set: true, _value: v}
 }
 
-func (f UserCredit_CreatedAt_Field) value() interface{} {
+func (f AccessGrant_CreatedAt_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (UserCredit_CreatedAt_Field) _Column() string { return "created_at" }
+func (AccessGrant_CreatedAt_Field) _Column() string { return "created_at" }
 
 func toUTC(t time.Time) time.Time {
 	return t.UTC()
@@ -11566,10 +13116,12 @@ type BandwidthLimit_Row struct {
 	BandwidthLimit *int64
 }
 
-type BandwidthLimit_UsageLimit_SegmentLimit_Row struct {
-	BandwidthLimit *int64
-	UsageLimit     *int64
-	SegmentLimit   *int64
+type BandwidthLimit_UsageLimit_SegmentLimit_BandwidthRateLimit_BandwidthBurstLimit_Row struct {
+	BandwidthLimit      *int64
+	UsageLimit          *int64
+	SegmentLimit        *int64
+	BandwidthRateLimit  *int64
+	BandwidthBurstLimit *int64
 }
 
 type BlockNumber_Row struct {
@@ -11960,23 +13512,27 @@ func (obj *pgxImpl) Create_WebappSession(ctx context.Context,
 	webapp_session_expires_at WebappSession_ExpiresAt_Field) (
 	webapp_session *WebappSession, err error) {
 	defer mon.Task()(&ctx)(&err)
+
+	__now := obj.db.Hooks.Now().UTC()
 	__id_val := webapp_session_id.value()
 	__user_id_val := webapp_session_user_id.value()
 	__ip_address_val := webapp_session_ip_address.value()
 	__user_agent_val := webapp_session_user_agent.value()
 	__status_val := int(0)
 	__expires_at_val := webapp_session_expires_at.value()
+	__created_at_val := __now
+	__last_seen_at_val := __now
 
-	var __embed_stmt = __sqlbundle_Literal("INSERT INTO webapp_sessions ( id, user_id, ip_address, user_agent, status, expires_at ) VALUES ( ?, ?, ?, ?, ?, ? ) RETURNING webapp_sessions.id, webapp_sessions.user_id, webapp_sessions.ip_address, webapp_sessions.user_agent, webapp_sessions.status, webapp_sessions.expires_at")
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO webapp_sessions ( id, user_id, ip_address, user_agent, status, expires_at, created_at, last_seen_at ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ? ) RETURNING webapp_sessions.id, webapp_sessions.user_id, webapp_sessions.ip_address, webapp_sessions.user_agent, webapp_sessions.status, webapp_sessions.expires_at, webapp_sessions.created_at, webapp_sessions.last_seen_at")
 
 	var __values []interface{}
-	__values = append(__values, __id_val, __user_id_val, __ip_address_val, __user_agent_val, __status_val, __expires_at_val)
+	__values = append(__values, __id_val, __user_id_val, __ip_address_val, __user_agent_val, __status_val, __expires_at_val, __created_at_val, __last_seen_at_val)
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
 	webapp_session = &WebappSession{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&webapp_session.Id, &webapp_session.UserId, &webapp_session.IpAddress, &webapp_session.UserAgent, &webapp_session.Status, &webapp_session.ExpiresAt)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&webapp_session.Id, &webapp_session.UserId, &webapp_session.IpAddress, &webapp_session.UserAgent, &webapp_session.Status, &webapp_session.ExpiresAt, &webapp_session.CreatedAt, &webapp_session.LastSeenAt)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -12002,20 +13558,23 @@ func (obj *pgxImpl) Create_Project(ctx context.Context,
 	__bandwidth_limit_val := optional.BandwidthLimit.value()
 	__rate_limit_val := optional.RateLimit.value()
 	__burst_limit_val := optional.BurstLimit.value()
+	__bandwidth_rate_limit_val := optional.BandwidthRateLimit.value()
+	__bandwidth_burst_limit_val := optional.BandwidthBurstLimit.value()
 	__max_buckets_val := optional.MaxBuckets.value()
 	__partner_id_val := optional.PartnerId.value()
 	__user_agent_val := optional.UserAgent.value()
 	__owner_id_val := project_owner_id.value()
+	__organization_id_val := optional.OrganizationId.value()
 	__created_at_val := __now
 
-	var __columns = &__sqlbundle_Hole{SQL: __sqlbundle_Literal("id, public_id, name, description, usage_limit, bandwidth_limit, rate_limit, burst_limit, max_buckets, partner_id, user_agent, owner_id, created_at")}
-	var __placeholders = &__sqlbundle_Hole{SQL: __sqlbundle_Literal("?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?")}
+	var __columns = &__sqlbundle_Hole{SQL: __sqlbundle_Literal("id, public_id, name, description, usage_limit, bandwidth_limit, rate_limit, burst_limit, bandwidth_rate_limit, bandwidth_burst_limit, max_buckets, partner_id, user_agent, owner_id, organization_id, created_at")}
+	var __placeholders = &__sqlbundle_Hole{SQL: __sqlbundle_Literal("?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?")}
 	var __clause = &__sqlbundle_Hole{SQL: __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("("), __columns, __sqlbundle_Literal(") VALUES ("), __placeholders, __sqlbundle_Literal(")")}}}
 
-	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("INSERT INTO projects "), __clause, __sqlbundle_Literal(" RETURNING projects.id, projects.public_id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at")}}
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("INSERT INTO projects "), __clause, __sqlbundle_Literal(" RETURNING projects.id, projects.public_id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.bandwidth_rate_limit, projects.bandwidth_burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.organization_id, projects.created_at")}}
 
 	var __values []interface{}
-	__values = append(__values, __id_val, __public_id_val, __name_val, __description_val, __usage_limit_val, __bandwidth_limit_val, __rate_limit_val, __burst_limit_val, __max_buckets_val, __partner_id_val, __user_agent_val, __owner_id_val, __created_at_val)
+	__values = append(__values, __id_val, __public_id_val, __name_val, __description_val, __usage_limit_val, __bandwidth_limit_val, __rate_limit_val, __burst_limit_val, __bandwidth_rate_limit_val, __bandwidth_burst_limit_val, __max_buckets_val, __partner_id_val, __user_agent_val, __owner_id_val, __organization_id_val, __created_at_val)
 
 	__optional_columns := __sqlbundle_Literals{Join: ", "}
 	__optional_placeholders := __sqlbundle_Literals{Join: ", "}
@@ -12038,7 +13597,7 @@ func (obj *pgxImpl) Create_Project(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	project = &Project{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&project.Id, &project.PublicId, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&project.Id, &project.PublicId, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.BandwidthRateLimit, &project.BandwidthBurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.OrganizationId, &project.CreatedAt)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -12074,6 +13633,137 @@ func (obj *pgxImpl) Create_ProjectMember(ctx context.Context,
 
 }
 
+func (obj *pgxImpl) CreateNoReturn_ProjectSpendingCap(ctx context.Context,
+	project_spending_cap_project_id ProjectSpendingCap_ProjectId_Field,
+	project_spending_cap_cap ProjectSpendingCap_Cap_Field,
+	project_spending_cap_alert_thresholds ProjectSpendingCap_AlertThresholds_Field,
+	project_spending_cap_enforce ProjectSpendingCap_Enforce_Field,
+	project_spending_cap_limit_bandwidth ProjectSpendingCap_LimitBandwidth_Field,
+	project_spending_cap_alert_period ProjectSpendingCap_AlertPeriod_Field,
+	project_spending_cap_alerted_threshold ProjectSpendingCap_AlertedThreshold_Field,
+	project_spending_cap_capped ProjectSpendingCap_Capped_Field) (
+	err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	__now := obj.db.Hooks.Now().UTC()
+	__project_id_val := project_spending_cap_project_id.value()
+	__cap_val := project_spending_cap_cap.value()
+	__alert_thresholds_val := project_spending_cap_alert_thresholds.value()
+	__enforce_val := project_spending_cap_enforce.value()
+	__limit_bandwidth_val := project_spending_cap_limit_bandwidth.value()
+	__alert_period_val := project_spending_cap_alert_period.value()
+	__alerted_threshold_val := project_spending_cap_alerted_threshold.value()
+	__capped_val := project_spending_cap_capped.value()
+	__created_at_val := __now
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO project_spending_caps ( project_id, cap, alert_thresholds, enforce, limit_bandwidth, alert_period, alerted_threshold, capped, created_at ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ? )")
+
+	var __values []interface{}
+	__values = append(__values, __project_id_val, __cap_val, __alert_thresholds_val, __enforce_val, __limit_bandwidth_val, __alert_period_val, __alerted_threshold_val, __capped_val, __created_at_val)
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	_, err = obj.driver.ExecContext(ctx, __stmt, __values...)
+	if err != nil {
+		return obj.makeErr(err)
+	}
+	return nil
+
+}
+
+func (obj *pgxImpl) CreateNoReturn_ProjectUsageExport(ctx context.Context,
+	project_usage_export_project_id ProjectUsageExport_ProjectId_Field,
+	project_usage_export_encrypted_access ProjectUsageExport_EncryptedAccess_Field,
+	project_usage_export_bucket ProjectUsageExport_Bucket_Field,
+	project_usage_export_prefix ProjectUsageExport_Prefix_Field,
+	project_usage_export_format ProjectUsageExport_Format_Field,
+	project_usage_export_schedule ProjectUsageExport_Schedule_Field,
+	project_usage_export_exported_until ProjectUsageExport_ExportedUntil_Field) (
+	err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	__now := obj.db.Hooks.Now().UTC()
+	__project_id_val := project_usage_export_project_id.value()
+	__encrypted_access_val := project_usage_export_encrypted_access.value()
+	__bucket_val := project_usage_export_bucket.value()
+	__prefix_val := project_usage_export_prefix.value()
+	__format_val := project_usage_export_format.value()
+	__schedule_val := project_usage_export_schedule.value()
+	__exported_until_val := project_usage_export_exported_until.value()
+	__created_at_val := __now
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO project_usage_exports ( project_id, encrypted_access, bucket, prefix, format, schedule, exported_until, created_at ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ? )")
+
+	var __values []interface{}
+	__values = append(__values, __project_id_val, __encrypted_access_val, __bucket_val, __prefix_val, __format_val, __schedule_val, __exported_until_val, __created_at_val)
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	_, err = obj.driver.ExecContext(ctx, __stmt, __values...)
+	if err != nil {
+		return obj.makeErr(err)
+	}
+	return nil
+
+}
+
+func (obj *pgxImpl) Create_Organization(ctx context.Context,
+	organization_id Organization_Id_Field,
+	organization_name Organization_Name_Field) (
+	organization *Organization, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	__now := obj.db.Hooks.Now().UTC()
+	__id_val := organization_id.value()
+	__name_val := organization_name.value()
+	__created_at_val := __now
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO organizations ( id, name, created_at ) VALUES ( ?, ?, ? ) RETURNING organizations.id, organizations.name, organizations.created_at")
+
+	var __values []interface{}
+	__values = append(__values, __id_val, __name_val, __created_at_val)
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	organization = &Organization{}
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&organization.Id, &organization.Name, &organization.CreatedAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return organization, nil
+
+}
+
+func (obj *pgxImpl) CreateNoReturn_OrganizationMember(ctx context.Context,
+	organization_member_organization_id OrganizationMember_OrganizationId_Field,
+	organization_member_member_id OrganizationMember_MemberId_Field) (
+	err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	__now := obj.db.Hooks.Now().UTC()
+	__organization_id_val := organization_member_organization_id.value()
+	__member_id_val := organization_member_member_id.value()
+	__created_at_val := __now
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO organization_members ( organization_id, member_id, created_at ) VALUES ( ?, ?, ? )")
+
+	var __values []interface{}
+	__values = append(__values, __organization_id_val, __member_id_val, __created_at_val)
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	_, err = obj.driver.ExecContext(ctx, __stmt, __values...)
+	if err != nil {
+		return obj.makeErr(err)
+	}
+	return nil
+
+}
+
 func (obj *pgxImpl) Create_ApiKey(ctx context.Context,
 	api_key_id ApiKey_Id_Field,
 	api_key_project_id ApiKey_ProjectId_Field,
@@ -12111,6 +13801,43 @@ func (obj *pgxImpl) Create_ApiKey(ctx context.Context,
 
 }
 
+func (obj *pgxImpl) CreateNoReturn_AccessGrant(ctx context.Context,
+	access_grant_id AccessGrant_Id_Field,
+	access_grant_project_id AccessGrant_ProjectId_Field,
+	access_grant_api_key_id AccessGrant_ApiKeyId_Field,
+	access_grant_name AccessGrant_Name_Field,
+	access_grant_caveats AccessGrant_Caveats_Field,
+	access_grant_tails AccessGrant_Tails_Field,
+	access_grant_created_by AccessGrant_CreatedBy_Field) (
+	err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	__now := obj.db.Hooks.Now().UTC()
+	__id_val := access_grant_id.value()
+	__project_id_val := access_grant_project_id.value()
+	__api_key_id_val := access_grant_api_key_id.value()
+	__name_val := access_grant_name.value()
+	__caveats_val := access_grant_caveats.value()
+	__tails_val := access_grant_tails.value()
+	__created_by_val := access_grant_created_by.value()
+	__created_at_val := __now
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO access_grants ( id, project_id, api_key_id, name, caveats, tails, created_by, created_at ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ? )")
+
+	var __values []interface{}
+	__values = append(__values, __id_val, __project_id_val, __api_key_id_val, __name_val, __caveats_val, __tails_val, __created_by_val, __created_at_val)
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	_, err = obj.driver.ExecContext(ctx, __stmt, __values...)
+	if err != nil {
+		return obj.makeErr(err)
+	}
+	return nil
+
+}
+
 func (obj *pgxImpl) CreateNoReturn_Revocation(ctx context.Context,
 	revocation_revoked Revocation_Revoked_Field,
 	revocation_api_key_id Revocation_ApiKeyId_Field) (
@@ -12450,35 +14177,76 @@ func (obj *pgxImpl) Create_StripeCustomer(ctx context.Context,
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
-	return stripe_customer, nil
+	return stripe_customer, nil
+
+}
+
+func (obj *pgxImpl) CreateNoReturn_BillingTransaction(ctx context.Context,
+	billing_transaction_tx_id BillingTransaction_TxId_Field,
+	billing_transaction_user_id BillingTransaction_UserId_Field,
+	billing_transaction_amount BillingTransaction_Amount_Field,
+	billing_transaction_currency BillingTransaction_Currency_Field,
+	billing_transaction_description BillingTransaction_Description_Field,
+	billing_transaction_type BillingTransaction_Type_Field,
+	billing_transaction_timestamp BillingTransaction_Timestamp_Field) (
+	err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	__now := obj.db.Hooks.Now().UTC()
+	__tx_id_val := billing_transaction_tx_id.value()
+	__user_id_val := billing_transaction_user_id.value()
+	__amount_val := billing_transaction_amount.value()
+	__currency_val := billing_transaction_currency.value()
+	__description_val := billing_transaction_description.value()
+	__type_val := billing_transaction_type.value()
+	__timestamp_val := billing_transaction_timestamp.value()
+	__created_at_val := __now
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO billing_transactions ( tx_id, user_id, amount, currency, description, type, timestamp, created_at ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ? )")
+
+	var __values []interface{}
+	__values = append(__values, __tx_id_val, __user_id_val, __amount_val, __currency_val, __description_val, __type_val, __timestamp_val, __created_at_val)
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	_, err = obj.driver.ExecContext(ctx, __stmt, __values...)
+	if err != nil {
+		return obj.makeErr(err)
+	}
+	return nil
 
 }
 
-func (obj *pgxImpl) CreateNoReturn_BillingTransaction(ctx context.Context,
-	billing_transaction_tx_id BillingTransaction_TxId_Field,
-	billing_transaction_user_id BillingTransaction_UserId_Field,
-	billing_transaction_amount BillingTransaction_Amount_Field,
-	billing_transaction_currency BillingTransaction_Currency_Field,
-	billing_transaction_description BillingTransaction_Description_Field,
-	billing_transaction_type BillingTransaction_Type_Field,
-	billing_transaction_timestamp BillingTransaction_Timestamp_Field) (
+func (obj *pgxImpl) CreateNoReturn_BillingInvoice(ctx context.Context,
+	billing_invoice_id BillingInvoice_Id_Field,
+	billing_invoice_user_id BillingInvoice_UserId_Field,
+	billing_invoice_period_start BillingInvoice_PeriodStart_Field,
+	billing_invoice_period_end BillingInvoice_PeriodEnd_Field,
+	billing_invoice_description BillingInvoice_Description_Field,
+	billing_invoice_items BillingInvoice_Items_Field,
+	billing_invoice_amount BillingInvoice_Amount_Field,
+	billing_invoice_status BillingInvoice_Status_Field,
+	optional BillingInvoice_Create_Fields) (
 	err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	__now := obj.db.Hooks.Now().UTC()
-	__tx_id_val := billing_transaction_tx_id.value()
-	__user_id_val := billing_transaction_user_id.value()
-	__amount_val := billing_transaction_amount.value()
-	__currency_val := billing_transaction_currency.value()
-	__description_val := billing_transaction_description.value()
-	__type_val := billing_transaction_type.value()
-	__timestamp_val := billing_transaction_timestamp.value()
+	__id_val := billing_invoice_id.value()
+	__user_id_val := billing_invoice_user_id.value()
+	__period_start_val := billing_invoice_period_start.value()
+	__period_end_val := billing_invoice_period_end.value()
+	__description_val := billing_invoice_description.value()
+	__items_val := billing_invoice_items.value()
+	__amount_val := billing_invoice_amount.value()
+	__status_val := billing_invoice_status.value()
 	__created_at_val := __now
+	__paid_at_val := optional.PaidAt.value()
 
-	var __embed_stmt = __sqlbundle_Literal("INSERT INTO billing_transactions ( tx_id, user_id, amount, currency, description, type, timestamp, created_at ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ? )")
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO billing_invoices ( id, user_id, period_start, period_end, description, items, amount, status, created_at, paid_at ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ? )")
 
 	var __values []interface{}
-	__values = append(__values, __tx_id_val, __user_id_val, __amount_val, __currency_val, __description_val, __type_val, __timestamp_val, __created_at_val)
+	__values = append(__values, __id_val, __user_id_val, __period_start_val, __period_end_val, __description_val, __items_val, __amount_val, __status_val, __created_at_val, __paid_at_val)
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
@@ -12518,6 +14286,35 @@ func (obj *pgxImpl) CreateNoReturn_StorjscanWallet(ctx context.Context,
 
 }
 
+func (obj *pgxImpl) CreateNoReturn_SsoIdentity(ctx context.Context,
+	sso_identity_issuer SsoIdentity_Issuer_Field,
+	sso_identity_subject SsoIdentity_Subject_Field,
+	sso_identity_user_id SsoIdentity_UserId_Field) (
+	err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	__now := obj.db.Hooks.Now().UTC()
+	__issuer_val := sso_identity_issuer.value()
+	__subject_val := sso_identity_subject.value()
+	__user_id_val := sso_identity_user_id.value()
+	__created_at_val := __now
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO sso_identities ( issuer, subject, user_id, created_at ) VALUES ( ?, ?, ?, ? )")
+
+	var __values []interface{}
+	__values = append(__values, __issuer_val, __subject_val, __user_id_val, __created_at_val)
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	_, err = obj.driver.ExecContext(ctx, __stmt, __values...)
+	if err != nil {
+		return obj.makeErr(err)
+	}
+	return nil
+
+}
+
 func (obj *pgxImpl) Create_CoinpaymentsTransaction(ctx context.Context,
 	coinpayments_transaction_id CoinpaymentsTransaction_Id_Field,
 	coinpayments_transaction_user_id CoinpaymentsTransaction_UserId_Field,
@@ -12883,7 +14680,8 @@ func (obj *pgxImpl) CreateNoReturn_OauthToken(ctx context.Context,
 	oauth_token_kind OauthToken_Kind_Field,
 	oauth_token_token OauthToken_Token_Field,
 	oauth_token_created_at OauthToken_CreatedAt_Field,
-	oauth_token_expires_at OauthToken_ExpiresAt_Field) (
+	oauth_token_expires_at OauthToken_ExpiresAt_Field,
+	optional OauthToken_Create_Fields) (
 	err error) {
 	defer mon.Task()(&ctx)(&err)
 	__client_id_val := oauth_token_client_id.value()
@@ -12893,11 +14691,13 @@ func (obj *pgxImpl) CreateNoReturn_OauthToken(ctx context.Context,
 	__token_val := oauth_token_token.value()
 	__created_at_val := oauth_token_created_at.value()
 	__expires_at_val := oauth_token_expires_at.value()
+	__name_val := optional.Name.value()
+	__last_used_at_val := optional.LastUsedAt.value()
 
-	var __embed_stmt = __sqlbundle_Literal("INSERT INTO oauth_tokens ( client_id, user_id, scope, kind, token, created_at, expires_at ) VALUES ( ?, ?, ?, ?, ?, ?, ? )")
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO oauth_tokens ( client_id, user_id, scope, kind, token, created_at, expires_at, name, last_used_at ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ? )")
 
 	var __values []interface{}
-	__values = append(__values, __client_id_val, __user_id_val, __scope_val, __kind_val, __token_val, __created_at_val, __expires_at_val)
+	__values = append(__values, __client_id_val, __user_id_val, __scope_val, __kind_val, __token_val, __created_at_val, __expires_at_val, __name_val, __last_used_at_val)
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
@@ -13365,7 +15165,7 @@ func (obj *pgxImpl) All_WebappSession_By_UserId(ctx context.Context,
 	rows []*WebappSession, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT webapp_sessions.id, webapp_sessions.user_id, webapp_sessions.ip_address, webapp_sessions.user_agent, webapp_sessions.status, webapp_sessions.expires_at FROM webapp_sessions WHERE webapp_sessions.user_id = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT webapp_sessions.id, webapp_sessions.user_id, webapp_sessions.ip_address, webapp_sessions.user_agent, webapp_sessions.status, webapp_sessions.expires_at, webapp_sessions.created_at, webapp_sessions.last_seen_at FROM webapp_sessions WHERE webapp_sessions.user_id = ?")
 
 	var __values []interface{}
 	__values = append(__values, webapp_session_user_id.value())
@@ -13383,7 +15183,7 @@ func (obj *pgxImpl) All_WebappSession_By_UserId(ctx context.Context,
 
 			for __rows.Next() {
 				webapp_session := &WebappSession{}
-				err = __rows.Scan(&webapp_session.Id, &webapp_session.UserId, &webapp_session.IpAddress, &webapp_session.UserAgent, &webapp_session.Status, &webapp_session.ExpiresAt)
+				err = __rows.Scan(&webapp_session.Id, &webapp_session.UserId, &webapp_session.IpAddress, &webapp_session.UserAgent, &webapp_session.Status, &webapp_session.ExpiresAt, &webapp_session.CreatedAt, &webapp_session.LastSeenAt)
 				if err != nil {
 					return nil, err
 				}
@@ -13410,7 +15210,7 @@ func (obj *pgxImpl) Get_WebappSession_By_Id(ctx context.Context,
 	webapp_session *WebappSession, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT webapp_sessions.id, webapp_sessions.user_id, webapp_sessions.ip_address, webapp_sessions.user_agent, webapp_sessions.status, webapp_sessions.expires_at FROM webapp_sessions WHERE webapp_sessions.id = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT webapp_sessions.id, webapp_sessions.user_id, webapp_sessions.ip_address, webapp_sessions.user_agent, webapp_sessions.status, webapp_sessions.expires_at, webapp_sessions.created_at, webapp_sessions.last_seen_at FROM webapp_sessions WHERE webapp_sessions.id = ?")
 
 	var __values []interface{}
 	__values = append(__values, webapp_session_id.value())
@@ -13419,7 +15219,7 @@ func (obj *pgxImpl) Get_WebappSession_By_Id(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	webapp_session = &WebappSession{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&webapp_session.Id, &webapp_session.UserId, &webapp_session.IpAddress, &webapp_session.UserAgent, &webapp_session.Status, &webapp_session.ExpiresAt)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&webapp_session.Id, &webapp_session.UserId, &webapp_session.IpAddress, &webapp_session.UserAgent, &webapp_session.Status, &webapp_session.ExpiresAt, &webapp_session.CreatedAt, &webapp_session.LastSeenAt)
 	if err != nil {
 		return (*WebappSession)(nil), obj.makeErr(err)
 	}
@@ -13427,6 +15227,29 @@ func (obj *pgxImpl) Get_WebappSession_By_Id(ctx context.Context,
 
 }
 
+func (obj *pgxImpl) Get_SsoIdentity_By_Issuer_And_Subject(ctx context.Context,
+	sso_identity_issuer SsoIdentity_Issuer_Field,
+	sso_identity_subject SsoIdentity_Subject_Field) (
+	sso_identity *SsoIdentity, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT sso_identities.issuer, sso_identities.subject, sso_identities.user_id, sso_identities.created_at FROM sso_identities WHERE sso_identities.issuer = ? AND sso_identities.subject = ?")
+
+	var __values []interface{}
+	__values = append(__values, sso_identity_issuer.value(), sso_identity_subject.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	sso_identity = &SsoIdentity{}
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&sso_identity.Issuer, &sso_identity.Subject, &sso_identity.UserId, &sso_identity.CreatedAt)
+	if err != nil {
+		return (*SsoIdentity)(nil), obj.makeErr(err)
+	}
+	return sso_identity, nil
+
+}
+
 func (obj *pgxImpl) Get_Project_By_PublicId(ctx context.Context,
 	project_public_id Project_PublicId_Field) (
 	project *Project, err error) {
@@ -13434,7 +15257,7 @@ func (obj *pgxImpl) Get_Project_By_PublicId(ctx context.Context,
 
 	var __cond_0 = &__sqlbundle_Condition{Left: "projects.public_id", Equal: true, Right: "?", Null: true}
 
-	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("SELECT projects.id, projects.public_id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at FROM projects WHERE "), __cond_0, __sqlbundle_Literal(" LIMIT 2")}}
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("SELECT projects.id, projects.public_id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.bandwidth_rate_limit, projects.bandwidth_burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.organization_id, projects.created_at FROM projects WHERE "), __cond_0, __sqlbundle_Literal(" LIMIT 2")}}
 
 	var __values []interface{}
 	if !project_public_id.isnull() {
@@ -13461,7 +15284,7 @@ func (obj *pgxImpl) Get_Project_By_PublicId(ctx context.Context,
 			}
 
 			project = &Project{}
-			err = __rows.Scan(&project.Id, &project.PublicId, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
+			err = __rows.Scan(&project.Id, &project.PublicId, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.BandwidthRateLimit, &project.BandwidthBurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.OrganizationId, &project.CreatedAt)
 			if err != nil {
 				return nil, err
 			}
@@ -13495,7 +15318,7 @@ func (obj *pgxImpl) Get_Project_By_Id(ctx context.Context,
 	project *Project, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.public_id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at FROM projects WHERE projects.id = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.public_id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.bandwidth_rate_limit, projects.bandwidth_burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.organization_id, projects.created_at FROM projects WHERE projects.id = ?")
 
 	var __values []interface{}
 	__values = append(__values, project_id.value())
@@ -13504,7 +15327,7 @@ func (obj *pgxImpl) Get_Project_By_Id(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	project = &Project{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&project.Id, &project.PublicId, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&project.Id, &project.PublicId, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.BandwidthRateLimit, &project.BandwidthBurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.OrganizationId, &project.CreatedAt)
 	if err != nil {
 		return (*Project)(nil), obj.makeErr(err)
 	}
@@ -13600,12 +15423,12 @@ func (obj *pgxImpl) Get_Project_MaxBuckets_By_Id(ctx context.Context,
 
 }
 
-func (obj *pgxImpl) Get_Project_BandwidthLimit_Project_UsageLimit_Project_SegmentLimit_By_Id(ctx context.Context,
+func (obj *pgxImpl) Get_Project_BandwidthLimit_Project_UsageLimit_Project_SegmentLimit_Project_BandwidthRateLimit_Project_BandwidthBurstLimit_By_Id(ctx context.Context,
 	project_id Project_Id_Field) (
-	row *BandwidthLimit_UsageLimit_SegmentLimit_Row, err error) {
+	row *BandwidthLimit_UsageLimit_SegmentLimit_BandwidthRateLimit_BandwidthBurstLimit_Row, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT projects.bandwidth_limit, projects.usage_limit, projects.segment_limit FROM projects WHERE projects.id = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT projects.bandwidth_limit, projects.usage_limit, projects.segment_limit, projects.bandwidth_rate_limit, projects.bandwidth_burst_limit FROM projects WHERE projects.id = ?")
 
 	var __values []interface{}
 	__values = append(__values, project_id.value())
@@ -13613,22 +15436,250 @@ func (obj *pgxImpl) Get_Project_BandwidthLimit_Project_UsageLimit_Project_Segmen
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
-	row = &BandwidthLimit_UsageLimit_SegmentLimit_Row{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&row.BandwidthLimit, &row.UsageLimit, &row.SegmentLimit)
+	row = &BandwidthLimit_UsageLimit_SegmentLimit_BandwidthRateLimit_BandwidthBurstLimit_Row{}
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&row.BandwidthLimit, &row.UsageLimit, &row.SegmentLimit, &row.BandwidthRateLimit, &row.BandwidthBurstLimit)
 	if err != nil {
-		return (*BandwidthLimit_UsageLimit_SegmentLimit_Row)(nil), obj.makeErr(err)
+		return (*BandwidthLimit_UsageLimit_SegmentLimit_BandwidthRateLimit_BandwidthBurstLimit_Row)(nil), obj.makeErr(err)
+	}
+	return row, nil
+
+}
+
+func (obj *pgxImpl) All_Project(ctx context.Context) (
+	rows []*Project, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.public_id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.bandwidth_rate_limit, projects.bandwidth_burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.organization_id, projects.created_at FROM projects")
+
+	var __values []interface{}
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	for {
+		rows, err = func() (rows []*Project, err error) {
+			__rows, err := obj.driver.QueryContext(ctx, __stmt, __values...)
+			if err != nil {
+				return nil, err
+			}
+			defer __rows.Close()
+
+			for __rows.Next() {
+				project := &Project{}
+				err = __rows.Scan(&project.Id, &project.PublicId, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.BandwidthRateLimit, &project.BandwidthBurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.OrganizationId, &project.CreatedAt)
+				if err != nil {
+					return nil, err
+				}
+				rows = append(rows, project)
+			}
+			if err := __rows.Err(); err != nil {
+				return nil, err
+			}
+			return rows, nil
+		}()
+		if err != nil {
+			if obj.shouldRetry(err) {
+				continue
+			}
+			return nil, obj.makeErr(err)
+		}
+		return rows, nil
+	}
+
+}
+
+func (obj *pgxImpl) All_Project_By_CreatedAt_Less_OrderBy_Asc_CreatedAt(ctx context.Context,
+	project_created_at_less Project_CreatedAt_Field) (
+	rows []*Project, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.public_id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.bandwidth_rate_limit, projects.bandwidth_burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.organization_id, projects.created_at FROM projects WHERE projects.created_at < ? ORDER BY projects.created_at")
+
+	var __values []interface{}
+	__values = append(__values, project_created_at_less.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	for {
+		rows, err = func() (rows []*Project, err error) {
+			__rows, err := obj.driver.QueryContext(ctx, __stmt, __values...)
+			if err != nil {
+				return nil, err
+			}
+			defer __rows.Close()
+
+			for __rows.Next() {
+				project := &Project{}
+				err = __rows.Scan(&project.Id, &project.PublicId, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.BandwidthRateLimit, &project.BandwidthBurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.OrganizationId, &project.CreatedAt)
+				if err != nil {
+					return nil, err
+				}
+				rows = append(rows, project)
+			}
+			if err := __rows.Err(); err != nil {
+				return nil, err
+			}
+			return rows, nil
+		}()
+		if err != nil {
+			if obj.shouldRetry(err) {
+				continue
+			}
+			return nil, obj.makeErr(err)
+		}
+		return rows, nil
+	}
+
+}
+
+func (obj *pgxImpl) All_Project_By_OwnerId_OrderBy_Asc_CreatedAt(ctx context.Context,
+	project_owner_id Project_OwnerId_Field) (
+	rows []*Project, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.public_id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.bandwidth_rate_limit, projects.bandwidth_burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.organization_id, projects.created_at FROM projects WHERE projects.owner_id = ? ORDER BY projects.created_at")
+
+	var __values []interface{}
+	__values = append(__values, project_owner_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	for {
+		rows, err = func() (rows []*Project, err error) {
+			__rows, err := obj.driver.QueryContext(ctx, __stmt, __values...)
+			if err != nil {
+				return nil, err
+			}
+			defer __rows.Close()
+
+			for __rows.Next() {
+				project := &Project{}
+				err = __rows.Scan(&project.Id, &project.PublicId, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.BandwidthRateLimit, &project.BandwidthBurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.OrganizationId, &project.CreatedAt)
+				if err != nil {
+					return nil, err
+				}
+				rows = append(rows, project)
+			}
+			if err := __rows.Err(); err != nil {
+				return nil, err
+			}
+			return rows, nil
+		}()
+		if err != nil {
+			if obj.shouldRetry(err) {
+				continue
+			}
+			return nil, obj.makeErr(err)
+		}
+		return rows, nil
+	}
+
+}
+
+func (obj *pgxImpl) All_Project_By_ProjectMember_MemberId_OrderBy_Asc_Project_Name(ctx context.Context,
+	project_member_member_id ProjectMember_MemberId_Field) (
+	rows []*Project, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.public_id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.bandwidth_rate_limit, projects.bandwidth_burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.organization_id, projects.created_at FROM projects  JOIN project_members ON projects.id = project_members.project_id WHERE project_members.member_id = ? ORDER BY projects.name")
+
+	var __values []interface{}
+	__values = append(__values, project_member_member_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	for {
+		rows, err = func() (rows []*Project, err error) {
+			__rows, err := obj.driver.QueryContext(ctx, __stmt, __values...)
+			if err != nil {
+				return nil, err
+			}
+			defer __rows.Close()
+
+			for __rows.Next() {
+				project := &Project{}
+				err = __rows.Scan(&project.Id, &project.PublicId, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.BandwidthRateLimit, &project.BandwidthBurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.OrganizationId, &project.CreatedAt)
+				if err != nil {
+					return nil, err
+				}
+				rows = append(rows, project)
+			}
+			if err := __rows.Err(); err != nil {
+				return nil, err
+			}
+			return rows, nil
+		}()
+		if err != nil {
+			if obj.shouldRetry(err) {
+				continue
+			}
+			return nil, obj.makeErr(err)
+		}
+		return rows, nil
+	}
+
+}
+
+func (obj *pgxImpl) All_Project_By_OrganizationId_OrderBy_Asc_CreatedAt(ctx context.Context,
+	project_organization_id Project_OrganizationId_Field) (
+	rows []*Project, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.public_id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.bandwidth_rate_limit, projects.bandwidth_burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.organization_id, projects.created_at FROM projects WHERE projects.organization_id = ? ORDER BY projects.created_at")
+
+	var __values []interface{}
+	__values = append(__values, project_organization_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	for {
+		rows, err = func() (rows []*Project, err error) {
+			__rows, err := obj.driver.QueryContext(ctx, __stmt, __values...)
+			if err != nil {
+				return nil, err
+			}
+			defer __rows.Close()
+
+			for __rows.Next() {
+				project := &Project{}
+				err = __rows.Scan(&project.Id, &project.PublicId, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.BandwidthRateLimit, &project.BandwidthBurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.OrganizationId, &project.CreatedAt)
+				if err != nil {
+					return nil, err
+				}
+				rows = append(rows, project)
+			}
+			if err := __rows.Err(); err != nil {
+				return nil, err
+			}
+			return rows, nil
+		}()
+		if err != nil {
+			if obj.shouldRetry(err) {
+				continue
+			}
+			return nil, obj.makeErr(err)
+		}
+		return rows, nil
 	}
-	return row, nil
 
 }
 
-func (obj *pgxImpl) All_Project(ctx context.Context) (
+func (obj *pgxImpl) Limited_Project_By_CreatedAt_Less_OrderBy_Asc_CreatedAt(ctx context.Context,
+	project_created_at_less Project_CreatedAt_Field,
+	limit int, offset int64) (
 	rows []*Project, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.public_id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at FROM projects")
+	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.public_id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.bandwidth_rate_limit, projects.bandwidth_burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.organization_id, projects.created_at FROM projects WHERE projects.created_at < ? ORDER BY projects.created_at LIMIT ? OFFSET ?")
 
 	var __values []interface{}
+	__values = append(__values, project_created_at_less.value())
+
+	__values = append(__values, limit, offset)
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
@@ -13643,13 +15694,14 @@ func (obj *pgxImpl) All_Project(ctx context.Context) (
 
 			for __rows.Next() {
 				project := &Project{}
-				err = __rows.Scan(&project.Id, &project.PublicId, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
+				err = __rows.Scan(&project.Id, &project.PublicId, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.BandwidthRateLimit, &project.BandwidthBurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.OrganizationId, &project.CreatedAt)
 				if err != nil {
 					return nil, err
 				}
 				rows = append(rows, project)
 			}
-			if err := __rows.Err(); err != nil {
+			err = __rows.Err()
+			if err != nil {
 				return nil, err
 			}
 			return rows, nil
@@ -13665,21 +15717,21 @@ func (obj *pgxImpl) All_Project(ctx context.Context) (
 
 }
 
-func (obj *pgxImpl) All_Project_By_CreatedAt_Less_OrderBy_Asc_CreatedAt(ctx context.Context,
-	project_created_at_less Project_CreatedAt_Field) (
-	rows []*Project, err error) {
+func (obj *pgxImpl) All_ProjectMember_By_MemberId(ctx context.Context,
+	project_member_member_id ProjectMember_MemberId_Field) (
+	rows []*ProjectMember, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.public_id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at FROM projects WHERE projects.created_at < ? ORDER BY projects.created_at")
+	var __embed_stmt = __sqlbundle_Literal("SELECT project_members.member_id, project_members.project_id, project_members.created_at FROM project_members WHERE project_members.member_id = ?")
 
 	var __values []interface{}
-	__values = append(__values, project_created_at_less.value())
+	__values = append(__values, project_member_member_id.value())
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
 	for {
-		rows, err = func() (rows []*Project, err error) {
+		rows, err = func() (rows []*ProjectMember, err error) {
 			__rows, err := obj.driver.QueryContext(ctx, __stmt, __values...)
 			if err != nil {
 				return nil, err
@@ -13687,12 +15739,12 @@ func (obj *pgxImpl) All_Project_By_CreatedAt_Less_OrderBy_Asc_CreatedAt(ctx cont
 			defer __rows.Close()
 
 			for __rows.Next() {
-				project := &Project{}
-				err = __rows.Scan(&project.Id, &project.PublicId, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
+				project_member := &ProjectMember{}
+				err = __rows.Scan(&project_member.MemberId, &project_member.ProjectId, &project_member.CreatedAt)
 				if err != nil {
 					return nil, err
 				}
-				rows = append(rows, project)
+				rows = append(rows, project_member)
 			}
 			if err := __rows.Err(); err != nil {
 				return nil, err
@@ -13710,21 +15762,41 @@ func (obj *pgxImpl) All_Project_By_CreatedAt_Less_OrderBy_Asc_CreatedAt(ctx cont
 
 }
 
-func (obj *pgxImpl) All_Project_By_OwnerId_OrderBy_Asc_CreatedAt(ctx context.Context,
-	project_owner_id Project_OwnerId_Field) (
-	rows []*Project, err error) {
+func (obj *pgxImpl) Get_ProjectSpendingCap_By_ProjectId(ctx context.Context,
+	project_spending_cap_project_id ProjectSpendingCap_ProjectId_Field) (
+	project_spending_cap *ProjectSpendingCap, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.public_id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at FROM projects WHERE projects.owner_id = ? ORDER BY projects.created_at")
+	var __embed_stmt = __sqlbundle_Literal("SELECT project_spending_caps.project_id, project_spending_caps.cap, project_spending_caps.alert_thresholds, project_spending_caps.enforce, project_spending_caps.limit_bandwidth, project_spending_caps.alert_period, project_spending_caps.alerted_threshold, project_spending_caps.capped, project_spending_caps.created_at FROM project_spending_caps WHERE project_spending_caps.project_id = ?")
+
+	var __values []interface{}
+	__values = append(__values, project_spending_cap_project_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	project_spending_cap = &ProjectSpendingCap{}
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&project_spending_cap.ProjectId, &project_spending_cap.Cap, &project_spending_cap.AlertThresholds, &project_spending_cap.Enforce, &project_spending_cap.LimitBandwidth, &project_spending_cap.AlertPeriod, &project_spending_cap.AlertedThreshold, &project_spending_cap.Capped, &project_spending_cap.CreatedAt)
+	if err != nil {
+		return (*ProjectSpendingCap)(nil), obj.makeErr(err)
+	}
+	return project_spending_cap, nil
+
+}
+
+func (obj *pgxImpl) All_ProjectSpendingCap(ctx context.Context) (
+	rows []*ProjectSpendingCap, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT project_spending_caps.project_id, project_spending_caps.cap, project_spending_caps.alert_thresholds, project_spending_caps.enforce, project_spending_caps.limit_bandwidth, project_spending_caps.alert_period, project_spending_caps.alerted_threshold, project_spending_caps.capped, project_spending_caps.created_at FROM project_spending_caps")
 
 	var __values []interface{}
-	__values = append(__values, project_owner_id.value())
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
 	for {
-		rows, err = func() (rows []*Project, err error) {
+		rows, err = func() (rows []*ProjectSpendingCap, err error) {
 			__rows, err := obj.driver.QueryContext(ctx, __stmt, __values...)
 			if err != nil {
 				return nil, err
@@ -13732,12 +15804,12 @@ func (obj *pgxImpl) All_Project_By_OwnerId_OrderBy_Asc_CreatedAt(ctx context.Con
 			defer __rows.Close()
 
 			for __rows.Next() {
-				project := &Project{}
-				err = __rows.Scan(&project.Id, &project.PublicId, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
+				project_spending_cap := &ProjectSpendingCap{}
+				err = __rows.Scan(&project_spending_cap.ProjectId, &project_spending_cap.Cap, &project_spending_cap.AlertThresholds, &project_spending_cap.Enforce, &project_spending_cap.LimitBandwidth, &project_spending_cap.AlertPeriod, &project_spending_cap.AlertedThreshold, &project_spending_cap.Capped, &project_spending_cap.CreatedAt)
 				if err != nil {
 					return nil, err
 				}
-				rows = append(rows, project)
+				rows = append(rows, project_spending_cap)
 			}
 			if err := __rows.Err(); err != nil {
 				return nil, err
@@ -13755,21 +15827,41 @@ func (obj *pgxImpl) All_Project_By_OwnerId_OrderBy_Asc_CreatedAt(ctx context.Con
 
 }
 
-func (obj *pgxImpl) All_Project_By_ProjectMember_MemberId_OrderBy_Asc_Project_Name(ctx context.Context,
-	project_member_member_id ProjectMember_MemberId_Field) (
-	rows []*Project, err error) {
+func (obj *pgxImpl) Get_ProjectUsageExport_By_ProjectId(ctx context.Context,
+	project_usage_export_project_id ProjectUsageExport_ProjectId_Field) (
+	project_usage_export *ProjectUsageExport, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.public_id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at FROM projects  JOIN project_members ON projects.id = project_members.project_id WHERE project_members.member_id = ? ORDER BY projects.name")
+	var __embed_stmt = __sqlbundle_Literal("SELECT project_usage_exports.project_id, project_usage_exports.encrypted_access, project_usage_exports.bucket, project_usage_exports.prefix, project_usage_exports.format, project_usage_exports.schedule, project_usage_exports.exported_until, project_usage_exports.created_at FROM project_usage_exports WHERE project_usage_exports.project_id = ?")
+
+	var __values []interface{}
+	__values = append(__values, project_usage_export_project_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	project_usage_export = &ProjectUsageExport{}
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&project_usage_export.ProjectId, &project_usage_export.EncryptedAccess, &project_usage_export.Bucket, &project_usage_export.Prefix, &project_usage_export.Format, &project_usage_export.Schedule, &project_usage_export.ExportedUntil, &project_usage_export.CreatedAt)
+	if err != nil {
+		return (*ProjectUsageExport)(nil), obj.makeErr(err)
+	}
+	return project_usage_export, nil
+
+}
+
+func (obj *pgxImpl) All_ProjectUsageExport(ctx context.Context) (
+	rows []*ProjectUsageExport, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT project_usage_exports.project_id, project_usage_exports.encrypted_access, project_usage_exports.bucket, project_usage_exports.prefix, project_usage_exports.format, project_usage_exports.schedule, project_usage_exports.exported_until, project_usage_exports.created_at FROM project_usage_exports")
 
 	var __values []interface{}
-	__values = append(__values, project_member_member_id.value())
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
 	for {
-		rows, err = func() (rows []*Project, err error) {
+		rows, err = func() (rows []*ProjectUsageExport, err error) {
 			__rows, err := obj.driver.QueryContext(ctx, __stmt, __values...)
 			if err != nil {
 				return nil, err
@@ -13777,12 +15869,12 @@ func (obj *pgxImpl) All_Project_By_ProjectMember_MemberId_OrderBy_Asc_Project_Na
 			defer __rows.Close()
 
 			for __rows.Next() {
-				project := &Project{}
-				err = __rows.Scan(&project.Id, &project.PublicId, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
+				project_usage_export := &ProjectUsageExport{}
+				err = __rows.Scan(&project_usage_export.ProjectId, &project_usage_export.EncryptedAccess, &project_usage_export.Bucket, &project_usage_export.Prefix, &project_usage_export.Format, &project_usage_export.Schedule, &project_usage_export.ExportedUntil, &project_usage_export.CreatedAt)
 				if err != nil {
 					return nil, err
 				}
-				rows = append(rows, project)
+				rows = append(rows, project_usage_export)
 			}
 			if err := __rows.Err(); err != nil {
 				return nil, err
@@ -13800,24 +15892,43 @@ func (obj *pgxImpl) All_Project_By_ProjectMember_MemberId_OrderBy_Asc_Project_Na
 
 }
 
-func (obj *pgxImpl) Limited_Project_By_CreatedAt_Less_OrderBy_Asc_CreatedAt(ctx context.Context,
-	project_created_at_less Project_CreatedAt_Field,
-	limit int, offset int64) (
-	rows []*Project, err error) {
+func (obj *pgxImpl) Get_Organization_By_Id(ctx context.Context,
+	organization_id Organization_Id_Field) (
+	organization *Organization, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.public_id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at FROM projects WHERE projects.created_at < ? ORDER BY projects.created_at LIMIT ? OFFSET ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT organizations.id, organizations.name, organizations.created_at FROM organizations WHERE organizations.id = ?")
 
 	var __values []interface{}
-	__values = append(__values, project_created_at_less.value())
+	__values = append(__values, organization_id.value())
 
-	__values = append(__values, limit, offset)
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	organization = &Organization{}
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&organization.Id, &organization.Name, &organization.CreatedAt)
+	if err != nil {
+		return (*Organization)(nil), obj.makeErr(err)
+	}
+	return organization, nil
+
+}
+
+func (obj *pgxImpl) All_Organization_By_OrganizationMember_MemberId_OrderBy_Asc_Organization_Name(ctx context.Context,
+	organization_member_member_id OrganizationMember_MemberId_Field) (
+	rows []*Organization, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT organizations.id, organizations.name, organizations.created_at FROM organizations  JOIN organization_members ON organizations.id = organization_members.organization_id WHERE organization_members.member_id = ? ORDER BY organizations.name")
+
+	var __values []interface{}
+	__values = append(__values, organization_member_member_id.value())
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
 	for {
-		rows, err = func() (rows []*Project, err error) {
+		rows, err = func() (rows []*Organization, err error) {
 			__rows, err := obj.driver.QueryContext(ctx, __stmt, __values...)
 			if err != nil {
 				return nil, err
@@ -13825,15 +15936,14 @@ func (obj *pgxImpl) Limited_Project_By_CreatedAt_Less_OrderBy_Asc_CreatedAt(ctx
 			defer __rows.Close()
 
 			for __rows.Next() {
-				project := &Project{}
-				err = __rows.Scan(&project.Id, &project.PublicId, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
+				organization := &Organization{}
+				err = __rows.Scan(&organization.Id, &organization.Name, &organization.CreatedAt)
 				if err != nil {
 					return nil, err
 				}
-				rows = append(rows, project)
+				rows = append(rows, organization)
 			}
-			err = __rows.Err()
-			if err != nil {
+			if err := __rows.Err(); err != nil {
 				return nil, err
 			}
 			return rows, nil
@@ -13849,21 +15959,21 @@ func (obj *pgxImpl) Limited_Project_By_CreatedAt_Less_OrderBy_Asc_CreatedAt(ctx
 
 }
 
-func (obj *pgxImpl) All_ProjectMember_By_MemberId(ctx context.Context,
-	project_member_member_id ProjectMember_MemberId_Field) (
-	rows []*ProjectMember, err error) {
+func (obj *pgxImpl) All_OrganizationMember_By_OrganizationId_OrderBy_Asc_CreatedAt(ctx context.Context,
+	organization_member_organization_id OrganizationMember_OrganizationId_Field) (
+	rows []*OrganizationMember, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT project_members.member_id, project_members.project_id, project_members.created_at FROM project_members WHERE project_members.member_id = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT organization_members.organization_id, organization_members.member_id, organization_members.created_at FROM organization_members WHERE organization_members.organization_id = ? ORDER BY organization_members.created_at")
 
 	var __values []interface{}
-	__values = append(__values, project_member_member_id.value())
+	__values = append(__values, organization_member_organization_id.value())
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
 	for {
-		rows, err = func() (rows []*ProjectMember, err error) {
+		rows, err = func() (rows []*OrganizationMember, err error) {
 			__rows, err := obj.driver.QueryContext(ctx, __stmt, __values...)
 			if err != nil {
 				return nil, err
@@ -13871,12 +15981,12 @@ func (obj *pgxImpl) All_ProjectMember_By_MemberId(ctx context.Context,
 			defer __rows.Close()
 
 			for __rows.Next() {
-				project_member := &ProjectMember{}
-				err = __rows.Scan(&project_member.MemberId, &project_member.ProjectId, &project_member.CreatedAt)
+				organization_member := &OrganizationMember{}
+				err = __rows.Scan(&organization_member.OrganizationId, &organization_member.MemberId, &organization_member.CreatedAt)
 				if err != nil {
 					return nil, err
 				}
-				rows = append(rows, project_member)
+				rows = append(rows, organization_member)
 			}
 			if err := __rows.Err(); err != nil {
 				return nil, err
@@ -13894,6 +16004,28 @@ func (obj *pgxImpl) All_ProjectMember_By_MemberId(ctx context.Context,
 
 }
 
+func (obj *pgxImpl) Has_OrganizationMember_By_OrganizationId_And_MemberId(ctx context.Context,
+	organization_member_organization_id OrganizationMember_OrganizationId_Field,
+	organization_member_member_id OrganizationMember_MemberId_Field) (
+	has bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT EXISTS( SELECT 1 FROM organization_members WHERE organization_members.organization_id = ? AND organization_members.member_id = ? )")
+
+	var __values []interface{}
+	__values = append(__values, organization_member_organization_id.value(), organization_member_member_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&has)
+	if err != nil {
+		return false, obj.makeErr(err)
+	}
+	return has, nil
+
+}
+
 func (obj *pgxImpl) Get_ApiKey_By_Id(ctx context.Context,
 	api_key_id ApiKey_Id_Field) (
 	api_key *ApiKey, err error) {
@@ -13961,6 +16093,73 @@ func (obj *pgxImpl) Get_ApiKey_By_Name_And_ProjectId(ctx context.Context,
 
 }
 
+func (obj *pgxImpl) Get_AccessGrant_By_Id(ctx context.Context,
+	access_grant_id AccessGrant_Id_Field) (
+	access_grant *AccessGrant, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT access_grants.id, access_grants.project_id, access_grants.api_key_id, access_grants.name, access_grants.caveats, access_grants.tails, access_grants.created_by, access_grants.created_at FROM access_grants WHERE access_grants.id = ?")
+
+	var __values []interface{}
+	__values = append(__values, access_grant_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	access_grant = &AccessGrant{}
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&access_grant.Id, &access_grant.ProjectId, &access_grant.ApiKeyId, &access_grant.Name, &access_grant.Caveats, &access_grant.Tails, &access_grant.CreatedBy, &access_grant.CreatedAt)
+	if err != nil {
+		return (*AccessGrant)(nil), obj.makeErr(err)
+	}
+	return access_grant, nil
+
+}
+
+func (obj *pgxImpl) All_AccessGrant_By_ProjectId_OrderBy_Asc_CreatedAt(ctx context.Context,
+	access_grant_project_id AccessGrant_ProjectId_Field) (
+	rows []*AccessGrant, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT access_grants.id, access_grants.project_id, access_grants.api_key_id, access_grants.name, access_grants.caveats, access_grants.tails, access_grants.created_by, access_grants.created_at FROM access_grants WHERE access_grants.project_id = ? ORDER BY access_grants.created_at")
+
+	var __values []interface{}
+	__values = append(__values, access_grant_project_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	for {
+		rows, err = func() (rows []*AccessGrant, err error) {
+			__rows, err := obj.driver.QueryContext(ctx, __stmt, __values...)
+			if err != nil {
+				return nil, err
+			}
+			defer __rows.Close()
+
+			for __rows.Next() {
+				access_grant := &AccessGrant{}
+				err = __rows.Scan(&access_grant.Id, &access_grant.ProjectId, &access_grant.ApiKeyId, &access_grant.Name, &access_grant.Caveats, &access_grant.Tails, &access_grant.CreatedBy, &access_grant.CreatedAt)
+				if err != nil {
+					return nil, err
+				}
+				rows = append(rows, access_grant)
+			}
+			if err := __rows.Err(); err != nil {
+				return nil, err
+			}
+			return rows, nil
+		}()
+		if err != nil {
+			if obj.shouldRetry(err) {
+				continue
+			}
+			return nil, obj.makeErr(err)
+		}
+		return rows, nil
+	}
+
+}
+
 func (obj *pgxImpl) Paged_BucketBandwidthRollup_By_IntervalStart_GreaterOrEqual(ctx context.Context,
 	bucket_bandwidth_rollup_interval_start_greater_or_equal BucketBandwidthRollup_IntervalStart_Field,
 	limit int, start *Paged_BucketBandwidthRollup_By_IntervalStart_GreaterOrEqual_Continuation) (
@@ -15201,28 +17400,120 @@ func (obj *pgxImpl) Get_StripeCustomer_CustomerId_By_UserId(ctx context.Context,
 	if err != nil {
 		return (*CustomerId_Row)(nil), obj.makeErr(err)
 	}
-	return row, nil
+	return row, nil
+
+}
+
+func (obj *pgxImpl) Limited_StripeCustomer_By_CreatedAt_LessOrEqual_OrderBy_Desc_CreatedAt(ctx context.Context,
+	stripe_customer_created_at_less_or_equal StripeCustomer_CreatedAt_Field,
+	limit int, offset int64) (
+	rows []*StripeCustomer, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT stripe_customers.user_id, stripe_customers.customer_id, stripe_customers.created_at FROM stripe_customers WHERE stripe_customers.created_at <= ? ORDER BY stripe_customers.created_at DESC LIMIT ? OFFSET ?")
+
+	var __values []interface{}
+	__values = append(__values, stripe_customer_created_at_less_or_equal.value())
+
+	__values = append(__values, limit, offset)
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	for {
+		rows, err = func() (rows []*StripeCustomer, err error) {
+			__rows, err := obj.driver.QueryContext(ctx, __stmt, __values...)
+			if err != nil {
+				return nil, err
+			}
+			defer __rows.Close()
+
+			for __rows.Next() {
+				stripe_customer := &StripeCustomer{}
+				err = __rows.Scan(&stripe_customer.UserId, &stripe_customer.CustomerId, &stripe_customer.CreatedAt)
+				if err != nil {
+					return nil, err
+				}
+				rows = append(rows, stripe_customer)
+			}
+			err = __rows.Err()
+			if err != nil {
+				return nil, err
+			}
+			return rows, nil
+		}()
+		if err != nil {
+			if obj.shouldRetry(err) {
+				continue
+			}
+			return nil, obj.makeErr(err)
+		}
+		return rows, nil
+	}
+
+}
+
+func (obj *pgxImpl) All_BillingTransaction_By_UserId_OrderBy_Desc_Timestamp(ctx context.Context,
+	billing_transaction_user_id BillingTransaction_UserId_Field) (
+	rows []*BillingTransaction, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT billing_transactions.tx_id, billing_transactions.user_id, billing_transactions.amount, billing_transactions.currency, billing_transactions.description, billing_transactions.type, billing_transactions.timestamp, billing_transactions.created_at FROM billing_transactions WHERE billing_transactions.user_id = ? ORDER BY billing_transactions.timestamp DESC")
+
+	var __values []interface{}
+	__values = append(__values, billing_transaction_user_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	for {
+		rows, err = func() (rows []*BillingTransaction, err error) {
+			__rows, err := obj.driver.QueryContext(ctx, __stmt, __values...)
+			if err != nil {
+				return nil, err
+			}
+			defer __rows.Close()
+
+			for __rows.Next() {
+				billing_transaction := &BillingTransaction{}
+				err = __rows.Scan(&billing_transaction.TxId, &billing_transaction.UserId, &billing_transaction.Amount, &billing_transaction.Currency, &billing_transaction.Description, &billing_transaction.Type, &billing_transaction.Timestamp, &billing_transaction.CreatedAt)
+				if err != nil {
+					return nil, err
+				}
+				rows = append(rows, billing_transaction)
+			}
+			if err := __rows.Err(); err != nil {
+				return nil, err
+			}
+			return rows, nil
+		}()
+		if err != nil {
+			if obj.shouldRetry(err) {
+				continue
+			}
+			return nil, obj.makeErr(err)
+		}
+		return rows, nil
+	}
 
 }
 
-func (obj *pgxImpl) Limited_StripeCustomer_By_CreatedAt_LessOrEqual_OrderBy_Desc_CreatedAt(ctx context.Context,
-	stripe_customer_created_at_less_or_equal StripeCustomer_CreatedAt_Field,
-	limit int, offset int64) (
-	rows []*StripeCustomer, err error) {
+func (obj *pgxImpl) All_BillingTransaction_By_UserId_And_Type_OrderBy_Desc_Timestamp(ctx context.Context,
+	billing_transaction_user_id BillingTransaction_UserId_Field,
+	billing_transaction_type BillingTransaction_Type_Field) (
+	rows []*BillingTransaction, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT stripe_customers.user_id, stripe_customers.customer_id, stripe_customers.created_at FROM stripe_customers WHERE stripe_customers.created_at <= ? ORDER BY stripe_customers.created_at DESC LIMIT ? OFFSET ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT billing_transactions.tx_id, billing_transactions.user_id, billing_transactions.amount, billing_transactions.currency, billing_transactions.description, billing_transactions.type, billing_transactions.timestamp, billing_transactions.created_at FROM billing_transactions WHERE billing_transactions.user_id = ? AND billing_transactions.type = ? ORDER BY billing_transactions.timestamp DESC")
 
 	var __values []interface{}
-	__values = append(__values, stripe_customer_created_at_less_or_equal.value())
-
-	__values = append(__values, limit, offset)
+	__values = append(__values, billing_transaction_user_id.value(), billing_transaction_type.value())
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
 	for {
-		rows, err = func() (rows []*StripeCustomer, err error) {
+		rows, err = func() (rows []*BillingTransaction, err error) {
 			__rows, err := obj.driver.QueryContext(ctx, __stmt, __values...)
 			if err != nil {
 				return nil, err
@@ -15230,15 +17521,14 @@ func (obj *pgxImpl) Limited_StripeCustomer_By_CreatedAt_LessOrEqual_OrderBy_Desc
 			defer __rows.Close()
 
 			for __rows.Next() {
-				stripe_customer := &StripeCustomer{}
-				err = __rows.Scan(&stripe_customer.UserId, &stripe_customer.CustomerId, &stripe_customer.CreatedAt)
+				billing_transaction := &BillingTransaction{}
+				err = __rows.Scan(&billing_transaction.TxId, &billing_transaction.UserId, &billing_transaction.Amount, &billing_transaction.Currency, &billing_transaction.Description, &billing_transaction.Type, &billing_transaction.Timestamp, &billing_transaction.CreatedAt)
 				if err != nil {
 					return nil, err
 				}
-				rows = append(rows, stripe_customer)
+				rows = append(rows, billing_transaction)
 			}
-			err = __rows.Err()
-			if err != nil {
+			if err := __rows.Err(); err != nil {
 				return nil, err
 			}
 			return rows, nil
@@ -15254,21 +17544,66 @@ func (obj *pgxImpl) Limited_StripeCustomer_By_CreatedAt_LessOrEqual_OrderBy_Desc
 
 }
 
-func (obj *pgxImpl) All_BillingTransaction_By_UserId_OrderBy_Desc_Timestamp(ctx context.Context,
-	billing_transaction_user_id BillingTransaction_UserId_Field) (
-	rows []*BillingTransaction, err error) {
+func (obj *pgxImpl) Get_BillingInvoice_By_Id(ctx context.Context,
+	billing_invoice_id BillingInvoice_Id_Field) (
+	billing_invoice *BillingInvoice, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT billing_transactions.tx_id, billing_transactions.user_id, billing_transactions.amount, billing_transactions.currency, billing_transactions.description, billing_transactions.type, billing_transactions.timestamp, billing_transactions.created_at FROM billing_transactions WHERE billing_transactions.user_id = ? ORDER BY billing_transactions.timestamp DESC")
+	var __embed_stmt = __sqlbundle_Literal("SELECT billing_invoices.id, billing_invoices.user_id, billing_invoices.period_start, billing_invoices.period_end, billing_invoices.description, billing_invoices.items, billing_invoices.amount, billing_invoices.status, billing_invoices.created_at, billing_invoices.paid_at FROM billing_invoices WHERE billing_invoices.id = ?")
 
 	var __values []interface{}
-	__values = append(__values, billing_transaction_user_id.value())
+	__values = append(__values, billing_invoice_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	billing_invoice = &BillingInvoice{}
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&billing_invoice.Id, &billing_invoice.UserId, &billing_invoice.PeriodStart, &billing_invoice.PeriodEnd, &billing_invoice.Description, &billing_invoice.Items, &billing_invoice.Amount, &billing_invoice.Status, &billing_invoice.CreatedAt, &billing_invoice.PaidAt)
+	if err != nil {
+		return (*BillingInvoice)(nil), obj.makeErr(err)
+	}
+	return billing_invoice, nil
+
+}
+
+func (obj *pgxImpl) Get_BillingInvoice_By_UserId_And_PeriodStart(ctx context.Context,
+	billing_invoice_user_id BillingInvoice_UserId_Field,
+	billing_invoice_period_start BillingInvoice_PeriodStart_Field) (
+	billing_invoice *BillingInvoice, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT billing_invoices.id, billing_invoices.user_id, billing_invoices.period_start, billing_invoices.period_end, billing_invoices.description, billing_invoices.items, billing_invoices.amount, billing_invoices.status, billing_invoices.created_at, billing_invoices.paid_at FROM billing_invoices WHERE billing_invoices.user_id = ? AND billing_invoices.period_start = ?")
+
+	var __values []interface{}
+	__values = append(__values, billing_invoice_user_id.value(), billing_invoice_period_start.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	billing_invoice = &BillingInvoice{}
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&billing_invoice.Id, &billing_invoice.UserId, &billing_invoice.PeriodStart, &billing_invoice.PeriodEnd, &billing_invoice.Description, &billing_invoice.Items, &billing_invoice.Amount, &billing_invoice.Status, &billing_invoice.CreatedAt, &billing_invoice.PaidAt)
+	if err != nil {
+		return (*BillingInvoice)(nil), obj.makeErr(err)
+	}
+	return billing_invoice, nil
+
+}
+
+func (obj *pgxImpl) All_BillingInvoice_By_UserId_OrderBy_Desc_PeriodStart(ctx context.Context,
+	billing_invoice_user_id BillingInvoice_UserId_Field) (
+	rows []*BillingInvoice, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT billing_invoices.id, billing_invoices.user_id, billing_invoices.period_start, billing_invoices.period_end, billing_invoices.description, billing_invoices.items, billing_invoices.amount, billing_invoices.status, billing_invoices.created_at, billing_invoices.paid_at FROM billing_invoices WHERE billing_invoices.user_id = ? ORDER BY billing_invoices.period_start DESC")
+
+	var __values []interface{}
+	__values = append(__values, billing_invoice_user_id.value())
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
 	for {
-		rows, err = func() (rows []*BillingTransaction, err error) {
+		rows, err = func() (rows []*BillingInvoice, err error) {
 			__rows, err := obj.driver.QueryContext(ctx, __stmt, __values...)
 			if err != nil {
 				return nil, err
@@ -15276,12 +17611,12 @@ func (obj *pgxImpl) All_BillingTransaction_By_UserId_OrderBy_Desc_Timestamp(ctx
 			defer __rows.Close()
 
 			for __rows.Next() {
-				billing_transaction := &BillingTransaction{}
-				err = __rows.Scan(&billing_transaction.TxId, &billing_transaction.UserId, &billing_transaction.Amount, &billing_transaction.Currency, &billing_transaction.Description, &billing_transaction.Type, &billing_transaction.Timestamp, &billing_transaction.CreatedAt)
+				billing_invoice := &BillingInvoice{}
+				err = __rows.Scan(&billing_invoice.Id, &billing_invoice.UserId, &billing_invoice.PeriodStart, &billing_invoice.PeriodEnd, &billing_invoice.Description, &billing_invoice.Items, &billing_invoice.Amount, &billing_invoice.Status, &billing_invoice.CreatedAt, &billing_invoice.PaidAt)
 				if err != nil {
 					return nil, err
 				}
-				rows = append(rows, billing_transaction)
+				rows = append(rows, billing_invoice)
 			}
 			if err := __rows.Err(); err != nil {
 				return nil, err
@@ -15299,22 +17634,21 @@ func (obj *pgxImpl) All_BillingTransaction_By_UserId_OrderBy_Desc_Timestamp(ctx
 
 }
 
-func (obj *pgxImpl) All_BillingTransaction_By_UserId_And_Type_OrderBy_Desc_Timestamp(ctx context.Context,
-	billing_transaction_user_id BillingTransaction_UserId_Field,
-	billing_transaction_type BillingTransaction_Type_Field) (
-	rows []*BillingTransaction, err error) {
+func (obj *pgxImpl) All_BillingInvoice_By_Status_OrderBy_Asc_PeriodStart(ctx context.Context,
+	billing_invoice_status BillingInvoice_Status_Field) (
+	rows []*BillingInvoice, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT billing_transactions.tx_id, billing_transactions.user_id, billing_transactions.amount, billing_transactions.currency, billing_transactions.description, billing_transactions.type, billing_transactions.timestamp, billing_transactions.created_at FROM billing_transactions WHERE billing_transactions.user_id = ? AND billing_transactions.type = ? ORDER BY billing_transactions.timestamp DESC")
+	var __embed_stmt = __sqlbundle_Literal("SELECT billing_invoices.id, billing_invoices.user_id, billing_invoices.period_start, billing_invoices.period_end, billing_invoices.description, billing_invoices.items, billing_invoices.amount, billing_invoices.status, billing_invoices.created_at, billing_invoices.paid_at FROM billing_invoices WHERE billing_invoices.status = ? ORDER BY billing_invoices.period_start")
 
 	var __values []interface{}
-	__values = append(__values, billing_transaction_user_id.value(), billing_transaction_type.value())
+	__values = append(__values, billing_invoice_status.value())
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
 	for {
-		rows, err = func() (rows []*BillingTransaction, err error) {
+		rows, err = func() (rows []*BillingInvoice, err error) {
 			__rows, err := obj.driver.QueryContext(ctx, __stmt, __values...)
 			if err != nil {
 				return nil, err
@@ -15322,12 +17656,12 @@ func (obj *pgxImpl) All_BillingTransaction_By_UserId_And_Type_OrderBy_Desc_Times
 			defer __rows.Close()
 
 			for __rows.Next() {
-				billing_transaction := &BillingTransaction{}
-				err = __rows.Scan(&billing_transaction.TxId, &billing_transaction.UserId, &billing_transaction.Amount, &billing_transaction.Currency, &billing_transaction.Description, &billing_transaction.Type, &billing_transaction.Timestamp, &billing_transaction.CreatedAt)
+				billing_invoice := &BillingInvoice{}
+				err = __rows.Scan(&billing_invoice.Id, &billing_invoice.UserId, &billing_invoice.PeriodStart, &billing_invoice.PeriodEnd, &billing_invoice.Description, &billing_invoice.Items, &billing_invoice.Amount, &billing_invoice.Status, &billing_invoice.CreatedAt, &billing_invoice.PaidAt)
 				if err != nil {
 					return nil, err
 				}
-				rows = append(rows, billing_transaction)
+				rows = append(rows, billing_invoice)
 			}
 			if err := __rows.Err(); err != nil {
 				return nil, err
@@ -16078,7 +18412,7 @@ func (obj *pgxImpl) Get_OauthToken_By_Kind_And_Token(ctx context.Context,
 	oauth_token *OauthToken, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT oauth_tokens.client_id, oauth_tokens.user_id, oauth_tokens.scope, oauth_tokens.kind, oauth_tokens.token, oauth_tokens.created_at, oauth_tokens.expires_at FROM oauth_tokens WHERE oauth_tokens.kind = ? AND oauth_tokens.token = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT oauth_tokens.client_id, oauth_tokens.user_id, oauth_tokens.scope, oauth_tokens.kind, oauth_tokens.token, oauth_tokens.created_at, oauth_tokens.expires_at, oauth_tokens.name, oauth_tokens.last_used_at FROM oauth_tokens WHERE oauth_tokens.kind = ? AND oauth_tokens.token = ?")
 
 	var __values []interface{}
 	__values = append(__values, oauth_token_kind.value(), oauth_token_token.value())
@@ -16087,7 +18421,7 @@ func (obj *pgxImpl) Get_OauthToken_By_Kind_And_Token(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	oauth_token = &OauthToken{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&oauth_token.ClientId, &oauth_token.UserId, &oauth_token.Scope, &oauth_token.Kind, &oauth_token.Token, &oauth_token.CreatedAt, &oauth_token.ExpiresAt)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&oauth_token.ClientId, &oauth_token.UserId, &oauth_token.Scope, &oauth_token.Kind, &oauth_token.Token, &oauth_token.CreatedAt, &oauth_token.ExpiresAt, &oauth_token.Name, &oauth_token.LastUsedAt)
 	if err != nil {
 		return (*OauthToken)(nil), obj.makeErr(err)
 	}
@@ -16095,6 +18429,52 @@ func (obj *pgxImpl) Get_OauthToken_By_Kind_And_Token(ctx context.Context,
 
 }
 
+func (obj *pgxImpl) All_OauthToken_By_UserId_And_Kind_OrderBy_Asc_CreatedAt(ctx context.Context,
+	oauth_token_user_id OauthToken_UserId_Field,
+	oauth_token_kind OauthToken_Kind_Field) (
+	rows []*OauthToken, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT oauth_tokens.client_id, oauth_tokens.user_id, oauth_tokens.scope, oauth_tokens.kind, oauth_tokens.token, oauth_tokens.created_at, oauth_tokens.expires_at, oauth_tokens.name, oauth_tokens.last_used_at FROM oauth_tokens WHERE oauth_tokens.user_id = ? AND oauth_tokens.kind = ? ORDER BY oauth_tokens.created_at")
+
+	var __values []interface{}
+	__values = append(__values, oauth_token_user_id.value(), oauth_token_kind.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	for {
+		rows, err = func() (rows []*OauthToken, err error) {
+			__rows, err := obj.driver.QueryContext(ctx, __stmt, __values...)
+			if err != nil {
+				return nil, err
+			}
+			defer __rows.Close()
+
+			for __rows.Next() {
+				oauth_token := &OauthToken{}
+				err = __rows.Scan(&oauth_token.ClientId, &oauth_token.UserId, &oauth_token.Scope, &oauth_token.Kind, &oauth_token.Token, &oauth_token.CreatedAt, &oauth_token.ExpiresAt, &oauth_token.Name, &oauth_token.LastUsedAt)
+				if err != nil {
+					return nil, err
+				}
+				rows = append(rows, oauth_token)
+			}
+			if err := __rows.Err(); err != nil {
+				return nil, err
+			}
+			return rows, nil
+		}()
+		if err != nil {
+			if obj.shouldRetry(err) {
+				continue
+			}
+			return nil, obj.makeErr(err)
+		}
+		return rows, nil
+	}
+
+}
+
 func (obj *pgxImpl) UpdateNoReturn_AccountingTimestamps_By_Name(ctx context.Context,
 	accounting_timestamps_name AccountingTimestamps_Name_Field,
 	update AccountingTimestamps_Update_Fields) (
@@ -17126,31 +19506,173 @@ func (obj *pgxImpl) Update_User_By_Id(ctx context.Context,
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("signup_promo_code = ?"))
 	}
 
-	if update.LastVerificationReminder._set {
-		__values = append(__values, update.LastVerificationReminder.value())
-		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("last_verification_reminder = ?"))
+	if update.LastVerificationReminder._set {
+		__values = append(__values, update.LastVerificationReminder.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("last_verification_reminder = ?"))
+	}
+
+	if update.VerificationReminders._set {
+		__values = append(__values, update.VerificationReminders.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("verification_reminders = ?"))
+	}
+
+	if update.FailedLoginCount._set {
+		__values = append(__values, update.FailedLoginCount.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("failed_login_count = ?"))
+	}
+
+	if update.LoginLockoutExpiration._set {
+		__values = append(__values, update.LoginLockoutExpiration.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("login_lockout_expiration = ?"))
+	}
+
+	if len(__sets_sql.SQLs) == 0 {
+		return nil, emptyUpdate()
+	}
+
+	__args = append(__args, user_id.value())
+
+	__values = append(__values, __args...)
+	__sets.SQL = __sets_sql
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	user = &User{}
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&user.Id, &user.Email, &user.NormalizedEmail, &user.FullName, &user.ShortName, &user.PasswordHash, &user.Status, &user.PartnerId, &user.UserAgent, &user.CreatedAt, &user.ProjectLimit, &user.ProjectBandwidthLimit, &user.ProjectStorageLimit, &user.ProjectSegmentLimit, &user.PaidTier, &user.Position, &user.CompanyName, &user.CompanySize, &user.WorkingOn, &user.IsProfessional, &user.EmployeeCount, &user.HaveSalesContact, &user.MfaEnabled, &user.MfaSecretKey, &user.MfaRecoveryCodes, &user.SignupPromoCode, &user.LastVerificationReminder, &user.VerificationReminders, &user.FailedLoginCount, &user.LoginLockoutExpiration)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return user, nil
+}
+
+func (obj *pgxImpl) Update_WebappSession_By_Id(ctx context.Context,
+	webapp_session_id WebappSession_Id_Field,
+	update WebappSession_Update_Fields) (
+	webapp_session *WebappSession, err error) {
+	defer mon.Task()(&ctx)(&err)
+	var __sets = &__sqlbundle_Hole{}
+
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE webapp_sessions SET "), __sets, __sqlbundle_Literal(" WHERE webapp_sessions.id = ? RETURNING webapp_sessions.id, webapp_sessions.user_id, webapp_sessions.ip_address, webapp_sessions.user_agent, webapp_sessions.status, webapp_sessions.expires_at, webapp_sessions.created_at, webapp_sessions.last_seen_at")}}
+
+	__sets_sql := __sqlbundle_Literals{Join: ", "}
+	var __values []interface{}
+	var __args []interface{}
+
+	if update.Status._set {
+		__values = append(__values, update.Status.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("status = ?"))
+	}
+
+	if update.ExpiresAt._set {
+		__values = append(__values, update.ExpiresAt.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("expires_at = ?"))
+	}
+
+	if update.LastSeenAt._set {
+		__values = append(__values, update.LastSeenAt.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("last_seen_at = ?"))
+	}
+
+	if len(__sets_sql.SQLs) == 0 {
+		return nil, emptyUpdate()
+	}
+
+	__args = append(__args, webapp_session_id.value())
+
+	__values = append(__values, __args...)
+	__sets.SQL = __sets_sql
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	webapp_session = &WebappSession{}
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&webapp_session.Id, &webapp_session.UserId, &webapp_session.IpAddress, &webapp_session.UserAgent, &webapp_session.Status, &webapp_session.ExpiresAt, &webapp_session.CreatedAt, &webapp_session.LastSeenAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return webapp_session, nil
+}
+
+func (obj *pgxImpl) Update_Project_By_Id(ctx context.Context,
+	project_id Project_Id_Field,
+	update Project_Update_Fields) (
+	project *Project, err error) {
+	defer mon.Task()(&ctx)(&err)
+	var __sets = &__sqlbundle_Hole{}
+
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE projects SET "), __sets, __sqlbundle_Literal(" WHERE projects.id = ? RETURNING projects.id, projects.public_id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.bandwidth_rate_limit, projects.bandwidth_burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.organization_id, projects.created_at")}}
+
+	__sets_sql := __sqlbundle_Literals{Join: ", "}
+	var __values []interface{}
+	var __args []interface{}
+
+	if update.Name._set {
+		__values = append(__values, update.Name.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("name = ?"))
+	}
+
+	if update.Description._set {
+		__values = append(__values, update.Description.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("description = ?"))
+	}
+
+	if update.UsageLimit._set {
+		__values = append(__values, update.UsageLimit.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("usage_limit = ?"))
+	}
+
+	if update.BandwidthLimit._set {
+		__values = append(__values, update.BandwidthLimit.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("bandwidth_limit = ?"))
+	}
+
+	if update.SegmentLimit._set {
+		__values = append(__values, update.SegmentLimit.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("segment_limit = ?"))
+	}
+
+	if update.RateLimit._set {
+		__values = append(__values, update.RateLimit.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("rate_limit = ?"))
+	}
+
+	if update.BurstLimit._set {
+		__values = append(__values, update.BurstLimit.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("burst_limit = ?"))
+	}
+
+	if update.BandwidthRateLimit._set {
+		__values = append(__values, update.BandwidthRateLimit.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("bandwidth_rate_limit = ?"))
 	}
 
-	if update.VerificationReminders._set {
-		__values = append(__values, update.VerificationReminders.value())
-		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("verification_reminders = ?"))
+	if update.BandwidthBurstLimit._set {
+		__values = append(__values, update.BandwidthBurstLimit.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("bandwidth_burst_limit = ?"))
 	}
 
-	if update.FailedLoginCount._set {
-		__values = append(__values, update.FailedLoginCount.value())
-		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("failed_login_count = ?"))
+	if update.MaxBuckets._set {
+		__values = append(__values, update.MaxBuckets.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("max_buckets = ?"))
 	}
 
-	if update.LoginLockoutExpiration._set {
-		__values = append(__values, update.LoginLockoutExpiration.value())
-		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("login_lockout_expiration = ?"))
+	if update.OrganizationId._set {
+		__values = append(__values, update.OrganizationId.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("organization_id = ?"))
 	}
 
 	if len(__sets_sql.SQLs) == 0 {
 		return nil, emptyUpdate()
 	}
 
-	__args = append(__args, user_id.value())
+	__args = append(__args, project_id.value())
 
 	__values = append(__values, __args...)
 	__sets.SQL = __sets_sql
@@ -17158,45 +19680,70 @@ func (obj *pgxImpl) Update_User_By_Id(ctx context.Context,
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
-	user = &User{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&user.Id, &user.Email, &user.NormalizedEmail, &user.FullName, &user.ShortName, &user.PasswordHash, &user.Status, &user.PartnerId, &user.UserAgent, &user.CreatedAt, &user.ProjectLimit, &user.ProjectBandwidthLimit, &user.ProjectStorageLimit, &user.ProjectSegmentLimit, &user.PaidTier, &user.Position, &user.CompanyName, &user.CompanySize, &user.WorkingOn, &user.IsProfessional, &user.EmployeeCount, &user.HaveSalesContact, &user.MfaEnabled, &user.MfaSecretKey, &user.MfaRecoveryCodes, &user.SignupPromoCode, &user.LastVerificationReminder, &user.VerificationReminders, &user.FailedLoginCount, &user.LoginLockoutExpiration)
+	project = &Project{}
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&project.Id, &project.PublicId, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.BandwidthRateLimit, &project.BandwidthBurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.OrganizationId, &project.CreatedAt)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
-	return user, nil
+	return project, nil
 }
 
-func (obj *pgxImpl) Update_WebappSession_By_Id(ctx context.Context,
-	webapp_session_id WebappSession_Id_Field,
-	update WebappSession_Update_Fields) (
-	webapp_session *WebappSession, err error) {
+func (obj *pgxImpl) UpdateNoReturn_ProjectSpendingCap_By_ProjectId(ctx context.Context,
+	project_spending_cap_project_id ProjectSpendingCap_ProjectId_Field,
+	update ProjectSpendingCap_Update_Fields) (
+	err error) {
 	defer mon.Task()(&ctx)(&err)
 	var __sets = &__sqlbundle_Hole{}
 
-	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE webapp_sessions SET "), __sets, __sqlbundle_Literal(" WHERE webapp_sessions.id = ? RETURNING webapp_sessions.id, webapp_sessions.user_id, webapp_sessions.ip_address, webapp_sessions.user_agent, webapp_sessions.status, webapp_sessions.expires_at")}}
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE project_spending_caps SET "), __sets, __sqlbundle_Literal(" WHERE project_spending_caps.project_id = ?")}}
 
 	__sets_sql := __sqlbundle_Literals{Join: ", "}
 	var __values []interface{}
 	var __args []interface{}
 
-	if update.Status._set {
-		__values = append(__values, update.Status.value())
-		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("status = ?"))
+	if update.Cap._set {
+		__values = append(__values, update.Cap.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("cap = ?"))
 	}
 
-	if update.ExpiresAt._set {
-		__values = append(__values, update.ExpiresAt.value())
-		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("expires_at = ?"))
+	if update.AlertThresholds._set {
+		__values = append(__values, update.AlertThresholds.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("alert_thresholds = ?"))
+	}
+
+	if update.Enforce._set {
+		__values = append(__values, update.Enforce.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("enforce = ?"))
+	}
+
+	if update.LimitBandwidth._set {
+		__values = append(__values, update.LimitBandwidth.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("limit_bandwidth = ?"))
+	}
+
+	if update.AlertPeriod._set {
+		__values = append(__values, update.AlertPeriod.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("alert_period = ?"))
+	}
+
+	if update.AlertedThreshold._set {
+		__values = append(__values, update.AlertedThreshold.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("alerted_threshold = ?"))
+	}
+
+	if update.Capped._set {
+		__values = append(__values, update.Capped.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("capped = ?"))
 	}
 
 	if len(__sets_sql.SQLs) == 0 {
-		return nil, emptyUpdate()
+		return emptyUpdate()
 	}
 
-	__args = append(__args, webapp_session_id.value())
+	__args = append(__args, project_spending_cap_project_id.value())
 
 	__values = append(__values, __args...)
 	__sets.SQL = __sets_sql
@@ -17204,75 +19751,98 @@ func (obj *pgxImpl) Update_WebappSession_By_Id(ctx context.Context,
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
-	webapp_session = &WebappSession{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&webapp_session.Id, &webapp_session.UserId, &webapp_session.IpAddress, &webapp_session.UserAgent, &webapp_session.Status, &webapp_session.ExpiresAt)
-	if err == sql.ErrNoRows {
-		return nil, nil
-	}
+	_, err = obj.driver.ExecContext(ctx, __stmt, __values...)
 	if err != nil {
-		return nil, obj.makeErr(err)
+		return obj.makeErr(err)
 	}
-	return webapp_session, nil
+	return nil
 }
 
-func (obj *pgxImpl) Update_Project_By_Id(ctx context.Context,
-	project_id Project_Id_Field,
-	update Project_Update_Fields) (
-	project *Project, err error) {
+func (obj *pgxImpl) UpdateNoReturn_ProjectUsageExport_By_ProjectId(ctx context.Context,
+	project_usage_export_project_id ProjectUsageExport_ProjectId_Field,
+	update ProjectUsageExport_Update_Fields) (
+	err error) {
 	defer mon.Task()(&ctx)(&err)
 	var __sets = &__sqlbundle_Hole{}
 
-	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE projects SET "), __sets, __sqlbundle_Literal(" WHERE projects.id = ? RETURNING projects.id, projects.public_id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at")}}
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE project_usage_exports SET "), __sets, __sqlbundle_Literal(" WHERE project_usage_exports.project_id = ?")}}
 
 	__sets_sql := __sqlbundle_Literals{Join: ", "}
 	var __values []interface{}
 	var __args []interface{}
 
-	if update.Name._set {
-		__values = append(__values, update.Name.value())
-		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("name = ?"))
+	if update.EncryptedAccess._set {
+		__values = append(__values, update.EncryptedAccess.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("encrypted_access = ?"))
 	}
 
-	if update.Description._set {
-		__values = append(__values, update.Description.value())
-		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("description = ?"))
+	if update.Bucket._set {
+		__values = append(__values, update.Bucket.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("bucket = ?"))
 	}
 
-	if update.UsageLimit._set {
-		__values = append(__values, update.UsageLimit.value())
-		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("usage_limit = ?"))
+	if update.Prefix._set {
+		__values = append(__values, update.Prefix.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("prefix = ?"))
 	}
 
-	if update.BandwidthLimit._set {
-		__values = append(__values, update.BandwidthLimit.value())
-		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("bandwidth_limit = ?"))
+	if update.Format._set {
+		__values = append(__values, update.Format.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("format = ?"))
 	}
 
-	if update.SegmentLimit._set {
-		__values = append(__values, update.SegmentLimit.value())
-		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("segment_limit = ?"))
+	if update.Schedule._set {
+		__values = append(__values, update.Schedule.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("schedule = ?"))
 	}
 
-	if update.RateLimit._set {
-		__values = append(__values, update.RateLimit.value())
-		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("rate_limit = ?"))
+	if update.ExportedUntil._set {
+		__values = append(__values, update.ExportedUntil.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("exported_until = ?"))
 	}
 
-	if update.BurstLimit._set {
-		__values = append(__values, update.BurstLimit.value())
-		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("burst_limit = ?"))
+	if len(__sets_sql.SQLs) == 0 {
+		return emptyUpdate()
 	}
 
-	if update.MaxBuckets._set {
-		__values = append(__values, update.MaxBuckets.value())
-		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("max_buckets = ?"))
+	__args = append(__args, project_usage_export_project_id.value())
+
+	__values = append(__values, __args...)
+	__sets.SQL = __sets_sql
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	_, err = obj.driver.ExecContext(ctx, __stmt, __values...)
+	if err != nil {
+		return obj.makeErr(err)
+	}
+	return nil
+}
+
+func (obj *pgxImpl) Update_Organization_By_Id(ctx context.Context,
+	organization_id Organization_Id_Field,
+	update Organization_Update_Fields) (
+	organization *Organization, err error) {
+	defer mon.Task()(&ctx)(&err)
+	var __sets = &__sqlbundle_Hole{}
+
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE organizations SET "), __sets, __sqlbundle_Literal(" WHERE organizations.id = ? RETURNING organizations.id, organizations.name, organizations.created_at")}}
+
+	__sets_sql := __sqlbundle_Literals{Join: ", "}
+	var __values []interface{}
+	var __args []interface{}
+
+	if update.Name._set {
+		__values = append(__values, update.Name.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("name = ?"))
 	}
 
 	if len(__sets_sql.SQLs) == 0 {
 		return nil, emptyUpdate()
 	}
 
-	__args = append(__args, project_id.value())
+	__args = append(__args, organization_id.value())
 
 	__values = append(__values, __args...)
 	__sets.SQL = __sets_sql
@@ -17280,15 +19850,15 @@ func (obj *pgxImpl) Update_Project_By_Id(ctx context.Context,
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
-	project = &Project{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&project.Id, &project.PublicId, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
+	organization = &Organization{}
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&organization.Id, &organization.Name, &organization.CreatedAt)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
-	return project, nil
+	return organization, nil
 }
 
 func (obj *pgxImpl) UpdateNoReturn_ApiKey_By_Id(ctx context.Context,
@@ -17579,6 +20149,48 @@ func (obj *pgxImpl) UpdateNoReturn_GracefulExitSegmentTransfer_By_NodeId_And_Str
 	return nil
 }
 
+func (obj *pgxImpl) UpdateNoReturn_BillingInvoice_By_Id(ctx context.Context,
+	billing_invoice_id BillingInvoice_Id_Field,
+	update BillingInvoice_Update_Fields) (
+	err error) {
+	defer mon.Task()(&ctx)(&err)
+	var __sets = &__sqlbundle_Hole{}
+
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE billing_invoices SET "), __sets, __sqlbundle_Literal(" WHERE billing_invoices.id = ?")}}
+
+	__sets_sql := __sqlbundle_Literals{Join: ", "}
+	var __values []interface{}
+	var __args []interface{}
+
+	if update.Status._set {
+		__values = append(__values, update.Status.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("status = ?"))
+	}
+
+	if update.PaidAt._set {
+		__values = append(__values, update.PaidAt.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("paid_at = ?"))
+	}
+
+	if len(__sets_sql.SQLs) == 0 {
+		return emptyUpdate()
+	}
+
+	__args = append(__args, billing_invoice_id.value())
+
+	__values = append(__values, __args...)
+	__sets.SQL = __sets_sql
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	_, err = obj.driver.ExecContext(ctx, __stmt, __values...)
+	if err != nil {
+		return obj.makeErr(err)
+	}
+	return nil
+}
+
 func (obj *pgxImpl) Update_CoinpaymentsTransaction_By_Id(ctx context.Context,
 	coinpayments_transaction_id CoinpaymentsTransaction_Id_Field,
 	update CoinpaymentsTransaction_Update_Fields) (
@@ -17896,6 +20508,11 @@ func (obj *pgxImpl) UpdateNoReturn_OauthToken_By_Token_And_Kind(ctx context.Cont
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("expires_at = ?"))
 	}
 
+	if update.LastUsedAt._set {
+		__values = append(__values, update.LastUsedAt.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("last_used_at = ?"))
+	}
+
 	if len(__sets_sql.SQLs) == 0 {
 		return emptyUpdate()
 	}
@@ -17915,42 +20532,150 @@ func (obj *pgxImpl) UpdateNoReturn_OauthToken_By_Token_And_Kind(ctx context.Cont
 	return nil
 }
 
-func (obj *pgxImpl) Delete_SegmentPendingAudits_By_NodeId(ctx context.Context,
-	segment_pending_audits_node_id SegmentPendingAudits_NodeId_Field) (
-	deleted bool, err error) {
+func (obj *pgxImpl) Delete_SegmentPendingAudits_By_NodeId(ctx context.Context,
+	segment_pending_audits_node_id SegmentPendingAudits_NodeId_Field) (
+	deleted bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("DELETE FROM segment_pending_audits WHERE segment_pending_audits.node_id = ?")
+
+	var __values []interface{}
+	__values = append(__values, segment_pending_audits_node_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__res, err := obj.driver.ExecContext(ctx, __stmt, __values...)
+	if err != nil {
+		return false, obj.makeErr(err)
+	}
+
+	__count, err := __res.RowsAffected()
+	if err != nil {
+		return false, obj.makeErr(err)
+	}
+
+	return __count > 0, nil
+
+}
+
+func (obj *pgxImpl) Delete_RepairQueue_By_UpdatedAt_Less(ctx context.Context,
+	repair_queue_updated_at_less RepairQueue_UpdatedAt_Field) (
+	count int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("DELETE FROM repair_queue WHERE repair_queue.updated_at < ?")
+
+	var __values []interface{}
+	__values = append(__values, repair_queue_updated_at_less.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__res, err := obj.driver.ExecContext(ctx, __stmt, __values...)
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	return count, nil
+
+}
+
+func (obj *pgxImpl) Delete_User_By_Id(ctx context.Context,
+	user_id User_Id_Field) (
+	deleted bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("DELETE FROM users WHERE users.id = ?")
+
+	var __values []interface{}
+	__values = append(__values, user_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__res, err := obj.driver.ExecContext(ctx, __stmt, __values...)
+	if err != nil {
+		return false, obj.makeErr(err)
+	}
+
+	__count, err := __res.RowsAffected()
+	if err != nil {
+		return false, obj.makeErr(err)
+	}
+
+	return __count > 0, nil
+
+}
+
+func (obj *pgxImpl) Delete_WebappSession_By_Id(ctx context.Context,
+	webapp_session_id WebappSession_Id_Field) (
+	deleted bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("DELETE FROM webapp_sessions WHERE webapp_sessions.id = ?")
+
+	var __values []interface{}
+	__values = append(__values, webapp_session_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__res, err := obj.driver.ExecContext(ctx, __stmt, __values...)
+	if err != nil {
+		return false, obj.makeErr(err)
+	}
+
+	__count, err := __res.RowsAffected()
+	if err != nil {
+		return false, obj.makeErr(err)
+	}
+
+	return __count > 0, nil
+
+}
+
+func (obj *pgxImpl) Delete_SsoIdentity_By_UserId(ctx context.Context,
+	sso_identity_user_id SsoIdentity_UserId_Field) (
+	count int64, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("DELETE FROM segment_pending_audits WHERE segment_pending_audits.node_id = ?")
+	var __embed_stmt = __sqlbundle_Literal("DELETE FROM sso_identities WHERE sso_identities.user_id = ?")
 
 	var __values []interface{}
-	__values = append(__values, segment_pending_audits_node_id.value())
+	__values = append(__values, sso_identity_user_id.value())
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
 	__res, err := obj.driver.ExecContext(ctx, __stmt, __values...)
 	if err != nil {
-		return false, obj.makeErr(err)
+		return 0, obj.makeErr(err)
 	}
 
-	__count, err := __res.RowsAffected()
+	count, err = __res.RowsAffected()
 	if err != nil {
-		return false, obj.makeErr(err)
+		return 0, obj.makeErr(err)
 	}
 
-	return __count > 0, nil
+	return count, nil
 
 }
 
-func (obj *pgxImpl) Delete_RepairQueue_By_UpdatedAt_Less(ctx context.Context,
-	repair_queue_updated_at_less RepairQueue_UpdatedAt_Field) (
+func (obj *pgxImpl) Delete_WebappSession_By_UserId(ctx context.Context,
+	webapp_session_user_id WebappSession_UserId_Field) (
 	count int64, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("DELETE FROM repair_queue WHERE repair_queue.updated_at < ?")
+	var __embed_stmt = __sqlbundle_Literal("DELETE FROM webapp_sessions WHERE webapp_sessions.user_id = ?")
 
 	var __values []interface{}
-	__values = append(__values, repair_queue_updated_at_less.value())
+	__values = append(__values, webapp_session_user_id.value())
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
@@ -17969,15 +20694,15 @@ func (obj *pgxImpl) Delete_RepairQueue_By_UpdatedAt_Less(ctx context.Context,
 
 }
 
-func (obj *pgxImpl) Delete_User_By_Id(ctx context.Context,
-	user_id User_Id_Field) (
+func (obj *pgxImpl) Delete_Project_By_Id(ctx context.Context,
+	project_id Project_Id_Field) (
 	deleted bool, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("DELETE FROM users WHERE users.id = ?")
+	var __embed_stmt = __sqlbundle_Literal("DELETE FROM projects WHERE projects.id = ?")
 
 	var __values []interface{}
-	__values = append(__values, user_id.value())
+	__values = append(__values, project_id.value())
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
@@ -17996,15 +20721,16 @@ func (obj *pgxImpl) Delete_User_By_Id(ctx context.Context,
 
 }
 
-func (obj *pgxImpl) Delete_WebappSession_By_Id(ctx context.Context,
-	webapp_session_id WebappSession_Id_Field) (
+func (obj *pgxImpl) Delete_ProjectMember_By_MemberId_And_ProjectId(ctx context.Context,
+	project_member_member_id ProjectMember_MemberId_Field,
+	project_member_project_id ProjectMember_ProjectId_Field) (
 	deleted bool, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("DELETE FROM webapp_sessions WHERE webapp_sessions.id = ?")
+	var __embed_stmt = __sqlbundle_Literal("DELETE FROM project_members WHERE project_members.member_id = ? AND project_members.project_id = ?")
 
 	var __values []interface{}
-	__values = append(__values, webapp_session_id.value())
+	__values = append(__values, project_member_member_id.value(), project_member_project_id.value())
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
@@ -18023,42 +20749,42 @@ func (obj *pgxImpl) Delete_WebappSession_By_Id(ctx context.Context,
 
 }
 
-func (obj *pgxImpl) Delete_WebappSession_By_UserId(ctx context.Context,
-	webapp_session_user_id WebappSession_UserId_Field) (
-	count int64, err error) {
+func (obj *pgxImpl) Delete_ProjectSpendingCap_By_ProjectId(ctx context.Context,
+	project_spending_cap_project_id ProjectSpendingCap_ProjectId_Field) (
+	deleted bool, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("DELETE FROM webapp_sessions WHERE webapp_sessions.user_id = ?")
+	var __embed_stmt = __sqlbundle_Literal("DELETE FROM project_spending_caps WHERE project_spending_caps.project_id = ?")
 
 	var __values []interface{}
-	__values = append(__values, webapp_session_user_id.value())
+	__values = append(__values, project_spending_cap_project_id.value())
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
 	__res, err := obj.driver.ExecContext(ctx, __stmt, __values...)
 	if err != nil {
-		return 0, obj.makeErr(err)
+		return false, obj.makeErr(err)
 	}
 
-	count, err = __res.RowsAffected()
+	__count, err := __res.RowsAffected()
 	if err != nil {
-		return 0, obj.makeErr(err)
+		return false, obj.makeErr(err)
 	}
 
-	return count, nil
+	return __count > 0, nil
 
 }
 
-func (obj *pgxImpl) Delete_Project_By_Id(ctx context.Context,
-	project_id Project_Id_Field) (
+func (obj *pgxImpl) Delete_ProjectUsageExport_By_ProjectId(ctx context.Context,
+	project_usage_export_project_id ProjectUsageExport_ProjectId_Field) (
 	deleted bool, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("DELETE FROM projects WHERE projects.id = ?")
+	var __embed_stmt = __sqlbundle_Literal("DELETE FROM project_usage_exports WHERE project_usage_exports.project_id = ?")
 
 	var __values []interface{}
-	__values = append(__values, project_id.value())
+	__values = append(__values, project_usage_export_project_id.value())
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
@@ -18077,16 +20803,16 @@ func (obj *pgxImpl) Delete_Project_By_Id(ctx context.Context,
 
 }
 
-func (obj *pgxImpl) Delete_ProjectMember_By_MemberId_And_ProjectId(ctx context.Context,
-	project_member_member_id ProjectMember_MemberId_Field,
-	project_member_project_id ProjectMember_ProjectId_Field) (
+func (obj *pgxImpl) Delete_OrganizationMember_By_OrganizationId_And_MemberId(ctx context.Context,
+	organization_member_organization_id OrganizationMember_OrganizationId_Field,
+	organization_member_member_id OrganizationMember_MemberId_Field) (
 	deleted bool, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("DELETE FROM project_members WHERE project_members.member_id = ? AND project_members.project_id = ?")
+	var __embed_stmt = __sqlbundle_Literal("DELETE FROM organization_members WHERE organization_members.organization_id = ? AND organization_members.member_id = ?")
 
 	var __values []interface{}
-	__values = append(__values, project_member_member_id.value(), project_member_project_id.value())
+	__values = append(__values, organization_member_organization_id.value(), organization_member_member_id.value())
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
@@ -18132,6 +20858,33 @@ func (obj *pgxImpl) Delete_ApiKey_By_Id(ctx context.Context,
 
 }
 
+func (obj *pgxImpl) Delete_AccessGrant_By_Id(ctx context.Context,
+	access_grant_id AccessGrant_Id_Field) (
+	deleted bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("DELETE FROM access_grants WHERE access_grants.id = ?")
+
+	var __values []interface{}
+	__values = append(__values, access_grant_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__res, err := obj.driver.ExecContext(ctx, __stmt, __values...)
+	if err != nil {
+		return false, obj.makeErr(err)
+	}
+
+	__count, err := __res.RowsAffected()
+	if err != nil {
+		return false, obj.makeErr(err)
+	}
+
+	return __count > 0, nil
+
+}
+
 func (obj *pgxImpl) Delete_ResetPasswordToken_By_Secret(ctx context.Context,
 	reset_password_token_secret ResetPasswordToken_Secret_Field) (
 	deleted bool, err error) {
@@ -18393,6 +21146,16 @@ func (obj *pgxImpl) deleteAll(ctx context.Context) (count int64, err error) {
 	defer mon.Task()(&ctx)(&err)
 	var __res sql.Result
 	var __count int64
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM access_grants;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
 	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM user_credits;")
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -18408,6 +21171,26 @@ func (obj *pgxImpl) deleteAll(ctx context.Context) (count int64, err error) {
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM project_usage_exports;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM project_spending_caps;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -18418,6 +21201,16 @@ func (obj *pgxImpl) deleteAll(ctx context.Context) (count int64, err error) {
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM organization_members;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -18568,6 +21361,16 @@ func (obj *pgxImpl) deleteAll(ctx context.Context) (count int64, err error) {
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM sso_identities;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -18678,6 +21481,16 @@ func (obj *pgxImpl) deleteAll(ctx context.Context) (count int64, err error) {
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM organizations;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -18838,6 +21651,16 @@ func (obj *pgxImpl) deleteAll(ctx context.Context) (count int64, err error) {
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM billing_invoices;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -19140,23 +21963,27 @@ func (obj *pgxcockroachImpl) Create_WebappSession(ctx context.Context,
 	webapp_session_expires_at WebappSession_ExpiresAt_Field) (
 	webapp_session *WebappSession, err error) {
 	defer mon.Task()(&ctx)(&err)
+
+	__now := obj.db.Hooks.Now().UTC()
 	__id_val := webapp_session_id.value()
 	__user_id_val := webapp_session_user_id.value()
 	__ip_address_val := webapp_session_ip_address.value()
 	__user_agent_val := webapp_session_user_agent.value()
 	__status_val := int(0)
 	__expires_at_val := webapp_session_expires_at.value()
+	__created_at_val := __now
+	__last_seen_at_val := __now
 
-	var __embed_stmt = __sqlbundle_Literal("INSERT INTO webapp_sessions ( id, user_id, ip_address, user_agent, status, expires_at ) VALUES ( ?, ?, ?, ?, ?, ? ) RETURNING webapp_sessions.id, webapp_sessions.user_id, webapp_sessions.ip_address, webapp_sessions.user_agent, webapp_sessions.status, webapp_sessions.expires_at")
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO webapp_sessions ( id, user_id, ip_address, user_agent, status, expires_at, created_at, last_seen_at ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ? ) RETURNING webapp_sessions.id, webapp_sessions.user_id, webapp_sessions.ip_address, webapp_sessions.user_agent, webapp_sessions.status, webapp_sessions.expires_at, webapp_sessions.created_at, webapp_sessions.last_seen_at")
 
 	var __values []interface{}
-	__values = append(__values, __id_val, __user_id_val, __ip_address_val, __user_agent_val, __status_val, __expires_at_val)
+	__values = append(__values, __id_val, __user_id_val, __ip_address_val, __user_agent_val, __status_val, __expires_at_val, __created_at_val, __last_seen_at_val)
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
 	webapp_session = &WebappSession{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&webapp_session.Id, &webapp_session.UserId, &webapp_session.IpAddress, &webapp_session.UserAgent, &webapp_session.Status, &webapp_session.ExpiresAt)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&webapp_session.Id, &webapp_session.UserId, &webapp_session.IpAddress, &webapp_session.UserAgent, &webapp_session.Status, &webapp_session.ExpiresAt, &webapp_session.CreatedAt, &webapp_session.LastSeenAt)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -19182,75 +22009,209 @@ func (obj *pgxcockroachImpl) Create_Project(ctx context.Context,
 	__bandwidth_limit_val := optional.BandwidthLimit.value()
 	__rate_limit_val := optional.RateLimit.value()
 	__burst_limit_val := optional.BurstLimit.value()
+	__bandwidth_rate_limit_val := optional.BandwidthRateLimit.value()
+	__bandwidth_burst_limit_val := optional.BandwidthBurstLimit.value()
 	__max_buckets_val := optional.MaxBuckets.value()
 	__partner_id_val := optional.PartnerId.value()
 	__user_agent_val := optional.UserAgent.value()
 	__owner_id_val := project_owner_id.value()
+	__organization_id_val := optional.OrganizationId.value()
 	__created_at_val := __now
 
-	var __columns = &__sqlbundle_Hole{SQL: __sqlbundle_Literal("id, public_id, name, description, usage_limit, bandwidth_limit, rate_limit, burst_limit, max_buckets, partner_id, user_agent, owner_id, created_at")}
-	var __placeholders = &__sqlbundle_Hole{SQL: __sqlbundle_Literal("?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?")}
+	var __columns = &__sqlbundle_Hole{SQL: __sqlbundle_Literal("id, public_id, name, description, usage_limit, bandwidth_limit, rate_limit, burst_limit, bandwidth_rate_limit, bandwidth_burst_limit, max_buckets, partner_id, user_agent, owner_id, organization_id, created_at")}
+	var __placeholders = &__sqlbundle_Hole{SQL: __sqlbundle_Literal("?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?")}
 	var __clause = &__sqlbundle_Hole{SQL: __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("("), __columns, __sqlbundle_Literal(") VALUES ("), __placeholders, __sqlbundle_Literal(")")}}}
 
-	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("INSERT INTO projects "), __clause, __sqlbundle_Literal(" RETURNING projects.id, projects.public_id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at")}}
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("INSERT INTO projects "), __clause, __sqlbundle_Literal(" RETURNING projects.id, projects.public_id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.bandwidth_rate_limit, projects.bandwidth_burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.organization_id, projects.created_at")}}
+
+	var __values []interface{}
+	__values = append(__values, __id_val, __public_id_val, __name_val, __description_val, __usage_limit_val, __bandwidth_limit_val, __rate_limit_val, __burst_limit_val, __bandwidth_rate_limit_val, __bandwidth_burst_limit_val, __max_buckets_val, __partner_id_val, __user_agent_val, __owner_id_val, __organization_id_val, __created_at_val)
+
+	__optional_columns := __sqlbundle_Literals{Join: ", "}
+	__optional_placeholders := __sqlbundle_Literals{Join: ", "}
+
+	if optional.SegmentLimit._set {
+		__values = append(__values, optional.SegmentLimit.value())
+		__optional_columns.SQLs = append(__optional_columns.SQLs, __sqlbundle_Literal("segment_limit"))
+		__optional_placeholders.SQLs = append(__optional_placeholders.SQLs, __sqlbundle_Literal("?"))
+	}
+
+	if len(__optional_columns.SQLs) == 0 {
+		if __columns.SQL == nil {
+			__clause.SQL = __sqlbundle_Literal("DEFAULT VALUES")
+		}
+	} else {
+		__columns.SQL = __sqlbundle_Literals{Join: ", ", SQLs: []__sqlbundle_SQL{__columns.SQL, __optional_columns}}
+		__placeholders.SQL = __sqlbundle_Literals{Join: ", ", SQLs: []__sqlbundle_SQL{__placeholders.SQL, __optional_placeholders}}
+	}
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	project = &Project{}
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&project.Id, &project.PublicId, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.BandwidthRateLimit, &project.BandwidthBurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.OrganizationId, &project.CreatedAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return project, nil
+
+}
+
+func (obj *pgxcockroachImpl) Create_ProjectMember(ctx context.Context,
+	project_member_member_id ProjectMember_MemberId_Field,
+	project_member_project_id ProjectMember_ProjectId_Field) (
+	project_member *ProjectMember, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	__now := obj.db.Hooks.Now().UTC()
+	__member_id_val := project_member_member_id.value()
+	__project_id_val := project_member_project_id.value()
+	__created_at_val := __now
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO project_members ( member_id, project_id, created_at ) VALUES ( ?, ?, ? ) RETURNING project_members.member_id, project_members.project_id, project_members.created_at")
+
+	var __values []interface{}
+	__values = append(__values, __member_id_val, __project_id_val, __created_at_val)
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	project_member = &ProjectMember{}
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&project_member.MemberId, &project_member.ProjectId, &project_member.CreatedAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return project_member, nil
+
+}
+
+func (obj *pgxcockroachImpl) CreateNoReturn_ProjectSpendingCap(ctx context.Context,
+	project_spending_cap_project_id ProjectSpendingCap_ProjectId_Field,
+	project_spending_cap_cap ProjectSpendingCap_Cap_Field,
+	project_spending_cap_alert_thresholds ProjectSpendingCap_AlertThresholds_Field,
+	project_spending_cap_enforce ProjectSpendingCap_Enforce_Field,
+	project_spending_cap_limit_bandwidth ProjectSpendingCap_LimitBandwidth_Field,
+	project_spending_cap_alert_period ProjectSpendingCap_AlertPeriod_Field,
+	project_spending_cap_alerted_threshold ProjectSpendingCap_AlertedThreshold_Field,
+	project_spending_cap_capped ProjectSpendingCap_Capped_Field) (
+	err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	__now := obj.db.Hooks.Now().UTC()
+	__project_id_val := project_spending_cap_project_id.value()
+	__cap_val := project_spending_cap_cap.value()
+	__alert_thresholds_val := project_spending_cap_alert_thresholds.value()
+	__enforce_val := project_spending_cap_enforce.value()
+	__limit_bandwidth_val := project_spending_cap_limit_bandwidth.value()
+	__alert_period_val := project_spending_cap_alert_period.value()
+	__alerted_threshold_val := project_spending_cap_alerted_threshold.value()
+	__capped_val := project_spending_cap_capped.value()
+	__created_at_val := __now
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO project_spending_caps ( project_id, cap, alert_thresholds, enforce, limit_bandwidth, alert_period, alerted_threshold, capped, created_at ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ? )")
+
+	var __values []interface{}
+	__values = append(__values, __project_id_val, __cap_val, __alert_thresholds_val, __enforce_val, __limit_bandwidth_val, __alert_period_val, __alerted_threshold_val, __capped_val, __created_at_val)
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	_, err = obj.driver.ExecContext(ctx, __stmt, __values...)
+	if err != nil {
+		return obj.makeErr(err)
+	}
+	return nil
+
+}
+
+func (obj *pgxcockroachImpl) CreateNoReturn_ProjectUsageExport(ctx context.Context,
+	project_usage_export_project_id ProjectUsageExport_ProjectId_Field,
+	project_usage_export_encrypted_access ProjectUsageExport_EncryptedAccess_Field,
+	project_usage_export_bucket ProjectUsageExport_Bucket_Field,
+	project_usage_export_prefix ProjectUsageExport_Prefix_Field,
+	project_usage_export_format ProjectUsageExport_Format_Field,
+	project_usage_export_schedule ProjectUsageExport_Schedule_Field,
+	project_usage_export_exported_until ProjectUsageExport_ExportedUntil_Field) (
+	err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	__now := obj.db.Hooks.Now().UTC()
+	__project_id_val := project_usage_export_project_id.value()
+	__encrypted_access_val := project_usage_export_encrypted_access.value()
+	__bucket_val := project_usage_export_bucket.value()
+	__prefix_val := project_usage_export_prefix.value()
+	__format_val := project_usage_export_format.value()
+	__schedule_val := project_usage_export_schedule.value()
+	__exported_until_val := project_usage_export_exported_until.value()
+	__created_at_val := __now
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO project_usage_exports ( project_id, encrypted_access, bucket, prefix, format, schedule, exported_until, created_at ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ? )")
+
+	var __values []interface{}
+	__values = append(__values, __project_id_val, __encrypted_access_val, __bucket_val, __prefix_val, __format_val, __schedule_val, __exported_until_val, __created_at_val)
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	_, err = obj.driver.ExecContext(ctx, __stmt, __values...)
+	if err != nil {
+		return obj.makeErr(err)
+	}
+	return nil
+
+}
+
+func (obj *pgxcockroachImpl) Create_Organization(ctx context.Context,
+	organization_id Organization_Id_Field,
+	organization_name Organization_Name_Field) (
+	organization *Organization, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	__now := obj.db.Hooks.Now().UTC()
+	__id_val := organization_id.value()
+	__name_val := organization_name.value()
+	__created_at_val := __now
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO organizations ( id, name, created_at ) VALUES ( ?, ?, ? ) RETURNING organizations.id, organizations.name, organizations.created_at")
 
 	var __values []interface{}
-	__values = append(__values, __id_val, __public_id_val, __name_val, __description_val, __usage_limit_val, __bandwidth_limit_val, __rate_limit_val, __burst_limit_val, __max_buckets_val, __partner_id_val, __user_agent_val, __owner_id_val, __created_at_val)
-
-	__optional_columns := __sqlbundle_Literals{Join: ", "}
-	__optional_placeholders := __sqlbundle_Literals{Join: ", "}
-
-	if optional.SegmentLimit._set {
-		__values = append(__values, optional.SegmentLimit.value())
-		__optional_columns.SQLs = append(__optional_columns.SQLs, __sqlbundle_Literal("segment_limit"))
-		__optional_placeholders.SQLs = append(__optional_placeholders.SQLs, __sqlbundle_Literal("?"))
-	}
+	__values = append(__values, __id_val, __name_val, __created_at_val)
 
-	if len(__optional_columns.SQLs) == 0 {
-		if __columns.SQL == nil {
-			__clause.SQL = __sqlbundle_Literal("DEFAULT VALUES")
-		}
-	} else {
-		__columns.SQL = __sqlbundle_Literals{Join: ", ", SQLs: []__sqlbundle_SQL{__columns.SQL, __optional_columns}}
-		__placeholders.SQL = __sqlbundle_Literals{Join: ", ", SQLs: []__sqlbundle_SQL{__placeholders.SQL, __optional_placeholders}}
-	}
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
-	project = &Project{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&project.Id, &project.PublicId, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
+	organization = &Organization{}
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&organization.Id, &organization.Name, &organization.CreatedAt)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
-	return project, nil
+	return organization, nil
 
 }
 
-func (obj *pgxcockroachImpl) Create_ProjectMember(ctx context.Context,
-	project_member_member_id ProjectMember_MemberId_Field,
-	project_member_project_id ProjectMember_ProjectId_Field) (
-	project_member *ProjectMember, err error) {
+func (obj *pgxcockroachImpl) CreateNoReturn_OrganizationMember(ctx context.Context,
+	organization_member_organization_id OrganizationMember_OrganizationId_Field,
+	organization_member_member_id OrganizationMember_MemberId_Field) (
+	err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	__now := obj.db.Hooks.Now().UTC()
-	__member_id_val := project_member_member_id.value()
-	__project_id_val := project_member_project_id.value()
+	__organization_id_val := organization_member_organization_id.value()
+	__member_id_val := organization_member_member_id.value()
 	__created_at_val := __now
 
-	var __embed_stmt = __sqlbundle_Literal("INSERT INTO project_members ( member_id, project_id, created_at ) VALUES ( ?, ?, ? ) RETURNING project_members.member_id, project_members.project_id, project_members.created_at")
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO organization_members ( organization_id, member_id, created_at ) VALUES ( ?, ?, ? )")
 
 	var __values []interface{}
-	__values = append(__values, __member_id_val, __project_id_val, __created_at_val)
+	__values = append(__values, __organization_id_val, __member_id_val, __created_at_val)
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
-	project_member = &ProjectMember{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&project_member.MemberId, &project_member.ProjectId, &project_member.CreatedAt)
+	_, err = obj.driver.ExecContext(ctx, __stmt, __values...)
 	if err != nil {
-		return nil, obj.makeErr(err)
+		return obj.makeErr(err)
 	}
-	return project_member, nil
+	return nil
 
 }
 
@@ -19291,6 +22252,43 @@ func (obj *pgxcockroachImpl) Create_ApiKey(ctx context.Context,
 
 }
 
+func (obj *pgxcockroachImpl) CreateNoReturn_AccessGrant(ctx context.Context,
+	access_grant_id AccessGrant_Id_Field,
+	access_grant_project_id AccessGrant_ProjectId_Field,
+	access_grant_api_key_id AccessGrant_ApiKeyId_Field,
+	access_grant_name AccessGrant_Name_Field,
+	access_grant_caveats AccessGrant_Caveats_Field,
+	access_grant_tails AccessGrant_Tails_Field,
+	access_grant_created_by AccessGrant_CreatedBy_Field) (
+	err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	__now := obj.db.Hooks.Now().UTC()
+	__id_val := access_grant_id.value()
+	__project_id_val := access_grant_project_id.value()
+	__api_key_id_val := access_grant_api_key_id.value()
+	__name_val := access_grant_name.value()
+	__caveats_val := access_grant_caveats.value()
+	__tails_val := access_grant_tails.value()
+	__created_by_val := access_grant_created_by.value()
+	__created_at_val := __now
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO access_grants ( id, project_id, api_key_id, name, caveats, tails, created_by, created_at ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ? )")
+
+	var __values []interface{}
+	__values = append(__values, __id_val, __project_id_val, __api_key_id_val, __name_val, __caveats_val, __tails_val, __created_by_val, __created_at_val)
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	_, err = obj.driver.ExecContext(ctx, __stmt, __values...)
+	if err != nil {
+		return obj.makeErr(err)
+	}
+	return nil
+
+}
+
 func (obj *pgxcockroachImpl) CreateNoReturn_Revocation(ctx context.Context,
 	revocation_revoked Revocation_Revoked_Field,
 	revocation_api_key_id Revocation_ApiKeyId_Field) (
@@ -19671,6 +22669,47 @@ func (obj *pgxcockroachImpl) CreateNoReturn_BillingTransaction(ctx context.Conte
 
 }
 
+func (obj *pgxcockroachImpl) CreateNoReturn_BillingInvoice(ctx context.Context,
+	billing_invoice_id BillingInvoice_Id_Field,
+	billing_invoice_user_id BillingInvoice_UserId_Field,
+	billing_invoice_period_start BillingInvoice_PeriodStart_Field,
+	billing_invoice_period_end BillingInvoice_PeriodEnd_Field,
+	billing_invoice_description BillingInvoice_Description_Field,
+	billing_invoice_items BillingInvoice_Items_Field,
+	billing_invoice_amount BillingInvoice_Amount_Field,
+	billing_invoice_status BillingInvoice_Status_Field,
+	optional BillingInvoice_Create_Fields) (
+	err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	__now := obj.db.Hooks.Now().UTC()
+	__id_val := billing_invoice_id.value()
+	__user_id_val := billing_invoice_user_id.value()
+	__period_start_val := billing_invoice_period_start.value()
+	__period_end_val := billing_invoice_period_end.value()
+	__description_val := billing_invoice_description.value()
+	__items_val := billing_invoice_items.value()
+	__amount_val := billing_invoice_amount.value()
+	__status_val := billing_invoice_status.value()
+	__created_at_val := __now
+	__paid_at_val := optional.PaidAt.value()
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO billing_invoices ( id, user_id, period_start, period_end, description, items, amount, status, created_at, paid_at ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ? )")
+
+	var __values []interface{}
+	__values = append(__values, __id_val, __user_id_val, __period_start_val, __period_end_val, __description_val, __items_val, __amount_val, __status_val, __created_at_val, __paid_at_val)
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	_, err = obj.driver.ExecContext(ctx, __stmt, __values...)
+	if err != nil {
+		return obj.makeErr(err)
+	}
+	return nil
+
+}
+
 func (obj *pgxcockroachImpl) CreateNoReturn_StorjscanWallet(ctx context.Context,
 	storjscan_wallet_user_id StorjscanWallet_UserId_Field,
 	storjscan_wallet_wallet_address StorjscanWallet_WalletAddress_Field) (
@@ -19698,6 +22737,35 @@ func (obj *pgxcockroachImpl) CreateNoReturn_StorjscanWallet(ctx context.Context,
 
 }
 
+func (obj *pgxcockroachImpl) CreateNoReturn_SsoIdentity(ctx context.Context,
+	sso_identity_issuer SsoIdentity_Issuer_Field,
+	sso_identity_subject SsoIdentity_Subject_Field,
+	sso_identity_user_id SsoIdentity_UserId_Field) (
+	err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	__now := obj.db.Hooks.Now().UTC()
+	__issuer_val := sso_identity_issuer.value()
+	__subject_val := sso_identity_subject.value()
+	__user_id_val := sso_identity_user_id.value()
+	__created_at_val := __now
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO sso_identities ( issuer, subject, user_id, created_at ) VALUES ( ?, ?, ?, ? )")
+
+	var __values []interface{}
+	__values = append(__values, __issuer_val, __subject_val, __user_id_val, __created_at_val)
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	_, err = obj.driver.ExecContext(ctx, __stmt, __values...)
+	if err != nil {
+		return obj.makeErr(err)
+	}
+	return nil
+
+}
+
 func (obj *pgxcockroachImpl) Create_CoinpaymentsTransaction(ctx context.Context,
 	coinpayments_transaction_id CoinpaymentsTransaction_Id_Field,
 	coinpayments_transaction_user_id CoinpaymentsTransaction_UserId_Field,
@@ -20063,7 +23131,8 @@ func (obj *pgxcockroachImpl) CreateNoReturn_OauthToken(ctx context.Context,
 	oauth_token_kind OauthToken_Kind_Field,
 	oauth_token_token OauthToken_Token_Field,
 	oauth_token_created_at OauthToken_CreatedAt_Field,
-	oauth_token_expires_at OauthToken_ExpiresAt_Field) (
+	oauth_token_expires_at OauthToken_ExpiresAt_Field,
+	optional OauthToken_Create_Fields) (
 	err error) {
 	defer mon.Task()(&ctx)(&err)
 	__client_id_val := oauth_token_client_id.value()
@@ -20073,11 +23142,13 @@ func (obj *pgxcockroachImpl) CreateNoReturn_OauthToken(ctx context.Context,
 	__token_val := oauth_token_token.value()
 	__created_at_val := oauth_token_created_at.value()
 	__expires_at_val := oauth_token_expires_at.value()
+	__name_val := optional.Name.value()
+	__last_used_at_val := optional.LastUsedAt.value()
 
-	var __embed_stmt = __sqlbundle_Literal("INSERT INTO oauth_tokens ( client_id, user_id, scope, kind, token, created_at, expires_at ) VALUES ( ?, ?, ?, ?, ?, ?, ? )")
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO oauth_tokens ( client_id, user_id, scope, kind, token, created_at, expires_at, name, last_used_at ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ? )")
 
 	var __values []interface{}
-	__values = append(__values, __client_id_val, __user_id_val, __scope_val, __kind_val, __token_val, __created_at_val, __expires_at_val)
+	__values = append(__values, __client_id_val, __user_id_val, __scope_val, __kind_val, __token_val, __created_at_val, __expires_at_val, __name_val, __last_used_at_val)
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
@@ -20545,7 +23616,7 @@ func (obj *pgxcockroachImpl) All_WebappSession_By_UserId(ctx context.Context,
 	rows []*WebappSession, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT webapp_sessions.id, webapp_sessions.user_id, webapp_sessions.ip_address, webapp_sessions.user_agent, webapp_sessions.status, webapp_sessions.expires_at FROM webapp_sessions WHERE webapp_sessions.user_id = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT webapp_sessions.id, webapp_sessions.user_id, webapp_sessions.ip_address, webapp_sessions.user_agent, webapp_sessions.status, webapp_sessions.expires_at, webapp_sessions.created_at, webapp_sessions.last_seen_at FROM webapp_sessions WHERE webapp_sessions.user_id = ?")
 
 	var __values []interface{}
 	__values = append(__values, webapp_session_user_id.value())
@@ -20563,7 +23634,7 @@ func (obj *pgxcockroachImpl) All_WebappSession_By_UserId(ctx context.Context,
 
 			for __rows.Next() {
 				webapp_session := &WebappSession{}
-				err = __rows.Scan(&webapp_session.Id, &webapp_session.UserId, &webapp_session.IpAddress, &webapp_session.UserAgent, &webapp_session.Status, &webapp_session.ExpiresAt)
+				err = __rows.Scan(&webapp_session.Id, &webapp_session.UserId, &webapp_session.IpAddress, &webapp_session.UserAgent, &webapp_session.Status, &webapp_session.ExpiresAt, &webapp_session.CreatedAt, &webapp_session.LastSeenAt)
 				if err != nil {
 					return nil, err
 				}
@@ -20590,7 +23661,7 @@ func (obj *pgxcockroachImpl) Get_WebappSession_By_Id(ctx context.Context,
 	webapp_session *WebappSession, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT webapp_sessions.id, webapp_sessions.user_id, webapp_sessions.ip_address, webapp_sessions.user_agent, webapp_sessions.status, webapp_sessions.expires_at FROM webapp_sessions WHERE webapp_sessions.id = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT webapp_sessions.id, webapp_sessions.user_id, webapp_sessions.ip_address, webapp_sessions.user_agent, webapp_sessions.status, webapp_sessions.expires_at, webapp_sessions.created_at, webapp_sessions.last_seen_at FROM webapp_sessions WHERE webapp_sessions.id = ?")
 
 	var __values []interface{}
 	__values = append(__values, webapp_session_id.value())
@@ -20599,7 +23670,7 @@ func (obj *pgxcockroachImpl) Get_WebappSession_By_Id(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	webapp_session = &WebappSession{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&webapp_session.Id, &webapp_session.UserId, &webapp_session.IpAddress, &webapp_session.UserAgent, &webapp_session.Status, &webapp_session.ExpiresAt)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&webapp_session.Id, &webapp_session.UserId, &webapp_session.IpAddress, &webapp_session.UserAgent, &webapp_session.Status, &webapp_session.ExpiresAt, &webapp_session.CreatedAt, &webapp_session.LastSeenAt)
 	if err != nil {
 		return (*WebappSession)(nil), obj.makeErr(err)
 	}
@@ -20607,6 +23678,29 @@ func (obj *pgxcockroachImpl) Get_WebappSession_By_Id(ctx context.Context,
 
 }
 
+func (obj *pgxcockroachImpl) Get_SsoIdentity_By_Issuer_And_Subject(ctx context.Context,
+	sso_identity_issuer SsoIdentity_Issuer_Field,
+	sso_identity_subject SsoIdentity_Subject_Field) (
+	sso_identity *SsoIdentity, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT sso_identities.issuer, sso_identities.subject, sso_identities.user_id, sso_identities.created_at FROM sso_identities WHERE sso_identities.issuer = ? AND sso_identities.subject = ?")
+
+	var __values []interface{}
+	__values = append(__values, sso_identity_issuer.value(), sso_identity_subject.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	sso_identity = &SsoIdentity{}
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&sso_identity.Issuer, &sso_identity.Subject, &sso_identity.UserId, &sso_identity.CreatedAt)
+	if err != nil {
+		return (*SsoIdentity)(nil), obj.makeErr(err)
+	}
+	return sso_identity, nil
+
+}
+
 func (obj *pgxcockroachImpl) Get_Project_By_PublicId(ctx context.Context,
 	project_public_id Project_PublicId_Field) (
 	project *Project, err error) {
@@ -20614,7 +23708,7 @@ func (obj *pgxcockroachImpl) Get_Project_By_PublicId(ctx context.Context,
 
 	var __cond_0 = &__sqlbundle_Condition{Left: "projects.public_id", Equal: true, Right: "?", Null: true}
 
-	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("SELECT projects.id, projects.public_id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at FROM projects WHERE "), __cond_0, __sqlbundle_Literal(" LIMIT 2")}}
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("SELECT projects.id, projects.public_id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.bandwidth_rate_limit, projects.bandwidth_burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.organization_id, projects.created_at FROM projects WHERE "), __cond_0, __sqlbundle_Literal(" LIMIT 2")}}
 
 	var __values []interface{}
 	if !project_public_id.isnull() {
@@ -20641,7 +23735,7 @@ func (obj *pgxcockroachImpl) Get_Project_By_PublicId(ctx context.Context,
 			}
 
 			project = &Project{}
-			err = __rows.Scan(&project.Id, &project.PublicId, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
+			err = __rows.Scan(&project.Id, &project.PublicId, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.BandwidthRateLimit, &project.BandwidthBurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.OrganizationId, &project.CreatedAt)
 			if err != nil {
 				return nil, err
 			}
@@ -20675,7 +23769,7 @@ func (obj *pgxcockroachImpl) Get_Project_By_Id(ctx context.Context,
 	project *Project, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.public_id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at FROM projects WHERE projects.id = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.public_id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.bandwidth_rate_limit, projects.bandwidth_burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.organization_id, projects.created_at FROM projects WHERE projects.id = ?")
 
 	var __values []interface{}
 	__values = append(__values, project_id.value())
@@ -20684,7 +23778,7 @@ func (obj *pgxcockroachImpl) Get_Project_By_Id(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	project = &Project{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&project.Id, &project.PublicId, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&project.Id, &project.PublicId, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.BandwidthRateLimit, &project.BandwidthBurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.OrganizationId, &project.CreatedAt)
 	if err != nil {
 		return (*Project)(nil), obj.makeErr(err)
 	}
@@ -20754,61 +23848,289 @@ func (obj *pgxcockroachImpl) Get_Project_SegmentLimit_By_Id(ctx context.Context,
 	if err != nil {
 		return (*SegmentLimit_Row)(nil), obj.makeErr(err)
 	}
-	return row, nil
+	return row, nil
+
+}
+
+func (obj *pgxcockroachImpl) Get_Project_MaxBuckets_By_Id(ctx context.Context,
+	project_id Project_Id_Field) (
+	row *MaxBuckets_Row, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT projects.max_buckets FROM projects WHERE projects.id = ?")
+
+	var __values []interface{}
+	__values = append(__values, project_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	row = &MaxBuckets_Row{}
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&row.MaxBuckets)
+	if err != nil {
+		return (*MaxBuckets_Row)(nil), obj.makeErr(err)
+	}
+	return row, nil
+
+}
+
+func (obj *pgxcockroachImpl) Get_Project_BandwidthLimit_Project_UsageLimit_Project_SegmentLimit_Project_BandwidthRateLimit_Project_BandwidthBurstLimit_By_Id(ctx context.Context,
+	project_id Project_Id_Field) (
+	row *BandwidthLimit_UsageLimit_SegmentLimit_BandwidthRateLimit_BandwidthBurstLimit_Row, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT projects.bandwidth_limit, projects.usage_limit, projects.segment_limit, projects.bandwidth_rate_limit, projects.bandwidth_burst_limit FROM projects WHERE projects.id = ?")
+
+	var __values []interface{}
+	__values = append(__values, project_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	row = &BandwidthLimit_UsageLimit_SegmentLimit_BandwidthRateLimit_BandwidthBurstLimit_Row{}
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&row.BandwidthLimit, &row.UsageLimit, &row.SegmentLimit, &row.BandwidthRateLimit, &row.BandwidthBurstLimit)
+	if err != nil {
+		return (*BandwidthLimit_UsageLimit_SegmentLimit_BandwidthRateLimit_BandwidthBurstLimit_Row)(nil), obj.makeErr(err)
+	}
+	return row, nil
+
+}
+
+func (obj *pgxcockroachImpl) All_Project(ctx context.Context) (
+	rows []*Project, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.public_id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.bandwidth_rate_limit, projects.bandwidth_burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.organization_id, projects.created_at FROM projects")
+
+	var __values []interface{}
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	for {
+		rows, err = func() (rows []*Project, err error) {
+			__rows, err := obj.driver.QueryContext(ctx, __stmt, __values...)
+			if err != nil {
+				return nil, err
+			}
+			defer __rows.Close()
+
+			for __rows.Next() {
+				project := &Project{}
+				err = __rows.Scan(&project.Id, &project.PublicId, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.BandwidthRateLimit, &project.BandwidthBurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.OrganizationId, &project.CreatedAt)
+				if err != nil {
+					return nil, err
+				}
+				rows = append(rows, project)
+			}
+			if err := __rows.Err(); err != nil {
+				return nil, err
+			}
+			return rows, nil
+		}()
+		if err != nil {
+			if obj.shouldRetry(err) {
+				continue
+			}
+			return nil, obj.makeErr(err)
+		}
+		return rows, nil
+	}
+
+}
+
+func (obj *pgxcockroachImpl) All_Project_By_CreatedAt_Less_OrderBy_Asc_CreatedAt(ctx context.Context,
+	project_created_at_less Project_CreatedAt_Field) (
+	rows []*Project, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.public_id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.bandwidth_rate_limit, projects.bandwidth_burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.organization_id, projects.created_at FROM projects WHERE projects.created_at < ? ORDER BY projects.created_at")
+
+	var __values []interface{}
+	__values = append(__values, project_created_at_less.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	for {
+		rows, err = func() (rows []*Project, err error) {
+			__rows, err := obj.driver.QueryContext(ctx, __stmt, __values...)
+			if err != nil {
+				return nil, err
+			}
+			defer __rows.Close()
+
+			for __rows.Next() {
+				project := &Project{}
+				err = __rows.Scan(&project.Id, &project.PublicId, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.BandwidthRateLimit, &project.BandwidthBurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.OrganizationId, &project.CreatedAt)
+				if err != nil {
+					return nil, err
+				}
+				rows = append(rows, project)
+			}
+			if err := __rows.Err(); err != nil {
+				return nil, err
+			}
+			return rows, nil
+		}()
+		if err != nil {
+			if obj.shouldRetry(err) {
+				continue
+			}
+			return nil, obj.makeErr(err)
+		}
+		return rows, nil
+	}
+
+}
+
+func (obj *pgxcockroachImpl) All_Project_By_OwnerId_OrderBy_Asc_CreatedAt(ctx context.Context,
+	project_owner_id Project_OwnerId_Field) (
+	rows []*Project, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.public_id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.bandwidth_rate_limit, projects.bandwidth_burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.organization_id, projects.created_at FROM projects WHERE projects.owner_id = ? ORDER BY projects.created_at")
+
+	var __values []interface{}
+	__values = append(__values, project_owner_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	for {
+		rows, err = func() (rows []*Project, err error) {
+			__rows, err := obj.driver.QueryContext(ctx, __stmt, __values...)
+			if err != nil {
+				return nil, err
+			}
+			defer __rows.Close()
+
+			for __rows.Next() {
+				project := &Project{}
+				err = __rows.Scan(&project.Id, &project.PublicId, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.BandwidthRateLimit, &project.BandwidthBurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.OrganizationId, &project.CreatedAt)
+				if err != nil {
+					return nil, err
+				}
+				rows = append(rows, project)
+			}
+			if err := __rows.Err(); err != nil {
+				return nil, err
+			}
+			return rows, nil
+		}()
+		if err != nil {
+			if obj.shouldRetry(err) {
+				continue
+			}
+			return nil, obj.makeErr(err)
+		}
+		return rows, nil
+	}
 
 }
 
-func (obj *pgxcockroachImpl) Get_Project_MaxBuckets_By_Id(ctx context.Context,
-	project_id Project_Id_Field) (
-	row *MaxBuckets_Row, err error) {
+func (obj *pgxcockroachImpl) All_Project_By_ProjectMember_MemberId_OrderBy_Asc_Project_Name(ctx context.Context,
+	project_member_member_id ProjectMember_MemberId_Field) (
+	rows []*Project, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT projects.max_buckets FROM projects WHERE projects.id = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.public_id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.bandwidth_rate_limit, projects.bandwidth_burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.organization_id, projects.created_at FROM projects  JOIN project_members ON projects.id = project_members.project_id WHERE project_members.member_id = ? ORDER BY projects.name")
 
 	var __values []interface{}
-	__values = append(__values, project_id.value())
+	__values = append(__values, project_member_member_id.value())
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
-	row = &MaxBuckets_Row{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&row.MaxBuckets)
-	if err != nil {
-		return (*MaxBuckets_Row)(nil), obj.makeErr(err)
+	for {
+		rows, err = func() (rows []*Project, err error) {
+			__rows, err := obj.driver.QueryContext(ctx, __stmt, __values...)
+			if err != nil {
+				return nil, err
+			}
+			defer __rows.Close()
+
+			for __rows.Next() {
+				project := &Project{}
+				err = __rows.Scan(&project.Id, &project.PublicId, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.BandwidthRateLimit, &project.BandwidthBurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.OrganizationId, &project.CreatedAt)
+				if err != nil {
+					return nil, err
+				}
+				rows = append(rows, project)
+			}
+			if err := __rows.Err(); err != nil {
+				return nil, err
+			}
+			return rows, nil
+		}()
+		if err != nil {
+			if obj.shouldRetry(err) {
+				continue
+			}
+			return nil, obj.makeErr(err)
+		}
+		return rows, nil
 	}
-	return row, nil
 
 }
 
-func (obj *pgxcockroachImpl) Get_Project_BandwidthLimit_Project_UsageLimit_Project_SegmentLimit_By_Id(ctx context.Context,
-	project_id Project_Id_Field) (
-	row *BandwidthLimit_UsageLimit_SegmentLimit_Row, err error) {
+func (obj *pgxcockroachImpl) All_Project_By_OrganizationId_OrderBy_Asc_CreatedAt(ctx context.Context,
+	project_organization_id Project_OrganizationId_Field) (
+	rows []*Project, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT projects.bandwidth_limit, projects.usage_limit, projects.segment_limit FROM projects WHERE projects.id = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.public_id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.bandwidth_rate_limit, projects.bandwidth_burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.organization_id, projects.created_at FROM projects WHERE projects.organization_id = ? ORDER BY projects.created_at")
 
 	var __values []interface{}
-	__values = append(__values, project_id.value())
+	__values = append(__values, project_organization_id.value())
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
-	row = &BandwidthLimit_UsageLimit_SegmentLimit_Row{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&row.BandwidthLimit, &row.UsageLimit, &row.SegmentLimit)
-	if err != nil {
-		return (*BandwidthLimit_UsageLimit_SegmentLimit_Row)(nil), obj.makeErr(err)
+	for {
+		rows, err = func() (rows []*Project, err error) {
+			__rows, err := obj.driver.QueryContext(ctx, __stmt, __values...)
+			if err != nil {
+				return nil, err
+			}
+			defer __rows.Close()
+
+			for __rows.Next() {
+				project := &Project{}
+				err = __rows.Scan(&project.Id, &project.PublicId, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.BandwidthRateLimit, &project.BandwidthBurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.OrganizationId, &project.CreatedAt)
+				if err != nil {
+					return nil, err
+				}
+				rows = append(rows, project)
+			}
+			if err := __rows.Err(); err != nil {
+				return nil, err
+			}
+			return rows, nil
+		}()
+		if err != nil {
+			if obj.shouldRetry(err) {
+				continue
+			}
+			return nil, obj.makeErr(err)
+		}
+		return rows, nil
 	}
-	return row, nil
 
 }
 
-func (obj *pgxcockroachImpl) All_Project(ctx context.Context) (
+func (obj *pgxcockroachImpl) Limited_Project_By_CreatedAt_Less_OrderBy_Asc_CreatedAt(ctx context.Context,
+	project_created_at_less Project_CreatedAt_Field,
+	limit int, offset int64) (
 	rows []*Project, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.public_id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at FROM projects")
+	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.public_id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.bandwidth_rate_limit, projects.bandwidth_burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.organization_id, projects.created_at FROM projects WHERE projects.created_at < ? ORDER BY projects.created_at LIMIT ? OFFSET ?")
 
 	var __values []interface{}
+	__values = append(__values, project_created_at_less.value())
+
+	__values = append(__values, limit, offset)
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
@@ -20823,13 +24145,14 @@ func (obj *pgxcockroachImpl) All_Project(ctx context.Context) (
 
 			for __rows.Next() {
 				project := &Project{}
-				err = __rows.Scan(&project.Id, &project.PublicId, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
+				err = __rows.Scan(&project.Id, &project.PublicId, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.BandwidthRateLimit, &project.BandwidthBurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.OrganizationId, &project.CreatedAt)
 				if err != nil {
 					return nil, err
 				}
 				rows = append(rows, project)
 			}
-			if err := __rows.Err(); err != nil {
+			err = __rows.Err()
+			if err != nil {
 				return nil, err
 			}
 			return rows, nil
@@ -20845,21 +24168,21 @@ func (obj *pgxcockroachImpl) All_Project(ctx context.Context) (
 
 }
 
-func (obj *pgxcockroachImpl) All_Project_By_CreatedAt_Less_OrderBy_Asc_CreatedAt(ctx context.Context,
-	project_created_at_less Project_CreatedAt_Field) (
-	rows []*Project, err error) {
+func (obj *pgxcockroachImpl) All_ProjectMember_By_MemberId(ctx context.Context,
+	project_member_member_id ProjectMember_MemberId_Field) (
+	rows []*ProjectMember, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.public_id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at FROM projects WHERE projects.created_at < ? ORDER BY projects.created_at")
+	var __embed_stmt = __sqlbundle_Literal("SELECT project_members.member_id, project_members.project_id, project_members.created_at FROM project_members WHERE project_members.member_id = ?")
 
 	var __values []interface{}
-	__values = append(__values, project_created_at_less.value())
+	__values = append(__values, project_member_member_id.value())
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
 	for {
-		rows, err = func() (rows []*Project, err error) {
+		rows, err = func() (rows []*ProjectMember, err error) {
 			__rows, err := obj.driver.QueryContext(ctx, __stmt, __values...)
 			if err != nil {
 				return nil, err
@@ -20867,12 +24190,12 @@ func (obj *pgxcockroachImpl) All_Project_By_CreatedAt_Less_OrderBy_Asc_CreatedAt
 			defer __rows.Close()
 
 			for __rows.Next() {
-				project := &Project{}
-				err = __rows.Scan(&project.Id, &project.PublicId, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
+				project_member := &ProjectMember{}
+				err = __rows.Scan(&project_member.MemberId, &project_member.ProjectId, &project_member.CreatedAt)
 				if err != nil {
 					return nil, err
 				}
-				rows = append(rows, project)
+				rows = append(rows, project_member)
 			}
 			if err := __rows.Err(); err != nil {
 				return nil, err
@@ -20890,21 +24213,41 @@ func (obj *pgxcockroachImpl) All_Project_By_CreatedAt_Less_OrderBy_Asc_CreatedAt
 
 }
 
-func (obj *pgxcockroachImpl) All_Project_By_OwnerId_OrderBy_Asc_CreatedAt(ctx context.Context,
-	project_owner_id Project_OwnerId_Field) (
-	rows []*Project, err error) {
+func (obj *pgxcockroachImpl) Get_ProjectSpendingCap_By_ProjectId(ctx context.Context,
+	project_spending_cap_project_id ProjectSpendingCap_ProjectId_Field) (
+	project_spending_cap *ProjectSpendingCap, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.public_id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at FROM projects WHERE projects.owner_id = ? ORDER BY projects.created_at")
+	var __embed_stmt = __sqlbundle_Literal("SELECT project_spending_caps.project_id, project_spending_caps.cap, project_spending_caps.alert_thresholds, project_spending_caps.enforce, project_spending_caps.limit_bandwidth, project_spending_caps.alert_period, project_spending_caps.alerted_threshold, project_spending_caps.capped, project_spending_caps.created_at FROM project_spending_caps WHERE project_spending_caps.project_id = ?")
+
+	var __values []interface{}
+	__values = append(__values, project_spending_cap_project_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	project_spending_cap = &ProjectSpendingCap{}
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&project_spending_cap.ProjectId, &project_spending_cap.Cap, &project_spending_cap.AlertThresholds, &project_spending_cap.Enforce, &project_spending_cap.LimitBandwidth, &project_spending_cap.AlertPeriod, &project_spending_cap.AlertedThreshold, &project_spending_cap.Capped, &project_spending_cap.CreatedAt)
+	if err != nil {
+		return (*ProjectSpendingCap)(nil), obj.makeErr(err)
+	}
+	return project_spending_cap, nil
+
+}
+
+func (obj *pgxcockroachImpl) All_ProjectSpendingCap(ctx context.Context) (
+	rows []*ProjectSpendingCap, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT project_spending_caps.project_id, project_spending_caps.cap, project_spending_caps.alert_thresholds, project_spending_caps.enforce, project_spending_caps.limit_bandwidth, project_spending_caps.alert_period, project_spending_caps.alerted_threshold, project_spending_caps.capped, project_spending_caps.created_at FROM project_spending_caps")
 
 	var __values []interface{}
-	__values = append(__values, project_owner_id.value())
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
 	for {
-		rows, err = func() (rows []*Project, err error) {
+		rows, err = func() (rows []*ProjectSpendingCap, err error) {
 			__rows, err := obj.driver.QueryContext(ctx, __stmt, __values...)
 			if err != nil {
 				return nil, err
@@ -20912,12 +24255,12 @@ func (obj *pgxcockroachImpl) All_Project_By_OwnerId_OrderBy_Asc_CreatedAt(ctx co
 			defer __rows.Close()
 
 			for __rows.Next() {
-				project := &Project{}
-				err = __rows.Scan(&project.Id, &project.PublicId, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
+				project_spending_cap := &ProjectSpendingCap{}
+				err = __rows.Scan(&project_spending_cap.ProjectId, &project_spending_cap.Cap, &project_spending_cap.AlertThresholds, &project_spending_cap.Enforce, &project_spending_cap.LimitBandwidth, &project_spending_cap.AlertPeriod, &project_spending_cap.AlertedThreshold, &project_spending_cap.Capped, &project_spending_cap.CreatedAt)
 				if err != nil {
 					return nil, err
 				}
-				rows = append(rows, project)
+				rows = append(rows, project_spending_cap)
 			}
 			if err := __rows.Err(); err != nil {
 				return nil, err
@@ -20935,21 +24278,41 @@ func (obj *pgxcockroachImpl) All_Project_By_OwnerId_OrderBy_Asc_CreatedAt(ctx co
 
 }
 
-func (obj *pgxcockroachImpl) All_Project_By_ProjectMember_MemberId_OrderBy_Asc_Project_Name(ctx context.Context,
-	project_member_member_id ProjectMember_MemberId_Field) (
-	rows []*Project, err error) {
+func (obj *pgxcockroachImpl) Get_ProjectUsageExport_By_ProjectId(ctx context.Context,
+	project_usage_export_project_id ProjectUsageExport_ProjectId_Field) (
+	project_usage_export *ProjectUsageExport, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.public_id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at FROM projects  JOIN project_members ON projects.id = project_members.project_id WHERE project_members.member_id = ? ORDER BY projects.name")
+	var __embed_stmt = __sqlbundle_Literal("SELECT project_usage_exports.project_id, project_usage_exports.encrypted_access, project_usage_exports.bucket, project_usage_exports.prefix, project_usage_exports.format, project_usage_exports.schedule, project_usage_exports.exported_until, project_usage_exports.created_at FROM project_usage_exports WHERE project_usage_exports.project_id = ?")
+
+	var __values []interface{}
+	__values = append(__values, project_usage_export_project_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	project_usage_export = &ProjectUsageExport{}
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&project_usage_export.ProjectId, &project_usage_export.EncryptedAccess, &project_usage_export.Bucket, &project_usage_export.Prefix, &project_usage_export.Format, &project_usage_export.Schedule, &project_usage_export.ExportedUntil, &project_usage_export.CreatedAt)
+	if err != nil {
+		return (*ProjectUsageExport)(nil), obj.makeErr(err)
+	}
+	return project_usage_export, nil
+
+}
+
+func (obj *pgxcockroachImpl) All_ProjectUsageExport(ctx context.Context) (
+	rows []*ProjectUsageExport, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT project_usage_exports.project_id, project_usage_exports.encrypted_access, project_usage_exports.bucket, project_usage_exports.prefix, project_usage_exports.format, project_usage_exports.schedule, project_usage_exports.exported_until, project_usage_exports.created_at FROM project_usage_exports")
 
 	var __values []interface{}
-	__values = append(__values, project_member_member_id.value())
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
 	for {
-		rows, err = func() (rows []*Project, err error) {
+		rows, err = func() (rows []*ProjectUsageExport, err error) {
 			__rows, err := obj.driver.QueryContext(ctx, __stmt, __values...)
 			if err != nil {
 				return nil, err
@@ -20957,12 +24320,12 @@ func (obj *pgxcockroachImpl) All_Project_By_ProjectMember_MemberId_OrderBy_Asc_P
 			defer __rows.Close()
 
 			for __rows.Next() {
-				project := &Project{}
-				err = __rows.Scan(&project.Id, &project.PublicId, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
+				project_usage_export := &ProjectUsageExport{}
+				err = __rows.Scan(&project_usage_export.ProjectId, &project_usage_export.EncryptedAccess, &project_usage_export.Bucket, &project_usage_export.Prefix, &project_usage_export.Format, &project_usage_export.Schedule, &project_usage_export.ExportedUntil, &project_usage_export.CreatedAt)
 				if err != nil {
 					return nil, err
 				}
-				rows = append(rows, project)
+				rows = append(rows, project_usage_export)
 			}
 			if err := __rows.Err(); err != nil {
 				return nil, err
@@ -20980,24 +24343,43 @@ func (obj *pgxcockroachImpl) All_Project_By_ProjectMember_MemberId_OrderBy_Asc_P
 
 }
 
-func (obj *pgxcockroachImpl) Limited_Project_By_CreatedAt_Less_OrderBy_Asc_CreatedAt(ctx context.Context,
-	project_created_at_less Project_CreatedAt_Field,
-	limit int, offset int64) (
-	rows []*Project, err error) {
+func (obj *pgxcockroachImpl) Get_Organization_By_Id(ctx context.Context,
+	organization_id Organization_Id_Field) (
+	organization *Organization, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT projects.id, projects.public_id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at FROM projects WHERE projects.created_at < ? ORDER BY projects.created_at LIMIT ? OFFSET ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT organizations.id, organizations.name, organizations.created_at FROM organizations WHERE organizations.id = ?")
 
 	var __values []interface{}
-	__values = append(__values, project_created_at_less.value())
+	__values = append(__values, organization_id.value())
 
-	__values = append(__values, limit, offset)
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	organization = &Organization{}
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&organization.Id, &organization.Name, &organization.CreatedAt)
+	if err != nil {
+		return (*Organization)(nil), obj.makeErr(err)
+	}
+	return organization, nil
+
+}
+
+func (obj *pgxcockroachImpl) All_Organization_By_OrganizationMember_MemberId_OrderBy_Asc_Organization_Name(ctx context.Context,
+	organization_member_member_id OrganizationMember_MemberId_Field) (
+	rows []*Organization, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT organizations.id, organizations.name, organizations.created_at FROM organizations  JOIN organization_members ON organizations.id = organization_members.organization_id WHERE organization_members.member_id = ? ORDER BY organizations.name")
+
+	var __values []interface{}
+	__values = append(__values, organization_member_member_id.value())
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
 	for {
-		rows, err = func() (rows []*Project, err error) {
+		rows, err = func() (rows []*Organization, err error) {
 			__rows, err := obj.driver.QueryContext(ctx, __stmt, __values...)
 			if err != nil {
 				return nil, err
@@ -21005,15 +24387,14 @@ func (obj *pgxcockroachImpl) Limited_Project_By_CreatedAt_Less_OrderBy_Asc_Creat
 			defer __rows.Close()
 
 			for __rows.Next() {
-				project := &Project{}
-				err = __rows.Scan(&project.Id, &project.PublicId, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
+				organization := &Organization{}
+				err = __rows.Scan(&organization.Id, &organization.Name, &organization.CreatedAt)
 				if err != nil {
 					return nil, err
 				}
-				rows = append(rows, project)
+				rows = append(rows, organization)
 			}
-			err = __rows.Err()
-			if err != nil {
+			if err := __rows.Err(); err != nil {
 				return nil, err
 			}
 			return rows, nil
@@ -21029,21 +24410,21 @@ func (obj *pgxcockroachImpl) Limited_Project_By_CreatedAt_Less_OrderBy_Asc_Creat
 
 }
 
-func (obj *pgxcockroachImpl) All_ProjectMember_By_MemberId(ctx context.Context,
-	project_member_member_id ProjectMember_MemberId_Field) (
-	rows []*ProjectMember, err error) {
+func (obj *pgxcockroachImpl) All_OrganizationMember_By_OrganizationId_OrderBy_Asc_CreatedAt(ctx context.Context,
+	organization_member_organization_id OrganizationMember_OrganizationId_Field) (
+	rows []*OrganizationMember, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT project_members.member_id, project_members.project_id, project_members.created_at FROM project_members WHERE project_members.member_id = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT organization_members.organization_id, organization_members.member_id, organization_members.created_at FROM organization_members WHERE organization_members.organization_id = ? ORDER BY organization_members.created_at")
 
 	var __values []interface{}
-	__values = append(__values, project_member_member_id.value())
+	__values = append(__values, organization_member_organization_id.value())
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
 	for {
-		rows, err = func() (rows []*ProjectMember, err error) {
+		rows, err = func() (rows []*OrganizationMember, err error) {
 			__rows, err := obj.driver.QueryContext(ctx, __stmt, __values...)
 			if err != nil {
 				return nil, err
@@ -21051,12 +24432,12 @@ func (obj *pgxcockroachImpl) All_ProjectMember_By_MemberId(ctx context.Context,
 			defer __rows.Close()
 
 			for __rows.Next() {
-				project_member := &ProjectMember{}
-				err = __rows.Scan(&project_member.MemberId, &project_member.ProjectId, &project_member.CreatedAt)
+				organization_member := &OrganizationMember{}
+				err = __rows.Scan(&organization_member.OrganizationId, &organization_member.MemberId, &organization_member.CreatedAt)
 				if err != nil {
 					return nil, err
 				}
-				rows = append(rows, project_member)
+				rows = append(rows, organization_member)
 			}
 			if err := __rows.Err(); err != nil {
 				return nil, err
@@ -21074,6 +24455,28 @@ func (obj *pgxcockroachImpl) All_ProjectMember_By_MemberId(ctx context.Context,
 
 }
 
+func (obj *pgxcockroachImpl) Has_OrganizationMember_By_OrganizationId_And_MemberId(ctx context.Context,
+	organization_member_organization_id OrganizationMember_OrganizationId_Field,
+	organization_member_member_id OrganizationMember_MemberId_Field) (
+	has bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT EXISTS( SELECT 1 FROM organization_members WHERE organization_members.organization_id = ? AND organization_members.member_id = ? )")
+
+	var __values []interface{}
+	__values = append(__values, organization_member_organization_id.value(), organization_member_member_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&has)
+	if err != nil {
+		return false, obj.makeErr(err)
+	}
+	return has, nil
+
+}
+
 func (obj *pgxcockroachImpl) Get_ApiKey_By_Id(ctx context.Context,
 	api_key_id ApiKey_Id_Field) (
 	api_key *ApiKey, err error) {
@@ -21137,7 +24540,74 @@ func (obj *pgxcockroachImpl) Get_ApiKey_By_Name_And_ProjectId(ctx context.Contex
 	if err != nil {
 		return (*ApiKey)(nil), obj.makeErr(err)
 	}
-	return api_key, nil
+	return api_key, nil
+
+}
+
+func (obj *pgxcockroachImpl) Get_AccessGrant_By_Id(ctx context.Context,
+	access_grant_id AccessGrant_Id_Field) (
+	access_grant *AccessGrant, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT access_grants.id, access_grants.project_id, access_grants.api_key_id, access_grants.name, access_grants.caveats, access_grants.tails, access_grants.created_by, access_grants.created_at FROM access_grants WHERE access_grants.id = ?")
+
+	var __values []interface{}
+	__values = append(__values, access_grant_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	access_grant = &AccessGrant{}
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&access_grant.Id, &access_grant.ProjectId, &access_grant.ApiKeyId, &access_grant.Name, &access_grant.Caveats, &access_grant.Tails, &access_grant.CreatedBy, &access_grant.CreatedAt)
+	if err != nil {
+		return (*AccessGrant)(nil), obj.makeErr(err)
+	}
+	return access_grant, nil
+
+}
+
+func (obj *pgxcockroachImpl) All_AccessGrant_By_ProjectId_OrderBy_Asc_CreatedAt(ctx context.Context,
+	access_grant_project_id AccessGrant_ProjectId_Field) (
+	rows []*AccessGrant, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT access_grants.id, access_grants.project_id, access_grants.api_key_id, access_grants.name, access_grants.caveats, access_grants.tails, access_grants.created_by, access_grants.created_at FROM access_grants WHERE access_grants.project_id = ? ORDER BY access_grants.created_at")
+
+	var __values []interface{}
+	__values = append(__values, access_grant_project_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	for {
+		rows, err = func() (rows []*AccessGrant, err error) {
+			__rows, err := obj.driver.QueryContext(ctx, __stmt, __values...)
+			if err != nil {
+				return nil, err
+			}
+			defer __rows.Close()
+
+			for __rows.Next() {
+				access_grant := &AccessGrant{}
+				err = __rows.Scan(&access_grant.Id, &access_grant.ProjectId, &access_grant.ApiKeyId, &access_grant.Name, &access_grant.Caveats, &access_grant.Tails, &access_grant.CreatedBy, &access_grant.CreatedAt)
+				if err != nil {
+					return nil, err
+				}
+				rows = append(rows, access_grant)
+			}
+			if err := __rows.Err(); err != nil {
+				return nil, err
+			}
+			return rows, nil
+		}()
+		if err != nil {
+			if obj.shouldRetry(err) {
+				continue
+			}
+			return nil, obj.makeErr(err)
+		}
+		return rows, nil
+	}
 
 }
 
@@ -22525,6 +25995,141 @@ func (obj *pgxcockroachImpl) All_BillingTransaction_By_UserId_And_Type_OrderBy_D
 
 }
 
+func (obj *pgxcockroachImpl) Get_BillingInvoice_By_Id(ctx context.Context,
+	billing_invoice_id BillingInvoice_Id_Field) (
+	billing_invoice *BillingInvoice, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT billing_invoices.id, billing_invoices.user_id, billing_invoices.period_start, billing_invoices.period_end, billing_invoices.description, billing_invoices.items, billing_invoices.amount, billing_invoices.status, billing_invoices.created_at, billing_invoices.paid_at FROM billing_invoices WHERE billing_invoices.id = ?")
+
+	var __values []interface{}
+	__values = append(__values, billing_invoice_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	billing_invoice = &BillingInvoice{}
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&billing_invoice.Id, &billing_invoice.UserId, &billing_invoice.PeriodStart, &billing_invoice.PeriodEnd, &billing_invoice.Description, &billing_invoice.Items, &billing_invoice.Amount, &billing_invoice.Status, &billing_invoice.CreatedAt, &billing_invoice.PaidAt)
+	if err != nil {
+		return (*BillingInvoice)(nil), obj.makeErr(err)
+	}
+	return billing_invoice, nil
+
+}
+
+func (obj *pgxcockroachImpl) Get_BillingInvoice_By_UserId_And_PeriodStart(ctx context.Context,
+	billing_invoice_user_id BillingInvoice_UserId_Field,
+	billing_invoice_period_start BillingInvoice_PeriodStart_Field) (
+	billing_invoice *BillingInvoice, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT billing_invoices.id, billing_invoices.user_id, billing_invoices.period_start, billing_invoices.period_end, billing_invoices.description, billing_invoices.items, billing_invoices.amount, billing_invoices.status, billing_invoices.created_at, billing_invoices.paid_at FROM billing_invoices WHERE billing_invoices.user_id = ? AND billing_invoices.period_start = ?")
+
+	var __values []interface{}
+	__values = append(__values, billing_invoice_user_id.value(), billing_invoice_period_start.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	billing_invoice = &BillingInvoice{}
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&billing_invoice.Id, &billing_invoice.UserId, &billing_invoice.PeriodStart, &billing_invoice.PeriodEnd, &billing_invoice.Description, &billing_invoice.Items, &billing_invoice.Amount, &billing_invoice.Status, &billing_invoice.CreatedAt, &billing_invoice.PaidAt)
+	if err != nil {
+		return (*BillingInvoice)(nil), obj.makeErr(err)
+	}
+	return billing_invoice, nil
+
+}
+
+func (obj *pgxcockroachImpl) All_BillingInvoice_By_UserId_OrderBy_Desc_PeriodStart(ctx context.Context,
+	billing_invoice_user_id BillingInvoice_UserId_Field) (
+	rows []*BillingInvoice, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT billing_invoices.id, billing_invoices.user_id, billing_invoices.period_start, billing_invoices.period_end, billing_invoices.description, billing_invoices.items, billing_invoices.amount, billing_invoices.status, billing_invoices.created_at, billing_invoices.paid_at FROM billing_invoices WHERE billing_invoices.user_id = ? ORDER BY billing_invoices.period_start DESC")
+
+	var __values []interface{}
+	__values = append(__values, billing_invoice_user_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	for {
+		rows, err = func() (rows []*BillingInvoice, err error) {
+			__rows, err := obj.driver.QueryContext(ctx, __stmt, __values...)
+			if err != nil {
+				return nil, err
+			}
+			defer __rows.Close()
+
+			for __rows.Next() {
+				billing_invoice := &BillingInvoice{}
+				err = __rows.Scan(&billing_invoice.Id, &billing_invoice.UserId, &billing_invoice.PeriodStart, &billing_invoice.PeriodEnd, &billing_invoice.Description, &billing_invoice.Items, &billing_invoice.Amount, &billing_invoice.Status, &billing_invoice.CreatedAt, &billing_invoice.PaidAt)
+				if err != nil {
+					return nil, err
+				}
+				rows = append(rows, billing_invoice)
+			}
+			if err := __rows.Err(); err != nil {
+				return nil, err
+			}
+			return rows, nil
+		}()
+		if err != nil {
+			if obj.shouldRetry(err) {
+				continue
+			}
+			return nil, obj.makeErr(err)
+		}
+		return rows, nil
+	}
+
+}
+
+func (obj *pgxcockroachImpl) All_BillingInvoice_By_Status_OrderBy_Asc_PeriodStart(ctx context.Context,
+	billing_invoice_status BillingInvoice_Status_Field) (
+	rows []*BillingInvoice, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT billing_invoices.id, billing_invoices.user_id, billing_invoices.period_start, billing_invoices.period_end, billing_invoices.description, billing_invoices.items, billing_invoices.amount, billing_invoices.status, billing_invoices.created_at, billing_invoices.paid_at FROM billing_invoices WHERE billing_invoices.status = ? ORDER BY billing_invoices.period_start")
+
+	var __values []interface{}
+	__values = append(__values, billing_invoice_status.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	for {
+		rows, err = func() (rows []*BillingInvoice, err error) {
+			__rows, err := obj.driver.QueryContext(ctx, __stmt, __values...)
+			if err != nil {
+				return nil, err
+			}
+			defer __rows.Close()
+
+			for __rows.Next() {
+				billing_invoice := &BillingInvoice{}
+				err = __rows.Scan(&billing_invoice.Id, &billing_invoice.UserId, &billing_invoice.PeriodStart, &billing_invoice.PeriodEnd, &billing_invoice.Description, &billing_invoice.Items, &billing_invoice.Amount, &billing_invoice.Status, &billing_invoice.CreatedAt, &billing_invoice.PaidAt)
+				if err != nil {
+					return nil, err
+				}
+				rows = append(rows, billing_invoice)
+			}
+			if err := __rows.Err(); err != nil {
+				return nil, err
+			}
+			return rows, nil
+		}()
+		if err != nil {
+			if obj.shouldRetry(err) {
+				continue
+			}
+			return nil, obj.makeErr(err)
+		}
+		return rows, nil
+	}
+
+}
+
 func (obj *pgxcockroachImpl) Get_StorjscanWallet_WalletAddress_By_UserId(ctx context.Context,
 	storjscan_wallet_user_id StorjscanWallet_UserId_Field) (
 	row *WalletAddress_Row, err error) {
@@ -23258,7 +26863,7 @@ func (obj *pgxcockroachImpl) Get_OauthToken_By_Kind_And_Token(ctx context.Contex
 	oauth_token *OauthToken, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT oauth_tokens.client_id, oauth_tokens.user_id, oauth_tokens.scope, oauth_tokens.kind, oauth_tokens.token, oauth_tokens.created_at, oauth_tokens.expires_at FROM oauth_tokens WHERE oauth_tokens.kind = ? AND oauth_tokens.token = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT oauth_tokens.client_id, oauth_tokens.user_id, oauth_tokens.scope, oauth_tokens.kind, oauth_tokens.token, oauth_tokens.created_at, oauth_tokens.expires_at, oauth_tokens.name, oauth_tokens.last_used_at FROM oauth_tokens WHERE oauth_tokens.kind = ? AND oauth_tokens.token = ?")
 
 	var __values []interface{}
 	__values = append(__values, oauth_token_kind.value(), oauth_token_token.value())
@@ -23267,7 +26872,7 @@ func (obj *pgxcockroachImpl) Get_OauthToken_By_Kind_And_Token(ctx context.Contex
 	obj.logStmt(__stmt, __values...)
 
 	oauth_token = &OauthToken{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&oauth_token.ClientId, &oauth_token.UserId, &oauth_token.Scope, &oauth_token.Kind, &oauth_token.Token, &oauth_token.CreatedAt, &oauth_token.ExpiresAt)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&oauth_token.ClientId, &oauth_token.UserId, &oauth_token.Scope, &oauth_token.Kind, &oauth_token.Token, &oauth_token.CreatedAt, &oauth_token.ExpiresAt, &oauth_token.Name, &oauth_token.LastUsedAt)
 	if err != nil {
 		return (*OauthToken)(nil), obj.makeErr(err)
 	}
@@ -23275,6 +26880,52 @@ func (obj *pgxcockroachImpl) Get_OauthToken_By_Kind_And_Token(ctx context.Contex
 
 }
 
+func (obj *pgxcockroachImpl) All_OauthToken_By_UserId_And_Kind_OrderBy_Asc_CreatedAt(ctx context.Context,
+	oauth_token_user_id OauthToken_UserId_Field,
+	oauth_token_kind OauthToken_Kind_Field) (
+	rows []*OauthToken, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT oauth_tokens.client_id, oauth_tokens.user_id, oauth_tokens.scope, oauth_tokens.kind, oauth_tokens.token, oauth_tokens.created_at, oauth_tokens.expires_at, oauth_tokens.name, oauth_tokens.last_used_at FROM oauth_tokens WHERE oauth_tokens.user_id = ? AND oauth_tokens.kind = ? ORDER BY oauth_tokens.created_at")
+
+	var __values []interface{}
+	__values = append(__values, oauth_token_user_id.value(), oauth_token_kind.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	for {
+		rows, err = func() (rows []*OauthToken, err error) {
+			__rows, err := obj.driver.QueryContext(ctx, __stmt, __values...)
+			if err != nil {
+				return nil, err
+			}
+			defer __rows.Close()
+
+			for __rows.Next() {
+				oauth_token := &OauthToken{}
+				err = __rows.Scan(&oauth_token.ClientId, &oauth_token.UserId, &oauth_token.Scope, &oauth_token.Kind, &oauth_token.Token, &oauth_token.CreatedAt, &oauth_token.ExpiresAt, &oauth_token.Name, &oauth_token.LastUsedAt)
+				if err != nil {
+					return nil, err
+				}
+				rows = append(rows, oauth_token)
+			}
+			if err := __rows.Err(); err != nil {
+				return nil, err
+			}
+			return rows, nil
+		}()
+		if err != nil {
+			if obj.shouldRetry(err) {
+				continue
+			}
+			return nil, obj.makeErr(err)
+		}
+		return rows, nil
+	}
+
+}
+
 func (obj *pgxcockroachImpl) UpdateNoReturn_AccountingTimestamps_By_Name(ctx context.Context,
 	accounting_timestamps_name AccountingTimestamps_Name_Field,
 	update AccountingTimestamps_Update_Fields) (
@@ -24356,7 +28007,7 @@ func (obj *pgxcockroachImpl) Update_WebappSession_By_Id(ctx context.Context,
 	defer mon.Task()(&ctx)(&err)
 	var __sets = &__sqlbundle_Hole{}
 
-	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE webapp_sessions SET "), __sets, __sqlbundle_Literal(" WHERE webapp_sessions.id = ? RETURNING webapp_sessions.id, webapp_sessions.user_id, webapp_sessions.ip_address, webapp_sessions.user_agent, webapp_sessions.status, webapp_sessions.expires_at")}}
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE webapp_sessions SET "), __sets, __sqlbundle_Literal(" WHERE webapp_sessions.id = ? RETURNING webapp_sessions.id, webapp_sessions.user_id, webapp_sessions.ip_address, webapp_sessions.user_agent, webapp_sessions.status, webapp_sessions.expires_at, webapp_sessions.created_at, webapp_sessions.last_seen_at")}}
 
 	__sets_sql := __sqlbundle_Literals{Join: ", "}
 	var __values []interface{}
@@ -24372,6 +28023,11 @@ func (obj *pgxcockroachImpl) Update_WebappSession_By_Id(ctx context.Context,
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("expires_at = ?"))
 	}
 
+	if update.LastSeenAt._set {
+		__values = append(__values, update.LastSeenAt.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("last_seen_at = ?"))
+	}
+
 	if len(__sets_sql.SQLs) == 0 {
 		return nil, emptyUpdate()
 	}
@@ -24385,7 +28041,7 @@ func (obj *pgxcockroachImpl) Update_WebappSession_By_Id(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	webapp_session = &WebappSession{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&webapp_session.Id, &webapp_session.UserId, &webapp_session.IpAddress, &webapp_session.UserAgent, &webapp_session.Status, &webapp_session.ExpiresAt)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&webapp_session.Id, &webapp_session.UserId, &webapp_session.IpAddress, &webapp_session.UserAgent, &webapp_session.Status, &webapp_session.ExpiresAt, &webapp_session.CreatedAt, &webapp_session.LastSeenAt)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -24402,7 +28058,7 @@ func (obj *pgxcockroachImpl) Update_Project_By_Id(ctx context.Context,
 	defer mon.Task()(&ctx)(&err)
 	var __sets = &__sqlbundle_Hole{}
 
-	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE projects SET "), __sets, __sqlbundle_Literal(" WHERE projects.id = ? RETURNING projects.id, projects.public_id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.created_at")}}
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE projects SET "), __sets, __sqlbundle_Literal(" WHERE projects.id = ? RETURNING projects.id, projects.public_id, projects.name, projects.description, projects.usage_limit, projects.bandwidth_limit, projects.segment_limit, projects.rate_limit, projects.burst_limit, projects.bandwidth_rate_limit, projects.bandwidth_burst_limit, projects.max_buckets, projects.partner_id, projects.user_agent, projects.owner_id, projects.organization_id, projects.created_at")}}
 
 	__sets_sql := __sqlbundle_Literals{Join: ", "}
 	var __values []interface{}
@@ -24438,21 +28094,206 @@ func (obj *pgxcockroachImpl) Update_Project_By_Id(ctx context.Context,
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("rate_limit = ?"))
 	}
 
-	if update.BurstLimit._set {
-		__values = append(__values, update.BurstLimit.value())
-		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("burst_limit = ?"))
-	}
+	if update.BurstLimit._set {
+		__values = append(__values, update.BurstLimit.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("burst_limit = ?"))
+	}
+
+	if update.BandwidthRateLimit._set {
+		__values = append(__values, update.BandwidthRateLimit.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("bandwidth_rate_limit = ?"))
+	}
+
+	if update.BandwidthBurstLimit._set {
+		__values = append(__values, update.BandwidthBurstLimit.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("bandwidth_burst_limit = ?"))
+	}
+
+	if update.MaxBuckets._set {
+		__values = append(__values, update.MaxBuckets.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("max_buckets = ?"))
+	}
+
+	if update.OrganizationId._set {
+		__values = append(__values, update.OrganizationId.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("organization_id = ?"))
+	}
+
+	if len(__sets_sql.SQLs) == 0 {
+		return nil, emptyUpdate()
+	}
+
+	__args = append(__args, project_id.value())
+
+	__values = append(__values, __args...)
+	__sets.SQL = __sets_sql
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	project = &Project{}
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&project.Id, &project.PublicId, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.BandwidthRateLimit, &project.BandwidthBurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.OrganizationId, &project.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return project, nil
+}
+
+func (obj *pgxcockroachImpl) UpdateNoReturn_ProjectSpendingCap_By_ProjectId(ctx context.Context,
+	project_spending_cap_project_id ProjectSpendingCap_ProjectId_Field,
+	update ProjectSpendingCap_Update_Fields) (
+	err error) {
+	defer mon.Task()(&ctx)(&err)
+	var __sets = &__sqlbundle_Hole{}
+
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE project_spending_caps SET "), __sets, __sqlbundle_Literal(" WHERE project_spending_caps.project_id = ?")}}
+
+	__sets_sql := __sqlbundle_Literals{Join: ", "}
+	var __values []interface{}
+	var __args []interface{}
+
+	if update.Cap._set {
+		__values = append(__values, update.Cap.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("cap = ?"))
+	}
+
+	if update.AlertThresholds._set {
+		__values = append(__values, update.AlertThresholds.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("alert_thresholds = ?"))
+	}
+
+	if update.Enforce._set {
+		__values = append(__values, update.Enforce.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("enforce = ?"))
+	}
+
+	if update.LimitBandwidth._set {
+		__values = append(__values, update.LimitBandwidth.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("limit_bandwidth = ?"))
+	}
+
+	if update.AlertPeriod._set {
+		__values = append(__values, update.AlertPeriod.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("alert_period = ?"))
+	}
+
+	if update.AlertedThreshold._set {
+		__values = append(__values, update.AlertedThreshold.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("alerted_threshold = ?"))
+	}
+
+	if update.Capped._set {
+		__values = append(__values, update.Capped.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("capped = ?"))
+	}
+
+	if len(__sets_sql.SQLs) == 0 {
+		return emptyUpdate()
+	}
+
+	__args = append(__args, project_spending_cap_project_id.value())
+
+	__values = append(__values, __args...)
+	__sets.SQL = __sets_sql
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	_, err = obj.driver.ExecContext(ctx, __stmt, __values...)
+	if err != nil {
+		return obj.makeErr(err)
+	}
+	return nil
+}
+
+func (obj *pgxcockroachImpl) UpdateNoReturn_ProjectUsageExport_By_ProjectId(ctx context.Context,
+	project_usage_export_project_id ProjectUsageExport_ProjectId_Field,
+	update ProjectUsageExport_Update_Fields) (
+	err error) {
+	defer mon.Task()(&ctx)(&err)
+	var __sets = &__sqlbundle_Hole{}
+
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE project_usage_exports SET "), __sets, __sqlbundle_Literal(" WHERE project_usage_exports.project_id = ?")}}
+
+	__sets_sql := __sqlbundle_Literals{Join: ", "}
+	var __values []interface{}
+	var __args []interface{}
+
+	if update.EncryptedAccess._set {
+		__values = append(__values, update.EncryptedAccess.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("encrypted_access = ?"))
+	}
+
+	if update.Bucket._set {
+		__values = append(__values, update.Bucket.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("bucket = ?"))
+	}
+
+	if update.Prefix._set {
+		__values = append(__values, update.Prefix.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("prefix = ?"))
+	}
+
+	if update.Format._set {
+		__values = append(__values, update.Format.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("format = ?"))
+	}
+
+	if update.Schedule._set {
+		__values = append(__values, update.Schedule.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("schedule = ?"))
+	}
+
+	if update.ExportedUntil._set {
+		__values = append(__values, update.ExportedUntil.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("exported_until = ?"))
+	}
+
+	if len(__sets_sql.SQLs) == 0 {
+		return emptyUpdate()
+	}
+
+	__args = append(__args, project_usage_export_project_id.value())
+
+	__values = append(__values, __args...)
+	__sets.SQL = __sets_sql
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	_, err = obj.driver.ExecContext(ctx, __stmt, __values...)
+	if err != nil {
+		return obj.makeErr(err)
+	}
+	return nil
+}
+
+func (obj *pgxcockroachImpl) Update_Organization_By_Id(ctx context.Context,
+	organization_id Organization_Id_Field,
+	update Organization_Update_Fields) (
+	organization *Organization, err error) {
+	defer mon.Task()(&ctx)(&err)
+	var __sets = &__sqlbundle_Hole{}
 
-	if update.MaxBuckets._set {
-		__values = append(__values, update.MaxBuckets.value())
-		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("max_buckets = ?"))
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE organizations SET "), __sets, __sqlbundle_Literal(" WHERE organizations.id = ? RETURNING organizations.id, organizations.name, organizations.created_at")}}
+
+	__sets_sql := __sqlbundle_Literals{Join: ", "}
+	var __values []interface{}
+	var __args []interface{}
+
+	if update.Name._set {
+		__values = append(__values, update.Name.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("name = ?"))
 	}
 
 	if len(__sets_sql.SQLs) == 0 {
 		return nil, emptyUpdate()
 	}
 
-	__args = append(__args, project_id.value())
+	__args = append(__args, organization_id.value())
 
 	__values = append(__values, __args...)
 	__sets.SQL = __sets_sql
@@ -24460,15 +28301,15 @@ func (obj *pgxcockroachImpl) Update_Project_By_Id(ctx context.Context,
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
-	project = &Project{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&project.Id, &project.PublicId, &project.Name, &project.Description, &project.UsageLimit, &project.BandwidthLimit, &project.SegmentLimit, &project.RateLimit, &project.BurstLimit, &project.MaxBuckets, &project.PartnerId, &project.UserAgent, &project.OwnerId, &project.CreatedAt)
+	organization = &Organization{}
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&organization.Id, &organization.Name, &organization.CreatedAt)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
-	return project, nil
+	return organization, nil
 }
 
 func (obj *pgxcockroachImpl) UpdateNoReturn_ApiKey_By_Id(ctx context.Context,
@@ -24759,6 +28600,48 @@ func (obj *pgxcockroachImpl) UpdateNoReturn_GracefulExitSegmentTransfer_By_NodeI
 	return nil
 }
 
+func (obj *pgxcockroachImpl) UpdateNoReturn_BillingInvoice_By_Id(ctx context.Context,
+	billing_invoice_id BillingInvoice_Id_Field,
+	update BillingInvoice_Update_Fields) (
+	err error) {
+	defer mon.Task()(&ctx)(&err)
+	var __sets = &__sqlbundle_Hole{}
+
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE billing_invoices SET "), __sets, __sqlbundle_Literal(" WHERE billing_invoices.id = ?")}}
+
+	__sets_sql := __sqlbundle_Literals{Join: ", "}
+	var __values []interface{}
+	var __args []interface{}
+
+	if update.Status._set {
+		__values = append(__values, update.Status.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("status = ?"))
+	}
+
+	if update.PaidAt._set {
+		__values = append(__values, update.PaidAt.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("paid_at = ?"))
+	}
+
+	if len(__sets_sql.SQLs) == 0 {
+		return emptyUpdate()
+	}
+
+	__args = append(__args, billing_invoice_id.value())
+
+	__values = append(__values, __args...)
+	__sets.SQL = __sets_sql
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	_, err = obj.driver.ExecContext(ctx, __stmt, __values...)
+	if err != nil {
+		return obj.makeErr(err)
+	}
+	return nil
+}
+
 func (obj *pgxcockroachImpl) Update_CoinpaymentsTransaction_By_Id(ctx context.Context,
 	coinpayments_transaction_id CoinpaymentsTransaction_Id_Field,
 	update CoinpaymentsTransaction_Update_Fields) (
@@ -25076,6 +28959,11 @@ func (obj *pgxcockroachImpl) UpdateNoReturn_OauthToken_By_Token_And_Kind(ctx con
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("expires_at = ?"))
 	}
 
+	if update.LastUsedAt._set {
+		__values = append(__values, update.LastUsedAt.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("last_used_at = ?"))
+	}
+
 	if len(__sets_sql.SQLs) == 0 {
 		return emptyUpdate()
 	}
@@ -25203,6 +29091,33 @@ func (obj *pgxcockroachImpl) Delete_WebappSession_By_Id(ctx context.Context,
 
 }
 
+func (obj *pgxcockroachImpl) Delete_SsoIdentity_By_UserId(ctx context.Context,
+	sso_identity_user_id SsoIdentity_UserId_Field) (
+	count int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("DELETE FROM sso_identities WHERE sso_identities.user_id = ?")
+
+	var __values []interface{}
+	__values = append(__values, sso_identity_user_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__res, err := obj.driver.ExecContext(ctx, __stmt, __values...)
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	return count, nil
+
+}
+
 func (obj *pgxcockroachImpl) Delete_WebappSession_By_UserId(ctx context.Context,
 	webapp_session_user_id WebappSession_UserId_Field) (
 	count int64, err error) {
@@ -25285,6 +29200,88 @@ func (obj *pgxcockroachImpl) Delete_ProjectMember_By_MemberId_And_ProjectId(ctx
 
 }
 
+func (obj *pgxcockroachImpl) Delete_ProjectSpendingCap_By_ProjectId(ctx context.Context,
+	project_spending_cap_project_id ProjectSpendingCap_ProjectId_Field) (
+	deleted bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("DELETE FROM project_spending_caps WHERE project_spending_caps.project_id = ?")
+
+	var __values []interface{}
+	__values = append(__values, project_spending_cap_project_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__res, err := obj.driver.ExecContext(ctx, __stmt, __values...)
+	if err != nil {
+		return false, obj.makeErr(err)
+	}
+
+	__count, err := __res.RowsAffected()
+	if err != nil {
+		return false, obj.makeErr(err)
+	}
+
+	return __count > 0, nil
+
+}
+
+func (obj *pgxcockroachImpl) Delete_ProjectUsageExport_By_ProjectId(ctx context.Context,
+	project_usage_export_project_id ProjectUsageExport_ProjectId_Field) (
+	deleted bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("DELETE FROM project_usage_exports WHERE project_usage_exports.project_id = ?")
+
+	var __values []interface{}
+	__values = append(__values, project_usage_export_project_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__res, err := obj.driver.ExecContext(ctx, __stmt, __values...)
+	if err != nil {
+		return false, obj.makeErr(err)
+	}
+
+	__count, err := __res.RowsAffected()
+	if err != nil {
+		return false, obj.makeErr(err)
+	}
+
+	return __count > 0, nil
+
+}
+
+func (obj *pgxcockroachImpl) Delete_OrganizationMember_By_OrganizationId_And_MemberId(ctx context.Context,
+	organization_member_organization_id OrganizationMember_OrganizationId_Field,
+	organization_member_member_id OrganizationMember_MemberId_Field) (
+	deleted bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("DELETE FROM organization_members WHERE organization_members.organization_id = ? AND organization_members.member_id = ?")
+
+	var __values []interface{}
+	__values = append(__values, organization_member_organization_id.value(), organization_member_member_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__res, err := obj.driver.ExecContext(ctx, __stmt, __values...)
+	if err != nil {
+		return false, obj.makeErr(err)
+	}
+
+	__count, err := __res.RowsAffected()
+	if err != nil {
+		return false, obj.makeErr(err)
+	}
+
+	return __count > 0, nil
+
+}
+
 func (obj *pgxcockroachImpl) Delete_ApiKey_By_Id(ctx context.Context,
 	api_key_id ApiKey_Id_Field) (
 	deleted bool, err error) {
@@ -25312,6 +29309,33 @@ func (obj *pgxcockroachImpl) Delete_ApiKey_By_Id(ctx context.Context,
 
 }
 
+func (obj *pgxcockroachImpl) Delete_AccessGrant_By_Id(ctx context.Context,
+	access_grant_id AccessGrant_Id_Field) (
+	deleted bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("DELETE FROM access_grants WHERE access_grants.id = ?")
+
+	var __values []interface{}
+	__values = append(__values, access_grant_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__res, err := obj.driver.ExecContext(ctx, __stmt, __values...)
+	if err != nil {
+		return false, obj.makeErr(err)
+	}
+
+	__count, err := __res.RowsAffected()
+	if err != nil {
+		return false, obj.makeErr(err)
+	}
+
+	return __count > 0, nil
+
+}
+
 func (obj *pgxcockroachImpl) Delete_ResetPasswordToken_By_Secret(ctx context.Context,
 	reset_password_token_secret ResetPasswordToken_Secret_Field) (
 	deleted bool, err error) {
@@ -25573,6 +29597,16 @@ func (obj *pgxcockroachImpl) deleteAll(ctx context.Context) (count int64, err er
 	defer mon.Task()(&ctx)(&err)
 	var __res sql.Result
 	var __count int64
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM access_grants;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
 	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM user_credits;")
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -25588,6 +29622,26 @@ func (obj *pgxcockroachImpl) deleteAll(ctx context.Context) (count int64, err er
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM project_usage_exports;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM project_spending_caps;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -25598,6 +29652,16 @@ func (obj *pgxcockroachImpl) deleteAll(ctx context.Context) (count int64, err er
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM organization_members;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -25748,6 +29812,16 @@ func (obj *pgxcockroachImpl) deleteAll(ctx context.Context) (count int64, err er
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM sso_identities;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -25858,6 +29932,16 @@ func (obj *pgxcockroachImpl) deleteAll(ctx context.Context) (count int64, err er
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM organizations;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -26018,6 +30102,16 @@ func (obj *pgxcockroachImpl) deleteAll(ctx context.Context) (count int64, err er
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM billing_invoices;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -26087,7 +30181,37 @@ func (rx *Rx) Rollback() (err error) {
 		err = rx.tx.Rollback()
 		rx.tx = nil
 	}
-	return err
+	return err
+}
+
+func (rx *Rx) All_AccessGrant_By_ProjectId_OrderBy_Asc_CreatedAt(ctx context.Context,
+	access_grant_project_id AccessGrant_ProjectId_Field) (
+	rows []*AccessGrant, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.All_AccessGrant_By_ProjectId_OrderBy_Asc_CreatedAt(ctx, access_grant_project_id)
+}
+
+func (rx *Rx) All_BillingInvoice_By_Status_OrderBy_Asc_PeriodStart(ctx context.Context,
+	billing_invoice_status BillingInvoice_Status_Field) (
+	rows []*BillingInvoice, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.All_BillingInvoice_By_Status_OrderBy_Asc_PeriodStart(ctx, billing_invoice_status)
+}
+
+func (rx *Rx) All_BillingInvoice_By_UserId_OrderBy_Desc_PeriodStart(ctx context.Context,
+	billing_invoice_user_id BillingInvoice_UserId_Field) (
+	rows []*BillingInvoice, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.All_BillingInvoice_By_UserId_OrderBy_Desc_PeriodStart(ctx, billing_invoice_user_id)
 }
 
 func (rx *Rx) All_BillingTransaction_By_UserId_And_Type_OrderBy_Desc_Timestamp(ctx context.Context,
@@ -26192,6 +30316,37 @@ func (rx *Rx) All_Node_Id_Node_PieceCount_By_PieceCount_Not_Number(ctx context.C
 	return tx.All_Node_Id_Node_PieceCount_By_PieceCount_Not_Number(ctx)
 }
 
+func (rx *Rx) All_OauthToken_By_UserId_And_Kind_OrderBy_Asc_CreatedAt(ctx context.Context,
+	oauth_token_user_id OauthToken_UserId_Field,
+	oauth_token_kind OauthToken_Kind_Field) (
+	rows []*OauthToken, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.All_OauthToken_By_UserId_And_Kind_OrderBy_Asc_CreatedAt(ctx, oauth_token_user_id, oauth_token_kind)
+}
+
+func (rx *Rx) All_OrganizationMember_By_OrganizationId_OrderBy_Asc_CreatedAt(ctx context.Context,
+	organization_member_organization_id OrganizationMember_OrganizationId_Field) (
+	rows []*OrganizationMember, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.All_OrganizationMember_By_OrganizationId_OrderBy_Asc_CreatedAt(ctx, organization_member_organization_id)
+}
+
+func (rx *Rx) All_Organization_By_OrganizationMember_MemberId_OrderBy_Asc_Organization_Name(ctx context.Context,
+	organization_member_member_id OrganizationMember_MemberId_Field) (
+	rows []*Organization, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.All_Organization_By_OrganizationMember_MemberId_OrderBy_Asc_Organization_Name(ctx, organization_member_member_id)
+}
+
 func (rx *Rx) All_Project(ctx context.Context) (
 	rows []*Project, err error) {
 	var tx *Tx
@@ -26211,6 +30366,24 @@ func (rx *Rx) All_ProjectMember_By_MemberId(ctx context.Context,
 	return tx.All_ProjectMember_By_MemberId(ctx, project_member_member_id)
 }
 
+func (rx *Rx) All_ProjectSpendingCap(ctx context.Context) (
+	rows []*ProjectSpendingCap, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.All_ProjectSpendingCap(ctx)
+}
+
+func (rx *Rx) All_ProjectUsageExport(ctx context.Context) (
+	rows []*ProjectUsageExport, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.All_ProjectUsageExport(ctx)
+}
+
 func (rx *Rx) All_Project_By_CreatedAt_Less_OrderBy_Asc_CreatedAt(ctx context.Context,
 	project_created_at_less Project_CreatedAt_Field) (
 	rows []*Project, err error) {
@@ -26221,6 +30394,16 @@ func (rx *Rx) All_Project_By_CreatedAt_Less_OrderBy_Asc_CreatedAt(ctx context.Co
 	return tx.All_Project_By_CreatedAt_Less_OrderBy_Asc_CreatedAt(ctx, project_created_at_less)
 }
 
+func (rx *Rx) All_Project_By_OrganizationId_OrderBy_Asc_CreatedAt(ctx context.Context,
+	project_organization_id Project_OrganizationId_Field) (
+	rows []*Project, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.All_Project_By_OrganizationId_OrderBy_Asc_CreatedAt(ctx, project_organization_id)
+}
+
 func (rx *Rx) All_Project_By_OwnerId_OrderBy_Asc_CreatedAt(ctx context.Context,
 	project_owner_id Project_OwnerId_Field) (
 	rows []*Project, err error) {
@@ -26350,6 +30533,23 @@ func (rx *Rx) Count_BucketMetainfo_Name_By_ProjectId(ctx context.Context,
 	return tx.Count_BucketMetainfo_Name_By_ProjectId(ctx, bucket_metainfo_project_id)
 }
 
+func (rx *Rx) CreateNoReturn_AccessGrant(ctx context.Context,
+	access_grant_id AccessGrant_Id_Field,
+	access_grant_project_id AccessGrant_ProjectId_Field,
+	access_grant_api_key_id AccessGrant_ApiKeyId_Field,
+	access_grant_name AccessGrant_Name_Field,
+	access_grant_caveats AccessGrant_Caveats_Field,
+	access_grant_tails AccessGrant_Tails_Field,
+	access_grant_created_by AccessGrant_CreatedBy_Field) (
+	err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.CreateNoReturn_AccessGrant(ctx, access_grant_id, access_grant_project_id, access_grant_api_key_id, access_grant_name, access_grant_caveats, access_grant_tails, access_grant_created_by)
+
+}
+
 func (rx *Rx) CreateNoReturn_AccountingTimestamps(ctx context.Context,
 	accounting_timestamps_name AccountingTimestamps_Name_Field,
 	accounting_timestamps_value AccountingTimestamps_Value_Field) (
@@ -26362,6 +30562,25 @@ func (rx *Rx) CreateNoReturn_AccountingTimestamps(ctx context.Context,
 
 }
 
+func (rx *Rx) CreateNoReturn_BillingInvoice(ctx context.Context,
+	billing_invoice_id BillingInvoice_Id_Field,
+	billing_invoice_user_id BillingInvoice_UserId_Field,
+	billing_invoice_period_start BillingInvoice_PeriodStart_Field,
+	billing_invoice_period_end BillingInvoice_PeriodEnd_Field,
+	billing_invoice_description BillingInvoice_Description_Field,
+	billing_invoice_items BillingInvoice_Items_Field,
+	billing_invoice_amount BillingInvoice_Amount_Field,
+	billing_invoice_status BillingInvoice_Status_Field,
+	optional BillingInvoice_Create_Fields) (
+	err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.CreateNoReturn_BillingInvoice(ctx, billing_invoice_id, billing_invoice_user_id, billing_invoice_period_start, billing_invoice_period_end, billing_invoice_description, billing_invoice_items, billing_invoice_amount, billing_invoice_status, optional)
+
+}
+
 func (rx *Rx) CreateNoReturn_BillingTransaction(ctx context.Context,
 	billing_transaction_tx_id BillingTransaction_TxId_Field,
 	billing_transaction_user_id BillingTransaction_UserId_Field,
@@ -26422,13 +30641,26 @@ func (rx *Rx) CreateNoReturn_OauthToken(ctx context.Context,
 	oauth_token_kind OauthToken_Kind_Field,
 	oauth_token_token OauthToken_Token_Field,
 	oauth_token_created_at OauthToken_CreatedAt_Field,
-	oauth_token_expires_at OauthToken_ExpiresAt_Field) (
+	oauth_token_expires_at OauthToken_ExpiresAt_Field,
+	optional OauthToken_Create_Fields) (
+	err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.CreateNoReturn_OauthToken(ctx, oauth_token_client_id, oauth_token_user_id, oauth_token_scope, oauth_token_kind, oauth_token_token, oauth_token_created_at, oauth_token_expires_at, optional)
+
+}
+
+func (rx *Rx) CreateNoReturn_OrganizationMember(ctx context.Context,
+	organization_member_organization_id OrganizationMember_OrganizationId_Field,
+	organization_member_member_id OrganizationMember_MemberId_Field) (
 	err error) {
 	var tx *Tx
 	if tx, err = rx.getTx(ctx); err != nil {
 		return
 	}
-	return tx.CreateNoReturn_OauthToken(ctx, oauth_token_client_id, oauth_token_user_id, oauth_token_scope, oauth_token_kind, oauth_token_token, oauth_token_created_at, oauth_token_expires_at)
+	return tx.CreateNoReturn_OrganizationMember(ctx, organization_member_organization_id, organization_member_member_id)
 
 }
 
@@ -26445,6 +30677,41 @@ func (rx *Rx) CreateNoReturn_PeerIdentity(ctx context.Context,
 
 }
 
+func (rx *Rx) CreateNoReturn_ProjectSpendingCap(ctx context.Context,
+	project_spending_cap_project_id ProjectSpendingCap_ProjectId_Field,
+	project_spending_cap_cap ProjectSpendingCap_Cap_Field,
+	project_spending_cap_alert_thresholds ProjectSpendingCap_AlertThresholds_Field,
+	project_spending_cap_enforce ProjectSpendingCap_Enforce_Field,
+	project_spending_cap_limit_bandwidth ProjectSpendingCap_LimitBandwidth_Field,
+	project_spending_cap_alert_period ProjectSpendingCap_AlertPeriod_Field,
+	project_spending_cap_alerted_threshold ProjectSpendingCap_AlertedThreshold_Field,
+	project_spending_cap_capped ProjectSpendingCap_Capped_Field) (
+	err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.CreateNoReturn_ProjectSpendingCap(ctx, project_spending_cap_project_id, project_spending_cap_cap, project_spending_cap_alert_thresholds, project_spending_cap_enforce, project_spending_cap_limit_bandwidth, project_spending_cap_alert_period, project_spending_cap_alerted_threshold, project_spending_cap_capped)
+
+}
+
+func (rx *Rx) CreateNoReturn_ProjectUsageExport(ctx context.Context,
+	project_usage_export_project_id ProjectUsageExport_ProjectId_Field,
+	project_usage_export_encrypted_access ProjectUsageExport_EncryptedAccess_Field,
+	project_usage_export_bucket ProjectUsageExport_Bucket_Field,
+	project_usage_export_prefix ProjectUsageExport_Prefix_Field,
+	project_usage_export_format ProjectUsageExport_Format_Field,
+	project_usage_export_schedule ProjectUsageExport_Schedule_Field,
+	project_usage_export_exported_until ProjectUsageExport_ExportedUntil_Field) (
+	err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.CreateNoReturn_ProjectUsageExport(ctx, project_usage_export_project_id, project_usage_export_encrypted_access, project_usage_export_bucket, project_usage_export_prefix, project_usage_export_format, project_usage_export_schedule, project_usage_export_exported_until)
+
+}
+
 func (rx *Rx) CreateNoReturn_Revocation(ctx context.Context,
 	revocation_revoked Revocation_Revoked_Field,
 	revocation_api_key_id Revocation_ApiKeyId_Field) (
@@ -26457,6 +30724,19 @@ func (rx *Rx) CreateNoReturn_Revocation(ctx context.Context,
 
 }
 
+func (rx *Rx) CreateNoReturn_SsoIdentity(ctx context.Context,
+	sso_identity_issuer SsoIdentity_Issuer_Field,
+	sso_identity_subject SsoIdentity_Subject_Field,
+	sso_identity_user_id SsoIdentity_UserId_Field) (
+	err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.CreateNoReturn_SsoIdentity(ctx, sso_identity_issuer, sso_identity_subject, sso_identity_user_id)
+
+}
+
 func (rx *Rx) CreateNoReturn_StoragenodePayment(ctx context.Context,
 	storagenode_payment_node_id StoragenodePayment_NodeId_Field,
 	storagenode_payment_period StoragenodePayment_Period_Field,
@@ -26609,6 +30889,18 @@ func (rx *Rx) Create_CouponUsage(ctx context.Context,
 
 }
 
+func (rx *Rx) Create_Organization(ctx context.Context,
+	organization_id Organization_Id_Field,
+	organization_name Organization_Name_Field) (
+	organization *Organization, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Create_Organization(ctx, organization_id, organization_name)
+
+}
+
 func (rx *Rx) Create_Project(ctx context.Context,
 	project_id Project_Id_Field,
 	project_name Project_Name_Field,
@@ -26777,6 +31069,16 @@ func (rx *Rx) Create_WebappSession(ctx context.Context,
 
 }
 
+func (rx *Rx) Delete_AccessGrant_By_Id(ctx context.Context,
+	access_grant_id AccessGrant_Id_Field) (
+	deleted bool, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Delete_AccessGrant_By_Id(ctx, access_grant_id)
+}
+
 func (rx *Rx) Delete_ApiKey_By_Id(ctx context.Context,
 	api_key_id ApiKey_Id_Field) (
 	deleted bool, err error) {
@@ -26863,6 +31165,17 @@ func (rx *Rx) Delete_OauthClient_By_Id(ctx context.Context,
 	return tx.Delete_OauthClient_By_Id(ctx, oauth_client_id)
 }
 
+func (rx *Rx) Delete_OrganizationMember_By_OrganizationId_And_MemberId(ctx context.Context,
+	organization_member_organization_id OrganizationMember_OrganizationId_Field,
+	organization_member_member_id OrganizationMember_MemberId_Field) (
+	deleted bool, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Delete_OrganizationMember_By_OrganizationId_And_MemberId(ctx, organization_member_organization_id, organization_member_member_id)
+}
+
 func (rx *Rx) Delete_ProjectMember_By_MemberId_And_ProjectId(ctx context.Context,
 	project_member_member_id ProjectMember_MemberId_Field,
 	project_member_project_id ProjectMember_ProjectId_Field) (
@@ -26874,6 +31187,26 @@ func (rx *Rx) Delete_ProjectMember_By_MemberId_And_ProjectId(ctx context.Context
 	return tx.Delete_ProjectMember_By_MemberId_And_ProjectId(ctx, project_member_member_id, project_member_project_id)
 }
 
+func (rx *Rx) Delete_ProjectSpendingCap_By_ProjectId(ctx context.Context,
+	project_spending_cap_project_id ProjectSpendingCap_ProjectId_Field) (
+	deleted bool, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Delete_ProjectSpendingCap_By_ProjectId(ctx, project_spending_cap_project_id)
+}
+
+func (rx *Rx) Delete_ProjectUsageExport_By_ProjectId(ctx context.Context,
+	project_usage_export_project_id ProjectUsageExport_ProjectId_Field) (
+	deleted bool, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Delete_ProjectUsageExport_By_ProjectId(ctx, project_usage_export_project_id)
+}
+
 func (rx *Rx) Delete_Project_By_Id(ctx context.Context,
 	project_id Project_Id_Field) (
 	deleted bool, err error) {
@@ -26915,6 +31248,17 @@ func (rx *Rx) Delete_SegmentPendingAudits_By_NodeId(ctx context.Context,
 	return tx.Delete_SegmentPendingAudits_By_NodeId(ctx, segment_pending_audits_node_id)
 }
 
+func (rx *Rx) Delete_SsoIdentity_By_UserId(ctx context.Context,
+	sso_identity_user_id SsoIdentity_UserId_Field) (
+	count int64, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Delete_SsoIdentity_By_UserId(ctx, sso_identity_user_id)
+
+}
+
 func (rx *Rx) Delete_StorjscanPayment_By_Status(ctx context.Context,
 	storjscan_payment_status StorjscanPayment_Status_Field) (
 	count int64, err error) {
@@ -26977,6 +31321,16 @@ func (rx *Rx) First_StorjscanPayment_BlockNumber_By_Status_OrderBy_Desc_BlockNum
 	return tx.First_StorjscanPayment_BlockNumber_By_Status_OrderBy_Desc_BlockNumber_Desc_LogIndex(ctx, storjscan_payment_status)
 }
 
+func (rx *Rx) Get_AccessGrant_By_Id(ctx context.Context,
+	access_grant_id AccessGrant_Id_Field) (
+	access_grant *AccessGrant, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Get_AccessGrant_By_Id(ctx, access_grant_id)
+}
+
 func (rx *Rx) Get_ApiKey_By_Head(ctx context.Context,
 	api_key_head ApiKey_Head_Field) (
 	api_key *ApiKey, err error) {
@@ -27008,6 +31362,27 @@ func (rx *Rx) Get_ApiKey_By_Name_And_ProjectId(ctx context.Context,
 	return tx.Get_ApiKey_By_Name_And_ProjectId(ctx, api_key_name, api_key_project_id)
 }
 
+func (rx *Rx) Get_BillingInvoice_By_Id(ctx context.Context,
+	billing_invoice_id BillingInvoice_Id_Field) (
+	billing_invoice *BillingInvoice, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Get_BillingInvoice_By_Id(ctx, billing_invoice_id)
+}
+
+func (rx *Rx) Get_BillingInvoice_By_UserId_And_PeriodStart(ctx context.Context,
+	billing_invoice_user_id BillingInvoice_UserId_Field,
+	billing_invoice_period_start BillingInvoice_PeriodStart_Field) (
+	billing_invoice *BillingInvoice, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Get_BillingInvoice_By_UserId_And_PeriodStart(ctx, billing_invoice_user_id, billing_invoice_period_start)
+}
+
 func (rx *Rx) Get_BucketMetainfo_By_ProjectId_And_Name(ctx context.Context,
 	bucket_metainfo_project_id BucketMetainfo_ProjectId_Field,
 	bucket_metainfo_name BucketMetainfo_Name_Field) (
@@ -27136,6 +31511,16 @@ func (rx *Rx) Get_OauthToken_By_Kind_And_Token(ctx context.Context,
 	return tx.Get_OauthToken_By_Kind_And_Token(ctx, oauth_token_kind, oauth_token_token)
 }
 
+func (rx *Rx) Get_Organization_By_Id(ctx context.Context,
+	organization_id Organization_Id_Field) (
+	organization *Organization, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Get_Organization_By_Id(ctx, organization_id)
+}
+
 func (rx *Rx) Get_PeerIdentity_By_NodeId(ctx context.Context,
 	peer_identity_node_id PeerIdentity_NodeId_Field) (
 	peer_identity *PeerIdentity, err error) {
@@ -27156,6 +31541,26 @@ func (rx *Rx) Get_PeerIdentity_LeafSerialNumber_By_NodeId(ctx context.Context,
 	return tx.Get_PeerIdentity_LeafSerialNumber_By_NodeId(ctx, peer_identity_node_id)
 }
 
+func (rx *Rx) Get_ProjectSpendingCap_By_ProjectId(ctx context.Context,
+	project_spending_cap_project_id ProjectSpendingCap_ProjectId_Field) (
+	project_spending_cap *ProjectSpendingCap, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Get_ProjectSpendingCap_By_ProjectId(ctx, project_spending_cap_project_id)
+}
+
+func (rx *Rx) Get_ProjectUsageExport_By_ProjectId(ctx context.Context,
+	project_usage_export_project_id ProjectUsageExport_ProjectId_Field) (
+	project_usage_export *ProjectUsageExport, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Get_ProjectUsageExport_By_ProjectId(ctx, project_usage_export_project_id)
+}
+
 func (rx *Rx) Get_Project_BandwidthLimit_By_Id(ctx context.Context,
 	project_id Project_Id_Field) (
 	row *BandwidthLimit_Row, err error) {
@@ -27166,14 +31571,14 @@ func (rx *Rx) Get_Project_BandwidthLimit_By_Id(ctx context.Context,
 	return tx.Get_Project_BandwidthLimit_By_Id(ctx, project_id)
 }
 
-func (rx *Rx) Get_Project_BandwidthLimit_Project_UsageLimit_Project_SegmentLimit_By_Id(ctx context.Context,
+func (rx *Rx) Get_Project_BandwidthLimit_Project_UsageLimit_Project_SegmentLimit_Project_BandwidthRateLimit_Project_BandwidthBurstLimit_By_Id(ctx context.Context,
 	project_id Project_Id_Field) (
-	row *BandwidthLimit_UsageLimit_SegmentLimit_Row, err error) {
+	row *BandwidthLimit_UsageLimit_SegmentLimit_BandwidthRateLimit_BandwidthBurstLimit_Row, err error) {
 	var tx *Tx
 	if tx, err = rx.getTx(ctx); err != nil {
 		return
 	}
-	return tx.Get_Project_BandwidthLimit_Project_UsageLimit_Project_SegmentLimit_By_Id(ctx, project_id)
+	return tx.Get_Project_BandwidthLimit_Project_UsageLimit_Project_SegmentLimit_Project_BandwidthRateLimit_Project_BandwidthBurstLimit_By_Id(ctx, project_id)
 }
 
 func (rx *Rx) Get_Project_By_Id(ctx context.Context,
@@ -27286,6 +31691,17 @@ func (rx *Rx) Get_SegmentPendingAudits_By_NodeId(ctx context.Context,
 	return tx.Get_SegmentPendingAudits_By_NodeId(ctx, segment_pending_audits_node_id)
 }
 
+func (rx *Rx) Get_SsoIdentity_By_Issuer_And_Subject(ctx context.Context,
+	sso_identity_issuer SsoIdentity_Issuer_Field,
+	sso_identity_subject SsoIdentity_Subject_Field) (
+	sso_identity *SsoIdentity, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Get_SsoIdentity_By_Issuer_And_Subject(ctx, sso_identity_issuer, sso_identity_subject)
+}
+
 func (rx *Rx) Get_StoragenodePaystub_By_NodeId_And_Period(ctx context.Context,
 	storagenode_paystub_node_id StoragenodePaystub_NodeId_Field,
 	storagenode_paystub_period StoragenodePaystub_Period_Field) (
@@ -27432,6 +31848,17 @@ func (rx *Rx) Has_NodeApiVersion_By_Id_And_ApiVersion_GreaterOrEqual(ctx context
 	return tx.Has_NodeApiVersion_By_Id_And_ApiVersion_GreaterOrEqual(ctx, node_api_version_id, node_api_version_api_version_greater_or_equal)
 }
 
+func (rx *Rx) Has_OrganizationMember_By_OrganizationId_And_MemberId(ctx context.Context,
+	organization_member_organization_id OrganizationMember_OrganizationId_Field,
+	organization_member_member_id OrganizationMember_MemberId_Field) (
+	has bool, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Has_OrganizationMember_By_OrganizationId_And_MemberId(ctx, organization_member_organization_id, organization_member_member_id)
+}
+
 func (rx *Rx) Limited_BucketMetainfo_By_ProjectId_And_Name_GreaterOrEqual_OrderBy_Asc_Name(ctx context.Context,
 	bucket_metainfo_project_id BucketMetainfo_ProjectId_Field,
 	bucket_metainfo_name_greater_or_equal BucketMetainfo_Name_Field,
@@ -27680,6 +32107,17 @@ func (rx *Rx) UpdateNoReturn_ApiKey_By_Id(ctx context.Context,
 	return tx.UpdateNoReturn_ApiKey_By_Id(ctx, api_key_id, update)
 }
 
+func (rx *Rx) UpdateNoReturn_BillingInvoice_By_Id(ctx context.Context,
+	billing_invoice_id BillingInvoice_Id_Field,
+	update BillingInvoice_Update_Fields) (
+	err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.UpdateNoReturn_BillingInvoice_By_Id(ctx, billing_invoice_id, update)
+}
+
 func (rx *Rx) UpdateNoReturn_GracefulExitSegmentTransfer_By_NodeId_And_StreamId_And_Position_And_PieceNum(ctx context.Context,
 	graceful_exit_segment_transfer_node_id GracefulExitSegmentTransfer_NodeId_Field,
 	graceful_exit_segment_transfer_stream_id GracefulExitSegmentTransfer_StreamId_Field,
@@ -27773,6 +32211,28 @@ func (rx *Rx) UpdateNoReturn_PeerIdentity_By_NodeId(ctx context.Context,
 	return tx.UpdateNoReturn_PeerIdentity_By_NodeId(ctx, peer_identity_node_id, update)
 }
 
+func (rx *Rx) UpdateNoReturn_ProjectSpendingCap_By_ProjectId(ctx context.Context,
+	project_spending_cap_project_id ProjectSpendingCap_ProjectId_Field,
+	update ProjectSpendingCap_Update_Fields) (
+	err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.UpdateNoReturn_ProjectSpendingCap_By_ProjectId(ctx, project_spending_cap_project_id, update)
+}
+
+func (rx *Rx) UpdateNoReturn_ProjectUsageExport_By_ProjectId(ctx context.Context,
+	project_usage_export_project_id ProjectUsageExport_ProjectId_Field,
+	update ProjectUsageExport_Update_Fields) (
+	err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.UpdateNoReturn_ProjectUsageExport_By_ProjectId(ctx, project_usage_export_project_id, update)
+}
+
 func (rx *Rx) UpdateNoReturn_Reputation_By_Id(ctx context.Context,
 	reputation_id Reputation_Id_Field,
 	update Reputation_Update_Fields) (
@@ -27841,6 +32301,17 @@ func (rx *Rx) Update_Node_By_Id(ctx context.Context,
 	return tx.Update_Node_By_Id(ctx, node_id, update)
 }
 
+func (rx *Rx) Update_Organization_By_Id(ctx context.Context,
+	organization_id Organization_Id_Field,
+	update Organization_Update_Fields) (
+	organization *Organization, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Update_Organization_By_Id(ctx, organization_id, update)
+}
+
 func (rx *Rx) Update_Project_By_Id(ctx context.Context,
 	project_id Project_Id_Field,
 	update Project_Update_Fields) (
@@ -27920,6 +32391,18 @@ func (rx *Rx) Update_WebappSession_By_Id(ctx context.Context,
 }
 
 type Methods interface {
+	All_AccessGrant_By_ProjectId_OrderBy_Asc_CreatedAt(ctx context.Context,
+		access_grant_project_id AccessGrant_ProjectId_Field) (
+		rows []*AccessGrant, err error)
+
+	All_BillingInvoice_By_Status_OrderBy_Asc_PeriodStart(ctx context.Context,
+		billing_invoice_status BillingInvoice_Status_Field) (
+		rows []*BillingInvoice, err error)
+
+	All_BillingInvoice_By_UserId_OrderBy_Desc_PeriodStart(ctx context.Context,
+		billing_invoice_user_id BillingInvoice_UserId_Field) (
+		rows []*BillingInvoice, err error)
+
 	All_BillingTransaction_By_UserId_And_Type_OrderBy_Desc_Timestamp(ctx context.Context,
 		billing_transaction_user_id BillingTransaction_UserId_Field,
 		billing_transaction_type BillingTransaction_Type_Field) (
@@ -27962,6 +32445,19 @@ type Methods interface {
 	All_Node_Id_Node_PieceCount_By_PieceCount_Not_Number(ctx context.Context) (
 		rows []*Id_PieceCount_Row, err error)
 
+	All_OauthToken_By_UserId_And_Kind_OrderBy_Asc_CreatedAt(ctx context.Context,
+		oauth_token_user_id OauthToken_UserId_Field,
+		oauth_token_kind OauthToken_Kind_Field) (
+		rows []*OauthToken, err error)
+
+	All_OrganizationMember_By_OrganizationId_OrderBy_Asc_CreatedAt(ctx context.Context,
+		organization_member_organization_id OrganizationMember_OrganizationId_Field) (
+		rows []*OrganizationMember, err error)
+
+	All_Organization_By_OrganizationMember_MemberId_OrderBy_Asc_Organization_Name(ctx context.Context,
+		organization_member_member_id OrganizationMember_MemberId_Field) (
+		rows []*Organization, err error)
+
 	All_Project(ctx context.Context) (
 		rows []*Project, err error)
 
@@ -27969,10 +32465,20 @@ type Methods interface {
 		project_member_member_id ProjectMember_MemberId_Field) (
 		rows []*ProjectMember, err error)
 
+	All_ProjectSpendingCap(ctx context.Context) (
+		rows []*ProjectSpendingCap, err error)
+
+	All_ProjectUsageExport(ctx context.Context) (
+		rows []*ProjectUsageExport, err error)
+
 	All_Project_By_CreatedAt_Less_OrderBy_Asc_CreatedAt(ctx context.Context,
 		project_created_at_less Project_CreatedAt_Field) (
 		rows []*Project, err error)
 
+	All_Project_By_OrganizationId_OrderBy_Asc_CreatedAt(ctx context.Context,
+		project_organization_id Project_OrganizationId_Field) (
+		rows []*Project, err error)
+
 	All_Project_By_OwnerId_OrderBy_Asc_CreatedAt(ctx context.Context,
 		project_owner_id Project_OwnerId_Field) (
 		rows []*Project, err error)
@@ -28024,11 +32530,33 @@ type Methods interface {
 		bucket_metainfo_project_id BucketMetainfo_ProjectId_Field) (
 		count int64, err error)
 
+	CreateNoReturn_AccessGrant(ctx context.Context,
+		access_grant_id AccessGrant_Id_Field,
+		access_grant_project_id AccessGrant_ProjectId_Field,
+		access_grant_api_key_id AccessGrant_ApiKeyId_Field,
+		access_grant_name AccessGrant_Name_Field,
+		access_grant_caveats AccessGrant_Caveats_Field,
+		access_grant_tails AccessGrant_Tails_Field,
+		access_grant_created_by AccessGrant_CreatedBy_Field) (
+		err error)
+
 	CreateNoReturn_AccountingTimestamps(ctx context.Context,
 		accounting_timestamps_name AccountingTimestamps_Name_Field,
 		accounting_timestamps_value AccountingTimestamps_Value_Field) (
 		err error)
 
+	CreateNoReturn_BillingInvoice(ctx context.Context,
+		billing_invoice_id BillingInvoice_Id_Field,
+		billing_invoice_user_id BillingInvoice_UserId_Field,
+		billing_invoice_period_start BillingInvoice_PeriodStart_Field,
+		billing_invoice_period_end BillingInvoice_PeriodEnd_Field,
+		billing_invoice_description BillingInvoice_Description_Field,
+		billing_invoice_items BillingInvoice_Items_Field,
+		billing_invoice_amount BillingInvoice_Amount_Field,
+		billing_invoice_status BillingInvoice_Status_Field,
+		optional BillingInvoice_Create_Fields) (
+		err error)
+
 	CreateNoReturn_BillingTransaction(ctx context.Context,
 		billing_transaction_tx_id BillingTransaction_TxId_Field,
 		billing_transaction_user_id BillingTransaction_UserId_Field,
@@ -28068,7 +32596,13 @@ type Methods interface {
 		oauth_token_kind OauthToken_Kind_Field,
 		oauth_token_token OauthToken_Token_Field,
 		oauth_token_created_at OauthToken_CreatedAt_Field,
-		oauth_token_expires_at OauthToken_ExpiresAt_Field) (
+		oauth_token_expires_at OauthToken_ExpiresAt_Field,
+		optional OauthToken_Create_Fields) (
+		err error)
+
+	CreateNoReturn_OrganizationMember(ctx context.Context,
+		organization_member_organization_id OrganizationMember_OrganizationId_Field,
+		organization_member_member_id OrganizationMember_MemberId_Field) (
 		err error)
 
 	CreateNoReturn_PeerIdentity(ctx context.Context,
@@ -28077,11 +32611,38 @@ type Methods interface {
 		peer_identity_chain PeerIdentity_Chain_Field) (
 		err error)
 
+	CreateNoReturn_ProjectSpendingCap(ctx context.Context,
+		project_spending_cap_project_id ProjectSpendingCap_ProjectId_Field,
+		project_spending_cap_cap ProjectSpendingCap_Cap_Field,
+		project_spending_cap_alert_thresholds ProjectSpendingCap_AlertThresholds_Field,
+		project_spending_cap_enforce ProjectSpendingCap_Enforce_Field,
+		project_spending_cap_limit_bandwidth ProjectSpendingCap_LimitBandwidth_Field,
+		project_spending_cap_alert_period ProjectSpendingCap_AlertPeriod_Field,
+		project_spending_cap_alerted_threshold ProjectSpendingCap_AlertedThreshold_Field,
+		project_spending_cap_capped ProjectSpendingCap_Capped_Field) (
+		err error)
+
+	CreateNoReturn_ProjectUsageExport(ctx context.Context,
+		project_usage_export_project_id ProjectUsageExport_ProjectId_Field,
+		project_usage_export_encrypted_access ProjectUsageExport_EncryptedAccess_Field,
+		project_usage_export_bucket ProjectUsageExport_Bucket_Field,
+		project_usage_export_prefix ProjectUsageExport_Prefix_Field,
+		project_usage_export_format ProjectUsageExport_Format_Field,
+		project_usage_export_schedule ProjectUsageExport_Schedule_Field,
+		project_usage_export_exported_until ProjectUsageExport_ExportedUntil_Field) (
+		err error)
+
 	CreateNoReturn_Revocation(ctx context.Context,
 		revocation_revoked Revocation_Revoked_Field,
 		revocation_api_key_id Revocation_ApiKeyId_Field) (
 		err error)
 
+	CreateNoReturn_SsoIdentity(ctx context.Context,
+		sso_identity_issuer SsoIdentity_Issuer_Field,
+		sso_identity_subject SsoIdentity_Subject_Field,
+		sso_identity_user_id SsoIdentity_UserId_Field) (
+		err error)
+
 	CreateNoReturn_StoragenodePayment(ctx context.Context,
 		storagenode_payment_node_id StoragenodePayment_NodeId_Field,
 		storagenode_payment_period StoragenodePayment_Period_Field,
@@ -28171,6 +32732,11 @@ type Methods interface {
 		coupon_usage_period CouponUsage_Period_Field) (
 		coupon_usage *CouponUsage, err error)
 
+	Create_Organization(ctx context.Context,
+		organization_id Organization_Id_Field,
+		organization_name Organization_Name_Field) (
+		organization *Organization, err error)
+
 	Create_Project(ctx context.Context,
 		project_id Project_Id_Field,
 		project_name Project_Name_Field,
@@ -28255,6 +32821,10 @@ type Methods interface {
 		webapp_session_expires_at WebappSession_ExpiresAt_Field) (
 		webapp_session *WebappSession, err error)
 
+	Delete_AccessGrant_By_Id(ctx context.Context,
+		access_grant_id AccessGrant_Id_Field) (
+		deleted bool, err error)
+
 	Delete_ApiKey_By_Id(ctx context.Context,
 		api_key_id ApiKey_Id_Field) (
 		deleted bool, err error)
@@ -28291,11 +32861,24 @@ type Methods interface {
 		oauth_client_id OauthClient_Id_Field) (
 		deleted bool, err error)
 
+	Delete_OrganizationMember_By_OrganizationId_And_MemberId(ctx context.Context,
+		organization_member_organization_id OrganizationMember_OrganizationId_Field,
+		organization_member_member_id OrganizationMember_MemberId_Field) (
+		deleted bool, err error)
+
 	Delete_ProjectMember_By_MemberId_And_ProjectId(ctx context.Context,
 		project_member_member_id ProjectMember_MemberId_Field,
 		project_member_project_id ProjectMember_ProjectId_Field) (
 		deleted bool, err error)
 
+	Delete_ProjectSpendingCap_By_ProjectId(ctx context.Context,
+		project_spending_cap_project_id ProjectSpendingCap_ProjectId_Field) (
+		deleted bool, err error)
+
+	Delete_ProjectUsageExport_By_ProjectId(ctx context.Context,
+		project_usage_export_project_id ProjectUsageExport_ProjectId_Field) (
+		deleted bool, err error)
+
 	Delete_Project_By_Id(ctx context.Context,
 		project_id Project_Id_Field) (
 		deleted bool, err error)
@@ -28312,6 +32895,10 @@ type Methods interface {
 		segment_pending_audits_node_id SegmentPendingAudits_NodeId_Field) (
 		deleted bool, err error)
 
+	Delete_SsoIdentity_By_UserId(ctx context.Context,
+		sso_identity_user_id SsoIdentity_UserId_Field) (
+		count int64, err error)
+
 	Delete_StorjscanPayment_By_Status(ctx context.Context,
 		storjscan_payment_status StorjscanPayment_Status_Field) (
 		count int64, err error)
@@ -28336,6 +32923,10 @@ type Methods interface {
 		storjscan_payment_status StorjscanPayment_Status_Field) (
 		row *BlockNumber_Row, err error)
 
+	Get_AccessGrant_By_Id(ctx context.Context,
+		access_grant_id AccessGrant_Id_Field) (
+		access_grant *AccessGrant, err error)
+
 	Get_ApiKey_By_Head(ctx context.Context,
 		api_key_head ApiKey_Head_Field) (
 		api_key *ApiKey, err error)
@@ -28349,6 +32940,15 @@ type Methods interface {
 		api_key_project_id ApiKey_ProjectId_Field) (
 		api_key *ApiKey, err error)
 
+	Get_BillingInvoice_By_Id(ctx context.Context,
+		billing_invoice_id BillingInvoice_Id_Field) (
+		billing_invoice *BillingInvoice, err error)
+
+	Get_BillingInvoice_By_UserId_And_PeriodStart(ctx context.Context,
+		billing_invoice_user_id BillingInvoice_UserId_Field,
+		billing_invoice_period_start BillingInvoice_PeriodStart_Field) (
+		billing_invoice *BillingInvoice, err error)
+
 	Get_BucketMetainfo_By_ProjectId_And_Name(ctx context.Context,
 		bucket_metainfo_project_id BucketMetainfo_ProjectId_Field,
 		bucket_metainfo_name BucketMetainfo_Name_Field) (
@@ -28405,6 +33005,10 @@ type Methods interface {
 		oauth_token_token OauthToken_Token_Field) (
 		oauth_token *OauthToken, err error)
 
+	Get_Organization_By_Id(ctx context.Context,
+		organization_id Organization_Id_Field) (
+		organization *Organization, err error)
+
 	Get_PeerIdentity_By_NodeId(ctx context.Context,
 		peer_identity_node_id PeerIdentity_NodeId_Field) (
 		peer_identity *PeerIdentity, err error)
@@ -28413,13 +33017,21 @@ type Methods interface {
 		peer_identity_node_id PeerIdentity_NodeId_Field) (
 		row *LeafSerialNumber_Row, err error)
 
+	Get_ProjectSpendingCap_By_ProjectId(ctx context.Context,
+		project_spending_cap_project_id ProjectSpendingCap_ProjectId_Field) (
+		project_spending_cap *ProjectSpendingCap, err error)
+
+	Get_ProjectUsageExport_By_ProjectId(ctx context.Context,
+		project_usage_export_project_id ProjectUsageExport_ProjectId_Field) (
+		project_usage_export *ProjectUsageExport, err error)
+
 	Get_Project_BandwidthLimit_By_Id(ctx context.Context,
 		project_id Project_Id_Field) (
 		row *BandwidthLimit_Row, err error)
 
-	Get_Project_BandwidthLimit_Project_UsageLimit_Project_SegmentLimit_By_Id(ctx context.Context,
+	Get_Project_BandwidthLimit_Project_UsageLimit_Project_SegmentLimit_Project_BandwidthRateLimit_Project_BandwidthBurstLimit_By_Id(ctx context.Context,
 		project_id Project_Id_Field) (
-		row *BandwidthLimit_UsageLimit_SegmentLimit_Row, err error)
+		row *BandwidthLimit_UsageLimit_SegmentLimit_BandwidthRateLimit_BandwidthBurstLimit_Row, err error)
 
 	Get_Project_By_Id(ctx context.Context,
 		project_id Project_Id_Field) (
@@ -28465,6 +33077,11 @@ type Methods interface {
 		segment_pending_audits_node_id SegmentPendingAudits_NodeId_Field) (
 		segment_pending_audits *SegmentPendingAudits, err error)
 
+	Get_SsoIdentity_By_Issuer_And_Subject(ctx context.Context,
+		sso_identity_issuer SsoIdentity_Issuer_Field,
+		sso_identity_subject SsoIdentity_Subject_Field) (
+		sso_identity *SsoIdentity, err error)
+
 	Get_StoragenodePaystub_By_NodeId_And_Period(ctx context.Context,
 		storagenode_paystub_node_id StoragenodePaystub_NodeId_Field,
 		storagenode_paystub_period StoragenodePaystub_Period_Field) (
@@ -28527,6 +33144,11 @@ type Methods interface {
 		node_api_version_api_version_greater_or_equal NodeApiVersion_ApiVersion_Field) (
 		has bool, err error)
 
+	Has_OrganizationMember_By_OrganizationId_And_MemberId(ctx context.Context,
+		organization_member_organization_id OrganizationMember_OrganizationId_Field,
+		organization_member_member_id OrganizationMember_MemberId_Field) (
+		has bool, err error)
+
 	Limited_BucketMetainfo_By_ProjectId_And_Name_GreaterOrEqual_OrderBy_Asc_Name(ctx context.Context,
 		bucket_metainfo_project_id BucketMetainfo_ProjectId_Field,
 		bucket_metainfo_name_greater_or_equal BucketMetainfo_Name_Field,
@@ -28653,6 +33275,11 @@ type Methods interface {
 		update ApiKey_Update_Fields) (
 		err error)
 
+	UpdateNoReturn_BillingInvoice_By_Id(ctx context.Context,
+		billing_invoice_id BillingInvoice_Id_Field,
+		update BillingInvoice_Update_Fields) (
+		err error)
+
 	UpdateNoReturn_GracefulExitSegmentTransfer_By_NodeId_And_StreamId_And_Position_And_PieceNum(ctx context.Context,
 		graceful_exit_segment_transfer_node_id GracefulExitSegmentTransfer_NodeId_Field,
 		graceful_exit_segment_transfer_stream_id GracefulExitSegmentTransfer_StreamId_Field,
@@ -28698,6 +33325,16 @@ type Methods interface {
 		update PeerIdentity_Update_Fields) (
 		err error)
 
+	UpdateNoReturn_ProjectSpendingCap_By_ProjectId(ctx context.Context,
+		project_spending_cap_project_id ProjectSpendingCap_ProjectId_Field,
+		update ProjectSpendingCap_Update_Fields) (
+		err error)
+
+	UpdateNoReturn_ProjectUsageExport_By_ProjectId(ctx context.Context,
+		project_usage_export_project_id ProjectUsageExport_ProjectId_Field,
+		update ProjectUsageExport_Update_Fields) (
+		err error)
+
 	UpdateNoReturn_Reputation_By_Id(ctx context.Context,
 		reputation_id Reputation_Id_Field,
 		update Reputation_Update_Fields) (
@@ -28730,6 +33367,11 @@ type Methods interface {
 		update Node_Update_Fields) (
 		node *Node, err error)
 
+	Update_Organization_By_Id(ctx context.Context,
+		organization_id Organization_Id_Field,
+		update Organization_Update_Fields) (
+		organization *Organization, err error)
+
 	Update_Project_By_Id(ctx context.Context,
 		project_id Project_Id_Field,
 		update Project_Update_Fields) (
@@ -0,0 +1,171 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitedb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/payments/invoicing"
+	"storj.io/storj/satellite/satellitedb/dbx"
+)
+
+// ensures that *invoicingDB implements invoicing.DB.
+var _ invoicing.DB = (*invoicingDB)(nil)
+
+// invoicingDB stores the invoices of the built-in invoicing.
+//
+// architecture: Database
+type invoicingDB struct {
+	db *satelliteDB
+}
+
+// Insert inserts the invoice.
+func (db *invoicingDB) Insert(ctx context.Context, invoice invoicing.Invoice) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	items, err := json.Marshal(invoice.Items)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	return Error.Wrap(db.db.CreateNoReturn_BillingInvoice(ctx,
+		dbx.BillingInvoice_Id(invoice.ID.Bytes()),
+		dbx.BillingInvoice_UserId(invoice.UserID.Bytes()),
+		dbx.BillingInvoice_PeriodStart(invoice.PeriodStart),
+		dbx.BillingInvoice_PeriodEnd(invoice.PeriodEnd),
+		dbx.BillingInvoice_Description(invoice.Description),
+		dbx.BillingInvoice_Items(items),
+		dbx.BillingInvoice_Amount(invoice.Amount),
+		dbx.BillingInvoice_Status(string(invoice.Status)),
+		dbx.BillingInvoice_Create_Fields{
+			PaidAt: dbx.BillingInvoice_PaidAt_Raw(invoice.PaidAt),
+		},
+	))
+}
+
+// Get returns the invoice with the ID.
+func (db *invoicingDB) Get(ctx context.Context, id uuid.UUID) (_ invoicing.Invoice, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	dbxInvoice, err := db.db.Get_BillingInvoice_By_Id(ctx, dbx.BillingInvoice_Id(id.Bytes()))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return invoicing.Invoice{}, invoicing.ErrInvoiceNotFound.New("%s", id)
+		}
+		return invoicing.Invoice{}, Error.Wrap(err)
+	}
+
+	return invoiceFromDBX(dbxInvoice)
+}
+
+// GetByPeriod returns the invoice of the payment account for the period starting at periodStart.
+func (db *invoicingDB) GetByPeriod(ctx context.Context, userID uuid.UUID, periodStart time.Time) (_ invoicing.Invoice, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	dbxInvoice, err := db.db.Get_BillingInvoice_By_UserId_And_PeriodStart(ctx,
+		dbx.BillingInvoice_UserId(userID.Bytes()),
+		dbx.BillingInvoice_PeriodStart(periodStart))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return invoicing.Invoice{}, invoicing.ErrInvoiceNotFound.New("%s for %s", userID, periodStart.Format("2006-01"))
+		}
+		return invoicing.Invoice{}, Error.Wrap(err)
+	}
+
+	return invoiceFromDBX(dbxInvoice)
+}
+
+// ListByUserID returns the invoices of the payment account, the latest period first.
+func (db *invoicingDB) ListByUserID(ctx context.Context, userID uuid.UUID) (_ []invoicing.Invoice, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	dbxInvoices, err := db.db.All_BillingInvoice_By_UserId_OrderBy_Desc_PeriodStart(ctx, dbx.BillingInvoice_UserId(userID.Bytes()))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return invoicesFromDBX(dbxInvoices)
+}
+
+// ListByStatus returns the invoices with the status, the earliest period first.
+func (db *invoicingDB) ListByStatus(ctx context.Context, status invoicing.Status) (_ []invoicing.Invoice, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	dbxInvoices, err := db.db.All_BillingInvoice_By_Status_OrderBy_Asc_PeriodStart(ctx, dbx.BillingInvoice_Status(string(status)))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return invoicesFromDBX(dbxInvoices)
+}
+
+// UpdateStatus sets the status of the invoice and the time it was paid at.
+func (db *invoicingDB) UpdateStatus(ctx context.Context, id uuid.UUID, status invoicing.Status, paidAt *time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return Error.Wrap(db.db.UpdateNoReturn_BillingInvoice_By_Id(ctx,
+		dbx.BillingInvoice_Id(id.Bytes()),
+		dbx.BillingInvoice_Update_Fields{
+			Status: dbx.BillingInvoice_Status(string(status)),
+			PaidAt: dbx.BillingInvoice_PaidAt_Raw(paidAt),
+		},
+	))
+}
+
+// invoicesFromDBX converts a slice of *dbx.BillingInvoice to invoices.
+func invoicesFromDBX(dbxInvoices []*dbx.BillingInvoice) (_ []invoicing.Invoice, err error) {
+	invoices := make([]invoicing.Invoice, 0, len(dbxInvoices))
+	for _, dbxInvoice := range dbxInvoices {
+		invoice, err := invoiceFromDBX(dbxInvoice)
+		if err != nil {
+			return nil, err
+		}
+		invoices = append(invoices, invoice)
+	}
+	return invoices, nil
+}
+
+// invoiceFromDBX converts *dbx.BillingInvoice to an invoice.
+func invoiceFromDBX(dbxInvoice *dbx.BillingInvoice) (_ invoicing.Invoice, err error) {
+	id, err := uuid.FromBytes(dbxInvoice.Id)
+	if err != nil {
+		return invoicing.Invoice{}, errs.Wrap(err)
+	}
+
+	userID, err := uuid.FromBytes(dbxInvoice.UserId)
+	if err != nil {
+		return invoicing.Invoice{}, errs.Wrap(err)
+	}
+
+	var items []invoicing.Item
+	if err = json.Unmarshal(dbxInvoice.Items, &items); err != nil {
+		return invoicing.Invoice{}, errs.Wrap(err)
+	}
+
+	var paidAt *time.Time
+	if dbxInvoice.PaidAt != nil {
+		utc := dbxInvoice.PaidAt.UTC()
+		paidAt = &utc
+	}
+
+	return invoicing.Invoice{
+		ID:          id,
+		UserID:      userID,
+		PeriodStart: dbxInvoice.PeriodStart.UTC(),
+		PeriodEnd:   dbxInvoice.PeriodEnd.UTC(),
+		Description: dbxInvoice.Description,
+		Items:       items,
+		Amount:      dbxInvoice.Amount,
+		Status:      invoicing.Status(dbxInvoice.Status),
+		CreatedAt:   dbxInvoice.CreatedAt,
+		PaidAt:      paidAt,
+	}, nil
+}
@@ -1983,6 +1983,183 @@ func (db *satelliteDB) PostgresMigration() *migrate.Migration {
 					`CREATE INDEX IF NOT EXISTS projects_public_id_index ON projects ( public_id );`,
 				},
 			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add projects.bandwidth_rate_limit and projects.bandwidth_burst_limit",
+				Version:     202,
+				Action: migrate.SQL{
+					`ALTER TABLE projects ADD COLUMN bandwidth_rate_limit bigint;`,
+					`ALTER TABLE projects ADD COLUMN bandwidth_burst_limit bigint;`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add oauth_tokens.name and oauth_tokens.last_used_at",
+				Version:     203,
+				Action: migrate.SQL{
+					`ALTER TABLE oauth_tokens ADD COLUMN name text;`,
+					`ALTER TABLE oauth_tokens ADD COLUMN last_used_at timestamp with time zone;`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add sso_identities table",
+				Version:     204,
+				Action: migrate.SQL{
+					`CREATE TABLE sso_identities (
+						issuer text NOT NULL,
+						subject text NOT NULL,
+						user_id bytea NOT NULL,
+						created_at timestamp with time zone NOT NULL,
+						PRIMARY KEY ( issuer, subject )
+					);`,
+					`CREATE INDEX sso_identities_user_id_index ON sso_identities ( user_id );`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add webapp_sessions.created_at and webapp_sessions.last_seen_at",
+				Version:     205,
+				Action: migrate.SQL{
+					`ALTER TABLE webapp_sessions ADD COLUMN created_at timestamp with time zone NOT NULL DEFAULT current_timestamp;`,
+					`ALTER TABLE webapp_sessions ADD COLUMN last_seen_at timestamp with time zone NOT NULL DEFAULT current_timestamp;`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add organizations and organization_members tables and projects.organization_id",
+				Version:     206,
+				Action: migrate.SQL{
+					`CREATE TABLE organizations (
+						id bytea NOT NULL,
+						name text NOT NULL,
+						created_at timestamp with time zone NOT NULL,
+						PRIMARY KEY ( id )
+					);`,
+					`CREATE TABLE organization_members (
+						organization_id bytea NOT NULL REFERENCES organizations( id ) ON DELETE CASCADE,
+						member_id bytea NOT NULL REFERENCES users( id ) ON DELETE CASCADE,
+						created_at timestamp with time zone NOT NULL,
+						PRIMARY KEY ( organization_id, member_id )
+					);`,
+					`CREATE INDEX organization_members_member_id_index ON organization_members ( member_id );`,
+					`ALTER TABLE projects ADD COLUMN organization_id bytea;`,
+					`CREATE INDEX projects_organization_id_index ON projects ( organization_id );`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add project_spending_caps table",
+				Version:     207,
+				Action: migrate.SQL{
+					`CREATE TABLE project_spending_caps (
+						project_id bytea NOT NULL REFERENCES projects( id ) ON DELETE CASCADE,
+						cap bigint NOT NULL,
+						alert_thresholds text NOT NULL,
+						enforce boolean NOT NULL,
+						limit_bandwidth boolean NOT NULL,
+						alert_period timestamp with time zone NOT NULL,
+						alerted_threshold integer NOT NULL,
+						capped boolean NOT NULL,
+						created_at timestamp with time zone NOT NULL,
+						PRIMARY KEY ( project_id )
+					);`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add access_grants table",
+				Version:     208,
+				Action: migrate.SQL{
+					`CREATE TABLE access_grants (
+						id bytea NOT NULL,
+						project_id bytea NOT NULL REFERENCES projects( id ) ON DELETE CASCADE,
+						api_key_id bytea NOT NULL REFERENCES api_keys( id ) ON DELETE CASCADE,
+						name text NOT NULL,
+						caveats text NOT NULL,
+						tails bytea NOT NULL,
+						created_by bytea NOT NULL,
+						created_at timestamp with time zone NOT NULL,
+						PRIMARY KEY ( id )
+					);`,
+					`CREATE INDEX access_grants_project_id_index ON access_grants ( project_id );`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add project_usage_exports table",
+				Version:     209,
+				Action: migrate.SQL{
+					`CREATE TABLE project_usage_exports (
+						project_id bytea NOT NULL REFERENCES projects( id ) ON DELETE CASCADE,
+						encrypted_access bytea NOT NULL,
+						bucket text NOT NULL,
+						prefix text NOT NULL,
+						format text NOT NULL,
+						schedule text NOT NULL,
+						exported_until timestamp with time zone NOT NULL,
+						created_at timestamp with time zone NOT NULL,
+						PRIMARY KEY ( project_id )
+					);`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add billing_invoices table",
+				Version:     210,
+				Action: migrate.SQL{
+					`CREATE TABLE billing_invoices (
+						id bytea NOT NULL,
+						user_id bytea NOT NULL,
+						period_start timestamp with time zone NOT NULL,
+						period_end timestamp with time zone NOT NULL,
+						description text NOT NULL,
+						items bytea NOT NULL,
+						amount bigint NOT NULL,
+						status text NOT NULL,
+						created_at timestamp with time zone NOT NULL,
+						paid_at timestamp with time zone,
+						PRIMARY KEY ( id ),
+						UNIQUE ( user_id, period_start )
+					);`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add node_maintenance_windows table",
+				Version:     211,
+				Action: migrate.SQL{
+					`CREATE TABLE node_maintenance_windows (
+						node_id bytea NOT NULL,
+						start_time timestamp with time zone NOT NULL,
+						end_time timestamp with time zone NOT NULL,
+						created_at timestamp with time zone NOT NULL,
+						PRIMARY KEY ( node_id, start_time )
+					);`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add node_audit_queue table",
+				Version:     212,
+				Action: migrate.SQL{
+					`CREATE TABLE node_audit_queue (
+						node_id bytea NOT NULL,
+						pieces integer NOT NULL,
+						requested_at timestamp with time zone NOT NULL,
+						PRIMARY KEY ( node_id )
+					);`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "replace the oauth_tokens user_id index with a user_id and kind index",
+				Version:     213,
+				Action: migrate.SQL{
+					`CREATE INDEX oauth_tokens_user_id_kind_index ON oauth_tokens ( user_id, kind ) ;`,
+					`DROP INDEX oauth_tokens_user_id_index;`,
+				},
+			},
 			// NB: after updating testdata in `testdata`, run
 			//     `go generate` to update `migratez.go`.
 		},
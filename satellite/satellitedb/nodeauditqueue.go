@@ -0,0 +1,69 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitedb
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/storj"
+	"storj.io/storj/satellite/audit"
+)
+
+type nodeAuditQueue struct {
+	db *satelliteDB
+}
+
+// Enqueue queues the node for auditing the given number of pieces.
+func (queue *nodeAuditQueue) Enqueue(ctx context.Context, nodeID storj.NodeID, pieces int) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = queue.db.ExecContext(ctx, `
+		INSERT INTO node_audit_queue (node_id, pieces, requested_at)
+		VALUES ($1, $2, now())
+		ON CONFLICT (node_id) DO UPDATE
+		SET pieces = EXCLUDED.pieces, requested_at = EXCLUDED.requested_at
+	`, nodeID, pieces)
+	return errs.Wrap(err)
+}
+
+// Select returns at most limit requests, the oldest first.
+func (queue *nodeAuditQueue) Select(ctx context.Context, limit int) (_ []audit.NodeAuditRequest, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rows, err := queue.db.QueryContext(ctx, `
+		SELECT node_id, pieces, requested_at
+		FROM node_audit_queue
+		ORDER BY requested_at
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var requests []audit.NodeAuditRequest
+	for rows.Next() {
+		var request audit.NodeAuditRequest
+		if err := rows.Scan(&request.NodeID, &request.Pieces, &request.RequestedAt); err != nil {
+			return nil, errs.Wrap(err)
+		}
+		requests = append(requests, request)
+	}
+	return requests, errs.Wrap(rows.Err())
+}
+
+// Delete removes the request from the queue, unless the node was requeued
+// since the request was selected.
+func (queue *nodeAuditQueue) Delete(ctx context.Context, request audit.NodeAuditRequest) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = queue.db.ExecContext(ctx, `
+		DELETE FROM node_audit_queue
+		WHERE node_id = $1 AND requested_at = $2
+	`, request.NodeID, request.RequestedAt.UTC().Truncate(time.Microsecond))
+	return errs.Wrap(err)
+}
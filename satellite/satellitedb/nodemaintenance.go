@@ -0,0 +1,130 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitedb
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/storj"
+	"storj.io/private/dbutil/pgutil"
+	"storj.io/storj/private/nodemaintenance"
+)
+
+// notInMaintenanceCondition is a node selection condition excluding the nodes
+// in a maintenance window at the given time.
+const notInMaintenanceCondition = `NOT EXISTS (
+	SELECT 1 FROM node_maintenance_windows
+	WHERE node_maintenance_windows.node_id = nodes.id
+	AND start_time <= ? AND end_time > ?
+)`
+
+// GetMaintenanceWindows returns the maintenance windows of the node ending
+// after since, ordered by their start.
+func (cache *overlaycache) GetMaintenanceWindows(ctx context.Context, nodeID storj.NodeID, since time.Time) (windows []nodemaintenance.Window, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rows, err := cache.db.Query(ctx, cache.db.Rebind(`
+		SELECT start_time, end_time FROM node_maintenance_windows
+		WHERE node_id = ? AND end_time > ?
+		ORDER BY start_time
+	`), nodeID.Bytes(), since)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	for rows.Next() {
+		var window nodemaintenance.Window
+		if err := rows.Scan(&window.Start, &window.End); err != nil {
+			return nil, Error.Wrap(err)
+		}
+		window.Start, window.End = window.Start.UTC(), window.End.UTC()
+		windows = append(windows, window)
+	}
+	return windows, Error.Wrap(rows.Err())
+}
+
+// SetMaintenanceWindow inserts the maintenance window of the node or updates
+// the end of the window starting at the same time.
+func (cache *overlaycache) SetMaintenanceWindow(ctx context.Context, nodeID storj.NodeID, window nodemaintenance.Window) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = cache.db.ExecContext(ctx, cache.db.Rebind(`
+		INSERT INTO node_maintenance_windows ( node_id, start_time, end_time, created_at )
+		VALUES ( ?, ?, ?, ? )
+		ON CONFLICT ( node_id, start_time ) DO UPDATE SET end_time = EXCLUDED.end_time
+	`), nodeID.Bytes(), window.Start, window.End, time.Now())
+	return Error.Wrap(err)
+}
+
+// DeleteMaintenanceWindow deletes the maintenance window of the node starting
+// at start.
+func (cache *overlaycache) DeleteMaintenanceWindow(ctx context.Context, nodeID storj.NodeID, start time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = cache.db.ExecContext(ctx, cache.db.Rebind(`
+		DELETE FROM node_maintenance_windows
+		WHERE node_id = ? AND start_time = ?
+	`), nodeID.Bytes(), start)
+	return Error.Wrap(err)
+}
+
+// GetNodesInMaintenance returns the nodes of nodeIDs which are in a maintenance
+// window at the given time.
+func (cache *overlaycache) GetNodesInMaintenance(ctx context.Context, nodeIDs storj.NodeIDList, at time.Time) (inMaintenance storj.NodeIDList, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if len(nodeIDs) == 0 {
+		return nil, nil
+	}
+
+	rows, err := cache.db.Query(ctx, cache.db.Rebind(`
+		SELECT DISTINCT node_id FROM node_maintenance_windows
+		WHERE node_id = ANY(?::bytea[])
+		AND start_time <= ? AND end_time > ?
+	`), pgutil.NodeIDArray(nodeIDs), at, at)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	for rows.Next() {
+		var id storj.NodeID
+		if err := rows.Scan(&id); err != nil {
+			return nil, Error.Wrap(err)
+		}
+		inMaintenance = append(inMaintenance, id)
+	}
+	return inMaintenance, Error.Wrap(rows.Err())
+}
+
+// GetActiveMaintenanceWindows returns the maintenance windows of all nodes
+// ending after since.
+func (cache *overlaycache) GetActiveMaintenanceWindows(ctx context.Context, since time.Time) (windows map[storj.NodeID][]nodemaintenance.Window, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rows, err := cache.db.Query(ctx, cache.db.Rebind(`
+		SELECT node_id, start_time, end_time FROM node_maintenance_windows
+		WHERE end_time > ?
+	`), since)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	windows = make(map[storj.NodeID][]nodemaintenance.Window)
+	for rows.Next() {
+		var id storj.NodeID
+		var window nodemaintenance.Window
+		if err := rows.Scan(&id, &window.Start, &window.End); err != nil {
+			return nil, Error.Wrap(err)
+		}
+		window.Start, window.End = window.Start.UTC(), window.End.UTC()
+		windows[id] = append(windows[id], window)
+	}
+	return windows, Error.Wrap(rows.Err())
+}
@@ -0,0 +1,197 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitedb
+
+import (
+	"context"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/satellitedb/dbx"
+)
+
+// ensures that *organizations implements console.Organizations.
+var _ console.Organizations = (*organizations)(nil)
+
+type organizations struct {
+	methods dbx.Methods
+	db      *satelliteDB
+}
+
+// Insert inserts the organization.
+func (db *organizations) Insert(ctx context.Context, organization *console.Organization) (_ *console.Organization, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	dbxOrganization, err := db.methods.Create_Organization(ctx,
+		dbx.Organization_Id(organization.ID.Bytes()),
+		dbx.Organization_Name(organization.Name),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return organizationFromDBX(dbxOrganization)
+}
+
+// Get returns the organization with the ID.
+func (db *organizations) Get(ctx context.Context, id uuid.UUID) (_ *console.Organization, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	dbxOrganization, err := db.methods.Get_Organization_By_Id(ctx, dbx.Organization_Id(id.Bytes()))
+	if err != nil {
+		return nil, err
+	}
+
+	return organizationFromDBX(dbxOrganization)
+}
+
+// GetByMemberID returns the organizations the user is a member of.
+func (db *organizations) GetByMemberID(ctx context.Context, memberID uuid.UUID) (_ []console.Organization, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	dbxOrganizations, err := db.methods.All_Organization_By_OrganizationMember_MemberId_OrderBy_Asc_Organization_Name(ctx,
+		dbx.OrganizationMember_MemberId(memberID.Bytes()),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	organizations := make([]console.Organization, 0, len(dbxOrganizations))
+	for _, dbxOrganization := range dbxOrganizations {
+		organization, err := organizationFromDBX(dbxOrganization)
+		if err != nil {
+			return nil, err
+		}
+		organizations = append(organizations, *organization)
+	}
+
+	return organizations, nil
+}
+
+// UpdateName renames the organization.
+func (db *organizations) UpdateName(ctx context.Context, id uuid.UUID, name string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = db.methods.Update_Organization_By_Id(ctx,
+		dbx.Organization_Id(id.Bytes()),
+		dbx.Organization_Update_Fields{
+			Name: dbx.Organization_Name(name),
+		})
+
+	return err
+}
+
+// InsertMember adds the user to the members of the organization.
+func (db *organizations) InsertMember(ctx context.Context, organizationID, memberID uuid.UUID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return db.methods.CreateNoReturn_OrganizationMember(ctx,
+		dbx.OrganizationMember_OrganizationId(organizationID.Bytes()),
+		dbx.OrganizationMember_MemberId(memberID.Bytes()),
+	)
+}
+
+// DeleteMember removes the user from the members of the organization.
+func (db *organizations) DeleteMember(ctx context.Context, organizationID, memberID uuid.UUID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = db.methods.Delete_OrganizationMember_By_OrganizationId_And_MemberId(ctx,
+		dbx.OrganizationMember_OrganizationId(organizationID.Bytes()),
+		dbx.OrganizationMember_MemberId(memberID.Bytes()),
+	)
+
+	return err
+}
+
+// GetMembers returns the members of the organization.
+func (db *organizations) GetMembers(ctx context.Context, organizationID uuid.UUID) (_ []console.OrganizationMember, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	dbxMembers, err := db.methods.All_OrganizationMember_By_OrganizationId_OrderBy_Asc_CreatedAt(ctx,
+		dbx.OrganizationMember_OrganizationId(organizationID.Bytes()),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]console.OrganizationMember, 0, len(dbxMembers))
+	for _, dbxMember := range dbxMembers {
+		organizationID, err := uuid.FromBytes(dbxMember.OrganizationId)
+		if err != nil {
+			return nil, err
+		}
+
+		memberID, err := uuid.FromBytes(dbxMember.MemberId)
+		if err != nil {
+			return nil, err
+		}
+
+		members = append(members, console.OrganizationMember{
+			OrganizationID: organizationID,
+			MemberID:       memberID,
+			CreatedAt:      dbxMember.CreatedAt,
+		})
+	}
+
+	return members, nil
+}
+
+// IsMember returns whether the user is a member of the organization.
+func (db *organizations) IsMember(ctx context.Context, organizationID, memberID uuid.UUID) (_ bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return db.methods.Has_OrganizationMember_By_OrganizationId_And_MemberId(ctx,
+		dbx.OrganizationMember_OrganizationId(organizationID.Bytes()),
+		dbx.OrganizationMember_MemberId(memberID.Bytes()),
+	)
+}
+
+// organizationFromDBX is used for creating Organization entity from autogenerated dbx.Organization struct.
+func organizationFromDBX(organization *dbx.Organization) (*console.Organization, error) {
+	if organization == nil {
+		return nil, errs.New("organization parameter is nil")
+	}
+
+	id, err := uuid.FromBytes(organization.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &console.Organization{
+		ID:        id,
+		Name:      organization.Name,
+		CreatedAt: organization.CreatedAt,
+	}, nil
+}
+
+// GetMemberInfos returns the members of the organization together with their
+// user details, ordered by the time they joined.
+func (db *organizations) GetMemberInfos(ctx context.Context, organizationID uuid.UUID) (_ []console.OrganizationMemberInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rows, err := db.db.QueryContext(ctx, db.db.Rebind(`
+		SELECT u.id, u.full_name, u.email, om.created_at
+		FROM organization_members om
+		INNER JOIN users u ON om.member_id = u.id
+		WHERE om.organization_id = ?
+		ORDER BY om.created_at ASC
+	`), organizationID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var infos []console.OrganizationMemberInfo
+	for rows.Next() {
+		var info console.OrganizationMemberInfo
+		if err := rows.Scan(&info.ID, &info.FullName, &info.Email, &info.JoinedAt); err != nil {
+			return nil, err
+		}
+		infos = append(infos, info)
+	}
+
+	return infos, rows.Err()
+}
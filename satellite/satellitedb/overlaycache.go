@@ -57,7 +57,7 @@ func (cache *overlaycache) selectAllStorageNodesUpload(ctx context.Context, sele
 	defer mon.Task()(&ctx)(&err)
 
 	query := `
-		SELECT id, address, last_net, last_ip_port, vetted_at, country_code
+		SELECT id, address, last_net, last_ip_port, vetted_at, country_code, wallet
 			FROM nodes
 			` + cache.db.impl.AsOfSystemInterval(selectionCfg.AsOfSystemTime.Interval()) + `
 			WHERE disqualified IS NULL
@@ -67,23 +67,31 @@ func (cache *overlaycache) selectAllStorageNodesUpload(ctx context.Context, sele
 			AND type = $1
 			AND free_disk >= $2
 			AND last_contact_success > $3
+			AND NOT EXISTS (
+				SELECT 1 FROM node_maintenance_windows
+				WHERE node_maintenance_windows.node_id = nodes.id
+				AND start_time <= $4 AND end_time > $4
+			)
 	`
+	now := time.Now()
 	args := []interface{}{
 		// $1
 		int(pb.NodeType_STORAGE),
 		// $2
 		selectionCfg.MinimumDiskSpace.Int64(),
 		// $3
-		time.Now().Add(-selectionCfg.OnlineWindow),
+		now.Add(-selectionCfg.OnlineWindow),
+		// $4
+		now,
 	}
 	if selectionCfg.MinimumVersion != "" {
 		version, err := version.NewSemVer(selectionCfg.MinimumVersion)
 		if err != nil {
 			return nil, nil, err
 		}
-		query += `AND (major > $4 OR (major = $5 AND (minor > $6 OR (minor = $7 AND patch >= $8)))) AND release`
+		query += `AND (major > $5 OR (major = $6 AND (minor > $7 OR (minor = $8 AND patch >= $9)))) AND release`
 		args = append(args,
-			// $4 - $8
+			// $5 - $9
 			version.Major, version.Major, version.Minor, version.Minor, version.Patch,
 		)
 	}
@@ -101,7 +109,7 @@ func (cache *overlaycache) selectAllStorageNodesUpload(ctx context.Context, sele
 		node.Address = &pb.NodeAddress{}
 		var lastIPPort sql.NullString
 		var vettedAt *time.Time
-		err = rows.Scan(&node.ID, &node.Address.Address, &node.LastNet, &lastIPPort, &vettedAt, &node.CountryCode)
+		err = rows.Scan(&node.ID, &node.Address.Address, &node.LastNet, &lastIPPort, &vettedAt, &node.CountryCode, &node.Wallet)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -139,7 +147,7 @@ func (cache *overlaycache) selectAllStorageNodesDownload(ctx context.Context, on
 	defer mon.Task()(&ctx)(&err)
 
 	query := `
-		SELECT id, address, last_net, last_ip_port
+		SELECT id, address, last_net, last_ip_port, country_code, wallet
 			FROM nodes
 			` + cache.db.impl.AsOfSystemInterval(asOfConfig.Interval()) + `
 			WHERE disqualified IS NULL
@@ -162,7 +170,7 @@ func (cache *overlaycache) selectAllStorageNodesDownload(ctx context.Context, on
 		var node overlay.SelectedNode
 		node.Address = &pb.NodeAddress{}
 		var lastIPPort sql.NullString
-		err = rows.Scan(&node.ID, &node.Address.Address, &node.LastNet, &lastIPPort)
+		err = rows.Scan(&node.ID, &node.Address.Address, &node.LastNet, &lastIPPort, &node.CountryCode, &node.Wallet)
 		if err != nil {
 			return nil, err
 		}
@@ -392,6 +392,34 @@ func (db *ProjectAccounting) UpdateProjectBandwidthLimit(ctx context.Context, pr
 	return err
 }
 
+// UpdateProjectBandwidthRateLimit updates the number of bytes per second a project can allocate.
+func (db *ProjectAccounting) UpdateProjectBandwidthRateLimit(ctx context.Context, projectID uuid.UUID, limit memory.Size) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = db.db.Update_Project_By_Id(ctx,
+		dbx.Project_Id(projectID[:]),
+		dbx.Project_Update_Fields{
+			BandwidthRateLimit: dbx.Project_BandwidthRateLimit(limit.Int64()),
+		},
+	)
+
+	return err
+}
+
+// UpdateProjectBandwidthBurstLimit updates the number of bytes a project can allocate at once.
+func (db *ProjectAccounting) UpdateProjectBandwidthBurstLimit(ctx context.Context, projectID uuid.UUID, limit memory.Size) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = db.db.Update_Project_By_Id(ctx,
+		dbx.Project_Id(projectID[:]),
+		dbx.Project_Update_Fields{
+			BandwidthBurstLimit: dbx.Project_BandwidthBurstLimit(limit.Int64()),
+		},
+	)
+
+	return err
+}
+
 // UpdateProjectSegmentLimit updates project segment limit.
 func (db *ProjectAccounting) UpdateProjectSegmentLimit(ctx context.Context, projectID uuid.UUID, limit int64) (err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -1036,18 +1064,45 @@ func timeTruncateDown(t time.Time) time.Time {
 func (db *ProjectAccounting) GetProjectLimits(ctx context.Context, projectID uuid.UUID) (_ accounting.ProjectLimits, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	row, err := db.db.Get_Project_BandwidthLimit_Project_UsageLimit_Project_SegmentLimit_By_Id(ctx,
+	row, err := db.db.Get_Project_BandwidthLimit_Project_UsageLimit_Project_SegmentLimit_Project_BandwidthRateLimit_Project_BandwidthBurstLimit_By_Id(ctx,
 		dbx.Project_Id(projectID[:]),
 	)
 	if err != nil {
 		return accounting.ProjectLimits{}, err
 	}
 
-	return accounting.ProjectLimits{
+	limits := accounting.ProjectLimits{
 		Usage:     row.UsageLimit,
 		Bandwidth: row.BandwidthLimit,
 		Segments:  row.SegmentLimit,
-	}, nil
+
+		BandwidthRate:  row.BandwidthRateLimit,
+		BandwidthBurst: row.BandwidthBurstLimit,
+	}
+
+	spendingCap, err := db.db.Get_ProjectSpendingCap_By_ProjectId(ctx,
+		dbx.ProjectSpendingCap_ProjectId(projectID[:]),
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return limits, nil
+		}
+		return accounting.ProjectLimits{}, err
+	}
+
+	// a project which reached its spending cap can't upload anymore until the
+	// next month or until the cap is raised. Downloads are only paused when
+	// the owner opted in, so the stored data stays reachable by default.
+	if spendingCap.Capped {
+		var zero int64
+		limits.Usage = &zero
+		limits.Segments = &zero
+		if spendingCap.LimitBandwidth {
+			limits.Bandwidth = &zero
+		}
+	}
+
+	return limits, nil
 }
 
 // GetRollupsSince retrieves all archived rollup records since a given time.
@@ -49,6 +49,18 @@ func (projects *projects) GetOwn(ctx context.Context, userID uuid.UUID) (_ []con
 	return projectsFromDbxSlice(ctx, projectsDbx)
 }
 
+// GetByOrganizationID is a method for querying all projects owned by the organization from the database.
+func (projects *projects) GetByOrganizationID(ctx context.Context, organizationID uuid.UUID) (_ []console.Project, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	projectsDbx, err := projects.db.All_Project_By_OrganizationId_OrderBy_Asc_CreatedAt(ctx, dbx.Project_OrganizationId(organizationID[:]))
+	if err != nil {
+		return nil, err
+	}
+
+	return projectsFromDbxSlice(ctx, projectsDbx)
+}
+
 // GetCreatedBefore retrieves all projects created before provided date.
 func (projects *projects) GetCreatedBefore(ctx context.Context, before time.Time) (_ []console.Project, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -210,6 +222,19 @@ func (projects *projects) UpdateBucketLimit(ctx context.Context, id uuid.UUID, n
 	return err
 }
 
+// UpdateOrganization is a method for transferring the project to an organization.
+func (projects *projects) UpdateOrganization(ctx context.Context, id uuid.UUID, organizationID uuid.UUID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = projects.db.Update_Project_By_Id(ctx,
+		dbx.Project_Id(id[:]),
+		dbx.Project_Update_Fields{
+			OrganizationId: dbx.Project_OrganizationId(organizationID[:]),
+		})
+
+	return err
+}
+
 // List returns paginated projects, created before provided timestamp.
 func (projects *projects) List(ctx context.Context, offset int64, limit int, before time.Time) (_ console.ProjectsPage, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -347,6 +372,14 @@ func projectFromDBX(ctx context.Context, project *dbx.Project) (_ *console.Proje
 		return nil, err
 	}
 
+	var organizationID uuid.UUID
+	if len(project.OrganizationId) > 0 {
+		organizationID, err = uuid.FromBytes(project.OrganizationId)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &console.Project{
 		ID:             id,
 		Name:           project.Name,
@@ -354,6 +387,7 @@ func projectFromDBX(ctx context.Context, project *dbx.Project) (_ *console.Proje
 		PartnerID:      partnerID,
 		UserAgent:      userAgent,
 		OwnerID:        ownerID,
+		OrganizationID: organizationID,
 		RateLimit:      project.RateLimit,
 		BurstLimit:     project.BurstLimit,
 		MaxBuckets:     project.MaxBuckets,
@@ -0,0 +1,148 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitedb
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/satellitedb/dbx"
+)
+
+// ensures that *projectSpendingCaps implements console.ProjectSpendingCaps.
+var _ console.ProjectSpendingCaps = (*projectSpendingCaps)(nil)
+
+type projectSpendingCaps struct {
+	db dbx.Methods
+}
+
+// Insert inserts the spending cap of a project.
+func (db *projectSpendingCaps) Insert(ctx context.Context, spendingCap console.ProjectSpendingCap) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return db.db.CreateNoReturn_ProjectSpendingCap(ctx,
+		dbx.ProjectSpendingCap_ProjectId(spendingCap.ProjectID.Bytes()),
+		dbx.ProjectSpendingCap_Cap(spendingCap.Cap),
+		dbx.ProjectSpendingCap_AlertThresholds(formatAlertThresholds(spendingCap.AlertThresholds)),
+		dbx.ProjectSpendingCap_Enforce(spendingCap.Enforce),
+		dbx.ProjectSpendingCap_LimitBandwidth(spendingCap.LimitBandwidth),
+		dbx.ProjectSpendingCap_AlertPeriod(spendingCap.AlertPeriod),
+		dbx.ProjectSpendingCap_AlertedThreshold(spendingCap.AlertedThreshold),
+		dbx.ProjectSpendingCap_Capped(spendingCap.Capped),
+	)
+}
+
+// Get returns the spending cap of the project.
+func (db *projectSpendingCaps) Get(ctx context.Context, projectID uuid.UUID) (_ *console.ProjectSpendingCap, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	dbxSpendingCap, err := db.db.Get_ProjectSpendingCap_By_ProjectId(ctx, dbx.ProjectSpendingCap_ProjectId(projectID.Bytes()))
+	if err != nil {
+		return nil, err
+	}
+
+	return projectSpendingCapFromDBX(dbxSpendingCap)
+}
+
+// GetAll returns the spending caps of all projects.
+func (db *projectSpendingCaps) GetAll(ctx context.Context) (_ []console.ProjectSpendingCap, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	dbxSpendingCaps, err := db.db.All_ProjectSpendingCap(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	spendingCaps := make([]console.ProjectSpendingCap, 0, len(dbxSpendingCaps))
+	for _, dbxSpendingCap := range dbxSpendingCaps {
+		spendingCap, err := projectSpendingCapFromDBX(dbxSpendingCap)
+		if err != nil {
+			return nil, err
+		}
+		spendingCaps = append(spendingCaps, *spendingCap)
+	}
+
+	return spendingCaps, nil
+}
+
+// Update updates the spending cap of a project.
+func (db *projectSpendingCaps) Update(ctx context.Context, spendingCap console.ProjectSpendingCap) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return db.db.UpdateNoReturn_ProjectSpendingCap_By_ProjectId(ctx,
+		dbx.ProjectSpendingCap_ProjectId(spendingCap.ProjectID.Bytes()),
+		dbx.ProjectSpendingCap_Update_Fields{
+			Cap:              dbx.ProjectSpendingCap_Cap(spendingCap.Cap),
+			AlertThresholds:  dbx.ProjectSpendingCap_AlertThresholds(formatAlertThresholds(spendingCap.AlertThresholds)),
+			Enforce:          dbx.ProjectSpendingCap_Enforce(spendingCap.Enforce),
+			LimitBandwidth:   dbx.ProjectSpendingCap_LimitBandwidth(spendingCap.LimitBandwidth),
+			AlertPeriod:      dbx.ProjectSpendingCap_AlertPeriod(spendingCap.AlertPeriod),
+			AlertedThreshold: dbx.ProjectSpendingCap_AlertedThreshold(spendingCap.AlertedThreshold),
+			Capped:           dbx.ProjectSpendingCap_Capped(spendingCap.Capped),
+		},
+	)
+}
+
+// Delete removes the spending cap of the project.
+func (db *projectSpendingCaps) Delete(ctx context.Context, projectID uuid.UUID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = db.db.Delete_ProjectSpendingCap_By_ProjectId(ctx, dbx.ProjectSpendingCap_ProjectId(projectID.Bytes()))
+	return err
+}
+
+// projectSpendingCapFromDBX is used for creating ProjectSpendingCap entity from autogenerated dbx.ProjectSpendingCap struct.
+func projectSpendingCapFromDBX(spendingCap *dbx.ProjectSpendingCap) (_ *console.ProjectSpendingCap, err error) {
+	projectID, err := uuid.FromBytes(spendingCap.ProjectId)
+	if err != nil {
+		return nil, err
+	}
+
+	thresholds, err := parseAlertThresholds(spendingCap.AlertThresholds)
+	if err != nil {
+		return nil, err
+	}
+
+	return &console.ProjectSpendingCap{
+		ProjectID:        projectID,
+		Cap:              spendingCap.Cap,
+		AlertThresholds:  thresholds,
+		Enforce:          spendingCap.Enforce,
+		LimitBandwidth:   spendingCap.LimitBandwidth,
+		AlertPeriod:      spendingCap.AlertPeriod,
+		AlertedThreshold: spendingCap.AlertedThreshold,
+		Capped:           spendingCap.Capped,
+		CreatedAt:        spendingCap.CreatedAt,
+	}, nil
+}
+
+// formatAlertThresholds stores the alert thresholds as comma-separated percentages.
+func formatAlertThresholds(thresholds []int) string {
+	values := make([]string, 0, len(thresholds))
+	for _, threshold := range thresholds {
+		values = append(values, strconv.Itoa(threshold))
+	}
+	return strings.Join(values, ",")
+}
+
+// parseAlertThresholds parses comma-separated percentages.
+func parseAlertThresholds(value string) ([]int, error) {
+	thresholds := []int{}
+	if value == "" {
+		return thresholds, nil
+	}
+
+	for _, field := range strings.Split(value, ",") {
+		threshold, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		thresholds = append(thresholds, threshold)
+	}
+
+	return thresholds, nil
+}
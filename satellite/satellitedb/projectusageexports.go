@@ -0,0 +1,111 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitedb
+
+import (
+	"context"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/satellitedb/dbx"
+)
+
+// ensures that *projectUsageExports implements console.ProjectUsageExports.
+var _ console.ProjectUsageExports = (*projectUsageExports)(nil)
+
+type projectUsageExports struct {
+	db dbx.Methods
+}
+
+// Insert inserts the usage export of a project.
+func (db *projectUsageExports) Insert(ctx context.Context, export console.ProjectUsageExport) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return db.db.CreateNoReturn_ProjectUsageExport(ctx,
+		dbx.ProjectUsageExport_ProjectId(export.ProjectID.Bytes()),
+		dbx.ProjectUsageExport_EncryptedAccess(export.EncryptedAccess),
+		dbx.ProjectUsageExport_Bucket(export.Bucket),
+		dbx.ProjectUsageExport_Prefix(export.Prefix),
+		dbx.ProjectUsageExport_Format(string(export.Format)),
+		dbx.ProjectUsageExport_Schedule(string(export.Schedule)),
+		dbx.ProjectUsageExport_ExportedUntil(export.ExportedUntil),
+	)
+}
+
+// Get returns the usage export of the project.
+func (db *projectUsageExports) Get(ctx context.Context, projectID uuid.UUID) (_ *console.ProjectUsageExport, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	dbxExport, err := db.db.Get_ProjectUsageExport_By_ProjectId(ctx, dbx.ProjectUsageExport_ProjectId(projectID.Bytes()))
+	if err != nil {
+		return nil, err
+	}
+
+	return projectUsageExportFromDBX(dbxExport)
+}
+
+// GetAll returns the usage exports of all projects.
+func (db *projectUsageExports) GetAll(ctx context.Context) (_ []console.ProjectUsageExport, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	dbxExports, err := db.db.All_ProjectUsageExport(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	exports := make([]console.ProjectUsageExport, 0, len(dbxExports))
+	for _, dbxExport := range dbxExports {
+		export, err := projectUsageExportFromDBX(dbxExport)
+		if err != nil {
+			return nil, err
+		}
+		exports = append(exports, *export)
+	}
+
+	return exports, nil
+}
+
+// Update updates the usage export of a project.
+func (db *projectUsageExports) Update(ctx context.Context, export console.ProjectUsageExport) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return db.db.UpdateNoReturn_ProjectUsageExport_By_ProjectId(ctx,
+		dbx.ProjectUsageExport_ProjectId(export.ProjectID.Bytes()),
+		dbx.ProjectUsageExport_Update_Fields{
+			EncryptedAccess: dbx.ProjectUsageExport_EncryptedAccess(export.EncryptedAccess),
+			Bucket:          dbx.ProjectUsageExport_Bucket(export.Bucket),
+			Prefix:          dbx.ProjectUsageExport_Prefix(export.Prefix),
+			Format:          dbx.ProjectUsageExport_Format(string(export.Format)),
+			Schedule:        dbx.ProjectUsageExport_Schedule(string(export.Schedule)),
+			ExportedUntil:   dbx.ProjectUsageExport_ExportedUntil(export.ExportedUntil),
+		},
+	)
+}
+
+// Delete removes the usage export of the project.
+func (db *projectUsageExports) Delete(ctx context.Context, projectID uuid.UUID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = db.db.Delete_ProjectUsageExport_By_ProjectId(ctx, dbx.ProjectUsageExport_ProjectId(projectID.Bytes()))
+	return err
+}
+
+// projectUsageExportFromDBX is used for creating ProjectUsageExport entity from autogenerated dbx.ProjectUsageExport struct.
+func projectUsageExportFromDBX(export *dbx.ProjectUsageExport) (_ *console.ProjectUsageExport, err error) {
+	projectID, err := uuid.FromBytes(export.ProjectId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &console.ProjectUsageExport{
+		ProjectID:       projectID,
+		EncryptedAccess: export.EncryptedAccess,
+		Bucket:          export.Bucket,
+		Prefix:          export.Prefix,
+		Format:          console.UsageExportFormat(export.Format),
+		Schedule:        console.UsageExportSchedule(export.Schedule),
+		ExportedUntil:   export.ExportedUntil.UTC(),
+		CreatedAt:       export.CreatedAt,
+	}, nil
+}
@@ -0,0 +1,63 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitedb
+
+import (
+	"context"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/satellitedb/dbx"
+)
+
+// ensures that *ssoIdentities implements console.SSOIdentities.
+var _ console.SSOIdentities = (*ssoIdentities)(nil)
+
+type ssoIdentities struct {
+	db dbx.Methods
+}
+
+// Insert links the user to the identity.
+func (db *ssoIdentities) Insert(ctx context.Context, identity console.SSOIdentity) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return db.db.CreateNoReturn_SsoIdentity(ctx,
+		dbx.SsoIdentity_Issuer(identity.Issuer),
+		dbx.SsoIdentity_Subject(identity.Subject),
+		dbx.SsoIdentity_UserId(identity.UserID.Bytes()),
+	)
+}
+
+// Get returns the link of the identity with the subject at the issuer.
+func (db *ssoIdentities) Get(ctx context.Context, issuer, subject string) (_ *console.SSOIdentity, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	dbxIdentity, err := db.db.Get_SsoIdentity_By_Issuer_And_Subject(ctx,
+		dbx.SsoIdentity_Issuer(issuer),
+		dbx.SsoIdentity_Subject(subject),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := uuid.FromBytes(dbxIdentity.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &console.SSOIdentity{
+		Issuer:    dbxIdentity.Issuer,
+		Subject:   dbxIdentity.Subject,
+		UserID:    userID,
+		CreatedAt: dbxIdentity.CreatedAt,
+	}, nil
+}
+
+// DeleteByUserID deletes all links of the user.
+func (db *ssoIdentities) DeleteByUserID(ctx context.Context, userID uuid.UUID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = db.db.Delete_SsoIdentity_By_UserId(ctx, dbx.SsoIdentity_UserId(userID.Bytes()))
+	return err
+}
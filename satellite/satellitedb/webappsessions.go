@@ -60,6 +60,17 @@ func (db *webappSessions) GetAllByUserID(ctx context.Context, userID uuid.UUID)
 	return sessions, nil
 }
 
+// UpdateLastSeen updates the time the session was last used.
+func (db *webappSessions) UpdateLastSeen(ctx context.Context, sessionID uuid.UUID, lastSeenAt time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = db.db.Update_WebappSession_By_Id(ctx, dbx.WebappSession_Id(sessionID.Bytes()), dbx.WebappSession_Update_Fields{
+		LastSeenAt: dbx.WebappSession_LastSeenAt(lastSeenAt),
+	})
+
+	return err
+}
+
 // DeleteBySessionID deletes a webapp session by ID.
 func (db *webappSessions) DeleteBySessionID(ctx context.Context, sessionID uuid.UUID) (err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -86,11 +97,13 @@ func getSessionFromDBX(dbxSession *dbx.WebappSession) (consoleauth.WebappSession
 		return consoleauth.WebappSession{}, err
 	}
 	return consoleauth.WebappSession{
-		ID:        id,
-		UserID:    userID,
-		Address:   dbxSession.IpAddress,
-		UserAgent: dbxSession.UserAgent,
-		Status:    dbxSession.Status,
-		ExpiresAt: dbxSession.ExpiresAt,
+		ID:         id,
+		UserID:     userID,
+		Address:    dbxSession.IpAddress,
+		UserAgent:  dbxSession.UserAgent,
+		Status:     dbxSession.Status,
+		ExpiresAt:  dbxSession.ExpiresAt,
+		CreatedAt:  dbxSession.CreatedAt,
+		LastSeenAt: dbxSession.LastSeenAt,
 	}, nil
 }
@@ -121,6 +121,33 @@ type Blobs interface {
 	Close() error
 }
 
+// PrefixWalker is implemented by blob stores, which can walk the blobs of a
+// namespace grouped by key prefix, so that an interrupted walk can be resumed.
+type PrefixWalker interface {
+	// WalkNamespaceAfterPrefix executes walkFunc for each locally stored blob in the given
+	// namespace, like WalkNamespace, in increasing order of their key prefixes. Prefixes up
+	// to and including afterPrefix are skipped; an empty afterPrefix walks all of them.
+	// prefixDone is called once all blobs of a prefix were walked.
+	WalkNamespaceAfterPrefix(ctx context.Context, namespace []byte, afterPrefix string, walkFunc func(BlobInfo) error, prefixDone func(prefix string) error) error
+}
+
+// TrashStater is implemented by blob stores, which can look up blobs in the
+// trash.
+type TrashStater interface {
+	// StatTrash looks up disk metadata of the blob in the trash and returns when
+	// it was trashed. It fails with an os.ErrNotExist error when the blob isn't
+	// in the trash.
+	StatTrash(ctx context.Context, ref BlobRef) (_ BlobInfo, trashedAt time.Time, err error)
+}
+
+// BlobExtent is implemented by the infos of blobs, which are stored in a part
+// of a file shared with other blobs instead of a file of their own.
+type BlobExtent interface {
+	// Extent returns the path of the file containing the blob and the offset
+	// and the size of the blob data in it.
+	Extent(ctx context.Context) (path string, offset, size int64, err error)
+}
+
 // BlobInfo allows lazy inspection of a blob and its underlying file during iteration with
 // WalkNamespace-type methods.
 type BlobInfo interface {
@@ -13,6 +13,7 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -254,6 +255,42 @@ func (dir *Dir) Commit(ctx context.Context, file *os.File, ref storage.BlobRef,
 	return nil
 }
 
+// CommitTrash commits the temporary file to the trash, as if the blob was trashed at trashedAt.
+func (dir *Dir) CommitTrash(ctx context.Context, file *os.File, ref storage.BlobRef, formatVersion storage.FormatVersion, trashedAt time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	syncErr := file.Sync()
+	chmodErr := os.Chmod(file.Name(), blobPermission)
+	closeErr := file.Close()
+	// the mtime is set after closing, so that no write changes it anymore.
+	chtimesErr := os.Chtimes(file.Name(), trashedAt, trashedAt)
+
+	if syncErr != nil || chmodErr != nil || closeErr != nil || chtimesErr != nil {
+		removeErr := os.Remove(file.Name())
+		return errs.Combine(syncErr, chmodErr, closeErr, chtimesErr, removeErr)
+	}
+
+	path, err := dir.refToDirPath(ref, dir.trashdir())
+	if err != nil {
+		removeErr := os.Remove(file.Name())
+		return errs.Combine(err, removeErr)
+	}
+	path = blobPathForFormatVersion(path, formatVersion)
+
+	mkdirErr := os.MkdirAll(filepath.Dir(path), dirPermission)
+	if mkdirErr != nil && !os.IsExist(mkdirErr) {
+		removeErr := os.Remove(file.Name())
+		return errs.Combine(mkdirErr, removeErr)
+	}
+
+	renameErr := rename(file.Name(), path)
+	if renameErr != nil {
+		removeErr := os.Remove(file.Name())
+		return errs.Combine(renameErr, removeErr)
+	}
+
+	return nil
+}
+
 // Open opens the file with the specified ref. It may need to check in more than one location in
 // order to find the blob, if it was stored with an older version of the storage node software.
 // In cases where the storage format version of a blob is already known, OpenWithStorageFormat()
@@ -348,6 +385,27 @@ func (dir *Dir) StatWithStorageFormat(ctx context.Context, ref storage.BlobRef,
 	return nil, Error.New("unable to stat %q: %v", vPath, err)
 }
 
+// StatTrash looks up disk metadata on the blob file in the trash. The time the blob was trashed
+// is the mtime of the file, which is changed when it's moved to the trash.
+func (dir *Dir) StatTrash(ctx context.Context, ref storage.BlobRef) (_ storage.BlobInfo, trashedAt time.Time, err error) {
+	defer mon.Task()(&ctx)(&err)
+	path, err := dir.refToDirPath(ref, dir.trashdir())
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	for formatVer := MaxFormatVersionSupported; formatVer >= MinFormatVersionSupported; formatVer-- {
+		vPath := blobPathForFormatVersion(path, formatVer)
+		stat, err := os.Stat(vPath)
+		if err == nil {
+			return newBlobInfo(ref, vPath, stat, formatVer), stat.ModTime(), nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, time.Time{}, Error.New("unable to stat %q: %v", vPath, err)
+		}
+	}
+	return nil, time.Time{}, os.ErrNotExist
+}
+
 // Trash moves the piece specified by ref to the trashdir for every format version.
 func (dir *Dir) Trash(ctx context.Context, ref storage.BlobRef) (err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -411,6 +469,25 @@ func (dir *Dir) TrashWithStorageFormat(ctx context.Context, ref storage.BlobRef,
 	return err
 }
 
+// DeleteTrashWithStorageFormat deletes the blob with the specified format version from the trash.
+func (dir *Dir) DeleteTrashWithStorageFormat(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return dir.deleteWithStorageFormatInPath(ctx, dir.trashdir(), ref, formatVer)
+}
+
+// ListTrashNamespaces finds all known namespace IDs in use in the trash.
+func (dir *Dir) ListTrashNamespaces(ctx context.Context) (ids [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return dir.listNamespacesInPath(ctx, dir.trashdir())
+}
+
+// WalkTrash executes walkFunc for each blob in the trash of the namespace. The time the blob
+// was trashed is the mtime of the file.
+func (dir *Dir) WalkTrash(ctx context.Context, namespace []byte, walkFunc func(storage.BlobInfo) error) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return dir.walkNamespaceInPath(ctx, namespace, dir.trashdir(), walkFunc)
+}
+
 // ReplaceTrashnow is a helper for tests to replace the trashnow function used
 // when moving files to the trash.
 func (dir *Dir) ReplaceTrashnow(trashnow func() time.Time) {
@@ -730,6 +807,74 @@ func (dir *Dir) walkNamespaceInPath(ctx context.Context, namespace []byte, path
 	}
 }
 
+// WalkNamespaceAfterPrefix executes walkFunc for each locally stored blob in the given namespace,
+// walking the key prefix directories in sorted order and skipping those up to and including
+// afterPrefix. prefixDone is called after the blobs of each prefix directory were walked.
+func (dir *Dir) WalkNamespaceAfterPrefix(ctx context.Context, namespace []byte, afterPrefix string, walkFunc func(storage.BlobInfo) error, prefixDone func(prefix string) error) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	keyPrefixes, err := dir.ListNamespacePrefixes(ctx, namespace)
+	if err != nil {
+		return err
+	}
+
+	for _, keyPrefix := range keyPrefixes {
+		if keyPrefix <= afterPrefix {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := dir.WalkNamespacePrefix(ctx, namespace, keyPrefix, walkFunc); err != nil {
+			return err
+		}
+		if err := prefixDone(keyPrefix); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListNamespacePrefixes returns the sorted key prefix directories of the given namespace.
+func (dir *Dir) ListNamespacePrefixes(ctx context.Context, namespace []byte) (keyPrefixes []string, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	nsDir := filepath.Join(dir.blobsdir(), pathEncoding.EncodeToString(namespace))
+	openDir, err := os.Open(nsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	subdirNames, err := openDir.Readdirnames(-1)
+	err = errs.Combine(err, openDir.Close())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, keyPrefix := range subdirNames {
+		if len(keyPrefix) == 2 {
+			keyPrefixes = append(keyPrefixes, keyPrefix)
+		}
+	}
+	sort.Strings(keyPrefixes)
+	return keyPrefixes, nil
+}
+
+// WalkNamespacePrefix executes walkFunc for each locally stored blob in the given key prefix
+// directory of the namespace.
+func (dir *Dir) WalkNamespacePrefix(ctx context.Context, namespace []byte, keyPrefix string, walkFunc func(storage.BlobInfo) error) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	nsDir := filepath.Join(dir.blobsdir(), pathEncoding.EncodeToString(namespace))
+	err = walkNamespaceWithPrefix(ctx, dir.log, namespace, nsDir, keyPrefix, walkFunc)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
 func decodeBlobInfo(namespace []byte, keyPrefix, keyDir string, keyInfo os.FileInfo) (info storage.BlobInfo, ok bool) {
 	blobFileName := keyInfo.Name()
 	encodedKey := keyPrefix + blobFileName
@@ -28,7 +28,8 @@ var (
 
 	mon = monkit.Package()
 
-	_ storage.Blobs = (*blobStore)(nil)
+	_ storage.Blobs       = (*blobStore)(nil)
+	_ storage.TrashStater = (*blobStore)(nil)
 )
 
 func monFileInTrash(namespace []byte) *monkit.Meter {
@@ -110,6 +111,13 @@ func (store *blobStore) StatWithStorageFormat(ctx context.Context, ref storage.B
 	return info, Error.Wrap(err)
 }
 
+// StatTrash looks up disk metadata on the blob file in the trash.
+func (store *blobStore) StatTrash(ctx context.Context, ref storage.BlobRef) (_ storage.BlobInfo, trashedAt time.Time, err error) {
+	defer mon.Task()(&ctx)(&err)
+	info, trashedAt, err := store.dir.StatTrash(ctx, ref)
+	return info, trashedAt, Error.Wrap(err)
+}
+
 // Delete deletes blobs with the specified ref.
 //
 // It doesn't return an error if the blob isn't found for any reason or it cannot
@@ -284,6 +292,12 @@ func (store *blobStore) WalkNamespace(ctx context.Context, namespace []byte, wal
 	return store.dir.WalkNamespace(ctx, namespace, walkFunc)
 }
 
+// WalkNamespaceAfterPrefix executes walkFunc for each locally stored blob in the given namespace,
+// in the order of the key prefix directories, skipping those up to and including afterPrefix.
+func (store *blobStore) WalkNamespaceAfterPrefix(ctx context.Context, namespace []byte, afterPrefix string, walkFunc func(storage.BlobInfo) error, prefixDone func(prefix string) error) (err error) {
+	return store.dir.WalkNamespaceAfterPrefix(ctx, namespace, afterPrefix, walkFunc, prefixDone)
+}
+
 // TestCreateV0 creates a new V0 blob that can be written. This is ONLY appropriate in test situations.
 func (store *blobStore) TestCreateV0(ctx context.Context, ref storage.BlobRef) (_ storage.BlobWriter, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -18,6 +18,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/zeebo/errs"
+	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
 
 	"storj.io/common/identity/testidentity"
@@ -26,6 +27,7 @@ import (
 	"storj.io/common/testrand"
 	"storj.io/storj/storage"
 	"storj.io/storj/storage/filestore"
+	"storj.io/storj/storage/packstore"
 )
 
 const (
@@ -33,14 +35,34 @@ const (
 	keySize       = 32
 )
 
-func TestStoreLoad(t *testing.T) {
+// newStoreFunc creates a blob store in the directory.
+type newStoreFunc func(log *zap.Logger, path string, config filestore.Config) (storage.Blobs, error)
+
+// forEachStore runs the test against every blob store implementation, which
+// must all behave the same.
+func forEachStore(t *testing.T, test func(t *testing.T, newStore newStoreFunc)) {
+	t.Run("filestore", func(t *testing.T) {
+		test(t, filestore.NewAt)
+	})
+	t.Run("packstore", func(t *testing.T) {
+		test(t, func(log *zap.Logger, path string, config filestore.Config) (storage.Blobs, error) {
+			packConfig := packstore.DefaultConfig
+			packConfig.WriteBufferSize = config.WriteBufferSize
+			return packstore.NewAt(log, path, packConfig)
+		})
+	})
+}
+
+func TestStoreLoad(t *testing.T) { forEachStore(t, testStoreLoad) }
+
+func testStoreLoad(t *testing.T, newStore newStoreFunc) {
 	const blobSize = 8 << 10
 	const repeatCount = 16
 
 	ctx := testcontext.New(t)
 	defer ctx.Cleanup()
 
-	store, err := filestore.NewAt(zaptest.NewLogger(t), ctx.Dir("store"), filestore.DefaultConfig)
+	store, err := newStore(zaptest.NewLogger(t), ctx.Dir("store"), filestore.DefaultConfig)
 	require.NoError(t, err)
 	defer ctx.Check(store.Close)
 
@@ -163,13 +185,15 @@ func TestStoreLoad(t *testing.T) {
 	}
 }
 
-func TestDeleteWhileReading(t *testing.T) {
+func TestDeleteWhileReading(t *testing.T) { forEachStore(t, testDeleteWhileReading) }
+
+func testDeleteWhileReading(t *testing.T, newStore newStoreFunc) {
 	const blobSize = 8 << 10
 
 	ctx := testcontext.New(t)
 	defer ctx.Cleanup()
 
-	store, err := filestore.NewAt(zaptest.NewLogger(t), ctx.Dir("store"), filestore.DefaultConfig)
+	store, err := newStore(zaptest.NewLogger(t), ctx.Dir("store"), filestore.DefaultConfig)
 	require.NoError(t, err)
 	defer ctx.Check(store.Close)
 
@@ -301,10 +325,14 @@ func tryOpeningABlob(ctx context.Context, t testing.TB, store storage.Blobs, blo
 }
 
 func TestMultipleStorageFormatVersions(t *testing.T) {
+	forEachStore(t, testMultipleStorageFormatVersions)
+}
+
+func testMultipleStorageFormatVersions(t *testing.T, newStore newStoreFunc) {
 	ctx := testcontext.New(t)
 	defer ctx.Cleanup()
 
-	store, err := filestore.NewAt(zaptest.NewLogger(t), ctx.Dir("store"), filestore.DefaultConfig)
+	store, err := newStore(zaptest.NewLogger(t), ctx.Dir("store"), filestore.DefaultConfig)
 	require.NoError(t, err)
 	defer ctx.Check(store.Close)
 
@@ -360,11 +388,13 @@ func TestMultipleStorageFormatVersions(t *testing.T) {
 
 // Check that the SpaceUsedForBlobs and SpaceUsedForBlobsInNamespace methods on
 // filestore.blobStore work as expected.
-func TestStoreSpaceUsed(t *testing.T) {
+func TestStoreSpaceUsed(t *testing.T) { forEachStore(t, testStoreSpaceUsed) }
+
+func testStoreSpaceUsed(t *testing.T, newStore newStoreFunc) {
 	ctx := testcontext.New(t)
 	defer ctx.Cleanup()
 
-	store, err := filestore.NewAt(zaptest.NewLogger(t), ctx.Dir("store"), filestore.DefaultConfig)
+	store, err := newStore(zaptest.NewLogger(t), ctx.Dir("store"), filestore.DefaultConfig)
 	require.NoError(t, err)
 	defer ctx.Check(store.Close)
 
@@ -410,11 +440,13 @@ func TestStoreSpaceUsed(t *testing.T) {
 }
 
 // Check that ListNamespaces and WalkNamespace work as expected.
-func TestStoreTraversals(t *testing.T) {
+func TestStoreTraversals(t *testing.T) { forEachStore(t, testStoreTraversals) }
+
+func testStoreTraversals(t *testing.T, newStore newStoreFunc) {
 	ctx := testcontext.New(t)
 	defer ctx.Cleanup()
 
-	store, err := filestore.NewAt(zaptest.NewLogger(t), ctx.Dir("store"), filestore.DefaultConfig)
+	store, err := newStore(zaptest.NewLogger(t), ctx.Dir("store"), filestore.DefaultConfig)
 	require.NoError(t, err)
 	defer ctx.Check(store.Close)
 
@@ -533,11 +565,13 @@ func TestStoreTraversals(t *testing.T) {
 	assert.Equal(t, 2, iterations)
 }
 
-func TestEmptyTrash(t *testing.T) {
+func TestEmptyTrash(t *testing.T) { forEachStore(t, testEmptyTrash) }
+
+func testEmptyTrash(t *testing.T, newStore newStoreFunc) {
 	ctx := testcontext.New(t)
 	defer ctx.Cleanup()
 
-	store, err := filestore.NewAt(zaptest.NewLogger(t), ctx.Dir("store"), filestore.DefaultConfig)
+	store, err := newStore(zaptest.NewLogger(t), ctx.Dir("store"), filestore.DefaultConfig)
 	require.NoError(t, err)
 	defer ctx.Check(store.Close)
 
@@ -658,11 +692,13 @@ func TestEmptyTrash(t *testing.T) {
 	assert.Equal(t, int(expectedFilesEmptied), len(keys))
 }
 
-func TestTrashAndRestore(t *testing.T) {
+func TestTrashAndRestore(t *testing.T) { forEachStore(t, testTrashAndRestore) }
+
+func testTrashAndRestore(t *testing.T, newStore newStoreFunc) {
 	ctx := testcontext.New(t)
 	defer ctx.Cleanup()
 
-	store, err := filestore.NewAt(zaptest.NewLogger(t), ctx.Dir("store"), filestore.DefaultConfig)
+	store, err := newStore(zaptest.NewLogger(t), ctx.Dir("store"), filestore.DefaultConfig)
 	require.NoError(t, err)
 	defer ctx.Check(store.Close)
 
@@ -815,6 +851,47 @@ func TestTrashAndRestore(t *testing.T) {
 	}
 }
 
+func TestStatTrash(t *testing.T) { forEachStore(t, testStatTrash) }
+
+func testStatTrash(t *testing.T, newStore newStoreFunc) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	store, err := newStore(zaptest.NewLogger(t), ctx.Dir("store"), filestore.DefaultConfig)
+	require.NoError(t, err)
+	defer ctx.Check(store.Close)
+
+	stater, ok := store.(storage.TrashStater)
+	require.Truef(t, ok, "blob store (%T) doesn't implement storage.TrashStater", store)
+
+	ref := storage.BlobRef{
+		Namespace: testrand.Bytes(namespaceSize),
+		Key:       testrand.Bytes(keySize),
+	}
+	writer, err := store.Create(ctx, ref, memory.KB.Int64())
+	require.NoError(t, err)
+	_, err = writer.Write(testrand.Bytes(memory.KB))
+	require.NoError(t, err)
+	require.NoError(t, writer.Commit(ctx))
+
+	_, _, err = stater.StatTrash(ctx, ref)
+	require.True(t, errs.IsFunc(err, os.IsNotExist), "unexpected error: %v", err)
+
+	before := time.Now().Add(-time.Second)
+	require.NoError(t, store.Trash(ctx, ref))
+
+	info, trashedAt, err := stater.StatTrash(ctx, ref)
+	require.NoError(t, err)
+	require.Equal(t, ref, info.BlobRef())
+	require.Equal(t, filestore.FormatV1, info.StorageFormatVersion())
+	require.True(t, trashedAt.After(before), "trashed at %v", trashedAt)
+
+	_, err = store.RestoreTrash(ctx, ref.Namespace)
+	require.NoError(t, err)
+	_, _, err = stater.StatTrash(ctx, ref)
+	require.True(t, errs.IsFunc(err, os.IsNotExist), "unexpected error: %v", err)
+}
+
 func requireFileMatches(ctx context.Context, t *testing.T, store storage.Blobs, data []byte, ref storage.BlobRef, formatVer storage.FormatVersion) {
 	r, err := store.OpenWithStorageFormat(ctx, ref, formatVer)
 	require.NoError(t, err)
@@ -827,13 +904,15 @@ func requireFileMatches(ctx context.Context, t *testing.T, store storage.Blobs,
 
 // TestBlobMemoryBuffer ensures that buffering doesn't have problems with
 // small writes randomly seeked through the file.
-func TestBlobMemoryBuffer(t *testing.T) {
+func TestBlobMemoryBuffer(t *testing.T) { forEachStore(t, testBlobMemoryBuffer) }
+
+func testBlobMemoryBuffer(t *testing.T, newStore newStoreFunc) {
 	ctx := testcontext.New(t)
 	defer ctx.Cleanup()
 
 	const size = 2048
 
-	store, err := filestore.NewAt(zaptest.NewLogger(t), ctx.Dir("store"), filestore.Config{
+	store, err := newStore(zaptest.NewLogger(t), ctx.Dir("store"), filestore.Config{
 		WriteBufferSize: 1 * memory.KiB,
 	})
 	require.NoError(t, err)
@@ -0,0 +1,256 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package packstore
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/storage"
+)
+
+// blobReader implements reading a blob from its log file.
+type blobReader struct {
+	*io.SectionReader
+	file          *os.File
+	formatVersion storage.FormatVersion
+}
+
+func newBlobReader(file *os.File, offset, size int64, formatVersion storage.FormatVersion) *blobReader {
+	return &blobReader{
+		SectionReader: io.NewSectionReader(file, offset, size),
+		file:          file,
+		formatVersion: formatVersion,
+	}
+}
+
+// Close closes the log file.
+func (blob *blobReader) Close() error {
+	return blob.file.Close()
+}
+
+// Size returns how large is the blob.
+func (blob *blobReader) Size() (int64, error) {
+	return blob.SectionReader.Size(), nil
+}
+
+// StorageFormatVersion gets the storage format version being used by the blob.
+func (blob *blobReader) StorageFormatVersion() storage.FormatVersion {
+	return blob.formatVersion
+}
+
+// blobWriter implements writing blobs. The blob is streamed into a record at
+// the end of a log file, which the writer holds until it's done, so no other
+// record is appended after it. The record header is written on commit, when
+// the size of the blob is known.
+type blobWriter struct {
+	ref           storage.BlobRef
+	store         *blobStore
+	ns            *namespace
+	closed        bool
+	formatVersion storage.FormatVersion
+	buffer        *bufio.Writer
+
+	log  *logFile
+	file *os.File
+	// offset is the offset of the record in the log file.
+	offset int64
+	// dataOffset is the offset of the blob data in the log file.
+	dataOffset int64
+	// pos is the position of the next write in the blob and end is the end
+	// of the data written so far.
+	pos, end int64
+}
+
+func newBlobWriter(ref storage.BlobRef, store *blobStore, ns *namespace, formatVersion storage.FormatVersion, log *logFile, file *os.File, offset int64, bufferSize int) *blobWriter {
+	blob := &blobWriter{
+		ref:           ref,
+		store:         store,
+		ns:            ns,
+		closed:        false,
+		formatVersion: formatVersion,
+		log:           log,
+		file:          file,
+		offset:        offset,
+		dataOffset:    offset + recordHeaderSize + int64(len(ref.Key)),
+	}
+	blob.buffer = bufio.NewWriterSize(blobData{blob}, bufferSize)
+	return blob
+}
+
+// blobData writes the blob data at the current position in the log file.
+type blobData struct{ blob *blobWriter }
+
+// Write writes p at the current position.
+func (data blobData) Write(p []byte) (int, error) {
+	blob := data.blob
+	n, err := blob.file.WriteAt(p, blob.dataOffset+blob.pos)
+	blob.pos += int64(n)
+	if blob.pos > blob.end {
+		blob.end = blob.pos
+	}
+	return n, err
+}
+
+// Write adds data to the blob.
+func (blob *blobWriter) Write(p []byte) (int, error) {
+	if blob.closed {
+		return 0, Error.New("already closed")
+	}
+	return blob.buffer.Write(p)
+}
+
+// Cancel discards the blob and rolls the log file back to the start of its record.
+func (blob *blobWriter) Cancel(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if blob.closed {
+		return nil
+	}
+	blob.closed = true
+
+	return Error.Wrap(blob.store.cancel(ctx, blob.ns, blob.log, blob.file))
+}
+
+// Commit completes the record of the blob and adds the blob to the index.
+func (blob *blobWriter) Commit(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if blob.closed {
+		return Error.New("already closed")
+	}
+	blob.closed = true
+
+	if err := blob.buffer.Flush(); err != nil {
+		return Error.Wrap(errs.Combine(err, blob.store.cancel(ctx, blob.ns, blob.log, blob.file)))
+	}
+
+	// like the file-per-piece store, the blob ends at the current position.
+	return Error.Wrap(blob.store.put(ctx, blob.ns, blob.ref, blob.formatVersion, blob.log, blob.file, blob.offset, blob.pos, blob.end))
+}
+
+// Seek flushes any buffer and moves the position in the blob.
+func (blob *blobWriter) Seek(offset int64, whence int) (int64, error) {
+	if err := blob.buffer.Flush(); err != nil {
+		return 0, err
+	}
+
+	var pos int64
+	switch whence {
+	case io.SeekStart:
+		pos = offset
+	case io.SeekCurrent:
+		pos = blob.pos + offset
+	case io.SeekEnd:
+		pos = blob.end + offset
+	default:
+		return 0, Error.New("invalid whence %d", whence)
+	}
+	if pos < 0 {
+		return 0, Error.New("negative position %d", pos)
+	}
+
+	blob.pos = pos
+	return pos, nil
+}
+
+// Size returns how much has been written so far.
+func (blob *blobWriter) Size() (int64, error) {
+	pos, err := blob.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, err
+	}
+
+	return pos, err
+}
+
+// StorageFormatVersion indicates what storage format version the blob is using.
+func (blob *blobWriter) StorageFormatVersion() storage.FormatVersion {
+	return blob.formatVersion
+}
+
+// blobInfo implements storage.BlobInfo for a blob in a log file.
+type blobInfo struct {
+	ref           storage.BlobRef
+	formatVersion storage.FormatVersion
+	logPath       string
+	entry         entry
+}
+
+func newBlobInfo(ref storage.BlobRef, formatVersion storage.FormatVersion, logPath string, entry entry) storage.BlobInfo {
+	return &blobInfo{
+		ref:           ref,
+		formatVersion: formatVersion,
+		logPath:       logPath,
+		entry:         entry,
+	}
+}
+
+// BlobRef returns the relevant BlobRef for the blob.
+func (info *blobInfo) BlobRef() storage.BlobRef {
+	return info.ref
+}
+
+// StorageFormatVersion indicates the storage format version used to store the piece.
+func (info *blobInfo) StorageFormatVersion() storage.FormatVersion {
+	return info.formatVersion
+}
+
+// name returns the name identifying the blob in its log file.
+func (info *blobInfo) name() string {
+	return fmt.Sprintf("%s@%d", filepath.Base(info.logPath), info.entry.offset)
+}
+
+// FullPath returns the path of the log file containing the blob, suffixed
+// with "@" and the offset of the blob record in the log file.
+func (info *blobInfo) FullPath(ctx context.Context) (string, error) {
+	return filepath.Join(filepath.Dir(info.logPath), info.name()), nil
+}
+
+// Extent returns the path of the log file containing the blob and the offset
+// and the size of the blob data in it.
+func (info *blobInfo) Extent(ctx context.Context) (path string, offset, size int64, err error) {
+	return info.logPath, info.entry.dataOffset(string(info.ref.Key)), info.entry.size, nil
+}
+
+// Stat returns the metadata of the blob as a file info.
+func (info *blobInfo) Stat(ctx context.Context) (os.FileInfo, error) {
+	return &fileInfo{
+		name:    info.name(),
+		size:    info.entry.size,
+		modTime: info.entry.modTime,
+	}, nil
+}
+
+// fileInfo implements os.FileInfo for a blob in a log file.
+type fileInfo struct {
+	name    string
+	size    int64
+	modTime time.Time
+}
+
+// Name returns the name identifying the blob in its log file.
+func (info *fileInfo) Name() string { return info.name }
+
+// Size returns the size of the blob.
+func (info *fileInfo) Size() int64 { return info.size }
+
+// Mode returns the file mode of the log file.
+func (info *fileInfo) Mode() os.FileMode { return filePermission }
+
+// ModTime returns when the blob was committed.
+func (info *fileInfo) ModTime() time.Time { return info.modTime }
+
+// IsDir returns false.
+func (info *fileInfo) IsDir() bool { return false }
+
+// Sys returns nil.
+func (info *fileInfo) Sys() interface{} { return nil }
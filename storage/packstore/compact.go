@@ -0,0 +1,255 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package packstore
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+
+	"storj.io/common/sync2"
+)
+
+// GarbageCollect compacts the log files with too many unreferenced bytes,
+// removes the log files no blob references anymore and checkpoints the
+// indexes with long journals.
+func (store *blobStore) GarbageCollect(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var group errs.Group
+	for _, ns := range store.allNamespaces() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		group.Add(store.compact(ctx, ns))
+	}
+	group.Add(store.dir.GarbageCollect(ctx))
+	return Error.Wrap(group.Err())
+}
+
+// compact compacts the log files of the namespace.
+func (store *blobStore) compact(ctx context.Context, ns *namespace) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ns.mu.Lock()
+	var candidates []*logFile
+	for _, logFile := range ns.logs {
+		if logFile.held || logFile.live == 0 {
+			// log files without live records are removed below.
+			continue
+		}
+		if float64(logFile.dead()) >= float64(logFile.size)*store.config.CompactionThreshold {
+			// no records are appended to the log file while it's compacted.
+			ns.retire(logFile)
+			candidates = append(candidates, logFile)
+		}
+	}
+	ns.mu.Unlock()
+
+	if len(candidates) > 0 {
+		if err := store.compactLogs(ctx, ns, candidates); err != nil {
+			return err
+		}
+	}
+
+	ns.checkpointMu.Lock()
+	defer ns.checkpointMu.Unlock()
+	ns.syncMu.Lock()
+	ns.mu.Lock()
+
+	// the index doesn't reference a log file without live records.
+	for id, logFile := range ns.logs {
+		if logFile.live > 0 || logFile.held {
+			continue
+		}
+		ns.retire(logFile)
+		if err := os.Remove(logFile.path); err != nil && !os.IsNotExist(err) {
+			// the file may be still open for reading on some platforms.
+			store.log.Debug("unable to remove unreferenced log file", zap.String("Path", logFile.path), zap.Error(err))
+			continue
+		}
+		delete(ns.logs, id)
+	}
+
+	if ns.count == 0 && len(ns.logs) == 0 {
+		// nothing is stored anymore, so the directory is recreated with the next write.
+		err := ns.close()
+		ns.journaled = 0
+		ns.mu.Unlock()
+		ns.syncMu.Unlock()
+		return errs.Combine(err, os.RemoveAll(ns.dir))
+	}
+
+	checkpoint := len(ns.changes) >= checkpointChanges || float64(ns.journaled) > float64(ns.count)*checkpointFraction
+	ns.mu.Unlock()
+	ns.syncMu.Unlock()
+
+	if checkpoint {
+		return ns.checkpoint()
+	}
+	return nil
+}
+
+// compactLogs moves the records referenced by the index from the log files
+// to other log files.
+func (store *blobStore) compactLogs(ctx context.Context, ns *namespace, logFiles []*logFile) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	moves := map[uint32]map[entryKey]entry{}
+	for _, logFile := range logFiles {
+		moves[logFile.id] = map[entryKey]entry{}
+	}
+
+	ns.mu.Lock()
+	snapshot := ns.snapshot()
+	ns.mu.Unlock()
+	err = snapshot.walk(entryKey{}, func(key entryKey, found entry) (bool, error) {
+		if logMoves, ok := moves[found.log]; ok {
+			logMoves[key] = found
+		}
+		return true, ctx.Err()
+	})
+	snapshot.release()
+	if err != nil {
+		return err
+	}
+
+	for _, logFile := range logFiles {
+		if err := store.compactLog(ctx, ns, logFile, moves[logFile.id]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compactLog moves the records of the blobs from the log file to other log
+// files.
+func (store *blobStore) compactLog(ctx context.Context, ns *namespace, logFile *logFile, moves map[entryKey]entry) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	source, err := os.Open(logFile.path)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, source.Close()) }()
+
+	for key, found := range moves {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := store.move(ns, source, key, found); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// move copies the record of the blob from source to a log file the
+// compaction holds and points the index to the copy, unless the blob changed
+// in the meantime.
+func (store *blobStore) move(ns *namespace, source *os.File, key entryKey, found entry) error {
+	maxLogSize := store.config.MaxLogSize.Int64()
+
+	ns.mu.Lock()
+	current, ok, err := ns.get(key)
+	if err != nil || !ok || current.log != found.log || current.offset != found.offset {
+		ns.mu.Unlock()
+		return err
+	}
+	target, err := ns.acquire(maxLogSize)
+	var file *os.File
+	var offset int64
+	if err == nil {
+		file, offset = target.file, target.size
+	}
+	ns.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	data := io.NewSectionReader(source, found.dataOffset(key.key), found.size)
+	writeErr := writeRecord(file, offset, key.key, key.formatVersion, data, found.size)
+
+	ns.mu.Lock()
+	if writeErr != nil {
+		err := ns.rollback(target, file, maxLogSize)
+		ns.mu.Unlock()
+		return errs.Combine(writeErr, err)
+	}
+
+	target.size = offset + found.recordSize(key.key)
+	ns.release(target, maxLogSize)
+
+	// the copy is left unreferenced, when the blob was deleted or replaced
+	// while copying.
+	current, ok, err = ns.get(key)
+	if err != nil || !ok || current.log != found.log || current.offset != found.offset {
+		ns.mu.Unlock()
+		return err
+	}
+
+	seq, err := ns.record(indexEntry{
+		op:            opPut,
+		key:           []byte(key.key),
+		formatVersion: key.formatVersion,
+		log:           target.id,
+		offset:        offset,
+		size:          found.size,
+		modTime:       found.modTime,
+		trashedAt:     current.trashedAt,
+	})
+	ns.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	return ns.commit(seq)
+}
+
+// Compactor is a blob store that reclaims space in the background.
+type Compactor interface {
+	GarbageCollect(ctx context.Context) error
+}
+
+// Chore periodically compacts the log files of the store.
+type Chore struct {
+	log   *zap.Logger
+	store Compactor
+
+	Loop *sync2.Cycle
+}
+
+// NewChore creates a new compaction chore.
+func NewChore(log *zap.Logger, store Compactor, interval time.Duration) *Chore {
+	return &Chore{
+		log:   log,
+		store: store,
+		Loop:  sync2.NewCycle(interval),
+	}
+}
+
+// Run runs the compaction in a loop.
+func (chore *Chore) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var group errgroup.Group
+	chore.Loop.Start(ctx, &group, func(ctx context.Context) error {
+		if err := chore.store.GarbageCollect(ctx); err != nil {
+			chore.log.Error("compaction failed", zap.Error(err))
+		}
+		return nil
+	})
+	return group.Wait()
+}
+
+// Close stops the chore.
+func (chore *Chore) Close() error {
+	chore.Loop.Close()
+	return nil
+}
@@ -0,0 +1,180 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package packstore
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+	"os"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/storage"
+)
+
+// indexOp is the operation recorded by an index entry.
+type indexOp byte
+
+const (
+	// opPut records that a blob is stored at a location of a log file.
+	opPut indexOp = 1
+	// opDelete records that a blob was deleted.
+	opDelete indexOp = 2
+	// opTrash records that a blob was moved to the trash.
+	opTrash indexOp = 3
+	// opRestore records that a blob was restored from the trash.
+	opRestore indexOp = 4
+)
+
+// indexEntryHeaderSize is the size of the fixed part of an encoded index entry:
+// op, format version, key length, log id, offset, size, mod time and trashed at.
+const indexEntryHeaderSize = 1 + 2 + 2 + 4 + 8 + 8 + 8 + 8
+
+// indexEntry is a single record of the index journal.
+//
+// The journals of a namespace are append-only sequences of these entries. The
+// checkpoint of a namespace is a table of put entries for every stored blob,
+// which is written when the journals grow long. The current state is the
+// checkpoint with the journals written since applied.
+type indexEntry struct {
+	op            indexOp
+	key           []byte
+	formatVersion storage.FormatVersion
+
+	log       uint32
+	offset    int64
+	size      int64
+	modTime   time.Time
+	trashedAt time.Time
+}
+
+// encode appends the binary encoding of the entry, followed by its checksum, to buf.
+func (entry *indexEntry) encode(buf []byte) []byte {
+	start := len(buf)
+
+	var header [indexEntryHeaderSize]byte
+	header[0] = byte(entry.op)
+	binary.LittleEndian.PutUint16(header[1:], uint16(entry.formatVersion))
+	binary.LittleEndian.PutUint16(header[3:], uint16(len(entry.key)))
+	binary.LittleEndian.PutUint32(header[5:], entry.log)
+	binary.LittleEndian.PutUint64(header[9:], uint64(entry.offset))
+	binary.LittleEndian.PutUint64(header[17:], uint64(entry.size))
+	binary.LittleEndian.PutUint64(header[25:], uint64(unixNano(entry.modTime)))
+	binary.LittleEndian.PutUint64(header[33:], uint64(unixNano(entry.trashedAt)))
+
+	buf = append(buf, header[:]...)
+	buf = append(buf, entry.key...)
+
+	var sum [4]byte
+	binary.LittleEndian.PutUint32(sum[:], crc32.ChecksumIEEE(buf[start:]))
+	return append(buf, sum[:]...)
+}
+
+// entryKey returns the key of the blob the entry is recorded for.
+func (entry *indexEntry) entryKey() entryKey {
+	return entryKey{key: string(entry.key), formatVersion: entry.formatVersion}
+}
+
+// entryOf returns the location and metadata of the blob recorded by a put entry.
+func entryOf(indexEntry *indexEntry) entry {
+	return entry{
+		log:       indexEntry.log,
+		offset:    indexEntry.offset,
+		size:      indexEntry.size,
+		modTime:   indexEntry.modTime,
+		trashedAt: indexEntry.trashedAt,
+	}
+}
+
+// errTornEntry is returned when the journal ends with a partially written or
+// corrupted entry, which happens when the node stops in the middle of a write.
+var errTornEntry = errs.New("torn index entry")
+
+// decodeIndexEntry reads the next entry from r. It returns io.EOF at the end of
+// the journal and errTornEntry when the remaining data is not a valid entry.
+func decodeIndexEntry(r io.Reader) (entry indexEntry, n int64, err error) {
+	var header [indexEntryHeaderSize]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		if errors.Is(err, io.EOF) {
+			return entry, 0, io.EOF
+		}
+		return entry, 0, errTornEntry
+	}
+
+	key := make([]byte, binary.LittleEndian.Uint16(header[3:]))
+	if _, err := io.ReadFull(r, key); err != nil {
+		return entry, 0, errTornEntry
+	}
+
+	var sum [4]byte
+	if _, err := io.ReadFull(r, sum[:]); err != nil {
+		return entry, 0, errTornEntry
+	}
+
+	crc := crc32.NewIEEE()
+	_, _ = crc.Write(header[:])
+	_, _ = crc.Write(key)
+	if crc.Sum32() != binary.LittleEndian.Uint32(sum[:]) {
+		return entry, 0, errTornEntry
+	}
+
+	entry = indexEntry{
+		op:            indexOp(header[0]),
+		formatVersion: storage.FormatVersion(binary.LittleEndian.Uint16(header[1:])),
+		key:           key,
+		log:           binary.LittleEndian.Uint32(header[5:]),
+		offset:        int64(binary.LittleEndian.Uint64(header[9:])),
+		size:          int64(binary.LittleEndian.Uint64(header[17:])),
+		modTime:       fromUnixNano(int64(binary.LittleEndian.Uint64(header[25:]))),
+		trashedAt:     fromUnixNano(int64(binary.LittleEndian.Uint64(header[33:]))),
+	}
+	return entry, int64(len(header) + len(key) + len(sum)), nil
+}
+
+// replayIndex reads all the entries of the journal at path and calls apply for
+// each of them, until apply fails. A torn entry at the end of the journal is
+// truncated away.
+func replayIndex(path string, apply func(indexEntry) error) (err error) {
+	file, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, file.Close()) }()
+
+	var valid int64
+	r := bufio.NewReaderSize(file, 256*1024)
+	for {
+		entry, n, err := decodeIndexEntry(r)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if errors.Is(err, errTornEntry) {
+			return file.Truncate(valid)
+		}
+		valid += n
+		if err := apply(entry); err != nil {
+			return err
+		}
+	}
+}
+
+// unixNano returns t as unix nanoseconds, where the zero time is encoded as 0.
+func unixNano(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+// fromUnixNano is the inverse of unixNano.
+func fromUnixNano(nanos int64) time.Time {
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos)
+}
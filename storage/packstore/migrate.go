@@ -0,0 +1,135 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package packstore
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/storage"
+)
+
+// MigrationStats summarizes a migration from another blob store.
+type MigrationStats struct {
+	Blobs   int64
+	Bytes   int64
+	Skipped int64
+}
+
+// Migrate copies all blobs from the file-per-piece store to the packed store,
+// preserving their storage format versions. Blobs already in the packed store
+// with the same size are skipped, so an interrupted migration can be resumed.
+// When deleteSource is true, every blob is deleted from the source once it's
+// committed to the packed store, and so are the emptied namespaces.
+//
+// The trash of the source is restored before copying, because trashed blobs
+// can't be listed. Anything restored that is still garbage is trashed again
+// by the next retain request.
+func Migrate(ctx context.Context, log *zap.Logger, from, to storage.Blobs, deleteSource bool) (stats MigrationStats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	store, ok := to.(*blobStore)
+	if !ok {
+		return stats, Error.New("destination is not a packed blob store: %T", to)
+	}
+
+	namespaces, err := from.ListNamespaces(ctx)
+	if err != nil {
+		return stats, Error.Wrap(err)
+	}
+
+	for _, namespace := range namespaces {
+		restored, err := from.RestoreTrash(ctx, namespace)
+		if err != nil {
+			return stats, Error.Wrap(err)
+		}
+		if len(restored) > 0 {
+			log.Info("restored trash before migration", zap.Binary("Namespace", namespace), zap.Int("Count", len(restored)))
+		}
+
+		err = from.WalkNamespace(ctx, namespace, func(info storage.BlobInfo) error {
+			migrated, size, err := store.migrateBlob(ctx, from, info)
+			if err != nil {
+				return err
+			}
+			if migrated {
+				stats.Blobs++
+				stats.Bytes += size
+			} else {
+				stats.Skipped++
+			}
+
+			if deleteSource {
+				return from.DeleteWithStorageFormat(ctx, info.BlobRef(), info.StorageFormatVersion())
+			}
+			return nil
+		})
+		if err != nil {
+			return stats, Error.Wrap(err)
+		}
+
+		if deleteSource {
+			// removes the emptied directories of the namespace.
+			if err := from.DeleteNamespace(ctx, namespace); err != nil {
+				return stats, Error.Wrap(err)
+			}
+		}
+
+		log.Info("migrated namespace", zap.Binary("Namespace", namespace), zap.Int64("Blobs", stats.Blobs), zap.Int64("Skipped", stats.Skipped))
+	}
+
+	return stats, nil
+}
+
+// migrateBlob copies a single blob from the source store. It returns false
+// when the blob was already migrated.
+func (store *blobStore) migrateBlob(ctx context.Context, from storage.Blobs, info storage.BlobInfo) (migrated bool, size int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ref := info.BlobRef()
+	formatVersion := info.StorageFormatVersion()
+
+	reader, err := from.OpenWithStorageFormat(ctx, ref, formatVersion)
+	if err != nil {
+		return false, 0, err
+	}
+	defer func() { err = errs.Combine(err, reader.Close()) }()
+
+	size, err = reader.Size()
+	if err != nil {
+		return false, 0, err
+	}
+
+	existing, err := store.StatWithStorageFormat(ctx, ref, formatVersion)
+	if err == nil {
+		stat, err := existing.Stat(ctx)
+		if err != nil {
+			return false, 0, err
+		}
+		if stat.Size() == size {
+			return false, size, nil
+		}
+	} else if !errs.IsFunc(err, os.IsNotExist) {
+		return false, 0, err
+	}
+
+	writer, err := store.create(ctx, ref, formatVersion)
+	if err != nil {
+		return false, 0, err
+	}
+
+	written, err := io.Copy(writer, reader)
+	if err != nil {
+		return false, 0, errs.Combine(err, writer.Cancel(ctx))
+	}
+	if written != size {
+		return false, 0, errs.Combine(Error.New("copied %d of %d bytes of blob", written, size), writer.Cancel(ctx))
+	}
+
+	return true, size, writer.Commit(ctx)
+}
@@ -0,0 +1,814 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package packstore
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/storage"
+)
+
+const (
+	filePermission = 0600
+	dirPermission  = 0700
+
+	// the index of a namespace is a checkpoint of all its entries and the
+	// journals of the operations since the checkpoint.
+	checkpointFileName      = "checkpoint"
+	journalFileName         = "index"
+	previousJournalFileName = "index.prev"
+	logFileSuffix           = ".log"
+
+	// checkpointFraction is the fraction of the entries of the namespace the
+	// journals may grow to before compaction writes a new checkpoint.
+	checkpointFraction = 0.25
+	// checkpointChanges is the number of entries changed since the checkpoint
+	// at which compaction writes a new checkpoint, however large the
+	// namespace is.
+	checkpointChanges = 1 << 16
+	// maxChanges is the number of entries changed since the checkpoint at
+	// which a new checkpoint is written right away. The changes are kept in
+	// memory, so this bounds the memory of a namespace between compactions.
+	maxChanges = 1 << 18
+
+	// recordMagic starts every record of a log file, so the records can be
+	// recognized when the index is lost.
+	recordMagic = 0x4b504a53 // "SJPK"
+	// recordHeaderSize is the size of the record header: magic, format
+	// version, key length and data size.
+	recordHeaderSize = 4 + 2 + 2 + 8
+)
+
+// entryKey identifies a stored blob of a namespace.
+type entryKey struct {
+	key           string
+	formatVersion storage.FormatVersion
+}
+
+// entry is the location and metadata of a stored blob.
+type entry struct {
+	log    uint32
+	offset int64 // offset of the record in the log file
+	size   int64 // size of the blob data
+
+	modTime   time.Time
+	trashedAt time.Time // zero when the blob is not in the trash
+}
+
+// recordSize returns the size of the record of the blob in its log file.
+func (entry *entry) recordSize(key string) int64 {
+	return recordHeaderSize + int64(len(key)) + entry.size
+}
+
+// dataOffset returns the offset of the blob data in its log file.
+func (entry *entry) dataOffset(key string) int64 {
+	return entry.offset + recordHeaderSize + int64(len(key))
+}
+
+// logFile is a file that records of blobs are appended to.
+type logFile struct {
+	id   uint32
+	path string
+
+	// size is the end of the last committed record.
+	size int64
+	// live is the sum of the sizes of the records referenced by the index.
+	live int64
+	// held is whether a writer is appending a record to the file. Only the
+	// holder appends to the file, so it can stream the record without
+	// knowing its size upfront.
+	held bool
+	// file is the file opened for appending, while the log is held or idle.
+	file *os.File
+}
+
+// dead returns the number of bytes in the log that no blob references.
+func (log *logFile) dead() int64 { return log.size - log.live }
+
+// namespace holds the index and the log files of the blobs of a namespace.
+//
+// The index is the table of the last checkpoint on disk and the entries
+// changed since in memory, so that the memory of a namespace doesn't grow
+// with the number of blobs it stores.
+type namespace struct {
+	log *zap.Logger
+	id  []byte
+	dir string
+
+	// checkpointMu serializes the checkpoints and closing the namespace.
+	// It's acquired before syncMu.
+	checkpointMu sync.Mutex
+	// syncMu serializes the syncs of the journal, so that the entries of
+	// concurrent operations are synced together. It's acquired before mu.
+	syncMu sync.Mutex
+
+	mu      sync.Mutex
+	journal *os.File
+	// table is the last checkpoint, which may be nil, and changes are the
+	// entries changed since, where a nil entry is a deleted one. The entries
+	// of changes are replaced instead of modified, so snapshots can share them.
+	table   *table
+	changes map[entryKey]*entry
+	// count is the number of stored blobs, used and trashed are the sizes of
+	// the blobs out of and in the trash.
+	count, used, trashed int64
+	logs                 map[uint32]*logFile
+	// idle are the log files, which records can be appended to and which
+	// no writer holds.
+	idle    []*logFile
+	nextLog uint32
+	// journaled is the number of entries in the journals since the checkpoint.
+	journaled int
+	// checkpointing is set while a write checkpoints the namespace, because
+	// the changes reached maxChanges.
+	checkpointing bool
+	// written and synced are the sequence numbers of the last write to the
+	// journal and of the last write known to be synced.
+	written, synced uint64
+}
+
+// newNamespace returns an empty namespace stored in dir.
+func newNamespace(log *zap.Logger, id []byte, dir string) *namespace {
+	return &namespace{
+		log:     log,
+		id:      id,
+		dir:     dir,
+		changes: map[entryKey]*entry{},
+		logs:    map[uint32]*logFile{},
+		nextLog: 1,
+	}
+}
+
+// openNamespace loads the log files and the index of the namespace stored
+// in dir. The directory doesn't have to exist yet.
+func openNamespace(log *zap.Logger, id []byte, dir string) (_ *namespace, err error) {
+	ns := newNamespace(log, id, dir)
+	defer func() {
+		if err != nil && ns.table != nil {
+			err = errs.Combine(err, ns.table.file.Close())
+		}
+	}()
+
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, info := range infos {
+		if info.IsDir() || !strings.HasSuffix(info.Name(), logFileSuffix) {
+			continue
+		}
+		id, err := strconv.ParseUint(strings.TrimSuffix(info.Name(), logFileSuffix), 10, 32)
+		if err != nil {
+			continue
+		}
+		// records may be appended to the log files found, which are sealed
+		// when they are acquired and already full. Anything after the last
+		// committed record was left by a crash and is dead.
+		logFile := &logFile{
+			id:   uint32(id),
+			path: filepath.Join(dir, info.Name()),
+			size: info.Size(),
+		}
+		ns.logs[logFile.id] = logFile
+		ns.idle = append(ns.idle, logFile)
+		if logFile.id >= ns.nextLog {
+			ns.nextLog = logFile.id + 1
+		}
+	}
+
+	ns.table, err = openTable(filepath.Join(dir, checkpointFileName))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if ns.table != nil {
+		ns.count, ns.used, ns.trashed = ns.table.stats.count, ns.table.stats.used, ns.table.stats.trashed
+		for id, tableLog := range ns.table.stats.logs {
+			if logFile, ok := ns.logs[id]; ok {
+				logFile.live = tableLog.live
+			}
+		}
+	}
+
+	// the journals are replayed on top of the checkpoint. A journal may
+	// already be included in the checkpoint, when the node stopped before
+	// removing it, which is fine because replaying is idempotent.
+	for _, name := range []string{previousJournalFileName, journalFileName} {
+		err = replayIndex(filepath.Join(dir, name), func(entry indexEntry) error {
+			ns.journaled++
+			if err := ns.load(entry.entryKey()); err != nil {
+				return err
+			}
+			ns.apply(entry)
+			return nil
+		})
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+
+	// the data of a blob is synced before it's recorded, so data is only
+	// missing when log files were lost or truncated. The whole table is only
+	// checked when the log files don't match the checkpoint.
+	damaged := false
+	if ns.table != nil {
+		for id, tableLog := range ns.table.stats.logs {
+			if logFile, ok := ns.logs[id]; !ok || tableLog.end > logFile.size {
+				damaged = true
+			}
+		}
+	}
+
+	var missing []entryKey
+	check := func(key entryKey, found entry) (bool, error) {
+		logFile, ok := ns.logs[found.log]
+		if !ok || found.offset+found.recordSize(key.key) > logFile.size {
+			log.Warn("dropping blob with missing data from index",
+				zap.Binary("Namespace", id), zap.Binary("Key", []byte(key.key)), zap.Uint32("Log", found.log))
+			missing = append(missing, key)
+		}
+		return true, nil
+	}
+	if damaged {
+		snapshot := ns.snapshot()
+		err = snapshot.walk(entryKey{}, check)
+		snapshot.release()
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		for key, found := range ns.changes {
+			if found != nil {
+				_, _ = check(key, *found)
+			}
+		}
+	}
+	for _, key := range missing {
+		if err := ns.load(key); err != nil {
+			return nil, err
+		}
+		ns.unlink(key)
+	}
+
+	return ns, nil
+}
+
+// get returns the entry of the blob. ns.mu must be held.
+func (ns *namespace) get(key entryKey) (_ entry, ok bool, err error) {
+	if found, ok := ns.changes[key]; ok {
+		if found == nil {
+			return entry{}, false, nil
+		}
+		return *found, true, nil
+	}
+	if ns.table == nil {
+		return entry{}, false, nil
+	}
+	return ns.table.find(key)
+}
+
+// find returns the entry of the blob like get, but doesn't hold ns.mu while
+// reading the table. ns.mu must not be held.
+func (ns *namespace) find(key entryKey) (_ entry, ok bool, err error) {
+	ns.mu.Lock()
+	if found, ok := ns.changes[key]; ok {
+		ns.mu.Unlock()
+		if found == nil {
+			return entry{}, false, nil
+		}
+		return *found, true, nil
+	}
+	table := ns.acquireTable()
+	ns.mu.Unlock()
+
+	if table == nil {
+		return entry{}, false, nil
+	}
+	defer ns.releaseTable(table)
+	return table.find(key)
+}
+
+// load copies the entry of the blob from the table to the changes, so that
+// it can be changed. ns.mu must be held.
+func (ns *namespace) load(key entryKey) error {
+	if _, ok := ns.changes[key]; ok || ns.table == nil {
+		return nil
+	}
+	found, ok, err := ns.table.find(key)
+	if err != nil {
+		return err
+	}
+	if ok {
+		ns.changes[key] = &found
+	} else {
+		ns.changes[key] = nil
+	}
+	return nil
+}
+
+// apply applies an index entry to the state of the namespace. The entry of
+// the blob must be loaded. ns.mu must be held.
+func (ns *namespace) apply(indexEntry indexEntry) {
+	key := indexEntry.entryKey()
+
+	switch indexEntry.op {
+	case opPut:
+		found := entryOf(&indexEntry)
+		ns.unlink(key)
+		ns.link(key, &found)
+	case opDelete:
+		ns.unlink(key)
+	case opTrash, opRestore:
+		found := ns.changes[key]
+		if found == nil {
+			return
+		}
+		changed := *found
+		changed.trashedAt = time.Time{}
+		if indexEntry.op == opTrash {
+			changed.trashedAt = indexEntry.trashedAt
+		}
+		ns.unlink(key)
+		ns.link(key, &changed)
+	}
+}
+
+// link adds the blob to the state of the namespace. ns.mu must be held.
+func (ns *namespace) link(key entryKey, found *entry) {
+	ns.changes[key] = found
+	ns.count++
+	if found.trashedAt.IsZero() {
+		ns.used += found.size
+	} else {
+		ns.trashed += found.size
+	}
+	if logFile, ok := ns.logs[found.log]; ok {
+		logFile.live += found.recordSize(key.key)
+	}
+}
+
+// unlink removes the loaded blob from the state of the namespace.
+// ns.mu must be held.
+func (ns *namespace) unlink(key entryKey) {
+	found := ns.changes[key]
+	if found == nil {
+		return
+	}
+	ns.changes[key] = nil
+	ns.count--
+	if found.trashedAt.IsZero() {
+		ns.used -= found.size
+	} else {
+		ns.trashed -= found.size
+	}
+	if logFile, ok := ns.logs[found.log]; ok {
+		logFile.live -= found.recordSize(key.key)
+	}
+}
+
+// acquireTable returns the table, which is kept open until it's released.
+// ns.mu must be held.
+func (ns *namespace) acquireTable() *table {
+	if ns.table != nil {
+		ns.table.refs++
+	}
+	return ns.table
+}
+
+// releaseTable releases the acquired table. ns.mu must not be held.
+func (ns *namespace) releaseTable(table *table) {
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+
+	table.refs--
+	if table.retired && table.refs == 0 {
+		_ = table.file.Close()
+	}
+}
+
+// retireTable closes the table, which isn't the checkpoint of the namespace
+// anymore, once it's released. ns.mu must be held.
+func (ns *namespace) retireTable(table *table) {
+	table.retired = true
+	if table.refs == 0 {
+		_ = table.file.Close()
+	}
+}
+
+// snapshot is a view of the entries of a namespace at some point, which can
+// be read without holding the mutex of the namespace.
+type snapshot struct {
+	ns      *namespace
+	table   *table
+	changes []change
+}
+
+// snapshot returns a snapshot of the entries, which must be released.
+// ns.mu must be held.
+func (ns *namespace) snapshot() *snapshot {
+	changes := make([]change, 0, len(ns.changes))
+	for key, found := range ns.changes {
+		changes = append(changes, change{key: key, entry: found})
+	}
+	sort.Slice(changes, func(i, k int) bool { return compareKeys(changes[i].key, changes[k].key) < 0 })
+	return &snapshot{ns: ns, table: ns.acquireTable(), changes: changes}
+}
+
+// walk calls fn for the entries from the first key not less than from in key
+// order, until fn returns false.
+func (snapshot *snapshot) walk(from entryKey, fn func(key entryKey, found entry) (bool, error)) error {
+	return mergeEntries(snapshot.table, snapshot.changes, from, fn)
+}
+
+// release releases the table of the snapshot. ns.mu must not be held.
+func (snapshot *snapshot) release() {
+	if snapshot.table != nil {
+		snapshot.ns.releaseTable(snapshot.table)
+	}
+}
+
+// record appends the entries to the journal and applies them. The entries
+// are durable once commit returns for the returned sequence number.
+// ns.mu must be held.
+func (ns *namespace) record(entries ...indexEntry) (seq uint64, err error) {
+	if len(entries) == 0 {
+		return 0, nil
+	}
+
+	// the entries of the blobs are loaded before the journal is written, so
+	// that applying the entries can't fail.
+	for i := range entries {
+		if err := ns.load(entries[i].entryKey()); err != nil {
+			return 0, err
+		}
+	}
+
+	if ns.journal == nil {
+		if err := os.MkdirAll(ns.dir, dirPermission); err != nil {
+			return 0, err
+		}
+		ns.journal, err = os.OpenFile(filepath.Join(ns.dir, journalFileName), os.O_WRONLY|os.O_CREATE|os.O_APPEND, filePermission)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	var buf []byte
+	for i := range entries {
+		buf = entries[i].encode(buf)
+	}
+	if _, err := ns.journal.Write(buf); err != nil {
+		return 0, err
+	}
+	ns.written++
+
+	for _, entry := range entries {
+		ns.journaled++
+		ns.apply(entry)
+	}
+	return ns.written, nil
+}
+
+// commit syncs the journal up to the write with the sequence number seq.
+// Callers which arrive while a sync is in progress wait for it and are synced
+// together by the next one, so that concurrent operations share a sync.
+// None of the mutexes of the namespace may be held.
+func (ns *namespace) commit(seq uint64) error {
+	if err := ns.sync(seq); err != nil {
+		return err
+	}
+
+	if err := ns.checkpointIfFull(); err != nil {
+		// the entries are durable in the journal, so the checkpoint is only
+		// retried by the next write or compaction.
+		ns.log.Error("checkpointing index failed", zap.Binary("Namespace", ns.id), zap.Error(err))
+	}
+	return nil
+}
+
+// sync syncs the journal up to the write with the sequence number seq.
+func (ns *namespace) sync(seq uint64) error {
+	ns.syncMu.Lock()
+	defer ns.syncMu.Unlock()
+
+	ns.mu.Lock()
+	if ns.synced >= seq {
+		ns.mu.Unlock()
+		return nil
+	}
+	journal, written := ns.journal, ns.written
+	ns.mu.Unlock()
+
+	if err := journal.Sync(); err != nil {
+		return err
+	}
+
+	ns.mu.Lock()
+	ns.synced = written
+	ns.mu.Unlock()
+	return nil
+}
+
+// acquire returns a log file to append a record to, which the caller holds
+// until it calls release. Log files which reached maxLogSize are sealed and a
+// new log file is started when no other is idle. ns.mu must be held.
+func (ns *namespace) acquire(maxLogSize int64) (_ *logFile, err error) {
+	for len(ns.idle) > 0 {
+		logFile := ns.idle[len(ns.idle)-1]
+		ns.idle = ns.idle[:len(ns.idle)-1]
+
+		if logFile.size >= maxLogSize {
+			ns.closeLog(logFile)
+			continue
+		}
+		if logFile.file == nil {
+			logFile.file, err = os.OpenFile(logFile.path, os.O_RDWR, filePermission)
+			if err != nil {
+				return nil, err
+			}
+		}
+		logFile.held = true
+		return logFile, nil
+	}
+
+	if err := os.MkdirAll(ns.dir, dirPermission); err != nil {
+		return nil, err
+	}
+
+	id := ns.nextLog
+	path := ns.logPath(id)
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_EXCL, filePermission)
+	if err != nil {
+		return nil, err
+	}
+	ns.nextLog++
+
+	logFile := &logFile{id: id, path: path, file: file, held: true}
+	ns.logs[id] = logFile
+	return logFile, nil
+}
+
+// logPath returns the path of the log file with the given id.
+func (ns *namespace) logPath(id uint32) string {
+	return filepath.Join(ns.dir, fmt.Sprintf("%010d%s", id, logFileSuffix))
+}
+
+// release returns the held log file, which is sealed when it reached
+// maxLogSize. ns.mu must be held.
+func (ns *namespace) release(logFile *logFile, maxLogSize int64) {
+	logFile.held = false
+	if logFile.file == nil {
+		// the namespace was closed in the meantime.
+		return
+	}
+	if logFile.size >= maxLogSize {
+		ns.closeLog(logFile)
+		return
+	}
+	ns.idle = append(ns.idle, logFile)
+}
+
+// rollback discards the partially written record at the end of the held log
+// file and releases it. ns.mu must be held.
+func (ns *namespace) rollback(logFile *logFile, file *os.File, maxLogSize int64) error {
+	err := file.Truncate(logFile.size)
+	ns.release(logFile, maxLogSize)
+	return err
+}
+
+// retire stops appending to the log file, which must not be held.
+// ns.mu must be held.
+func (ns *namespace) retire(logFile *logFile) {
+	for i, idle := range ns.idle {
+		if idle == logFile {
+			ns.idle = append(ns.idle[:i], ns.idle[i+1:]...)
+			break
+		}
+	}
+	ns.closeLog(logFile)
+}
+
+// closeLog closes the log file for appending.
+func (ns *namespace) closeLog(logFile *logFile) {
+	if logFile.file != nil {
+		// the records are synced when they are committed.
+		_ = logFile.file.Close()
+		logFile.file = nil
+	}
+}
+
+// checkpointIfFull writes a new checkpoint, when the changes since the last
+// one reached maxChanges. None of the mutexes of the namespace may be held.
+func (ns *namespace) checkpointIfFull() error {
+	ns.mu.Lock()
+	full := len(ns.changes) >= maxChanges && !ns.checkpointing
+	ns.checkpointing = ns.checkpointing || full
+	ns.mu.Unlock()
+	if !full {
+		return nil
+	}
+
+	defer func() {
+		ns.mu.Lock()
+		ns.checkpointing = false
+		ns.mu.Unlock()
+	}()
+
+	ns.checkpointMu.Lock()
+	defer ns.checkpointMu.Unlock()
+	return ns.checkpoint()
+}
+
+// checkpoint merges the changes into a new table and starts a new journal,
+// so that opening the namespace doesn't replay every operation since the
+// namespace was created and the changes don't have to be kept in memory.
+// The previous journal is kept until the checkpoint is durable.
+// ns.checkpointMu must be held, ns.syncMu and ns.mu must not.
+func (ns *namespace) checkpoint() (err error) {
+	ns.syncMu.Lock()
+	ns.mu.Lock()
+	snapshot := ns.snapshot()
+	err = ns.rotateJournal()
+	ns.mu.Unlock()
+	ns.syncMu.Unlock()
+	defer snapshot.release()
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(ns.dir, checkpointFileName)
+	tempPath := path + ".tmp"
+
+	writer, err := createTable(tempPath)
+	if err != nil {
+		return err
+	}
+	err = snapshot.walk(entryKey{}, func(key entryKey, found entry) (bool, error) {
+		return true, writer.add(key, &found)
+	})
+	if err != nil {
+		return errs.Combine(err, writer.abort(), os.Remove(tempPath))
+	}
+	if err := writer.finish(); err != nil {
+		return errs.Combine(err, os.Remove(tempPath))
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		return err
+	}
+
+	table, err := openTable(path)
+	if err != nil {
+		return err
+	}
+
+	// the changes included in the table are dropped, unless they changed
+	// again in the meantime.
+	ns.mu.Lock()
+	if ns.table != nil {
+		ns.retireTable(ns.table)
+	}
+	ns.table = table
+	for _, change := range snapshot.changes {
+		if current, ok := ns.changes[change.key]; ok && current == change.entry {
+			delete(ns.changes, change.key)
+		}
+	}
+	ns.mu.Unlock()
+
+	err = os.Remove(filepath.Join(ns.dir, previousJournalFileName))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// rotateJournal moves the journal aside as the previous journal, so that the
+// entries recorded from now on are not included in the next checkpoint.
+// ns.syncMu and ns.mu must be held.
+func (ns *namespace) rotateJournal() error {
+	if ns.journal != nil {
+		err := errs.Combine(ns.journal.Sync(), ns.journal.Close())
+		ns.journal = nil
+		if err != nil {
+			return err
+		}
+		ns.synced = ns.written
+	}
+
+	path := filepath.Join(ns.dir, journalFileName)
+	previousPath := filepath.Join(ns.dir, previousJournalFileName)
+
+	if _, err := os.Stat(previousPath); err == nil {
+		// writing the last checkpoint failed, so the previous journal isn't
+		// included in any checkpoint yet and the journal is appended to it.
+		if err := appendFile(previousPath, path); err != nil {
+			return err
+		}
+	} else if err := os.Rename(path, previousPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	ns.journaled = 0
+	return nil
+}
+
+// appendFile appends the file at path to the file at dst and removes it.
+func appendFile(dst, path string) (err error) {
+	source, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer func() { err = errs.Combine(err, source.Close()) }()
+
+	target, err := os.OpenFile(dst, os.O_WRONLY|os.O_APPEND, filePermission)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(target, source)
+	err = errs.Combine(err, target.Sync(), target.Close())
+	if err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
+
+// close syncs and closes the open files of the namespace and forgets its
+// entries. ns.checkpointMu, ns.syncMu and ns.mu must be held.
+func (ns *namespace) close() error {
+	var group errs.Group
+	if ns.journal != nil {
+		group.Add(ns.journal.Sync(), ns.journal.Close())
+		ns.journal = nil
+	}
+	ns.synced = ns.written
+	if ns.table != nil {
+		ns.retireTable(ns.table)
+		ns.table = nil
+	}
+	ns.changes = map[entryKey]*entry{}
+	ns.count, ns.used, ns.trashed = 0, 0, 0
+	for _, logFile := range ns.logs {
+		ns.closeLog(logFile)
+	}
+	ns.idle = nil
+	return group.Err()
+}
+
+// recordHeader returns the header of the record of a blob, followed by its key.
+func recordHeader(key string, formatVersion storage.FormatVersion, size int64) []byte {
+	header := make([]byte, recordHeaderSize, recordHeaderSize+len(key))
+	binary.LittleEndian.PutUint32(header[0:], recordMagic)
+	binary.LittleEndian.PutUint16(header[4:], uint16(formatVersion))
+	binary.LittleEndian.PutUint16(header[6:], uint16(len(key)))
+	binary.LittleEndian.PutUint64(header[8:], uint64(size))
+	return append(header, key...)
+}
+
+// writeRecord writes the record of the blob, read from data, at offset of the
+// log file and syncs it to disk.
+func writeRecord(file *os.File, offset int64, key string, formatVersion storage.FormatVersion, data io.Reader, size int64) error {
+	header := recordHeader(key, formatVersion, size)
+
+	if _, err := file.WriteAt(header, offset); err != nil {
+		return err
+	}
+
+	written, err := io.Copy(&offsetWriter{file: file, offset: offset + int64(len(header))}, io.LimitReader(data, size))
+	if err != nil {
+		return err
+	}
+	if written != size {
+		return errs.New("short write of blob: %d of %d bytes", written, size)
+	}
+
+	return file.Sync()
+}
+
+// offsetWriter writes sequentially to a file starting at an offset.
+type offsetWriter struct {
+	file   *os.File
+	offset int64
+}
+
+// Write writes p at the current offset.
+func (w *offsetWriter) Write(p []byte) (int, error) {
+	n, err := w.file.WriteAt(p, w.offset)
+	w.offset += int64(n)
+	return n, err
+}
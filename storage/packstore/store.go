@@ -0,0 +1,751 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package packstore implements a blob store, which appends blobs to large log
+// files and keeps their locations in an on-disk index, instead of storing
+// every blob in its own file.
+package packstore
+
+import (
+	"context"
+	"encoding/base32"
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/memory"
+	"storj.io/common/storj"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/filestore"
+)
+
+var (
+	// Error is the default packstore error class.
+	Error = errs.Class("packstore")
+
+	mon = monkit.Package()
+
+	_ storage.Blobs       = (*blobStore)(nil)
+	_ storage.TrashStater = (*blobStore)(nil)
+	_ storage.BlobExtent  = (*blobInfo)(nil)
+
+	pathEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+)
+
+// Config is configuration for the packed blob store.
+type Config struct {
+	Enabled             bool          `help:"store pieces appended to packed log files with an index instead of one file per piece" default:"false"`
+	WriteBufferSize     memory.Size   `help:"in-memory buffer for uploads" default:"128KiB"`
+	MaxLogSize          memory.Size   `help:"size at which a log file is sealed and a new one is started" default:"1GiB"`
+	CompactionThreshold float64       `help:"fraction of unreferenced bytes at which a log file is compacted" default:"0.25"`
+	CompactionInterval  time.Duration `help:"how often to compact log files and remove unreferenced ones" default:"1h"`
+}
+
+// DefaultConfig is the default value for Config.
+var DefaultConfig = Config{
+	WriteBufferSize:     128 * memory.KiB,
+	MaxLogSize:          memory.GiB,
+	CompactionThreshold: 0.25,
+	CompactionInterval:  time.Hour,
+}
+
+// blobStore implements a blob store, which packs the blobs of every namespace
+// into log files under the "packed" directory.
+type blobStore struct {
+	log    *zap.Logger
+	dir    *filestore.Dir
+	config Config
+
+	mu         sync.Mutex
+	namespaces map[string]*namespace
+}
+
+// New creates a new packed blob store in the specified directory. The index
+// of every namespace found in the directory is loaded.
+func New(log *zap.Logger, dir *filestore.Dir, config Config) (storage.Blobs, error) {
+	store := &blobStore{
+		log:        log,
+		dir:        dir,
+		config:     config,
+		namespaces: map[string]*namespace{},
+	}
+
+	if err := os.MkdirAll(store.packedDir(), dirPermission); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	infos, err := ioutil.ReadDir(store.packedDir())
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	for _, info := range infos {
+		id, err := pathEncoding.DecodeString(info.Name())
+		if err != nil || !info.IsDir() {
+			continue
+		}
+		ns, err := openNamespace(log, id, filepath.Join(store.packedDir(), info.Name()))
+		if err != nil {
+			return nil, Error.Wrap(errs.Combine(err, store.Close()))
+		}
+		store.namespaces[string(id)] = ns
+	}
+
+	return store, nil
+}
+
+// NewAt creates a new packed blob store in the specified directory.
+func NewAt(log *zap.Logger, path string, config Config) (storage.Blobs, error) {
+	dir, err := filestore.NewDir(log, path)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return New(log, dir, config)
+}
+
+// packedDir is the sub-directory containing a directory of log files for every namespace.
+func (store *blobStore) packedDir() string { return filepath.Join(store.dir.Path(), "packed") }
+
+// namespace returns the namespace with the given id. When create is false and
+// the namespace doesn't exist, nil is returned.
+func (store *blobStore) namespace(id []byte, create bool) *namespace {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	ns, ok := store.namespaces[string(id)]
+	if !ok && create {
+		ns = newNamespace(store.log, append([]byte{}, id...), filepath.Join(store.packedDir(), pathEncoding.EncodeToString(id)))
+		store.namespaces[string(id)] = ns
+	}
+	return ns
+}
+
+// allNamespaces returns all namespaces of the store.
+func (store *blobStore) allNamespaces() []*namespace {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	namespaces := make([]*namespace, 0, len(store.namespaces))
+	for _, ns := range store.namespaces {
+		namespaces = append(namespaces, ns)
+	}
+	return namespaces
+}
+
+// Close closes the store.
+func (store *blobStore) Close() error {
+	var group errs.Group
+	for _, ns := range store.allNamespaces() {
+		ns.checkpointMu.Lock()
+		ns.syncMu.Lock()
+		ns.mu.Lock()
+		group.Add(ns.close())
+		ns.mu.Unlock()
+		ns.syncMu.Unlock()
+		ns.checkpointMu.Unlock()
+	}
+	return Error.Wrap(group.Err())
+}
+
+// Create creates a new blob that can be written. The blob is appended to a
+// log file directly, so the size is not needed.
+func (store *blobStore) Create(ctx context.Context, ref storage.BlobRef, size int64) (_ storage.BlobWriter, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return store.create(ctx, ref, filestore.MaxFormatVersionSupported)
+}
+
+// TestCreateV0 creates a new V0 blob that can be written. This is ONLY appropriate in test situations.
+func (store *blobStore) TestCreateV0(ctx context.Context, ref storage.BlobRef) (_ storage.BlobWriter, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return store.create(ctx, ref, filestore.FormatV0)
+}
+
+// create creates a new blob with the given storage format version, which
+// holds a log file of the namespace until it's committed or canceled.
+func (store *blobStore) create(ctx context.Context, ref storage.BlobRef, formatVersion storage.FormatVersion) (_ storage.BlobWriter, err error) {
+	if !ref.IsValid() {
+		return nil, storage.ErrInvalidBlobRef.New("")
+	}
+
+	ns := store.namespace(ref.Namespace, true)
+
+	ns.mu.Lock()
+	logFile, err := ns.acquire(store.config.MaxLogSize.Int64())
+	var file *os.File
+	var offset int64
+	if err == nil {
+		file, offset = logFile.file, logFile.size
+	}
+	ns.mu.Unlock()
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return newBlobWriter(ref, store, ns, formatVersion, logFile, file, offset, store.config.WriteBufferSize.Int()), nil
+}
+
+// put completes the record of the blob, which was written after its header
+// at offset of the held log file, and records the blob in the index. The log
+// file is released and, when the record can't be completed, rolled back.
+func (store *blobStore) put(ctx context.Context, ns *namespace, ref storage.BlobRef, formatVersion storage.FormatVersion, logFile *logFile, file *os.File, offset, size, written int64) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	key := string(ref.Key)
+	end := offset + recordHeaderSize + int64(len(key)) + size
+
+	_, writeErr := file.WriteAt(recordHeader(key, formatVersion, size), offset)
+	if writeErr == nil && written != size {
+		// the blob was seeked back or forth and doesn't end at the last write.
+		writeErr = file.Truncate(end)
+	}
+	if writeErr == nil {
+		writeErr = file.Sync()
+	}
+
+	maxLogSize := store.config.MaxLogSize.Int64()
+
+	ns.mu.Lock()
+	if writeErr != nil {
+		err := ns.rollback(logFile, file, maxLogSize)
+		ns.mu.Unlock()
+		return errs.Combine(writeErr, err)
+	}
+
+	logFile.size = end
+	ns.release(logFile, maxLogSize)
+
+	seq, err := ns.record(indexEntry{
+		op:            opPut,
+		key:           ref.Key,
+		formatVersion: formatVersion,
+		log:           logFile.id,
+		offset:        offset,
+		size:          size,
+		modTime:       time.Now(),
+	})
+	ns.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	return ns.commit(seq)
+}
+
+// cancel rolls back the record of the blob written to the held log file and
+// releases the log file.
+func (store *blobStore) cancel(ctx context.Context, ns *namespace, logFile *logFile, file *os.File) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	return ns.rollback(logFile, file, store.config.MaxLogSize.Int64())
+}
+
+// lookup returns the location of the stored blob, which is not in the trash.
+func (store *blobStore) lookup(ref storage.BlobRef, formatVersion storage.FormatVersion) (logPath string, _ entry, ok bool, err error) {
+	ns := store.namespace(ref.Namespace, false)
+	if ns == nil {
+		return "", entry{}, false, nil
+	}
+
+	found, ok, err := ns.find(entryKey{key: string(ref.Key), formatVersion: formatVersion})
+	if err != nil || !ok || !found.trashedAt.IsZero() {
+		return "", entry{}, false, err
+	}
+	return ns.logPath(found.log), found, true, nil
+}
+
+// Open loads blob with the specified hash.
+func (store *blobStore) Open(ctx context.Context, ref storage.BlobRef) (_ storage.BlobReader, err error) {
+	defer mon.Task()(&ctx)(&err)
+	for formatVersion := filestore.MaxFormatVersionSupported; formatVersion >= filestore.MinFormatVersionSupported; formatVersion-- {
+		reader, err := store.OpenWithStorageFormat(ctx, ref, formatVersion)
+		if !os.IsNotExist(err) {
+			return reader, err
+		}
+	}
+	return nil, os.ErrNotExist
+}
+
+// OpenWithStorageFormat loads the already-located blob, avoiding the potential need to check multiple
+// storage formats to find the blob.
+func (store *blobStore) OpenWithStorageFormat(ctx context.Context, ref storage.BlobRef, formatVersion storage.FormatVersion) (_ storage.BlobReader, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	// compaction may move the blob to another log file and remove the one
+	// just looked up, so retry the lookup when that happens.
+	for attempt := 0; attempt < 3; attempt++ {
+		logPath, found, ok, err := store.lookup(ref, formatVersion)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		if !ok {
+			return nil, os.ErrNotExist
+		}
+
+		file, err := os.Open(logPath)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, Error.Wrap(err)
+		}
+		return newBlobReader(file, found.dataOffset(string(ref.Key)), found.size, formatVersion), nil
+	}
+	return nil, os.ErrNotExist
+}
+
+// Stat looks up metadata of the blob.
+func (store *blobStore) Stat(ctx context.Context, ref storage.BlobRef) (_ storage.BlobInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+	for formatVersion := filestore.MaxFormatVersionSupported; formatVersion >= filestore.MinFormatVersionSupported; formatVersion-- {
+		info, err := store.StatWithStorageFormat(ctx, ref, formatVersion)
+		if !errs.IsFunc(err, os.IsNotExist) {
+			return info, err
+		}
+	}
+	return nil, Error.Wrap(os.ErrNotExist)
+}
+
+// StatWithStorageFormat looks up metadata of the blob with the given storage format version.
+func (store *blobStore) StatWithStorageFormat(ctx context.Context, ref storage.BlobRef, formatVersion storage.FormatVersion) (_ storage.BlobInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+	logPath, found, ok, err := store.lookup(ref, formatVersion)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if !ok {
+		return nil, Error.Wrap(os.ErrNotExist)
+	}
+	return newBlobInfo(ref, formatVersion, logPath, found), nil
+}
+
+// StatTrash looks up metadata of the blob in the trash.
+func (store *blobStore) StatTrash(ctx context.Context, ref storage.BlobRef) (_ storage.BlobInfo, trashedAt time.Time, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ns := store.namespace(ref.Namespace, false)
+	if ns == nil {
+		return nil, time.Time{}, Error.Wrap(os.ErrNotExist)
+	}
+
+	for formatVersion := filestore.MaxFormatVersionSupported; formatVersion >= filestore.MinFormatVersionSupported; formatVersion-- {
+		found, ok, err := ns.find(entryKey{key: string(ref.Key), formatVersion: formatVersion})
+		if err != nil {
+			return nil, time.Time{}, Error.Wrap(err)
+		}
+		if ok && !found.trashedAt.IsZero() {
+			return newBlobInfo(ref, formatVersion, ns.logPath(found.log), found), found.trashedAt, nil
+		}
+	}
+	return nil, time.Time{}, Error.Wrap(os.ErrNotExist)
+}
+
+// Delete deletes blobs with the specified ref.
+//
+// It doesn't return an error if the blob isn't found.
+func (store *blobStore) Delete(ctx context.Context, ref storage.BlobRef) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return Error.Wrap(store.deleteVersions(ref, allFormatVersions()...))
+}
+
+// DeleteWithStorageFormat deletes blobs with the specified ref and storage format version.
+func (store *blobStore) DeleteWithStorageFormat(ctx context.Context, ref storage.BlobRef, formatVersion storage.FormatVersion) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return Error.Wrap(store.deleteVersions(ref, formatVersion))
+}
+
+// deleteVersions deletes the given storage format versions of the blob.
+func (store *blobStore) deleteVersions(ref storage.BlobRef, formatVersions ...storage.FormatVersion) error {
+	ns := store.namespace(ref.Namespace, false)
+	if ns == nil {
+		return nil
+	}
+
+	ns.mu.Lock()
+	var deletes []indexEntry
+	for _, formatVersion := range formatVersions {
+		_, ok, err := ns.get(entryKey{key: string(ref.Key), formatVersion: formatVersion})
+		if err != nil {
+			ns.mu.Unlock()
+			return err
+		}
+		if ok {
+			deletes = append(deletes, indexEntry{op: opDelete, key: ref.Key, formatVersion: formatVersion})
+		}
+	}
+	seq, err := ns.record(deletes...)
+	ns.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	return ns.commit(seq)
+}
+
+// DeleteNamespace deletes the index and the log files of the namespace.
+func (store *blobStore) DeleteNamespace(ctx context.Context, ref []byte) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	store.mu.Lock()
+	ns, ok := store.namespaces[string(ref)]
+	delete(store.namespaces, string(ref))
+	store.mu.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	ns.checkpointMu.Lock()
+	defer ns.checkpointMu.Unlock()
+	ns.syncMu.Lock()
+	defer ns.syncMu.Unlock()
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	return Error.Wrap(errs.Combine(ns.close(), os.RemoveAll(ns.dir)))
+}
+
+// Trash marks all storage format versions of the blob as trashed.
+func (store *blobStore) Trash(ctx context.Context, ref storage.BlobRef) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ns := store.namespace(ref.Namespace, false)
+	if ns == nil {
+		return nil
+	}
+
+	ns.mu.Lock()
+	now := time.Now()
+	var trashes []indexEntry
+	for _, formatVersion := range allFormatVersions() {
+		found, ok, err := ns.get(entryKey{key: string(ref.Key), formatVersion: formatVersion})
+		if err != nil {
+			ns.mu.Unlock()
+			return Error.Wrap(err)
+		}
+		if ok && found.trashedAt.IsZero() {
+			trashes = append(trashes, indexEntry{op: opTrash, key: ref.Key, formatVersion: formatVersion, trashedAt: now})
+		}
+	}
+	seq, err := ns.record(trashes...)
+	ns.mu.Unlock()
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	return Error.Wrap(ns.commit(seq))
+}
+
+// RestoreTrash restores every blob of the namespace in the trash and returns their keys.
+func (store *blobStore) RestoreTrash(ctx context.Context, namespace []byte) (keysRestored [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ns := store.namespace(namespace, false)
+	if ns == nil {
+		return nil, nil
+	}
+
+	trashed, err := ns.trashedBlobs(func(time.Time) bool { return true })
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	ns.mu.Lock()
+	var restores []indexEntry
+	for key := range trashed {
+		// the blob may have changed since the walk.
+		found, ok, err := ns.get(key)
+		if err != nil {
+			ns.mu.Unlock()
+			return nil, Error.Wrap(err)
+		}
+		if ok && !found.trashedAt.IsZero() {
+			restores = append(restores, indexEntry{op: opRestore, key: []byte(key.key), formatVersion: key.formatVersion})
+			keysRestored = append(keysRestored, []byte(key.key))
+		}
+	}
+	seq, err := ns.record(restores...)
+	ns.mu.Unlock()
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	if err := ns.commit(seq); err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return keysRestored, nil
+}
+
+// EmptyTrash deletes the blobs of the namespace, which were trashed before
+// trashedBefore, and returns their total size and keys.
+func (store *blobStore) EmptyTrash(ctx context.Context, namespace []byte, trashedBefore time.Time) (bytesEmptied int64, keys [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ns := store.namespace(namespace, false)
+	if ns == nil {
+		return 0, nil, nil
+	}
+
+	trashed, err := ns.trashedBlobs(func(trashedAt time.Time) bool { return trashedAt.Before(trashedBefore) })
+	if err != nil {
+		return 0, nil, Error.Wrap(err)
+	}
+
+	ns.mu.Lock()
+	var deletes []indexEntry
+	for key, trashedAt := range trashed {
+		// the blob may have changed since the walk.
+		found, ok, err := ns.get(key)
+		if err != nil {
+			ns.mu.Unlock()
+			return 0, nil, Error.Wrap(err)
+		}
+		if ok && found.trashedAt.Equal(trashedAt) {
+			deletes = append(deletes, indexEntry{op: opDelete, key: []byte(key.key), formatVersion: key.formatVersion})
+			keys = append(keys, []byte(key.key))
+			bytesEmptied += found.size
+		}
+	}
+	seq, err := ns.record(deletes...)
+	ns.mu.Unlock()
+	if err != nil {
+		return 0, nil, Error.Wrap(err)
+	}
+
+	if err := ns.commit(seq); err != nil {
+		return 0, nil, Error.Wrap(err)
+	}
+	return bytesEmptied, keys, nil
+}
+
+// SpaceUsedForBlobs adds up the space used by blobs in all namespaces.
+func (store *blobStore) SpaceUsedForBlobs(ctx context.Context) (total int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	for _, ns := range store.allNamespaces() {
+		total += ns.spaceUsed(false)
+	}
+	return total, nil
+}
+
+// SpaceUsedForBlobsInNamespace adds up the space used by blobs in the given namespace.
+func (store *blobStore) SpaceUsedForBlobsInNamespace(ctx context.Context, namespace []byte) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	ns := store.namespace(namespace, false)
+	if ns == nil {
+		return 0, nil
+	}
+	return ns.spaceUsed(false), nil
+}
+
+// SpaceUsedForTrash returns the total space used by the trash.
+func (store *blobStore) SpaceUsedForTrash(ctx context.Context) (total int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	for _, ns := range store.allNamespaces() {
+		total += ns.spaceUsed(true)
+	}
+	return total, nil
+}
+
+// trashedBlobs returns when the blobs in the trash, for which include returns
+// true, were trashed.
+func (ns *namespace) trashedBlobs(include func(trashedAt time.Time) bool) (map[entryKey]time.Time, error) {
+	ns.mu.Lock()
+	snapshot := ns.snapshot()
+	ns.mu.Unlock()
+	defer snapshot.release()
+
+	trashed := map[entryKey]time.Time{}
+	err := snapshot.walk(entryKey{}, func(key entryKey, found entry) (bool, error) {
+		if !found.trashedAt.IsZero() && include(found.trashedAt) {
+			trashed[key] = found.trashedAt
+		}
+		return true, nil
+	})
+	return trashed, err
+}
+
+// spaceUsed returns the size of the blobs in or out of the trash.
+func (ns *namespace) spaceUsed(trashed bool) int64 {
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+
+	if trashed {
+		return ns.trashed
+	}
+	return ns.used
+}
+
+// FreeSpace returns how much space left in underlying directory.
+func (store *blobStore) FreeSpace(ctx context.Context) (int64, error) {
+	info, err := store.dir.Info(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return info.AvailableSpace, nil
+}
+
+// CheckWritability tests writability of the storage directory by creating and deleting a file.
+func (store *blobStore) CheckWritability(ctx context.Context) error {
+	f, err := ioutil.TempFile(store.packedDir(), "write-test")
+	if err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Remove(f.Name())
+}
+
+// ListNamespaces finds all namespaces with an index. They are not guaranteed
+// to contain any blobs.
+func (store *blobStore) ListNamespaces(ctx context.Context) (ids [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+	for _, ns := range store.allNamespaces() {
+		ids = append(ids, ns.id)
+	}
+	return ids, nil
+}
+
+// WalkNamespace executes walkFunc for each blob in the given namespace, which
+// is not in the trash. If walkFunc returns a non-nil error, WalkNamespace will
+// stop iterating and return the error immediately. Unlike walking the files of
+// the file-per-piece layout, this only reads the index.
+func (store *blobStore) WalkNamespace(ctx context.Context, namespace []byte, walkFunc func(storage.BlobInfo) error) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ns := store.namespace(namespace, false)
+	if ns == nil {
+		return nil
+	}
+
+	ns.mu.Lock()
+	snapshot := ns.snapshot()
+	ns.mu.Unlock()
+	defer snapshot.release()
+
+	return snapshot.walk(entryKey{}, func(key entryKey, found entry) (bool, error) {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+		if !found.trashedAt.IsZero() {
+			return true, nil
+		}
+		return true, walkFunc(ns.blobInfo(key, found))
+	})
+}
+
+// WalkNamespaceAfterPrefix executes walkFunc for each blob in the given
+// namespace, which is not in the trash, grouped by the same key prefixes as
+// the directories of the file-per-piece layout. Prefixes up to and including
+// afterPrefix are skipped.
+func (store *blobStore) WalkNamespaceAfterPrefix(ctx context.Context, namespace []byte, afterPrefix string, walkFunc func(storage.BlobInfo) error, prefixDone func(prefix string) error) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ns := store.namespace(namespace, false)
+	if ns == nil {
+		return nil
+	}
+
+	ns.mu.Lock()
+	snapshot := ns.snapshot()
+	ns.mu.Unlock()
+	defer snapshot.release()
+
+	for _, prefix := range keyPrefixes() {
+		if prefix.name <= afterPrefix {
+			continue
+		}
+
+		// the index is sorted by key, so the blobs of a prefix are next to
+		// each other, starting at the first key with its bits.
+		walked := false
+		err := snapshot.walk(entryKey{key: string(prefix.first())}, func(key entryKey, found entry) (bool, error) {
+			if keyPrefixBits([]byte(key.key)) != prefix.bits {
+				return false, nil
+			}
+			if err := ctx.Err(); err != nil {
+				return false, err
+			}
+			if !found.trashedAt.IsZero() {
+				return true, nil
+			}
+			walked = true
+			return true, walkFunc(ns.blobInfo(key, found))
+		})
+		if err != nil {
+			return err
+		}
+		if walked {
+			if err := prefixDone(prefix.name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// blobInfo returns the info of the blob.
+func (ns *namespace) blobInfo(key entryKey, found entry) storage.BlobInfo {
+	ref := storage.BlobRef{Namespace: ns.id, Key: []byte(key.key)}
+	return newBlobInfo(ref, key.formatVersion, ns.logPath(found.log), found)
+}
+
+// keyPrefix is the prefix of the keys in a directory of the file-per-piece
+// layout, which is made of the first 10 bits of the keys.
+type keyPrefix struct {
+	name string
+	bits uint16
+}
+
+// first returns the smallest key with the prefix.
+func (prefix keyPrefix) first() []byte {
+	var first [2]byte
+	binary.BigEndian.PutUint16(first[:], prefix.bits<<6)
+	return first[:]
+}
+
+// keyPrefixes returns all key prefixes in the order of their names.
+func keyPrefixes() []keyPrefix {
+	prefixes := make([]keyPrefix, 0, 1<<10)
+	for bits := uint16(0); bits < 1<<10; bits++ {
+		prefix := keyPrefix{bits: bits}
+		prefix.name = pathEncoding.EncodeToString(prefix.first())[:2]
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Slice(prefixes, func(i, k int) bool { return prefixes[i].name < prefixes[k].name })
+	return prefixes
+}
+
+// keyPrefixBits returns the first 10 bits of the key, which is padded with
+// zeros like its path encoding.
+func keyPrefixBits(key []byte) uint16 {
+	var padded [2]byte
+	copy(padded[:], key)
+	return binary.BigEndian.Uint16(padded[:]) >> 6
+}
+
+// CreateVerificationFile creates a file to be used for storage directory verification.
+func (store *blobStore) CreateVerificationFile(ctx context.Context, id storj.NodeID) error {
+	return store.dir.CreateVerificationFile(ctx, id)
+}
+
+// VerifyStorageDir verifies that the storage directory is correct by checking for the existence and validity
+// of the verification file.
+func (store *blobStore) VerifyStorageDir(ctx context.Context, id storj.NodeID) error {
+	return store.dir.Verify(ctx, id)
+}
+
+// allFormatVersions returns all supported storage format versions.
+func allFormatVersions() (formatVersions []storage.FormatVersion) {
+	for formatVersion := filestore.MinFormatVersionSupported; formatVersion <= filestore.MaxFormatVersionSupported; formatVersion++ {
+		formatVersions = append(formatVersions, formatVersion)
+	}
+	return formatVersions
+}
@@ -0,0 +1,514 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package packstore_test
+
+import (
+	"context"
+	"encoding/base32"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"golang.org/x/sync/errgroup"
+
+	"storj.io/common/memory"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/filestore"
+	"storj.io/storj/storage/packstore"
+)
+
+var pathEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+func writeBlob(ctx *testcontext.Context, t *testing.T, store storage.Blobs, ref storage.BlobRef, data []byte) {
+	writer, err := store.Create(ctx, ref, int64(len(data)))
+	require.NoError(t, err)
+	_, err = writer.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, writer.Commit(ctx))
+}
+
+func readBlob(ctx *testcontext.Context, t *testing.T, store storage.Blobs, ref storage.BlobRef) []byte {
+	reader, err := store.Open(ctx, ref)
+	require.NoError(t, err)
+	defer ctx.Check(reader.Close)
+
+	data, err := ioutil.ReadAll(reader)
+	require.NoError(t, err)
+	return data
+}
+
+func logFiles(t *testing.T, dir string) (paths []string) {
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && filepath.Ext(path) == ".log" {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	return paths
+}
+
+func TestReopen(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	namespace := testrand.Bytes(32)
+	kept := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(32)}
+	deleted := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(32)}
+	trashed := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(32)}
+	keptData := testrand.BytesInt(1000)
+
+	store, err := packstore.NewAt(zaptest.NewLogger(t), ctx.Dir("store"), packstore.DefaultConfig)
+	require.NoError(t, err)
+
+	writeBlob(ctx, t, store, kept, keptData)
+	writeBlob(ctx, t, store, deleted, testrand.BytesInt(100))
+	writeBlob(ctx, t, store, trashed, testrand.BytesInt(200))
+	require.NoError(t, store.Delete(ctx, deleted))
+	require.NoError(t, store.Trash(ctx, trashed))
+	require.NoError(t, store.Close())
+
+	store, err = packstore.NewAt(zaptest.NewLogger(t), ctx.Dir("store"), packstore.DefaultConfig)
+	require.NoError(t, err)
+	defer ctx.Check(store.Close)
+
+	assert.Equal(t, keptData, readBlob(ctx, t, store, kept))
+
+	_, err = store.Open(ctx, deleted)
+	require.True(t, os.IsNotExist(err))
+	_, err = store.Open(ctx, trashed)
+	require.True(t, os.IsNotExist(err))
+
+	used, err := store.SpaceUsedForBlobs(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(keptData)), used)
+	trash, err := store.SpaceUsedForTrash(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(200), trash)
+
+	restored, err := store.RestoreTrash(ctx, namespace)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{trashed.Key}, restored)
+}
+
+func TestTornIndex(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	namespace := testrand.Bytes(32)
+	first := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(32)}
+	second := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(32)}
+	firstData := testrand.BytesInt(100)
+
+	store, err := packstore.NewAt(zaptest.NewLogger(t), ctx.Dir("store"), packstore.DefaultConfig)
+	require.NoError(t, err)
+	writeBlob(ctx, t, store, first, firstData)
+	require.NoError(t, store.Close())
+
+	indexes, err := filepath.Glob(filepath.Join(ctx.Dir("store"), "packed", "*", "index"))
+	require.NoError(t, err)
+	require.Len(t, indexes, 1)
+	before, err := os.Stat(indexes[0])
+	require.NoError(t, err)
+
+	// simulate a crash while appending an index entry.
+	index, err := os.OpenFile(indexes[0], os.O_WRONLY|os.O_APPEND, 0600)
+	require.NoError(t, err)
+	_, err = index.Write([]byte{1, 0, 1, 0, 32})
+	require.NoError(t, err)
+	require.NoError(t, index.Close())
+
+	store, err = packstore.NewAt(zaptest.NewLogger(t), ctx.Dir("store"), packstore.DefaultConfig)
+	require.NoError(t, err)
+	defer ctx.Check(store.Close)
+
+	after, err := os.Stat(indexes[0])
+	require.NoError(t, err)
+	assert.Equal(t, before.Size(), after.Size(), "torn entry should be truncated")
+
+	assert.Equal(t, firstData, readBlob(ctx, t, store, first))
+
+	// the index keeps working after the truncation.
+	secondData := testrand.BytesInt(100)
+	writeBlob(ctx, t, store, second, secondData)
+	assert.Equal(t, secondData, readBlob(ctx, t, store, second))
+}
+
+func TestCompaction(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	config := packstore.DefaultConfig
+	config.MaxLogSize = 10 * memory.KiB
+
+	store, err := packstore.NewAt(zaptest.NewLogger(t), ctx.Dir("store"), config)
+	require.NoError(t, err)
+	defer ctx.Check(store.Close)
+
+	namespace := testrand.Bytes(32)
+	refs := make([]storage.BlobRef, 20)
+	data := make([][]byte, len(refs))
+	for i := range refs {
+		refs[i] = storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(32)}
+		data[i] = testrand.BytesInt(1000)
+		writeBlob(ctx, t, store, refs[i], data[i])
+	}
+
+	// the records don't fit in a single log file.
+	before := logFiles(t, ctx.Dir("store"))
+	require.Greater(t, len(before), 1)
+
+	// delete every other blob and trash one of the remaining.
+	for i := 0; i < len(refs); i += 2 {
+		require.NoError(t, store.Delete(ctx, refs[i]))
+	}
+	require.NoError(t, store.Trash(ctx, refs[1]))
+
+	compactor, ok := store.(packstore.Compactor)
+	require.True(t, ok)
+	require.NoError(t, compactor.GarbageCollect(ctx))
+
+	// the live records of the first 20KiB fit into a fraction of the space.
+	var sizeAfter int64
+	for _, path := range logFiles(t, ctx.Dir("store")) {
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		sizeAfter += info.Size()
+	}
+	assert.Less(t, sizeAfter, 12*memory.KiB.Int64())
+
+	for i := 3; i < len(refs); i += 2 {
+		assert.Equal(t, data[i], readBlob(ctx, t, store, refs[i]))
+	}
+	for i := 0; i < len(refs); i += 2 {
+		_, err := store.Open(ctx, refs[i])
+		require.True(t, os.IsNotExist(err))
+	}
+
+	// the trashed blob stays in the trash after being moved.
+	_, err = store.Open(ctx, refs[1])
+	require.True(t, os.IsNotExist(err))
+	restored, err := store.RestoreTrash(ctx, namespace)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{refs[1].Key}, restored)
+	assert.Equal(t, data[1], readBlob(ctx, t, store, refs[1]))
+
+	// emptying everything removes the namespace directory.
+	for i := 1; i < len(refs); i += 2 {
+		require.NoError(t, store.Delete(ctx, refs[i]))
+	}
+	require.NoError(t, compactor.GarbageCollect(ctx))
+
+	dirs, err := ioutil.ReadDir(filepath.Join(ctx.Dir("store"), "packed"))
+	require.NoError(t, err)
+	assert.Empty(t, dirs)
+}
+
+func TestMigrate(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	log := zaptest.NewLogger(t)
+
+	dir, err := filestore.NewDir(log, ctx.Dir("store"))
+	require.NoError(t, err)
+	source := filestore.New(log, dir, filestore.DefaultConfig)
+	defer ctx.Check(source.Close)
+
+	namespace := testrand.Bytes(32)
+	v1 := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(32)}
+	v0 := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(32)}
+	trashed := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(32)}
+	v1Data := testrand.BytesInt(1000)
+	v0Data := testrand.BytesInt(500)
+	trashedData := testrand.BytesInt(300)
+
+	writeBlob(ctx, t, source, v1, v1Data)
+	writeBlob(ctx, t, source, trashed, trashedData)
+	require.NoError(t, source.Trash(ctx, trashed))
+
+	v0Writer, err := source.(interface {
+		TestCreateV0(ctx context.Context, ref storage.BlobRef) (storage.BlobWriter, error)
+	}).TestCreateV0(ctx, v0)
+	require.NoError(t, err)
+	_, err = v0Writer.Write(v0Data)
+	require.NoError(t, err)
+	require.NoError(t, v0Writer.Commit(ctx))
+
+	target, err := packstore.New(log, dir, packstore.DefaultConfig)
+	require.NoError(t, err)
+	defer ctx.Check(target.Close)
+
+	// first migrate without deleting the source, so that the migration can
+	// be resumed.
+	stats, err := packstore.Migrate(ctx, log, source, target, false)
+	require.NoError(t, err)
+	assert.Equal(t, packstore.MigrationStats{Blobs: 3, Bytes: 1800}, stats)
+
+	stats, err = packstore.Migrate(ctx, log, source, target, true)
+	require.NoError(t, err)
+	assert.Equal(t, packstore.MigrationStats{Skipped: 3}, stats)
+
+	namespaces, err := source.ListNamespaces(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, namespaces)
+
+	assert.Equal(t, v1Data, readBlob(ctx, t, target, v1))
+	assert.Equal(t, trashedData, readBlob(ctx, t, target, trashed))
+
+	reader, err := target.Open(ctx, v0)
+	require.NoError(t, err)
+	assert.Equal(t, filestore.FormatV0, reader.StorageFormatVersion())
+	data, err := ioutil.ReadAll(reader)
+	require.NoError(t, err)
+	require.NoError(t, reader.Close())
+	assert.Equal(t, v0Data, data)
+}
+
+func TestStreamingWrites(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	store, err := packstore.NewAt(zaptest.NewLogger(t), ctx.Dir("store"), packstore.DefaultConfig)
+	require.NoError(t, err)
+	defer ctx.Check(store.Close)
+
+	namespace := testrand.Bytes(32)
+	logSize := func() (total int64) {
+		for _, path := range logFiles(t, ctx.Dir("store")) {
+			info, err := os.Stat(path)
+			require.NoError(t, err)
+			total += info.Size()
+		}
+		return total
+	}
+
+	kept := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(32)}
+	keptData := testrand.BytesInt(1000)
+	writeBlob(ctx, t, store, kept, keptData)
+	committed := logSize()
+
+	// the blob is written to the log file directly instead of a temporary file.
+	canceled := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(32)}
+	writer, err := store.Create(ctx, canceled, -1)
+	require.NoError(t, err)
+	_, err = writer.Write(testrand.BytesInt(2 * memory.KiB.Int()))
+	require.NoError(t, err)
+	_, err = writer.Seek(0, io.SeekStart)
+	require.NoError(t, err)
+	require.Greater(t, logSize(), committed)
+
+	temps, err := ioutil.ReadDir(filepath.Join(ctx.Dir("store"), "temp"))
+	require.NoError(t, err)
+	assert.Empty(t, temps)
+
+	// canceling rolls the log file back to the start of the record.
+	require.NoError(t, writer.Cancel(ctx))
+	assert.Equal(t, committed, logSize())
+	_, err = store.Open(ctx, canceled)
+	require.True(t, os.IsNotExist(err))
+
+	// a blob seeked back to write its header ends at the current position.
+	seeked := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(32)}
+	writer, err = store.Create(ctx, seeked, -1)
+	require.NoError(t, err)
+	_, err = writer.Write(make([]byte, 500))
+	require.NoError(t, err)
+	_, err = writer.Seek(0, io.SeekStart)
+	require.NoError(t, err)
+	_, err = writer.Write([]byte("header"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Commit(ctx))
+	assert.Equal(t, []byte("header"), readBlob(ctx, t, store, seeked))
+
+	// the next record starts right after the committed ones.
+	next := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(32)}
+	nextData := testrand.BytesInt(100)
+	writeBlob(ctx, t, store, next, nextData)
+	assert.Equal(t, committed+2*(16+32)+6+100, logSize())
+
+	assert.Equal(t, keptData, readBlob(ctx, t, store, kept))
+	assert.Equal(t, nextData, readBlob(ctx, t, store, next))
+}
+
+func TestConcurrentWrites(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	config := packstore.DefaultConfig
+	config.MaxLogSize = 10 * memory.KiB
+
+	store, err := packstore.NewAt(zaptest.NewLogger(t), ctx.Dir("store"), config)
+	require.NoError(t, err)
+
+	namespace := testrand.Bytes(32)
+	refs := make([]storage.BlobRef, 50)
+	data := make([][]byte, len(refs))
+	for i := range refs {
+		refs[i] = storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(32)}
+		data[i] = testrand.BytesInt(1000 + i)
+	}
+
+	var group errgroup.Group
+	for i := range refs {
+		i := i
+		group.Go(func() error {
+			writer, err := store.Create(ctx, refs[i], -1)
+			if err != nil {
+				return err
+			}
+			if _, err := writer.Write(data[i]); err != nil {
+				return err
+			}
+			if i%5 == 0 {
+				return writer.Cancel(ctx)
+			}
+			return writer.Commit(ctx)
+		})
+	}
+	require.NoError(t, group.Wait())
+	require.NoError(t, store.Close())
+
+	store, err = packstore.NewAt(zaptest.NewLogger(t), ctx.Dir("store"), config)
+	require.NoError(t, err)
+	defer ctx.Check(store.Close)
+
+	for i := range refs {
+		if i%5 == 0 {
+			_, err := store.Open(ctx, refs[i])
+			require.True(t, os.IsNotExist(err))
+			continue
+		}
+		assert.Equal(t, data[i], readBlob(ctx, t, store, refs[i]))
+	}
+}
+
+func TestCheckpoint(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	store, err := packstore.NewAt(zaptest.NewLogger(t), ctx.Dir("store"), packstore.DefaultConfig)
+	require.NoError(t, err)
+
+	namespace := testrand.Bytes(32)
+	refs := make([]storage.BlobRef, 10)
+	data := make([][]byte, len(refs))
+	for i := range refs {
+		refs[i] = storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(32)}
+		data[i] = testrand.BytesInt(100)
+		writeBlob(ctx, t, store, refs[i], data[i])
+	}
+	require.NoError(t, store.Delete(ctx, refs[0]))
+	require.NoError(t, store.Trash(ctx, refs[1]))
+
+	dirs, err := filepath.Glob(filepath.Join(ctx.Dir("store"), "packed", "*"))
+	require.NoError(t, err)
+	require.Len(t, dirs, 1)
+	checkpoint := filepath.Join(dirs[0], "checkpoint")
+	journal := filepath.Join(dirs[0], "index")
+
+	// the journal is checkpointed, as it has more entries than the namespace.
+	compactor, ok := store.(packstore.Compactor)
+	require.True(t, ok)
+	require.NoError(t, compactor.GarbageCollect(ctx))
+
+	_, err = os.Stat(checkpoint)
+	require.NoError(t, err)
+	_, err = os.Stat(journal)
+	require.True(t, os.IsNotExist(err))
+
+	// the operations after the checkpoint are journaled.
+	require.NoError(t, store.Delete(ctx, refs[2]))
+	require.NoError(t, store.Close())
+
+	// a journal, which is already included in the checkpoint, is replayed
+	// on top of it without changing the state.
+	journalData, err := ioutil.ReadFile(journal)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dirs[0], "index.prev"), journalData, 0600))
+
+	for reopen := 0; reopen < 2; reopen++ {
+		store, err = packstore.NewAt(zaptest.NewLogger(t), ctx.Dir("store"), packstore.DefaultConfig)
+		require.NoError(t, err)
+
+		for i := range refs {
+			if i <= 2 {
+				_, err := store.Open(ctx, refs[i])
+				require.True(t, os.IsNotExist(err))
+				continue
+			}
+			assert.Equal(t, data[i], readBlob(ctx, t, store, refs[i]))
+		}
+		_, _, err = store.(storage.TrashStater).StatTrash(ctx, refs[1])
+		require.NoError(t, err)
+
+		// the next checkpoint includes the previous journal and removes it.
+		_, err = store.RestoreTrash(ctx, namespace)
+		require.NoError(t, err)
+		require.NoError(t, store.Trash(ctx, refs[1]))
+		require.NoError(t, store.(packstore.Compactor).GarbageCollect(ctx))
+		_, err = os.Stat(filepath.Join(dirs[0], "index.prev"))
+		require.True(t, os.IsNotExist(err))
+		require.NoError(t, store.Close())
+	}
+}
+
+func TestWalkNamespaceAfterPrefix(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	store, err := packstore.NewAt(zaptest.NewLogger(t), ctx.Dir("store"), packstore.DefaultConfig)
+	require.NoError(t, err)
+	defer ctx.Check(store.Close)
+
+	namespace := testrand.Bytes(32)
+	keys := map[string]bool{}
+	for i := 0; i < 50; i++ {
+		ref := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(32)}
+		writeBlob(ctx, t, store, ref, testrand.BytesInt(10))
+		keys[string(ref.Key)] = true
+	}
+
+	walk := func(afterPrefix string) (prefixes []string, walked map[string]bool) {
+		walked = map[string]bool{}
+		var current []string
+		err := store.(storage.PrefixWalker).WalkNamespaceAfterPrefix(ctx, namespace, afterPrefix, func(info storage.BlobInfo) error {
+			walked[string(info.BlobRef().Key)] = true
+			current = append(current, pathEncoding.EncodeToString(info.BlobRef().Key)[:2])
+			return nil
+		}, func(prefix string) error {
+			// the blobs of a prefix are walked before it's done.
+			for _, blobPrefix := range current {
+				assert.Equal(t, prefix, blobPrefix)
+			}
+			current = nil
+			prefixes = append(prefixes, prefix)
+			return nil
+		})
+		require.NoError(t, err)
+		return prefixes, walked
+	}
+
+	prefixes, walked := walk("")
+	assert.Equal(t, keys, walked)
+	assert.True(t, sort.StringsAreSorted(prefixes))
+
+	// resuming after a prefix walks only the blobs of the later prefixes.
+	after := prefixes[len(prefixes)/2]
+	_, walked = walk(after)
+	for key := range keys {
+		assert.Equal(t, pathEncoding.EncodeToString([]byte(key))[:2] > after, walked[key])
+	}
+}
@@ -0,0 +1,429 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package packstore
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/storage"
+)
+
+const (
+	// tablePageSize is the size at which the entries of a table are cut into
+	// pages. A lookup reads a single page.
+	tablePageSize = 16 * 1024
+
+	// tableMagic ends every table, so that a checkpoint in another format
+	// isn't mistaken for one.
+	tableMagic = 0x54504a53 // "SJPT"
+	// tableFooterSize is the size of the table footer: summary offset, count,
+	// used, trashed, summary checksum and magic.
+	tableFooterSize = 8 + 8 + 8 + 8 + 4 + 4
+)
+
+// tablePage is the location of a page of a table and its first key.
+type tablePage struct {
+	first  entryKey
+	offset int64
+	size   int64
+}
+
+// tablePageHeader is the encoding of a page in the summary of a table,
+// which is followed by the first key.
+type tablePageHeader struct {
+	Offset        uint64
+	Size          uint32
+	FormatVersion uint16
+	KeyLength     uint16
+}
+
+// tableLog is what the entries of a table reference of a log file.
+type tableLog struct {
+	// live is the sum of the sizes of the records of the entries.
+	live int64
+	// end is the end of the last record of the entries.
+	end int64
+}
+
+// tableLogStats is the encoding of a log file in the summary of a table.
+type tableLogStats struct {
+	ID   uint32
+	Live uint64
+	End  uint64
+}
+
+// tableStats summarizes the entries of a table.
+type tableStats struct {
+	count   int64
+	used    int64
+	trashed int64
+	logs    map[uint32]tableLog
+}
+
+// add adds the entry to the stats.
+func (stats *tableStats) add(key entryKey, found *entry) {
+	stats.count++
+	if found.trashedAt.IsZero() {
+		stats.used += found.size
+	} else {
+		stats.trashed += found.size
+	}
+	log := stats.logs[found.log]
+	log.live += found.recordSize(key.key)
+	if end := found.offset + found.recordSize(key.key); end > log.end {
+		log.end = end
+	}
+	stats.logs[found.log] = log
+}
+
+// table is a checkpoint of the entries of a namespace, which are stored on
+// disk sorted by key and cut into pages. Only the first key of every page is
+// kept in memory, so looking up an entry reads a single page.
+//
+// The file consists of the pages of put entries, encoded like the entries of
+// the journal, followed by the summary of the pages and the stats of the
+// entries, and the footer.
+type table struct {
+	file  *os.File
+	pages []tablePage
+	end   int64
+	stats tableStats
+
+	// refs is the number of snapshots and lookups reading the table and
+	// retired is set once a newer table replaced it. The file is closed when
+	// both happened. They are guarded by the mutex of the namespace.
+	refs    int
+	retired bool
+}
+
+// openTable opens the table at path and loads its summary.
+func openTable(path string) (_ *table, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			err = errs.Combine(err, file.Close())
+		}
+	}()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() < tableFooterSize {
+		return nil, errs.New("table %q is too short", path)
+	}
+
+	var footer [tableFooterSize]byte
+	if _, err := file.ReadAt(footer[:], info.Size()-tableFooterSize); err != nil {
+		return nil, err
+	}
+	if binary.LittleEndian.Uint32(footer[36:]) != tableMagic {
+		return nil, errs.New("%q is not a table", path)
+	}
+
+	t := &table{
+		file: file,
+		end:  int64(binary.LittleEndian.Uint64(footer[0:])),
+		stats: tableStats{
+			count:   int64(binary.LittleEndian.Uint64(footer[8:])),
+			used:    int64(binary.LittleEndian.Uint64(footer[16:])),
+			trashed: int64(binary.LittleEndian.Uint64(footer[24:])),
+			logs:    map[uint32]tableLog{},
+		},
+	}
+	if t.end < 0 || t.end > info.Size()-tableFooterSize {
+		return nil, errs.New("table %q has an invalid summary offset", path)
+	}
+
+	summary := make([]byte, info.Size()-tableFooterSize-t.end)
+	if _, err := file.ReadAt(summary, t.end); err != nil {
+		return nil, err
+	}
+	if crc32.ChecksumIEEE(summary) != binary.LittleEndian.Uint32(footer[32:]) {
+		return nil, errs.New("summary of table %q is corrupted", path)
+	}
+	if err := t.decodeSummary(summary); err != nil {
+		return nil, errs.New("summary of table %q is corrupted: %v", path, err)
+	}
+	return t, nil
+}
+
+// decodeSummary decodes the pages and the log stats of the summary.
+func (t *table) decodeSummary(summary []byte) error {
+	r := bytes.NewReader(summary)
+
+	var count uint32
+	if err := binary.Read(r, binary.LittleEndian, &count); err != nil {
+		return err
+	}
+	t.pages = make([]tablePage, 0, count)
+	for i := uint32(0); i < count; i++ {
+		var header tablePageHeader
+		if err := binary.Read(r, binary.LittleEndian, &header); err != nil {
+			return err
+		}
+		key := make([]byte, header.KeyLength)
+		if _, err := io.ReadFull(r, key); err != nil {
+			return err
+		}
+		t.pages = append(t.pages, tablePage{
+			first:  entryKey{key: string(key), formatVersion: storage.FormatVersion(header.FormatVersion)},
+			offset: int64(header.Offset),
+			size:   int64(header.Size),
+		})
+	}
+
+	if err := binary.Read(r, binary.LittleEndian, &count); err != nil {
+		return err
+	}
+	for i := uint32(0); i < count; i++ {
+		var log tableLogStats
+		if err := binary.Read(r, binary.LittleEndian, &log); err != nil {
+			return err
+		}
+		t.stats.logs[log.ID] = tableLog{live: int64(log.Live), end: int64(log.End)}
+	}
+	return nil
+}
+
+// page returns the index of the page, which contains the key when it's in
+// the table, or -1 when the key sorts before the first page.
+func (t *table) page(key entryKey) int {
+	return sort.Search(len(t.pages), func(i int) bool { return compareKeys(t.pages[i].first, key) > 0 }) - 1
+}
+
+// find looks up the entry with the given key.
+func (t *table) find(key entryKey) (_ entry, ok bool, err error) {
+	i := t.page(key)
+	if i < 0 {
+		return entry{}, false, nil
+	}
+
+	page := make([]byte, t.pages[i].size)
+	if _, err := t.file.ReadAt(page, t.pages[i].offset); err != nil {
+		return entry{}, false, err
+	}
+
+	r := bytes.NewReader(page)
+	for {
+		indexEntry, _, err := decodeIndexEntry(r)
+		if errors.Is(err, io.EOF) {
+			return entry{}, false, nil
+		}
+		if err != nil {
+			return entry{}, false, err
+		}
+		switch compareKeys(indexEntry.entryKey(), key) {
+		case 0:
+			return entryOf(&indexEntry), true, nil
+		case 1:
+			return entry{}, false, nil
+		}
+	}
+}
+
+// scan calls fn for the entries from the first key not less than from in
+// key order, until fn returns false.
+func (t *table) scan(from entryKey, fn func(key entryKey, found entry) (bool, error)) error {
+	i := t.page(from)
+	if i < 0 {
+		i = 0
+	}
+	if i >= len(t.pages) {
+		return nil
+	}
+
+	r := bufio.NewReaderSize(io.NewSectionReader(t.file, t.pages[i].offset, t.end-t.pages[i].offset), 256*1024)
+	for {
+		indexEntry, _, err := decodeIndexEntry(r)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		key := indexEntry.entryKey()
+		if compareKeys(key, from) < 0 {
+			continue
+		}
+		if more, err := fn(key, entryOf(&indexEntry)); !more || err != nil {
+			return err
+		}
+	}
+}
+
+// tableWriter writes the entries of a table in key order.
+type tableWriter struct {
+	file   *os.File
+	w      *bufio.Writer
+	offset int64
+	pages  []tablePage
+	stats  tableStats
+	buf    []byte
+}
+
+// createTable creates the table at path to write the entries to.
+func createTable(path string) (*tableWriter, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, filePermission)
+	if err != nil {
+		return nil, err
+	}
+	return &tableWriter{
+		file:  file,
+		w:     bufio.NewWriterSize(file, 256*1024),
+		stats: tableStats{logs: map[uint32]tableLog{}},
+	}, nil
+}
+
+// add appends the entry, which must sort after the entries added before.
+func (w *tableWriter) add(key entryKey, found *entry) error {
+	indexEntry := indexEntry{
+		op:            opPut,
+		key:           []byte(key.key),
+		formatVersion: key.formatVersion,
+		log:           found.log,
+		offset:        found.offset,
+		size:          found.size,
+		modTime:       found.modTime,
+		trashedAt:     found.trashedAt,
+	}
+	w.buf = indexEntry.encode(w.buf[:0])
+
+	last := len(w.pages) - 1
+	if last < 0 || w.pages[last].size+int64(len(w.buf)) > tablePageSize {
+		w.pages = append(w.pages, tablePage{first: key, offset: w.offset})
+		last++
+	}
+	if _, err := w.w.Write(w.buf); err != nil {
+		return err
+	}
+	w.pages[last].size += int64(len(w.buf))
+	w.offset += int64(len(w.buf))
+
+	w.stats.add(key, found)
+	return nil
+}
+
+// finish writes the summary and the footer and syncs and closes the table.
+func (w *tableWriter) finish() (err error) {
+	var summary bytes.Buffer
+	_ = binary.Write(&summary, binary.LittleEndian, uint32(len(w.pages)))
+	for _, page := range w.pages {
+		_ = binary.Write(&summary, binary.LittleEndian, tablePageHeader{
+			Offset:        uint64(page.offset),
+			Size:          uint32(page.size),
+			FormatVersion: uint16(page.first.formatVersion),
+			KeyLength:     uint16(len(page.first.key)),
+		})
+		_, _ = summary.WriteString(page.first.key)
+	}
+	_ = binary.Write(&summary, binary.LittleEndian, uint32(len(w.stats.logs)))
+	for id, log := range w.stats.logs {
+		_ = binary.Write(&summary, binary.LittleEndian, tableLogStats{
+			ID:   id,
+			Live: uint64(log.live),
+			End:  uint64(log.end),
+		})
+	}
+
+	var footer [tableFooterSize]byte
+	binary.LittleEndian.PutUint64(footer[0:], uint64(w.offset))
+	binary.LittleEndian.PutUint64(footer[8:], uint64(w.stats.count))
+	binary.LittleEndian.PutUint64(footer[16:], uint64(w.stats.used))
+	binary.LittleEndian.PutUint64(footer[24:], uint64(w.stats.trashed))
+	binary.LittleEndian.PutUint32(footer[32:], crc32.ChecksumIEEE(summary.Bytes()))
+	binary.LittleEndian.PutUint32(footer[36:], tableMagic)
+
+	_, err = w.w.Write(summary.Bytes())
+	if err == nil {
+		_, err = w.w.Write(footer[:])
+	}
+	if err == nil {
+		err = w.w.Flush()
+	}
+	if err == nil {
+		err = w.file.Sync()
+	}
+	return errs.Combine(err, w.file.Close())
+}
+
+// abort closes the table, which isn't finished.
+func (w *tableWriter) abort() error {
+	return w.file.Close()
+}
+
+// change is an entry of the namespace changed since the last checkpoint,
+// where a nil entry is a deleted one.
+type change struct {
+	key   entryKey
+	entry *entry
+}
+
+// mergeEntries calls fn for the entries of the table with the changes
+// applied, starting at the first key not less than from, in key order until
+// fn returns false. The changes must be sorted by key and the table may be nil.
+func mergeEntries(t *table, changes []change, from entryKey, fn func(key entryKey, found entry) (bool, error)) error {
+	changes = changes[sort.Search(len(changes), func(i int) bool { return compareKeys(changes[i].key, from) >= 0 }):]
+
+	// emit calls fn for the changes, which sort before key or all of them
+	// when key is nil.
+	emit := func(key *entryKey) (bool, error) {
+		for len(changes) > 0 && (key == nil || compareKeys(changes[0].key, *key) < 0) {
+			next := changes[0]
+			changes = changes[1:]
+			if next.entry == nil {
+				continue
+			}
+			if more, err := fn(next.key, *next.entry); !more || err != nil {
+				return false, err
+			}
+		}
+		return true, nil
+	}
+
+	if t != nil {
+		stopped := false
+		err := t.scan(from, func(key entryKey, found entry) (bool, error) {
+			more, err := emit(&key)
+			if more && err == nil && (len(changes) == 0 || compareKeys(changes[0].key, key) != 0) {
+				// the entry wasn't changed since the checkpoint.
+				more, err = fn(key, found)
+			}
+			stopped = !more
+			return more, err
+		})
+		if err != nil || stopped {
+			return err
+		}
+	}
+	_, err := emit(nil)
+	return err
+}
+
+// compareKeys orders entry keys by key and then by format version.
+func compareKeys(a, b entryKey) int {
+	if c := strings.Compare(a.key, b.key); c != 0 {
+		return c
+	}
+	switch {
+	case a.formatVersion < b.formatVersion:
+		return -1
+	case a.formatVersion > b.formatVersion:
+		return 1
+	}
+	return 0
+}
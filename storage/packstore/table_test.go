@@ -0,0 +1,215 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package packstore
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/storj/storage/filestore"
+)
+
+const (
+	// testBlobSize is the size of the blobs of the test namespaces.
+	testBlobSize = 64 << 10
+	// testLogSize is the size of the log files of the test namespaces.
+	testLogSize = 1 << 30
+)
+
+// testKey returns the key of the i-th of count blobs. The keys are spread
+// evenly over the key space and increase with i.
+func testKey(i, count int) entryKey {
+	key := make([]byte, 32)
+	binary.BigEndian.PutUint64(key, uint64(i)*(^uint64(0)/uint64(count)))
+	binary.BigEndian.PutUint64(key[8:], uint64(i))
+	return entryKey{key: string(key), formatVersion: filestore.FormatV1}
+}
+
+// createTestNamespace writes a checkpoint of count blobs stored in sparse log
+// files to dir.
+func createTestNamespace(tb testing.TB, dir string, count int) {
+	if err := os.MkdirAll(dir, dirPermission); err != nil {
+		tb.Fatal(err)
+	}
+
+	writer, err := createTable(filepath.Join(dir, checkpointFileName))
+	if err != nil {
+		tb.Fatal(err)
+	}
+	logs := map[uint32]int64{}
+	log, offset := uint32(1), int64(0)
+	modTime := time.Now()
+	for i := 0; i < count; i++ {
+		key := testKey(i, count)
+		found := &entry{log: log, offset: offset, size: testBlobSize, modTime: modTime}
+		if err := writer.add(key, found); err != nil {
+			tb.Fatal(err)
+		}
+		offset += found.recordSize(key.key)
+		logs[log] = offset
+		if offset >= testLogSize {
+			log, offset = log+1, 0
+		}
+	}
+	if err := writer.finish(); err != nil {
+		tb.Fatal(err)
+	}
+
+	for id, size := range logs {
+		logFile, err := os.Create(filepath.Join(dir, fmt.Sprintf("%010d%s", id, logFileSuffix)))
+		if err != nil {
+			tb.Fatal(err)
+		}
+		if err := logFile.Truncate(size); err != nil {
+			tb.Fatal(err)
+		}
+		if err := logFile.Close(); err != nil {
+			tb.Fatal(err)
+		}
+	}
+}
+
+// openMeasured opens the namespace in dir and returns how long that took and
+// how much heap the namespace holds.
+func openMeasured(tb testing.TB, dir string) (_ *namespace, duration time.Duration, heap int64) {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	start := time.Now()
+	ns, err := openNamespace(zap.NewNop(), []byte("namespace"), dir)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	duration = time.Since(start)
+
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+	return ns, duration, int64(after.HeapAlloc) - int64(before.HeapAlloc)
+}
+
+func closeNamespace(tb testing.TB, ns *namespace) {
+	ns.checkpointMu.Lock()
+	defer ns.checkpointMu.Unlock()
+	ns.syncMu.Lock()
+	defer ns.syncMu.Unlock()
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	if err := ns.close(); err != nil {
+		tb.Fatal(err)
+	}
+}
+
+func TestLargeNamespace(t *testing.T) {
+	const count = 250000
+
+	dir := t.TempDir()
+	createTestNamespace(t, dir, count)
+
+	ns, duration, heap := openMeasured(t, dir)
+	t.Logf("opened namespace of %d blobs in %v with %d bytes of heap", count, duration, heap)
+
+	// only the summary of the pages is kept in memory, which is a fraction
+	// of the entries.
+	if heap > 4<<20 {
+		t.Fatalf("namespace of %d blobs holds %d bytes of heap", count, heap)
+	}
+	if ns.count != count || ns.used != count*testBlobSize {
+		t.Fatalf("namespace has %d blobs of %d bytes", ns.count, ns.used)
+	}
+
+	for _, i := range []int{0, 1, count / 2, count - 1} {
+		found, ok, err := ns.find(testKey(i, count))
+		if err != nil || !ok || found.size != testBlobSize {
+			t.Fatalf("blob %d: %v %v %v", i, found, ok, err)
+		}
+	}
+	missing := testKey(1, count)
+	missing.key = missing.key[:31] + "x"
+	if _, ok, err := ns.find(missing); ok || err != nil {
+		t.Fatalf("missing blob: %v %v", ok, err)
+	}
+
+	// delete every 1000th blob and trash the ones after, which are merged
+	// into the table by the checkpoint.
+	ns.mu.Lock()
+	var entries []indexEntry
+	for i := 0; i < count; i += 1000 {
+		entries = append(entries,
+			indexEntry{op: opDelete, key: []byte(testKey(i, count).key), formatVersion: filestore.FormatV1},
+			indexEntry{op: opTrash, key: []byte(testKey(i+1, count).key), formatVersion: filestore.FormatV1, trashedAt: time.Now()})
+	}
+	seq, err := ns.record(entries...)
+	ns.mu.Unlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ns.commit(seq); err != nil {
+		t.Fatal(err)
+	}
+
+	ns.checkpointMu.Lock()
+	err = ns.checkpoint()
+	ns.checkpointMu.Unlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ns.changes) != 0 {
+		t.Fatalf("%d changes after the checkpoint", len(ns.changes))
+	}
+	closeNamespace(t, ns)
+
+	ns, _, _ = openMeasured(t, dir)
+	defer closeNamespace(t, ns)
+
+	const changed = count / 1000
+	if ns.count != count-changed || ns.used != (count-2*changed)*testBlobSize || ns.trashed != changed*testBlobSize {
+		t.Fatalf("namespace has %d blobs of %d bytes and %d bytes in the trash", ns.count, ns.used, ns.trashed)
+	}
+	if _, ok, err := ns.find(testKey(1000, count)); ok || err != nil {
+		t.Fatalf("deleted blob: %v %v", ok, err)
+	}
+	if found, ok, err := ns.find(testKey(1001, count)); !ok || err != nil || found.trashedAt.IsZero() {
+		t.Fatalf("trashed blob: %v %v %v", found, ok, err)
+	}
+
+	walked := 0
+	ns.mu.Lock()
+	snapshot := ns.snapshot()
+	ns.mu.Unlock()
+	defer snapshot.release()
+	err = snapshot.walk(entryKey{}, func(key entryKey, found entry) (bool, error) {
+		walked++
+		return true, nil
+	})
+	if err != nil || walked != count-changed {
+		t.Fatalf("walked %d blobs: %v", walked, err)
+	}
+}
+
+func BenchmarkOpenNamespace(b *testing.B) {
+	for _, count := range []int{1000000, 10000000} {
+		count := count
+		b.Run(fmt.Sprint(count), func(b *testing.B) {
+			dir := b.TempDir()
+			createTestNamespace(b, dir, count)
+			b.ResetTimer()
+
+			var heap int64
+			for i := 0; i < b.N; i++ {
+				ns, _, nsHeap := openMeasured(b, dir)
+				heap += nsHeap
+				closeNamespace(b, ns)
+			}
+			b.ReportMetric(float64(heap)/float64(b.N), "heap-bytes/op")
+		})
+	}
+}
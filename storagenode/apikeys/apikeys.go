@@ -12,8 +12,14 @@ import (
 	"storj.io/storj/private/multinodeauth"
 )
 
-// ErrNoAPIKey represents no api key error.
-var ErrNoAPIKey = errs.Class("no api key")
+var (
+	// ErrNoAPIKey represents no api key error.
+	ErrNoAPIKey = errs.Class("no api key")
+	// ErrExpired represents expired api key error.
+	ErrExpired = errs.Class("api key expired")
+	// ErrScope represents the error of an api key without the required scope.
+	ErrScope = errs.Class("api key scope")
+)
 
 // DB is interface for working with api keys.
 //
@@ -22,8 +28,11 @@ type DB interface {
 	// Store stores api key into db.
 	Store(ctx context.Context, apiKey APIKey) error
 
-	// Check checks if api key exists in db by secret.
-	Check(ctx context.Context, secret multinodeauth.Secret) error
+	// Get returns the api key with the secret.
+	Get(ctx context.Context, secret multinodeauth.Secret) (APIKey, error)
+
+	// List returns all api keys ordered by creation time.
+	List(ctx context.Context) ([]APIKey, error)
 
 	// Revoke removes api key from db.
 	Revoke(ctx context.Context, secret multinodeauth.Secret) error
@@ -34,5 +43,66 @@ type APIKey struct {
 	// APIKeys is PK of the table and keeps unique value sno api key.
 	Secret multinodeauth.Secret
 
-	CreatedAt time.Time `json:"createdAt"`
+	// Name describes who the key was issued to. Keys issued before keys
+	// had names have an empty name.
+	Name  string `json:"name"`
+	Scope Scope  `json:"scope"`
+
+	CreatedAt time.Time  `json:"createdAt"`
+	ExpiresAt *time.Time `json:"expiresAt"`
+}
+
+// keyIDLength is the length of the secret prefix identifying a key.
+const keyIDLength = 8
+
+// ID returns the prefix of the secret identifying the key, which can be shown
+// without giving access to the node.
+func (apiKey APIKey) ID() string {
+	return apiKey.Secret.String()[:keyIDLength]
+}
+
+// Expired returns whether the key is expired at the given time.
+func (apiKey APIKey) Expired(now time.Time) bool {
+	return apiKey.ExpiresAt != nil && !now.Before(*apiKey.ExpiresAt)
+}
+
+// Scope defines which multinode endpoints an api key may use.
+type Scope int
+
+const (
+	// ScopeReadOnly allows reading the node's stats and payouts, but not the
+	// operator's details.
+	ScopeReadOnly Scope = 1
+	// ScopeAdmin allows using all endpoints. Keys issued before keys had
+	// scopes have the admin scope.
+	ScopeAdmin Scope = 2
+)
+
+// String returns the name of the scope.
+func (scope Scope) String() string {
+	switch scope {
+	case ScopeReadOnly:
+		return "read-only"
+	case ScopeAdmin:
+		return "admin"
+	default:
+		return "unknown"
+	}
+}
+
+// Allows returns whether the scope includes the required scope.
+func (scope Scope) Allows(required Scope) bool {
+	return scope >= required
+}
+
+// ParseScope returns the scope with the given name.
+func ParseScope(name string) (Scope, error) {
+	switch name {
+	case "read-only":
+		return ScopeReadOnly, nil
+	case "admin":
+		return ScopeAdmin, nil
+	default:
+		return 0, errs.New("unknown api key scope %q, expected read-only or admin", name)
+	}
 }
@@ -24,28 +24,61 @@ func TestAPIKeysDB(t *testing.T) {
 		secret2, err := multinodeauth.NewSecret()
 		assert.NoError(t, err)
 
+		createdAt := time.Now().UTC().Truncate(time.Second)
+		expiresAt := createdAt.Add(time.Hour)
+
 		t.Run("Store", func(t *testing.T) {
 			err := apiKeys.Store(ctx, apikeys.APIKey{
 				Secret:    secret,
-				CreatedAt: time.Now().UTC(),
+				Name:      "dashboard",
+				Scope:     apikeys.ScopeReadOnly,
+				CreatedAt: createdAt,
+				ExpiresAt: &expiresAt,
+			})
+			assert.NoError(t, err)
+
+			err = apiKeys.Store(ctx, apikeys.APIKey{
+				Secret:    secret2,
+				Scope:     apikeys.ScopeAdmin,
+				CreatedAt: createdAt.Add(time.Second),
 			})
 			assert.NoError(t, err)
 		})
 
-		t.Run("Check", func(t *testing.T) {
-			err := apiKeys.Check(ctx, secret)
+		t.Run("Get", func(t *testing.T) {
+			apiKey, err := apiKeys.Get(ctx, secret)
+			assert.NoError(t, err)
+			assert.Equal(t, secret, apiKey.Secret)
+			assert.Equal(t, "dashboard", apiKey.Name)
+			assert.Equal(t, apikeys.ScopeReadOnly, apiKey.Scope)
+			assert.True(t, createdAt.Equal(apiKey.CreatedAt))
+			assert.NotNil(t, apiKey.ExpiresAt)
+			assert.True(t, expiresAt.Equal(*apiKey.ExpiresAt))
+
+			apiKey, err = apiKeys.Get(ctx, secret2)
 			assert.NoError(t, err)
+			assert.Nil(t, apiKey.ExpiresAt)
 
-			err = apiKeys.Check(ctx, secret2)
-			assert.Error(t, err)
+			missing, err := multinodeauth.NewSecret()
+			assert.NoError(t, err)
+			_, err = apiKeys.Get(ctx, missing)
+			assert.True(t, apikeys.ErrNoAPIKey.Has(err))
+		})
+
+		t.Run("List", func(t *testing.T) {
+			keys, err := apiKeys.List(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, 2, len(keys))
+			assert.Equal(t, secret, keys[0].Secret)
+			assert.Equal(t, secret2, keys[1].Secret)
 		})
 
 		t.Run("Revoke", func(t *testing.T) {
 			err = apiKeys.Revoke(ctx, secret)
 			assert.NoError(t, err)
 
-			err = apiKeys.Check(ctx, secret)
-			assert.Error(t, err)
+			_, err = apiKeys.Get(ctx, secret)
+			assert.True(t, apikeys.ErrNoAPIKey.Has(err))
 		})
 	})
 }
@@ -5,6 +5,7 @@ package apikeys
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/spacemonkeygo/monkit/v3"
@@ -32,16 +33,52 @@ func NewService(db DB) *Service {
 	return &Service{store: db}
 }
 
-// Issue generates new api key and stores it into db.
+// IssueOptions are the properties of a new api key.
+type IssueOptions struct {
+	Name      string
+	Scope     Scope
+	ExpiresAt *time.Time
+}
+
+// Issue generates new unnamed api key with the admin scope and stores it into db.
 func (service *Service) Issue(ctx context.Context) (apiKey APIKey, err error) {
 	defer mon.Task()(&ctx)(&err)
+
+	return service.IssueWithOptions(ctx, IssueOptions{Scope: ScopeAdmin})
+}
+
+// IssueWithOptions generates new api key and stores it into db.
+func (service *Service) IssueWithOptions(ctx context.Context, opts IssueOptions) (apiKey APIKey, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if opts.Scope != ScopeReadOnly && opts.Scope != ScopeAdmin {
+		return APIKey{}, ErrService.New("invalid scope %d", opts.Scope)
+	}
+	if opts.Name != "" {
+		keys, err := service.store.List(ctx)
+		if err != nil {
+			return APIKey{}, ErrService.Wrap(err)
+		}
+		for _, key := range keys {
+			if key.Name == opts.Name {
+				return APIKey{}, ErrService.New("an api key named %q already exists", opts.Name)
+			}
+		}
+	}
+
 	secret, err := multinodeauth.NewSecret()
 	if err != nil {
 		return APIKey{}, ErrService.Wrap(err)
 	}
 
 	apiKey.Secret = secret
+	apiKey.Name = opts.Name
+	apiKey.Scope = opts.Scope
 	apiKey.CreatedAt = time.Now().UTC()
+	if opts.ExpiresAt != nil {
+		expiresAt := opts.ExpiresAt.UTC()
+		apiKey.ExpiresAt = &expiresAt
+	}
 
 	err = service.store.Store(ctx, apiKey)
 	if err != nil {
@@ -51,11 +88,31 @@ func (service *Service) Issue(ctx context.Context) (apiKey APIKey, err error) {
 	return apiKey, nil
 }
 
-// Check returns error if api key does not exists.
-func (service *Service) Check(ctx context.Context, secret multinodeauth.Secret) (err error) {
+// Check returns error if api key does not exists, is expired or doesn't
+// have the required scope.
+func (service *Service) Check(ctx context.Context, secret multinodeauth.Secret, required Scope) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	apiKey, err := service.store.Get(ctx, secret)
+	if err != nil {
+		return err
+	}
+	if apiKey.Expired(time.Now()) {
+		return ErrExpired.New("api key %s expired at %s", apiKey.ID(), apiKey.ExpiresAt.Format(time.RFC3339))
+	}
+	if !apiKey.Scope.Allows(required) {
+		return ErrScope.New("api key %s has the %s scope, but %s is required", apiKey.ID(), apiKey.Scope, required)
+	}
+
+	return nil
+}
+
+// List returns all api keys.
+func (service *Service) List(ctx context.Context) (_ []APIKey, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	return service.store.Check(ctx, secret)
+	keys, err := service.store.List(ctx)
+	return keys, ErrService.Wrap(err)
 }
 
 // Remove revokes apikey, deletes it from db.
@@ -64,3 +121,41 @@ func (service *Service) Remove(ctx context.Context, secret multinodeauth.Secret)
 
 	return ErrService.Wrap(service.store.Revoke(ctx, secret))
 }
+
+// Revoke revokes the api key with the given name, ID or secret, and returns it.
+func (service *Service) Revoke(ctx context.Context, nameOrID string) (_ APIKey, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if nameOrID == "" {
+		return APIKey{}, ErrService.New("name or id of the api key is required")
+	}
+
+	keys, err := service.store.List(ctx)
+	if err != nil {
+		return APIKey{}, ErrService.Wrap(err)
+	}
+
+	var matches []APIKey
+	for _, key := range keys {
+		if key.Name == nameOrID {
+			matches = append(matches, key)
+			continue
+		}
+		if len(nameOrID) >= keyIDLength && strings.HasPrefix(key.Secret.String(), nameOrID) {
+			matches = append(matches, key)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return APIKey{}, ErrNoAPIKey.New("no api key named or with id %q", nameOrID)
+	case 1:
+	default:
+		return APIKey{}, ErrService.New("%d api keys match %q, use the id", len(matches), nameOrID)
+	}
+
+	if err := service.store.Revoke(ctx, matches[0].Secret); err != nil {
+		return APIKey{}, ErrService.Wrap(err)
+	}
+	return matches[0], nil
+}
@@ -0,0 +1,79 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package apikeys_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zeebo/assert"
+
+	"storj.io/common/testcontext"
+	"storj.io/storj/storagenode"
+	"storj.io/storj/storagenode/apikeys"
+	"storj.io/storj/storagenode/storagenodedb/storagenodedbtest"
+)
+
+func TestService(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		service := apikeys.NewService(db.APIKeys())
+
+		admin, err := service.Issue(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, apikeys.ScopeAdmin, admin.Scope)
+
+		readOnly, err := service.IssueWithOptions(ctx, apikeys.IssueOptions{
+			Name:  "dashboard",
+			Scope: apikeys.ScopeReadOnly,
+		})
+		assert.NoError(t, err)
+
+		_, err = service.IssueWithOptions(ctx, apikeys.IssueOptions{
+			Name:  "dashboard",
+			Scope: apikeys.ScopeAdmin,
+		})
+		assert.Error(t, err)
+
+		expiresAt := time.Now().Add(-time.Minute)
+		expired, err := service.IssueWithOptions(ctx, apikeys.IssueOptions{
+			Name:      "expired",
+			Scope:     apikeys.ScopeAdmin,
+			ExpiresAt: &expiresAt,
+		})
+		assert.NoError(t, err)
+
+		t.Run("Check", func(t *testing.T) {
+			assert.NoError(t, service.Check(ctx, admin.Secret, apikeys.ScopeAdmin))
+			assert.NoError(t, service.Check(ctx, admin.Secret, apikeys.ScopeReadOnly))
+			assert.NoError(t, service.Check(ctx, readOnly.Secret, apikeys.ScopeReadOnly))
+
+			err := service.Check(ctx, readOnly.Secret, apikeys.ScopeAdmin)
+			assert.True(t, apikeys.ErrScope.Has(err))
+
+			err = service.Check(ctx, expired.Secret, apikeys.ScopeReadOnly)
+			assert.True(t, apikeys.ErrExpired.Has(err))
+		})
+
+		t.Run("Revoke", func(t *testing.T) {
+			_, err := service.Revoke(ctx, "unknown")
+			assert.True(t, apikeys.ErrNoAPIKey.Has(err))
+
+			revoked, err := service.Revoke(ctx, "dashboard")
+			assert.NoError(t, err)
+			assert.Equal(t, readOnly.Secret, revoked.Secret)
+
+			revoked, err = service.Revoke(ctx, admin.ID())
+			assert.NoError(t, err)
+			assert.Equal(t, admin.Secret, revoked.Secret)
+
+			err = service.Check(ctx, admin.Secret, apikeys.ScopeReadOnly)
+			assert.True(t, apikeys.ErrNoAPIKey.Has(err))
+
+			keys, err := service.List(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, 1, len(keys))
+			assert.Equal(t, expired.Secret, keys[0].Secret)
+		})
+	})
+}
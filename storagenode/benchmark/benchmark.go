@@ -0,0 +1,251 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package benchmark measures the performance of the storage directory of a
+// storage node.
+package benchmark
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/memory"
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+	"storj.io/storj/storage"
+	"storj.io/storj/storagenode/pieces"
+)
+
+var (
+	// Error is the default error class for the benchmark.
+	Error = errs.Class("benchmark")
+
+	mon = monkit.Package()
+)
+
+// namespace is the namespace of the pieces written by the benchmark. It's
+// fixed, so that the pieces left behind by an interrupted benchmark are
+// deleted by the next one.
+var namespace = storj.NodeID{'s', 't', 'o', 'r', 'a', 'g', 'e', 'n', 'o', 'd', 'e', '-', 'b', 'e', 'n', 'c', 'h', 'm', 'a', 'r', 'k'}
+
+// Config contains the configuration of the benchmark and the recommended
+// performance of the storage directory.
+type Config struct {
+	Preflight bool        `help:"whether to benchmark the storage directory before starting the node" default:"false"`
+	Pieces    int         `help:"number of pieces written and read by the benchmark" default:"20"`
+	PieceSize memory.Size `help:"size of the pieces written and read by the benchmark" default:"2MiB"`
+
+	MinWriteThroughput memory.Size   `help:"recommended minimum throughput of writing pieces per second" default:"10MB"`
+	MaxCommitLatency   time.Duration `help:"recommended maximum 95th percentile of the latency of committing (fsync) a piece" default:"200ms"`
+	MinReadThroughput  memory.Size   `help:"recommended minimum throughput of reading pieces per second" default:"10MB"`
+	MaxReadLatency     time.Duration `help:"recommended maximum 95th percentile of the latency of opening a piece and reading its header" default:"100ms"`
+}
+
+// Latency summarizes the latencies of an operation.
+type Latency struct {
+	Average time.Duration `json:"average"`
+	P95     time.Duration `json:"p95"`
+	Max     time.Duration `json:"max"`
+}
+
+// newLatency summarizes the latencies.
+func newLatency(latencies []time.Duration) (latency Latency) {
+	if len(latencies) == 0 {
+		return Latency{}
+	}
+
+	sorted := append([]time.Duration(nil), latencies...)
+	sort.Slice(sorted, func(i, k int) bool { return sorted[i] < sorted[k] })
+
+	var total time.Duration
+	for _, l := range sorted {
+		total += l
+	}
+	return Latency{
+		Average: total / time.Duration(len(sorted)),
+		P95:     sorted[(len(sorted)*95+99)/100-1],
+		Max:     sorted[len(sorted)-1],
+	}
+}
+
+// Result contains the measured performance of the storage directory.
+type Result struct {
+	StartedAt time.Time     `json:"startedAt"`
+	Duration  time.Duration `json:"duration"`
+	Pieces    int           `json:"pieces"`
+	PieceSize int64         `json:"pieceSize"`
+
+	// WriteLatency is the latency of writing the data of a piece, without
+	// committing it.
+	WriteLatency Latency `json:"writeLatency"`
+	// CommitLatency is the latency of committing a piece, which includes the
+	// fsync of its file.
+	CommitLatency Latency `json:"commitLatency"`
+	// ReadLatency is the latency of opening a piece and reading its header.
+	ReadLatency Latency `json:"readLatency"`
+
+	// WriteThroughput is the number of bytes written and committed per second.
+	WriteThroughput int64 `json:"writeThroughput"`
+	// ReadThroughput is the number of bytes read per second.
+	ReadThroughput int64 `json:"readThroughput"`
+
+	// Warnings describe the measurements that don't meet the recommendations.
+	Warnings []string `json:"warnings"`
+}
+
+// Passed returns whether the measurements meet the recommendations.
+func (result Result) Passed() bool { return len(result.Warnings) == 0 }
+
+// check compares the measurements with the recommendations of the config.
+func (result *Result) check(config Config) {
+	result.Warnings = []string{}
+	if result.WriteThroughput < config.MinWriteThroughput.Int64() {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("write throughput of %s/s is below the recommended %s/s",
+			memory.Size(result.WriteThroughput), config.MinWriteThroughput))
+	}
+	if result.CommitLatency.P95 > config.MaxCommitLatency {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("commit (fsync) latency of %s is above the recommended %s",
+			result.CommitLatency.P95, config.MaxCommitLatency))
+	}
+	if result.ReadThroughput < config.MinReadThroughput.Int64() {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("read throughput of %s/s is below the recommended %s/s",
+			memory.Size(result.ReadThroughput), config.MinReadThroughput))
+	}
+	if result.ReadLatency.P95 > config.MaxReadLatency {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("read latency of %s is above the recommended %s",
+			result.ReadLatency.P95, config.MaxReadLatency))
+	}
+}
+
+// Run benchmarks the blob store of the node by writing and reading pieces the
+// same way uploads and downloads do, so that whatever the blob store is
+// configured with, such as the packed storage, the tiered cache or a
+// migration in progress, is measured too. The pieces are written to a
+// separate namespace, which is deleted afterwards, so that the blobs of the
+// node aren't touched.
+func Run(ctx context.Context, log *zap.Logger, blobs storage.Blobs, piecesConfig pieces.Config, config Config) (result Result, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if config.Pieces <= 0 || config.PieceSize <= 0 {
+		return Result{}, Error.New("the number and the size of the pieces must be positive")
+	}
+
+	// the pieces are written to the blob store directly, so that they aren't
+	// counted in the space used by the satellites.
+	store := pieces.NewStore(log, blobs, nil, nil, nil, piecesConfig)
+
+	if err := blobs.DeleteNamespace(ctx, namespace.Bytes()); err != nil {
+		return Result{}, Error.Wrap(err)
+	}
+	defer func() {
+		err = errs.Combine(err, Error.Wrap(blobs.DeleteNamespace(ctx, namespace.Bytes())))
+	}()
+
+	data := make([]byte, config.PieceSize.Int64())
+	if _, err := rand.Read(data); err != nil {
+		return Result{}, Error.Wrap(err)
+	}
+
+	result = Result{
+		StartedAt: time.Now(),
+		Pieces:    config.Pieces,
+		PieceSize: config.PieceSize.Int64(),
+	}
+
+	pieceIDs := make([]storj.PieceID, config.Pieces)
+	writeLatencies := make([]time.Duration, config.Pieces)
+	commitLatencies := make([]time.Duration, config.Pieces)
+	var writeTime time.Duration
+	for i := range pieceIDs {
+		pieceIDs[i] = storj.NewPieceID()
+
+		start := time.Now()
+		writer, err := store.Writer(ctx, namespace, pieceIDs[i])
+		if err != nil {
+			return Result{}, Error.Wrap(err)
+		}
+		if _, err := writer.Write(data); err != nil {
+			return Result{}, Error.Wrap(errs.Combine(err, writer.Cancel(ctx)))
+		}
+		written := time.Now()
+
+		err = writer.Commit(ctx, &pb.PieceHeader{
+			Hash:         writer.Hash(),
+			CreationTime: start,
+		})
+		if err != nil {
+			return Result{}, Error.Wrap(err)
+		}
+		committed := time.Now()
+
+		writeLatencies[i] = written.Sub(start)
+		commitLatencies[i] = committed.Sub(written)
+		writeTime += committed.Sub(start)
+	}
+
+	// drop the pieces from the page cache where possible, so that they're
+	// read from the disk.
+	for _, pieceID := range pieceIDs {
+		dropPageCache(ctx, blobs, storage.BlobRef{Namespace: namespace.Bytes(), Key: pieceID.Bytes()})
+	}
+
+	readLatencies := make([]time.Duration, config.Pieces)
+	var readTime time.Duration
+	// read the pieces in the reverse order, so that they aren't read sequentially.
+	for i := len(pieceIDs) - 1; i >= 0; i-- {
+		start := time.Now()
+		reader, err := store.Reader(ctx, namespace, pieceIDs[i])
+		if err != nil {
+			return Result{}, Error.Wrap(err)
+		}
+		_, err = reader.GetPieceHeader()
+		if err != nil {
+			return Result{}, Error.Wrap(errs.Combine(err, reader.Close()))
+		}
+		opened := time.Now()
+
+		n, err := io.Copy(io.Discard, reader)
+		err = errs.Combine(err, reader.Close())
+		if err != nil {
+			return Result{}, Error.Wrap(err)
+		}
+		if n != config.PieceSize.Int64() {
+			return Result{}, Error.New("read %d of %d bytes of piece", n, config.PieceSize.Int64())
+		}
+
+		readLatencies[i] = opened.Sub(start)
+		readTime += time.Since(start)
+	}
+
+	totalSize := config.PieceSize.Int64() * int64(config.Pieces)
+	result.Duration = time.Since(result.StartedAt)
+	result.WriteLatency = newLatency(writeLatencies)
+	result.CommitLatency = newLatency(commitLatencies)
+	result.ReadLatency = newLatency(readLatencies)
+	result.WriteThroughput = throughput(totalSize, writeTime)
+	result.ReadThroughput = throughput(totalSize, readTime)
+	result.check(config)
+
+	mon.IntVal("benchmark_write_throughput").Observe(result.WriteThroughput)
+	mon.IntVal("benchmark_read_throughput").Observe(result.ReadThroughput)
+	mon.DurationVal("benchmark_commit_latency_p95").Observe(result.CommitLatency.P95)
+	mon.DurationVal("benchmark_read_latency_p95").Observe(result.ReadLatency.P95)
+
+	return result, nil
+}
+
+// throughput returns the bytes per second.
+func throughput(bytes int64, duration time.Duration) int64 {
+	if duration <= 0 {
+		return 0
+	}
+	return int64(float64(bytes) / duration.Seconds())
+}
@@ -0,0 +1,45 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+//go:build linux
+// +build linux
+
+package benchmark
+
+import (
+	"context"
+	"os"
+
+	"golang.org/x/sys/unix"
+
+	"storj.io/storj/storage"
+)
+
+// dropPageCache asks the kernel to drop the pages of the blob from the page
+// cache, which are the whole blob file or the part of the shared file the blob
+// is stored in. Failures are ignored, because they only make the reads faster.
+func dropPageCache(ctx context.Context, blobs storage.Blobs, ref storage.BlobRef) {
+	info, err := blobs.Stat(ctx, ref)
+	if err != nil {
+		return
+	}
+
+	var path string
+	var offset, size int64
+	if extent, ok := info.(storage.BlobExtent); ok {
+		path, offset, size, err = extent.Extent(ctx)
+	} else {
+		path, err = info.FullPath(ctx)
+	}
+	if err != nil {
+		return
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer func() { _ = file.Close() }()
+
+	_ = unix.Fadvise(int(file.Fd()), offset, size, unix.FADV_DONTNEED)
+}
@@ -0,0 +1,17 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+//go:build !linux
+// +build !linux
+
+package benchmark
+
+import (
+	"context"
+
+	"storj.io/storj/storage"
+)
+
+// dropPageCache does nothing. Dropping blobs from the page cache is only
+// supported on Linux.
+func dropPageCache(ctx context.Context, blobs storage.Blobs, ref storage.BlobRef) {}
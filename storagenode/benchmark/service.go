@@ -0,0 +1,126 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package benchmark
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/memory"
+	"storj.io/storj/storage"
+	"storj.io/storj/storagenode/pieces"
+)
+
+// Service benchmarks the storage directory and keeps the latest result in a
+// file, so that the results of the benchmark command are shown in the
+// dashboard too.
+//
+// architecture: Service
+type Service struct {
+	log          *zap.Logger
+	blobs        storage.Blobs
+	piecesConfig pieces.Config
+	config       Config
+	path         string
+
+	mu sync.Mutex
+}
+
+// NewService creates a new benchmark service for the blob store of the node,
+// which keeps the latest result in the file at path.
+func NewService(log *zap.Logger, blobs storage.Blobs, piecesConfig pieces.Config, config Config, path string) *Service {
+	return &Service{
+		log:          log,
+		blobs:        blobs,
+		piecesConfig: piecesConfig,
+		config:       config,
+		path:         path,
+	}
+}
+
+// Run benchmarks the storage directory and saves the result.
+func (service *Service) Run(ctx context.Context) (_ Result, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	service.mu.Lock()
+	defer service.mu.Unlock()
+
+	result, err := Run(ctx, service.log, service.blobs, service.piecesConfig, service.config)
+	if err != nil {
+		return Result{}, err
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return Result{}, Error.Wrap(err)
+	}
+	if err := os.WriteFile(service.path, data, 0644); err != nil {
+		return Result{}, Error.Wrap(err)
+	}
+	return result, nil
+}
+
+// Latest returns the result of the latest benchmark, or nil when the storage
+// directory wasn't benchmarked yet.
+func (service *Service) Latest(ctx context.Context) (_ *Result, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	data, err := os.ReadFile(service.path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, Error.Wrap(err)
+	}
+
+	var result Result
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return &result, nil
+}
+
+// Preflight benchmarks the storage directory when it's enabled in the config
+// and warns when it's slower than recommended. It fails only when the
+// benchmark can't write or read the pieces.
+func (service *Service) Preflight(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if !service.config.Preflight {
+		service.log.Debug("storage directory benchmark is not enabled")
+		return nil
+	}
+
+	service.log.Info("benchmarking the storage directory",
+		zap.String("Path", filepath.Dir(service.path)),
+		zap.Int("Pieces", service.config.Pieces),
+		zap.Stringer("Piece Size", service.config.PieceSize))
+
+	result, err := service.Run(ctx)
+	if err != nil {
+		return err
+	}
+
+	fields := []zap.Field{
+		zap.Stringer("Write Throughput", memory.Size(result.WriteThroughput)),
+		zap.Duration("Commit Latency", result.CommitLatency.P95),
+		zap.Stringer("Read Throughput", memory.Size(result.ReadThroughput)),
+		zap.Duration("Read Latency", result.ReadLatency.P95),
+	}
+	if result.Passed() {
+		service.log.Info("storage directory benchmark passed", fields...)
+		return nil
+	}
+	for _, warning := range result.Warnings {
+		service.log.Warn("storage directory is slower than recommended; the node may lose upload races", append(fields, zap.String("Warning", warning))...)
+	}
+	return nil
+}
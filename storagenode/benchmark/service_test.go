@@ -0,0 +1,101 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package benchmark_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/memory"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/filestore"
+	"storj.io/storj/storage/packstore"
+	"storj.io/storj/storagenode/benchmark"
+	"storj.io/storj/storagenode/pieces"
+)
+
+func TestService(t *testing.T) {
+	t.Run("filestore", func(t *testing.T) {
+		testService(t, func(dir *filestore.Dir) (storage.Blobs, error) {
+			return filestore.New(zaptest.NewLogger(t), dir, filestore.DefaultConfig), nil
+		})
+	})
+	t.Run("packstore", func(t *testing.T) {
+		testService(t, func(dir *filestore.Dir) (storage.Blobs, error) {
+			return packstore.New(zaptest.NewLogger(t), dir, packstore.DefaultConfig)
+		})
+	})
+}
+
+func testService(t *testing.T, newBlobs func(dir *filestore.Dir) (storage.Blobs, error)) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	log := zaptest.NewLogger(t)
+
+	storagePath := ctx.Dir("storage")
+	dir, err := filestore.NewDir(log, storagePath)
+	require.NoError(t, err)
+	blobs, err := newBlobs(dir)
+	require.NoError(t, err)
+	defer ctx.Check(blobs.Close)
+
+	ref := storage.BlobRef{Namespace: testrand.NodeID().Bytes(), Key: testrand.PieceID().Bytes()}
+	writer, err := blobs.Create(ctx, ref, -1)
+	require.NoError(t, err)
+	_, err = writer.Write(testrand.BytesInt(1000))
+	require.NoError(t, err)
+	require.NoError(t, writer.Commit(ctx))
+
+	config := benchmark.Config{
+		Pieces:           5,
+		PieceSize:        64 * memory.KiB,
+		MaxCommitLatency: time.Hour,
+		MaxReadLatency:   time.Hour,
+	}
+	service := benchmark.NewService(log, blobs, pieces.DefaultConfig, config, filepath.Join(storagePath, "benchmark.json"))
+
+	latest, err := service.Latest(ctx)
+	require.NoError(t, err)
+	require.Nil(t, latest)
+
+	result, err := service.Run(ctx)
+	require.NoError(t, err)
+	require.True(t, result.Passed(), result.Warnings)
+	require.Equal(t, 5, result.Pieces)
+	require.EqualValues(t, 64*memory.KiB, result.PieceSize)
+	require.Positive(t, result.WriteThroughput)
+	require.Positive(t, result.ReadThroughput)
+	require.Positive(t, result.CommitLatency.Max)
+	require.LessOrEqual(t, result.CommitLatency.P95, result.CommitLatency.Max)
+
+	latest, err = service.Latest(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, latest)
+	require.Equal(t, result.WriteThroughput, latest.WriteThroughput)
+	require.True(t, result.StartedAt.Equal(latest.StartedAt))
+
+	// the pieces of the benchmark are written to a separate namespace, which
+	// is deleted, and the blobs of the node aren't touched.
+	namespaces, err := blobs.ListNamespaces(ctx)
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{ref.Namespace}, namespaces)
+	_, err = blobs.Stat(ctx, ref)
+	require.NoError(t, err)
+
+	// a storage directory slower than recommended is reported.
+	config.MinWriteThroughput = memory.EB
+	config.MaxReadLatency = 0
+	service = benchmark.NewService(log, blobs, pieces.DefaultConfig, config, filepath.Join(storagePath, "benchmark.json"))
+	result, err = service.Run(ctx)
+	require.NoError(t, err)
+	require.False(t, result.Passed())
+	require.Len(t, result.Warnings, 2)
+}
@@ -0,0 +1,70 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleapi
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/storagenode/benchmark"
+)
+
+// ErrBenchmarkAPI - console benchmark api error type.
+var ErrBenchmarkAPI = errs.Class("consoleapi benchmark")
+
+// Benchmark is an api controller that exposes the result of the latest
+// benchmark of the storage directory.
+type Benchmark struct {
+	log *zap.Logger
+
+	service *benchmark.Service
+}
+
+// NewBenchmark is a constructor for benchmark controller.
+func NewBenchmark(log *zap.Logger, service *benchmark.Service) *Benchmark {
+	return &Benchmark{
+		log:     log,
+		service: service,
+	}
+}
+
+// Latest returns the result of the latest benchmark, or null when the storage
+// directory wasn't benchmarked yet.
+func (controller *Benchmark) Latest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set(contentType, applicationJSON)
+
+	result, err := controller.service.Latest(ctx)
+	if err != nil {
+		controller.serveJSONError(w, http.StatusInternalServerError, ErrBenchmarkAPI.Wrap(err))
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		controller.log.Error("failed to encode json benchmark response", zap.Error(ErrBenchmarkAPI.Wrap(err)))
+	}
+}
+
+// serveJSONError writes JSON error to response output stream.
+func (controller *Benchmark) serveJSONError(w http.ResponseWriter, status int, err error) {
+	w.WriteHeader(status)
+
+	var response struct {
+		Error string `json:"error"`
+	}
+
+	response.Error = err.Error()
+
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		controller.log.Error("failed to write json error response", zap.Error(ErrBenchmarkAPI.Wrap(err)))
+		return
+	}
+}
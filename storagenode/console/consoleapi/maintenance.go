@@ -0,0 +1,169 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+	"storj.io/storj/private/nodemaintenance"
+	"storj.io/storj/storagenode/contact"
+	"storj.io/storj/storagenode/maintenance"
+)
+
+// ErrMaintenanceAPI - console maintenance api error type.
+var ErrMaintenanceAPI = errs.Class("consoleapi maintenance")
+
+// Maintenance is an api controller that exposes the planned maintenance window.
+type Maintenance struct {
+	log *zap.Logger
+
+	service *maintenance.Service
+	contact *contact.Chore
+}
+
+// MaintenanceWindow is the planned maintenance window and the answers of the
+// satellites to it.
+type MaintenanceWindow struct {
+	Start      *time.Time                   `json:"start"`
+	End        *time.Time                   `json:"end"`
+	Satellites []MaintenanceSatelliteStatus `json:"satellites"`
+}
+
+// MaintenanceSatelliteStatus is the answer of a satellite to the planned
+// maintenance window.
+type MaintenanceSatelliteStatus struct {
+	ID            storj.NodeID `json:"id"`
+	Supported     bool         `json:"supported"`
+	AcceptedStart *time.Time   `json:"acceptedStart"`
+	AcceptedEnd   *time.Time   `json:"acceptedEnd"`
+	Message       string       `json:"message"`
+	UpdatedAt     time.Time    `json:"updatedAt"`
+}
+
+// NewMaintenance is a constructor for maintenance controller.
+func NewMaintenance(log *zap.Logger, service *maintenance.Service, contact *contact.Chore) *Maintenance {
+	return &Maintenance{
+		log:     log,
+		service: service,
+		contact: contact,
+	}
+}
+
+// Window returns the planned maintenance window.
+func (controller *Maintenance) Window(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set(contentType, applicationJSON)
+
+	window, err := controller.service.Window(ctx)
+	if err != nil {
+		controller.serveJSONError(w, http.StatusInternalServerError, ErrMaintenanceAPI.Wrap(err))
+		return
+	}
+
+	controller.serveWindow(w, window)
+}
+
+// Plan replaces the planned maintenance window and reports it to the
+// satellites.
+func (controller *Maintenance) Plan(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set(contentType, applicationJSON)
+
+	var request struct {
+		Start time.Time `json:"start"`
+		End   time.Time `json:"end"`
+	}
+	if err = json.NewDecoder(r.Body).Decode(&request); err != nil {
+		controller.serveJSONError(w, http.StatusBadRequest, ErrMaintenanceAPI.Wrap(err))
+		return
+	}
+
+	window := nodemaintenance.Window{Start: request.Start, End: request.End}
+	if err = controller.service.Plan(ctx, window); err != nil {
+		controller.serveJSONError(w, http.StatusBadRequest, ErrMaintenanceAPI.Wrap(err))
+		return
+	}
+	controller.contact.Trigger(ctx)
+
+	window, err = controller.service.Window(ctx)
+	if err != nil {
+		controller.serveJSONError(w, http.StatusInternalServerError, ErrMaintenanceAPI.Wrap(err))
+		return
+	}
+
+	controller.serveWindow(w, window)
+}
+
+// Cancel cancels the planned maintenance window and reports it to the
+// satellites.
+func (controller *Maintenance) Cancel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set(contentType, applicationJSON)
+
+	if err = controller.service.Cancel(ctx); err != nil {
+		controller.serveJSONError(w, http.StatusInternalServerError, ErrMaintenanceAPI.Wrap(err))
+		return
+	}
+	controller.contact.Trigger(ctx)
+}
+
+// serveWindow writes the window and the answers of the satellites to the
+// response output stream.
+func (controller *Maintenance) serveWindow(w http.ResponseWriter, window nodemaintenance.Window) {
+	response := MaintenanceWindow{
+		Satellites: []MaintenanceSatelliteStatus{},
+	}
+	if !window.IsZero() {
+		response.Start, response.End = &window.Start, &window.End
+	}
+
+	for _, status := range controller.service.Statuses() {
+		satellite := MaintenanceSatelliteStatus{
+			ID:        status.SatelliteID,
+			Supported: status.Supported,
+			Message:   status.Message,
+			UpdatedAt: status.UpdatedAt,
+		}
+		if accepted := status.Accepted; !accepted.IsZero() {
+			satellite.AcceptedStart, satellite.AcceptedEnd = &accepted.Start, &accepted.End
+		}
+		response.Satellites = append(response.Satellites, satellite)
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		controller.log.Error("failed to encode json maintenance window response", zap.Error(ErrMaintenanceAPI.Wrap(err)))
+	}
+}
+
+// serveJSONError writes JSON error to response output stream.
+func (controller *Maintenance) serveJSONError(w http.ResponseWriter, status int, err error) {
+	w.WriteHeader(status)
+
+	var response struct {
+		Error string `json:"error"`
+	}
+
+	response.Error = err.Error()
+
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		controller.log.Error("failed to write json error response", zap.Error(ErrMaintenanceAPI.Wrap(err)))
+		return
+	}
+}
@@ -19,8 +19,11 @@ import (
 
 	"storj.io/common/errs2"
 	"storj.io/storj/private/web"
+	"storj.io/storj/storagenode/benchmark"
 	"storj.io/storj/storagenode/console"
 	"storj.io/storj/storagenode/console/consoleapi"
+	"storj.io/storj/storagenode/contact"
+	"storj.io/storj/storagenode/maintenance"
 	"storj.io/storj/storagenode/notifications"
 	"storj.io/storj/storagenode/payouts"
 )
@@ -46,6 +49,9 @@ type Server struct {
 	service       *console.Service
 	notifications *notifications.Service
 	payout        *payouts.Service
+	maintenance   *maintenance.Service
+	contact       *contact.Chore
+	benchmark     *benchmark.Service
 	listener      net.Listener
 	assets        fs.FS
 
@@ -53,7 +59,7 @@ type Server struct {
 }
 
 // NewServer creates new instance of storagenode console web server.
-func NewServer(logger *zap.Logger, assets fs.FS, notifications *notifications.Service, service *console.Service, payout *payouts.Service, listener net.Listener) *Server {
+func NewServer(logger *zap.Logger, assets fs.FS, notifications *notifications.Service, service *console.Service, payout *payouts.Service, maintenance *maintenance.Service, contact *contact.Chore, benchmark *benchmark.Service, listener net.Listener) *Server {
 	server := Server{
 		log:           logger,
 		service:       service,
@@ -61,6 +67,9 @@ func NewServer(logger *zap.Logger, assets fs.FS, notifications *notifications.Se
 		assets:        assets,
 		notifications: notifications,
 		payout:        payout,
+		maintenance:   maintenance,
+		contact:       contact,
+		benchmark:     benchmark,
 	}
 
 	router := mux.NewRouter()
@@ -74,6 +83,13 @@ func NewServer(logger *zap.Logger, assets fs.FS, notifications *notifications.Se
 	storageNodeRouter.HandleFunc("/satellite/{id}", storageNodeController.Satellite).Methods(http.MethodGet)
 	storageNodeRouter.HandleFunc("/estimated-payout", storageNodeController.EstimatedPayout).Methods(http.MethodGet)
 
+	maintenanceController := consoleapi.NewMaintenance(server.log, server.maintenance, server.contact)
+	storageNodeRouter.HandleFunc("/maintenance", maintenanceController.Window).Methods(http.MethodGet)
+	storageNodeRouter.HandleFunc("/maintenance", maintenanceController.Plan).Methods(http.MethodPost)
+	storageNodeRouter.HandleFunc("/maintenance", maintenanceController.Cancel).Methods(http.MethodDelete)
+	benchmarkController := consoleapi.NewBenchmark(server.log, server.benchmark)
+	storageNodeRouter.HandleFunc("/benchmark", benchmarkController.Latest).Methods(http.MethodGet)
+
 	notificationController := consoleapi.NewNotifications(server.log, server.notifications)
 	notificationRouter := router.PathPrefix("/api/notifications").Subrouter()
 	notificationRouter.StrictSlash(true)
@@ -3,6 +3,12 @@
 
 package console
 
+import (
+	"time"
+
+	"storj.io/common/storj"
+)
+
 // DiskSpaceInfo stores all info about storagenode disk space usage.
 type DiskSpaceInfo struct {
 	Used      int64 `json:"used"`
@@ -10,3 +16,12 @@ type DiskSpaceInfo struct {
 	Trash     int64 `json:"trash"`
 	Overused  int64 `json:"overused"`
 }
+
+// UsedSpaceWalkInfo stores the progress of recalculating the disk space used by a satellite.
+type UsedSpaceWalkInfo struct {
+	SatelliteID storj.NodeID `json:"satelliteID"`
+	Percent     float64      `json:"percent"`
+	StartedAt   time.Time    `json:"startedAt"`
+	UpdatedAt   time.Time    `json:"updatedAt"`
+	CompletedAt *time.Time   `json:"completedAt"`
+}
@@ -145,8 +145,9 @@ type Dashboard struct {
 
 	Satellites []SatelliteInfo `json:"satellites"`
 
-	DiskSpace DiskSpaceInfo `json:"diskSpace"`
-	Bandwidth BandwidthInfo `json:"bandwidth"`
+	DiskSpace     DiskSpaceInfo       `json:"diskSpace"`
+	UsedSpaceWalk []UsedSpaceWalkInfo `json:"usedSpaceWalk"`
+	Bandwidth     BandwidthInfo       `json:"bandwidth"`
 
 	LastPinged time.Time `json:"lastPinged"`
 
@@ -233,6 +234,20 @@ func (s *Service) GetDashboardData(ctx context.Context) (_ *Dashboard, err error
 		data.DiskSpace.Overused = int64(math.Abs(float64(overused)))
 	}
 
+	walkProgress, err := s.pieceStore.UsedSpaceWalkProgress(ctx)
+	if err != nil {
+		return nil, SNOServiceErr.Wrap(err)
+	}
+	for _, progress := range walkProgress {
+		data.UsedSpaceWalk = append(data.UsedSpaceWalk, UsedSpaceWalkInfo{
+			SatelliteID: progress.SatelliteID,
+			Percent:     progress.Percent(),
+			StartedAt:   progress.StartedAt,
+			UpdatedAt:   progress.UpdatedAt,
+			CompletedAt: progress.CompletedAt,
+		})
+	}
+
 	data.Bandwidth = BandwidthInfo{
 		Used: bandwidthUsage,
 	}
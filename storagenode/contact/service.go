@@ -18,6 +18,8 @@ import (
 	"storj.io/common/rpc"
 	"storj.io/common/storj"
 	"storj.io/common/sync2"
+	"storj.io/storj/private/nodemaintenance"
+	"storj.io/storj/storagenode/maintenance"
 	"storj.io/storj/storagenode/trust"
 )
 
@@ -58,19 +60,21 @@ type Service struct {
 	mu   sync.Mutex
 	self NodeInfo
 
-	trust *trust.Pool
+	trust       *trust.Pool
+	maintenance *maintenance.Service
 
 	initialized sync2.Fence
 }
 
 // NewService creates a new contact service.
-func NewService(log *zap.Logger, dialer rpc.Dialer, self NodeInfo, trust *trust.Pool) *Service {
+func NewService(log *zap.Logger, dialer rpc.Dialer, self NodeInfo, trust *trust.Pool, maintenance *maintenance.Service) *Service {
 	return &Service{
-		log:    log,
-		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
-		dialer: dialer,
-		trust:  trust,
-		self:   self,
+		log:         log,
+		rand:        rand.New(rand.NewSource(time.Now().UnixNano())),
+		dialer:      dialer,
+		trust:       trust,
+		maintenance: maintenance,
+		self:        self,
 	}
 }
 
@@ -126,15 +130,29 @@ func (service *Service) pingSatelliteOnce(ctx context.Context, id storj.NodeID)
 	defer func() { err = errs.Combine(err, conn.Close()) }()
 
 	self := service.Local()
-	resp, err := pb.NewDRPCNodeClient(conn).CheckIn(ctx, &pb.CheckInRequest{
+	req := &pb.CheckInRequest{
 		Address:  self.Address,
 		Version:  &self.Version,
 		Capacity: &self.Capacity,
 		Operator: &self.Operator,
-	})
+	}
+
+	var window nodemaintenance.Window
+	if service.maintenance != nil {
+		window, err = service.maintenance.Window(ctx)
+		if err != nil {
+			service.log.Error("failed to load the maintenance window", zap.Error(err))
+		}
+		nodemaintenance.SetRequest(req, window)
+	}
+
+	resp, err := pb.NewDRPCNodeClient(conn).CheckIn(ctx, req)
 	if err != nil {
 		return errPingSatellite.Wrap(err)
 	}
+	if service.maintenance != nil {
+		service.updateMaintenanceStatus(id, window, resp)
+	}
 	if resp != nil && !resp.PingNodeSuccess {
 		return errPingSatellite.New("%s", resp.PingErrorMessage)
 	}
@@ -144,6 +162,35 @@ func (service *Service) pingSatelliteOnce(ctx context.Context, id storj.NodeID)
 	return nil
 }
 
+// updateMaintenanceStatus records the answer of the satellite to the reported
+// maintenance window.
+func (service *Service) updateMaintenanceStatus(id storj.NodeID, window nodemaintenance.Window, resp *pb.CheckInResponse) {
+	status, supported, err := nodemaintenance.FromResponse(resp)
+	if err != nil {
+		service.log.Warn("invalid maintenance status from satellite", zap.Stringer("Satellite ID", id), zap.Error(err))
+	}
+	if window.IsZero() {
+		return
+	}
+
+	service.maintenance.SetStatus(maintenance.SatelliteStatus{
+		SatelliteID: id,
+		Status:      status,
+		Supported:   supported,
+		UpdatedAt:   time.Now(),
+	})
+
+	switch {
+	case !supported:
+		service.log.Warn("The satellite doesn't support maintenance windows.", zap.Stringer("Satellite ID", id))
+	case status.Accepted.IsZero():
+		service.log.Warn("The satellite rejected the maintenance window.", zap.Stringer("Satellite ID", id), zap.String("Reason", status.Message))
+	case !status.Accepted.Equal(window):
+		service.log.Warn("The satellite accepted a different maintenance window.", zap.Stringer("Satellite ID", id),
+			zap.Time("Start", status.Accepted.Start), zap.Time("End", status.Accepted.End), zap.String("Reason", status.Message))
+	}
+}
+
 // RequestPingMeQUIC sends pings request to satellite for a pingBack via QUIC.
 func (service *Service) RequestPingMeQUIC(ctx context.Context) (err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -0,0 +1,152 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package maintenance plans maintenance windows of the node, during which the
+// satellites don't count the node being offline against it.
+package maintenance
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+	"storj.io/storj/private/nodemaintenance"
+)
+
+var (
+	mon = monkit.Package()
+
+	// Error is the error class for the maintenance service.
+	Error = errs.Class("maintenance")
+)
+
+// DB stores the planned maintenance window of the node.
+//
+// architecture: Database
+type DB interface {
+	// Get returns the planned maintenance window or a zero window.
+	Get(ctx context.Context) (nodemaintenance.Window, error)
+	// Set replaces the planned maintenance window.
+	Set(ctx context.Context, window nodemaintenance.Window) error
+	// Delete deletes the planned maintenance window.
+	Delete(ctx context.Context) error
+}
+
+// SatelliteStatus is the answer of a satellite to the reported window.
+type SatelliteStatus struct {
+	SatelliteID storj.NodeID
+	nodemaintenance.Status
+	// Supported is false when the satellite doesn't accept maintenance
+	// windows.
+	Supported bool
+	UpdatedAt time.Time
+}
+
+// Service keeps the planned maintenance window of the node, which is reported
+// to the satellites when checking in, and the answers of the satellites.
+//
+// architecture: Service
+type Service struct {
+	log *zap.Logger
+	db  DB
+
+	mu       sync.Mutex
+	statuses map[storj.NodeID]SatelliteStatus
+}
+
+// NewService creates a new maintenance service.
+func NewService(log *zap.Logger, db DB) *Service {
+	return &Service{
+		log:      log,
+		db:       db,
+		statuses: make(map[storj.NodeID]SatelliteStatus),
+	}
+}
+
+// Window returns the planned maintenance window, or a zero window when no
+// window is planned or the planned one is over.
+func (service *Service) Window(ctx context.Context) (_ nodemaintenance.Window, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	window, err := service.db.Get(ctx)
+	if err != nil {
+		return nodemaintenance.Window{}, Error.Wrap(err)
+	}
+	if !window.IsZero() && !window.End.After(time.Now()) {
+		return nodemaintenance.Window{}, nil
+	}
+	return window, nil
+}
+
+// Plan replaces the planned maintenance window. It's reported to the
+// satellites at the next check-in.
+func (service *Service) Plan(ctx context.Context, window nodemaintenance.Window) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := window.Validate(); err != nil {
+		return Error.Wrap(err)
+	}
+	if !window.End.After(time.Now()) {
+		return Error.New("the maintenance window is already over")
+	}
+
+	// the satellites only keep whole seconds.
+	window.Start = window.Start.UTC().Truncate(time.Second)
+	window.End = window.End.UTC().Truncate(time.Second)
+	if err := service.db.Set(ctx, window); err != nil {
+		return Error.Wrap(err)
+	}
+	service.resetStatuses()
+
+	service.log.Info("planned maintenance window",
+		zap.Time("Start", window.Start),
+		zap.Time("End", window.End))
+	return nil
+}
+
+// Cancel cancels the planned maintenance window, or ends the one in progress,
+// at the next check-in.
+func (service *Service) Cancel(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := service.db.Delete(ctx); err != nil {
+		return Error.Wrap(err)
+	}
+	service.resetStatuses()
+
+	service.log.Info("canceled maintenance window")
+	return nil
+}
+
+// SetStatus records the answer of a satellite to the reported window.
+func (service *Service) SetStatus(status SatelliteStatus) {
+	service.mu.Lock()
+	defer service.mu.Unlock()
+
+	service.statuses[status.SatelliteID] = status
+}
+
+// Statuses returns the answers of the satellites to the reported window
+// since it was last changed.
+func (service *Service) Statuses() []SatelliteStatus {
+	service.mu.Lock()
+	defer service.mu.Unlock()
+
+	statuses := make([]SatelliteStatus, 0, len(service.statuses))
+	for _, status := range service.statuses {
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+func (service *Service) resetStatuses() {
+	service.mu.Lock()
+	defer service.mu.Unlock()
+
+	service.statuses = make(map[storj.NodeID]SatelliteStatus)
+}
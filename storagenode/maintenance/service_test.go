@@ -0,0 +1,61 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package maintenance_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/private/nodemaintenance"
+	"storj.io/storj/storagenode"
+	"storj.io/storj/storagenode/maintenance"
+	"storj.io/storj/storagenode/storagenodedb/storagenodedbtest"
+)
+
+func TestService(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		service := maintenance.NewService(zaptest.NewLogger(t), db.Maintenance())
+
+		window, err := service.Window(ctx)
+		require.NoError(t, err)
+		require.True(t, window.IsZero())
+
+		now := time.Now()
+		planned := nodemaintenance.Window{Start: now.Add(time.Hour), End: now.Add(3 * time.Hour)}
+
+		// invalid and past windows are refused.
+		require.Error(t, service.Plan(ctx, nodemaintenance.Window{Start: planned.End, End: planned.Start}))
+		require.Error(t, service.Plan(ctx, nodemaintenance.Window{Start: now.Add(-2 * time.Hour), End: now.Add(-time.Hour)}))
+
+		require.NoError(t, service.Plan(ctx, planned))
+		window, err = service.Window(ctx)
+		require.NoError(t, err)
+		require.True(t, window.Equal(nodemaintenance.Window{
+			Start: planned.Start.Truncate(time.Second),
+			End:   planned.End.Truncate(time.Second),
+		}))
+
+		service.SetStatus(maintenance.SatelliteStatus{
+			SatelliteID: testrand.NodeID(),
+			Status:      nodemaintenance.Status{Accepted: window},
+			Supported:   true,
+			UpdatedAt:   now,
+		})
+		require.Len(t, service.Statuses(), 1)
+
+		// replacing the window forgets the answers to the previous one.
+		require.NoError(t, service.Plan(ctx, nodemaintenance.Window{Start: now, End: now.Add(time.Hour)}))
+		require.Empty(t, service.Statuses())
+
+		require.NoError(t, service.Cancel(ctx))
+		window, err = service.Window(ctx)
+		require.NoError(t, err)
+		require.True(t, window.IsZero())
+	})
+}
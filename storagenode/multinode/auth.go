@@ -6,20 +6,25 @@ package multinode
 import (
 	"context"
 
+	"storj.io/common/rpc/rpcstatus"
 	"storj.io/storj/private/multinodeauth"
 	"storj.io/storj/private/multinodepb"
 	"storj.io/storj/storagenode/apikeys"
 )
 
-// authenticate checks if request header contains valid api key.
-func authenticate(ctx context.Context, apiKeys *apikeys.Service, header *multinodepb.RequestHeader) error {
+// authenticate checks if request header contains valid api key with the
+// required scope.
+func authenticate(ctx context.Context, apiKeys *apikeys.Service, required apikeys.Scope, header *multinodepb.RequestHeader) error {
 	secret, err := multinodeauth.SecretFromBytes(header.GetApiKey())
 	if err != nil {
-		return err
+		return rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
 	}
 
-	if err = apiKeys.Check(ctx, secret); err != nil {
-		return err
+	if err = apiKeys.Check(ctx, secret, required); err != nil {
+		if apikeys.ErrScope.Has(err) {
+			return rpcstatus.Wrap(rpcstatus.PermissionDenied, err)
+		}
+		return rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
 	}
 
 	return nil
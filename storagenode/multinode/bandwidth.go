@@ -42,8 +42,8 @@ func NewBandwidthEndpoint(log *zap.Logger, apiKeys *apikeys.Service, db bandwidt
 func (bandwidth *BandwidthEndpoint) MonthSummary(ctx context.Context, req *multinodepb.BandwidthMonthSummaryRequest) (_ *multinodepb.BandwidthMonthSummaryResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, bandwidth.apiKeys, req.GetHeader()); err != nil {
-		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	if err = authenticate(ctx, bandwidth.apiKeys, apikeys.ScopeReadOnly, req.GetHeader()); err != nil {
+		return nil, err
 	}
 
 	used, err := bandwidth.db.MonthSummary(ctx, time.Now())
@@ -60,8 +60,8 @@ func (bandwidth *BandwidthEndpoint) MonthSummary(ctx context.Context, req *multi
 func (bandwidth *BandwidthEndpoint) BandwidthSummarySatellite(ctx context.Context, req *multinodepb.BandwidthSummarySatelliteRequest) (_ *multinodepb.BandwidthSummarySatelliteResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, bandwidth.apiKeys, req.GetHeader()); err != nil {
-		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	if err = authenticate(ctx, bandwidth.apiKeys, apikeys.ScopeReadOnly, req.GetHeader()); err != nil {
+		return nil, err
 	}
 
 	from, to := date.MonthBoundary(time.Now().UTC())
@@ -78,8 +78,8 @@ func (bandwidth *BandwidthEndpoint) BandwidthSummarySatellite(ctx context.Contex
 func (bandwidth *BandwidthEndpoint) BandwidthSummary(ctx context.Context, req *multinodepb.BandwidthSummaryRequest) (_ *multinodepb.BandwidthSummaryResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, bandwidth.apiKeys, req.GetHeader()); err != nil {
-		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	if err = authenticate(ctx, bandwidth.apiKeys, apikeys.ScopeReadOnly, req.GetHeader()); err != nil {
+		return nil, err
 	}
 
 	from, to := date.MonthBoundary(time.Now().UTC())
@@ -96,8 +96,8 @@ func (bandwidth *BandwidthEndpoint) BandwidthSummary(ctx context.Context, req *m
 func (bandwidth *BandwidthEndpoint) EgressSummarySatellite(ctx context.Context, req *multinodepb.EgressSummarySatelliteRequest) (_ *multinodepb.EgressSummarySatelliteResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, bandwidth.apiKeys, req.GetHeader()); err != nil {
-		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	if err = authenticate(ctx, bandwidth.apiKeys, apikeys.ScopeReadOnly, req.GetHeader()); err != nil {
+		return nil, err
 	}
 
 	from, to := date.MonthBoundary(time.Now().UTC())
@@ -114,8 +114,8 @@ func (bandwidth *BandwidthEndpoint) EgressSummarySatellite(ctx context.Context,
 func (bandwidth *BandwidthEndpoint) EgressSummary(ctx context.Context, req *multinodepb.EgressSummaryRequest) (_ *multinodepb.EgressSummaryResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, bandwidth.apiKeys, req.GetHeader()); err != nil {
-		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	if err = authenticate(ctx, bandwidth.apiKeys, apikeys.ScopeReadOnly, req.GetHeader()); err != nil {
+		return nil, err
 	}
 
 	from, to := date.MonthBoundary(time.Now().UTC())
@@ -132,8 +132,8 @@ func (bandwidth *BandwidthEndpoint) EgressSummary(ctx context.Context, req *mult
 func (bandwidth *BandwidthEndpoint) IngressSummarySatellite(ctx context.Context, req *multinodepb.IngressSummarySatelliteRequest) (_ *multinodepb.IngressSummarySatelliteResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, bandwidth.apiKeys, req.GetHeader()); err != nil {
-		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	if err = authenticate(ctx, bandwidth.apiKeys, apikeys.ScopeReadOnly, req.GetHeader()); err != nil {
+		return nil, err
 	}
 
 	from, to := date.MonthBoundary(time.Now().UTC())
@@ -150,8 +150,8 @@ func (bandwidth *BandwidthEndpoint) IngressSummarySatellite(ctx context.Context,
 func (bandwidth *BandwidthEndpoint) IngressSummary(ctx context.Context, req *multinodepb.IngressSummaryRequest) (_ *multinodepb.IngressSummaryResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, bandwidth.apiKeys, req.GetHeader()); err != nil {
-		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	if err = authenticate(ctx, bandwidth.apiKeys, apikeys.ScopeReadOnly, req.GetHeader()); err != nil {
+		return nil, err
 	}
 
 	from, to := date.MonthBoundary(time.Now().UTC())
@@ -168,8 +168,8 @@ func (bandwidth *BandwidthEndpoint) IngressSummary(ctx context.Context, req *mul
 func (bandwidth *BandwidthEndpoint) DailySatellite(ctx context.Context, req *multinodepb.DailySatelliteRequest) (_ *multinodepb.DailySatelliteResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, bandwidth.apiKeys, req.GetHeader()); err != nil {
-		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	if err = authenticate(ctx, bandwidth.apiKeys, apikeys.ScopeReadOnly, req.GetHeader()); err != nil {
+		return nil, err
 	}
 
 	from, to := date.MonthBoundary(time.Now().UTC())
@@ -203,8 +203,8 @@ func (bandwidth *BandwidthEndpoint) DailySatellite(ctx context.Context, req *mul
 func (bandwidth *BandwidthEndpoint) Daily(ctx context.Context, req *multinodepb.DailyRequest) (_ *multinodepb.DailyResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, bandwidth.apiKeys, req.GetHeader()); err != nil {
-		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	if err = authenticate(ctx, bandwidth.apiKeys, apikeys.ScopeReadOnly, req.GetHeader()); err != nil {
+		return nil, err
 	}
 
 	from, to := date.MonthBoundary(time.Now().UTC())
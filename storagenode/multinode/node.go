@@ -54,8 +54,8 @@ func NewNodeEndpoint(log *zap.Logger, config operator.Config, apiKeys *apikeys.S
 func (node *NodeEndpoint) Version(ctx context.Context, req *multinodepb.VersionRequest) (_ *multinodepb.VersionResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, node.apiKeys, req.GetHeader()); err != nil {
-		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	if err = authenticate(ctx, node.apiKeys, apikeys.ScopeReadOnly, req.GetHeader()); err != nil {
+		return nil, err
 	}
 
 	return &multinodepb.VersionResponse{
@@ -67,8 +67,8 @@ func (node *NodeEndpoint) Version(ctx context.Context, req *multinodepb.VersionR
 func (node *NodeEndpoint) LastContact(ctx context.Context, req *multinodepb.LastContactRequest) (_ *multinodepb.LastContactResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, node.apiKeys, req.GetHeader()); err != nil {
-		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	if err = authenticate(ctx, node.apiKeys, apikeys.ScopeReadOnly, req.GetHeader()); err != nil {
+		return nil, err
 	}
 
 	return &multinodepb.LastContactResponse{
@@ -80,8 +80,8 @@ func (node *NodeEndpoint) LastContact(ctx context.Context, req *multinodepb.Last
 func (node *NodeEndpoint) Reputation(ctx context.Context, req *multinodepb.ReputationRequest) (_ *multinodepb.ReputationResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, node.apiKeys, req.GetHeader()); err != nil {
-		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	if err = authenticate(ctx, node.apiKeys, apikeys.ScopeReadOnly, req.GetHeader()); err != nil {
+		return nil, err
 	}
 
 	rep, err := node.reputation.Get(ctx, req.SatelliteId)
@@ -132,8 +132,8 @@ func (node *NodeEndpoint) Reputation(ctx context.Context, req *multinodepb.Reput
 func (node *NodeEndpoint) TrustedSatellites(ctx context.Context, req *multinodepb.TrustedSatellitesRequest) (_ *multinodepb.TrustedSatellitesResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, node.apiKeys, req.GetHeader()); err != nil {
-		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	if err = authenticate(ctx, node.apiKeys, apikeys.ScopeReadOnly, req.GetHeader()); err != nil {
+		return nil, err
 	}
 
 	response := new(multinodepb.TrustedSatellitesResponse)
@@ -157,8 +157,8 @@ func (node *NodeEndpoint) TrustedSatellites(ctx context.Context, req *multinodep
 // Operator returns operators data.
 func (node *NodeEndpoint) Operator(ctx context.Context, req *multinodepb.OperatorRequest) (_ *multinodepb.OperatorResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
-	if err = authenticate(ctx, node.apiKeys, req.GetHeader()); err != nil {
-		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	if err = authenticate(ctx, node.apiKeys, apikeys.ScopeAdmin, req.GetHeader()); err != nil {
+		return nil, err
 	}
 	return &multinodepb.OperatorResponse{
 		Email:          node.config.Email,
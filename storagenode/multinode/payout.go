@@ -48,8 +48,8 @@ func NewPayoutEndpoint(log *zap.Logger, apiKeys *apikeys.Service, db payouts.DB,
 func (payout *PayoutEndpoint) Earned(ctx context.Context, req *multinodepb.EarnedRequest) (_ *multinodepb.EarnedResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, payout.apiKeys, req.GetHeader()); err != nil {
-		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	if err = authenticate(ctx, payout.apiKeys, apikeys.ScopeReadOnly, req.GetHeader()); err != nil {
+		return nil, err
 	}
 
 	earned, err := payout.db.GetTotalEarned(ctx)
@@ -66,8 +66,8 @@ func (payout *PayoutEndpoint) Earned(ctx context.Context, req *multinodepb.Earne
 func (payout *PayoutEndpoint) EarnedSatellite(ctx context.Context, req *multinodepb.EarnedSatelliteRequest) (_ *multinodepb.EarnedSatelliteResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, payout.apiKeys, req.GetHeader()); err != nil {
-		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	if err = authenticate(ctx, payout.apiKeys, apikeys.ScopeReadOnly, req.GetHeader()); err != nil {
+		return nil, err
 	}
 
 	var resp multinodepb.EarnedSatelliteResponse
@@ -96,8 +96,8 @@ func (payout *PayoutEndpoint) EarnedSatellite(ctx context.Context, req *multinod
 func (payout *PayoutEndpoint) EstimatedPayout(ctx context.Context, req *multinodepb.EstimatedPayoutRequest) (_ *multinodepb.EstimatedPayoutResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, payout.apiKeys, req.GetHeader()); err != nil {
-		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	if err = authenticate(ctx, payout.apiKeys, apikeys.ScopeReadOnly, req.GetHeader()); err != nil {
+		return nil, err
 	}
 
 	estimated, err := payout.estimatedPayouts.GetAllSatellitesEstimatedPayout(ctx, time.Now())
@@ -112,8 +112,8 @@ func (payout *PayoutEndpoint) EstimatedPayout(ctx context.Context, req *multinod
 func (payout *PayoutEndpoint) EstimatedPayoutSatellite(ctx context.Context, req *multinodepb.EstimatedPayoutSatelliteRequest) (_ *multinodepb.EstimatedPayoutSatelliteResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, payout.apiKeys, req.GetHeader()); err != nil {
-		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	if err = authenticate(ctx, payout.apiKeys, apikeys.ScopeReadOnly, req.GetHeader()); err != nil {
+		return nil, err
 	}
 
 	estimated, err := payout.estimatedPayouts.GetSatelliteEstimatedPayout(ctx, req.SatelliteId, time.Now())
@@ -128,8 +128,8 @@ func (payout *PayoutEndpoint) EstimatedPayoutSatellite(ctx context.Context, req
 func (payout *PayoutEndpoint) Summary(ctx context.Context, req *multinodepb.SummaryRequest) (_ *multinodepb.SummaryResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, payout.apiKeys, req.GetHeader()); err != nil {
-		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	if err = authenticate(ctx, payout.apiKeys, apikeys.ScopeReadOnly, req.GetHeader()); err != nil {
+		return nil, err
 	}
 
 	var totalPaid, totalHeld int64
@@ -156,8 +156,8 @@ func (payout *PayoutEndpoint) Summary(ctx context.Context, req *multinodepb.Summ
 func (payout *PayoutEndpoint) SummaryPeriod(ctx context.Context, req *multinodepb.SummaryPeriodRequest) (_ *multinodepb.SummaryPeriodResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, payout.apiKeys, req.GetHeader()); err != nil {
-		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	if err = authenticate(ctx, payout.apiKeys, apikeys.ScopeReadOnly, req.GetHeader()); err != nil {
+		return nil, err
 	}
 
 	var totalPaid, totalHeld int64
@@ -184,8 +184,8 @@ func (payout *PayoutEndpoint) SummaryPeriod(ctx context.Context, req *multinodep
 func (payout *PayoutEndpoint) SummarySatellite(ctx context.Context, req *multinodepb.SummarySatelliteRequest) (_ *multinodepb.SummarySatelliteResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, payout.apiKeys, req.GetHeader()); err != nil {
-		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	if err = authenticate(ctx, payout.apiKeys, apikeys.ScopeReadOnly, req.GetHeader()); err != nil {
+		return nil, err
 	}
 
 	var totalPaid, totalHeld int64
@@ -202,8 +202,8 @@ func (payout *PayoutEndpoint) SummarySatellite(ctx context.Context, req *multino
 func (payout *PayoutEndpoint) SummarySatellitePeriod(ctx context.Context, req *multinodepb.SummarySatellitePeriodRequest) (_ *multinodepb.SummarySatellitePeriodResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, payout.apiKeys, req.GetHeader()); err != nil {
-		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	if err = authenticate(ctx, payout.apiKeys, apikeys.ScopeReadOnly, req.GetHeader()); err != nil {
+		return nil, err
 	}
 
 	var totalPaid, totalHeld int64
@@ -220,8 +220,8 @@ func (payout *PayoutEndpoint) SummarySatellitePeriod(ctx context.Context, req *m
 func (payout *PayoutEndpoint) Undistributed(ctx context.Context, req *multinodepb.UndistributedRequest) (_ *multinodepb.UndistributedResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, payout.apiKeys, req.GetHeader()); err != nil {
-		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	if err = authenticate(ctx, payout.apiKeys, apikeys.ScopeReadOnly, req.GetHeader()); err != nil {
+		return nil, err
 	}
 
 	earned, err := payout.db.GetUndistributed(ctx)
@@ -236,8 +236,8 @@ func (payout *PayoutEndpoint) Undistributed(ctx context.Context, req *multinodep
 func (payout *PayoutEndpoint) PaystubSatellite(ctx context.Context, req *multinodepb.PaystubSatelliteRequest) (_ *multinodepb.PaystubSatelliteResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, payout.apiKeys, req.GetHeader()); err != nil {
-		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	if err = authenticate(ctx, payout.apiKeys, apikeys.ScopeReadOnly, req.GetHeader()); err != nil {
+		return nil, err
 	}
 
 	paystub, err := payout.db.GetSatellitePaystubs(ctx, req.SatelliteId)
@@ -265,8 +265,8 @@ func (payout *PayoutEndpoint) PaystubSatellite(ctx context.Context, req *multino
 func (payout *PayoutEndpoint) Paystub(ctx context.Context, req *multinodepb.PaystubRequest) (_ *multinodepb.PaystubResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, payout.apiKeys, req.GetHeader()); err != nil {
-		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	if err = authenticate(ctx, payout.apiKeys, apikeys.ScopeReadOnly, req.GetHeader()); err != nil {
+		return nil, err
 	}
 
 	paystub, err := payout.db.GetPaystubs(ctx)
@@ -294,8 +294,8 @@ func (payout *PayoutEndpoint) Paystub(ctx context.Context, req *multinodepb.Pays
 func (payout *PayoutEndpoint) PaystubPeriod(ctx context.Context, req *multinodepb.PaystubPeriodRequest) (_ *multinodepb.PaystubPeriodResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, payout.apiKeys, req.GetHeader()); err != nil {
-		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	if err = authenticate(ctx, payout.apiKeys, apikeys.ScopeReadOnly, req.GetHeader()); err != nil {
+		return nil, err
 	}
 
 	paystub, err := payout.db.GetPeriodPaystubs(ctx, req.Period)
@@ -323,8 +323,8 @@ func (payout *PayoutEndpoint) PaystubPeriod(ctx context.Context, req *multinodep
 func (payout *PayoutEndpoint) PaystubSatellitePeriod(ctx context.Context, req *multinodepb.PaystubSatellitePeriodRequest) (_ *multinodepb.PaystubSatellitePeriodResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, payout.apiKeys, req.GetHeader()); err != nil {
-		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	if err = authenticate(ctx, payout.apiKeys, apikeys.ScopeReadOnly, req.GetHeader()); err != nil {
+		return nil, err
 	}
 
 	paystub, err := payout.db.GetSatellitePeriodPaystubs(ctx, req.Period, req.SatelliteId)
@@ -352,8 +352,8 @@ func (payout *PayoutEndpoint) PaystubSatellitePeriod(ctx context.Context, req *m
 func (payout *PayoutEndpoint) HeldAmountHistory(ctx context.Context, req *multinodepb.HeldAmountHistoryRequest) (_ *multinodepb.HeldAmountHistoryResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, payout.apiKeys, req.GetHeader()); err != nil {
-		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	if err = authenticate(ctx, payout.apiKeys, apikeys.ScopeReadOnly, req.GetHeader()); err != nil {
+		return nil, err
 	}
 
 	heldHistory, err := payout.service.HeldAmountHistory(ctx)
@@ -386,8 +386,8 @@ func (payout *PayoutEndpoint) HeldAmountHistory(ctx context.Context, req *multin
 func (payout *PayoutEndpoint) PeriodPaystub(ctx context.Context, req *multinodepb.PeriodPaystubRequest) (_ *multinodepb.PeriodPaystubResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, payout.apiKeys, req.GetHeader()); err != nil {
-		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	if err = authenticate(ctx, payout.apiKeys, apikeys.ScopeReadOnly, req.GetHeader()); err != nil {
+		return nil, err
 	}
 
 	paystub, err := payout.db.GetPeriodPaystubs(ctx, req.Period)
@@ -415,8 +415,8 @@ func (payout *PayoutEndpoint) PeriodPaystub(ctx context.Context, req *multinodep
 func (payout *PayoutEndpoint) EarnedPerSatellite(ctx context.Context, req *multinodepb.EarnedPerSatelliteRequest) (_ *multinodepb.EarnedPerSatelliteResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, payout.apiKeys, req.GetHeader()); err != nil {
-		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	if err = authenticate(ctx, payout.apiKeys, apikeys.ScopeReadOnly, req.GetHeader()); err != nil {
+		return nil, err
 	}
 
 	var resp multinodepb.EarnedPerSatelliteResponse
@@ -444,8 +444,8 @@ func (payout *PayoutEndpoint) EarnedPerSatellite(ctx context.Context, req *multi
 func (payout *PayoutEndpoint) EstimatedPayoutTotal(ctx context.Context, req *multinodepb.EstimatedPayoutTotalRequest) (_ *multinodepb.EstimatedPayoutTotalResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, payout.apiKeys, req.GetHeader()); err != nil {
-		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	if err = authenticate(ctx, payout.apiKeys, apikeys.ScopeReadOnly, req.GetHeader()); err != nil {
+		return nil, err
 	}
 
 	estimated, err := payout.estimatedPayouts.GetAllSatellitesEstimatedPayout(ctx, time.Now())
@@ -460,8 +460,8 @@ func (payout *PayoutEndpoint) EstimatedPayoutTotal(ctx context.Context, req *mul
 func (payout *PayoutEndpoint) AllSatellitesSummary(ctx context.Context, req *multinodepb.AllSatellitesSummaryRequest) (_ *multinodepb.AllSatellitesSummaryResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, payout.apiKeys, req.GetHeader()); err != nil {
-		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	if err = authenticate(ctx, payout.apiKeys, apikeys.ScopeReadOnly, req.GetHeader()); err != nil {
+		return nil, err
 	}
 
 	var totalPaid, totalHeld int64
@@ -487,8 +487,8 @@ func (payout *PayoutEndpoint) AllSatellitesSummary(ctx context.Context, req *mul
 func (payout *PayoutEndpoint) AllSatellitesPeriodSummary(ctx context.Context, req *multinodepb.AllSatellitesPeriodSummaryRequest) (_ *multinodepb.AllSatellitesPeriodSummaryResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, payout.apiKeys, req.GetHeader()); err != nil {
-		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	if err = authenticate(ctx, payout.apiKeys, apikeys.ScopeReadOnly, req.GetHeader()); err != nil {
+		return nil, err
 	}
 
 	var totalPaid, totalHeld int64
@@ -514,8 +514,8 @@ func (payout *PayoutEndpoint) AllSatellitesPeriodSummary(ctx context.Context, re
 func (payout *PayoutEndpoint) SatelliteSummary(ctx context.Context, req *multinodepb.SatelliteSummaryRequest) (_ *multinodepb.SatelliteSummaryResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, payout.apiKeys, req.GetHeader()); err != nil {
-		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	if err = authenticate(ctx, payout.apiKeys, apikeys.ScopeReadOnly, req.GetHeader()); err != nil {
+		return nil, err
 	}
 
 	var totalPaid, totalHeld int64
@@ -532,8 +532,8 @@ func (payout *PayoutEndpoint) SatelliteSummary(ctx context.Context, req *multino
 func (payout *PayoutEndpoint) SatellitePeriodSummary(ctx context.Context, req *multinodepb.SatellitePeriodSummaryRequest) (_ *multinodepb.SatellitePeriodSummaryResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, payout.apiKeys, req.GetHeader()); err != nil {
-		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	if err = authenticate(ctx, payout.apiKeys, apikeys.ScopeReadOnly, req.GetHeader()); err != nil {
+		return nil, err
 	}
 
 	var totalPaid, totalHeld int64
@@ -550,8 +550,8 @@ func (payout *PayoutEndpoint) SatellitePeriodSummary(ctx context.Context, req *m
 func (payout *PayoutEndpoint) SatellitePaystub(ctx context.Context, req *multinodepb.SatellitePaystubRequest) (_ *multinodepb.SatellitePaystubResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, payout.apiKeys, req.GetHeader()); err != nil {
-		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	if err = authenticate(ctx, payout.apiKeys, apikeys.ScopeReadOnly, req.GetHeader()); err != nil {
+		return nil, err
 	}
 
 	paystub, err := payout.db.GetSatellitePaystubs(ctx, req.SatelliteId)
@@ -579,8 +579,8 @@ func (payout *PayoutEndpoint) SatellitePaystub(ctx context.Context, req *multino
 func (payout *PayoutEndpoint) SatellitePeriodPaystub(ctx context.Context, req *multinodepb.SatellitePeriodPaystubRequest) (_ *multinodepb.SatellitePeriodPaystubResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, payout.apiKeys, req.GetHeader()); err != nil {
-		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	if err = authenticate(ctx, payout.apiKeys, apikeys.ScopeReadOnly, req.GetHeader()); err != nil {
+		return nil, err
 	}
 
 	paystub, err := payout.db.GetSatellitePeriodPaystubs(ctx, req.Period, req.SatelliteId)
@@ -44,8 +44,8 @@ func NewStorageEndpoint(log *zap.Logger, apiKeys *apikeys.Service, monitor *moni
 func (storage *StorageEndpoint) DiskSpace(ctx context.Context, req *multinodepb.DiskSpaceRequest) (_ *multinodepb.DiskSpaceResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, storage.apiKeys, req.GetHeader()); err != nil {
-		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	if err = authenticate(ctx, storage.apiKeys, apikeys.ScopeReadOnly, req.GetHeader()); err != nil {
+		return nil, err
 	}
 
 	diskSpace, err := storage.monitor.DiskSpace(ctx)
@@ -68,8 +68,8 @@ func (storage *StorageEndpoint) DiskSpace(ctx context.Context, req *multinodepb.
 func (storage *StorageEndpoint) Usage(ctx context.Context, req *multinodepb.StorageUsageRequest) (_ *multinodepb.StorageUsageResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, storage.apiKeys, req.GetHeader()); err != nil {
-		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	if err = authenticate(ctx, storage.apiKeys, apikeys.ScopeReadOnly, req.GetHeader()); err != nil {
+		return nil, err
 	}
 
 	from := req.GetFrom()
@@ -108,8 +108,8 @@ func (storage *StorageEndpoint) Usage(ctx context.Context, req *multinodepb.Stor
 func (storage *StorageEndpoint) UsageSatellite(ctx context.Context, req *multinodepb.StorageUsageSatelliteRequest) (_ *multinodepb.StorageUsageSatelliteResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, storage.apiKeys, req.GetHeader()); err != nil {
-		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	if err = authenticate(ctx, storage.apiKeys, apikeys.ScopeReadOnly, req.GetHeader()); err != nil {
+		return nil, err
 	}
 
 	if req.SatelliteId.IsZero() {
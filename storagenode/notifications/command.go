@@ -0,0 +1,75 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"storj.io/common/storj"
+)
+
+// CommandConfig contains the configuration of the command sink.
+type CommandConfig struct {
+	Path    string        `help:"command run for every notification, which receives it as JSON on stdin and in STORJ_NOTIFICATION_* environment variables (disabled when empty)" default:""`
+	Timeout time.Duration `help:"time after which the command is killed" default:"30s"`
+	Types   string        `help:"comma separated notification types the command is run for (custom, audit-check-failure, disqualification, suspension)" default:"audit-check-failure,disqualification,suspension"`
+}
+
+// CommandSink runs a local command for notifications.
+type CommandSink struct {
+	path    string
+	timeout time.Duration
+}
+
+// NewCommandSink creates a new command sink.
+func NewCommandSink(config CommandConfig) *CommandSink {
+	return &CommandSink{
+		path:    config.Path,
+		timeout: config.Timeout,
+	}
+}
+
+// Name implements Sink.
+func (sink *CommandSink) Name() string { return "command" }
+
+// Deliver implements Sink.
+func (sink *CommandSink) Deliver(ctx context.Context, nodeID storj.NodeID, notification Notification) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	payload := NewPayload(nodeID, notification)
+	stdin, err := json.Marshal(payload)
+	if err != nil {
+		return ErrSender.Wrap(err)
+	}
+
+	if sink.timeout > 0 {
+		var cancel func()
+		ctx, cancel = context.WithTimeout(ctx, sink.timeout)
+		defer cancel()
+	}
+
+	var output bytes.Buffer
+	cmd := exec.CommandContext(ctx, sink.path)
+	cmd.Stdin = bytes.NewReader(stdin)
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	cmd.Env = append(os.Environ(),
+		"STORJ_NOTIFICATION_NODE_ID="+payload.NodeID.String(),
+		"STORJ_NOTIFICATION_SATELLITE_ID="+payload.SatelliteID.String(),
+		"STORJ_NOTIFICATION_TYPE="+payload.Type,
+		"STORJ_NOTIFICATION_TITLE="+payload.Title,
+		"STORJ_NOTIFICATION_MESSAGE="+payload.Message,
+	)
+
+	if err := cmd.Run(); err != nil {
+		return ErrSender.New("%s: %v: %s", sink.path, err, strings.TrimSpace(output.String()))
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strings"
+
+	"storj.io/common/storj"
+	"storj.io/storj/private/post"
+)
+
+// EmailConfig contains the configuration of the email sink.
+type EmailConfig struct {
+	To            string `help:"comma separated email addresses notifications are sent to (disabled when empty)" default:""`
+	From          string `help:"sender email address" default:""`
+	ServerAddress string `help:"address of the SMTP server, which must support STARTTLS" default:""`
+	Login         string `help:"login of the SMTP server" default:""`
+	Password      string `help:"password of the SMTP server" default:""`
+	Types         string `help:"comma separated notification types sent by email (custom, audit-check-failure, disqualification, suspension)" default:"audit-check-failure,disqualification,suspension"`
+}
+
+// EmailSink sends notifications by email.
+type EmailSink struct {
+	sender *post.SMTPSender
+	to     []post.Address
+}
+
+// NewEmailSink creates a new email sink.
+func NewEmailSink(config EmailConfig) (*EmailSink, error) {
+	to, err := mail.ParseAddressList(config.To)
+	if err != nil {
+		return nil, ErrSender.New("invalid email recipients: %v", err)
+	}
+	from, err := mail.ParseAddress(config.From)
+	if err != nil {
+		return nil, ErrSender.New("invalid email sender: %v", err)
+	}
+	host, _, err := net.SplitHostPort(config.ServerAddress)
+	if err != nil {
+		return nil, ErrSender.New("invalid SMTP server address: %v", err)
+	}
+
+	return &EmailSink{
+		sender: &post.SMTPSender{
+			ServerAddress: config.ServerAddress,
+			From:          *from,
+			Auth:          smtp.PlainAuth("", config.Login, config.Password, host),
+		},
+		to: addressValues(to),
+	}, nil
+}
+
+// Name implements Sink.
+func (sink *EmailSink) Name() string { return "email" }
+
+// Deliver implements Sink.
+func (sink *EmailSink) Deliver(ctx context.Context, nodeID storj.NodeID, notification Notification) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "%s\n\n", notification.Message)
+	fmt.Fprintf(&body, "Node: %s\n", nodeID)
+	fmt.Fprintf(&body, "Satellite: %s\n", notification.SenderID)
+	fmt.Fprintf(&body, "Type: %s\n", notification.Type)
+	fmt.Fprintf(&body, "Time: %s\n", notification.CreatedAt.UTC().Format("2006-01-02 15:04:05 MST"))
+
+	err = sink.sender.SendEmail(ctx, &post.Message{
+		From:      sink.sender.FromAddress(),
+		To:        sink.to,
+		Subject:   fmt.Sprintf("[Storage node %s] %s", nodeID.String()[:7], notification.Title),
+		Date:      notification.CreatedAt,
+		PlainText: body.String(),
+	})
+	return ErrSender.Wrap(err)
+}
+
+// addressValues dereferences the parsed addresses.
+func addressValues(addresses []*mail.Address) []post.Address {
+	values := make([]post.Address, 0, len(addresses))
+	for _, address := range addresses {
+		values = append(values, *address)
+	}
+	return values
+}
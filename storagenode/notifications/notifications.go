@@ -5,8 +5,11 @@ package notifications
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	"github.com/zeebo/errs"
+
 	"storj.io/common/storj"
 	"storj.io/common/uuid"
 )
@@ -37,6 +40,33 @@ const (
 	TypeSuspension Type = 3
 )
 
+// typeNames are the names of the notification types used in the configuration
+// and by the sinks.
+var typeNames = map[Type]string{
+	TypeCustom:            "custom",
+	TypeAuditCheckFailure: "audit-check-failure",
+	TypeDisqualification:  "disqualification",
+	TypeSuspension:        "suspension",
+}
+
+// String returns the name of the notification type.
+func (t Type) String() string {
+	if name, ok := typeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("type-%d", int(t))
+}
+
+// ParseType returns the notification type with the given name.
+func ParseType(name string) (Type, error) {
+	for t, typeName := range typeNames {
+		if typeName == name {
+			return t, nil
+		}
+	}
+	return 0, errs.New("unknown notification type %q", name)
+}
+
 // NewNotification holds notification entity info which is being received from satellite or local client.
 type NewNotification struct {
 	SenderID storj.NodeID
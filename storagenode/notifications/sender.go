@@ -0,0 +1,239 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package notifications
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+)
+
+// ErrSender is the error class for delivering notifications to sinks.
+var ErrSender = errs.Class("notifications sender")
+
+// senderQueueSize is the number of notifications waiting for delivery before
+// new ones are dropped.
+const senderQueueSize = 100
+
+// Config contains the configuration of the external delivery of notifications.
+type Config struct {
+	RateLimit    time.Duration `help:"minimum time between two deliveries of notifications with the same type and satellite to a sink" default:"1h"`
+	DrainTimeout time.Duration `help:"how long the notifications still waiting for delivery are delivered for when the node shuts down" default:"10s"`
+
+	Email   EmailConfig
+	Webhook WebhookConfig
+	Command CommandConfig
+}
+
+// Sink delivers notifications outside of the storage node.
+type Sink interface {
+	// Name returns the name of the sink used in logs.
+	Name() string
+	// Deliver delivers a notification of the node.
+	Deliver(ctx context.Context, nodeID storj.NodeID, notification Notification) error
+}
+
+// Sender delivers notifications to the configured sinks in the background.
+//
+// architecture: Service
+type Sender struct {
+	log          *zap.Logger
+	nodeID       storj.NodeID
+	sinks        []filteredSink
+	rateLimit    time.Duration
+	drainTimeout time.Duration
+	queue        chan Notification
+
+	mu       sync.Mutex
+	lastSent map[rateLimitKey]time.Time
+	closed   bool
+}
+
+// filteredSink is a sink with the notification types it accepts.
+type filteredSink struct {
+	sink  Sink
+	types map[Type]bool
+}
+
+// rateLimitKey identifies the notifications limited together.
+type rateLimitKey struct {
+	sink     string
+	typ      Type
+	senderID storj.NodeID
+}
+
+// NewSender creates a sender for the sinks enabled in config.
+func NewSender(log *zap.Logger, nodeID storj.NodeID, config Config) (*Sender, error) {
+	sender := &Sender{
+		log:          log,
+		nodeID:       nodeID,
+		rateLimit:    config.RateLimit,
+		drainTimeout: config.DrainTimeout,
+		queue:        make(chan Notification, senderQueueSize),
+		lastSent:     make(map[rateLimitKey]time.Time),
+	}
+
+	if config.Email.To != "" {
+		sink, err := NewEmailSink(config.Email)
+		if err != nil {
+			return nil, err
+		}
+		if err := sender.AddSink(sink, config.Email.Types); err != nil {
+			return nil, err
+		}
+	}
+	if config.Webhook.URL != "" {
+		if err := sender.AddSink(NewWebhookSink(config.Webhook), config.Webhook.Types); err != nil {
+			return nil, err
+		}
+	}
+	if config.Command.Path != "" {
+		if err := sender.AddSink(NewCommandSink(config.Command), config.Command.Types); err != nil {
+			return nil, err
+		}
+	}
+
+	return sender, nil
+}
+
+// AddSink adds a sink which receives the notifications of the comma separated
+// types.
+func (sender *Sender) AddSink(sink Sink, types string) error {
+	filtered := filteredSink{
+		sink:  sink,
+		types: make(map[Type]bool),
+	}
+	for _, name := range strings.Split(types, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		typ, err := ParseType(name)
+		if err != nil {
+			return ErrSender.New("%s: %v", sink.Name(), err)
+		}
+		filtered.types[typ] = true
+	}
+
+	sender.sinks = append(sender.sinks, filtered)
+	return nil
+}
+
+// Send queues the notification for delivery. The notification is dropped when
+// too many notifications are waiting or the sender is closed.
+func (sender *Sender) Send(notification Notification) {
+	if len(sender.sinks) == 0 {
+		return
+	}
+
+	sender.mu.Lock()
+	closed := sender.closed
+	sender.mu.Unlock()
+	if closed {
+		sender.drop("notification sender is closed, dropping notification", notification)
+		return
+	}
+
+	select {
+	case sender.queue <- notification:
+	default:
+		sender.drop("too many notifications waiting for delivery, dropping notification", notification)
+	}
+}
+
+// drop logs and counts a notification which isn't delivered.
+func (sender *Sender) drop(msg string, notification Notification) {
+	mon.Counter("notification_dropped").Inc(1)
+	sender.log.Warn(msg,
+		zap.Stringer("Satellite ID", notification.SenderID),
+		zap.Stringer("Type", notification.Type),
+		zap.String("Title", notification.Title))
+}
+
+// Run delivers the queued notifications until ctx is canceled. The
+// notifications still waiting then are delivered by Close.
+func (sender *Sender) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case notification := <-sender.queue:
+			sender.deliver(ctx, notification)
+		}
+	}
+}
+
+// Close stops accepting notifications and delivers the notifications still
+// waiting, for at most the drain timeout. The notifications which can't be
+// delivered in time are dropped.
+func (sender *Sender) Close() error {
+	sender.mu.Lock()
+	sender.closed = true
+	sender.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), sender.drainTimeout)
+	defer cancel()
+
+	for {
+		select {
+		case notification := <-sender.queue:
+			if ctx.Err() != nil {
+				sender.drop("shutting down, dropping notification waiting for delivery", notification)
+				continue
+			}
+			sender.deliver(ctx, notification)
+		default:
+			return nil
+		}
+	}
+}
+
+// deliver delivers the notification to every sink accepting it.
+func (sender *Sender) deliver(ctx context.Context, notification Notification) {
+	for _, filtered := range sender.sinks {
+		if !filtered.types[notification.Type] {
+			continue
+		}
+		if !sender.allow(filtered.sink, notification, time.Now()) {
+			mon.Counter("notification_rate_limited").Inc(1)
+			continue
+		}
+
+		err := filtered.sink.Deliver(ctx, sender.nodeID, notification)
+		if err != nil {
+			mon.Counter("notification_delivery_failed").Inc(1)
+			sender.log.Error("failed to deliver notification",
+				zap.String("Sink", filtered.sink.Name()),
+				zap.Stringer("Satellite ID", notification.SenderID),
+				zap.Stringer("Type", notification.Type),
+				zap.Error(err))
+		}
+	}
+}
+
+// allow returns whether the notification can be delivered to the sink, and
+// records it when it can.
+func (sender *Sender) allow(sink Sink, notification Notification, now time.Time) bool {
+	sender.mu.Lock()
+	defer sender.mu.Unlock()
+
+	key := rateLimitKey{
+		sink:     sink.Name(),
+		typ:      notification.Type,
+		senderID: notification.SenderID,
+	}
+	if last, ok := sender.lastSent[key]; ok && now.Sub(last) < sender.rateLimit {
+		return false
+	}
+	sender.lastSent[key] = now
+	return true
+}
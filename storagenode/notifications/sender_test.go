@@ -0,0 +1,196 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package notifications_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storagenode/notifications"
+)
+
+type recordingSink struct {
+	mu        sync.Mutex
+	delivered []notifications.Notification
+}
+
+func (sink *recordingSink) Name() string { return "recording" }
+
+func (sink *recordingSink) Deliver(ctx context.Context, nodeID storj.NodeID, notification notifications.Notification) error {
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+	sink.delivered = append(sink.delivered, notification)
+	return nil
+}
+
+func (sink *recordingSink) count() int {
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+	return len(sink.delivered)
+}
+
+func TestSenderFilterAndRateLimit(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	sender, err := notifications.NewSender(zaptest.NewLogger(t), testrand.NodeID(), notifications.Config{
+		RateLimit: time.Hour,
+	})
+	require.NoError(t, err)
+
+	sink := &recordingSink{}
+	require.Error(t, sender.AddSink(sink, "suspension,unknown"))
+	require.NoError(t, sender.AddSink(sink, "suspension, disqualification"))
+
+	runCtx, cancel := context.WithCancel(ctx)
+	ctx.Go(func() error { return sender.Run(runCtx) })
+	defer cancel()
+
+	satellite1, satellite2 := testrand.NodeID(), testrand.NodeID()
+	sender.Send(notifications.Notification{SenderID: satellite1, Type: notifications.TypeCustom})
+	sender.Send(notifications.Notification{SenderID: satellite1, Type: notifications.TypeSuspension})
+	// rate limited, it has the same type and satellite.
+	sender.Send(notifications.Notification{SenderID: satellite1, Type: notifications.TypeSuspension})
+	sender.Send(notifications.Notification{SenderID: satellite2, Type: notifications.TypeSuspension})
+	sender.Send(notifications.Notification{SenderID: satellite1, Type: notifications.TypeDisqualification})
+
+	require.Eventually(t, func() bool { return sink.count() == 3 }, 5*time.Second, 10*time.Millisecond)
+
+	// wait for the queue to drain before checking nothing else was delivered.
+	sender.Send(notifications.Notification{SenderID: satellite2, Type: notifications.TypeDisqualification})
+	require.Eventually(t, func() bool { return sink.count() == 4 }, 5*time.Second, 10*time.Millisecond)
+
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+	for _, notification := range sink.delivered {
+		require.NotEqual(t, notifications.TypeCustom, notification.Type)
+	}
+}
+
+type blockingSink struct{}
+
+func (blockingSink) Name() string { return "blocking" }
+
+func (blockingSink) Deliver(ctx context.Context, nodeID storj.NodeID, notification notifications.Notification) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestSenderClose(t *testing.T) {
+	sender, err := notifications.NewSender(zaptest.NewLogger(t), testrand.NodeID(), notifications.Config{
+		RateLimit:    time.Hour,
+		DrainTimeout: time.Minute,
+	})
+	require.NoError(t, err)
+
+	sink := &recordingSink{}
+	require.NoError(t, sender.AddSink(sink, "suspension"))
+
+	// the notifications waiting when the node shuts down are delivered.
+	for i := 0; i < 3; i++ {
+		sender.Send(notifications.Notification{SenderID: testrand.NodeID(), Type: notifications.TypeSuspension})
+	}
+	require.NoError(t, sender.Close())
+	require.Equal(t, 3, sink.count())
+
+	sender.Send(notifications.Notification{SenderID: testrand.NodeID(), Type: notifications.TypeSuspension})
+	require.NoError(t, sender.Close())
+	require.Equal(t, 3, sink.count())
+
+	// delivering the waiting notifications stops after the drain timeout.
+	sender, err = notifications.NewSender(zaptest.NewLogger(t), testrand.NodeID(), notifications.Config{
+		RateLimit:    time.Hour,
+		DrainTimeout: 10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	require.NoError(t, sender.AddSink(blockingSink{}, "suspension"))
+	for i := 0; i < 10; i++ {
+		sender.Send(notifications.Notification{SenderID: testrand.NodeID(), Type: notifications.TypeSuspension})
+	}
+
+	start := time.Now()
+	require.NoError(t, sender.Close())
+	require.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestWebhookSink(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	received := make(chan notifications.Payload, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload notifications.Payload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if payload.Type == notifications.TypeCustom.String() {
+			http.Error(w, "unexpected type", http.StatusTeapot)
+			return
+		}
+		received <- payload
+	}))
+	defer server.Close()
+
+	sink := notifications.NewWebhookSink(notifications.WebhookConfig{URL: server.URL, Timeout: 5 * time.Second})
+
+	nodeID := testrand.NodeID()
+	notification := notifications.Notification{
+		SenderID:  testrand.NodeID(),
+		Type:      notifications.TypeSuspension,
+		Title:     "suspended",
+		Message:   "your node is suspended",
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+	require.NoError(t, sink.Deliver(ctx, nodeID, notification))
+	require.Equal(t, notifications.NewPayload(nodeID, notification), <-received)
+
+	notification.Type = notifications.TypeCustom
+	require.Error(t, sink.Deliver(ctx, nodeID, notification))
+}
+
+func TestCommandSink(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test command is a shell script")
+	}
+
+	ctx := testcontext.New(t)
+
+	output := ctx.File("output")
+	script := ctx.File("notify.sh")
+	require.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\n"+
+		"cat > "+output+"\n"+
+		"echo \"$STORJ_NOTIFICATION_TYPE\" >> "+output+"\n"), 0755))
+
+	sink := notifications.NewCommandSink(notifications.CommandConfig{Path: script, Timeout: 5 * time.Second})
+
+	nodeID := testrand.NodeID()
+	notification := notifications.Notification{
+		SenderID: testrand.NodeID(),
+		Type:     notifications.TypeDisqualification,
+		Title:    "disqualified",
+	}
+	require.NoError(t, sink.Deliver(ctx, nodeID, notification))
+
+	data, err := os.ReadFile(output)
+	require.NoError(t, err)
+	expected, err := json.Marshal(notifications.NewPayload(nodeID, notification))
+	require.NoError(t, err)
+	require.Equal(t, string(expected)+"disqualification\n", string(data))
+
+	failing := notifications.NewCommandSink(notifications.CommandConfig{Path: filepath.Join(ctx.Dir(), "missing")})
+	require.Error(t, failing.Deliver(ctx, nodeID, notification))
+}
@@ -33,25 +33,33 @@ const (
 // Service is the notification service between storage nodes and satellites.
 // architecture: Service
 type Service struct {
-	log *zap.Logger
-	db  DB
+	log    *zap.Logger
+	db     DB
+	sender *Sender
 }
 
-// NewService creates a new notification service.
-func NewService(log *zap.Logger, db DB) *Service {
+// NewService creates a new notification service. sender delivers the received
+// notifications to external sinks and may be nil.
+func NewService(log *zap.Logger, db DB, sender *Sender) *Service {
 	return &Service{
-		log: log,
-		db:  db,
+		log:    log,
+		db:     db,
+		sender: sender,
 	}
 }
 
-// Receive - receives notifications from satellite and Insert them into DB.
+// Receive - receives notifications from satellite, Insert them into DB and
+// deliver them to the configured sinks.
 func (service *Service) Receive(ctx context.Context, newNotification NewNotification) (Notification, error) {
 	notification, err := service.db.Insert(ctx, newNotification)
 	if err != nil {
 		return Notification{}, err
 	}
 
+	if service.sender != nil {
+		service.sender.Send(notification)
+	}
+
 	return notification, nil
 }
 
@@ -0,0 +1,93 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/storj"
+)
+
+// WebhookConfig contains the configuration of the webhook sink.
+type WebhookConfig struct {
+	URL     string        `help:"URL notifications are posted to as JSON (disabled when empty)" default:""`
+	Timeout time.Duration `help:"timeout of posting a notification" default:"10s"`
+	Types   string        `help:"comma separated notification types posted to the webhook (custom, audit-check-failure, disqualification, suspension)" default:"audit-check-failure,disqualification,suspension"`
+}
+
+// Payload is the JSON document the webhook and command sinks deliver.
+type Payload struct {
+	NodeID      storj.NodeID `json:"nodeId"`
+	SatelliteID storj.NodeID `json:"satelliteId"`
+	Type        string       `json:"type"`
+	Title       string       `json:"title"`
+	Message     string       `json:"message"`
+	CreatedAt   time.Time    `json:"createdAt"`
+}
+
+// NewPayload creates the payload of a notification.
+func NewPayload(nodeID storj.NodeID, notification Notification) Payload {
+	return Payload{
+		NodeID:      nodeID,
+		SatelliteID: notification.SenderID,
+		Type:        notification.Type.String(),
+		Title:       notification.Title,
+		Message:     notification.Message,
+		CreatedAt:   notification.CreatedAt,
+	}
+}
+
+// WebhookSink posts notifications as JSON to a URL.
+type WebhookSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookSink creates a new webhook sink.
+func NewWebhookSink(config WebhookConfig) *WebhookSink {
+	return &WebhookSink{
+		url:    config.URL,
+		client: &http.Client{Timeout: config.Timeout},
+	}
+}
+
+// Name implements Sink.
+func (sink *WebhookSink) Name() string { return "webhook" }
+
+// Deliver implements Sink.
+func (sink *WebhookSink) Deliver(ctx context.Context, nodeID storj.NodeID, notification Notification) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	body, err := json.Marshal(NewPayload(nodeID, notification))
+	if err != nil {
+		return ErrSender.Wrap(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sink.url, bytes.NewReader(body))
+	if err != nil {
+		return ErrSender.Wrap(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := sink.client.Do(req)
+	if err != nil {
+		return ErrSender.Wrap(err)
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		err = errs.Combine(err, resp.Body.Close())
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return ErrSender.New("webhook responded with %s", resp.Status)
+	}
+	return nil
+}
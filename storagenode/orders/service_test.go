@@ -46,6 +46,7 @@ func TestOrderDBSettle(t *testing.T) {
 			},
 			time.Now().Add(2*time.Hour),
 			2000,
+			2000,
 		)
 		require.NoError(t, err)
 		require.Len(t, orderLimits, 1)
@@ -152,6 +153,7 @@ func TestOrderFileStoreAndDBSettle(t *testing.T) {
 			},
 			time.Now().Add(2*time.Hour),
 			2000,
+			2000,
 		)
 		require.NoError(t, err)
 		require.Len(t, orderLimits, 1)
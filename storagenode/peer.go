@@ -33,8 +33,10 @@ import (
 	"storj.io/storj/private/version/checker"
 	"storj.io/storj/storage"
 	"storj.io/storj/storage/filestore"
+	"storj.io/storj/storage/packstore"
 	"storj.io/storj/storagenode/apikeys"
 	"storj.io/storj/storagenode/bandwidth"
+	"storj.io/storj/storagenode/benchmark"
 	"storj.io/storj/storagenode/collector"
 	"storj.io/storj/storagenode/console"
 	"storj.io/storj/storagenode/console/consoleserver"
@@ -42,6 +44,7 @@ import (
 	"storj.io/storj/storagenode/gracefulexit"
 	"storj.io/storj/storagenode/inspector"
 	"storj.io/storj/storagenode/internalpb"
+	"storj.io/storj/storagenode/maintenance"
 	"storj.io/storj/storagenode/monitor"
 	"storj.io/storj/storagenode/multinode"
 	"storj.io/storj/storagenode/nodestats"
@@ -51,6 +54,7 @@ import (
 	"storj.io/storj/storagenode/payouts"
 	"storj.io/storj/storagenode/payouts/estimatedpayouts"
 	"storj.io/storj/storagenode/pieces"
+	"storj.io/storj/storagenode/pieces/tiered"
 	"storj.io/storj/storagenode/piecestore"
 	"storj.io/storj/storagenode/piecestore/usedserials"
 	"storj.io/storj/storagenode/piecetransfer"
@@ -59,6 +63,7 @@ import (
 	"storj.io/storj/storagenode/reputation"
 	"storj.io/storj/storagenode/retain"
 	"storj.io/storj/storagenode/satellites"
+	"storj.io/storj/storagenode/storagemigration"
 	"storj.io/storj/storagenode/storagenodedb"
 	"storj.io/storj/storagenode/storageusage"
 	"storj.io/storj/storagenode/trust"
@@ -93,6 +98,7 @@ type DB interface {
 	Payout() payouts.DB
 	Pricing() pricing.DB
 	APIKeys() apikeys.DB
+	Maintenance() maintenance.DB
 
 	Preflight(ctx context.Context) error
 }
@@ -105,6 +111,7 @@ type Config struct {
 	Debug  debug.Config
 
 	Preflight preflight.Config
+	Benchmark benchmark.Config
 	Contact   contact.Config
 	Operator  operator.Config
 
@@ -113,7 +120,9 @@ type Config struct {
 	Storage2  piecestore.Config
 	Collector collector.Config
 
-	Filestore filestore.Config
+	Filestore   filestore.Config
+	Packstore   packstore.Config
+	TieredCache tiered.Config
 
 	Pieces pieces.Config
 
@@ -123,6 +132,8 @@ type Config struct {
 
 	Console consoleserver.Config
 
+	Notifications notifications.Config
+
 	Version checker.Config
 
 	Bandwidth bandwidth.Config
@@ -137,14 +148,22 @@ func (config *Config) DatabaseConfig() storagenodedb.Config {
 		dbdir = config.Storage.Path
 	}
 	return storagenodedb.Config{
-		Storage:   config.Storage.Path,
-		Info:      filepath.Join(dbdir, "piecestore.db"),
-		Info2:     filepath.Join(dbdir, "info.db"),
-		Pieces:    config.Storage.Path,
-		Filestore: config.Filestore,
+		Storage:     config.Storage.Path,
+		Info:        filepath.Join(dbdir, "piecestore.db"),
+		Info2:       filepath.Join(dbdir, "info.db"),
+		Pieces:      config.Storage.Path,
+		Filestore:   config.Filestore,
+		Packstore:   config.Packstore,
+		TieredCache: config.TieredCache,
 	}
 }
 
+// BenchmarkResultPath returns the path of the file with the result of the latest
+// benchmark of the storage directory.
+func (config *Config) BenchmarkResultPath() string {
+	return filepath.Join(config.Storage.Path, "benchmark.json")
+}
+
 // Verify verifies whether configuration is consistent and acceptable.
 func (config *Config) Verify(log *zap.Logger) error {
 	err := config.Operator.Verify(log)
@@ -227,6 +246,14 @@ type Peer struct {
 		PingStats *contact.PingStats
 	}
 
+	Maintenance struct {
+		Service *maintenance.Service
+	}
+
+	Benchmark struct {
+		Service *benchmark.Service
+	}
+
 	Estimation struct {
 		Service *estimatedpayouts.Service
 	}
@@ -236,6 +263,9 @@ type Peer struct {
 		Trust         *trust.Pool
 		Store         *pieces.Store
 		TrashChore    *pieces.TrashChore
+		Compaction    *packstore.Chore
+		Migration     *storagemigration.Service
+		TieredCache   *tiered.Service
 		BlobsCache    *pieces.BlobsUsageCache
 		CacheService  *pieces.CacheService
 		RetainService *retain.Service
@@ -273,6 +303,7 @@ type Peer struct {
 
 	Notifications struct {
 		Service *notifications.Service
+		Sender  *notifications.Sender
 	}
 
 	Payout struct {
@@ -304,7 +335,18 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 	}
 
 	{ // setup notification service.
-		peer.Notifications.Service = notifications.NewService(peer.Log, peer.DB.Notifications())
+		sender, err := notifications.NewSender(peer.Log.Named("notifications:sender"), peer.Identity.ID, config.Notifications)
+		if err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
+		peer.Notifications.Sender = sender
+		peer.Services.Add(lifecycle.Item{
+			Name:  "notifications:sender",
+			Run:   peer.Notifications.Sender.Run,
+			Close: peer.Notifications.Sender.Close,
+		})
+
+		peer.Notifications.Service = notifications.NewService(peer.Log, peer.DB.Notifications(), peer.Notifications.Sender)
 	}
 
 	{ // setup debug
@@ -388,6 +430,7 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 
 	{
 		peer.Preflight.LocalTime = preflight.NewLocalTime(peer.Log.Named("preflight:localtime"), config.Preflight, peer.Storage2.Trust, peer.Dialer)
+		peer.Benchmark.Service = benchmark.NewService(peer.Log.Named("benchmark"), peer.DB.Pieces(), config.Pieces, config.Benchmark, config.BenchmarkResultPath())
 	}
 
 	{ // setup contact service
@@ -410,8 +453,10 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 			},
 			Version: *pbVersion,
 		}
+		peer.Maintenance.Service = maintenance.NewService(peer.Log.Named("maintenance"), peer.DB.Maintenance())
+
 		peer.Contact.PingStats = new(contact.PingStats)
-		peer.Contact.Service = contact.NewService(peer.Log.Named("contact:service"), peer.Dialer, self, peer.Storage2.Trust)
+		peer.Contact.Service = contact.NewService(peer.Log.Named("contact:service"), peer.Dialer, self, peer.Storage2.Trust, peer.Maintenance.Service)
 
 		peer.Contact.Chore = contact.NewChore(peer.Log.Named("contact:chore"), config.Contact.Interval, peer.Contact.Service)
 		peer.Services.Add(lifecycle.Item{
@@ -457,11 +502,54 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 			Close: peer.Storage2.TrashChore.Close,
 		})
 
+		if compactor, ok := peer.DB.Pieces().(packstore.Compactor); ok && config.Packstore.Enabled {
+			peer.Storage2.Compaction = packstore.NewChore(
+				log.Named("packstore:compaction"),
+				compactor,
+				config.Packstore.CompactionInterval,
+			)
+			peer.Services.Add(lifecycle.Item{
+				Name:  "packstore:compaction",
+				Run:   peer.Storage2.Compaction.Run,
+				Close: peer.Storage2.Compaction.Close,
+			})
+			peer.Debug.Server.Panel.Add(
+				debug.Cycle("Packstore Compaction", peer.Storage2.Compaction.Loop))
+		}
+
+		if migratingBlobs, ok := peer.DB.Pieces().(*storagemigration.Blobs); ok {
+			peer.Storage2.Migration = storagemigration.NewService(
+				log.Named("storagemigration"),
+				migratingBlobs,
+				peer.Identity.ID,
+			)
+			peer.Services.Add(lifecycle.Item{
+				Name:  "storagemigration",
+				Run:   peer.Storage2.Migration.Run,
+				Close: peer.Storage2.Migration.Close,
+			})
+		}
+
+		if tieredBlobs, ok := peer.DB.Pieces().(*tiered.Blobs); ok {
+			peer.Storage2.TieredCache = tiered.NewService(
+				log.Named("tiered"),
+				tieredBlobs,
+			)
+			peer.Services.Add(lifecycle.Item{
+				Name:  "tiered",
+				Run:   peer.Storage2.TieredCache.Run,
+				Close: peer.Storage2.TieredCache.Close,
+			})
+			peer.Debug.Server.Panel.Add(
+				debug.Cycle("Tiered Cache", peer.Storage2.TieredCache.Loop))
+		}
+
 		peer.Storage2.CacheService = pieces.NewService(
 			log.Named("piecestore:cache"),
 			peer.Storage2.BlobsCache,
 			peer.Storage2.Store,
 			config.Storage2.CacheSyncInterval,
+			config.Pieces.UsedSpace,
 		)
 		peer.Services.Add(lifecycle.Item{
 			Name:  "piecestore:cache",
@@ -682,6 +770,9 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 			peer.Notifications.Service,
 			peer.Console.Service,
 			peer.Payout.Service,
+			peer.Maintenance.Service,
+			peer.Contact.Chore,
+			peer.Benchmark.Service,
 			peer.Console.Listener,
 		)
 		// NOTE: Console service is added to peer services during peer run to allow for QUIC checkins
@@ -877,6 +968,11 @@ func (peer *Peer) Run(ctx context.Context) (err error) {
 		return err
 	}
 
+	if err := peer.Benchmark.Service.Preflight(ctx); err != nil {
+		peer.Log.Error("Failed preflight check.", zap.Error(err))
+		return err
+	}
+
 	group, ctx := errgroup.WithContext(ctx)
 
 	peer.Servers.Run(ctx, group)
@@ -24,6 +24,7 @@ type CacheService struct {
 	log        *zap.Logger
 	usageCache *BlobsUsageCache
 	store      *Store
+	config     UsedSpaceConfig
 	Loop       *sync2.Cycle
 
 	// InitFence is released once the cache's Run method returns or when it has
@@ -33,50 +34,37 @@ type CacheService struct {
 
 // NewService creates a new cache service that updates the space usage cache on startup and syncs the cache values to
 // persistent storage on an interval.
-func NewService(log *zap.Logger, usageCache *BlobsUsageCache, pieces *Store, interval time.Duration) *CacheService {
+func NewService(log *zap.Logger, usageCache *BlobsUsageCache, pieces *Store, interval time.Duration, config UsedSpaceConfig) *CacheService {
 	return &CacheService{
 		log:        log,
 		usageCache: usageCache,
 		store:      pieces,
+		config:     config,
 		Loop:       sync2.NewCycle(interval),
 	}
 }
 
-// Run recalculates the space used cache once and also runs a loop to sync the space used cache
-// to persistent storage on an interval.
+// Run recalculates the space used cache once, unless it's configured to only track changes,
+// and also runs a loop to sync the space used cache to persistent storage on an interval.
 func (service *CacheService) Run(ctx context.Context) (err error) {
 	defer mon.Task()(&ctx)(&err)
 	defer service.InitFence.Release()
 
-	totalsAtStart := service.usageCache.copyCacheTotals()
-
-	// recalculate the cache once
-	piecesTotal, piecesContentSize, totalsBySatellite, err := service.store.SpaceUsedTotalAndBySatellite(ctx)
-	if err != nil {
-		service.log.Error("error getting current used space: ", zap.Error(err))
-		return err
-	}
-	trashTotal, err := service.usageCache.Blobs.SpaceUsedForTrash(ctx)
-	if err != nil {
-		service.log.Error("error getting current used space for trash: ", zap.Error(err))
-		return err
-	}
-	service.usageCache.Recalculate(
-		piecesTotal,
-		totalsAtStart.piecesTotal,
-		piecesContentSize,
-		totalsAtStart.piecesContentSize,
-		trashTotal,
-		totalsAtStart.trashTotal,
-		totalsBySatellite,
-		totalsAtStart.spaceUsedBySatellite,
-	)
-
 	if err = service.store.spaceUsedDB.Init(ctx); err != nil {
 		service.log.Error("error during init space usage db: ", zap.Error(err))
 		return err
 	}
 
+	if service.config.ScanOnStartup {
+		err = withLowIOPriority(service.log, service.config.LowIOPriority, func() error {
+			return service.walkUsedSpace(ctx)
+		})
+		if err != nil {
+			service.log.Error("error getting current used space: ", zap.Error(err))
+			return err
+		}
+	}
+
 	return service.Loop.Run(ctx, func(ctx context.Context) (err error) {
 		defer mon.Task()(&ctx)(&err)
 
@@ -344,6 +332,62 @@ func (blobs *BlobsUsageCache) RestoreTrash(ctx context.Context, namespace []byte
 	return keysRestored, err
 }
 
+// satelliteUsage returns the cached space used by the satellite.
+func (blobs *BlobsUsageCache) satelliteUsage(satelliteID storj.NodeID) SatelliteUsage {
+	blobs.mu.Lock()
+	defer blobs.mu.Unlock()
+	return blobs.spaceUsedBySatellite[satelliteID]
+}
+
+// RecalculateSatellite replaces the space used by the satellite with the totals of walking its
+// pieces, estimating the changes missed while walking like Recalculate, and updates the totals
+// of all pieces accordingly.
+func (blobs *BlobsUsageCache) RecalculateSatellite(satelliteID storj.NodeID, total, totalAtStart, contentSize, contentSizeAtStart int64) {
+	blobs.mu.Lock()
+	defer blobs.mu.Unlock()
+
+	current := blobs.spaceUsedBySatellite[satelliteID]
+	estimated := SatelliteUsage{
+		Total:       estimate(total, totalAtStart, current.Total),
+		ContentSize: estimate(contentSize, contentSizeAtStart, current.ContentSize),
+	}
+
+	blobs.piecesTotal += estimated.Total - current.Total
+	blobs.piecesContentSize += estimated.ContentSize - current.ContentSize
+	blobs.ensurePositiveCacheValue(&blobs.piecesTotal, "piecesTotal")
+	blobs.ensurePositiveCacheValue(&blobs.piecesContentSize, "piecesContentSize")
+
+	// a zero usage is kept, so that persisting the cache removes the satellite.
+	blobs.spaceUsedBySatellite[satelliteID] = estimated
+}
+
+// RecalculateTrash replaces the space used by the trash with the total of walking it,
+// estimating the changes missed while walking like Recalculate.
+func (blobs *BlobsUsageCache) RecalculateTrash(trashTotal, trashTotalAtStart int64) {
+	blobs.mu.Lock()
+	defer blobs.mu.Unlock()
+
+	blobs.trashTotal = estimate(trashTotal, trashTotalAtStart, blobs.trashTotal)
+}
+
+// WalkNamespaceAfterPrefix walks the namespace by key prefix, when the underlying blob store supports it.
+func (blobs *BlobsUsageCache) WalkNamespaceAfterPrefix(ctx context.Context, namespace []byte, afterPrefix string, walkFunc func(storage.BlobInfo) error, prefixDone func(prefix string) error) error {
+	walker, ok := blobs.Blobs.(storage.PrefixWalker)
+	if !ok {
+		return Error.New("blob store does not support walking by key prefix")
+	}
+	return walker.WalkNamespaceAfterPrefix(ctx, namespace, afterPrefix, walkFunc, prefixDone)
+}
+
+// StatTrash looks up the blob in the trash, when the underlying blob store supports it.
+func (blobs *BlobsUsageCache) StatTrash(ctx context.Context, ref storage.BlobRef) (storage.BlobInfo, time.Time, error) {
+	stater, ok := blobs.Blobs.(storage.TrashStater)
+	if !ok {
+		return nil, time.Time{}, Error.New("blob store does not support looking up the trash")
+	}
+	return stater.StatTrash(ctx, ref)
+}
+
 func (blobs *BlobsUsageCache) copyCacheTotals() BlobsUsageCache {
 	blobs.mu.Lock()
 	defer blobs.mu.Unlock()
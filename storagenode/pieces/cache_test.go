@@ -4,6 +4,7 @@
 package pieces_test
 
 import (
+	"encoding/base32"
 	"testing"
 	"time"
 
@@ -115,6 +116,7 @@ func TestCacheInit(t *testing.T) {
 			cache,
 			pieces.NewStore(log, cache, nil, nil, spaceUsedDB, pieces.DefaultConfig),
 			1*time.Hour,
+			pieces.DefaultUsedSpaceConfig,
 		)
 
 		// Confirm that when we call init before the cache has been persisted.
@@ -153,6 +155,7 @@ func TestCacheInit(t *testing.T) {
 			cache,
 			pieces.NewStore(log, cache, nil, nil, spaceUsedDB, pieces.DefaultConfig),
 			1*time.Hour,
+			pieces.DefaultUsedSpaceConfig,
 		)
 		err = cacheService.PersistCacheTotals(ctx)
 		require.NoError(t, err)
@@ -163,6 +166,7 @@ func TestCacheInit(t *testing.T) {
 			cache,
 			pieces.NewStore(log, cache, nil, nil, spaceUsedDB, pieces.DefaultConfig),
 			1*time.Hour,
+			pieces.DefaultUsedSpaceConfig,
 		)
 		// Confirm that when we call Init after the cache has been persisted
 		// that the cache gets initialized with the values from the database
@@ -226,6 +230,7 @@ func TestCachServiceRun(t *testing.T) {
 			cache,
 			pieces.NewStore(log, cache, nil, nil, spaceUsedDB, pieces.DefaultConfig),
 			1*time.Hour,
+			pieces.DefaultUsedSpaceConfig,
 		)
 
 		// Init the cache service, to read the values from the db (should all be 0)
@@ -264,6 +269,154 @@ func TestCachServiceRun(t *testing.T) {
 	})
 }
 
+func TestCacheServiceResumesWalk(t *testing.T) {
+	log := zaptest.NewLogger(t)
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		spaceUsedDB := db.PieceSpaceUsedDB()
+		require.NoError(t, spaceUsedDB.Init(ctx))
+
+		blobstore, err := filestore.NewAt(log, ctx.Dir(), filestore.DefaultConfig)
+		require.NoError(t, err)
+
+		pathEncoding := base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+		// the previous walk was interrupted after the key prefix "mm".
+		satelliteID := testrand.NodeID()
+		checkpoint := pieces.UsedSpaceProgress{
+			SatelliteID:        satelliteID,
+			LastPrefix:         "mm",
+			Total:              100 * memory.KB.Int64(),
+			ContentSize:        100*memory.KB.Int64() - pieces.V1PieceHeaderReservedArea,
+			TotalAtStart:       0,
+			ContentSizeAtStart: 0,
+			StartedAt:          time.Now().Add(-time.Hour).UTC(),
+			UpdatedAt:          time.Now().Add(-time.Minute).UTC(),
+		}
+		require.NoError(t, spaceUsedDB.UpdateUsedSpaceWalkProgress(ctx, checkpoint))
+
+		expectedTotal := checkpoint.Total
+		expectedContentSize := checkpoint.ContentSize
+		for i := 0; i < 20; i++ {
+			ref := storage.BlobRef{
+				Namespace: satelliteID.Bytes(),
+				Key:       testrand.PieceID().Bytes(),
+			}
+			size := int64(i+1) * memory.KB.Int64()
+
+			w, err := blobstore.Create(ctx, ref, -1)
+			require.NoError(t, err)
+			_, err = w.Write(testrand.BytesInt(int(size)))
+			require.NoError(t, err)
+			require.NoError(t, w.Commit(ctx))
+
+			if pathEncoding.EncodeToString(ref.Key)[:2] > checkpoint.LastPrefix {
+				expectedTotal += size
+				expectedContentSize += size - pieces.V1PieceHeaderReservedArea
+			}
+		}
+
+		cache := pieces.NewBlobsUsageCache(log, blobstore)
+		cacheService := pieces.NewService(log,
+			cache,
+			pieces.NewStore(log, cache, nil, nil, spaceUsedDB, pieces.DefaultConfig),
+			1*time.Hour,
+			pieces.DefaultUsedSpaceConfig,
+		)
+		require.NoError(t, cacheService.Init(ctx))
+
+		var eg errgroup.Group
+		eg.Go(func() error {
+			return cacheService.Run(ctx)
+		})
+		cacheService.InitFence.Wait(ctx)
+
+		piecesTotal, piecesContentSize, err := cache.SpaceUsedBySatellite(ctx, satelliteID)
+		require.NoError(t, err)
+		assert.Equal(t, expectedTotal, piecesTotal)
+		assert.Equal(t, expectedContentSize, piecesContentSize)
+
+		progress, err := spaceUsedDB.GetUsedSpaceWalkProgress(ctx)
+		require.NoError(t, err)
+		require.Len(t, progress, 1)
+		require.NotNil(t, progress[0].CompletedAt)
+		assert.Equal(t, float64(100), progress[0].Percent())
+		assert.Equal(t, expectedTotal, progress[0].Total)
+
+		// the totals are persisted once the satellite is walked.
+		totals, err := spaceUsedDB.GetPieceTotalsForAllSatellites(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, expectedTotal, totals[satelliteID].Total)
+
+		require.NoError(t, cacheService.Close())
+		require.NoError(t, eg.Wait())
+	})
+}
+
+func TestCacheServiceWithoutScan(t *testing.T) {
+	log := zaptest.NewLogger(t)
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		spaceUsedDB := db.PieceSpaceUsedDB()
+
+		blobstore, err := filestore.NewAt(log, ctx.Dir(), filestore.DefaultConfig)
+		require.NoError(t, err)
+
+		w, err := blobstore.Create(ctx, storage.BlobRef{
+			Namespace: testrand.NodeID().Bytes(),
+			Key:       testrand.PieceID().Bytes(),
+		}, -1)
+		require.NoError(t, err)
+		_, err = w.Write(testrand.Bytes(memory.KB))
+		require.NoError(t, err)
+		require.NoError(t, w.Commit(ctx))
+
+		config := pieces.DefaultUsedSpaceConfig
+		config.ScanOnStartup = false
+
+		cache := pieces.NewBlobsUsageCache(log, blobstore)
+		cacheService := pieces.NewService(log,
+			cache,
+			pieces.NewStore(log, cache, nil, nil, spaceUsedDB, pieces.DefaultConfig),
+			1*time.Hour,
+			config,
+		)
+		require.NoError(t, cacheService.Init(ctx))
+
+		var eg errgroup.Group
+		eg.Go(func() error {
+			return cacheService.Run(ctx)
+		})
+		cacheService.InitFence.Wait(ctx)
+
+		// without walking, only the persisted totals are used.
+		piecesTotal, _, err := cache.SpaceUsedForPieces(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), piecesTotal)
+
+		progress, err := spaceUsedDB.GetUsedSpaceWalkProgress(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, progress)
+
+		require.NoError(t, cacheService.Close())
+		require.NoError(t, eg.Wait())
+	})
+}
+
+func TestUsedSpaceProgressPercent(t *testing.T) {
+	completedAt := time.Now()
+	for _, tt := range []struct {
+		progress pieces.UsedSpaceProgress
+		percent  float64
+	}{
+		{progress: pieces.UsedSpaceProgress{}, percent: 0},
+		{progress: pieces.UsedSpaceProgress{LastPrefix: "22"}, percent: 100.0 / 1024},
+		{progress: pieces.UsedSpaceProgress{LastPrefix: "a2"}, percent: 100.0 * (6*32 + 1) / 1024},
+		{progress: pieces.UsedSpaceProgress{LastPrefix: "zz"}, percent: 100},
+		{progress: pieces.UsedSpaceProgress{LastPrefix: "aa", CompletedAt: &completedAt}, percent: 100},
+	} {
+		assert.InDelta(t, tt.percent, tt.progress.Percent(), 1e-9, tt.progress.LastPrefix)
+	}
+}
+
 func TestPersistCacheTotals(t *testing.T) {
 	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
 		log := zaptest.NewLogger(t)
@@ -312,6 +465,7 @@ func TestPersistCacheTotals(t *testing.T) {
 			cache,
 			pieces.NewStore(log, cache, nil, nil, spaceUsedDB, pieces.DefaultConfig),
 			1*time.Hour,
+			pieces.DefaultUsedSpaceConfig,
 		)
 		err = cacheService.PersistCacheTotals(ctx)
 		require.NoError(t, err)
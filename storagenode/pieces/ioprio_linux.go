@@ -0,0 +1,53 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+//go:build linux
+// +build linux
+
+package pieces
+
+import (
+	"runtime"
+
+	"go.uber.org/zap"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	ioprioWhoProcess = 1
+	ioprioClassShift = 13
+	ioprioClassIdle  = 3
+)
+
+// withLowIOPriority runs fn on a dedicated thread with idle disk I/O priority,
+// so that walking the pieces doesn't slow down uploads and downloads. The
+// priority is set for the thread only, because Linux tracks it per thread.
+func withLowIOPriority(log *zap.Logger, enabled bool, fn func() error) error {
+	if !enabled {
+		return fn()
+	}
+
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	// who 0 is the calling thread.
+	previous, _, errno := unix.Syscall(unix.SYS_IOPRIO_GET, ioprioWhoProcess, 0, 0)
+	if errno != 0 {
+		log.Warn("unable to get disk I/O priority", zap.Error(errno))
+		return fn()
+	}
+
+	_, _, errno = unix.Syscall(unix.SYS_IOPRIO_SET, ioprioWhoProcess, 0, ioprioClassIdle<<ioprioClassShift)
+	if errno != 0 {
+		log.Warn("unable to lower disk I/O priority", zap.Error(errno))
+		return fn()
+	}
+	defer func() {
+		_, _, errno := unix.Syscall(unix.SYS_IOPRIO_SET, ioprioWhoProcess, 0, previous)
+		if errno != 0 {
+			log.Warn("unable to restore disk I/O priority", zap.Error(errno))
+		}
+	}()
+
+	return fn()
+}
@@ -0,0 +1,14 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+//go:build !linux
+// +build !linux
+
+package pieces
+
+import "go.uber.org/zap"
+
+// withLowIOPriority runs fn. Lowering the disk I/O priority is only supported on Linux.
+func withLowIOPriority(log *zap.Logger, enabled bool, fn func() error) error {
+	return fn()
+}
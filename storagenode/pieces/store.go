@@ -121,6 +121,12 @@ type PieceSpaceUsedDB interface {
 	GetTrashTotal(ctx context.Context) (int64, error)
 	// UpdateTrashTotal updates the record for total spaced used for trash with a new value
 	UpdateTrashTotal(ctx context.Context, newTotal int64) error
+	// GetUsedSpaceWalkProgress returns the checkpoints of the used-space walk of all satellites
+	GetUsedSpaceWalkProgress(ctx context.Context) ([]UsedSpaceProgress, error)
+	// UpdateUsedSpaceWalkProgress saves the checkpoint of the used-space walk of a satellite
+	UpdateUsedSpaceWalkProgress(ctx context.Context, progress UsedSpaceProgress) error
+	// DeleteUsedSpaceWalkProgress removes the checkpoints of all satellites to start a new walk
+	DeleteUsedSpaceWalkProgress(ctx context.Context) error
 }
 
 // StoredPieceAccess allows inspection and manipulation of a piece during iteration with
@@ -156,11 +162,14 @@ type SatelliteUsage struct {
 type Config struct {
 	WritePreallocSize memory.Size `help:"file preallocated for uploading" default:"4MiB"`
 	DeleteToTrash     bool        `help:"move pieces to trash upon deletion. Warning: if set to false, you risk disqualification for failed audits if a satellite database is restored from backup." default:"true"`
+
+	UsedSpace UsedSpaceConfig
 }
 
 // DefaultConfig is the default value for the Config.
 var DefaultConfig = Config{
 	WritePreallocSize: 4 * memory.MiB,
+	UsedSpace:         DefaultUsedSpaceConfig,
 }
 
 // Store implements storing pieces onto a blob storage implementation.
@@ -360,6 +369,29 @@ func (store *Store) Trash(ctx context.Context, satellite storj.NodeID, pieceID s
 	return Error.Wrap(err)
 }
 
+// TrashedAt returns when the piece was moved to the trash. ok is false when the piece isn't in
+// the trash.
+func (store *Store) TrashedAt(ctx context.Context, satellite storj.NodeID, pieceID storj.PieceID) (trashedAt time.Time, ok bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	stater, isStater := store.blobs.(storage.TrashStater)
+	if !isStater {
+		return time.Time{}, false, Error.New("blob store does not support looking up the trash")
+	}
+
+	_, trashedAt, err = stater.StatTrash(ctx, storage.BlobRef{
+		Namespace: satellite.Bytes(),
+		Key:       pieceID.Bytes(),
+	})
+	if errs.IsFunc(err, os.IsNotExist) {
+		return time.Time{}, false, nil
+	}
+	if err != nil {
+		return time.Time{}, false, Error.Wrap(err)
+	}
+	return trashedAt, true, nil
+}
+
 // EmptyTrash deletes pieces in the trash that have been in there longer than trashExpiryInterval.
 func (store *Store) EmptyTrash(ctx context.Context, satelliteID storj.NodeID, trashedBefore time.Time) (err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -514,6 +546,43 @@ func (store *Store) WalkSatellitePieces(ctx context.Context, satellite storj.Nod
 	return err
 }
 
+// WalkSatellitePiecesAfterPrefix executes walkFunc for each locally stored piece in the namespace
+// of the given satellite like WalkSatellitePieces, but walks the V1 pieces grouped by key prefix,
+// skipping the prefixes up to and including afterPrefix. prefixDone is called after all pieces of
+// a prefix were walked. The V0 pieces are walked after all prefixes.
+func (store *Store) WalkSatellitePiecesAfterPrefix(ctx context.Context, satellite storj.NodeID, afterPrefix string, walkFunc func(StoredPieceAccess) error, prefixDone func(prefix string) error) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	walker, ok := store.blobs.(storage.PrefixWalker)
+	if !ok {
+		return Error.New("blob store does not support walking by key prefix")
+	}
+
+	err = walker.WalkNamespaceAfterPrefix(ctx, satellite.Bytes(), afterPrefix, func(blobInfo storage.BlobInfo) error {
+		if blobInfo.StorageFormatVersion() < filestore.FormatV1 {
+			// we'll address this piece while iterating over the V0 pieces below.
+			return nil
+		}
+		pieceAccess, err := newStoredPieceAccess(store, blobInfo)
+		if err != nil {
+			// not a real piece blob, see WalkSatellitePieces.
+			return nil //nolint: nilerr // we ignore other files
+		}
+		return walkFunc(pieceAccess)
+	}, prefixDone)
+	if err == nil && store.v0PieceInfo != nil {
+		err = store.v0PieceInfo.WalkSatelliteV0Pieces(ctx, store.blobs, satellite, walkFunc)
+	}
+	return err
+}
+
+// UsedSpaceWalkProgress returns the progress of the walk recalculating the
+// space used by every satellite.
+func (store *Store) UsedSpaceWalkProgress(ctx context.Context) (_ []UsedSpaceProgress, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return store.spaceUsedDB.GetUsedSpaceWalkProgress(ctx)
+}
+
 // GetExpired gets piece IDs that are expired and were created before the given time.
 func (store *Store) GetExpired(ctx context.Context, expiredAt time.Time, limit int64) (_ []ExpiredInfo, err error) {
 	defer mon.Task()(&ctx)(&err)
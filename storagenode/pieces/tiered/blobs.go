@@ -0,0 +1,700 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package tiered implements a cache tier for the pieces of a storage node,
+// which keeps new and frequently downloaded pieces on a fast disk in front of
+// the main storage directory.
+package tiered
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/memory"
+	"storj.io/common/storj"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/filestore"
+)
+
+var (
+	// Error is the default error class for the cache tier.
+	Error = errs.Class("tiered cache")
+
+	mon = monkit.Package()
+)
+
+// Config contains the configuration of the cache tier.
+type Config struct {
+	Path              string        `help:"path of a directory on a fast disk where new pieces are written and frequently downloaded pieces are kept (disabled when empty)" default:""`
+	MaxSize           memory.Size   `help:"maximum space used by the pieces in the cache directory" default:"50GB"`
+	MigrationInterval time.Duration `help:"how often the pieces written to the cache are moved to the main storage directory and the cache is trimmed to its maximum size" default:"1m0s"`
+	PromoteAfter      int           `help:"number of downloads within a migration interval after which a piece of the main storage directory is copied to the cache (0 disables promotion)" default:"3"`
+}
+
+// promotionQueueSize is the number of pieces waiting to be copied to the
+// cache, after which further promotions are dropped.
+const promotionQueueSize = 100
+
+// lockStripes is the number of locks serializing the changes of a blob in
+// both tiers.
+const lockStripes = 64
+
+// Stats summarizes the use of the cache.
+type Stats struct {
+	Hits   int64
+	Misses int64
+	Used   int64
+}
+
+// HitRate returns the ratio of downloads served from the cache.
+func (stats Stats) HitRate() float64 {
+	if stats.Hits+stats.Misses == 0 {
+		return 0
+	}
+	return float64(stats.Hits) / float64(stats.Hits+stats.Misses)
+}
+
+// Blobs is a blob store that writes new blobs to a cache directory on a fast
+// disk, from where the Service moves them to the main storage directory in
+// the background. Copies of blobs in the cache, which are in the main storage
+// directory too, are kept for serving downloads until the cache is full.
+//
+// A blob in the cache that is missing from the main storage directory is
+// always moved before it's removed from the cache, so that no blob is lost
+// when the node is restarted.
+type Blobs struct {
+	log    *zap.Logger
+	config Config
+
+	mainDir  *filestore.Dir
+	main     storage.Blobs
+	cacheDir *filestore.Dir
+	cache    storage.Blobs
+
+	locks [lockStripes]sync.Mutex
+
+	mu        sync.Mutex
+	used      int64
+	reserved  int64
+	lastRead  map[string]time.Time
+	mainReads map[string]int
+
+	hits   int64
+	misses int64
+
+	promotions chan storage.BlobRef
+}
+
+var _ storage.Blobs = (*Blobs)(nil)
+var _ storage.PrefixWalker = (*Blobs)(nil)
+var _ storage.TrashStater = (*Blobs)(nil)
+
+// NewBlobs creates a blob store caching the blobs of mainDir in cacheDir.
+func NewBlobs(log *zap.Logger, mainDir, cacheDir *filestore.Dir, filestoreConfig filestore.Config, config Config) *Blobs {
+	return &Blobs{
+		log:        log,
+		config:     config,
+		mainDir:    mainDir,
+		main:       filestore.New(log, mainDir, filestoreConfig),
+		cacheDir:   cacheDir,
+		cache:      filestore.New(log, cacheDir, filestoreConfig),
+		lastRead:   map[string]time.Time{},
+		mainReads:  map[string]int{},
+		promotions: make(chan storage.BlobRef, promotionQueueSize),
+	}
+}
+
+// Stats returns the use of the cache since the node started.
+func (blobs *Blobs) Stats() Stats {
+	blobs.mu.Lock()
+	used := blobs.used
+	blobs.mu.Unlock()
+
+	return Stats{
+		Hits:   atomic.LoadInt64(&blobs.hits),
+		Misses: atomic.LoadInt64(&blobs.misses),
+		Used:   used,
+	}
+}
+
+// lock locks the changes of the blob in both tiers and returns the unlock func.
+func (blobs *Blobs) lock(ref storage.BlobRef) func() {
+	var stripe int
+	if len(ref.Key) > 0 {
+		stripe = int(ref.Key[0]) % lockStripes
+	}
+	blobs.locks[stripe].Lock()
+	return blobs.locks[stripe].Unlock
+}
+
+// accessKey returns the key of the blob in the access maps.
+func accessKey(ref storage.BlobRef) string {
+	return string(ref.Namespace) + string(ref.Key)
+}
+
+// reserve reserves space for a blob in the cache and returns whether it fits.
+func (blobs *Blobs) reserve(size int64) bool {
+	if size < 0 {
+		size = 0
+	}
+
+	blobs.mu.Lock()
+	defer blobs.mu.Unlock()
+
+	if blobs.used+blobs.reserved+size > blobs.config.MaxSize.Int64() {
+		return false
+	}
+	blobs.reserved += size
+	return true
+}
+
+// release releases the reserved space, adding the size of the blob that
+// was stored in the cache instead.
+func (blobs *Blobs) release(ref storage.BlobRef, reserved, stored int64) {
+	if reserved < 0 {
+		reserved = 0
+	}
+
+	blobs.mu.Lock()
+	defer blobs.mu.Unlock()
+
+	blobs.reserved -= reserved
+	if stored > 0 {
+		blobs.used += stored
+		blobs.lastRead[accessKey(ref)] = time.Now()
+	}
+}
+
+// read records a download of the blob.
+func (blobs *Blobs) read(ref storage.BlobRef, cached bool) {
+	if cached {
+		atomic.AddInt64(&blobs.hits, 1)
+		mon.Meter("tiered_cache_hit").Mark(1)
+	} else {
+		atomic.AddInt64(&blobs.misses, 1)
+		mon.Meter("tiered_cache_miss").Mark(1)
+	}
+
+	blobs.mu.Lock()
+	defer blobs.mu.Unlock()
+
+	key := accessKey(ref)
+	if cached {
+		blobs.lastRead[key] = time.Now()
+		return
+	}
+	if blobs.config.PromoteAfter <= 0 {
+		return
+	}
+
+	blobs.mainReads[key]++
+	if blobs.mainReads[key] != blobs.config.PromoteAfter {
+		return
+	}
+	select {
+	case blobs.promotions <- ref:
+	default:
+		mon.Meter("tiered_cache_promotion_dropped").Mark(1)
+	}
+}
+
+// Create creates a new blob in the cache, or in the main storage directory
+// when the cache is full.
+func (blobs *Blobs) Create(ctx context.Context, ref storage.BlobRef, size int64) (_ storage.BlobWriter, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if !blobs.reserve(size) {
+		mon.Meter("tiered_cache_full").Mark(1)
+		return blobs.main.Create(ctx, ref, size)
+	}
+
+	writer, err := blobs.cache.Create(ctx, ref, size)
+	if err != nil {
+		blobs.release(ref, size, 0)
+		blobs.log.Warn("failed to create blob in the cache, writing it to the main storage directory", zap.Error(err))
+		return blobs.main.Create(ctx, ref, size)
+	}
+	return &cacheWriter{BlobWriter: writer, blobs: blobs, ref: ref, reserved: size}, nil
+}
+
+// TestCreateV0 creates a new V0 blob in the main storage directory. This is ONLY appropriate in test situations.
+func (blobs *Blobs) TestCreateV0(ctx context.Context, ref storage.BlobRef) (_ storage.BlobWriter, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return blobs.main.(interface {
+		TestCreateV0(ctx context.Context, ref storage.BlobRef) (_ storage.BlobWriter, err error)
+	}).TestCreateV0(ctx, ref)
+}
+
+// Open opens the blob from the cache, or from the main storage directory when
+// it isn't cached.
+func (blobs *Blobs) Open(ctx context.Context, ref storage.BlobRef) (_ storage.BlobReader, err error) {
+	defer mon.Task()(&ctx)(&err)
+	reader, err := blobs.cache.Open(ctx, ref)
+	if err == nil {
+		blobs.read(ref, true)
+		return reader, nil
+	}
+	if !errs.IsFunc(err, os.IsNotExist) {
+		return nil, err
+	}
+
+	reader, err = blobs.main.Open(ctx, ref)
+	if err == nil {
+		blobs.read(ref, false)
+	}
+	return reader, err
+}
+
+// OpenWithStorageFormat opens the blob from the cache, or from the main storage
+// directory when it isn't cached.
+func (blobs *Blobs) OpenWithStorageFormat(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion) (_ storage.BlobReader, err error) {
+	defer mon.Task()(&ctx)(&err)
+	reader, err := blobs.cache.OpenWithStorageFormat(ctx, ref, formatVer)
+	if err == nil {
+		blobs.read(ref, true)
+		return reader, nil
+	}
+	if !errs.IsFunc(err, os.IsNotExist) {
+		return nil, err
+	}
+
+	reader, err = blobs.main.OpenWithStorageFormat(ctx, ref, formatVer)
+	if err == nil {
+		blobs.read(ref, false)
+	}
+	return reader, err
+}
+
+// Stat looks up the blob in the cache, or in the main storage directory when
+// it isn't cached.
+func (blobs *Blobs) Stat(ctx context.Context, ref storage.BlobRef) (_ storage.BlobInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+	info, err := blobs.cache.Stat(ctx, ref)
+	if errs.IsFunc(err, os.IsNotExist) {
+		return blobs.main.Stat(ctx, ref)
+	}
+	return info, err
+}
+
+// StatWithStorageFormat looks up the blob in the cache, or in the main storage
+// directory when it isn't cached.
+func (blobs *Blobs) StatWithStorageFormat(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion) (_ storage.BlobInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+	info, err := blobs.cache.StatWithStorageFormat(ctx, ref, formatVer)
+	if errs.IsFunc(err, os.IsNotExist) {
+		return blobs.main.StatWithStorageFormat(ctx, ref, formatVer)
+	}
+	return info, err
+}
+
+// StatTrash looks up the blob in the trash of the main storage directory, or
+// in the trash of the cache.
+func (blobs *Blobs) StatTrash(ctx context.Context, ref storage.BlobRef) (_ storage.BlobInfo, trashedAt time.Time, err error) {
+	defer mon.Task()(&ctx)(&err)
+	info, trashedAt, err := blobs.mainDir.StatTrash(ctx, ref)
+	if errs.IsFunc(err, os.IsNotExist) {
+		return blobs.cacheDir.StatTrash(ctx, ref)
+	}
+	return info, trashedAt, err
+}
+
+// Delete deletes the blob from both tiers.
+func (blobs *Blobs) Delete(ctx context.Context, ref storage.BlobRef) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer blobs.lock(ref)()
+
+	err = blobs.deleteFromCache(ctx, ref, func() error {
+		return blobs.cache.Delete(ctx, ref)
+	})
+	return errs.Combine(err, blobs.main.Delete(ctx, ref))
+}
+
+// DeleteWithStorageFormat deletes the blob with the storage format from both tiers.
+func (blobs *Blobs) DeleteWithStorageFormat(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer blobs.lock(ref)()
+
+	err = blobs.deleteFromCache(ctx, ref, func() error {
+		return blobs.cache.DeleteWithStorageFormat(ctx, ref, formatVer)
+	})
+	return errs.Combine(err, blobs.main.DeleteWithStorageFormat(ctx, ref, formatVer))
+}
+
+// deleteFromCache removes the blob from the cache with remove and updates the
+// space used by the cache. The blob must be locked.
+func (blobs *Blobs) deleteFromCache(ctx context.Context, ref storage.BlobRef, remove func() error) error {
+	var size int64
+	info, err := blobs.cache.Stat(ctx, ref)
+	if err == nil {
+		if stat, err := info.Stat(ctx); err == nil {
+			size = stat.Size()
+		}
+	}
+
+	if err := remove(); err != nil {
+		return err
+	}
+
+	blobs.mu.Lock()
+	defer blobs.mu.Unlock()
+	blobs.used -= size
+	if blobs.used < 0 {
+		blobs.used = 0
+	}
+	delete(blobs.lastRead, accessKey(ref))
+	return nil
+}
+
+// DeleteNamespace deletes the namespace from both tiers.
+func (blobs *Blobs) DeleteNamespace(ctx context.Context, ref []byte) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return errs.Combine(blobs.cache.DeleteNamespace(ctx, ref), blobs.main.DeleteNamespace(ctx, ref))
+}
+
+// Trash moves the blob to the trash. A blob that is in the main storage
+// directory is trashed there and removed from the cache, so that every
+// trashed blob is in exactly one trash.
+func (blobs *Blobs) Trash(ctx context.Context, ref storage.BlobRef) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer blobs.lock(ref)()
+
+	_, err = blobs.main.Stat(ctx, ref)
+	switch {
+	case err == nil:
+		if err := blobs.main.Trash(ctx, ref); err != nil {
+			return err
+		}
+		return blobs.deleteFromCache(ctx, ref, func() error {
+			return blobs.cache.Delete(ctx, ref)
+		})
+	case errs.IsFunc(err, os.IsNotExist):
+		return blobs.deleteFromCache(ctx, ref, func() error {
+			return blobs.cache.Trash(ctx, ref)
+		})
+	default:
+		return err
+	}
+}
+
+// RestoreTrash restores the trash of both tiers. The blobs restored in the
+// cache are moved to the main storage directory by the Service.
+func (blobs *Blobs) RestoreTrash(ctx context.Context, namespace []byte) (keysRestored [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+	keysRestored, err = blobs.main.RestoreTrash(ctx, namespace)
+	if err != nil {
+		return keysRestored, err
+	}
+
+	cacheRestored, err := blobs.cache.RestoreTrash(ctx, namespace)
+	for _, key := range cacheRestored {
+		info, err := blobs.cache.Stat(ctx, storage.BlobRef{Namespace: namespace, Key: key})
+		if err != nil {
+			continue
+		}
+		if stat, err := info.Stat(ctx); err == nil {
+			blobs.release(info.BlobRef(), 0, stat.Size())
+		}
+	}
+	return append(keysRestored, cacheRestored...), err
+}
+
+// EmptyTrash empties the trash of both tiers.
+func (blobs *Blobs) EmptyTrash(ctx context.Context, namespace []byte, trashedBefore time.Time) (bytesEmptied int64, keys [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+	bytesEmptied, keys, err = blobs.main.EmptyTrash(ctx, namespace, trashedBefore)
+	if err != nil {
+		return bytesEmptied, keys, err
+	}
+
+	cacheBytes, cacheKeys, err := blobs.cache.EmptyTrash(ctx, namespace, trashedBefore)
+	return bytesEmptied + cacheBytes, append(keys, cacheKeys...), err
+}
+
+// FreeSpace returns how much space is left in the main storage directory,
+// where every blob ends up.
+func (blobs *Blobs) FreeSpace(ctx context.Context) (int64, error) {
+	return blobs.main.FreeSpace(ctx)
+}
+
+// CheckWritability tests writability of both tiers.
+func (blobs *Blobs) CheckWritability(ctx context.Context) error {
+	return errs.Combine(blobs.main.CheckWritability(ctx), blobs.cache.CheckWritability(ctx))
+}
+
+// SpaceUsedForTrash returns the total space used by the trash of both tiers.
+func (blobs *Blobs) SpaceUsedForTrash(ctx context.Context) (total int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	total, err = blobs.main.SpaceUsedForTrash(ctx)
+	if err != nil {
+		return total, err
+	}
+	cacheTotal, err := blobs.cache.SpaceUsedForTrash(ctx)
+	return total + cacheTotal, err
+}
+
+// SpaceUsedForBlobs adds up how much is used in all namespaces, counting the
+// blobs in both tiers once.
+func (blobs *Blobs) SpaceUsedForBlobs(ctx context.Context) (total int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	namespaces, err := blobs.ListNamespaces(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, namespace := range namespaces {
+		used, err := blobs.SpaceUsedForBlobsInNamespace(ctx, namespace)
+		if err != nil {
+			return 0, err
+		}
+		total += used
+	}
+	return total, nil
+}
+
+// SpaceUsedForBlobsInNamespace adds up how much is used in the given namespace,
+// counting the blobs in both tiers once.
+func (blobs *Blobs) SpaceUsedForBlobsInNamespace(ctx context.Context, namespace []byte) (total int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	err = blobs.WalkNamespace(ctx, namespace, func(info storage.BlobInfo) error {
+		stat, err := info.Stat(ctx)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		total += stat.Size()
+		return nil
+	})
+	return total, err
+}
+
+// ListNamespaces finds the namespaces of both tiers.
+func (blobs *Blobs) ListNamespaces(ctx context.Context) (ids [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+	ids, err = blobs.main.ListNamespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheIDs, err := blobs.cache.ListNamespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, cacheID := range cacheIDs {
+		found := false
+		for _, id := range ids {
+			if bytes.Equal(id, cacheID) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			ids = append(ids, cacheID)
+		}
+	}
+	return ids, nil
+}
+
+// WalkNamespace executes walkFunc for each blob of the namespace in the main
+// storage directory and for each blob in the cache that isn't there yet.
+func (blobs *Blobs) WalkNamespace(ctx context.Context, namespace []byte, walkFunc func(storage.BlobInfo) error) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	err = blobs.main.WalkNamespace(ctx, namespace, walkFunc)
+	if err != nil {
+		return err
+	}
+	return blobs.cache.WalkNamespace(ctx, namespace, blobs.skipInMain(ctx, walkFunc))
+}
+
+// WalkNamespaceAfterPrefix executes walkFunc for each blob of the namespace like WalkNamespace,
+// grouped by the key prefixes of both tiers, skipping those up to and including afterPrefix.
+func (blobs *Blobs) WalkNamespaceAfterPrefix(ctx context.Context, namespace []byte, afterPrefix string, walkFunc func(storage.BlobInfo) error, prefixDone func(prefix string) error) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	keyPrefixes, err := blobs.mainDir.ListNamespacePrefixes(ctx, namespace)
+	if err != nil {
+		return err
+	}
+	cachePrefixes, err := blobs.cacheDir.ListNamespacePrefixes(ctx, namespace)
+	if err != nil {
+		return err
+	}
+	keyPrefixes = mergePrefixes(keyPrefixes, cachePrefixes)
+
+	for _, keyPrefix := range keyPrefixes {
+		if keyPrefix <= afterPrefix {
+			continue
+		}
+		if err := blobs.mainDir.WalkNamespacePrefix(ctx, namespace, keyPrefix, walkFunc); err != nil {
+			return err
+		}
+		if err := blobs.cacheDir.WalkNamespacePrefix(ctx, namespace, keyPrefix, blobs.skipInMain(ctx, walkFunc)); err != nil {
+			return err
+		}
+		if err := prefixDone(keyPrefix); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mergePrefixes merges two sorted lists of key prefixes without duplicates.
+func mergePrefixes(a, b []string) (merged []string) {
+	for len(a) > 0 || len(b) > 0 {
+		switch {
+		case len(b) == 0 || (len(a) > 0 && a[0] < b[0]):
+			merged, a = append(merged, a[0]), a[1:]
+		case len(a) == 0 || b[0] < a[0]:
+			merged, b = append(merged, b[0]), b[1:]
+		default:
+			merged, a, b = append(merged, a[0]), a[1:], b[1:]
+		}
+	}
+	return merged
+}
+
+// skipInMain wraps walkFunc to skip the cached blobs that are in the main storage directory too.
+func (blobs *Blobs) skipInMain(ctx context.Context, walkFunc func(storage.BlobInfo) error) func(storage.BlobInfo) error {
+	return func(info storage.BlobInfo) error {
+		inMain, err := blobs.inMain(ctx, info.BlobRef(), info.StorageFormatVersion())
+		if err != nil {
+			return err
+		}
+		if inMain {
+			return nil
+		}
+		return walkFunc(info)
+	}
+}
+
+// inMain returns whether the blob is in the main storage directory.
+func (blobs *Blobs) inMain(ctx context.Context, ref storage.BlobRef, formatVersion storage.FormatVersion) (bool, error) {
+	_, err := blobs.mainDir.StatWithStorageFormat(ctx, ref, formatVersion)
+	if err == nil {
+		return true, nil
+	}
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return false, err
+}
+
+// CreateVerificationFile creates the verification file in both tiers.
+func (blobs *Blobs) CreateVerificationFile(ctx context.Context, id storj.NodeID) error {
+	return errs.Combine(blobs.main.CreateVerificationFile(ctx, id), blobs.cache.CreateVerificationFile(ctx, id))
+}
+
+// VerifyStorageDir verifies both tiers. The verification file of the cache is
+// created when it doesn't exist yet.
+func (blobs *Blobs) VerifyStorageDir(ctx context.Context, id storj.NodeID) error {
+	if err := blobs.main.VerifyStorageDir(ctx, id); err != nil {
+		return err
+	}
+
+	err := blobs.cache.VerifyStorageDir(ctx, id)
+	if os.IsNotExist(err) {
+		if err := blobs.cache.CreateVerificationFile(ctx, id); err != nil {
+			return err
+		}
+		return blobs.cache.VerifyStorageDir(ctx, id)
+	}
+	return err
+}
+
+// Close closes both tiers.
+func (blobs *Blobs) Close() error {
+	return errs.Combine(blobs.cache.Close(), blobs.main.Close())
+}
+
+// copyBlob copies the blob from one directory to a temporary file of another
+// and calls commit with it while the blob is locked. The temporary file is
+// deleted when commit returns without passing it to Dir.Commit.
+func (blobs *Blobs) copyBlob(ctx context.Context, from, to *filestore.Dir, ref storage.BlobRef, formatVersion storage.FormatVersion, commit func(file *os.File, size int64) (consumed bool, err error)) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	source, err := from.OpenWithStorageFormat(ctx, ref, formatVersion)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, source.Close()) }()
+
+	stat, err := source.Stat()
+	if err != nil {
+		return err
+	}
+
+	file, err := to.CreateTemporaryFile(ctx, stat.Size())
+	if err != nil {
+		return err
+	}
+
+	written, err := io.Copy(file, source)
+	if err == nil && written != stat.Size() {
+		err = Error.New("copied %d of %d bytes of blob", written, stat.Size())
+	}
+	if err != nil {
+		return errs.Combine(err, to.DeleteTemporary(ctx, file))
+	}
+
+	unlock := blobs.lock(ref)
+	consumed, err := commit(file, written)
+	unlock()
+	if !consumed {
+		return errs.Combine(err, to.DeleteTemporary(ctx, file))
+	}
+	return err
+}
+
+// cacheWriter is a writer of a blob in the cache, which accounts for the space
+// used by the blob once it's committed.
+type cacheWriter struct {
+	storage.BlobWriter
+
+	blobs    *Blobs
+	ref      storage.BlobRef
+	reserved int64
+	released bool
+}
+
+// Commit commits the blob to the cache.
+func (writer *cacheWriter) Commit(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	size, err := writer.BlobWriter.Size()
+	if err != nil {
+		return errs.Combine(err, writer.Cancel(ctx))
+	}
+
+	err = writer.BlobWriter.Commit(ctx)
+	if err != nil {
+		writer.release(0)
+		return err
+	}
+	writer.release(size)
+	return nil
+}
+
+// Cancel discards the blob.
+func (writer *cacheWriter) Cancel(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	writer.release(0)
+	return writer.BlobWriter.Cancel(ctx)
+}
+
+// release releases the reserved space once.
+func (writer *cacheWriter) release(stored int64) {
+	if writer.released {
+		return
+	}
+	writer.released = true
+	writer.blobs.release(writer.ref, writer.reserved, stored)
+}
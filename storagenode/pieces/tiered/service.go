@@ -0,0 +1,315 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package tiered
+
+import (
+	"context"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+
+	"storj.io/common/errs2"
+	"storj.io/common/memory"
+	"storj.io/common/sync2"
+	"storj.io/storj/storage"
+)
+
+// evictionTarget is the part of the maximum size of the cache it's trimmed
+// to, so that new pieces can be written to the cache until the next cycle.
+const evictionTarget = 0.9
+
+// CycleStats summarizes a cycle of the Service.
+type CycleStats struct {
+	Migrated int64
+	Evicted  int64
+	Failed   int64
+	Used     int64
+}
+
+// cachedBlob is a blob in the cache, which can be evicted.
+type cachedBlob struct {
+	ref           storage.BlobRef
+	formatVersion storage.FormatVersion
+	size          int64
+	lastRead      time.Time
+}
+
+// Service moves the blobs written to the cache to the main storage directory,
+// trims the cache to its maximum size by evicting the least recently read
+// blobs, and copies the frequently read blobs of the main storage directory
+// to the cache.
+//
+// architecture: Chore
+type Service struct {
+	log   *zap.Logger
+	blobs *Blobs
+	Loop  *sync2.Cycle
+}
+
+// NewService creates a new cache tier service.
+func NewService(log *zap.Logger, blobs *Blobs) *Service {
+	return &Service{
+		log:   log,
+		blobs: blobs,
+		Loop:  sync2.NewCycle(blobs.config.MigrationInterval),
+	}
+}
+
+// Run moves and evicts the blobs of the cache on an interval and promotes the
+// frequently read blobs.
+func (service *Service) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	service.log.Info("using the cache tier",
+		zap.String("Path", service.blobs.cacheDir.Path()),
+		zap.Stringer("Max Size", service.blobs.config.MaxSize))
+
+	group, ctx := errgroup.WithContext(ctx)
+	group.Go(func() error {
+		return service.Loop.Run(ctx, func(ctx context.Context) error {
+			stats, err := service.Cycle(ctx)
+			if err != nil {
+				if errs2.IsCanceled(err) {
+					return err
+				}
+				service.log.Error("cache tier cycle failed", zap.Error(err))
+				return nil
+			}
+
+			cacheStats := service.blobs.Stats()
+			service.log.Debug("cache tier cycle completed",
+				zap.Int64("Migrated", stats.Migrated),
+				zap.Int64("Evicted", stats.Evicted),
+				zap.Int64("Failed", stats.Failed),
+				zap.Stringer("Used", memory.Size(stats.Used)),
+				zap.Float64("Hit Rate", cacheStats.HitRate()))
+			return nil
+		})
+	})
+	group.Go(func() error {
+		for {
+			select {
+			case ref := <-service.blobs.promotions:
+				if err := service.Promote(ctx, ref); err != nil {
+					if errs2.IsCanceled(err) {
+						return err
+					}
+					service.log.Warn("failed to copy piece to the cache", zap.Error(err))
+				}
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	})
+	return group.Wait()
+}
+
+// Cycle moves the blobs of the cache, which aren't in the main storage
+// directory yet, and evicts the least recently read blobs when the cache is
+// over its size limit.
+func (service *Service) Cycle(ctx context.Context) (stats CycleStats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	blobs := service.blobs
+
+	namespaces, err := blobs.cacheDir.ListNamespaces(ctx)
+	if err != nil {
+		return stats, Error.Wrap(err)
+	}
+
+	var cached []cachedBlob
+	seen := map[string]struct{}{}
+	for _, namespace := range namespaces {
+		err := blobs.cacheDir.WalkNamespace(ctx, namespace, func(info storage.BlobInfo) error {
+			stat, err := info.Stat(ctx)
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+
+			blob := cachedBlob{
+				ref:           info.BlobRef(),
+				formatVersion: info.StorageFormatVersion(),
+				size:          stat.Size(),
+				lastRead:      stat.ModTime(),
+			}
+
+			inMain, err := blobs.inMain(ctx, blob.ref, blob.formatVersion)
+			if err != nil {
+				return err
+			}
+			if !inMain {
+				if err := service.migrate(ctx, blob); err != nil {
+					if errs2.IsCanceled(err) {
+						return err
+					}
+					service.log.Warn("failed to move piece to the main storage directory", zap.Error(err))
+					stats.Failed++
+				} else {
+					stats.Migrated++
+				}
+			}
+
+			stats.Used += blob.size
+			cached = append(cached, blob)
+			seen[accessKey(blob.ref)] = struct{}{}
+			return nil
+		})
+		if err != nil {
+			return stats, Error.Wrap(err)
+		}
+	}
+
+	blobs.mu.Lock()
+	for key := range blobs.lastRead {
+		if _, ok := seen[key]; !ok {
+			delete(blobs.lastRead, key)
+		}
+	}
+	for i := range cached {
+		if lastRead, ok := blobs.lastRead[accessKey(cached[i].ref)]; ok && lastRead.After(cached[i].lastRead) {
+			cached[i].lastRead = lastRead
+		}
+	}
+	// downloads are counted for promotion within a cycle.
+	blobs.mainReads = map[string]int{}
+	// correct the drift of the tracked space used by the cache.
+	blobs.used = stats.Used
+	blobs.mu.Unlock()
+
+	target := int64(float64(blobs.config.MaxSize.Int64()) * evictionTarget)
+	if stats.Used > target {
+		sort.Slice(cached, func(i, k int) bool {
+			return cached[i].lastRead.Before(cached[k].lastRead)
+		})
+		for _, blob := range cached {
+			if stats.Used <= target {
+				break
+			}
+			evicted, err := service.evict(ctx, blob)
+			if err != nil {
+				if errs2.IsCanceled(err) {
+					return stats, err
+				}
+				service.log.Warn("failed to evict piece from the cache", zap.Error(err))
+				continue
+			}
+			if evicted {
+				stats.Evicted++
+				stats.Used -= blob.size
+			}
+		}
+	}
+
+	mon.Meter("tiered_cache_migrated").Mark64(stats.Migrated)
+	mon.Meter("tiered_cache_evicted").Mark64(stats.Evicted)
+	mon.IntVal("tiered_cache_used_bytes").Observe(stats.Used)
+	return stats, nil
+}
+
+// migrate copies the blob of the cache to the main storage directory. The
+// copy in the cache is kept for serving downloads.
+func (service *Service) migrate(ctx context.Context, blob cachedBlob) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	blobs := service.blobs
+	err = blobs.copyBlob(ctx, blobs.cacheDir, blobs.mainDir, blob.ref, blob.formatVersion, func(file *os.File, size int64) (bool, error) {
+		// a blob deleted or trashed while it was copied must not come back.
+		_, err := blobs.cacheDir.StatWithStorageFormat(ctx, blob.ref, blob.formatVersion)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		return true, blobs.mainDir.Commit(ctx, file, blob.ref, blob.formatVersion)
+	})
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return Error.Wrap(err)
+}
+
+// evict removes the blob from the cache, when it's in the main storage directory.
+func (service *Service) evict(ctx context.Context, blob cachedBlob) (evicted bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	blobs := service.blobs
+	defer blobs.lock(blob.ref)()
+
+	inMain, err := blobs.inMain(ctx, blob.ref, blob.formatVersion)
+	if err != nil || !inMain {
+		return false, Error.Wrap(err)
+	}
+
+	err = blobs.deleteFromCache(ctx, blob.ref, func() error {
+		return blobs.cacheDir.DeleteWithStorageFormat(ctx, blob.ref, blob.formatVersion)
+	})
+	return err == nil, Error.Wrap(err)
+}
+
+// Promote copies the blob of the main storage directory to the cache, when
+// it's not cached yet and fits.
+func (service *Service) Promote(ctx context.Context, ref storage.BlobRef) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	blobs := service.blobs
+
+	if _, err := blobs.cache.Stat(ctx, ref); err == nil {
+		return nil
+	}
+
+	info, err := blobs.main.Stat(ctx, ref)
+	if err != nil {
+		if errs.IsFunc(err, os.IsNotExist) {
+			return nil
+		}
+		return Error.Wrap(err)
+	}
+	stat, err := info.Stat(ctx)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	if !blobs.reserve(stat.Size()) {
+		mon.Meter("tiered_cache_promotion_skipped").Mark(1)
+		return nil
+	}
+
+	var stored int64
+	defer func() { blobs.release(ref, stat.Size(), stored) }()
+
+	formatVersion := info.StorageFormatVersion()
+	err = blobs.copyBlob(ctx, blobs.mainDir, blobs.cacheDir, ref, formatVersion, func(file *os.File, size int64) (bool, error) {
+		// a blob deleted or trashed while it was copied must not come back.
+		inMain, err := blobs.inMain(ctx, ref, formatVersion)
+		if err != nil || !inMain {
+			return false, err
+		}
+		if err := blobs.cacheDir.Commit(ctx, file, ref, formatVersion); err != nil {
+			return true, err
+		}
+		stored = size
+		return true, nil
+	})
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err == nil && stored > 0 {
+		mon.Meter("tiered_cache_promoted").Mark(1)
+	}
+	return Error.Wrap(err)
+}
+
+// Close closes the service.
+func (service *Service) Close() error {
+	service.Loop.Close()
+	return nil
+}
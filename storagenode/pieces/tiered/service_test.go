@@ -0,0 +1,200 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package tiered_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/memory"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/filestore"
+	"storj.io/storj/storagenode/pieces/tiered"
+)
+
+func writeBlob(ctx *testcontext.Context, t *testing.T, store storage.Blobs, ref storage.BlobRef, data []byte) {
+	writer, err := store.Create(ctx, ref, int64(len(data)))
+	require.NoError(t, err)
+	_, err = writer.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, writer.Commit(ctx))
+}
+
+func readBlob(ctx *testcontext.Context, t *testing.T, store storage.Blobs, ref storage.BlobRef) []byte {
+	reader, err := store.Open(ctx, ref)
+	require.NoError(t, err)
+	defer ctx.Check(reader.Close)
+
+	data, err := ioutil.ReadAll(reader)
+	require.NoError(t, err)
+	return data
+}
+
+func exists(ctx *testcontext.Context, t *testing.T, dir *filestore.Dir, ref storage.BlobRef) bool {
+	_, err := dir.Stat(ctx, ref)
+	if os.IsNotExist(err) {
+		return false
+	}
+	require.NoError(t, err)
+	return true
+}
+
+func TestCacheTier(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	log := zaptest.NewLogger(t)
+
+	mainDir, err := filestore.NewDir(log, ctx.Dir("main"))
+	require.NoError(t, err)
+	cacheDir, err := filestore.NewDir(log, ctx.Dir("cache"))
+	require.NoError(t, err)
+
+	config := tiered.Config{
+		MaxSize:           3 * memory.KB,
+		MigrationInterval: time.Hour,
+		PromoteAfter:      2,
+	}
+
+	namespace := testrand.Bytes(32)
+	refs := make([]storage.BlobRef, 3)
+	data := make([][]byte, len(refs))
+
+	blobs := tiered.NewBlobs(log, mainDir, cacheDir, filestore.DefaultConfig, config)
+	for i := range refs {
+		refs[i] = storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(32)}
+		data[i] = testrand.BytesInt(1000)
+		writeBlob(ctx, t, blobs, refs[i], data[i])
+
+		// new blobs are written to the cache only.
+		assert.True(t, exists(ctx, t, cacheDir, refs[i]))
+		assert.False(t, exists(ctx, t, mainDir, refs[i]))
+	}
+	assert.EqualValues(t, 3000, blobs.Stats().Used)
+
+	// the cache is full, so the blob is written to the main storage directory.
+	large := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(32)}
+	writeBlob(ctx, t, blobs, large, testrand.BytesInt(2000))
+	assert.False(t, exists(ctx, t, cacheDir, large))
+	assert.True(t, exists(ctx, t, mainDir, large))
+
+	used, err := blobs.SpaceUsedForBlobs(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 5000, used)
+
+	// the node restarts before the blobs were moved.
+	require.NoError(t, blobs.Close())
+	blobs = tiered.NewBlobs(log, mainDir, cacheDir, filestore.DefaultConfig, config)
+	defer ctx.Check(blobs.Close)
+	service := tiered.NewService(log, blobs)
+
+	// make the first blob the least recently read one.
+	time.Sleep(10 * time.Millisecond)
+	for i := 1; i < len(refs); i++ {
+		assert.Equal(t, data[i], readBlob(ctx, t, blobs, refs[i]))
+	}
+
+	stats, err := service.Cycle(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, stats.Migrated)
+	assert.EqualValues(t, 1, stats.Evicted)
+	assert.EqualValues(t, 2000, stats.Used)
+	for i := range refs {
+		assert.True(t, exists(ctx, t, mainDir, refs[i]))
+		assert.Equal(t, i > 0, exists(ctx, t, cacheDir, refs[i]))
+	}
+
+	used, err = blobs.SpaceUsedForBlobs(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 5000, used)
+
+	// reading the evicted blob often enough promotes it to the cache again.
+	serviceCtx, cancel := context.WithCancel(ctx)
+	ctx.Go(func() error {
+		err := service.Run(serviceCtx)
+		if serviceCtx.Err() != nil {
+			return nil
+		}
+		return err
+	})
+	defer cancel()
+	// the reads are counted within a cycle, so wait for the first one.
+	service.Loop.TriggerWait()
+
+	for i := 0; i < config.PromoteAfter; i++ {
+		assert.Equal(t, data[0], readBlob(ctx, t, blobs, refs[0]))
+	}
+	require.Eventually(t, func() bool {
+		return exists(ctx, t, cacheDir, refs[0])
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cacheStats := blobs.Stats()
+	assert.EqualValues(t, 2, cacheStats.Hits)
+	assert.EqualValues(t, 2, cacheStats.Misses)
+	assert.Equal(t, 0.5, cacheStats.HitRate())
+
+	// deleted blobs are removed from both tiers.
+	require.NoError(t, blobs.Delete(ctx, refs[1]))
+	assert.False(t, exists(ctx, t, cacheDir, refs[1]))
+	assert.False(t, exists(ctx, t, mainDir, refs[1]))
+}
+
+func TestCacheTierTrash(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	log := zaptest.NewLogger(t)
+
+	mainDir, err := filestore.NewDir(log, ctx.Dir("main"))
+	require.NoError(t, err)
+	cacheDir, err := filestore.NewDir(log, ctx.Dir("cache"))
+	require.NoError(t, err)
+
+	blobs := tiered.NewBlobs(log, mainDir, cacheDir, filestore.DefaultConfig, tiered.Config{
+		MaxSize:           memory.MB,
+		MigrationInterval: time.Hour,
+	})
+	defer ctx.Check(blobs.Close)
+	service := tiered.NewService(log, blobs)
+
+	namespace := testrand.Bytes(32)
+	cached := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(32)}
+	migrated := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(32)}
+	writeBlob(ctx, t, blobs, migrated, testrand.BytesInt(1000))
+
+	_, err = service.Cycle(ctx)
+	require.NoError(t, err)
+	writeBlob(ctx, t, blobs, cached, testrand.BytesInt(1000))
+
+	// every blob ends up in exactly one trash.
+	require.NoError(t, blobs.Trash(ctx, cached))
+	require.NoError(t, blobs.Trash(ctx, migrated))
+	assert.False(t, exists(ctx, t, cacheDir, cached))
+	assert.False(t, exists(ctx, t, cacheDir, migrated))
+	assert.False(t, exists(ctx, t, mainDir, migrated))
+
+	_, trashedAt, err := blobs.StatTrash(ctx, cached)
+	require.NoError(t, err)
+	assert.False(t, trashedAt.IsZero())
+
+	restored, err := blobs.RestoreTrash(ctx, namespace)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, [][]byte{cached.Key, migrated.Key}, restored)
+
+	// the blob restored in the cache is moved to the main storage directory.
+	stats, err := service.Cycle(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, stats.Migrated)
+	assert.True(t, exists(ctx, t, mainDir, cached))
+	assert.True(t, exists(ctx, t, mainDir, migrated))
+}
@@ -0,0 +1,212 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pieces
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+	"storj.io/common/sync2"
+)
+
+// UsedSpaceConfig configures how the used space is recalculated on startup.
+type UsedSpaceConfig struct {
+	ScanOnStartup bool          `help:"walk all pieces on startup to recalculate the used space; if false, the used space is only tracked on uploads, deletes and trash" default:"true"`
+	LowIOPriority bool          `help:"walk the pieces with idle disk I/O priority (Linux only)" default:"true"`
+	PrefixPause   time.Duration `help:"how long to pause after walking the pieces of a key prefix, to limit the disk load" default:"0s"`
+}
+
+// DefaultUsedSpaceConfig is the default value for UsedSpaceConfig.
+var DefaultUsedSpaceConfig = UsedSpaceConfig{
+	ScanOnStartup: true,
+	LowIOPriority: true,
+}
+
+// UsedSpaceProgress is the checkpoint of the walk recalculating the space used
+// by the pieces of a satellite.
+type UsedSpaceProgress struct {
+	SatelliteID storj.NodeID
+	// LastPrefix is the last key prefix whose pieces were all walked.
+	LastPrefix string
+	// Total and ContentSize sum up the pieces walked up to LastPrefix.
+	Total       int64
+	ContentSize int64
+	// TotalAtStart and ContentSizeAtStart are the cached space used by the
+	// satellite when the walk started, to estimate the changes missed while
+	// walking.
+	TotalAtStart       int64
+	ContentSizeAtStart int64
+
+	StartedAt   time.Time
+	UpdatedAt   time.Time
+	CompletedAt *time.Time
+}
+
+// prefixAlphabet holds the characters of the key prefixes, which are the
+// first two characters of the base32 encoded keys, in the order they are walked.
+const prefixAlphabet = "234567abcdefghijklmnopqrstuvwxyz"
+
+// Percent returns how much of the pieces of the satellite were walked,
+// assuming the pieces are spread evenly across the key prefixes.
+func (progress *UsedSpaceProgress) Percent() float64 {
+	if progress.CompletedAt != nil {
+		return 100
+	}
+	if len(progress.LastPrefix) != 2 {
+		return 0
+	}
+
+	first := strings.IndexByte(prefixAlphabet, progress.LastPrefix[0])
+	second := strings.IndexByte(prefixAlphabet, progress.LastPrefix[1])
+	if first < 0 || second < 0 {
+		return 0
+	}
+	walked := first*len(prefixAlphabet) + second + 1
+	return 100 * float64(walked) / float64(len(prefixAlphabet)*len(prefixAlphabet))
+}
+
+// walkUsedSpace recalculates the space used by every satellite, resuming the
+// walk from the saved checkpoints when the previous walk was interrupted.
+func (service *CacheService) walkUsedSpace(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	spaceUsedDB := service.store.spaceUsedDB
+
+	checkpoints, err := spaceUsedDB.GetUsedSpaceWalkProgress(ctx)
+	if err != nil {
+		return err
+	}
+
+	progressBySatellite := map[storj.NodeID]UsedSpaceProgress{}
+	interrupted := false
+	for _, progress := range checkpoints {
+		progressBySatellite[progress.SatelliteID] = progress
+		if progress.CompletedAt == nil {
+			interrupted = true
+		}
+	}
+	if !interrupted {
+		// the previous walk finished, so start over.
+		if err := spaceUsedDB.DeleteUsedSpaceWalkProgress(ctx); err != nil {
+			return err
+		}
+		progressBySatellite = map[storj.NodeID]UsedSpaceProgress{}
+	} else {
+		service.log.Info("resuming used-space walk", zap.Int("Checkpoints", len(checkpoints)))
+	}
+
+	satelliteIDs, err := service.store.getAllStoringSatellites(ctx)
+	if err != nil {
+		return Error.New("failed to enumerate satellites: %w", err)
+	}
+
+	trashTotalAtStart := service.usageCache.copyCacheTotals().trashTotal
+
+	walked := map[storj.NodeID]bool{}
+	for _, satelliteID := range satelliteIDs {
+		walked[satelliteID] = true
+
+		progress, ok := progressBySatellite[satelliteID]
+		if ok && progress.CompletedAt != nil {
+			continue
+		}
+		if !ok {
+			atStart := service.usageCache.satelliteUsage(satelliteID)
+			progress = UsedSpaceProgress{
+				SatelliteID:        satelliteID,
+				TotalAtStart:       atStart.Total,
+				ContentSizeAtStart: atStart.ContentSize,
+				StartedAt:          time.Now().UTC(),
+			}
+		}
+
+		if err := service.walkSatelliteUsedSpace(ctx, progress); err != nil {
+			return err
+		}
+	}
+
+	// satellites without any pieces left don't use any space.
+	for satelliteID, usage := range service.usageCache.copyCacheTotals().spaceUsedBySatellite {
+		if walked[satelliteID] {
+			continue
+		}
+		service.usageCache.RecalculateSatellite(satelliteID, 0, usage.Total, 0, usage.ContentSize)
+	}
+
+	trashTotal, err := service.usageCache.Blobs.SpaceUsedForTrash(ctx)
+	if err != nil {
+		return err
+	}
+	service.usageCache.RecalculateTrash(trashTotal, trashTotalAtStart)
+
+	return service.PersistCacheTotals(ctx)
+}
+
+// walkSatelliteUsedSpace walks the pieces of the satellite after the
+// checkpoint, saving a checkpoint after every key prefix, and updates the
+// space used by the satellite once all pieces were walked.
+func (service *CacheService) walkSatelliteUsedSpace(ctx context.Context, progress UsedSpaceProgress) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	spaceUsedDB := service.store.spaceUsedDB
+
+	var prefixTotal, prefixContentSize int64
+	err = service.store.WalkSatellitePiecesAfterPrefix(ctx, progress.SatelliteID, progress.LastPrefix, func(access StoredPieceAccess) error {
+		pieceTotal, pieceContentSize, err := access.Size(ctx)
+		if err != nil {
+			return err
+		}
+		prefixTotal += pieceTotal
+		prefixContentSize += pieceContentSize
+		return nil
+	}, func(prefix string) error {
+		progress.LastPrefix = prefix
+		progress.Total += prefixTotal
+		progress.ContentSize += prefixContentSize
+		progress.UpdatedAt = time.Now().UTC()
+		prefixTotal, prefixContentSize = 0, 0
+
+		if err := spaceUsedDB.UpdateUsedSpaceWalkProgress(ctx, progress); err != nil {
+			return err
+		}
+		if service.config.PrefixPause > 0 && !sync2.Sleep(ctx, service.config.PrefixPause) {
+			return ctx.Err()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// the V0 pieces are walked after the last prefix.
+	progress.Total += prefixTotal
+	progress.ContentSize += prefixContentSize
+
+	completedAt := time.Now().UTC()
+	progress.UpdatedAt = completedAt
+	progress.CompletedAt = &completedAt
+
+	service.usageCache.RecalculateSatellite(progress.SatelliteID,
+		progress.Total, progress.TotalAtStart,
+		progress.ContentSize, progress.ContentSizeAtStart)
+
+	// persist the totals before the checkpoint, so that a completed satellite
+	// isn't lost when the node restarts.
+	if err := service.PersistCacheTotals(ctx); err != nil {
+		return err
+	}
+	if err := spaceUsedDB.UpdateUsedSpaceWalkProgress(ctx, progress); err != nil {
+		return err
+	}
+
+	service.log.Debug("used-space walk of satellite completed",
+		zap.Stringer("Satellite ID", progress.SatelliteID),
+		zap.Int64("Total", progress.Total),
+		zap.Int64("Content Size", progress.ContentSize))
+	return nil
+}
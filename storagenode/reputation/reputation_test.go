@@ -184,7 +184,7 @@ func TestServiceStore(t *testing.T) {
 		reputationDB := db.Reputation()
 		notificationsDB := db.Notifications()
 		log := zaptest.NewLogger(t)
-		notificationService := notifications.NewService(log, notificationsDB)
+		notificationService := notifications.NewService(log, notificationsDB, nil)
 		reputationService := reputation.NewService(log, reputationDB, storj.NodeID{}, notificationService)
 
 		id := testrand.NodeID()
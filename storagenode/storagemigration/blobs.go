@@ -0,0 +1,601 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package storagemigration moves the pieces of a running storage node to a
+// new storage directory.
+package storagemigration
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"io"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/filestore"
+)
+
+var (
+	// Error is the default error class for the storage migration.
+	Error = errs.Class("storage migration")
+
+	mon = monkit.Package()
+)
+
+// Blobs is a blob store that serves the blobs from two storage directories
+// while they're copied from the source to the destination. New blobs are
+// written to the destination, blobs are read from the destination first and
+// deleted from both. Once switched, the source isn't used anymore.
+type Blobs struct {
+	log *zap.Logger
+
+	sourceDir      *filestore.Dir
+	source         storage.Blobs
+	destinationDir *filestore.Dir
+	destination    storage.Blobs
+
+	switched int32
+}
+
+var _ storage.Blobs = (*Blobs)(nil)
+var _ storage.PrefixWalker = (*Blobs)(nil)
+var _ storage.TrashStater = (*Blobs)(nil)
+
+// NewBlobs creates a blob store migrating the blobs of sourceDir to destinationDir.
+func NewBlobs(log *zap.Logger, sourceDir, destinationDir *filestore.Dir, config filestore.Config) *Blobs {
+	return &Blobs{
+		log:            log,
+		sourceDir:      sourceDir,
+		source:         filestore.New(log, sourceDir, config),
+		destinationDir: destinationDir,
+		destination:    filestore.New(log, destinationDir, config),
+	}
+}
+
+// SourcePath returns the path of the storage directory the blobs are copied from.
+func (blobs *Blobs) SourcePath() string { return blobs.sourceDir.Path() }
+
+// DestinationPath returns the path of the storage directory the blobs are copied to.
+func (blobs *Blobs) DestinationPath() string { return blobs.destinationDir.Path() }
+
+// Switched returns whether the blobs are only served from the destination.
+func (blobs *Blobs) Switched() bool { return atomic.LoadInt32(&blobs.switched) == 1 }
+
+// switchToDestination stops using the source.
+func (blobs *Blobs) switchToDestination() { atomic.StoreInt32(&blobs.switched, 1) }
+
+// Create creates a new blob in the destination.
+func (blobs *Blobs) Create(ctx context.Context, ref storage.BlobRef, size int64) (_ storage.BlobWriter, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return blobs.destination.Create(ctx, ref, size)
+}
+
+// TestCreateV0 creates a new V0 blob in the destination. This is ONLY appropriate in test situations.
+func (blobs *Blobs) TestCreateV0(ctx context.Context, ref storage.BlobRef) (_ storage.BlobWriter, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return blobs.destination.(interface {
+		TestCreateV0(ctx context.Context, ref storage.BlobRef) (_ storage.BlobWriter, err error)
+	}).TestCreateV0(ctx, ref)
+}
+
+// Open opens the blob from the destination, or from the source when it wasn't copied yet.
+func (blobs *Blobs) Open(ctx context.Context, ref storage.BlobRef) (_ storage.BlobReader, err error) {
+	defer mon.Task()(&ctx)(&err)
+	reader, err := blobs.destination.Open(ctx, ref)
+	if blobs.useSource(err) {
+		return blobs.source.Open(ctx, ref)
+	}
+	return reader, err
+}
+
+// OpenWithStorageFormat opens the blob from the destination, or from the source when it wasn't
+// copied yet.
+func (blobs *Blobs) OpenWithStorageFormat(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion) (_ storage.BlobReader, err error) {
+	defer mon.Task()(&ctx)(&err)
+	reader, err := blobs.destination.OpenWithStorageFormat(ctx, ref, formatVer)
+	if blobs.useSource(err) {
+		return blobs.source.OpenWithStorageFormat(ctx, ref, formatVer)
+	}
+	return reader, err
+}
+
+// Stat looks up the blob in the destination, or in the source when it wasn't copied yet.
+func (blobs *Blobs) Stat(ctx context.Context, ref storage.BlobRef) (_ storage.BlobInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+	info, err := blobs.destination.Stat(ctx, ref)
+	if blobs.useSource(err) {
+		return blobs.source.Stat(ctx, ref)
+	}
+	return info, err
+}
+
+// StatWithStorageFormat looks up the blob in the destination, or in the source when it wasn't
+// copied yet.
+func (blobs *Blobs) StatWithStorageFormat(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion) (_ storage.BlobInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+	info, err := blobs.destination.StatWithStorageFormat(ctx, ref, formatVer)
+	if blobs.useSource(err) {
+		return blobs.source.StatWithStorageFormat(ctx, ref, formatVer)
+	}
+	return info, err
+}
+
+// StatTrash looks up the blob in the trash of the destination, or in the trash of the source
+// when it wasn't copied yet.
+func (blobs *Blobs) StatTrash(ctx context.Context, ref storage.BlobRef) (_ storage.BlobInfo, trashedAt time.Time, err error) {
+	defer mon.Task()(&ctx)(&err)
+	info, trashedAt, err := blobs.destinationDir.StatTrash(ctx, ref)
+	if blobs.useSource(err) {
+		return blobs.sourceDir.StatTrash(ctx, ref)
+	}
+	return info, trashedAt, err
+}
+
+// useSource returns whether the source should be tried after the destination failed with err.
+func (blobs *Blobs) useSource(err error) bool {
+	return errs.IsFunc(err, os.IsNotExist) && !blobs.Switched()
+}
+
+// Delete deletes the blob from both storage directories.
+func (blobs *Blobs) Delete(ctx context.Context, ref storage.BlobRef) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	err = blobs.destination.Delete(ctx, ref)
+	if blobs.Switched() {
+		return err
+	}
+	return errs.Combine(err, blobs.source.Delete(ctx, ref))
+}
+
+// DeleteWithStorageFormat deletes the blob with the storage format from both storage directories.
+func (blobs *Blobs) DeleteWithStorageFormat(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	err = blobs.destination.DeleteWithStorageFormat(ctx, ref, formatVer)
+	if blobs.Switched() {
+		return err
+	}
+	return errs.Combine(err, blobs.source.DeleteWithStorageFormat(ctx, ref, formatVer))
+}
+
+// DeleteNamespace deletes the namespace from both storage directories.
+func (blobs *Blobs) DeleteNamespace(ctx context.Context, ref []byte) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	err = blobs.destination.DeleteNamespace(ctx, ref)
+	if blobs.Switched() {
+		return err
+	}
+	return errs.Combine(err, blobs.source.DeleteNamespace(ctx, ref))
+}
+
+// Trash moves the blob to the trash. A blob that was already copied is
+// trashed in the destination and deleted from the source, so that every
+// trashed blob is in exactly one trash.
+func (blobs *Blobs) Trash(ctx context.Context, ref storage.BlobRef) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	if blobs.Switched() {
+		return blobs.destination.Trash(ctx, ref)
+	}
+
+	_, err = blobs.destination.Stat(ctx, ref)
+	switch {
+	case err == nil:
+		if err := blobs.destination.Trash(ctx, ref); err != nil {
+			return err
+		}
+		return blobs.source.Delete(ctx, ref)
+	case errs.IsFunc(err, os.IsNotExist):
+		return blobs.source.Trash(ctx, ref)
+	default:
+		return err
+	}
+}
+
+// RestoreTrash restores the trash of both storage directories. The blobs
+// restored in the source are copied to the destination.
+func (blobs *Blobs) RestoreTrash(ctx context.Context, namespace []byte) (keysRestored [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+	keysRestored, err = blobs.destination.RestoreTrash(ctx, namespace)
+	if err != nil || blobs.Switched() {
+		return keysRestored, err
+	}
+
+	sourceRestored, err := blobs.source.RestoreTrash(ctx, namespace)
+	if err != nil {
+		return keysRestored, err
+	}
+	for _, key := range sourceRestored {
+		ref := storage.BlobRef{Namespace: namespace, Key: key}
+		info, err := blobs.source.Stat(ctx, ref)
+		if err != nil {
+			if errs.IsFunc(err, os.IsNotExist) {
+				continue
+			}
+			return keysRestored, err
+		}
+		if _, _, err := blobs.copyBlob(ctx, info); err != nil {
+			return keysRestored, err
+		}
+	}
+	return append(keysRestored, sourceRestored...), nil
+}
+
+// EmptyTrash empties the trash of both storage directories.
+func (blobs *Blobs) EmptyTrash(ctx context.Context, namespace []byte, trashedBefore time.Time) (bytesEmptied int64, keys [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+	bytesEmptied, keys, err = blobs.destination.EmptyTrash(ctx, namespace, trashedBefore)
+	if err != nil || blobs.Switched() {
+		return bytesEmptied, keys, err
+	}
+
+	sourceBytes, sourceKeys, err := blobs.source.EmptyTrash(ctx, namespace, trashedBefore)
+	return bytesEmptied + sourceBytes, append(keys, sourceKeys...), err
+}
+
+// FreeSpace returns how much space is left in the destination, where the new blobs are written.
+func (blobs *Blobs) FreeSpace(ctx context.Context) (int64, error) {
+	return blobs.destination.FreeSpace(ctx)
+}
+
+// CheckWritability tests writability of the destination.
+func (blobs *Blobs) CheckWritability(ctx context.Context) error {
+	return blobs.destination.CheckWritability(ctx)
+}
+
+// SpaceUsedForTrash returns the total space used by the trash of both storage directories.
+func (blobs *Blobs) SpaceUsedForTrash(ctx context.Context) (total int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	total, err = blobs.destination.SpaceUsedForTrash(ctx)
+	if err != nil || blobs.Switched() {
+		return total, err
+	}
+	sourceTotal, err := blobs.source.SpaceUsedForTrash(ctx)
+	return total + sourceTotal, err
+}
+
+// SpaceUsedForBlobs adds up how much is used in all namespaces, counting the
+// blobs in both storage directories once.
+func (blobs *Blobs) SpaceUsedForBlobs(ctx context.Context) (total int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	namespaces, err := blobs.ListNamespaces(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, namespace := range namespaces {
+		used, err := blobs.SpaceUsedForBlobsInNamespace(ctx, namespace)
+		if err != nil {
+			return 0, err
+		}
+		total += used
+	}
+	return total, nil
+}
+
+// SpaceUsedForBlobsInNamespace adds up how much is used in the given namespace,
+// counting the blobs in both storage directories once.
+func (blobs *Blobs) SpaceUsedForBlobsInNamespace(ctx context.Context, namespace []byte) (total int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	err = blobs.WalkNamespace(ctx, namespace, func(info storage.BlobInfo) error {
+		stat, err := info.Stat(ctx)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		total += stat.Size()
+		return nil
+	})
+	return total, err
+}
+
+// ListNamespaces finds the namespaces of both storage directories.
+func (blobs *Blobs) ListNamespaces(ctx context.Context) (ids [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+	ids, err = blobs.destination.ListNamespaces(ctx)
+	if err != nil || blobs.Switched() {
+		return ids, err
+	}
+
+	sourceIDs, err := blobs.source.ListNamespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, sourceID := range sourceIDs {
+		found := false
+		for _, id := range ids {
+			if bytes.Equal(id, sourceID) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			ids = append(ids, sourceID)
+		}
+	}
+	return ids, nil
+}
+
+// WalkNamespace executes walkFunc for each blob of the namespace in the
+// destination and for each blob in the source that wasn't copied yet.
+func (blobs *Blobs) WalkNamespace(ctx context.Context, namespace []byte, walkFunc func(storage.BlobInfo) error) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	err = blobs.destination.WalkNamespace(ctx, namespace, walkFunc)
+	if err != nil || blobs.Switched() {
+		return err
+	}
+	return blobs.source.WalkNamespace(ctx, namespace, blobs.skipCopied(ctx, walkFunc))
+}
+
+// WalkNamespaceAfterPrefix executes walkFunc for each blob of the namespace like WalkNamespace,
+// grouped by the key prefixes of both storage directories, skipping those up to and including
+// afterPrefix.
+func (blobs *Blobs) WalkNamespaceAfterPrefix(ctx context.Context, namespace []byte, afterPrefix string, walkFunc func(storage.BlobInfo) error, prefixDone func(prefix string) error) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	if blobs.Switched() {
+		return blobs.destinationDir.WalkNamespaceAfterPrefix(ctx, namespace, afterPrefix, walkFunc, prefixDone)
+	}
+
+	keyPrefixes, err := blobs.destinationDir.ListNamespacePrefixes(ctx, namespace)
+	if err != nil {
+		return err
+	}
+	sourcePrefixes, err := blobs.sourceDir.ListNamespacePrefixes(ctx, namespace)
+	if err != nil {
+		return err
+	}
+	keyPrefixes = mergePrefixes(keyPrefixes, sourcePrefixes)
+
+	for _, keyPrefix := range keyPrefixes {
+		if keyPrefix <= afterPrefix {
+			continue
+		}
+		if err := blobs.destinationDir.WalkNamespacePrefix(ctx, namespace, keyPrefix, walkFunc); err != nil {
+			return err
+		}
+		if err := blobs.sourceDir.WalkNamespacePrefix(ctx, namespace, keyPrefix, blobs.skipCopied(ctx, walkFunc)); err != nil {
+			return err
+		}
+		if err := prefixDone(keyPrefix); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mergePrefixes merges two sorted lists of key prefixes without duplicates.
+func mergePrefixes(a, b []string) (merged []string) {
+	for len(a) > 0 || len(b) > 0 {
+		switch {
+		case len(b) == 0 || (len(a) > 0 && a[0] < b[0]):
+			merged, a = append(merged, a[0]), a[1:]
+		case len(a) == 0 || b[0] < a[0]:
+			merged, b = append(merged, b[0]), b[1:]
+		default:
+			merged, a, b = append(merged, a[0]), a[1:], b[1:]
+		}
+	}
+	return merged
+}
+
+// skipCopied wraps walkFunc to skip the source blobs that are already in the destination.
+func (blobs *Blobs) skipCopied(ctx context.Context, walkFunc func(storage.BlobInfo) error) func(storage.BlobInfo) error {
+	return func(info storage.BlobInfo) error {
+		_, err := blobs.destinationDir.StatWithStorageFormat(ctx, info.BlobRef(), info.StorageFormatVersion())
+		if err == nil {
+			return nil
+		}
+		if !os.IsNotExist(err) {
+			return err
+		}
+		return walkFunc(info)
+	}
+}
+
+// CreateVerificationFile creates the verification file in the destination.
+func (blobs *Blobs) CreateVerificationFile(ctx context.Context, id storj.NodeID) error {
+	return blobs.destination.CreateVerificationFile(ctx, id)
+}
+
+// VerifyStorageDir verifies both storage directories. The verification file
+// of the destination is created when it doesn't exist yet.
+func (blobs *Blobs) VerifyStorageDir(ctx context.Context, id storj.NodeID) error {
+	if !blobs.Switched() {
+		if err := blobs.source.VerifyStorageDir(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	err := blobs.destination.VerifyStorageDir(ctx, id)
+	if os.IsNotExist(err) {
+		if err := blobs.destination.CreateVerificationFile(ctx, id); err != nil {
+			return err
+		}
+		return blobs.destination.VerifyStorageDir(ctx, id)
+	}
+	return err
+}
+
+// Close closes both storage directories.
+func (blobs *Blobs) Close() error {
+	return errs.Combine(blobs.destination.Close(), blobs.source.Close())
+}
+
+// copyBlob copies a blob from the source to the destination and verifies the
+// copy. It returns false when the blob was already copied or doesn't exist
+// anymore.
+func (blobs *Blobs) copyBlob(ctx context.Context, info storage.BlobInfo) (copied bool, size int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ref := info.BlobRef()
+	formatVersion := info.StorageFormatVersion()
+
+	source, err := blobs.sourceDir.OpenWithStorageFormat(ctx, ref, formatVersion)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, 0, nil
+		}
+		return false, 0, err
+	}
+	defer func() { err = errs.Combine(err, source.Close()) }()
+
+	stat, err := source.Stat()
+	if err != nil {
+		return false, 0, err
+	}
+	size = stat.Size()
+
+	existing, err := blobs.destinationDir.StatWithStorageFormat(ctx, ref, formatVersion)
+	if err == nil {
+		existingStat, err := existing.Stat(ctx)
+		if err != nil {
+			return false, 0, err
+		}
+		if existingStat.Size() == size {
+			return false, size, nil
+		}
+	} else if !os.IsNotExist(err) {
+		return false, 0, err
+	}
+
+	file, err := blobs.destinationDir.CreateTemporaryFile(ctx, size)
+	if err != nil {
+		return false, 0, err
+	}
+
+	hash := sha256.New()
+	written, err := io.Copy(io.MultiWriter(file, hash), source)
+	if err == nil && written != size {
+		err = Error.New("copied %d of %d bytes of blob", written, size)
+	}
+	if err != nil {
+		return false, 0, errs.Combine(err, blobs.destinationDir.DeleteTemporary(ctx, file))
+	}
+
+	if err := blobs.destinationDir.Commit(ctx, file, ref, formatVersion); err != nil {
+		return false, 0, err
+	}
+
+	if err := blobs.verifyCopy(ctx, ref, formatVersion, hash.Sum(nil)); err != nil {
+		return false, 0, errs.Combine(err, blobs.destinationDir.DeleteWithStorageFormat(ctx, ref, formatVersion))
+	}
+
+	// a blob deleted or trashed while it was copied must not come back.
+	if _, err := blobs.sourceDir.StatWithStorageFormat(ctx, ref, formatVersion); err != nil {
+		if os.IsNotExist(err) {
+			return false, 0, blobs.destinationDir.DeleteWithStorageFormat(ctx, ref, formatVersion)
+		}
+		return false, 0, err
+	}
+
+	return true, size, nil
+}
+
+// moveTrashedBlob moves a blob from the trash of the source to the trash of
+// the destination, keeping the time it was trashed. It returns false when the
+// blob isn't in the trash of the source anymore.
+func (blobs *Blobs) moveTrashedBlob(ctx context.Context, info storage.BlobInfo) (moved bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ref := info.BlobRef()
+	formatVersion := info.StorageFormatVersion()
+
+	path, err := info.FullPath(ctx)
+	if err != nil {
+		return false, err
+	}
+	source, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer func() { err = errs.Combine(err, source.Close()) }()
+
+	stat, err := source.Stat()
+	if err != nil {
+		return false, err
+	}
+	size, trashedAt := stat.Size(), stat.ModTime()
+
+	file, err := blobs.destinationDir.CreateTemporaryFile(ctx, size)
+	if err != nil {
+		return false, err
+	}
+
+	hash := sha256.New()
+	written, err := io.Copy(io.MultiWriter(file, hash), source)
+	if err == nil && written != size {
+		err = Error.New("copied %d of %d bytes of trashed blob", written, size)
+	}
+	if err != nil {
+		return false, errs.Combine(err, blobs.destinationDir.DeleteTemporary(ctx, file))
+	}
+
+	if err := blobs.destinationDir.CommitTrash(ctx, file, ref, formatVersion, trashedAt); err != nil {
+		return false, err
+	}
+
+	copied, trashedCopyAt, err := blobs.destinationDir.StatTrash(ctx, ref)
+	if err == nil {
+		err = blobs.verifyFile(ctx, copied, hash.Sum(nil))
+	}
+	if err == nil && !trashedCopyAt.Equal(trashedAt) {
+		err = Error.New("trashed blob %x in namespace %x was trashed at %v instead of %v", ref.Key, ref.Namespace, trashedCopyAt, trashedAt)
+	}
+	if err != nil {
+		return false, errs.Combine(err, blobs.destinationDir.DeleteTrashWithStorageFormat(ctx, ref, formatVersion))
+	}
+
+	if err := blobs.sourceDir.DeleteTrashWithStorageFormat(ctx, ref, formatVersion); err != nil {
+		return false, err
+	}
+
+	// a blob restored while it was moved must not stay in the trash.
+	if _, err := blobs.sourceDir.StatWithStorageFormat(ctx, ref, formatVersion); err == nil {
+		return false, blobs.destinationDir.DeleteTrashWithStorageFormat(ctx, ref, formatVersion)
+	} else if !os.IsNotExist(err) {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// verifyCopy compares the hash of the copied blob with the hash of the source.
+func (blobs *Blobs) verifyCopy(ctx context.Context, ref storage.BlobRef, formatVersion storage.FormatVersion, expected []byte) (err error) {
+	info, err := blobs.destinationDir.StatWithStorageFormat(ctx, ref, formatVersion)
+	if err != nil {
+		return err
+	}
+	return blobs.verifyFile(ctx, info, expected)
+}
+
+// verifyFile compares the hash of the copied blob file with the hash of the source.
+func (blobs *Blobs) verifyFile(ctx context.Context, info storage.BlobInfo, expected []byte) (err error) {
+	path, err := info.FullPath(ctx)
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, file.Close()) }()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return err
+	}
+	if !bytes.Equal(hash.Sum(nil), expected) {
+		ref := info.BlobRef()
+		return Error.New("copy of blob %x in namespace %x doesn't match the source", ref.Key, ref.Namespace)
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package storagemigration
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/errs2"
+	"storj.io/common/memory"
+	"storj.io/common/storj"
+	"storj.io/storj/storage"
+)
+
+// maxVerifyPasses limits how often the source is walked again to copy the
+// blobs that were missed, before giving up.
+const maxVerifyPasses = 5
+
+// Stats summarizes a pass over the blobs of the source.
+type Stats struct {
+	Blobs   int64
+	Bytes   int64
+	Skipped int64
+}
+
+// Service copies the blobs of a migrating blob store to the destination in
+// the background and switches to the destination once every blob of the
+// source is verified to be in the destination.
+//
+// architecture: Service
+type Service struct {
+	log    *zap.Logger
+	blobs  *Blobs
+	nodeID storj.NodeID
+
+	onSwitch func(ctx context.Context) error
+}
+
+// NewService creates a new storage migration service.
+func NewService(log *zap.Logger, blobs *Blobs, nodeID storj.NodeID) *Service {
+	return &Service{
+		log:    log,
+		blobs:  blobs,
+		nodeID: nodeID,
+	}
+}
+
+// OnSwitch sets the function called after the copy was verified and before
+// the blobs are switched to the destination, e.g. to persist the new storage
+// directory in the config. The blobs aren't switched when it fails.
+func (service *Service) OnSwitch(fn func(ctx context.Context) error) {
+	service.onSwitch = fn
+}
+
+// Run copies the blobs and switches to the destination. A failed migration
+// doesn't stop the node, which keeps serving the blobs from both storage
+// directories.
+func (service *Service) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if service.blobs.Switched() {
+		return nil
+	}
+
+	err = service.migrate(ctx)
+	if err != nil && !errs2.IsCanceled(err) {
+		service.log.Error("storage migration failed; the node keeps using both storage directories until it's restarted",
+			zap.Error(err))
+	}
+	return nil
+}
+
+// migrate copies the blobs and switches to the destination.
+func (service *Service) migrate(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	service.log.Info("copying blobs",
+		zap.String("From", service.blobs.SourcePath()),
+		zap.String("To", service.blobs.DestinationPath()))
+
+	stats, err := service.copyAll(ctx)
+	if err != nil {
+		return err
+	}
+	service.log.Info("copied blobs",
+		zap.Int64("Blobs", stats.Blobs),
+		zap.Stringer("Size", memory.Size(stats.Bytes)),
+		zap.Int64("Skipped", stats.Skipped))
+
+	trashed, err := service.moveTrash(ctx)
+	if err != nil {
+		return err
+	}
+	service.log.Info("moved trash", zap.Int64("Blobs", trashed))
+
+	// blobs restored from the trash of the source while copying may
+	// have been missed, so walk the source again until nothing is missing.
+	for pass := 1; ; pass++ {
+		stats, err := service.copyAll(ctx)
+		if err != nil {
+			return err
+		}
+		if stats.Blobs == 0 {
+			break
+		}
+		if pass >= maxVerifyPasses {
+			return Error.New("%d blobs were still missing from the destination after %d passes", stats.Blobs, pass)
+		}
+		service.log.Info("copied missing blobs", zap.Int("Pass", pass), zap.Int64("Blobs", stats.Blobs))
+	}
+
+	if err := service.blobs.VerifyStorageDir(ctx, service.nodeID); err != nil {
+		return Error.Wrap(err)
+	}
+
+	if service.onSwitch != nil {
+		if err := service.onSwitch(ctx); err != nil {
+			return Error.New("switching to the destination failed: %w", err)
+		}
+	}
+	service.blobs.switchToDestination()
+
+	service.log.Info("switched to the new storage directory; the blobs in the old storage directory aren't used anymore",
+		zap.String("Path", service.blobs.DestinationPath()),
+		zap.String("Old Path", service.blobs.SourcePath()))
+	return nil
+}
+
+// copyAll copies every blob of the source that isn't in the destination.
+func (service *Service) copyAll(ctx context.Context) (stats Stats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	namespaces, err := service.blobs.source.ListNamespaces(ctx)
+	if err != nil {
+		return stats, Error.Wrap(err)
+	}
+
+	for _, namespace := range namespaces {
+		err := service.blobs.source.WalkNamespace(ctx, namespace, func(info storage.BlobInfo) error {
+			copied, size, err := service.blobs.copyBlob(ctx, info)
+			if err != nil {
+				return err
+			}
+			if copied {
+				stats.Blobs++
+				stats.Bytes += size
+			} else {
+				stats.Skipped++
+			}
+			return nil
+		})
+		if err != nil {
+			return stats, Error.Wrap(err)
+		}
+	}
+	return stats, nil
+}
+
+// moveTrash moves the trash of the source to the trash of the destination.
+// The moved blobs keep the time they were trashed, so they're emptied from the
+// trash when they would have been without the migration.
+func (service *Service) moveTrash(ctx context.Context) (moved int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	namespaces, err := service.blobs.sourceDir.ListTrashNamespaces(ctx)
+	if err != nil {
+		return 0, Error.Wrap(err)
+	}
+
+	for _, namespace := range namespaces {
+		err := service.blobs.sourceDir.WalkTrash(ctx, namespace, func(info storage.BlobInfo) error {
+			ok, err := service.blobs.moveTrashedBlob(ctx, info)
+			if ok {
+				moved++
+			}
+			return err
+		})
+		if err != nil {
+			return moved, Error.Wrap(err)
+		}
+	}
+	return moved, nil
+}
+
+// Close closes the service.
+func (service *Service) Close() error { return nil }
@@ -0,0 +1,150 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package storagemigration_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/filestore"
+	"storj.io/storj/storagenode/storagemigration"
+)
+
+func writeBlob(ctx *testcontext.Context, t *testing.T, store storage.Blobs, ref storage.BlobRef, data []byte) {
+	writer, err := store.Create(ctx, ref, int64(len(data)))
+	require.NoError(t, err)
+	_, err = writer.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, writer.Commit(ctx))
+}
+
+func readBlob(ctx *testcontext.Context, t *testing.T, store storage.Blobs, ref storage.BlobRef) []byte {
+	reader, err := store.Open(ctx, ref)
+	require.NoError(t, err)
+	defer ctx.Check(reader.Close)
+
+	data, err := ioutil.ReadAll(reader)
+	require.NoError(t, err)
+	return data
+}
+
+func TestMigration(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	log := zaptest.NewLogger(t)
+	nodeID := testrand.NodeID()
+
+	sourceDir, err := filestore.NewDir(log, ctx.Dir("source"))
+	require.NoError(t, err)
+	source := filestore.New(log, sourceDir, filestore.DefaultConfig)
+	require.NoError(t, source.CreateVerificationFile(ctx, nodeID))
+
+	namespace := testrand.Bytes(32)
+	refs := make([]storage.BlobRef, 10)
+	data := make([][]byte, len(refs))
+	for i := range refs {
+		refs[i] = storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(32)}
+		data[i] = testrand.BytesInt(1000 + i)
+		writeBlob(ctx, t, source, refs[i], data[i])
+	}
+	trashed := refs[0]
+	trashedAt := time.Now().Add(-24 * time.Hour).Truncate(time.Second)
+	sourceDir.ReplaceTrashnow(func() time.Time { return trashedAt })
+	require.NoError(t, source.Trash(ctx, trashed))
+
+	destinationDir, err := filestore.NewDir(log, ctx.Dir("destination"))
+	require.NoError(t, err)
+	blobs := storagemigration.NewBlobs(log, sourceDir, destinationDir, filestore.DefaultConfig)
+	defer ctx.Check(blobs.Close)
+
+	// the blobs are served from both directories while migrating.
+	assert.Equal(t, data[1], readBlob(ctx, t, blobs, refs[1]))
+
+	written := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(32)}
+	writtenData := testrand.BytesInt(500)
+	writeBlob(ctx, t, blobs, written, writtenData)
+	_, err = source.Stat(ctx, written)
+	require.True(t, errs.IsFunc(err, os.IsNotExist))
+
+	deleted := refs[2]
+	require.NoError(t, blobs.Delete(ctx, deleted))
+
+	used, err := blobs.SpaceUsedForBlobsInNamespace(ctx, namespace)
+	require.NoError(t, err)
+	expectedUsed := int64(len(writtenData) + len(data[1]))
+	for i := 3; i < len(refs); i++ {
+		expectedUsed += int64(len(data[i]))
+	}
+
+	var walked int64
+	err = blobs.WalkNamespaceAfterPrefix(ctx, namespace, "", func(info storage.BlobInfo) error {
+		stat, err := info.Stat(ctx)
+		if err != nil {
+			return err
+		}
+		walked += stat.Size()
+		return nil
+	}, func(string) error { return nil })
+	require.NoError(t, err)
+	assert.Equal(t, expectedUsed, used)
+	assert.Equal(t, expectedUsed, walked)
+
+	service := storagemigration.NewService(log, blobs, nodeID)
+	switched := false
+	service.OnSwitch(func(ctx context.Context) error {
+		switched = true
+		return nil
+	})
+	require.NoError(t, service.Run(ctx))
+	require.True(t, switched)
+	require.True(t, blobs.Switched())
+
+	// the trash was moved without restoring it in the source.
+	_, _, err = sourceDir.StatTrash(ctx, trashed)
+	require.True(t, errs.IsFunc(err, os.IsNotExist))
+	_, err = source.Stat(ctx, trashed)
+	require.True(t, errs.IsFunc(err, os.IsNotExist))
+
+	// the blobs of the source aren't used anymore.
+	require.NoError(t, os.RemoveAll(ctx.Dir("source")))
+
+	destination := filestore.New(log, destinationDir, filestore.DefaultConfig)
+	require.NoError(t, destination.VerifyStorageDir(ctx, nodeID))
+
+	assert.Equal(t, data[1], readBlob(ctx, t, destination, refs[1]))
+	for i := 3; i < len(refs); i++ {
+		assert.Equal(t, data[i], readBlob(ctx, t, destination, refs[i]))
+	}
+	assert.Equal(t, writtenData, readBlob(ctx, t, destination, written))
+
+	_, err = destination.Stat(ctx, deleted)
+	require.True(t, errs.IsFunc(err, os.IsNotExist))
+
+	// the trash was moved to the destination, keeping when the blob was trashed.
+	_, err = destination.Stat(ctx, trashed)
+	require.True(t, errs.IsFunc(err, os.IsNotExist))
+	_, movedAt, err := destinationDir.StatTrash(ctx, trashed)
+	require.NoError(t, err)
+	assert.True(t, trashedAt.Equal(movedAt), movedAt)
+	restored, err := blobs.RestoreTrash(ctx, namespace)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{trashed.Key}, restored)
+	assert.Equal(t, data[0], readBlob(ctx, t, destination, trashed))
+
+	used, err = blobs.SpaceUsedForBlobsInNamespace(ctx, namespace)
+	require.NoError(t, err)
+	assert.Equal(t, expectedUsed+int64(len(data[0])), used)
+}
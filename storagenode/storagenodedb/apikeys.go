@@ -7,7 +7,10 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strings"
+	"time"
 
+	"github.com/mattn/go-sqlite3"
 	"github.com/zeebo/errs"
 
 	"storj.io/storj/private/multinodeauth"
@@ -34,41 +37,65 @@ func (db *apiKeysDB) Store(ctx context.Context, apiKey apikeys.APIKey) (err erro
 
 	query := `INSERT INTO secret (
 			token,
-			created_at
-		) VALUES(?,?)`
+			created_at,
+			name,
+			scope,
+			expires_at
+		) VALUES(?,?,?,?,?)`
 
 	_, err = db.ExecContext(ctx, query,
 		apiKey.Secret[:],
 		apiKey.CreatedAt,
+		apiKey.Name,
+		apiKey.Scope,
+		apiKey.ExpiresAt,
 	)
 
 	return ErrAPIKeysDB.Wrap(err)
 }
 
-// Check checks if api key exists in db by secret.
-func (db *apiKeysDB) Check(ctx context.Context, secret multinodeauth.Secret) (err error) {
+// Get returns the api key with the secret.
+func (db *apiKeysDB) Get(ctx context.Context, secret multinodeauth.Secret) (_ apikeys.APIKey, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var bytes []uint8
-	var createdAt string
-
-	rowStub := db.QueryRowContext(ctx,
-		`SELECT token, created_at FROM secret WHERE token = ?`,
+	row := db.QueryRowContext(ctx,
+		`SELECT token, created_at, name, scope, expires_at FROM secret WHERE token = ?`,
 		secret[:],
 	)
 
-	err = rowStub.Scan(
-		&bytes,
-		&createdAt,
-	)
+	apiKey, err := scanAPIKey(row.Scan)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return apikeys.ErrNoAPIKey.Wrap(err)
+			return apikeys.APIKey{}, apikeys.ErrNoAPIKey.Wrap(err)
+		}
+		return apikeys.APIKey{}, ErrAPIKeysDB.Wrap(err)
+	}
+
+	return apiKey, nil
+}
+
+// List returns all api keys ordered by creation time.
+func (db *apiKeysDB) List(ctx context.Context) (_ []apikeys.APIKey, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rows, err := db.QueryContext(ctx,
+		`SELECT token, created_at, name, scope, expires_at FROM secret ORDER BY created_at`,
+	)
+	if err != nil {
+		return nil, ErrAPIKeysDB.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var keys []apikeys.APIKey
+	for rows.Next() {
+		apiKey, err := scanAPIKey(rows.Scan)
+		if err != nil {
+			return nil, ErrAPIKeysDB.Wrap(err)
 		}
-		return ErrAPIKeysDB.Wrap(err)
+		keys = append(keys, apiKey)
 	}
 
-	return nil
+	return keys, ErrAPIKeysDB.Wrap(rows.Err())
 }
 
 // Revoke removes api key from db.
@@ -81,3 +108,43 @@ func (db *apiKeysDB) Revoke(ctx context.Context, secret multinodeauth.Secret) (e
 
 	return ErrAPIKeysDB.Wrap(err)
 }
+
+// scanAPIKey scans an api key with the scan func of a row.
+func scanAPIKey(scan func(dest ...interface{}) error) (apiKey apikeys.APIKey, err error) {
+	var token []byte
+	// created_at is declared as "timestamp with time zone", which the driver
+	// doesn't recognize as a time column.
+	var createdAt string
+	var expiresAt *time.Time
+
+	err = scan(&token, &createdAt, &apiKey.Name, &apiKey.Scope, &expiresAt)
+	if err != nil {
+		return apikeys.APIKey{}, err
+	}
+
+	apiKey.Secret, err = multinodeauth.SecretFromBytes(token)
+	if err != nil {
+		return apikeys.APIKey{}, err
+	}
+	apiKey.CreatedAt, err = parseSQLiteTimestamp(createdAt)
+	if err != nil {
+		return apikeys.APIKey{}, err
+	}
+	if expiresAt != nil {
+		expires := expiresAt.UTC()
+		apiKey.ExpiresAt = &expires
+	}
+
+	return apiKey, nil
+}
+
+// parseSQLiteTimestamp parses a timestamp the sqlite driver stored as text.
+func parseSQLiteTimestamp(value string) (time.Time, error) {
+	value = strings.TrimSuffix(value, "Z")
+	for _, format := range sqlite3.SQLiteTimestampFormats {
+		if t, err := time.ParseInLocation(format, value, time.UTC); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, errs.New("invalid timestamp %q", value)
+}
@@ -25,15 +25,19 @@ import (
 	"storj.io/storj/private/migrate"
 	"storj.io/storj/storage"
 	"storj.io/storj/storage/filestore"
+	"storj.io/storj/storage/packstore"
 	"storj.io/storj/storagenode/apikeys"
 	"storj.io/storj/storagenode/bandwidth"
+	"storj.io/storj/storagenode/maintenance"
 	"storj.io/storj/storagenode/notifications"
 	"storj.io/storj/storagenode/orders"
 	"storj.io/storj/storagenode/payouts"
 	"storj.io/storj/storagenode/pieces"
+	"storj.io/storj/storagenode/pieces/tiered"
 	"storj.io/storj/storagenode/pricing"
 	"storj.io/storj/storagenode/reputation"
 	"storj.io/storj/storagenode/satellites"
+	"storj.io/storj/storagenode/storagemigration"
 	"storj.io/storj/storagenode/storageusage"
 )
 
@@ -83,6 +87,14 @@ type Config struct {
 	Driver    string // if unset, uses sqlite3
 	Pieces    string
 	Filestore filestore.Config
+	Packstore packstore.Config
+
+	// MigrateTo is the storage directory the pieces are moved to while
+	// the node is running, if set.
+	MigrateTo string
+
+	// TieredCache configures the cache directory in front of Pieces.
+	TieredCache tiered.Config
 }
 
 // DB contains access to different database tables.
@@ -108,6 +120,7 @@ type DB struct {
 	payoutDB          *payoutDB
 	pricingDB         *pricingDB
 	apiKeysDB         *apiKeysDB
+	maintenanceDB     *maintenanceDB
 
 	SQLDBs map[string]DBContainer
 }
@@ -119,76 +132,64 @@ func OpenNew(ctx context.Context, log *zap.Logger, config Config) (*DB, error) {
 		return nil, err
 	}
 
-	pieces := filestore.New(log, piecesDir, config.Filestore)
-
-	deprecatedInfoDB := &deprecatedInfoDB{}
-	v0PieceInfoDB := &v0PieceInfoDB{}
-	bandwidthDB := &bandwidthDB{}
-	ordersDB := &ordersDB{}
-	pieceExpirationDB := &pieceExpirationDB{}
-	pieceSpaceUsedDB := &pieceSpaceUsedDB{}
-	reputationDB := &reputationDB{}
-	storageUsageDB := &storageUsageDB{}
-	usedSerialsDB := &usedSerialsDB{}
-	satellitesDB := &satellitesDB{}
-	notificationsDB := &notificationDB{}
-	payoutDB := &payoutDB{}
-	pricingDB := &pricingDB{}
-	apiKeysDB := &apiKeysDB{}
+	pieces, err := openPieces(ctx, log, piecesDir, config)
+	if err != nil {
+		return nil, err
+	}
 
-	db := &DB{
-		log:    log,
-		config: config,
+	return newDB(log, config, pieces), nil
+}
 
-		pieces: pieces,
+// OpenExisting opens an existing master database for storage node.
+func OpenExisting(ctx context.Context, log *zap.Logger, config Config) (*DB, error) {
+	piecesDir, err := filestore.OpenDir(log, config.Pieces)
+	if err != nil {
+		return nil, err
+	}
 
-		dbDirectory: filepath.Dir(config.Info2),
+	pieces, err := openPieces(ctx, log, piecesDir, config)
+	if err != nil {
+		return nil, err
+	}
 
-		deprecatedInfoDB:  deprecatedInfoDB,
-		v0PieceInfoDB:     v0PieceInfoDB,
-		bandwidthDB:       bandwidthDB,
-		ordersDB:          ordersDB,
-		pieceExpirationDB: pieceExpirationDB,
-		pieceSpaceUsedDB:  pieceSpaceUsedDB,
-		reputationDB:      reputationDB,
-		storageUsageDB:    storageUsageDB,
-		usedSerialsDB:     usedSerialsDB,
-		satellitesDB:      satellitesDB,
-		notificationsDB:   notificationsDB,
-		payoutDB:          payoutDB,
-		pricingDB:         pricingDB,
-		apiKeysDB:         apiKeysDB,
+	db := newDB(log, config, pieces)
 
-		SQLDBs: map[string]DBContainer{
-			DeprecatedInfoDBName:  deprecatedInfoDB,
-			PieceInfoDBName:       v0PieceInfoDB,
-			BandwidthDBName:       bandwidthDB,
-			OrdersDBName:          ordersDB,
-			PieceExpirationDBName: pieceExpirationDB,
-			PieceSpaceUsedDBName:  pieceSpaceUsedDB,
-			ReputationDBName:      reputationDB,
-			StorageUsageDBName:    storageUsageDB,
-			UsedSerialsDBName:     usedSerialsDB,
-			SatellitesDBName:      satellitesDB,
-			NotificationsDBName:   notificationsDB,
-			HeldAmountDBName:      payoutDB,
-			PricingDBName:         pricingDB,
-			APIKeysDBName:         apiKeysDB,
-		},
+	err = db.openDatabases(ctx)
+	if err != nil {
+		return nil, err
 	}
 
 	return db, nil
 }
 
-// OpenExisting opens an existing master database for storage node.
-func OpenExisting(ctx context.Context, log *zap.Logger, config Config) (*DB, error) {
+// OpenForRepair opens the existing storage node databases like OpenExisting,
+// but databases that can't be opened are left closed instead of failing, so
+// that they can be checked and repaired.
+func OpenForRepair(ctx context.Context, log *zap.Logger, config Config) (*DB, error) {
 	piecesDir, err := filestore.OpenDir(log, config.Pieces)
 	if err != nil {
 		return nil, err
 	}
 
-	pieces := filestore.New(log, piecesDir, config.Filestore)
+	pieces, err := openPieces(ctx, log, piecesDir, config)
+	if err != nil {
+		return nil, err
+	}
 
+	db := newDB(log, config, pieces)
+
+	for _, dbName := range DatabaseNames() {
+		if err := db.openExistingDatabase(ctx, dbName); err != nil {
+			log.Warn("database can't be opened", zap.String("database", dbName), zap.Error(err))
+		}
+	}
+
+	return db, nil
+}
+
+// newDB creates the storage node database for the databases in the
+// directory of config.Info2, without opening them.
+func newDB(log *zap.Logger, config Config, pieces storage.Blobs) *DB {
 	deprecatedInfoDB := &deprecatedInfoDB{}
 	v0PieceInfoDB := &v0PieceInfoDB{}
 	bandwidthDB := &bandwidthDB{}
@@ -203,6 +204,7 @@ func OpenExisting(ctx context.Context, log *zap.Logger, config Config) (*DB, err
 	payoutDB := &payoutDB{}
 	pricingDB := &pricingDB{}
 	apiKeysDB := &apiKeysDB{}
+	maintenanceDB := &maintenanceDB{}
 
 	db := &DB{
 		log:    log,
@@ -226,6 +228,7 @@ func OpenExisting(ctx context.Context, log *zap.Logger, config Config) (*DB, err
 		payoutDB:          payoutDB,
 		pricingDB:         pricingDB,
 		apiKeysDB:         apiKeysDB,
+		maintenanceDB:     maintenanceDB,
 
 		SQLDBs: map[string]DBContainer{
 			DeprecatedInfoDBName:  deprecatedInfoDB,
@@ -242,25 +245,16 @@ func OpenExisting(ctx context.Context, log *zap.Logger, config Config) (*DB, err
 			HeldAmountDBName:      payoutDB,
 			PricingDBName:         pricingDB,
 			APIKeysDBName:         apiKeysDB,
+			MaintenanceDBName:     maintenanceDB,
 		},
 	}
 
-	err = db.openDatabases(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	return db, nil
+	return db
 }
 
-// openDatabases opens all the SQLite3 storage node databases and returns if any fails to open successfully.
-func (db *DB) openDatabases(ctx context.Context) error {
-	// These objects have a Configure method to allow setting the underlining SQLDB connection
-	// that each uses internally to do data access to the SQLite3 databases.
-	// The reason it was done this way was because there's some outside consumers that are
-	// taking a reference to the business object.
-
-	dbs := []string{
+// DatabaseNames returns the names of all the SQLite3 storage node databases.
+func DatabaseNames() []string {
+	return []string{
 		DeprecatedInfoDBName,
 		BandwidthDBName,
 		OrdersDBName,
@@ -275,9 +269,18 @@ func (db *DB) openDatabases(ctx context.Context) error {
 		HeldAmountDBName,
 		PricingDBName,
 		APIKeysDBName,
+		MaintenanceDBName,
 	}
+}
+
+// openDatabases opens all the SQLite3 storage node databases and returns if any fails to open successfully.
+func (db *DB) openDatabases(ctx context.Context) error {
+	// These objects have a Configure method to allow setting the underlining SQLDB connection
+	// that each uses internally to do data access to the SQLite3 databases.
+	// The reason it was done this way was because there's some outside consumers that are
+	// taking a reference to the business object.
 
-	for _, dbName := range dbs {
+	for _, dbName := range DatabaseNames() {
 		err := db.openExistingDatabase(ctx, dbName)
 		if err != nil {
 			return errs.Combine(err, db.closeDatabases())
@@ -360,7 +363,62 @@ func (db *DB) Preflight(ctx context.Context) (err error) {
 func (db *DB) preflight(ctx context.Context, dbName string, dbContainer DBContainer) error {
 	nextDB := dbContainer.GetDB()
 	// Preflight stage 1: test schema correctness
-	schema, err := sqliteutil.QuerySchema(ctx, nextDB)
+	if err := db.checkSchema(ctx, dbName, nextDB); err != nil {
+		return err
+	}
+
+	// Preflight stage 2: test basic read/write access
+	// for each database, create a new table, insert a row into that table, retrieve and validate that row, and drop the table.
+
+	// drop test table in case the last preflight check failed before table could be dropped
+	_, err := nextDB.ExecContext(ctx, "DROP TABLE IF EXISTS test_table")
+	if err != nil {
+		return ErrPreflight.New("database %q: failed drop if test_table: %w", dbName, err)
+	}
+	_, err = nextDB.ExecContext(ctx, "CREATE TABLE test_table(id int NOT NULL, name varchar(30), PRIMARY KEY (id))")
+	if err != nil {
+		return ErrPreflight.New("database %q: failed create test_table: %w", dbName, err)
+	}
+
+	var expectedID, actualID int
+	var expectedName, actualName string
+	expectedID = 1
+	expectedName = "TEST"
+	_, err = nextDB.ExecContext(ctx, "INSERT INTO test_table VALUES ( ?, ? )", expectedID, expectedName)
+	if err != nil {
+		return ErrPreflight.New("database: %q: failed inserting test value: %w", dbName, err)
+	}
+
+	rows, err := nextDB.QueryContext(ctx, "SELECT id, name FROM test_table")
+	if err != nil {
+		return ErrPreflight.New("database: %q: failed selecting test value: %w", dbName, err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+	if !rows.Next() {
+		return ErrPreflight.New("database %q: no rows in test_table", dbName)
+	}
+	err = rows.Scan(&actualID, &actualName)
+	if err != nil {
+		return ErrPreflight.New("database %q: failed scanning row: %w", dbName, err)
+	}
+	if expectedID != actualID || expectedName != actualName {
+		return ErrPreflight.New("database %q: expected (%d, '%s'), actual (%d, '%s')", dbName, expectedID, expectedName, actualID, actualName)
+	}
+	if rows.Next() {
+		return ErrPreflight.New("database %q: more than one row in test_table", dbName)
+	}
+
+	_, err = nextDB.ExecContext(ctx, "DROP TABLE test_table")
+	if err != nil {
+		return ErrPreflight.New("database %q: failed drop test_table %w", dbName, err)
+	}
+
+	return nil
+}
+
+// checkSchema compares the schema of the database with the expected schema.
+func (db *DB) checkSchema(ctx context.Context, dbName string, sqlDB tagsql.DB) error {
+	schema, err := sqliteutil.QuerySchema(ctx, sqlDB)
 	if err != nil {
 		return ErrPreflight.New("database %q: schema check failed: %v", dbName, err)
 	}
@@ -406,58 +464,54 @@ func (db *DB) preflight(ctx context.Context, dbName string, dbContainer DBContai
 		return ErrPreflight.New("database %q: expected schema does not match actual: %s", dbName, diff)
 	}
 
-	// Preflight stage 2: test basic read/write access
-	// for each database, create a new table, insert a row into that table, retrieve and validate that row, and drop the table.
+	return nil
+}
 
-	// drop test table in case the last preflight check failed before table could be dropped
-	_, err = nextDB.ExecContext(ctx, "DROP TABLE IF EXISTS test_table")
-	if err != nil {
-		return ErrPreflight.New("database %q: failed drop if test_table: %w", dbName, err)
-	}
-	_, err = nextDB.ExecContext(ctx, "CREATE TABLE test_table(id int NOT NULL, name varchar(30), PRIMARY KEY (id))")
-	if err != nil {
-		return ErrPreflight.New("database %q: failed create test_table: %w", dbName, err)
+// openPieces opens the blob storage for pieces selected by the config.
+func openPieces(ctx context.Context, log *zap.Logger, dir *filestore.Dir, config Config) (storage.Blobs, error) {
+	if config.TieredCache.Path != "" {
+		if config.Packstore.Enabled || config.MigrateTo != "" {
+			return nil, ErrDatabase.New("the cache tier can't be used with packed storage or while migrating to another storage directory")
+		}
+		cacheDir, err := filestore.NewDir(log, config.TieredCache.Path)
+		if err != nil {
+			return nil, err
+		}
+		return tiered.NewBlobs(log.Named("tiered"), dir, cacheDir, config.Filestore, config.TieredCache), nil
 	}
 
-	var expectedID, actualID int
-	var expectedName, actualName string
-	expectedID = 1
-	expectedName = "TEST"
-	_, err = nextDB.ExecContext(ctx, "INSERT INTO test_table VALUES ( ?, ? )", expectedID, expectedName)
-	if err != nil {
-		return ErrPreflight.New("database: %q: failed inserting test value: %w", dbName, err)
+	if config.MigrateTo != "" {
+		if config.Packstore.Enabled {
+			return nil, ErrDatabase.New("pieces in packed storage can't be migrated to another storage directory")
+		}
+		destination, err := filestore.NewDir(log, config.MigrateTo)
+		if err != nil {
+			return nil, err
+		}
+		return storagemigration.NewBlobs(log, dir, destination, config.Filestore), nil
 	}
 
-	rows, err := nextDB.QueryContext(ctx, "SELECT id, name FROM test_table")
-	if err != nil {
-		return ErrPreflight.New("database: %q: failed selecting test value: %w", dbName, err)
-	}
-	defer func() { err = errs.Combine(err, rows.Close()) }()
-	if !rows.Next() {
-		return ErrPreflight.New("database %q: no rows in test_table", dbName)
-	}
-	err = rows.Scan(&actualID, &actualName)
-	if err != nil {
-		return ErrPreflight.New("database %q: failed scanning row: %w", dbName, err)
-	}
-	if expectedID != actualID || expectedName != actualName {
-		return ErrPreflight.New("database %q: expected (%d, '%s'), actual (%d, '%s')", dbName, expectedID, expectedName, actualID, actualName)
-	}
-	if rows.Next() {
-		return ErrPreflight.New("database %q: more than one row in test_table", dbName)
+	filePieces := filestore.New(log, dir, config.Filestore)
+	if !config.Packstore.Enabled {
+		return filePieces, nil
 	}
 
-	_, err = nextDB.ExecContext(ctx, "DROP TABLE test_table")
+	// the pieces stored one file per piece can't be read from the packed
+	// storage, so the node would fail their audits and downloads.
+	namespaces, err := filePieces.ListNamespaces(ctx)
 	if err != nil {
-		return ErrPreflight.New("database %q: failed drop test_table %w", dbName, err)
+		return nil, err
+	}
+	if len(namespaces) > 0 {
+		return nil, ErrDatabase.New("pieces of %d satellites are stored one file per piece, which the packed storage can't read; run `storagenode migrate-pieces` with the node stopped before enabling the packed storage", len(namespaces))
 	}
 
-	return nil
+	return packstore.New(log, dir, config.Packstore)
 }
 
 // Close closes any resources.
 func (db *DB) Close() error {
-	return db.closeDatabases()
+	return errs.Combine(db.closeDatabases(), db.pieces.Close())
 }
 
 // closeDatabases closes all the SQLite database connections and removes them from the associated maps.
@@ -554,6 +608,11 @@ func (db *DB) APIKeys() apikeys.DB {
 	return db.apiKeysDB
 }
 
+// Maintenance returns instance of the Maintenance database.
+func (db *DB) Maintenance() maintenance.DB {
+	return db.maintenanceDB
+}
+
 // RawDatabases are required for testing purposes.
 func (db *DB) RawDatabases() map[string]DBContainer {
 	return db.SQLDBs
@@ -2002,6 +2061,56 @@ func (db *DB) Migration(ctx context.Context) *migrate.Migration {
 					 UPDATE satellites SET address = 'satellite.stefan-benten.de:7777' WHERE node_id = X'004ae89e970e703df42ba4ab1416a3b30b7e1d8e14aa0e558f7ee26800000000'`,
 				},
 			},
+			{
+				DB:          &db.pieceSpaceUsedDB.DB,
+				Description: "Add used_space_walk_progress table to resume the used-space walk",
+				Version:     54,
+				Action: migrate.SQL{
+					`CREATE TABLE used_space_walk_progress (
+						satellite_id BLOB NOT NULL,
+						last_prefix TEXT NOT NULL,
+						total INTEGER NOT NULL,
+						content_size INTEGER NOT NULL,
+						total_at_start INTEGER NOT NULL,
+						content_size_at_start INTEGER NOT NULL,
+						started_at TIMESTAMP NOT NULL,
+						updated_at TIMESTAMP NOT NULL,
+						completed_at TIMESTAMP,
+						PRIMARY KEY (satellite_id)
+					)`,
+				},
+			},
+			{
+				DB:          &db.maintenanceDB.DB,
+				Description: "Create maintenance_window table",
+				Version:     55,
+				CreateDB: func(ctx context.Context, log *zap.Logger) error {
+					if err := db.openDatabase(ctx, MaintenanceDBName); err != nil {
+						return ErrDatabase.Wrap(err)
+					}
+
+					return nil
+				},
+				Action: migrate.SQL{
+					`CREATE TABLE maintenance_window (
+						id INTEGER NOT NULL,
+						start_time TIMESTAMP NOT NULL,
+						end_time TIMESTAMP NOT NULL,
+						created_at TIMESTAMP NOT NULL,
+						PRIMARY KEY (id)
+					)`,
+				},
+			},
+			{
+				DB:          &db.apiKeysDB.DB,
+				Description: "Add name, scope and expires_at to secret table",
+				Version:     56,
+				Action: migrate.SQL{
+					`ALTER TABLE secret ADD COLUMN name TEXT NOT NULL DEFAULT ''`,
+					`ALTER TABLE secret ADD COLUMN scope INTEGER NOT NULL DEFAULT 2`,
+					`ALTER TABLE secret ADD COLUMN expires_at TIMESTAMP`,
+				},
+			},
 		},
 	}
 }
@@ -0,0 +1,536 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package storagenodedb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/private/dbutil/dbschema"
+	"storj.io/private/tagsql"
+)
+
+// ErrRepair is the error class for checking and repairing the databases.
+var ErrRepair = errs.Class("repair")
+
+// criticalDatabases are the databases holding data that can't be recovered
+// from the satellites or the pieces, so they can only be repaired.
+var criticalDatabases = map[string]string{
+	OrdersDBName:     "unsent orders are needed to get paid for the bandwidth",
+	PieceInfoDBName:  "the metadata of pieces uploaded before piece headers is needed to serve them",
+	SatellitesDBName: "the trusted satellites and the graceful exit progress are stored there",
+	APIKeysDBName:    "the multinode API key is stored there",
+}
+
+// CriticalDatabase returns why the database can't be recreated from scratch.
+func CriticalDatabase(dbName string) (reason string, critical bool) {
+	reason, critical = criticalDatabases[dbName]
+	return reason, critical
+}
+
+// HealthReport is the result of checking a database.
+type HealthReport struct {
+	Database string
+	Path     string
+	Critical bool
+	Missing  bool
+	Problems []string
+}
+
+// Healthy returns true when no problems were found.
+func (report *HealthReport) Healthy() bool {
+	return !report.Missing && len(report.Problems) == 0
+}
+
+// RepairReport is the result of repairing or recreating a database.
+type RepairReport struct {
+	Database   string
+	BackupPath string
+	Tables     []TableRepairReport
+}
+
+// Lost returns the number of rows lost in all tables, and whether the
+// number is known for every table.
+func (report *RepairReport) Lost() (lost int64, known bool) {
+	known = true
+	for _, table := range report.Tables {
+		if table.Lost < 0 {
+			known = false
+			continue
+		}
+		lost += table.Lost
+	}
+	return lost, known
+}
+
+// TableRepairReport is the result of copying the rows of a table.
+type TableRepairReport struct {
+	Table     string
+	Recovered int64
+	// Lost is -1 when the rows of the damaged table couldn't be counted.
+	Lost   int64
+	Errors []string
+}
+
+// maxReportedErrors limits the distinct errors reported per table.
+const maxReportedErrors = 5
+
+// maxRepairQueries limits how many queries are used to narrow down the
+// damaged rows of a table.
+const maxRepairQueries = 100000
+
+// CheckDatabase runs an integrity check and a schema check on the database.
+func (db *DB) CheckDatabase(ctx context.Context, dbName string) (report HealthReport, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	dbContainer, ok := db.SQLDBs[dbName]
+	if !ok {
+		return report, ErrRepair.New("unknown database %q", dbName)
+	}
+
+	_, critical := CriticalDatabase(dbName)
+	report = HealthReport{
+		Database: dbName,
+		Path:     db.filepathFromDBName(dbName),
+		Critical: critical,
+	}
+
+	if _, err := os.Stat(report.Path); err != nil {
+		if os.IsNotExist(err) {
+			report.Missing = true
+			return report, nil
+		}
+		return report, ErrRepair.Wrap(err)
+	}
+
+	if dbContainer.GetDB() == nil {
+		// OpenForRepair leaves the databases that can't be opened closed.
+		if err := db.openDatabase(ctx, dbName); err != nil {
+			report.Problems = append(report.Problems, err.Error())
+			return report, nil
+		}
+	}
+	sqlDB := dbContainer.GetDB()
+
+	problems, err := integrityCheck(ctx, sqlDB)
+	if err != nil {
+		report.Problems = append(report.Problems, err.Error())
+		// the schema can't be read from a database that can't be opened.
+		return report, nil
+	}
+	report.Problems = append(report.Problems, problems...)
+
+	if err := db.checkSchema(ctx, dbName, sqlDB); err != nil {
+		report.Problems = append(report.Problems, err.Error())
+	}
+
+	return report, nil
+}
+
+// integrityCheck returns the problems found by SQLite's integrity check.
+func integrityCheck(ctx context.Context, sqlDB tagsql.DB) (problems []string, err error) {
+	rows, err := sqlDB.QueryContext(ctx, "PRAGMA integrity_check")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	for rows.Next() {
+		var result string
+		if err := rows.Scan(&result); err != nil {
+			return nil, err
+		}
+		if result != "ok" {
+			problems = append(problems, result)
+		}
+	}
+	return problems, rows.Err()
+}
+
+// BackupDatabases copies the files of every database into backupDir. The
+// storage node must not be running.
+func (db *DB) BackupDatabases(ctx context.Context, backupDir string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	for _, dbName := range DatabaseNames() {
+		if err := db.backupDatabase(dbName, backupDir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// databaseFiles returns the paths of the database file and of its journal files.
+func (db *DB) databaseFiles(dbName string) []string {
+	path := db.filepathFromDBName(dbName)
+	return []string{path, path + "-wal", path + "-shm"}
+}
+
+// backupDatabase copies the existing files of the database into backupDir.
+func (db *DB) backupDatabase(dbName string, backupDir string) error {
+	if err := os.MkdirAll(backupDir, 0700); err != nil {
+		return ErrRepair.Wrap(err)
+	}
+
+	for _, path := range db.databaseFiles(dbName) {
+		err := copyFile(path, filepath.Join(backupDir, filepath.Base(path)))
+		if err != nil && !os.IsNotExist(err) {
+			return ErrRepair.New("backup of %s failed: %w", dbName, err)
+		}
+	}
+	return nil
+}
+
+// copyFile copies the file at src to dst, overwriting dst.
+func copyFile(src, dst string) (err error) {
+	source, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, source.Close()) }()
+
+	target, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(target, source); err != nil {
+		return errs.Combine(err, target.Close())
+	}
+	return errs.Combine(target.Sync(), target.Close())
+}
+
+// RepairDatabase backs up the database into backupDir and rebuilds it with
+// the latest schema, copying every row that can still be read from the
+// damaged database. The storage node must not be running.
+func (db *DB) RepairDatabase(ctx context.Context, dbName string, backupDir string) (report RepairReport, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return db.rebuildDatabase(ctx, dbName, backupDir, true)
+}
+
+// RecreateDatabase backs up the database into backupDir and replaces it with
+// an empty database with the latest schema. Critical databases can't be
+// recreated. The storage node must not be running.
+func (db *DB) RecreateDatabase(ctx context.Context, dbName string, backupDir string) (report RepairReport, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if reason, critical := CriticalDatabase(dbName); critical {
+		return report, ErrRepair.New("database %q can't be recreated: %s", dbName, reason)
+	}
+
+	return db.rebuildDatabase(ctx, dbName, backupDir, false)
+}
+
+// rebuildDatabase replaces the database with a fresh one, optionally
+// copying the rows of the damaged database.
+func (db *DB) rebuildDatabase(ctx context.Context, dbName string, backupDir string, copyRows bool) (report RepairReport, err error) {
+	if _, ok := db.SQLDBs[dbName]; !ok {
+		return report, ErrRepair.New("unknown database %q", dbName)
+	}
+
+	report = RepairReport{
+		Database:   dbName,
+		BackupPath: filepath.Join(backupDir, db.filenameFromDBName(dbName)),
+	}
+
+	if err := db.closeDatabase(dbName); err != nil {
+		return report, ErrRepair.Wrap(err)
+	}
+	if err := db.backupDatabase(dbName, backupDir); err != nil {
+		return report, err
+	}
+
+	tempDir, err := ioutil.TempDir(db.dbDirectory, "rebuild-"+dbName+"-")
+	if err != nil {
+		return report, ErrRepair.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, ErrRepair.Wrap(os.RemoveAll(tempDir))) }()
+
+	freshPath, err := db.createFreshDatabase(ctx, dbName, tempDir)
+	if err != nil {
+		return report, err
+	}
+
+	damagedPath := db.filepathFromDBName(dbName)
+	report.Tables, err = db.copyDatabaseRows(ctx, dbName, freshPath, damagedPath, copyRows)
+	if err != nil {
+		return report, err
+	}
+
+	for _, path := range db.databaseFiles(dbName) {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return report, ErrRepair.Wrap(err)
+		}
+	}
+	if err := os.Rename(freshPath, damagedPath); err != nil {
+		return report, ErrRepair.Wrap(err)
+	}
+
+	if err := db.openDatabase(ctx, dbName); err != nil {
+		return report, ErrRepair.Wrap(err)
+	}
+
+	return report, nil
+}
+
+// createFreshDatabase creates all databases with the latest schema in dir
+// and returns the path of the requested one.
+func (db *DB) createFreshDatabase(ctx context.Context, dbName string, dir string) (_ string, err error) {
+	fresh, err := OpenNew(ctx, db.log.Named("rebuild"), Config{
+		Storage: dir,
+		Info:    filepath.Join(dir, "piecestore.db"),
+		Info2:   filepath.Join(dir, "info.db"),
+		Driver:  db.config.Driver,
+		Pieces:  dir,
+	})
+	if err != nil {
+		return "", ErrRepair.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, ErrRepair.Wrap(fresh.Close())) }()
+
+	if err := fresh.MigrateToLatest(ctx); err != nil {
+		return "", ErrRepair.Wrap(err)
+	}
+	if err := fresh.checkSchema(ctx, dbName, fresh.SQLDBs[dbName].GetDB()); err != nil {
+		return "", ErrRepair.Wrap(err)
+	}
+
+	return fresh.filepathFromDBName(dbName), nil
+}
+
+// copyDatabaseRows copies the rows of every table of the damaged database
+// into the fresh database. When copyRows is false, the rows are only counted
+// to report them as lost.
+func (db *DB) copyDatabaseRows(ctx context.Context, dbName, freshPath, damagedPath string, copyRows bool) (tables []TableRepairReport, err error) {
+	driver := db.config.Driver
+	if driver == "" {
+		driver = "sqlite3"
+	}
+
+	fresh, err := tagsql.Open(ctx, driver, "file:"+freshPath+"?_journal=WAL&_busy_timeout=10000")
+	if err != nil {
+		return nil, ErrRepair.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, ErrRepair.Wrap(fresh.Close())) }()
+
+	// the damaged database is attached to the connection, so there must
+	// be only one.
+	fresh.SetMaxOpenConns(1)
+
+	if _, err := os.Stat(damagedPath); err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, ErrRepair.Wrap(err)
+	}
+
+	if _, err := fresh.ExecContext(ctx, "ATTACH DATABASE ? AS damaged", damagedPath); err != nil {
+		// nothing can be read from a database that can't be attached.
+		for _, table := range Schema()[dbName].Tables {
+			tables = append(tables, TableRepairReport{Table: table.Name, Lost: -1, Errors: []string{err.Error()}})
+		}
+		return tables, nil
+	}
+	defer func() {
+		_, detachErr := fresh.ExecContext(ctx, "DETACH DATABASE damaged")
+		err = errs.Combine(err, ErrRepair.Wrap(detachErr))
+	}()
+
+	// the columns of the damaged tables may differ from the latest schema.
+	damagedColumns := map[string]map[string]bool{}
+	damagedSchema, schemaErr := queryAttachedSchema(ctx, fresh)
+	if schemaErr == nil {
+		for _, table := range damagedSchema.Tables {
+			columns := map[string]bool{}
+			for _, column := range table.Columns {
+				columns[column.Name] = true
+			}
+			damagedColumns[table.Name] = columns
+		}
+	}
+
+	for _, table := range Schema()[dbName].Tables {
+		tableReport := TableRepairReport{Table: table.Name, Lost: -1}
+
+		var columns []string
+		for _, column := range table.Columns {
+			if schemaErr == nil && !damagedColumns[table.Name][column.Name] {
+				continue
+			}
+			columns = append(columns, column.Name)
+		}
+		if schemaErr == nil && damagedColumns[table.Name] == nil {
+			// the table didn't exist, so nothing was lost.
+			tables = append(tables, TableRepairReport{Table: table.Name})
+			continue
+		}
+
+		var count int64
+		if err := fresh.QueryRowContext(ctx, "SELECT COUNT(*) FROM damaged."+table.Name).Scan(&count); err != nil {
+			tableReport.addError(err)
+		} else {
+			tableReport.Lost = count
+		}
+
+		if copyRows && len(columns) > 0 {
+			copier := &rowCopier{db: fresh, table: table.Name, columns: strings.Join(columns, ", "), report: &tableReport}
+			if err := copier.copyAll(ctx); err != nil {
+				return nil, err
+			}
+			if tableReport.Lost >= 0 {
+				tableReport.Lost -= tableReport.Recovered
+				if tableReport.Lost < 0 {
+					tableReport.Lost = 0
+				}
+			}
+		}
+
+		tables = append(tables, tableReport)
+	}
+
+	return tables, nil
+}
+
+// queryAttachedSchema reads the schema of the attached damaged database.
+func queryAttachedSchema(ctx context.Context, sqlDB tagsql.DB) (_ *dbschema.Schema, err error) {
+	rows, err := sqlDB.QueryContext(ctx, "SELECT name FROM damaged.sqlite_master WHERE type = 'table'")
+	if err != nil {
+		return nil, err
+	}
+	var tableNames []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, errs.Combine(err, rows.Close())
+		}
+		tableNames = append(tableNames, name)
+	}
+	if err := errs.Combine(rows.Err(), rows.Close()); err != nil {
+		return nil, err
+	}
+
+	schema := &dbschema.Schema{}
+	for _, tableName := range tableNames {
+		table := schema.EnsureTable(tableName)
+		err := func() (err error) {
+			rows, err := sqlDB.QueryContext(ctx, "SELECT name FROM pragma_table_info(?, 'damaged')", tableName)
+			if err != nil {
+				return err
+			}
+			defer func() { err = errs.Combine(err, rows.Close()) }()
+
+			for rows.Next() {
+				var name string
+				if err := rows.Scan(&name); err != nil {
+					return err
+				}
+				table.AddColumn(&dbschema.Column{Name: name})
+			}
+			return rows.Err()
+		}()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return schema, nil
+}
+
+// rowCopier copies the readable rows of a damaged table, narrowing down the
+// rowid ranges that fail to copy until only the damaged rows are skipped.
+type rowCopier struct {
+	db      tagsql.DB
+	table   string
+	columns string
+	report  *TableRepairReport
+	queries int
+}
+
+// copyAll copies the rows of the whole table.
+func (copier *rowCopier) copyAll(ctx context.Context) error {
+	// the first and the last rowid are found without reading the damaged
+	// pages in between.
+	first, firstErr := copier.edgeRowID(ctx, "ASC")
+	last, lastErr := copier.edgeRowID(ctx, "DESC")
+	if errors.Is(firstErr, sql.ErrNoRows) || errors.Is(lastErr, sql.ErrNoRows) {
+		return nil
+	}
+	if firstErr != nil {
+		copier.report.addError(firstErr)
+		first = math.MinInt64
+	}
+	if lastErr != nil {
+		copier.report.addError(lastErr)
+		last = math.MaxInt64
+	}
+	return copier.copyRange(ctx, first, last)
+}
+
+// edgeRowID returns the first or the last rowid of the damaged table.
+func (copier *rowCopier) edgeRowID(ctx context.Context, order string) (rowID int64, err error) {
+	err = copier.db.QueryRowContext(ctx, "SELECT rowid FROM damaged."+copier.table+" ORDER BY rowid "+order+" LIMIT 1").Scan(&rowID)
+	return rowID, err
+}
+
+// copyRange copies the rows with rowids between first and last.
+func (copier *rowCopier) copyRange(ctx context.Context, first, last int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if copier.queries >= maxRepairQueries {
+		copier.report.addError(ErrRepair.New("too many damaged rows, skipping rowids %d to %d", first, last))
+		return nil
+	}
+	copier.queries++
+
+	result, err := copier.db.ExecContext(ctx, `
+		INSERT INTO main.`+copier.table+` (`+copier.columns+`)
+		SELECT `+copier.columns+` FROM damaged.`+copier.table+`
+		WHERE rowid BETWEEN ? AND ? ORDER BY rowid`, first, last)
+	if err == nil {
+		copied, err := result.RowsAffected()
+		if err != nil {
+			return ErrRepair.Wrap(err)
+		}
+		copier.report.Recovered += copied
+		return nil
+	}
+
+	if first == last {
+		copier.report.addError(err)
+		return nil
+	}
+
+	// avoids overflowing when the whole rowid range is copied.
+	middle := first/2 + last/2
+	if middle >= last {
+		middle = last - 1
+	}
+	if err := copier.copyRange(ctx, first, middle); err != nil {
+		return err
+	}
+	return copier.copyRange(ctx, middle+1, last)
+}
+
+// addError adds the error to the report, unless it's already reported.
+func (report *TableRepairReport) addError(err error) {
+	message := err.Error()
+	if len(report.Errors) >= maxReportedErrors {
+		return
+	}
+	for _, reported := range report.Errors {
+		if reported == message {
+			return
+		}
+	}
+	report.Errors = append(report.Errors, message)
+}
@@ -0,0 +1,111 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package storagenodedb_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storagenode/storagenodedb"
+)
+
+func TestRepairDatabases(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	log := zaptest.NewLogger(t)
+	storageDir := ctx.Dir("storage")
+	cfg := storagenodedb.Config{
+		Storage: storageDir,
+		Info:    filepath.Join(storageDir, "piecestore.db"),
+		Info2:   filepath.Join(storageDir, "info.db"),
+		Pieces:  storageDir,
+	}
+
+	db, err := storagenodedb.OpenNew(ctx, log, cfg)
+	require.NoError(t, err)
+	require.NoError(t, db.MigrateToLatest(ctx))
+
+	satelliteID := testrand.NodeID()
+	for i := 0; i < 10; i++ {
+		require.NoError(t, db.Bandwidth().Add(ctx, satelliteID, 1, int64(i), time.Now()))
+	}
+	require.NoError(t, db.Close())
+
+	// a database with an unexpected column and a database that isn't
+	// a database anymore.
+	db, err = storagenodedb.OpenExisting(ctx, log, cfg)
+	require.NoError(t, err)
+
+	_, err = db.RawDatabases()[storagenodedb.BandwidthDBName].GetDB().ExecContext(ctx, "ALTER TABLE bandwidth_usage ADD COLUMN extra INTEGER")
+	require.NoError(t, err)
+
+	require.NoError(t, db.Close())
+	notificationsPath := filepath.Join(storageDir, storagenodedb.NotificationsDBName+".db")
+	require.NoError(t, ioutil.WriteFile(notificationsPath, testrand.BytesInt(4096), 0600))
+
+	_, err = storagenodedb.OpenExisting(ctx, log, cfg)
+	require.Error(t, err)
+
+	db, err = storagenodedb.OpenForRepair(ctx, log, cfg)
+	require.NoError(t, err)
+	defer ctx.Check(db.Close)
+
+	for _, dbName := range storagenodedb.DatabaseNames() {
+		report, err := db.CheckDatabase(ctx, dbName)
+		require.NoError(t, err)
+
+		switch dbName {
+		case storagenodedb.BandwidthDBName, storagenodedb.NotificationsDBName:
+			assert.False(t, report.Healthy(), dbName)
+		default:
+			assert.True(t, report.Healthy(), "%s: %v", dbName, report.Problems)
+		}
+	}
+
+	backupDir := ctx.Dir("backup")
+
+	// critical databases can only be repaired.
+	_, err = db.RecreateDatabase(ctx, storagenodedb.OrdersDBName, backupDir)
+	require.Error(t, err)
+
+	repair, err := db.RepairDatabase(ctx, storagenodedb.BandwidthDBName, backupDir)
+	require.NoError(t, err)
+	lost, known := repair.Lost()
+	assert.True(t, known)
+	assert.Zero(t, lost)
+	for _, table := range repair.Tables {
+		if table.Table == "bandwidth_usage" {
+			assert.EqualValues(t, 10, table.Recovered)
+		}
+	}
+	_, err = os.Stat(repair.BackupPath)
+	require.NoError(t, err)
+
+	recreate, err := db.RecreateDatabase(ctx, storagenodedb.NotificationsDBName, backupDir)
+	require.NoError(t, err)
+	_, known = recreate.Lost()
+	assert.False(t, known)
+
+	for _, dbName := range []string{storagenodedb.BandwidthDBName, storagenodedb.NotificationsDBName} {
+		report, err := db.CheckDatabase(ctx, dbName)
+		require.NoError(t, err)
+		assert.True(t, report.Healthy(), "%s: %v", dbName, report.Problems)
+	}
+
+	summary, err := db.Bandwidth().Summary(ctx, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.EqualValues(t, 45, summary.Total())
+
+	require.NoError(t, db.Preflight(ctx))
+}
@@ -0,0 +1,69 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package storagenodedb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/private/nodemaintenance"
+	"storj.io/storj/storagenode/maintenance"
+)
+
+// ensures that maintenanceDB implements maintenance.DB interface.
+var _ maintenance.DB = (*maintenanceDB)(nil)
+
+// ErrMaintenanceDB represents errors from the maintenance database.
+var ErrMaintenanceDB = errs.Class("maintenancedb")
+
+// MaintenanceDBName represents the database name.
+const MaintenanceDBName = "maintenance"
+
+// maintenanceDB stores the planned maintenance window in a table with a
+// single row.
+//
+// architecture: Database
+type maintenanceDB struct {
+	dbContainerImpl
+}
+
+// Get returns the planned maintenance window or a zero window.
+func (db *maintenanceDB) Get(ctx context.Context) (window nodemaintenance.Window, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	row := db.QueryRowContext(ctx, `SELECT start_time, end_time FROM maintenance_window WHERE id = 1`)
+	err = row.Scan(&window.Start, &window.End)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nodemaintenance.Window{}, nil
+	}
+	if err != nil {
+		return nodemaintenance.Window{}, ErrMaintenanceDB.Wrap(err)
+	}
+
+	window.Start, window.End = window.Start.UTC(), window.End.UTC()
+	return window, nil
+}
+
+// Set replaces the planned maintenance window.
+func (db *maintenanceDB) Set(ctx context.Context, window nodemaintenance.Window) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = db.ExecContext(ctx, `
+		INSERT OR REPLACE INTO maintenance_window (id, start_time, end_time, created_at)
+		VALUES (1, ?, ?, ?)
+	`, window.Start.UTC(), window.End.UTC(), time.Now().UTC())
+	return ErrMaintenanceDB.Wrap(err)
+}
+
+// Delete deletes the planned maintenance window.
+func (db *maintenanceDB) Delete(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = db.ExecContext(ctx, `DELETE FROM maintenance_window`)
+	return ErrMaintenanceDB.Wrap(err)
+}
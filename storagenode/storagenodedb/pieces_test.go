@@ -0,0 +1,75 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package storagenodedb_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/filestore"
+	"storj.io/storj/storage/packstore"
+	"storj.io/storj/storagenode/storagenodedb"
+)
+
+func TestOpenPackedStorageWithUnmigratedPieces(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	log := zaptest.NewLogger(t)
+	storageDir := ctx.Dir("storage")
+	cfg := storagenodedb.Config{
+		Storage:   storageDir,
+		Info:      filepath.Join(storageDir, "piecestore.db"),
+		Info2:     filepath.Join(storageDir, "info.db"),
+		Pieces:    storageDir,
+		Filestore: filestore.DefaultConfig,
+		Packstore: packstore.DefaultConfig,
+	}
+
+	db, err := storagenodedb.OpenNew(ctx, log, cfg)
+	require.NoError(t, err)
+
+	ref := storage.BlobRef{Namespace: testrand.NodeID().Bytes(), Key: testrand.PieceID().Bytes()}
+	data := testrand.BytesInt(1000)
+	writer, err := db.Pieces().Create(ctx, ref, int64(len(data)))
+	require.NoError(t, err)
+	_, err = writer.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, writer.Commit(ctx))
+	require.NoError(t, db.Close())
+
+	// the pieces stored one file per piece would be unreadable.
+	cfg.Packstore.Enabled = true
+	_, err = storagenodedb.OpenNew(ctx, log, cfg)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "migrate-pieces")
+
+	dir, err := filestore.OpenDir(log, storageDir)
+	require.NoError(t, err)
+	from := filestore.New(log, dir, cfg.Filestore)
+	to, err := packstore.New(log, dir, cfg.Packstore)
+	require.NoError(t, err)
+	_, err = packstore.Migrate(ctx, log, from, to, true)
+	require.NoError(t, err)
+	require.NoError(t, to.Close())
+	require.NoError(t, from.Close())
+
+	db, err = storagenodedb.OpenNew(ctx, log, cfg)
+	require.NoError(t, err)
+	defer ctx.Check(db.Close)
+
+	reader, err := db.Pieces().Open(ctx, ref)
+	require.NoError(t, err)
+	read, err := ioutil.ReadAll(reader)
+	require.NoError(t, err)
+	require.NoError(t, reader.Close())
+	require.Equal(t, data, read)
+}
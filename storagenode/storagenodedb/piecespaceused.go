@@ -231,3 +231,58 @@ func (db *pieceSpaceUsedDB) deleteTotalBySatellite(ctx context.Context, satellit
 
 	return nil
 }
+
+// GetUsedSpaceWalkProgress returns the checkpoints of the used-space walk of all satellites.
+func (db *pieceSpaceUsedDB) GetUsedSpaceWalkProgress(ctx context.Context) (_ []pieces.UsedSpaceProgress, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT satellite_id, last_prefix, total, content_size, total_at_start, content_size_at_start,
+			started_at, updated_at, completed_at
+		FROM used_space_walk_progress
+		ORDER BY satellite_id
+	`)
+	if err != nil {
+		return nil, ErrPieceSpaceUsed.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var progresses []pieces.UsedSpaceProgress
+	for rows.Next() {
+		var progress pieces.UsedSpaceProgress
+		err := rows.Scan(&progress.SatelliteID, &progress.LastPrefix,
+			&progress.Total, &progress.ContentSize,
+			&progress.TotalAtStart, &progress.ContentSizeAtStart,
+			&progress.StartedAt, &progress.UpdatedAt, &progress.CompletedAt)
+		if err != nil {
+			return nil, ErrPieceSpaceUsed.Wrap(err)
+		}
+		progresses = append(progresses, progress)
+	}
+	return progresses, ErrPieceSpaceUsed.Wrap(rows.Err())
+}
+
+// UpdateUsedSpaceWalkProgress saves the checkpoint of the used-space walk of a satellite.
+func (db *pieceSpaceUsedDB) UpdateUsedSpaceWalkProgress(ctx context.Context, progress pieces.UsedSpaceProgress) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = db.ExecContext(ctx, `
+		INSERT OR REPLACE INTO used_space_walk_progress (
+			satellite_id, last_prefix, total, content_size, total_at_start, content_size_at_start,
+			started_at, updated_at, completed_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, progress.SatelliteID, progress.LastPrefix,
+		progress.Total, progress.ContentSize,
+		progress.TotalAtStart, progress.ContentSizeAtStart,
+		progress.StartedAt, progress.UpdatedAt, progress.CompletedAt)
+
+	return ErrPieceSpaceUsed.Wrap(err)
+}
+
+// DeleteUsedSpaceWalkProgress removes the checkpoints of all satellites to start a new walk.
+func (db *pieceSpaceUsedDB) DeleteUsedSpaceWalkProgress(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = db.ExecContext(ctx, `DELETE FROM used_space_walk_progress`)
+	return ErrPieceSpaceUsed.Wrap(err)
+}
@@ -229,6 +229,36 @@ func Schema() map[string]*dbschema.Schema {
 			},
 		},
 		"info": {},
+		"maintenance": {
+			Tables: []*dbschema.Table{
+				{
+					Name:       "maintenance_window",
+					PrimaryKey: []string{"id"},
+					Columns: []*dbschema.Column{
+						{
+							Name:       "created_at",
+							Type:       "TIMESTAMP",
+							IsNullable: false,
+						},
+						{
+							Name:       "end_time",
+							Type:       "TIMESTAMP",
+							IsNullable: false,
+						},
+						{
+							Name:       "id",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+						{
+							Name:       "start_time",
+							Type:       "TIMESTAMP",
+							IsNullable: false,
+						},
+					},
+				},
+			},
+		},
 		"notifications": {
 			Tables: []*dbschema.Table{
 				{
@@ -421,6 +451,57 @@ func Schema() map[string]*dbschema.Schema {
 						},
 					},
 				},
+				{
+					Name:       "used_space_walk_progress",
+					PrimaryKey: []string{"satellite_id"},
+					Columns: []*dbschema.Column{
+						{
+							Name:       "completed_at",
+							Type:       "TIMESTAMP",
+							IsNullable: true,
+						},
+						{
+							Name:       "content_size",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+						{
+							Name:       "content_size_at_start",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+						{
+							Name:       "last_prefix",
+							Type:       "TEXT",
+							IsNullable: false,
+						},
+						{
+							Name:       "satellite_id",
+							Type:       "BLOB",
+							IsNullable: false,
+						},
+						{
+							Name:       "started_at",
+							Type:       "TIMESTAMP",
+							IsNullable: false,
+						},
+						{
+							Name:       "total",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+						{
+							Name:       "total_at_start",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+						{
+							Name:       "updated_at",
+							Type:       "TIMESTAMP",
+							IsNullable: false,
+						},
+					},
+				},
 			},
 			Indexes: []*dbschema.Index{
 				{Name: "idx_piece_space_used_satellite_id", Table: "piece_space_used", Columns: []string{"satellite_id"}, Unique: true, Partial: ""},
@@ -697,6 +778,21 @@ func Schema() map[string]*dbschema.Schema {
 							Type:       "timestamp with time zone",
 							IsNullable: false,
 						},
+						{
+							Name:       "expires_at",
+							Type:       "TIMESTAMP",
+							IsNullable: true,
+						},
+						{
+							Name:       "name",
+							Type:       "TEXT",
+							IsNullable: false,
+						},
+						{
+							Name:       "scope",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
 						{
 							Name:       "token",
 							Type:       "bytea",
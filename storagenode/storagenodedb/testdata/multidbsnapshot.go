@@ -68,6 +68,9 @@ var States = MultiDBStates{
 		&v51,
 		&v52,
 		&v53,
+		&v54,
+		&v55,
+		&v56,
 	},
 }
 
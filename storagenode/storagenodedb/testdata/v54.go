@@ -0,0 +1,53 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package testdata
+
+import "storj.io/storj/storagenode/storagenodedb"
+
+var v54 = MultiDBState{
+	Version: 54,
+	DBStates: DBStates{
+		storagenodedb.UsedSerialsDBName:  v53.DBStates[storagenodedb.UsedSerialsDBName],
+		storagenodedb.StorageUsageDBName: v53.DBStates[storagenodedb.StorageUsageDBName],
+		storagenodedb.ReputationDBName:   v53.DBStates[storagenodedb.ReputationDBName],
+		storagenodedb.PieceSpaceUsedDBName: &DBState{
+			SQL: `
+				CREATE TABLE piece_space_used (
+					total INTEGER NOT NULL DEFAULT 0,
+					content_size INTEGER NOT NULL,
+					satellite_id BLOB
+				);
+				CREATE UNIQUE INDEX idx_piece_space_used_satellite_id ON piece_space_used(satellite_id);
+				INSERT INTO piece_space_used (content_size, total) VALUES (1337, 1337);
+				INSERT INTO piece_space_used (content_size, total, satellite_id) VALUES (1337, 1337, X'0ed28abb2813e184a1e98b0f6605c4911ea468c7e8433eb583e0fca7ceac3000');
+				INSERT INTO piece_space_used (content_size, total, satellite_id) VALUES (0, 0, X'0ed28abb2813e184a1e98b0f6605c4911ea468c7e8433eb583e0fca7ceac3001');
+				CREATE TABLE used_space_walk_progress (
+					satellite_id BLOB NOT NULL,
+					last_prefix TEXT NOT NULL,
+					total INTEGER NOT NULL,
+					content_size INTEGER NOT NULL,
+					total_at_start INTEGER NOT NULL,
+					content_size_at_start INTEGER NOT NULL,
+					started_at TIMESTAMP NOT NULL,
+					updated_at TIMESTAMP NOT NULL,
+					completed_at TIMESTAMP,
+					PRIMARY KEY (satellite_id)
+				);
+			`,
+			NewData: `
+				INSERT INTO used_space_walk_progress VALUES(X'0ed28abb2813e184a1e98b0f6605c4911ea468c7e8433eb583e0fca7ceac3000', 'ab', 1024, 512, 2048, 1024, '2022-01-10 10:00:00+00:00', '2022-01-10 11:00:00+00:00', NULL);
+			`,
+		},
+		storagenodedb.PieceInfoDBName:       v53.DBStates[storagenodedb.PieceInfoDBName],
+		storagenodedb.PieceExpirationDBName: v53.DBStates[storagenodedb.PieceExpirationDBName],
+		storagenodedb.OrdersDBName:          v53.DBStates[storagenodedb.OrdersDBName],
+		storagenodedb.BandwidthDBName:       v53.DBStates[storagenodedb.BandwidthDBName],
+		storagenodedb.SatellitesDBName:      v53.DBStates[storagenodedb.SatellitesDBName],
+		storagenodedb.DeprecatedInfoDBName:  v53.DBStates[storagenodedb.DeprecatedInfoDBName],
+		storagenodedb.NotificationsDBName:   v53.DBStates[storagenodedb.NotificationsDBName],
+		storagenodedb.HeldAmountDBName:      v53.DBStates[storagenodedb.HeldAmountDBName],
+		storagenodedb.PricingDBName:         v53.DBStates[storagenodedb.PricingDBName],
+		storagenodedb.APIKeysDBName:         v53.DBStates[storagenodedb.APIKeysDBName],
+	},
+}
@@ -0,0 +1,65 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package testdata
+
+import "storj.io/storj/storagenode/storagenodedb"
+
+var v55 = MultiDBState{
+	Version: 55,
+	DBStates: DBStates{
+		storagenodedb.UsedSerialsDBName:  v54.DBStates[storagenodedb.UsedSerialsDBName],
+		storagenodedb.StorageUsageDBName: v54.DBStates[storagenodedb.StorageUsageDBName],
+		storagenodedb.ReputationDBName:   v54.DBStates[storagenodedb.ReputationDBName],
+		storagenodedb.PieceSpaceUsedDBName: &DBState{
+			SQL: `
+				CREATE TABLE piece_space_used (
+					total INTEGER NOT NULL DEFAULT 0,
+					content_size INTEGER NOT NULL,
+					satellite_id BLOB
+				);
+				CREATE UNIQUE INDEX idx_piece_space_used_satellite_id ON piece_space_used(satellite_id);
+				INSERT INTO piece_space_used (content_size, total) VALUES (1337, 1337);
+				INSERT INTO piece_space_used (content_size, total, satellite_id) VALUES (1337, 1337, X'0ed28abb2813e184a1e98b0f6605c4911ea468c7e8433eb583e0fca7ceac3000');
+				INSERT INTO piece_space_used (content_size, total, satellite_id) VALUES (0, 0, X'0ed28abb2813e184a1e98b0f6605c4911ea468c7e8433eb583e0fca7ceac3001');
+				CREATE TABLE used_space_walk_progress (
+					satellite_id BLOB NOT NULL,
+					last_prefix TEXT NOT NULL,
+					total INTEGER NOT NULL,
+					content_size INTEGER NOT NULL,
+					total_at_start INTEGER NOT NULL,
+					content_size_at_start INTEGER NOT NULL,
+					started_at TIMESTAMP NOT NULL,
+					updated_at TIMESTAMP NOT NULL,
+					completed_at TIMESTAMP,
+					PRIMARY KEY (satellite_id)
+				);
+				INSERT INTO used_space_walk_progress VALUES(X'0ed28abb2813e184a1e98b0f6605c4911ea468c7e8433eb583e0fca7ceac3000', 'ab', 1024, 512, 2048, 1024, '2022-01-10 10:00:00+00:00', '2022-01-10 11:00:00+00:00', NULL);
+			`,
+		},
+		storagenodedb.PieceInfoDBName:       v54.DBStates[storagenodedb.PieceInfoDBName],
+		storagenodedb.PieceExpirationDBName: v54.DBStates[storagenodedb.PieceExpirationDBName],
+		storagenodedb.OrdersDBName:          v54.DBStates[storagenodedb.OrdersDBName],
+		storagenodedb.BandwidthDBName:       v54.DBStates[storagenodedb.BandwidthDBName],
+		storagenodedb.SatellitesDBName:      v54.DBStates[storagenodedb.SatellitesDBName],
+		storagenodedb.DeprecatedInfoDBName:  v54.DBStates[storagenodedb.DeprecatedInfoDBName],
+		storagenodedb.NotificationsDBName:   v54.DBStates[storagenodedb.NotificationsDBName],
+		storagenodedb.HeldAmountDBName:      v54.DBStates[storagenodedb.HeldAmountDBName],
+		storagenodedb.PricingDBName:         v54.DBStates[storagenodedb.PricingDBName],
+		storagenodedb.APIKeysDBName:         v54.DBStates[storagenodedb.APIKeysDBName],
+		storagenodedb.MaintenanceDBName: &DBState{
+			SQL: `
+				-- table to hold the planned maintenance window
+				CREATE TABLE maintenance_window (
+					id INTEGER NOT NULL,
+					start_time TIMESTAMP NOT NULL,
+					end_time TIMESTAMP NOT NULL,
+					created_at TIMESTAMP NOT NULL,
+					PRIMARY KEY (id)
+				);`,
+			NewData: `
+				INSERT INTO maintenance_window VALUES(1, '2022-07-01 08:00:00+00:00', '2022-07-01 12:00:00+00:00', '2022-06-30 10:00:00+00:00');
+			`,
+		},
+	},
+}
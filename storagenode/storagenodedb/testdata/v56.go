@@ -0,0 +1,53 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package testdata
+
+import "storj.io/storj/storagenode/storagenodedb"
+
+var v56 = MultiDBState{
+	Version: 56,
+	DBStates: DBStates{
+		storagenodedb.UsedSerialsDBName:     v55.DBStates[storagenodedb.UsedSerialsDBName],
+		storagenodedb.StorageUsageDBName:    v55.DBStates[storagenodedb.StorageUsageDBName],
+		storagenodedb.ReputationDBName:      v55.DBStates[storagenodedb.ReputationDBName],
+		storagenodedb.PieceSpaceUsedDBName:  v55.DBStates[storagenodedb.PieceSpaceUsedDBName],
+		storagenodedb.PieceInfoDBName:       v55.DBStates[storagenodedb.PieceInfoDBName],
+		storagenodedb.PieceExpirationDBName: v55.DBStates[storagenodedb.PieceExpirationDBName],
+		storagenodedb.OrdersDBName:          v55.DBStates[storagenodedb.OrdersDBName],
+		storagenodedb.BandwidthDBName:       v55.DBStates[storagenodedb.BandwidthDBName],
+		storagenodedb.SatellitesDBName:      v55.DBStates[storagenodedb.SatellitesDBName],
+		storagenodedb.DeprecatedInfoDBName:  v55.DBStates[storagenodedb.DeprecatedInfoDBName],
+		storagenodedb.NotificationsDBName:   v55.DBStates[storagenodedb.NotificationsDBName],
+		storagenodedb.HeldAmountDBName:      v55.DBStates[storagenodedb.HeldAmountDBName],
+		storagenodedb.PricingDBName:         v55.DBStates[storagenodedb.PricingDBName],
+		storagenodedb.APIKeysDBName: &DBState{
+			SQL: `
+				-- table to hold storagenode secret token
+				CREATE TABLE secret (
+					token bytea NOT NULL,
+					created_at timestamp with time zone NOT NULL,
+					name TEXT NOT NULL DEFAULT '',
+					scope INTEGER NOT NULL DEFAULT 2,
+					expires_at TIMESTAMP,
+					PRIMARY KEY ( token )
+				);`,
+			NewData: `
+				INSERT INTO secret VALUES(X'0ed28abb2813e184a1e98b0f6605c4911ea468c7e8433eb583e0fca7ceac3000', '2022-07-01 08:00:00+00:00', 'dashboard', 1, '2023-07-01 08:00:00+00:00');
+			`,
+		},
+		storagenodedb.MaintenanceDBName: &DBState{
+			SQL: `
+				-- table to hold the planned maintenance window
+				CREATE TABLE maintenance_window (
+					id INTEGER NOT NULL,
+					start_time TIMESTAMP NOT NULL,
+					end_time TIMESTAMP NOT NULL,
+					created_at TIMESTAMP NOT NULL,
+					PRIMARY KEY (id)
+				);
+				INSERT INTO maintenance_window VALUES(1, '2022-07-01 08:00:00+00:00', '2022-07-01 12:00:00+00:00', '2022-06-30 10:00:00+00:00');
+			`,
+		},
+	},
+}
@@ -154,6 +154,10 @@ export class ProjectsApiGql extends BaseGql implements ProjectsApi {
                 limits.storageUsed,
                 limits.objectCount,
                 limits.segmentCount,
+                limits.bandwidthRateLimit,
+                limits.bandwidthBurstLimit,
+                limits.spendingCapReached,
+                limits.downloadsPaused,
             );
         }
 
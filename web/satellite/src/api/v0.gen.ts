@@ -0,0 +1,513 @@
+// AUTOGENERATED BY private/apigen
+// DO NOT EDIT.
+
+import { HttpClient } from '@/utils/httpClient';
+
+export class ProjectInfo {
+    name: string;
+    description: string;
+    storageLimit: string;
+    bandwidthLimit: string;
+    createdAt: string;
+}
+
+export class Project {
+    id: string;
+    name: string;
+    description: string;
+    partnerId: string;
+    userAgent: string;
+    ownerId: string;
+    organizationId: string;
+    rateLimit: number | null;
+    burstLimit: number | null;
+    maxBuckets: number | null;
+    createdAt: string;
+    memberCount: number;
+    storageLimit: string | null;
+    bandwidthLimit: string | null;
+    segmentLimit: number | null;
+}
+
+export class ProjectsPage {
+    projects: Project[];
+    next: boolean;
+    nextOffset: number;
+    limit: number;
+    offset: number;
+    pageCount: number;
+    currentPage: number;
+    totalCount: number;
+    nextCursor: string;
+}
+
+export class ProjectUsage {
+    storage: number;
+    egress: number;
+    segmentCount: number;
+    objectCount: number;
+    since: string;
+    before: string;
+}
+
+export class BucketUsagePage {
+    bucketUsages: BucketUsage[];
+    search: string;
+    limit: number;
+    offset: number;
+    pageCount: number;
+    currentPage: number;
+    totalCount: number;
+    nextCursor: string;
+}
+
+export class BucketUsage {
+    projectID: string;
+    bucketName: string;
+    storage: number;
+    egress: number;
+    objectCount: number;
+    segmentCount: number;
+    since: string;
+    before: string;
+}
+
+export class BucketUsageRollup {
+    projectID: string;
+    bucketName: string;
+    totalStoredData: number;
+    totalSegments: number;
+    objectCount: number;
+    metadataSize: number;
+    repairEgress: number;
+    getEgress: number;
+    auditEgress: number;
+    since: string;
+    before: string;
+}
+
+export class CreateAPIKeyRequest {
+    projectID: string;
+    name: string;
+}
+
+export class CreateAPIKeyResponse {
+    key: string;
+    keyInfo: APIKeyInfo | null;
+}
+
+export class APIKeyInfo {
+    id: string;
+    projectId: string;
+    partnerId: string;
+    userAgent: string;
+    name: string;
+    createdAt: string;
+}
+
+export class APIKeyPage {
+    apiKeys: APIKeyInfo[];
+    search: string;
+    limit: number;
+    order: number;
+    orderDirection: number;
+    offset: number;
+    pageCount: number;
+    currentPage: number;
+    totalCount: number;
+    nextCursor: string;
+}
+
+export class DeleteAPIKeysRequest {
+    ids: string[];
+}
+
+export class ProjectMemberInfosPage {
+    projectMembers: ProjectMemberInfo[];
+    search: string;
+    limit: number;
+    order: number;
+    orderDirection: number;
+    offset: number;
+    pageCount: number;
+    currentPage: number;
+    totalCount: number;
+    nextCursor: string;
+}
+
+export class ProjectMemberInfo {
+    id: string;
+    fullName: string;
+    shortName: string;
+    email: string;
+    joinedAt: string;
+}
+
+export class ProjectMembersRequest {
+    projectID: string;
+    emails: string[];
+}
+
+export class ResponseUser {
+    id: string;
+    fullName: string;
+    shortName: string;
+    email: string;
+    partnerId: string;
+    userAgent: string;
+    projectLimit: number;
+    isProfessional: boolean;
+    position: string;
+    companyName: string;
+    employeeCount: string;
+    haveSalesContact: boolean;
+    paidTier: boolean;
+    isMFAEnabled: boolean;
+    mfaRecoveryCodeCount: number;
+}
+
+export class ProjectManagementHttpApiV0 {
+    private readonly http: HttpClient = new HttpClient();
+    private readonly ROOT_PATH: string = '/api/v0/projects';
+
+    /**
+     * Creates new Project with given info.
+     *
+     * @throws Error
+     */
+    public async genCreateProject(projectInfo: ProjectInfo): Promise<Project> {
+        const path = `${this.ROOT_PATH}/create`;
+        const response = await this.http.post(path, JSON.stringify(projectInfo));
+
+        if (response.ok) {
+            return response.json().then((body) => body as Project);
+        }
+        const err = await response.json();
+        throw new Error(err.error);
+    }
+
+    /**
+     * Updates project with given info.
+     *
+     * @throws Error
+     */
+    public async genUpdateProject(id: string, projectInfo: ProjectInfo): Promise<Project> {
+        const path = `${this.ROOT_PATH}/update/${encodeURIComponent(id)}`;
+        const response = await this.http.patch(path, JSON.stringify(projectInfo));
+
+        if (response.ok) {
+            return response.json().then((body) => body as Project);
+        }
+        const err = await response.json();
+        throw new Error(err.error);
+    }
+
+    /**
+     * Deletes project by id.
+     *
+     * @throws Error
+     */
+    public async genDeleteProject(id: string): Promise<void> {
+        const path = `${this.ROOT_PATH}/delete/${encodeURIComponent(id)}`;
+        const response = await this.http.delete(path);
+
+        if (response.ok) {
+            return;
+        }
+        const err = await response.json();
+        throw new Error(err.error);
+    }
+
+    /**
+     * Gets all projects user has.
+     *
+     * @throws Error
+     */
+    public async genGetUsersProjects(): Promise<Project[]> {
+        const path = `${this.ROOT_PATH}/`;
+        const response = await this.http.get(path);
+
+        if (response.ok) {
+            return response.json().then((body) => body as Project[]);
+        }
+        const err = await response.json();
+        throw new Error(err.error);
+    }
+
+    /**
+     * Gets project by id.
+     *
+     * @throws Error
+     */
+    public async genGetProject(id: string): Promise<Project> {
+        const u = new URL(`${this.ROOT_PATH}/project`, window.location.href);
+        u.searchParams.set('id', id);
+        const path = u.toString();
+        const response = await this.http.get(path);
+
+        if (response.ok) {
+            return response.json().then((body) => body as Project);
+        }
+        const err = await response.json();
+        throw new Error(err.error);
+    }
+
+    /**
+     * Gets paged projects user owns, starting at the page of the cursor or the first page of the limit.
+     *
+     * @throws Error
+     */
+    public async genGetUsersOwnedProjectsPage(cursor: string, limit: number): Promise<ProjectsPage> {
+        const u = new URL(`${this.ROOT_PATH}/paged`, window.location.href);
+        u.searchParams.set('cursor', cursor);
+        u.searchParams.set('limit', String(limit));
+        const path = u.toString();
+        const response = await this.http.get(path);
+
+        if (response.ok) {
+            return response.json().then((body) => body as ProjectsPage);
+        }
+        const err = await response.json();
+        throw new Error(err.error);
+    }
+
+    /**
+     * Gets project's storage, egress, object and segment usage for the period.
+     *
+     * @throws Error
+     */
+    public async genGetProjectUsage(projectID: string, since: Date, before: Date): Promise<ProjectUsage> {
+        const u = new URL(`${this.ROOT_PATH}/usage`, window.location.href);
+        u.searchParams.set('projectID', projectID);
+        u.searchParams.set('since', since.toISOString());
+        u.searchParams.set('before', before.toISOString());
+        const path = u.toString();
+        const response = await this.http.get(path);
+
+        if (response.ok) {
+            return response.json().then((body) => body as ProjectUsage);
+        }
+        const err = await response.json();
+        throw new Error(err.error);
+    }
+
+    /**
+     * Gets paged total usage of project's buckets since their creation, starting at the page of the cursor or the first page of the search and limit.
+     *
+     * @throws Error
+     */
+    public async genGetBucketTotals(projectID: string, cursor: string, search: string, limit: number, before: Date): Promise<BucketUsagePage> {
+        const u = new URL(`${this.ROOT_PATH}/bucket-totals`, window.location.href);
+        u.searchParams.set('projectID', projectID);
+        u.searchParams.set('cursor', cursor);
+        u.searchParams.set('search', search);
+        u.searchParams.set('limit', String(limit));
+        u.searchParams.set('before', before.toISOString());
+        const path = u.toString();
+        const response = await this.http.get(path);
+
+        if (response.ok) {
+            return response.json().then((body) => body as BucketUsagePage);
+        }
+        const err = await response.json();
+        throw new Error(err.error);
+    }
+
+    /**
+     * Gets project's single bucket usage by bucket ID.
+     *
+     * @throws Error
+     */
+    public async genGetSingleBucketUsageRollup(projectID: string, bucket: string, since: Date, before: Date): Promise<BucketUsageRollup> {
+        const u = new URL(`${this.ROOT_PATH}/bucket-rollup`, window.location.href);
+        u.searchParams.set('projectID', projectID);
+        u.searchParams.set('bucket', bucket);
+        u.searchParams.set('since', since.toISOString());
+        u.searchParams.set('before', before.toISOString());
+        const path = u.toString();
+        const response = await this.http.get(path);
+
+        if (response.ok) {
+            return response.json().then((body) => body as BucketUsageRollup);
+        }
+        const err = await response.json();
+        throw new Error(err.error);
+    }
+
+    /**
+     * Gets project's all buckets usage.
+     *
+     * @throws Error
+     */
+    public async genGetBucketUsageRollups(projectID: string, since: Date, before: Date): Promise<BucketUsageRollup[]> {
+        const u = new URL(`${this.ROOT_PATH}/bucket-rollups`, window.location.href);
+        u.searchParams.set('projectID', projectID);
+        u.searchParams.set('since', since.toISOString());
+        u.searchParams.set('before', before.toISOString());
+        const path = u.toString();
+        const response = await this.http.get(path);
+
+        if (response.ok) {
+            return response.json().then((body) => body as BucketUsageRollup[]);
+        }
+        const err = await response.json();
+        throw new Error(err.error);
+    }
+}
+
+export class APIKeyManagementHttpApiV0 {
+    private readonly http: HttpClient = new HttpClient();
+    private readonly ROOT_PATH: string = '/api/v0/apikeys';
+
+    /**
+     * Creates new macaroon API key with given info.
+     *
+     * @throws Error
+     */
+    public async genCreateAPIKey(apikeyInfo: CreateAPIKeyRequest): Promise<CreateAPIKeyResponse> {
+        const path = `${this.ROOT_PATH}/create`;
+        const response = await this.http.post(path, JSON.stringify(apikeyInfo));
+
+        if (response.ok) {
+            return response.json().then((body) => body as CreateAPIKeyResponse);
+        }
+        const err = await response.json();
+        throw new Error(err.error);
+    }
+
+    /**
+     * Gets paged API keys of the project, starting at the page of the cursor or the first page of the search, limit and order.
+     *
+     * @throws Error
+     */
+    public async genGetAPIKeys(projectID: string, cursor: string, search: string, limit: number, order: number, orderDirection: number): Promise<APIKeyPage> {
+        const u = new URL(`${this.ROOT_PATH}/paged`, window.location.href);
+        u.searchParams.set('projectID', projectID);
+        u.searchParams.set('cursor', cursor);
+        u.searchParams.set('search', search);
+        u.searchParams.set('limit', String(limit));
+        u.searchParams.set('order', String(order));
+        u.searchParams.set('orderDirection', String(orderDirection));
+        const path = u.toString();
+        const response = await this.http.get(path);
+
+        if (response.ok) {
+            return response.json().then((body) => body as APIKeyPage);
+        }
+        const err = await response.json();
+        throw new Error(err.error);
+    }
+
+    /**
+     * Deletes macaroon API key by id.
+     *
+     * @throws Error
+     */
+    public async genDeleteAPIKey(id: string): Promise<void> {
+        const path = `${this.ROOT_PATH}/delete/${encodeURIComponent(id)}`;
+        const response = await this.http.delete(path);
+
+        if (response.ok) {
+            return;
+        }
+        const err = await response.json();
+        throw new Error(err.error);
+    }
+
+    /**
+     * Deletes macaroon API keys by ids.
+     *
+     * @throws Error
+     */
+    public async genDeleteAPIKeys(request: DeleteAPIKeysRequest): Promise<void> {
+        const path = `${this.ROOT_PATH}/delete`;
+        const response = await this.http.post(path, JSON.stringify(request));
+
+        if (response.ok) {
+            return;
+        }
+        const err = await response.json();
+        throw new Error(err.error);
+    }
+}
+
+export class ProjectMemberManagementHttpApiV0 {
+    private readonly http: HttpClient = new HttpClient();
+    private readonly ROOT_PATH: string = '/api/v0/members';
+
+    /**
+     * Gets paged members of the project, starting at the page of the cursor or the first page of the search, limit and order.
+     *
+     * @throws Error
+     */
+    public async genGetProjectMembers(projectID: string, cursor: string, search: string, limit: number, order: number, orderDirection: number): Promise<ProjectMemberInfosPage> {
+        const u = new URL(`${this.ROOT_PATH}/paged`, window.location.href);
+        u.searchParams.set('projectID', projectID);
+        u.searchParams.set('cursor', cursor);
+        u.searchParams.set('search', search);
+        u.searchParams.set('limit', String(limit));
+        u.searchParams.set('order', String(order));
+        u.searchParams.set('orderDirection', String(orderDirection));
+        const path = u.toString();
+        const response = await this.http.get(path);
+
+        if (response.ok) {
+            return response.json().then((body) => body as ProjectMemberInfosPage);
+        }
+        const err = await response.json();
+        throw new Error(err.error);
+    }
+
+    /**
+     * Adds users with given emails to the project.
+     *
+     * @throws Error
+     */
+    public async genAddProjectMembers(request: ProjectMembersRequest): Promise<ProjectMemberInfo[]> {
+        const path = `${this.ROOT_PATH}/add`;
+        const response = await this.http.post(path, JSON.stringify(request));
+
+        if (response.ok) {
+            return response.json().then((body) => body as ProjectMemberInfo[]);
+        }
+        const err = await response.json();
+        throw new Error(err.error);
+    }
+
+    /**
+     * Removes users with given emails from the project.
+     *
+     * @throws Error
+     */
+    public async genDeleteProjectMembers(request: ProjectMembersRequest): Promise<void> {
+        const path = `${this.ROOT_PATH}/delete`;
+        const response = await this.http.post(path, JSON.stringify(request));
+
+        if (response.ok) {
+            return;
+        }
+        const err = await response.json();
+        throw new Error(err.error);
+    }
+}
+
+export class UserManagementHttpApiV0 {
+    private readonly http: HttpClient = new HttpClient();
+    private readonly ROOT_PATH: string = '/api/v0/users';
+
+    /**
+     * Gets User by request context.
+     *
+     * @throws Error
+     */
+    public async genGetUser(): Promise<ResponseUser> {
+        const path = `${this.ROOT_PATH}/`;
+        const response = await this.http.get(path);
+
+        if (response.ok) {
+            return response.json().then((body) => body as ResponseUser);
+        }
+        const err = await response.json();
+        throw new Error(err.error);
+    }
+}
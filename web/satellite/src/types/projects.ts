@@ -145,6 +145,10 @@ export class ProjectLimits {
         public storageUsed: number = 0,
         public objectCount: number = 0,
         public segmentCount: number = 0,
+        public bandwidthRateLimit: number = 0,
+        public bandwidthBurstLimit: number = 0,
+        public spendingCapReached: boolean = false,
+        public downloadsPaused: boolean = false,
     ) {}
 }
 
@@ -0,0 +1,57 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+import {
+    BenchmarkApi,
+    BenchmarkLatency,
+    BenchmarkResult,
+} from '@/storagenode/benchmark/benchmark';
+import { HttpClient } from '@/storagenode/utils/httpClient';
+
+/**
+ * BenchmarkHttpApi is a http implementation of Benchmark API.
+ * Exposes all storage directory benchmark-related functionality
+ */
+export class BenchmarkHttpApi implements BenchmarkApi {
+    private readonly client: HttpClient = new HttpClient();
+    private readonly ROOT_PATH: string = '/api/sno/benchmark';
+
+    /**
+     * Fetch the result of the latest benchmark.
+     *
+     * @returns benchmark result or null.
+     * @throws Error
+     */
+    public async latest(): Promise<BenchmarkResult | null> {
+        const response = await this.client.get(this.ROOT_PATH);
+
+        if (!response.ok) {
+            throw new Error('can not get benchmark result');
+        }
+
+        const json = await response.json();
+        if (!json) {
+            return null;
+        }
+
+        // eslint-disable-next-line @typescript-eslint/no-explicit-any
+        const toLatency = (latency: any): BenchmarkLatency => new BenchmarkLatency(
+            latency.average,
+            latency.p95,
+            latency.max,
+        );
+
+        return new BenchmarkResult(
+            new Date(json.startedAt),
+            json.duration,
+            json.pieces,
+            json.pieceSize,
+            toLatency(json.writeLatency),
+            toLatency(json.commitLatency),
+            toLatency(json.readLatency),
+            json.writeThroughput,
+            json.readThroughput,
+            json.warnings || [],
+        );
+    }
+}
@@ -0,0 +1,84 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+import {
+    MaintenanceApi,
+    MaintenanceSatelliteStatus,
+    MaintenanceWindow,
+} from '@/storagenode/maintenance/maintenance';
+import { HttpClient } from '@/storagenode/utils/httpClient';
+
+/**
+ * MaintenanceHttpApi is a http implementation of Maintenance API.
+ * Exposes all maintenance-related functionality
+ */
+export class MaintenanceHttpApi implements MaintenanceApi {
+    private readonly client: HttpClient = new HttpClient();
+    private readonly ROOT_PATH: string = '/api/sno/maintenance';
+
+    /**
+     * Fetch the planned maintenance window.
+     *
+     * @returns maintenance window.
+     * @throws Error
+     */
+    public async get(): Promise<MaintenanceWindow> {
+        const response = await this.client.get(this.ROOT_PATH);
+
+        if (!response.ok) {
+            throw new Error('can not get maintenance window');
+        }
+
+        return this.fromJSON(await response.json());
+    }
+
+    /**
+     * Replaces the planned maintenance window.
+     *
+     * @returns maintenance window.
+     * @throws Error
+     */
+    public async plan(start: Date, end: Date): Promise<MaintenanceWindow> {
+        const response = await this.client.post(this.ROOT_PATH, JSON.stringify({ start, end }));
+
+        if (!response.ok) {
+            const body = await response.json();
+            throw new Error(body.error || 'can not plan maintenance window');
+        }
+
+        return this.fromJSON(await response.json());
+    }
+
+    /**
+     * Cancels the planned maintenance window.
+     * @throws Error
+     */
+    public async cancel(): Promise<void> {
+        const response = await this.client.delete(this.ROOT_PATH);
+
+        if (response.ok) {
+            return;
+        }
+
+        throw new Error('can not cancel maintenance window');
+    }
+
+    // eslint-disable-next-line @typescript-eslint/no-explicit-any
+    private fromJSON(json: any): MaintenanceWindow {
+        const toDate = (value: string | null): Date | null => value ? new Date(value) : null;
+
+        return new MaintenanceWindow(
+            toDate(json.start),
+            toDate(json.end),
+            // eslint-disable-next-line @typescript-eslint/no-explicit-any
+            (json.satellites || []).map((satellite: any) => new MaintenanceSatelliteStatus(
+                satellite.id,
+                satellite.supported,
+                toDate(satellite.acceptedStart),
+                toDate(satellite.acceptedEnd),
+                satellite.message,
+                new Date(satellite.updatedAt),
+            )),
+        );
+    }
+}
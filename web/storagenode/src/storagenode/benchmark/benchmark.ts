@@ -0,0 +1,50 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+/**
+ * Exposes all storage directory benchmark-related functionality.
+ */
+export interface BenchmarkApi {
+    /**
+     * Fetches the result of the latest benchmark, or null when the storage directory wasn't benchmarked yet.
+     * @throws Error
+     */
+    latest(): Promise<BenchmarkResult | null>;
+}
+
+/**
+ * Describes the latencies of an operation in nanoseconds.
+ */
+export class BenchmarkLatency {
+    public constructor(
+        public average: number = 0,
+        public p95: number = 0,
+        public max: number = 0,
+    ) {}
+}
+
+/**
+ * Describes the measured performance of the storage directory.
+ * Throughputs are in bytes per second.
+ */
+export class BenchmarkResult {
+    public constructor(
+        public startedAt: Date = new Date(),
+        public duration: number = 0,
+        public pieces: number = 0,
+        public pieceSize: number = 0,
+        public writeLatency: BenchmarkLatency = new BenchmarkLatency(),
+        public commitLatency: BenchmarkLatency = new BenchmarkLatency(),
+        public readLatency: BenchmarkLatency = new BenchmarkLatency(),
+        public writeThroughput: number = 0,
+        public readThroughput: number = 0,
+        public warnings: string[] = [],
+    ) {}
+
+    /**
+     * Indicates whether the measurements meet the recommendations.
+     */
+    public get passed(): boolean {
+        return this.warnings.length === 0;
+    }
+}
@@ -0,0 +1,50 @@
+// Copyright (C) 2022 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+/**
+ * Exposes all maintenance-related functionality.
+ */
+export interface MaintenanceApi {
+    /**
+     * Fetches the planned maintenance window.
+     * @throws Error
+     */
+    get(): Promise<MaintenanceWindow>;
+
+    /**
+     * Replaces the planned maintenance window.
+     * @throws Error
+     */
+    plan(start: Date, end: Date): Promise<MaintenanceWindow>;
+
+    /**
+     * Cancels the planned maintenance window.
+     * @throws Error
+     */
+    cancel(): Promise<void>;
+}
+
+/**
+ * Describes the planned maintenance window and the answers of the satellites to it.
+ */
+export class MaintenanceWindow {
+    public constructor(
+        public start: Date | null = null,
+        public end: Date | null = null,
+        public satellites: MaintenanceSatelliteStatus[] = [],
+    ) {}
+}
+
+/**
+ * Describes the answer of a satellite to the planned maintenance window.
+ */
+export class MaintenanceSatelliteStatus {
+    public constructor(
+        public id: string = '',
+        public supported: boolean = false,
+        public acceptedStart: Date | null = null,
+        public acceptedEnd: Date | null = null,
+        public message: string = '',
+        public updatedAt: Date = new Date(),
+    ) {}
+}